@@ -12,6 +12,7 @@
 //   - plugin:remove: Plugin'i siler
 //   - plugin:list: Yüklü plugin'leri listeler
 //   - plugin:build: UI build alır
+//   - config:show: Etkin yapılandırmayı secret'lar maskelenmiş olarak yazdırır
 //
 // Tüm komutlar, gömülü stub dosyalarından şablonlar kullanarak dosyalar oluşturur.
 package main
@@ -25,7 +26,9 @@ import (
 	"strings"
 	"text/template"
 
+	"github.com/ferdiunal/panel.go/pkg/panel"
 	"github.com/ferdiunal/panel.go/pkg/plugin"
+	"github.com/joho/godotenv"
 	"github.com/spf13/cobra"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -83,6 +86,9 @@ func main() {
 	// Init komutu
 	rootCmd.AddCommand(newInitCommand())
 
+	// Config komutları
+	rootCmd.AddCommand(newConfigShowCommand())
+
 	// Plugin komutları
 	rootCmd.AddCommand(plugin.NewPluginCommand())
 
@@ -93,6 +99,52 @@ func main() {
 	}
 }
 
+// newConfigShowCommand, config:show komutunu oluşturur.
+//
+// Komut, panel.LoadConfig ile aynı kuralları (dosya + PANEL_* override'ları +
+// ${env:...}/${file:...} referansları) uygular ve etkin yapılandırmayı
+// secret değerleri maskelenmiş olarak yazdırır.
+func newConfigShowCommand() *cobra.Command {
+	var configPath string
+	var format string
+	var envFile string
+
+	cmd := &cobra.Command{
+		Use:   "config:show",
+		Short: "Etkin panel yapılandırmasını gösterir",
+		Long: `Yapılandırma dosyasını (panel.yaml, panel.yml veya panel.toml) ve PANEL_*
+environment override'larını birleştirip etkin yapılandırmayı yazdırır.
+DSN parolaları, API key'leri ve şifreleme anahtarları maskelenir.
+
+Dosya yolu verilmezse PANEL_CONFIG veya çalışma dizinindeki varsayılan dosya kullanılır.`,
+		Example: `  panel config:show
+  panel config:show --config config/panel.toml --format toml`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Uygulamanın kendisi gibi .env dosyasını yükle; ${env:...}
+			// referansları aynı değerlere çözülsün.
+			if envFile != "" {
+				if err := godotenv.Load(envFile); err != nil && !os.IsNotExist(err) {
+					return fmt.Errorf("%s yüklenemedi: %w", envFile, err)
+				}
+			}
+
+			out, err := panel.RenderConfig(configPath, format)
+			if err != nil {
+				return err
+			}
+			_, err = cmd.OutOrStdout().Write(out)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVarP(&configPath, "config", "c", "", "Yapılandırma dosyası yolu")
+	cmd.Flags().StringVarP(&format, "format", "f", "yaml", "Çıktı formatı (yaml, toml)")
+	cmd.Flags().StringVar(&envFile, "env-file", ".env", "Secret referansları için yüklenecek .env dosyası (boş: yükleme)")
+
+	return cmd
+}
+
 // newMakeResourceCommand, make:resource komutunu oluşturur.
 func newMakeResourceCommand() *cobra.Command {
 	return &cobra.Command{
@@ -757,13 +809,14 @@ func initProject(database string) {
 	fmt.Println("\nProject structure:")
 	fmt.Println("  ├── main.go              # Application entry point")
 	fmt.Println("  ├── go.mod               # Go module definition")
-	fmt.Println("  ├── .env                 # Environment configuration")
+	fmt.Println("  ├── panel.yaml           # Panel configuration (PANEL_* overrides)")
+	fmt.Println("  ├── .env                 # Secrets referenced from panel.yaml")
 	fmt.Println("  ├── internal/pages/      # Custom pages (Dashboard, Settings, Account)")
 	fmt.Println("  ├── locales/             # i18n translation files")
 	fmt.Println("  ├── .panel/stubs/        # Code generation templates")
 	fmt.Println("  └── .claude/skills/      # Claude Code skills")
 	fmt.Println("\nNext steps:")
-	fmt.Println("  1. Update .env with your configuration")
+	fmt.Println("  1. Update panel.yaml and .env with your configuration")
 	fmt.Println("  2. Run: go mod tidy")
 	fmt.Println("  3. Run: go run main.go")
 	fmt.Println("  4. Create a resource: panel make:resource blog")
@@ -841,6 +894,9 @@ func createProjectFiles(projectName, database string) {
 	}
 	createFileFromStub("env.stub", ".env", envData)
 
+	// panel.yaml oluştur (secret'lar .env üzerinden ${env:...} ile çözülür)
+	createFileFromStub("panel.yaml.stub", "panel.yaml", envData)
+
 	// permissions.toml oluştur
 	permissionsContent, err := stubsFS.ReadFile("stubs/permissions.toml.stub")
 	if err != nil {
@@ -193,3 +193,22 @@ type Policy interface {
 	// daha katı yetkilendirme kuralları uygulamalıdır.
 	Delete(ctx *context.Context, model interface{}) bool
 }
+
+// ImpersonationPolicy, kullanıcı kaynaklarının policy'lerinin isteğe bağlı olarak
+// uygulayabileceği impersonation yetkisini tanımlar.
+//
+// Panel, POST /auth/impersonate isteğinde "users" kaynağının Policy() değerinin bu
+// interface'i uygulayıp uygulamadığını kontrol eder. Uygulamayan policy'lerde
+// impersonation reddedilir.
+//
+// # Örnek Kullanım
+//
+//	func (p *UserPolicy) Impersonate(ctx *context.Context, model interface{}) bool {
+//	    target := model.(*User)
+//	    return !target.IsAdmin() || ctx.User().IsAdmin()
+//	}
+type ImpersonationPolicy interface {
+	// Impersonate, oturumdaki kullanıcının model ile temsil edilen kullanıcı
+	// adına oturum açıp açamayacağını belirler.
+	Impersonate(ctx *context.Context, model interface{}) bool
+}
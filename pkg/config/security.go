@@ -78,6 +78,10 @@ type SecurityConfig struct {
 	/// Başarısız giriş denemelerinden sonra hesapları otomatik olarak kilitler.
 	AccountLockout AccountLockoutConfig
 
+	/// Rate limit ve hesap kilitleme sayaçlarının tutulduğu store.
+	/// Birden fazla replika için paylaşılan bir store (database, redis) seçilmelidir.
+	CounterStore CounterStoreConfig
+
 	/// Oturum (session) güvenlik yapılandırması.
 	/// Cookie ayarları ve oturum yönetimi parametrelerini içerir.
 	Session SessionConfig
@@ -567,6 +571,35 @@ type AccountLockoutConfig struct {
 	LockoutDuration time.Duration
 }
 
+/// # CounterStoreConfig
+///
+/// Bu yapı, rate limiting ve hesap kilitleme sayaçlarının nerede tutulacağını belirler.
+/// Sayaçlar bellekte tutulduğunda load balancer arkasındaki her replika kendi limitini
+/// uygular; limitler replika sayısı kadar katlanır ve kilitler instance bazında kalır.
+///
+/// ## Driver'lar
+///
+/// - **memory** (varsayılan): Process belleği, tek instance için yeterlidir
+/// - **database**: Panel veritabanındaki `rate_limit_counters` tablosu
+/// - **redis**: Redis protokolünü konuşan bir sunucu (Redis, Valkey, KeyDB)
+///
+/// ## Örnek Kullanım
+///
+/// ```yaml
+/// security:
+///   counter_store:
+///     driver: redis
+///     redis_url: redis://:secret@cache.internal:6379/0
+/// ```
+type CounterStoreConfig struct {
+	/// Sayaç store'u: "memory", "database" veya "redis". Boş bırakılırsa "memory".
+	Driver string
+
+	/// Redis bağlantı adresi: redis://[:password@]host[:port][/db].
+	/// Sadece Driver "redis" iken kullanılır.
+	RedisURL string `config:"redis_url"`
+}
+
 /// # SessionConfig
 ///
 /// Bu yapı, oturum (session) güvenlik yapılandırmasını yönetir.
@@ -813,6 +846,17 @@ type SessionConfig struct {
 	/// **Not**: MaxAge ve Expires birlikte kullanılırsa MaxAge önceliklidir.
 	MaxAge int
 
+	/// Oturumun hareketsiz kalabileceği en uzun süre (saniye cinsinden).
+	/// Her istekte oturumun bitiş zamanı bu kadar ileri kaydırılır (sliding expiry);
+	/// MaxAge ise oturumun oluşturulmasından itibaren geçerli mutlak sınırdır.
+	///
+	/// **Önerilen değerler**:
+	/// - 900 (15 dakika): Yüksek güvenlik gerektiren admin panelleri
+	/// - 7200 (2 saat): Standart kullanım
+	///
+	/// **0 değeri**: Idle timeout kapalı, oturum yalnızca MaxAge dolunca biter
+	IdleTimeout int
+
 	/// Cookie'nin geçerli olduğu domain.
 	///
 	/// **Değerler**:
@@ -1613,7 +1657,13 @@ type AuditConfig struct {
 	///   - **UYARI**: SIEMEndpoint belirtilmeli, fallback mekanizması olmalı
 	///   - Örnekler: Splunk, ELK Stack, Datadog, Sumo Logic, Azure Sentinel
 	///
-	/// **Önerilen**: Production'da "file" veya "siem"
+	/// - `"database"`: Panel veritabanındaki `audit_events` tablosuna yaz
+	///   - Avantaj: Panelden aranabilir, filtrelenebilir ve dışa aktarılabilir
+	///   - Olaylar arka planda buffered channel ile toplu yazılır
+	///   - Ardışık olaylar hash zinciriyle bağlanır (değişiklik tespiti)
+	///   - Saklama süresi Retention ile sınırlandırılır
+	///
+	/// **Önerilen**: Production'da "file", "database" veya "siem"
 	///
 	/// **Best Practice**: Hybrid yaklaşım kullanın:
 	/// - Primary: SIEM (real-time monitoring)
@@ -1683,6 +1733,12 @@ type AuditConfig struct {
 	///
 	/// **Not**: Destination="siem" değilse bu alan kullanılmaz.
 	SIEMEndpoint string
+
+	/// Veritabanındaki audit olaylarının saklanma süresi (Destination="database").
+	/// Daha eski olaylar arka planda saatte bir silinir; 0 = süresiz sakla.
+	///
+	/// **Örnek**: 2160h (90 gün)
+	Retention time.Duration
 }
 
 /// # DefaultSecurityConfig
@@ -2214,7 +2270,7 @@ func ProductionSecurityConfig() SecurityConfig {
 /// - **LockoutDuration**: `15` dakika
 ///
 /// ### Session
-/// - **CookieName**: `__Host-session_token`
+/// - **CookieName**: `session_token` - `__Host-` prefix Secure gerektirdiği için kullanılmaz
 /// - **Secure**: `false` - HTTP'de çalışır
 /// - **HTTPOnly**: `true` - XSS koruması aktif
 /// - **SameSite**: `Lax` - Esnek CSRF koruması
@@ -2598,6 +2654,8 @@ func DevelopmentSecurityConfig() SecurityConfig {
 	// Development-specific overrides (still secure!)
 	config.CORS.AllowedOrigins = []string{"http://localhost:3000", "http://localhost:5173"}
 	config.Session.Secure = false // Allow HTTP in development
+	// __Host- prefixed cookies are rejected by browsers without Secure
+	config.Session.CookieName = "session_token"
 	config.Session.SameSite = "Lax"
 	config.RateLimit.AuthMaxRequests = 50 // More lenient for development
 	config.Audit.Destination = "console"
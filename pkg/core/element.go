@@ -375,6 +375,24 @@ type Element interface {
 	//   searchable := field.IsSearchable() // true
 	IsSearchable() bool
 
+	// IsEncrypted, bu element'in değerinin veritabanında şifreli saklanıp saklanmadığını döndürür.
+	//
+	// Encrypted() metodu ile işaretlenen element'ler true döner.
+	//
+	// Örnek:
+	//   field := fields.Text("TC Kimlik No", "national_id").Encrypted()
+	//   encrypted := field.IsEncrypted() // true
+	IsEncrypted() bool
+
+	// IsSensitive, bu element'in değerinin audit kayıtlarında maskelenip maskelenmeyeceğini döndürür.
+	//
+	// Sensitive() ile işaretlenen, Encrypted() olan ve şifre (password) element'leri true döner.
+	//
+	// Örnek:
+	//   field := fields.Text("IBAN", "iban").Sensitive()
+	//   sensitive := field.IsSensitive() // true
+	IsSensitive() bool
+
 	// ============================================================================
 	// Fluent Setter'lar - Görünüm Kontrolü (View Control)
 	// ============================================================================
@@ -853,6 +871,32 @@ type Element interface {
 	//   - docs/Fields.md: Arama ve sıralama bölümü
 	Searchable() Element
 
+	// Encrypted, element'in değerini veritabanında AES-GCM ile şifreli saklar.
+	//
+	// Şifreli alanlar kayıt sırasında şifrelenir, okunurken çözülür ve
+	// liste görünümlerinde maskelenir. Şifreli kolonlarda filtreleme ve
+	// sıralama yapılamaz; bu nedenle Encrypted() alanın Filterable, Sortable
+	// ve Searchable işaretlerini kaldırır.
+	//
+	// Döndürür:
+	//   - Yapılandırılmış Element pointer'ı (method chaining için)
+	//
+	// Örnek:
+	//   field := fields.Text("IBAN", "iban").OnForm().Encrypted()
+	Encrypted() Element
+
+	// Sensitive, element'in değerini hassas olarak işaretler.
+	//
+	// Hassas değerler veri değişikliği audit kayıtlarında maskelenir; kayıt
+	// yalnızca alanın değiştiğini gösterir.
+	//
+	// Döndürür:
+	//   - Yapılandırılmış Element pointer'ı (method chaining için)
+	//
+	// Örnek:
+	//   field := fields.Text("Maaş", "salary").Sensitive()
+	Sensitive() Element
+
 	// Stacked, element'i yığılmış (tam genişlik) olarak işaretler.
 	//
 	// Yığılmış element'ler, container'ın tam genişliğini kaplar.
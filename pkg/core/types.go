@@ -620,6 +620,109 @@ const (
 	// - Dosya isimleri sanitize edilmelidir
 	TYPE_FILE ElementType = "file"
 
+	// TYPE_GALLERY, bir alana birden fazla dosya yüklemek için kullanılan alan tipidir.
+	//
+	// Dosyalar sıralı bir liste olarak saklanır: varsayılan olarak JSON sütununda,
+	// istenirse modelin has-many ilişkisindeki ek kayıtlarında. Her öğe yol, URL,
+	// dosya adı, boyut, MIME tipi, açıklama ve sıra bilgisi taşır.
+	//
+	// # Örnek Kullanım
+	//
+	// ```go
+	// fields.Gallery("Görseller", "images").Limit(10)
+	// ```
+	//
+	// # Veri Formatı
+	//
+	// ```json
+	// [
+	//   {"path": "products/3f9c.jpg", "url": "/storage/products/3f9c.jpg", "caption": "Ön", "position": 0},
+	//   {"path": "products/a1b2.jpg", "url": "/storage/products/a1b2.jpg", "position": 1}
+	// ]
+	// ```
+	TYPE_GALLERY ElementType = "gallery"
+
+	// TYPE_REPEATER, aynı alt alan grubunun birden fazla kez girildiği alan tipidir.
+	//
+	// Satırlar varsayılan olarak JSON sütununda, istenirse modelin has-many
+	// ilişkisindeki alt kayıtlarda saklanır. Her satır alt alanların kurallarıyla
+	// doğrulanır; hatalar "items.2.price" biçimindeki key'lerle döner.
+	//
+	// # Örnek Kullanım
+	//
+	// ```go
+	// fields.Repeater("Kalemler", "items").
+	//     Fields(fields.Text("Ürün", "product"), fields.Number("Fiyat", "price")).
+	//     MinRepeats(1)
+	// ```
+	//
+	// # Veri Formatı
+	//
+	// ```json
+	// [
+	//   {"product": "Kalem", "price": 12.5},
+	//   {"product": "Defter", "price": 40}
+	// ]
+	// ```
+	TYPE_REPEATER ElementType = "repeater"
+
+	// TYPE_JSON, serbest yapılı JSON belgesi girişi için kullanılan alan tipidir.
+	//
+	// Değer kaydedilmeden önce geçerli JSON olarak doğrulanır ve sıkıştırılmış
+	// JSON metni olarak json/jsonb/text sütununa yazılır. Detay görünümünde
+	// girintili hali "pretty" anahtarıyla döner.
+	//
+	// # Örnek Kullanım
+	//
+	// ```go
+	// fields.JSON("Ayarlar", "settings")
+	// ```
+	TYPE_JSON ElementType = "json"
+
+	// TYPE_TAGS, serbest metin etiket listesi girişi için kullanılan alan tipidir.
+	//
+	// Etiketler varsayılan olarak JSON dizisi olarak saklanır, istenirse
+	// modelin many-to-many etiket ilişkisine yazılır. Öneri endpoint'i mevcut
+	// etiketleri önek eşleşmesiyle döner.
+	//
+	// # Örnek Kullanım
+	//
+	// ```go
+	// fields.Tags("Etiketler", "tags")
+	// ```
+	//
+	// # Veri Formatı
+	//
+	// ```json
+	// ["go", "panel", "admin"]
+	// ```
+	TYPE_TAGS ElementType = "tags"
+
+	// TYPE_SLUG, URL dostu tekil tanımlayıcı için kullanılan alan tipidir.
+	//
+	// Değer kaynak alandan bağımlılık çözümüyle üretilir, boş bırakılırsa
+	// kayıt sırasında kaynak alandan türetilir ve tabloda benzersiz olması
+	// zorunludur.
+	//
+	// # Örnek Kullanım
+	//
+	// ```go
+	// fields.Slug("Slug", "slug").From("title")
+	// ```
+	TYPE_SLUG ElementType = "slug"
+
+	// TYPE_MARKDOWN, Markdown metin girişi için kullanılan alan tipidir.
+	//
+	// Değer ham Markdown olarak text sütununda saklanır; detay görünümünde
+	// güvenli HTML'e çevrilmiş hali "html" anahtarıyla döner.
+	//
+	// # Örnek Kullanım
+	//
+	// ```go
+	// fields.Markdown("İçerik", "body")
+	// ```
+	TYPE_MARKDOWN ElementType = "markdown"
+
 	// TYPE_KEY_VALUE, anahtar-değer çifti girişi için kullanılan alan tipidir.
 	//
 	// Bu alan tipi, dinamik anahtar-değer çiftlerini saklamak için kullanılır.
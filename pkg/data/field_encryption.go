@@ -0,0 +1,204 @@
+package data
+
+import (
+	stdcontext "context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/ferdiunal/panel.go/shared/encrypt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+var (
+	// ErrEncryptedColumnQuery, şifreli bir kolonda filtreleme veya sıralama yapılmak
+	// istendiğinde döner. Şifreli değerler her yazımda farklı nonce ile üretildiği için
+	// veritabanı tarafında karşılaştırılamaz.
+	ErrEncryptedColumnQuery = errors.New("encrypted columns cannot be filtered or sorted")
+
+	// ErrFieldKeyringMissing, şifreli alan tanımlı olduğu halde keyring yapılandırılmadığında döner.
+	ErrFieldKeyringMissing = errors.New("field encryption keyring is not configured")
+)
+
+// EncryptedColumnQueryError, hangi kolonda hangi işlemin reddedildiğini taşır.
+// errors.Is(err, ErrEncryptedColumnQuery) ile yakalanabilir.
+type EncryptedColumnQueryError struct {
+	Column    string
+	Operation string
+}
+
+func (e *EncryptedColumnQueryError) Error() string {
+	return fmt.Sprintf("cannot %s by encrypted column %q", e.Operation, e.Column)
+}
+
+func (e *EncryptedColumnQueryError) Unwrap() error {
+	return ErrEncryptedColumnQuery
+}
+
+// SetEncryptedColumns, değerleri veritabanında şifreli saklanacak kolonları ayarlar.
+// Kolonlar field key'i, DB kolon adı veya struct field adı olarak verilebilir.
+func (p *GormDataProvider) SetEncryptedColumns(cols []string) {
+	if len(cols) == 0 {
+		p.encryptedColumns = nil
+		return
+	}
+	p.encryptedColumns = make(map[string]struct{}, len(cols))
+	for _, col := range cols {
+		if col = strings.TrimSpace(col); col != "" {
+			p.encryptedColumns[col] = struct{}{}
+		}
+	}
+}
+
+// SetFieldKeyring, şifreli kolonlarda kullanılacak keyring'i ayarlar.
+// Ayarlanmazsa şifreli kolonlara yazma/okuma ErrFieldKeyringMissing ile başarısız olur.
+func (p *GormDataProvider) SetFieldKeyring(keyring *encrypt.Keyring) {
+	p.fieldKeyring = keyring
+}
+
+func (p *GormDataProvider) hasEncryptedColumns() bool {
+	return len(p.encryptedColumns) > 0
+}
+
+// isEncryptedColumn, verilen isimlerden herhangi biri şifreli kolon olarak işaretlenmişse true döner.
+func (p *GormDataProvider) isEncryptedColumn(names ...string) bool {
+	if !p.hasEncryptedColumns() {
+		return false
+	}
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		if _, ok := p.encryptedColumns[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *GormDataProvider) isEncryptedSchemaField(key string, field *schema.Field) bool {
+	if field == nil {
+		return p.isEncryptedColumn(key)
+	}
+	return p.isEncryptedColumn(key, field.DBName, field.Name)
+}
+
+// validateEncryptedQuery, filtre ve sıralamaların şifreli kolonlara uygulanmadığını doğrular.
+func (p *GormDataProvider) validateEncryptedQuery(req QueryRequest) error {
+	if !p.hasEncryptedColumns() {
+		return nil
+	}
+	for _, filter := range req.Filters {
+		if p.isEncryptedColumn(filter.Field) {
+			return &EncryptedColumnQueryError{Column: filter.Field, Operation: "filter"}
+		}
+	}
+	for _, sort := range req.Sorts {
+		if p.isEncryptedColumn(sort.Column) {
+			return &EncryptedColumnQueryError{Column: sort.Column, Operation: "sort"}
+		}
+	}
+	return nil
+}
+
+// encryptFieldValue, şifreli kolona yazılacak değeri şifreler.
+// nil ve boş string değerler olduğu gibi bırakılır.
+func (p *GormDataProvider) encryptFieldValue(column string, value interface{}) (interface{}, error) {
+	var plaintext string
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		plaintext = v
+	case *string:
+		if v == nil {
+			return nil, nil
+		}
+		plaintext = *v
+	default:
+		return nil, fmt.Errorf("encrypted field %s must be a string, got %T", column, value)
+	}
+
+	if plaintext == "" {
+		return plaintext, nil
+	}
+
+	keyring := p.fieldKeyring
+	if keyring == nil {
+		return nil, fmt.Errorf("%w (field %s)", ErrFieldKeyringMissing, column)
+	}
+	return keyring.Encrypt(plaintext)
+}
+
+// decryptItems, sorgudan dönen model pointer'larındaki şifreli alanları yerinde çözer.
+// Keyring formatında olmayan (eski/düz metin) değerler değiştirilmez.
+func (p *GormDataProvider) decryptItems(ctx stdcontext.Context, items ...interface{}) error {
+	if !p.hasEncryptedColumns() || len(items) == 0 {
+		return nil
+	}
+
+	stmt := &gorm.Statement{DB: p.DB}
+	if err := stmt.Parse(p.Model); err != nil {
+		return err
+	}
+
+	encryptedFields := make([]*schema.Field, 0, len(p.encryptedColumns))
+	for _, field := range stmt.Schema.Fields {
+		if field.DBName != "" && p.isEncryptedSchemaField("", field) {
+			encryptedFields = append(encryptedFields, field)
+		}
+	}
+	if len(encryptedFields) == 0 {
+		return nil
+	}
+
+	for _, item := range items {
+		value := reflect.ValueOf(item)
+		if value.Kind() != reflect.Ptr || value.IsNil() {
+			continue
+		}
+		value = value.Elem()
+		if value.Kind() != reflect.Struct {
+			continue
+		}
+
+		for _, field := range encryptedFields {
+			if err := p.decryptStructField(ctx, field, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *GormDataProvider) decryptStructField(ctx stdcontext.Context, field *schema.Field, structValue reflect.Value) error {
+	fieldValue := field.ReflectValueOf(ctx, structValue)
+	if fieldValue.Kind() == reflect.Ptr {
+		if fieldValue.IsNil() {
+			return nil
+		}
+		fieldValue = fieldValue.Elem()
+	}
+	if fieldValue.Kind() != reflect.String || !fieldValue.CanSet() {
+		return nil
+	}
+
+	ciphertext := fieldValue.String()
+	if !encrypt.IsEncryptedValue(ciphertext) {
+		return nil
+	}
+
+	keyring := p.fieldKeyring
+	if keyring == nil {
+		return fmt.Errorf("%w (field %s)", ErrFieldKeyringMissing, field.DBName)
+	}
+	plaintext, err := keyring.Decrypt(ciphertext)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt field %s: %w", field.DBName, err)
+	}
+	fieldValue.SetString(plaintext)
+	return nil
+}
@@ -0,0 +1,135 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ferdiunal/panel.go/pkg/query"
+	"github.com/ferdiunal/panel.go/shared/encrypt"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type encryptedCustomer struct {
+	ID         uint `gorm:"primaryKey"`
+	Name       string
+	NationalID string
+}
+
+func newEncryptedCustomerProvider(t *testing.T) (*GormDataProvider, *gorm.DB) {
+	t.Helper()
+
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect sqlite in-memory db: %v", err)
+	}
+	if err := db.AutoMigrate(&encryptedCustomer{}); err != nil {
+		t.Fatalf("failed to migrate table: %v", err)
+	}
+
+	keyring, err := encrypt.NewKeyring("k1", map[string][]byte{"k1": []byte("0123456789abcdef0123456789abcdef")})
+	if err != nil {
+		t.Fatalf("failed to create keyring: %v", err)
+	}
+
+	provider := NewGormDataProvider(db, &encryptedCustomer{})
+	provider.SetEncryptedColumns([]string{"national_id"})
+	provider.SetFieldKeyring(keyring)
+	return provider, db
+}
+
+func TestGormDataProvider_EncryptedColumns_RoundTrip(t *testing.T) {
+	provider, db := newEncryptedCustomerProvider(t)
+
+	created, err := provider.Create(nil, map[string]interface{}{
+		"name":        "Ada",
+		"national_id": "12345678901",
+	})
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	customer := created.(*encryptedCustomer)
+	if customer.NationalID != "12345678901" {
+		t.Fatalf("expected decrypted value after create, got %q", customer.NationalID)
+	}
+
+	var stored encryptedCustomer
+	if err := db.First(&stored, customer.ID).Error; err != nil {
+		t.Fatalf("failed to reload row: %v", err)
+	}
+	if !strings.HasPrefix(stored.NationalID, "enc:v1:k1:") {
+		t.Fatalf("expected ciphertext at rest, got %q", stored.NationalID)
+	}
+
+	if _, err := provider.Update(nil, fmt.Sprint(customer.ID), map[string]interface{}{"national_id": "10987654321"}); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if err := db.First(&stored, customer.ID).Error; err != nil {
+		t.Fatalf("failed to reload row: %v", err)
+	}
+	if strings.Contains(stored.NationalID, "10987654321") {
+		t.Fatalf("expected updated value to be encrypted, got %q", stored.NationalID)
+	}
+
+	resp, err := provider.Index(nil, QueryRequest{Page: 1, PerPage: 10})
+	if err != nil {
+		t.Fatalf("index failed: %v", err)
+	}
+	if got := resp.Items[0].(*encryptedCustomer).NationalID; got != "10987654321" {
+		t.Fatalf("expected decrypted value on index, got %q", got)
+	}
+}
+
+func TestGormDataProvider_EncryptedColumns_RejectFilterAndSort(t *testing.T) {
+	provider, _ := newEncryptedCustomerProvider(t)
+
+	_, err := provider.Index(nil, QueryRequest{
+		Page:    1,
+		PerPage: 10,
+		Filters: []query.Filter{{Field: "national_id", Operator: query.OpEqual, Value: "1"}},
+	})
+	if !errors.Is(err, ErrEncryptedColumnQuery) {
+		t.Fatalf("expected encrypted filter error, got %v", err)
+	}
+
+	_, err = provider.Index(nil, QueryRequest{
+		Page:    1,
+		PerPage: 10,
+		Sorts:   []Sort{{Column: "national_id", Direction: "asc"}},
+	})
+	if !errors.Is(err, ErrEncryptedColumnQuery) {
+		t.Fatalf("expected encrypted sort error, got %v", err)
+	}
+}
+
+func TestGormDataProvider_EncryptedColumns_SearchOnlyEncryptedColumns(t *testing.T) {
+	provider, _ := newEncryptedCustomerProvider(t)
+	provider.SetSearchColumns([]string{"national_id"})
+
+	for _, nationalID := range []string{"11111111111", "22222222222", "33333333333"} {
+		if _, err := provider.Create(nil, map[string]interface{}{"name": "Ada", "national_id": nationalID}); err != nil {
+			t.Fatalf("create failed: %v", err)
+		}
+	}
+
+	resp, err := provider.Index(nil, QueryRequest{Page: 1, PerPage: 10, Search: "no-such-value"})
+	if err != nil {
+		t.Fatalf("index failed: %v", err)
+	}
+	if resp.Total != 0 || len(resp.Items) != 0 {
+		t.Fatalf("expected no rows when every search column is encrypted, got %d (total %d)", len(resp.Items), resp.Total)
+	}
+}
+
+func TestGormDataProvider_EncryptedColumns_RequireKeyring(t *testing.T) {
+	provider, _ := newEncryptedCustomerProvider(t)
+	provider.SetFieldKeyring(nil)
+
+	_, err := provider.Create(nil, map[string]interface{}{"name": "Ada", "national_id": "1"})
+	if !errors.Is(err, ErrFieldKeyringMissing) {
+		t.Fatalf("expected missing keyring error, got %v", err)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/ferdiunal/panel.go/pkg/fields"
 	internalconcurrency "github.com/ferdiunal/panel.go/pkg/internal/concurrency"
 	"github.com/ferdiunal/panel.go/pkg/query"
+	"github.com/ferdiunal/panel.go/shared/encrypt"
 	"github.com/iancoleman/strcase"
 	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
@@ -126,6 +127,14 @@ type GormDataProvider struct {
 	relationshipFields []fields.RelationshipField
 	/// Relationship lazy-load concurrency ayarları
 	relationshipConcurrency RelationshipConcurrencyConfig
+	/// İstek context'i yerine kullanılacak sorgu context'i (timeout/iptal için)
+	queryContext stdcontext.Context
+	/// Değerleri şifreli saklanan kolonlar (Encrypted() alanlar)
+	encryptedColumns map[string]struct{}
+	/// Şifreli kolonlar için keyring (nil ise şifreli kolonlar okunamaz/yazılamaz)
+	fieldKeyring *encrypt.Keyring
+	/// Index/Show okumaları için read replica bağlantısı (nil ise DB kullanılır)
+	readDB *gorm.DB
 }
 
 // / # NewGormDataProvider
@@ -243,6 +252,9 @@ func NewGormDataProvider(db *gorm.DB, model interface{}) *GormDataProvider {
 // / - Context timeout'ları GORM sorgularına yansır
 // / - Context iptali sorguyu iptal eder
 func (p *GormDataProvider) getContext(ctx *context.Context) stdcontext.Context {
+	if p.queryContext != nil {
+		return p.queryContext
+	}
 	if ctx == nil {
 		return stdcontext.Background()
 	}
@@ -253,6 +265,13 @@ func (p *GormDataProvider) getContext(ctx *context.Context) stdcontext.Context {
 	return stdCtx
 }
 
+// SetQueryContext, sorgularda istek context'i yerine kullanılacak standart context'i ayarlar.
+// Global arama gibi süre sınırlı fan-out işlemlerinde sorguların iptal edilebilmesini sağlar.
+// nil verilirse istek context'i kullanımına geri dönülür.
+func (p *GormDataProvider) SetQueryContext(ctx stdcontext.Context) {
+	p.queryContext = ctx
+}
+
 func (p *GormDataProvider) warnf(ctx stdcontext.Context, msg string, args ...interface{}) {
 	if p == nil || p.DB == nil || p.DB.Logger == nil {
 		return
@@ -534,12 +553,12 @@ func (p *GormDataProvider) applyFilters(db *gorm.DB, filters []query.Filter) *go
 			}
 
 		case query.OpIn:
-			if vals, ok := f.Value.([]string); ok && len(vals) > 0 {
+			if vals := filterValueList(f.Value); len(vals) > 0 {
 				db = db.Where(fmt.Sprintf("%s IN ?", safeColumn), vals)
 			}
 
 		case query.OpNotIn:
-			if vals, ok := f.Value.([]string); ok && len(vals) > 0 {
+			if vals := filterValueList(f.Value); len(vals) > 0 {
 				db = db.Where(fmt.Sprintf("%s NOT IN ?", safeColumn), vals)
 			}
 
@@ -554,7 +573,7 @@ func (p *GormDataProvider) applyFilters(db *gorm.DB, filters []query.Filter) *go
 			}
 
 		case query.OpBetween:
-			if vals, ok := f.Value.([]string); ok && len(vals) == 2 {
+			if vals := filterValueList(f.Value); len(vals) == 2 {
 				db = db.Where(fmt.Sprintf("%s BETWEEN ? AND ?", safeColumn), vals[0], vals[1])
 			}
 
@@ -566,6 +585,22 @@ func (p *GormDataProvider) applyFilters(db *gorm.DB, filters []query.Filter) *go
 	return db
 }
 
+// filterValueList, in/between filtrelerinin değerini listeye çevirir. Parser
+// string listesi, otomatik index filtreleri ise tipine çevrilmiş değer listesi üretir.
+func filterValueList(value interface{}) []interface{} {
+	switch vals := value.(type) {
+	case []string:
+		list := make([]interface{}, len(vals))
+		for i, v := range vals {
+			list[i] = v
+		}
+		return list
+	case []interface{}:
+		return vals
+	}
+	return nil
+}
+
 // / # Index
 // /
 // / Bu fonksiyon, veritabanından sayfalanmış, filtrelenmiş ve sıralanmış veri listesi döndürür.
@@ -734,8 +769,13 @@ func (p *GormDataProvider) Index(ctx *context.Context, req QueryRequest) (*Query
 	// Let's start with just using db.Model(p.Model).Find(&results) where results is []map[string]interface{}
 	// GORM supports finding into a map.
 
+	// Encrypted columns hold non-deterministic ciphertext and cannot be compared in SQL.
+	if err := p.validateEncryptedQuery(req); err != nil {
+		return nil, err
+	}
+
 	stdCtx := p.getContext(ctx)
-	db := p.DB.WithContext(stdCtx).Model(p.Model)
+	db := p.reader().WithContext(stdCtx).Model(p.Model)
 
 	// Apply base query (e.g. lens query) before dynamic filters and search.
 	if p.BaseQuery != nil {
@@ -763,8 +803,13 @@ func (p *GormDataProvider) Index(ctx *context.Context, req QueryRequest) (*Query
 
 	// Apply Search with column validation
 	if req.Search != "" && len(p.SearchColumns) > 0 {
-		searchQuery := p.DB.WithContext(stdCtx).Session(&gorm.Session{NewDB: true})
+		searchQuery := p.reader().WithContext(stdCtx).Session(&gorm.Session{NewDB: true})
+		searchable := 0
 		for _, col := range p.SearchColumns {
+			if p.isEncryptedColumn(col) {
+				continue
+			}
+
 			// SECURITY: Validate search column names
 			safeColumn := col
 			if p.columnValidator != nil {
@@ -780,8 +825,15 @@ func (p *GormDataProvider) Index(ctx *context.Context, req QueryRequest) (*Query
 				safeColumn = SanitizeColumnName(col)
 			}
 			searchQuery = searchQuery.Or(fmt.Sprintf("%s LIKE ?", safeColumn), "%"+req.Search+"%")
+			searchable++
+		}
+		// Aranabilir kolon kalmadıysa (hepsi şifreli veya geçersiz) boş OR grubu
+		// koşul eklemez; arama tüm kayıtları döndürmesin diye sonuç boş tutulur.
+		if searchable == 0 {
+			db = db.Where("1 = 0")
+		} else {
+			db = db.Where(searchQuery)
 		}
-		db = db.Where(searchQuery)
 	}
 
 	// Count Total
@@ -849,6 +901,10 @@ func (p *GormDataProvider) Index(ctx *context.Context, req QueryRequest) (*Query
 		items[i] = resultsVal.Index(i).Addr().Interface()
 	}
 
+	if err := p.decryptItems(stdCtx, items...); err != nil {
+		return nil, err
+	}
+
 	// Load lazy relationships manually (LAZY_LOADING strategy)
 	// Eager loading relationships are already loaded via GORM Preload above
 	// NOT: relationshipFields boş olabilir (field type detection sorunu nedeniyle)
@@ -1396,6 +1452,12 @@ func (p *GormDataProvider) loadLazyRelationshipsForItemContext(
 // / - Context timeout
 // / - Geçersiz ID formatı
 func (p *GormDataProvider) Show(ctx *context.Context, id string) (interface{}, error) {
+	return p.showFrom(p.reader(), ctx, id)
+}
+
+// showFrom, Show'un verilen bağlantı üzerinden çalışan halidir.
+// Create/Update sonrası yeniden okuma, replica gecikmesinden etkilenmemek için primary'yi kullanır.
+func (p *GormDataProvider) showFrom(conn *gorm.DB, ctx *context.Context, id string) (interface{}, error) {
 	// Create a new instance of the model to hold the result
 	// We use p.Model's type
 	// But simpler: just use map[string]interface{} for dynamic nature or try to use the model type via reflection if needed.
@@ -1411,7 +1473,7 @@ func (p *GormDataProvider) Show(ctx *context.Context, id string) (interface{}, e
 	result := reflect.New(modelType).Interface()
 
 	stdCtx := p.getContext(ctx)
-	db := p.DB.WithContext(stdCtx).Model(p.Model)
+	db := conn.WithContext(stdCtx).Model(p.Model)
 
 	// Apply Eager Loading with GORM Preload
 	// WORKAROUND: Direkt olarak WithRelationships kullan çünkü relationshipFields boş olabilir
@@ -1424,6 +1486,10 @@ func (p *GormDataProvider) Show(ctx *context.Context, id string) (interface{}, e
 		return nil, err
 	}
 
+	if err := p.decryptItems(stdCtx, result); err != nil {
+		return nil, err
+	}
+
 	// Load lazy relationships manually (LAZY_LOADING strategy)
 	// Eager loading relationships are already loaded via GORM Preload above
 	// NOT: relationshipFields boş olabilir (field type detection sorunu nedeniyle)
@@ -1596,6 +1662,13 @@ func (p *GormDataProvider) Create(ctx *context.Context, data map[string]interfac
 	for k, v := range data {
 		field := modelSchema.LookUpField(k)
 		if field != nil && field.DBName != "" {
+			if p.isEncryptedSchemaField(k, field) {
+				encrypted, err := p.encryptFieldValue(k, v)
+				if err != nil {
+					return nil, err
+				}
+				v = encrypted
+			}
 			validData[k] = v
 
 			// Set field value on newItem to ensure it's populated for Create
@@ -1712,7 +1785,7 @@ func (p *GormDataProvider) Create(ctx *context.Context, data map[string]interfac
 		idVal := reflect.ValueOf(newItem).Elem().FieldByName(modelSchema.PrioritizedPrimaryField.Name).Interface()
 		id := fmt.Sprint(idVal)
 		if id != "" && id != "0" {
-			return p.Show(ctx, id)
+			return p.showFrom(p.DB, ctx, id)
 		}
 	}
 
@@ -1935,6 +2008,13 @@ func (p *GormDataProvider) Update(ctx *context.Context, id string, data map[stri
 		}
 		if field != nil {
 			if field.DBName != "" {
+				if p.isEncryptedSchemaField(k, field) {
+					encrypted, err := p.encryptFieldValue(k, v)
+					if err != nil {
+						return nil, err
+					}
+					v = encrypted
+				}
 				updates[k] = v
 			} else if rel, ok := modelSchema.Relationships.Relations[field.Name]; ok {
 				switch rel.Type {
@@ -2038,7 +2118,7 @@ func (p *GormDataProvider) Update(ctx *context.Context, id string, data map[stri
 		}
 	}
 
-	return p.Show(ctx, id)
+	return p.showFrom(p.DB, ctx, id)
 }
 
 // / # Delete
@@ -2231,6 +2311,21 @@ func (p *GormDataProvider) Delete(ctx *context.Context, id string) error {
 	return p.DB.WithContext(stdCtx).Model(p.Model).Where("id = ?", id).Delete(nil).Error
 }
 
+// UsesSoftDelete, modelde gorm.DeletedAt alanı varsa true döner; bu durumda Delete
+// kaydı silmek yerine deleted_at sütununu doldurur.
+func (p *GormDataProvider) UsesSoftDelete() bool {
+	stmt := &gorm.Statement{DB: p.DB}
+	if err := stmt.Parse(p.Model); err != nil {
+		return false
+	}
+	for _, field := range stmt.Schema.Fields {
+		if field.FieldType == reflect.TypeOf(gorm.DeletedAt{}) {
+			return true
+		}
+	}
+	return false
+}
+
 // SetRelationshipFields, yüklenecek ilişki field'larını ayarlar.
 //
 // Bu metod, RelationshipLoader tarafından kullanılacak field'ları belirler.
@@ -2439,29 +2534,27 @@ func (p *GormDataProvider) replaceMany2Many(ctx stdcontext.Context, parentID int
 	safeParentColumn := SanitizeColumnName(parentColumn)
 	safeRelatedColumn := SanitizeColumnName(relatedColumn)
 
-	tx := p.DB.WithContext(ctx).Begin()
-
-	// 1. Clear existing relationships
-	if err := tx.Exec(
-		fmt.Sprintf("DELETE FROM %s WHERE %s = ?", safePivotTable, safeParentColumn),
-		parentID,
-	).Error; err != nil {
-		tx.Rollback()
-		return err
-	}
-
-	// 2. Insert new relationships
-	for _, relatedID := range relatedIDs {
+	// Transaction, provider zaten bir transaction içindeyse (BeginTx) savepoint kullanır
+	return p.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. Clear existing relationships
 		if err := tx.Exec(
-			fmt.Sprintf("INSERT INTO %s (%s, %s) VALUES (?, ?)", safePivotTable, safeParentColumn, safeRelatedColumn),
-			parentID, relatedID,
+			fmt.Sprintf("DELETE FROM %s WHERE %s = ?", safePivotTable, safeParentColumn),
+			parentID,
 		).Error; err != nil {
-			tx.Rollback()
 			return err
 		}
-	}
 
-	return tx.Commit().Error
+		// 2. Insert new relationships
+		for _, relatedID := range relatedIDs {
+			if err := tx.Exec(
+				fmt.Sprintf("INSERT INTO %s (%s, %s) VALUES (?, ?)", safePivotTable, safeParentColumn, safeRelatedColumn),
+				parentID, relatedID,
+			).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
 // clearMany2Many, Many2Many ilişkilerini raw SQL ile temizler.
@@ -2674,6 +2767,8 @@ func (p *GormDataProvider) BeginTx(ctx *context.Context) (DataProvider, error) {
 		relationshipLoader:      NewGormRelationshipLoader(tx),
 		relationshipFields:      p.relationshipFields,
 		relationshipConcurrency: p.relationshipConcurrency,
+		encryptedColumns:        p.encryptedColumns,
+		fieldKeyring:            p.fieldKeyring,
 	}, nil
 }
 
@@ -0,0 +1,98 @@
+package orm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ferdiunal/panel.go/pkg/domain/audit"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// auditBatchSize, dışa aktarma ve doğrulamada tek seferde okunan kayıt sayısıdır.
+const auditBatchSize = 500
+
+// AuditRepository, audit olaylarını hash zinciriyle saklar, doğrular ve saklama
+// süresi dolan kayıtları temizler.
+type AuditRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditRepository, verilen GORM bağlantısıyla bir AuditRepository oluşturur.
+func NewAuditRepository(db *gorm.DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+// Append, olayları zincir başındaki hash'e bağlayarak tek transaction içinde ekler.
+func (r *AuditRepository) Append(ctx context.Context, events []audit.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Zincir başı kilitlenir; eşzamanlı yazan replikalar sırayla ilerler
+		// (SQLite FOR UPDATE desteklemez, orada yazma işlemleri zaten tekildir)
+		query := tx
+		if tx.Dialector.Name() != "sqlite" {
+			query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+		}
+		head := audit.ChainHead{ID: 1}
+		if err := query.Where(audit.ChainHead{ID: 1}).FirstOrCreate(&head).Error; err != nil {
+			return err
+		}
+
+		prev := head.Hash
+		for i := range events {
+			events[i].ID = 0
+			events[i].Seal(prev)
+			prev = events[i].Hash
+		}
+		if err := tx.CreateInBatches(events, 100).Error; err != nil {
+			return err
+		}
+		return tx.Model(&head).Update("hash", prev).Error
+	})
+}
+
+// Each, [from, to) aralığındaki olayları ID sırasıyla parça parça fn'e verir.
+// Sıfır zaman değeri o yönde sınır olmadığı anlamına gelir.
+func (r *AuditRepository) Each(ctx context.Context, from, to time.Time, fn func([]audit.Event) error) error {
+	query := r.db.WithContext(ctx).Model(&audit.Event{})
+	if !from.IsZero() {
+		query = query.Where("timestamp >= ?", audit.NormalizeTimestamp(from))
+	}
+	if !to.IsZero() {
+		query = query.Where("timestamp < ?", audit.NormalizeTimestamp(to))
+	}
+
+	var batch []audit.Event
+	result := query.FindInBatches(&batch, auditBatchSize, func(tx *gorm.DB, _ int) error {
+		return fn(batch)
+	})
+	return result.Error
+}
+
+// Verify, tüm zinciri baştan sona doğrular.
+func (r *AuditRepository) Verify(ctx context.Context) (audit.ChainVerifier, error) {
+	var verifier audit.ChainVerifier
+	err := r.Each(ctx, time.Time{}, time.Time{}, func(events []audit.Event) error {
+		for _, event := range events {
+			if !verifier.Add(event) {
+				return errAuditChainBroken
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, errAuditChainBroken) {
+		err = nil
+	}
+	return verifier, err
+}
+
+var errAuditChainBroken = errors.New("audit chain broken")
+
+// DeleteBefore, cutoff'tan eski olayları siler ve silinen kayıt sayısını döndürür.
+func (r *AuditRepository) DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("timestamp < ?", audit.NormalizeTimestamp(cutoff)).Delete(&audit.Event{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,91 @@
+package orm
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ferdiunal/panel.go/pkg/domain/ratelimit"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// counterSweepInterval, süresi dolmuş sayaçların en fazla ne sıklıkla silineceğidir.
+const counterSweepInterval = time.Minute
+
+// CounterStore, rate limit ve hesap kilitleme sayaçlarını rate_limit_counters
+// tablosunda tutar. Aynı veritabanını kullanan replikalar sayaçları paylaşır.
+type CounterStore struct {
+	db  *gorm.DB
+	now func() time.Time
+
+	mu        sync.Mutex
+	lastSweep time.Time
+}
+
+// NewCounterStore, verilen veritabanı bağlantısını kullanan bir CounterStore oluşturur.
+func NewCounterStore(db *gorm.DB) *CounterStore {
+	return &CounterStore{db: db, now: time.Now}
+}
+
+// Increment, sayacı delta kadar artırır ve yeni değeri döner. Sayaç yoksa veya
+// süresi dolmuşsa delta ile yeniden başlar ve ttl sonra sona erer.
+func (s *CounterStore) Increment(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	now := s.now().UTC()
+	expiresAt := now.Add(ttl)
+	s.sweep(ctx, now)
+
+	table := ratelimit.Counter{}.TableName()
+	var hits int64
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Süresi dolmuş satır yeniden başlar; atamalar sıralıdır çünkü MySQL SET
+		// ifadelerini soldan sağa değerlendirir ve expires_at en son güncellenmelidir.
+		err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "counter_key"}},
+			DoUpdates: clause.Set{
+				{Column: clause.Column{Name: "hits"}, Value: gorm.Expr(
+					"CASE WHEN "+table+".expires_at <= ? THEN ? ELSE "+table+".hits + ? END", now, delta, delta)},
+				{Column: clause.Column{Name: "expires_at"}, Value: gorm.Expr(
+					"CASE WHEN "+table+".expires_at <= ? THEN ? ELSE "+table+".expires_at END", now, expiresAt)},
+			},
+		}).Create(&ratelimit.Counter{Key: key, Hits: delta, ExpiresAt: expiresAt}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&ratelimit.Counter{}).
+			Where("counter_key = ?", key).
+			Pluck("hits", &hits).Error
+	})
+	return hits, err
+}
+
+// Get, sayacın güncel değerini döner; sayaç yoksa veya süresi dolmuşsa 0 döner.
+func (s *CounterStore) Get(ctx context.Context, key string) (int64, error) {
+	var counter ratelimit.Counter
+	err := s.db.WithContext(ctx).
+		Where("counter_key = ? AND expires_at > ?", key, s.now().UTC()).
+		Take(&counter).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	return counter.Hits, err
+}
+
+// Delete, sayacı siler.
+func (s *CounterStore) Delete(ctx context.Context, key string) error {
+	return s.db.WithContext(ctx).Delete(&ratelimit.Counter{}, "counter_key = ?", key).Error
+}
+
+// sweep, süresi dolmuş sayaçları dakikada en fazla bir kez siler.
+func (s *CounterStore) sweep(ctx context.Context, now time.Time) {
+	s.mu.Lock()
+	if now.Sub(s.lastSweep) < counterSweepInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.lastSweep = now
+	s.mu.Unlock()
+
+	s.db.WithContext(ctx).Delete(&ratelimit.Counter{}, "expires_at <= ?", now)
+}
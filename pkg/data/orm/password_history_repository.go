@@ -0,0 +1,55 @@
+package orm
+
+import (
+	"context"
+
+	"github.com/ferdiunal/panel.go/pkg/domain/account"
+	"gorm.io/gorm"
+)
+
+// PasswordHistoryRepository, kullanıcıların önceki parola hash'lerini saklar.
+type PasswordHistoryRepository struct {
+	db *gorm.DB
+}
+
+// NewPasswordHistoryRepository, verilen GORM bağlantısıyla bir PasswordHistoryRepository oluşturur.
+func NewPasswordHistoryRepository(db *gorm.DB) *PasswordHistoryRepository {
+	return &PasswordHistoryRepository{db: db}
+}
+
+func (r *PasswordHistoryRepository) Recent(ctx context.Context, userID uint, limit int) ([]account.PasswordHistory, error) {
+	var entries []account.PasswordHistory
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&entries).Error
+	return entries, err
+}
+
+func (r *PasswordHistoryRepository) Add(ctx context.Context, entry *account.PasswordHistory, keep int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(entry).Error; err != nil {
+			return err
+		}
+		if keep <= 0 {
+			return nil
+		}
+		// OFFSET tek başına her sürücüde desteklenmediği için fazla kayıtlar uygulamada ayrılır
+		var ids []uint
+		if err := tx.Model(&account.PasswordHistory{}).
+			Where("user_id = ?", entry.UserID).
+			Order("created_at DESC, id DESC").
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) <= keep {
+			return nil
+		}
+		return tx.Delete(&account.PasswordHistory{}, ids[keep:]).Error
+	})
+}
+
+func (r *PasswordHistoryRepository) DeleteByUserID(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).Delete(&account.PasswordHistory{}, "user_id = ?", userID).Error
+}
@@ -5,6 +5,7 @@ package orm
 
 import (
 	"context"
+	"time"
 
 	"github.com/ferdiunal/panel.go/pkg/domain/session"
 	"gorm.io/gorm"
@@ -251,3 +252,52 @@ func (r *SessionRepository) DeleteByToken(ctx context.Context, token string) err
 func (r *SessionRepository) DeleteByUserID(ctx context.Context, userID uint) error {
 	return r.db.WithContext(ctx).Delete(&session.Session{}, "user_id = ?", userID).Error
 }
+
+// Bu metod, kullanıcının oturumlarını son görülme zamanına göre (yeniden eskiye) döndürür.
+func (r *SessionRepository) FindByUserID(ctx context.Context, userID uint) ([]session.Session, error) {
+	var sessions []session.Session
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("last_seen_at DESC").
+		Order("id DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+// Bu metod, oturumun son görülme ve bitiş zamanlarını tek bir UPDATE ile günceller.
+// UpdatedAt alanı da aynı sorguda güncellenir.
+func (r *SessionRepository) Touch(ctx context.Context, id uint, lastSeenAt, expiresAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&session.Session{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"last_seen_at": lastSeenAt,
+		"expires_at":   expiresAt,
+		"updated_at":   lastSeenAt,
+	}).Error
+}
+
+// Bu metod, keepID dışındaki tüm kullanıcı oturumlarını siler.
+// keepID sıfırsa DeleteByUserID ile aynı davranır.
+func (r *SessionRepository) DeleteByUserIDExcept(ctx context.Context, userID, keepID uint) error {
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if keepID != 0 {
+		query = query.Where("id <> ?", keepID)
+	}
+	return query.Delete(&session.Session{}).Error
+}
+
+// Bu metod, süresi before'dan önce dolmuş oturumları siler ve silinen kayıt sayısını döndürür.
+func (r *SessionRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("expires_at < ?", before).Delete(&session.Session{})
+	return result.RowsAffected, result.Error
+}
+
+// Bu metod, token'ı 64 karakter olmayan (sha256 hex hash'i olmayan) oturumları döndürür.
+func (r *SessionRepository) FindUnhashed(ctx context.Context) ([]session.Session, error) {
+	var sessions []session.Session
+	err := r.db.WithContext(ctx).Where("LENGTH(token) <> ?", 64).Find(&sessions).Error
+	return sessions, err
+}
+
+// Bu metod, oturumun token değerini günceller.
+func (r *SessionRepository) UpdateToken(ctx context.Context, id uint, token string) error {
+	return r.db.WithContext(ctx).Model(&session.Session{}).Where("id = ?", id).Update("token", token).Error
+}
@@ -0,0 +1,131 @@
+package orm
+
+import (
+	"context"
+	"time"
+
+	"github.com/ferdiunal/panel.go/pkg/domain/storedfile"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// StoredFileRepository, yüklenen dosyaların sahiplik ve yetim durumunu saklar.
+type StoredFileRepository struct {
+	db *gorm.DB
+}
+
+// NewStoredFileRepository, verilen GORM bağlantısıyla bir StoredFileRepository oluşturur.
+func NewStoredFileRepository(db *gorm.DB) *StoredFileRepository {
+	return &StoredFileRepository{db: db}
+}
+
+// Claim, dosyaları sahibine bağlar. Daha önce yetim olarak işaretlenmiş veya başka
+// bir kayda ait görünen dosyalar da bu sahibe geçer ve silinme sırasından çıkar.
+func (r *StoredFileRepository) Claim(ctx context.Context, owner storedfile.Owner, refs []storedfile.Ref) error {
+	if len(refs) == 0 {
+		return nil
+	}
+	rows := make([]storedfile.File, 0, len(refs))
+	for _, ref := range refs {
+		rows = append(rows, storedfile.File{
+			Disk:     ref.Disk,
+			Path:     ref.Path,
+			Resource: owner.Resource,
+			RecordID: owner.RecordID,
+			Field:    owner.Field,
+		})
+	}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "disk"}, {Name: "path"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"resource":    owner.Resource,
+			"record_id":   owner.RecordID,
+			"field":       owner.Field,
+			"orphaned_at": nil,
+			"updated_at":  time.Now(),
+		}),
+	}).Create(&rows).Error
+}
+
+// Owned, kaydın hâlâ kullanılan dosyalarını döner.
+func (r *StoredFileRepository) Owned(ctx context.Context, resource, recordID string) ([]storedfile.File, error) {
+	var files []storedfile.File
+	err := r.db.WithContext(ctx).
+		Where("resource = ? AND record_id = ? AND orphaned_at IS NULL", resource, recordID).
+		Order("id").
+		Find(&files).Error
+	return files, err
+}
+
+// Release, kayda ait dosyaları at zamanında yetim olarak işaretler. Henüz izlenmeyen
+// dosyalar (izleme açılmadan önce yüklenenler) yetim olarak eklenir; o sırada başka
+// bir kayda ait olan dosyalara dokunulmaz.
+func (r *StoredFileRepository) Release(ctx context.Context, owner storedfile.Owner, refs []storedfile.Ref, at time.Time) error {
+	if len(refs) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, ref := range refs {
+			row := storedfile.File{
+				Disk:       ref.Disk,
+				Path:       ref.Path,
+				Resource:   owner.Resource,
+				RecordID:   owner.RecordID,
+				Field:      owner.Field,
+				OrphanedAt: &at,
+			}
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&row)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected > 0 {
+				continue
+			}
+			err := tx.Model(&storedfile.File{}).
+				Where("disk = ? AND path = ? AND resource = ? AND record_id = ? AND orphaned_at IS NULL",
+					ref.Disk, ref.Path, owner.Resource, owner.RecordID).
+				Update("orphaned_at", at).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Due, cutoff'tan önce yetim kalmış en fazla limit dosyayı döner.
+func (r *StoredFileRepository) Due(ctx context.Context, cutoff time.Time, limit int) ([]storedfile.File, error) {
+	var files []storedfile.File
+	err := r.db.WithContext(ctx).
+		Where("orphaned_at IS NOT NULL AND orphaned_at <= ?", cutoff).
+		Order("orphaned_at").
+		Limit(limit).
+		Find(&files).Error
+	return files, err
+}
+
+// Take, yetim dosyanın kaydını siler ve kaydın hâlâ yetim olup olmadığını döner.
+// Arada dosya yeniden sahiplenildiyse false döner ve dosya diskten silinmemelidir.
+func (r *StoredFileRepository) Take(ctx context.Context, file storedfile.File) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND orphaned_at IS NOT NULL", file.ID).
+		Delete(&storedfile.File{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// Active, diskteki kullanılan (yetim olmayan) dosya yollarını döner.
+func (r *StoredFileRepository) Active(ctx context.Context, disk string) ([]string, error) {
+	var paths []string
+	err := r.db.WithContext(ctx).Model(&storedfile.File{}).
+		Where("disk = ? AND orphaned_at IS NULL", disk).
+		Pluck("path", &paths).Error
+	return paths, err
+}
+
+// Forget, diskten silinen dosyaların kayıtlarını siler.
+func (r *StoredFileRepository) Forget(ctx context.Context, disk string, paths []string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Where("disk = ? AND path IN ?", disk, paths).Delete(&storedfile.File{}).Error
+}
@@ -0,0 +1,86 @@
+package orm
+
+import (
+	"context"
+	"time"
+
+	"github.com/ferdiunal/panel.go/pkg/domain/twofactor"
+	"gorm.io/gorm"
+)
+
+// TwoFactorRepository, kullanıcıların iki adımlı doğrulama ayarlarını saklar.
+type TwoFactorRepository struct {
+	db *gorm.DB
+}
+
+// NewTwoFactorRepository, verilen GORM bağlantısıyla bir TwoFactorRepository oluşturur.
+func NewTwoFactorRepository(db *gorm.DB) *TwoFactorRepository {
+	return &TwoFactorRepository{db: db}
+}
+
+func (r *TwoFactorRepository) FindByUserID(ctx context.Context, userID uint) (*twofactor.TwoFactor, error) {
+	var tf twofactor.TwoFactor
+	if err := r.db.WithContext(ctx).First(&tf, "user_id = ?", userID).Error; err != nil {
+		return nil, err
+	}
+	return &tf, nil
+}
+
+func (r *TwoFactorRepository) Save(ctx context.Context, tf *twofactor.TwoFactor) error {
+	return r.db.WithContext(ctx).Save(tf).Error
+}
+
+func (r *TwoFactorRepository) DeleteByUserID(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&twofactor.RecoveryCode{}, "user_id = ?", userID).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&twofactor.TwoFactor{}, "user_id = ?", userID).Error
+	})
+}
+
+func (r *TwoFactorRepository) MarkStepUsed(ctx context.Context, id uint, step int64) (bool, error) {
+	// Koşullu update, aynı kodun eşzamanlı iki istekte kullanılmasını engeller
+	result := r.db.WithContext(ctx).Model(&twofactor.TwoFactor{}).
+		Where("id = ? AND last_used_step < ?", id, step).
+		Update("last_used_step", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *TwoFactorRepository) ReplaceRecoveryCodes(ctx context.Context, userID uint, hashes []string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&twofactor.RecoveryCode{}, "user_id = ?", userID).Error; err != nil {
+			return err
+		}
+		if len(hashes) == 0 {
+			return nil
+		}
+		now := time.Now()
+		codes := make([]twofactor.RecoveryCode, 0, len(hashes))
+		for _, hash := range hashes {
+			codes = append(codes, twofactor.RecoveryCode{UserID: userID, CodeHash: hash, CreatedAt: now})
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+func (r *TwoFactorRepository) UseRecoveryCode(ctx context.Context, userID uint, hash string) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&twofactor.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, hash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *TwoFactorRepository) CountUnusedRecoveryCodes(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&twofactor.RecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
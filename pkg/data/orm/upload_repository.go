@@ -0,0 +1,61 @@
+package orm
+
+import (
+	"context"
+	"time"
+
+	"github.com/ferdiunal/panel.go/pkg/domain/upload"
+	"gorm.io/gorm"
+)
+
+type UploadRepository struct {
+	db *gorm.DB
+}
+
+func NewUploadRepository(db *gorm.DB) *UploadRepository {
+	return &UploadRepository{db: db}
+}
+
+func (r *UploadRepository) Create(ctx context.Context, u *upload.Upload) error {
+	return r.db.WithContext(ctx).Create(u).Error
+}
+
+func (r *UploadRepository) FindByID(ctx context.Context, id string) (*upload.Upload, error) {
+	var u upload.Upload
+	if err := r.db.WithContext(ctx).First(&u, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// Advance, yüklemenin offset'ini from'dan to'ya ilerletir ve süresini uzatır.
+// Offset bu arada değiştiyse (eşzamanlı parça) false döner.
+func (r *UploadRepository) Advance(ctx context.Context, u *upload.Upload, from, to int64, expiresAt time.Time) (bool, error) {
+	updates := map[string]interface{}{
+		"upload_offset": to,
+		"expires_at":    expiresAt,
+		"updated_at":    time.Now(),
+	}
+	if to == u.Length {
+		updates["completed_at"] = time.Now()
+	}
+	result := r.db.WithContext(ctx).Model(&upload.Upload{}).
+		Where("id = ? AND upload_offset = ?", u.ID, from).
+		Updates(updates)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *UploadRepository) Delete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Delete(&upload.Upload{}, "id = ?", id).Error
+}
+
+// Expired, now itibarıyla süresi dolmuş en fazla limit yüklemeyi döner.
+func (r *UploadRepository) Expired(ctx context.Context, now time.Time, limit int) ([]upload.Upload, error) {
+	var uploads []upload.Upload
+	err := r.db.WithContext(ctx).
+		Where("expires_at <= ?", now).
+		Order("expires_at").
+		Limit(limit).
+		Find(&uploads).Error
+	return uploads, err
+}
@@ -235,3 +235,33 @@ func (r *VerificationRepository) Delete(ctx context.Context, id uint) error {
 func (r *VerificationRepository) DeleteByIdentifier(ctx context.Context, identifier string) error {
 	return r.db.WithContext(ctx).Delete(&verification.Verification{}, "identifier = ?", identifier).Error
 }
+
+// FindLatestByIdentifier, identifier'a ait en son oluşturulan doğrulama kaydını döndürür.
+//
+// Kayıt bulunamazsa gorm.ErrRecordNotFound döner. Süresi geçmiş kayıtlar filtrelenmez.
+func (r *VerificationRepository) FindLatestByIdentifier(ctx context.Context, identifier string) (*verification.Verification, error) {
+	var v verification.Verification
+	if err := r.db.WithContext(ctx).Where("identifier = ?", identifier).Order("created_at DESC").Order("id DESC").First(&v).Error; err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// IncrementAttempts, kaydın başarısız deneme sayısını tek bir UPDATE ile artırır ve yeni değeri döndürür.
+//
+// Eşzamanlı istekler aynı sayacı kaybetmeden artırır. Kayıt silinmişse gorm.ErrRecordNotFound döner.
+func (r *VerificationRepository) IncrementAttempts(ctx context.Context, id uint) (int, error) {
+	var attempts int
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&verification.Verification{}).Where("id = ?", id).
+			UpdateColumn("attempts", gorm.Expr("attempts + ?", 1))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Model(&verification.Verification{}).Select("attempts").Where("id = ?", id).Scan(&attempts).Error
+	})
+	return attempts, err
+}
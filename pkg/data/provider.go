@@ -415,3 +415,25 @@ type DataProvider interface {
 	// Normal işlemler için Provider metodlarını (Raw, Exec, QueryTable, vb.) kullanın.
 	GetClient() interface{}
 }
+
+// SoftDeleter, Delete'in kaydı kalıcı olarak silmeyip işaretlediğini (soft delete)
+// bildirebilen provider'ların uyguladığı isteğe bağlı arayüzdür. Dosya temizliği
+// soft delete edilen kayıtların dosyalarını geri yüklenebilmeleri için korur.
+type SoftDeleter interface {
+	UsesSoftDelete() bool
+}
+
+// ValueSuggester, bir kolonda verilen önekle başlayan mevcut değerleri
+// listeleyebilen provider'ların uyguladığı isteğe bağlı arayüzdür. Alan öneri
+// endpoint'i, alanda öneri callback'i tanımlı değilse bu arayüzü kullanır.
+type ValueSuggester interface {
+	SuggestValues(ctx *context.Context, column string, prefix string, limit int) ([]string, error)
+}
+
+// TagSuggester, etiket alanları için verilen önekle başlayan mevcut etiketleri
+// listeleyebilen provider'ların uyguladığı isteğe bağlı arayüzdür. field, JSON
+// etiket kolonu veya etiket ilişkisinin adıdır; relationColumn ilişki modunda
+// etiket adının tutulduğu sütundur.
+type TagSuggester interface {
+	SuggestTags(ctx *context.Context, field string, relationColumn string, prefix string, limit int) ([]string, error)
+}
@@ -0,0 +1,18 @@
+package data
+
+import "gorm.io/gorm"
+
+// SetReadDB, Index ve Show okumalarının yönlendirileceği read replica bağlantısını ayarlar.
+// Create/Update/Delete, transaction'lar ve ilişki yazımları her zaman DB (primary) üzerinden yapılır.
+// nil verilirse okumalar da primary'ye döner.
+func (p *GormDataProvider) SetReadDB(db *gorm.DB) {
+	p.readDB = db
+}
+
+// reader, liste ve detay sorgularında kullanılacak bağlantıyı döndürür.
+func (p *GormDataProvider) reader() *gorm.DB {
+	if p.readDB != nil {
+		return p.readDB
+	}
+	return p.DB
+}
@@ -0,0 +1,79 @@
+package data
+
+import (
+	"fmt"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type replicatedNote struct {
+	ID    uint `gorm:"primaryKey"`
+	Title string
+}
+
+func openReplicaTestDB(t *testing.T, name string) *gorm.DB {
+	t.Helper()
+
+	dsn := fmt.Sprintf("file:%s_%s?mode=memory&cache=shared", t.Name(), name)
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect sqlite in-memory db: %v", err)
+	}
+	if err := db.AutoMigrate(&replicatedNote{}); err != nil {
+		t.Fatalf("failed to migrate table: %v", err)
+	}
+	return db
+}
+
+func TestGormDataProvider_ReadReplica(t *testing.T) {
+	primary := openReplicaTestDB(t, "primary")
+	replica := openReplicaTestDB(t, "replica")
+	if err := replica.Create(&replicatedNote{ID: 7, Title: "from replica"}).Error; err != nil {
+		t.Fatalf("failed to seed replica: %v", err)
+	}
+
+	provider := NewGormDataProvider(primary, &replicatedNote{})
+	provider.SetReadDB(replica)
+
+	created, err := provider.Create(nil, map[string]interface{}{"title": "written"})
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	var count int64
+	primary.Model(&replicatedNote{}).Where("title = ?", "written").Count(&count)
+	if count != 1 {
+		t.Fatalf("expected create to hit the primary, got %d rows", count)
+	}
+	replica.Model(&replicatedNote{}).Where("title = ?", "written").Count(&count)
+	if count != 0 {
+		t.Fatalf("expected replica to be untouched by writes, got %d rows", count)
+	}
+
+	resp, err := provider.Index(nil, QueryRequest{Page: 1, PerPage: 10, Search: "replica"})
+	if err != nil {
+		t.Fatalf("index failed: %v", err)
+	}
+	if resp.Total != 1 || len(resp.Items) != 1 || resp.Items[0].(*replicatedNote).Title != "from replica" {
+		t.Fatalf("expected index to read from replica, got %+v", resp)
+	}
+
+	shown, err := provider.Show(nil, "7")
+	if err != nil {
+		t.Fatalf("show failed: %v", err)
+	}
+	if shown.(*replicatedNote).Title != "from replica" {
+		t.Fatalf("expected show to read from replica, got %+v", shown)
+	}
+
+	createdID := fmt.Sprint(created.(*replicatedNote).ID)
+	if _, err := provider.Update(nil, createdID, map[string]interface{}{"title": "updated"}); err != nil {
+		t.Fatalf("expected update to resolve the row on the primary: %v", err)
+	}
+
+	provider.SetReadDB(nil)
+	if _, err := provider.Show(nil, createdID); err != nil {
+		t.Fatalf("expected show to fall back to primary: %v", err)
+	}
+}
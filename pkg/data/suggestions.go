@@ -0,0 +1,153 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ferdiunal/panel.go/pkg/context"
+	"github.com/ferdiunal/panel.go/pkg/fields"
+	"github.com/iancoleman/strcase"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// ErrSuggestionColumn, öneri istenen kolon modelde yoksa veya metin kolonu
+// değilse döner.
+var ErrSuggestionColumn = errors.New("column does not support suggestions")
+
+// likeEscaper, LIKE desenindeki joker karakterleri ESCAPE '!' ile etkisizleştirir.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// SuggestValues, kolonda önekle başlayan (büyük/küçük harf duyarsız) farklı
+// değerleri alfabetik sırayla döner. BaseQuery uygulanır ve sorgu read replica
+// üzerinden çalışır. Şifreli kolonlar karşılaştırılamadığı için reddedilir.
+func (p *GormDataProvider) SuggestValues(ctx *context.Context, column string, prefix string, limit int) ([]string, error) {
+	stmt := &gorm.Statement{DB: p.DB}
+	if err := stmt.Parse(p.Model); err != nil || stmt.Schema == nil {
+		return nil, fmt.Errorf("%w: %s", ErrSuggestionColumn, column)
+	}
+	field := stmt.Schema.LookUpField(column)
+	if field == nil || field.DBName == "" || field.DataType != schema.String {
+		return nil, fmt.Errorf("%w: %s", ErrSuggestionColumn, column)
+	}
+	if p.isEncryptedSchemaField(column, field) {
+		return nil, &EncryptedColumnQueryError{Column: column, Operation: "search"}
+	}
+
+	db := p.reader().WithContext(p.getContext(ctx)).Model(p.Model)
+	if p.BaseQuery != nil {
+		db = p.BaseQuery(db)
+	}
+
+	col := clause.Column{Name: field.DBName}
+	pattern := likeEscaper.Replace(strings.ToLower(prefix)) + "%"
+	db = db.Distinct(field.DBName).
+		Where(clause.Expr{SQL: "? IS NOT NULL AND ? <> ''", Vars: []interface{}{col, col}}).
+		Where(clause.Expr{SQL: "LOWER(?) LIKE ? ESCAPE '!'", Vars: []interface{}{col, pattern}}).
+		Order(clause.OrderByColumn{Column: col})
+	if limit > 0 {
+		db = db.Limit(limit)
+	}
+
+	values := []string{}
+	if err := db.Pluck(field.DBName, &values).Error; err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// maxTagSuggestionScan, JSON etiket kolonundan öneri üretirken taranacak en
+// fazla kayıt sayısıdır.
+const maxTagSuggestionScan = 500
+
+// SuggestTags, önekle başlayan (büyük/küçük harf duyarsız) mevcut etiketleri
+// alfabetik sırayla döner. field bir many-to-many ilişkisiyse
+// ilişkili tablonun relationColumn sütunu sorgulanır; aksi halde JSON etiket
+// kolonunda öneki içeren kayıtlar (BaseQuery uygulanarak) taranır ve etiketler
+// ayrıştırılarak eşleşenler toplanır.
+func (p *GormDataProvider) SuggestTags(ctx *context.Context, field string, relationColumn string, prefix string, limit int) ([]string, error) {
+	stmt := &gorm.Statement{DB: p.DB}
+	if err := stmt.Parse(p.Model); err != nil || stmt.Schema == nil {
+		return nil, fmt.Errorf("%w: %s", ErrSuggestionColumn, field)
+	}
+	pattern := likeEscaper.Replace(strings.ToLower(prefix))
+
+	if rel := tagRelationship(stmt.Schema, field); rel != nil {
+		column := rel.FieldSchema.LookUpField(relationColumn)
+		if column == nil || column.DBName == "" || column.DataType != schema.String {
+			return nil, fmt.Errorf("%w: %s", ErrSuggestionColumn, field)
+		}
+		col := clause.Column{Name: column.DBName}
+		db := p.reader().WithContext(p.getContext(ctx)).Table(rel.FieldSchema.Table).
+			Distinct(column.DBName).
+			Where(clause.Expr{SQL: "LOWER(?) LIKE ? ESCAPE '!'", Vars: []interface{}{col, pattern + "%"}}).
+			Order(clause.OrderByColumn{Column: col})
+		if limit > 0 {
+			db = db.Limit(limit)
+		}
+		values := []string{}
+		if err := db.Pluck(column.DBName, &values).Error; err != nil {
+			return nil, err
+		}
+		return values, nil
+	}
+
+	column := stmt.Schema.LookUpField(field)
+	if column == nil || column.DBName == "" {
+		return nil, fmt.Errorf("%w: %s", ErrSuggestionColumn, field)
+	}
+	if p.isEncryptedSchemaField(field, column) {
+		return nil, &EncryptedColumnQueryError{Column: field, Operation: "search"}
+	}
+
+	db := p.reader().WithContext(p.getContext(ctx)).Model(p.Model)
+	if p.BaseQuery != nil {
+		db = p.BaseQuery(db)
+	}
+	col := clause.Column{Name: column.DBName}
+	var rows []string
+	if err := db.Where(clause.Expr{SQL: "LOWER(?) LIKE ? ESCAPE '!'", Vars: []interface{}{col, "%" + pattern + "%"}}).
+		Limit(maxTagSuggestionScan).
+		Pluck(column.DBName, &rows).Error; err != nil {
+		return nil, err
+	}
+
+	lowered := strings.ToLower(prefix)
+	seen := make(map[string]struct{})
+	values := []string{}
+	for _, row := range rows {
+		tags, err := fields.ParseTagList(row)
+		if err != nil {
+			continue
+		}
+		for _, tag := range tags {
+			folded := strings.ToLower(tag)
+			if _, ok := seen[folded]; ok || !strings.HasPrefix(folded, lowered) {
+				continue
+			}
+			seen[folded] = struct{}{}
+			values = append(values, tag)
+		}
+	}
+	sort.Slice(values, func(i, j int) bool { return strings.ToLower(values[i]) < strings.ToLower(values[j]) })
+	if limit > 0 && len(values) > limit {
+		values = values[:limit]
+	}
+	return values, nil
+}
+
+// tagRelationship, alan adıyla eşleşen many-to-many ilişkisini döner.
+func tagRelationship(modelSchema *schema.Schema, field string) *schema.Relationship {
+	for name, rel := range modelSchema.Relationships.Relations {
+		if name != field && strcase.ToSnake(name) != strcase.ToSnake(field) {
+			continue
+		}
+		if rel.Type == schema.Many2Many {
+			return rel
+		}
+	}
+	return nil
+}
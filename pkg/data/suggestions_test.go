@@ -0,0 +1,71 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type suggestedCity struct {
+	ID         uint `gorm:"primaryKey"`
+	Name       string
+	Population int
+	Archived   bool
+}
+
+func TestGormDataProvider_SuggestValues(t *testing.T) {
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect sqlite in-memory db: %v", err)
+	}
+	if err := db.AutoMigrate(&suggestedCity{}); err != nil {
+		t.Fatalf("failed to migrate table: %v", err)
+	}
+	for _, name := range []string{"Istanbul", "izmir", "Izmir", "Ankara", "Iz_dere", "Izmit", "Isparta", ""} {
+		db.Create(&suggestedCity{Name: name, Archived: name == "Izmit"})
+	}
+
+	provider := NewGormDataProvider(db, &suggestedCity{})
+	values, err := provider.SuggestValues(nil, "name", "iz", 10)
+	if err != nil {
+		t.Fatalf("suggest failed: %v", err)
+	}
+	if len(values) != 4 || values[0] != "Iz_dere" || values[3] != "izmir" {
+		t.Fatalf("expected distinct case-insensitive prefix matches, got %v", values)
+	}
+
+	values, _ = provider.SuggestValues(nil, "name", "iz_", 10)
+	if len(values) != 1 || values[0] != "Iz_dere" {
+		t.Fatalf("expected wildcard characters to match literally, got %v", values)
+	}
+
+	values, _ = provider.SuggestValues(nil, "name", "i", 2)
+	if len(values) != 2 {
+		t.Fatalf("expected limit to apply, got %v", values)
+	}
+
+	provider.BaseQuery = func(db *gorm.DB) *gorm.DB { return db.Where("archived = ?", false) }
+	values, _ = provider.SuggestValues(nil, "name", "izmi", 10)
+	if len(values) != 2 {
+		t.Fatalf("expected base query to hide archived rows, got %v", values)
+	}
+
+	if _, err := provider.SuggestValues(nil, "population", "1", 10); !errors.Is(err, ErrSuggestionColumn) {
+		t.Fatalf("expected non-text column to be rejected, got %v", err)
+	}
+	if _, err := provider.SuggestValues(nil, "name; DROP TABLE", "", 10); !errors.Is(err, ErrSuggestionColumn) {
+		t.Fatalf("expected unknown column to be rejected, got %v", err)
+	}
+}
+
+func TestGormDataProvider_SuggestValues_RejectsEncryptedColumns(t *testing.T) {
+	provider, _ := newEncryptedCustomerProvider(t)
+
+	if _, err := provider.SuggestValues(nil, "national_id", "12", 10); !errors.Is(err, ErrEncryptedColumnQuery) {
+		t.Fatalf("expected encrypted column error, got %v", err)
+	}
+}
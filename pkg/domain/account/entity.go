@@ -109,6 +109,11 @@ type Account struct {
 	// Uyarı: Asla log dosyalarına yazılmamalıdır.
 	Password string `json:"-"`
 
+	// PasswordChangedAt: Şifrenin son değiştirilme zamanı (sadece email/şifre sağlayıcısı için)
+	// Şifre politikasındaki azami yaş (MaxAge) bu alana göre hesaplanır.
+	// Boşsa (özellik öncesi oluşturulmuş hesaplar) CreatedAt kullanılır.
+	PasswordChangedAt *time.Time `json:"passwordChangedAt,omitempty"`
+
 	// Scope: OAuth2 sağlayıcısından istenen izinler (scope)
 	// Boşlukla ayrılmış izin listesi.
 	// Örnek: "email profile openid"
@@ -0,0 +1,29 @@
+package account
+
+import (
+	"context"
+	"time"
+)
+
+// PasswordHistory, bir kullanıcının daha önce kullandığı şifrelerin bcrypt hash'lerini saklar.
+// Şifre politikası, yeni şifrenin son N şifreden biri olmadığını bu kayıtlarla kontrol eder.
+// Hash'ler asla JSON yanıtında döndürülmez.
+type PasswordHistory struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	UserID    uint      `json:"user_id" gorm:"index"`
+	Hash      string    `json:"-"`
+	CreatedAt time.Time `json:"created_at" gorm:"index"`
+}
+
+// PasswordHistoryRepository, şifre geçmişi kayıtlarını yönetir.
+type PasswordHistoryRepository interface {
+	// Recent, kullanıcının en yeni limit adet şifre hash'ini yeniden eskiye sıralı döndürür.
+	Recent(ctx context.Context, userID uint, limit int) ([]PasswordHistory, error)
+
+	// Add, yeni bir hash ekler ve kullanıcının en yeni keep kaydı dışındaki geçmişini siler.
+	// keep sıfır veya negatifse eski kayıtlar silinmez.
+	Add(ctx context.Context, entry *PasswordHistory, keep int) error
+
+	// DeleteByUserID, kullanıcının tüm şifre geçmişini siler.
+	DeleteByUserID(ctx context.Context, userID uint) error
+}
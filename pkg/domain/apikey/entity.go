@@ -4,11 +4,18 @@ import "time"
 
 // APIKey stores a managed API key record.
 // Raw token is never persisted; only sha256 hash is stored.
+//
+// Scopes, Resources, AllowedIPs and RateLimit narrow what the key may do.
+// Keys created before scopes existed have no scopes and keep full access.
 type APIKey struct {
 	ID              uint       `json:"id" gorm:"primaryKey"`
 	Name            string     `json:"name" gorm:"index"`
 	Prefix          string     `json:"prefix" gorm:"index"`
 	KeyHash         string     `json:"-" gorm:"uniqueIndex;size:64"`
+	Scopes          []string   `json:"scopes" gorm:"serializer:json;type:text"`
+	Resources       []string   `json:"resources,omitempty" gorm:"serializer:json;type:text"`
+	AllowedIPs      []string   `json:"allowed_ips,omitempty" gorm:"serializer:json;type:text"`
+	RateLimit       int        `json:"rate_limit"` // requests per minute, 0 = unlimited
 	CreatedByUserID *uint      `json:"created_by_user_id,omitempty" gorm:"index"`
 	LastUsedAt      *time.Time `json:"last_used_at,omitempty" gorm:"index"`
 	ExpiresAt       *time.Time `json:"expires_at,omitempty" gorm:"index"`
@@ -30,3 +37,14 @@ func (k *APIKey) IsActive(now time.Time) bool {
 	return true
 }
 
+// EffectiveScopes returns the scopes enforced for the key.
+// Legacy keys without scopes are treated as "*".
+func (k *APIKey) EffectiveScopes() []string {
+	if k == nil {
+		return nil
+	}
+	if len(k.Scopes) == 0 {
+		return []string{ScopeAll}
+	}
+	return k.Scopes
+}
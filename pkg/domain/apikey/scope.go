@@ -0,0 +1,126 @@
+package apikey
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// Scope'larda kullanılan erişim seviyeleri. Yazma erişimi okuma erişimini de kapsar.
+const (
+	AccessRead  = "read"
+	AccessWrite = "write"
+
+	// ScopeAll, tüm kaynaklarda tüm erişim seviyelerini verir.
+	ScopeAll = "*"
+)
+
+// Scope, bir kaynağa erişim veren scope'u döner, örn. "products:read".
+func Scope(resource, access string) string {
+	return resource + ":" + access
+}
+
+// ParseScopes, scope'ları doğrular ve normalize eder.
+//
+// Kabul edilen biçimler "*", "<resource>:read", "<resource>:write", "<resource>:*",
+// "*:read" ve "*:write"'dır. Tekrarlar atılır, giriş sırası korunur.
+func ParseScopes(raw []string) ([]string, error) {
+	scopes := make([]string, 0, len(raw))
+	seen := make(map[string]struct{}, len(raw))
+	for _, scope := range raw {
+		scope = strings.TrimSpace(scope)
+		if scope == "" {
+			continue
+		}
+		if scope != ScopeAll {
+			resource, access, ok := strings.Cut(scope, ":")
+			if !ok || !isValidScopeResource(resource) {
+				return nil, fmt.Errorf("invalid scope %q", scope)
+			}
+			if access != AccessRead && access != AccessWrite && access != ScopeAll {
+				return nil, fmt.Errorf("invalid scope %q: access must be read, write or *", scope)
+			}
+		}
+		if _, ok := seen[scope]; ok {
+			continue
+		}
+		seen[scope] = struct{}{}
+		scopes = append(scopes, scope)
+	}
+	return scopes, nil
+}
+
+// HasScope, scope'ların kaynağa istenen erişimi verip vermediğini döner.
+func HasScope(scopes []string, resource, access string) bool {
+	for _, scope := range scopes {
+		if scope == ScopeAll {
+			return true
+		}
+		scopeResource, scopeAccess, ok := strings.Cut(scope, ":")
+		if !ok || (scopeResource != ScopeAll && scopeResource != resource) {
+			continue
+		}
+		if scopeAccess == ScopeAll || scopeAccess == access || (scopeAccess == AccessWrite && access == AccessRead) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseAllowedIPs, IP izin listesini doğrular. Girdiler tekil adres veya CIDR aralığı olabilir.
+func ParseAllowedIPs(raw []string) ([]string, error) {
+	entries := make([]string, 0, len(raw))
+	for _, entry := range raw {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if strings.Contains(entry, "/") {
+			if _, _, err := net.ParseCIDR(entry); err != nil {
+				return nil, fmt.Errorf("invalid CIDR %q", entry)
+			}
+		} else if net.ParseIP(entry) == nil {
+			return nil, fmt.Errorf("invalid IP address %q", entry)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// MatchIP, ip'nin izin listesinde olup olmadığını döner. Boş liste her adrese izin verir.
+func MatchIP(allowed []string, ip string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	addr := net.ParseIP(strings.TrimSpace(ip))
+	if addr == nil {
+		return false
+	}
+	for _, entry := range allowed {
+		if strings.Contains(entry, "/") {
+			if _, network, err := net.ParseCIDR(entry); err == nil && network.Contains(addr) {
+				return true
+			}
+			continue
+		}
+		if allowedIP := net.ParseIP(entry); allowedIP != nil && allowedIP.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func isValidScopeResource(resource string) bool {
+	if resource == ScopeAll {
+		return true
+	}
+	if resource == "" {
+		return false
+	}
+	for _, r := range resource {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.') {
+			return false
+		}
+	}
+	return true
+}
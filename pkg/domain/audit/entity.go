@@ -0,0 +1,144 @@
+// Package audit, veritabanında tutulan denetim (audit) olayları için domain
+// katmanını sağlar. Olaylar ardışık hash zinciriyle birbirine bağlanır; bir
+// kaydın değiştirilmesi veya araya kayıt eklenip silinmesi zinciri bozar.
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// Event, audit_events tablosundaki tek bir denetim olayıdır.
+//
+// Hash, PrevHash ve olayın içeriğinden hesaplanır (ComputeHash). İlk kaydın
+// PrevHash değeri boştur. Metadata, hash'in veritabanı gidiş-dönüşünden
+// etkilenmemesi için ham JSON metni olarak saklanır.
+type Event struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	Timestamp    time.Time `json:"timestamp" gorm:"index"`
+	EventType    string    `json:"event_type" gorm:"size:64;index"`
+	UserID       string    `json:"user_id,omitempty" gorm:"size:64;index"`
+	Email        string    `json:"email,omitempty"`
+	IP           string    `json:"ip" gorm:"size:64"`
+	UserAgent    string    `json:"user_agent"`
+	Method       string    `json:"method" gorm:"size:16"`
+	Path         string    `json:"path"`
+	StatusCode   int       `json:"status_code"`
+	Success      bool      `json:"success"`
+	ErrorMessage string    `json:"error_message,omitempty"`
+	Resource     string    `json:"resource,omitempty" gorm:"size:128;index"`
+	Action       string    `json:"action,omitempty" gorm:"size:64"`
+	Metadata     string    `json:"metadata,omitempty" gorm:"type:text"`
+	PrevHash     string    `json:"prev_hash" gorm:"size:64"`
+	Hash         string    `json:"hash" gorm:"size:64;index"`
+}
+
+// TableName, audit olay tablosunun adını döndürür.
+func (Event) TableName() string {
+	return "audit_events"
+}
+
+// ChainHead, zincirin son hash'ini tutan tek satırlık tablodur.
+// Yazarlar bu satırı kilitleyerek sıraya girer; böylece aynı veritabanına yazan
+// birden fazla replika zinciri çatallamaz.
+type ChainHead struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Hash      string    `json:"hash" gorm:"size:64"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName, zincir başı tablosunun adını döndürür.
+func (ChainHead) TableName() string {
+	return "audit_chain_heads"
+}
+
+// hashPayload, hash'e giren alanların sabit sıralı gösterimidir.
+type hashPayload struct {
+	PrevHash     string `json:"prev_hash"`
+	Timestamp    string `json:"timestamp"`
+	EventType    string `json:"event_type"`
+	UserID       string `json:"user_id"`
+	Email        string `json:"email"`
+	IP           string `json:"ip"`
+	UserAgent    string `json:"user_agent"`
+	Method       string `json:"method"`
+	Path         string `json:"path"`
+	StatusCode   int    `json:"status_code"`
+	Success      bool   `json:"success"`
+	ErrorMessage string `json:"error_message"`
+	Resource     string `json:"resource"`
+	Action       string `json:"action"`
+	Metadata     string `json:"metadata"`
+}
+
+// NormalizeTimestamp, zaman damgasını UTC milisaniyeye indirir.
+// MySQL'in varsayılan datetime(3) hassasiyeti dahil tüm sürücüler bunu koruduğu
+// için hash veritabanından okunduktan sonra da aynı kalır.
+func NormalizeTimestamp(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Millisecond)
+}
+
+// ComputeHash, olayın PrevHash ve içeriğinden SHA-256 hash'ini hesaplar.
+func (e *Event) ComputeHash() string {
+	payload, _ := json.Marshal(hashPayload{
+		PrevHash:     e.PrevHash,
+		Timestamp:    NormalizeTimestamp(e.Timestamp).Format(time.RFC3339Nano),
+		EventType:    e.EventType,
+		UserID:       e.UserID,
+		Email:        e.Email,
+		IP:           e.IP,
+		UserAgent:    e.UserAgent,
+		Method:       e.Method,
+		Path:         e.Path,
+		StatusCode:   e.StatusCode,
+		Success:      e.Success,
+		ErrorMessage: e.ErrorMessage,
+		Resource:     e.Resource,
+		Action:       e.Action,
+		Metadata:     e.Metadata,
+	})
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
+// Seal, olayı prevHash'e bağlar ve hash'ini hesaplar.
+func (e *Event) Seal(prevHash string) {
+	e.Timestamp = NormalizeTimestamp(e.Timestamp)
+	e.PrevHash = prevHash
+	e.Hash = e.ComputeHash()
+}
+
+// ChainVerifier, ID sırasıyla verilen olayların zincirini adım adım doğrular.
+//
+// İlk olayın PrevHash değeri kontrol edilmez; saklama süresi dolan eski kayıtlar
+// silindiğinde zincir kalan ilk kayıttan itibaren doğrulanır.
+type ChainVerifier struct {
+	Checked  int64
+	BrokenID uint   // zincirin bozulduğu ilk olay, 0 = bozulma yok
+	Reason   string // "hash_mismatch" veya "chain_mismatch"
+
+	lastHash string
+	started  bool
+}
+
+// Add, sıradaki olayı doğrular; zincir bozulduysa false döner.
+func (v *ChainVerifier) Add(e Event) bool {
+	if v.BrokenID != 0 {
+		return false
+	}
+	v.Checked++
+
+	if e.ComputeHash() != e.Hash {
+		v.BrokenID, v.Reason = e.ID, "hash_mismatch"
+		return false
+	}
+	if v.started && e.PrevHash != v.lastHash {
+		v.BrokenID, v.Reason = e.ID, "chain_mismatch"
+		return false
+	}
+	v.lastHash = e.Hash
+	v.started = true
+	return true
+}
@@ -0,0 +1,21 @@
+// Package ratelimit, rate limiting ve hesap kilitleme sayaçlarının
+// veritabanında tutulması için domain katmanını sağlar.
+package ratelimit
+
+import "time"
+
+// Counter, süresi dolan tek bir sayacı temsil eder.
+//
+// Birden fazla panel replikası aynı tabloyu kullandığında rate limit ve
+// hesap kilitleme sayaçları replikalar arasında paylaşılır. Süresi dolan
+// kayıtlar okunurken yok sayılır ve periyodik olarak silinir.
+type Counter struct {
+	Key       string    `json:"key" gorm:"column:counter_key;primaryKey;size:191"`
+	Hits      int64     `json:"hits"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"index"`
+}
+
+// TableName, sayaç tablosunun adını döndürür.
+func (Counter) TableName() string {
+	return "rate_limit_counters"
+}
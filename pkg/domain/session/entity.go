@@ -31,11 +31,11 @@ type Session struct {
 	// Veritabanında otomatik olarak artan bir sayıdır
 	ID uint `json:"id" gorm:"primaryKey"`
 
-	// Token: Oturumun benzersiz token değeri
-	// Kimlik doğrulama işlemlerinde kullanılan güvenli bir string'dir
-	// Örnek: "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
-	// Uyarı: Bu alan benzersiz olmalıdır, aynı token iki oturumda olamaz
-	Token string `json:"token" gorm:"uniqueIndex;not null"`
+	// Token: Oturum token'ının sha256 hash'i (hex)
+	// İstemciye verilen düz token veritabanında saklanmaz; auth servisi sorguları hash ile yapar
+	// ve döndürdüğü oturumlarda bu alanı istemcinin token'ı ile doldurur
+	// Uyarı: Bu alan benzersiz olmalıdır ve JSON çıktısına hiçbir zaman dahil edilmez
+	Token string `json:"-" gorm:"uniqueIndex;not null"`
 
 	// UserID: Oturumun ait olduğu kullanıcının ID'si
 	// Foreign Key olarak user tablosuna referans verir
@@ -44,10 +44,16 @@ type Session struct {
 
 	// ExpiresAt: Oturumun geçerlilik süresi bitişi zamanı
 	// Bu zaman geçtikten sonra oturum otomatik olarak geçersiz sayılır
+	// Idle timeout etkinse her istekte ileri kaydırılır, ancak mutlak süreyi aşmaz
 	// Örnek: 2026-02-07 18:00:00 UTC
 	// Uyarı: Sistem saati ile karşılaştırılarak kontrol edilmelidir
 	ExpiresAt time.Time `json:"expiresAt" gorm:"index"`
 
+	// LastSeenAt: Oturumla yapılan son isteğin zamanı
+	// Yazma yükünü azaltmak için dakikada en fazla bir kez güncellenir
+	// Kullanım: "Oturumlarım" listesinde son görülme bilgisi ve idle timeout hesabı
+	LastSeenAt time.Time `json:"lastSeenAt"`
+
 	// IPAddress: Oturumun oluşturulduğu istemcinin IP adresi
 	// Güvenlik denetimi ve anomali tespiti için kaydedilir
 	// Örnek: "192.168.1.100" veya "2001:0db8:85a3::8a2e:0370:7334"
@@ -60,6 +66,11 @@ type Session struct {
 	// Kullanım: Cihaz değişikliği tespiti ve güvenlik denetimi için kullanılır
 	UserAgent string `json:"userAgent"`
 
+	// ImpersonatorID: Oturumu başka bir kullanıcı adına başlatan destek personelinin ID'si
+	// Normal oturumlarda nil'dir; dolu ise oturum "kimliğine bürünme" (impersonation) oturumudur
+	// Kullanım: /api/init banner bilgisi, audit kayıtları ve hassas işlemlerin engellenmesi
+	ImpersonatorID *uint `json:"impersonatorId,omitempty" gorm:"index"`
+
 	// CreatedAt: Oturumun oluşturulduğu zaman
 	// Veritabanı tarafından otomatik olarak ayarlanır
 	// Örnek: 2026-02-07 16:00:00 UTC
@@ -80,6 +91,11 @@ type Session struct {
 	User *user.User `json:"user,omitempty" gorm:"foreignKey:UserID"`
 }
 
+// IsImpersonated, oturumun başka bir kullanıcı adına başlatılıp başlatılmadığını bildirir.
+func (s *Session) IsImpersonated() bool {
+	return s != nil && s.ImpersonatorID != nil
+}
+
 // Bu interface, oturum verilerinin veritabanında yönetilmesini sağlayan
 // repository katmanının sözleşmesini tanımlar.
 // Tüm oturum işlemleri bu interface üzerinden yapılmalıdır.
@@ -230,4 +246,26 @@ type Repository interface {
 	//   - Güvenlik olayı (şifre değişikliği, hesap ele geçirilmesi vb.) durumunda kullanılır
 	//   - Kullanıcı hesabı silindiğinde de bu metod çağrılmalıdır
 	DeleteByUserID(ctx context.Context, userID uint) error
+
+	// Bu metod, kullanıcının tüm oturumlarını son görülme zamanına göre (yeniden eskiye) listeler.
+	// "Oturumlarım" ekranında cihaz/IP/User-Agent bilgilerini göstermek için kullanılır.
+	FindByUserID(ctx context.Context, userID uint) ([]Session, error)
+
+	// Bu metod, oturumun son görülme ve bitiş zamanlarını günceller (sliding expiry).
+	Touch(ctx context.Context, id uint, lastSeenAt, expiresAt time.Time) error
+
+	// Bu metod, keepID dışındaki tüm kullanıcı oturumlarını siler.
+	// keepID sıfırsa kullanıcının tüm oturumları silinir.
+	DeleteByUserIDExcept(ctx context.Context, userID, keepID uint) error
+
+	// Bu metod, bitiş zamanı before'dan önce olan oturumları siler ve silinen kayıt sayısını döndürür.
+	// Arka plan temizleyicisi tarafından periyodik olarak çağrılır.
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+
+	// Bu metod, token'ı sha256 hash'i (64 karakterlik hex) biçiminde olmayan oturumları döndürür.
+	// Token'ların düz metin saklandığı sürümden kalan kayıtları bulmak için kullanılır.
+	FindUnhashed(ctx context.Context) ([]Session, error)
+
+	// Bu metod, oturumun token değerini günceller.
+	UpdateToken(ctx context.Context, id uint, token string) error
 }
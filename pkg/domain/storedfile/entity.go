@@ -0,0 +1,41 @@
+// Package storedfile, dosya alanlarının disklere yazdığı dosyaların hangi
+// kayda ve alana ait olduğunu izleyen domain katmanını sağlar. Sahibi olmayan
+// (yetim) dosyalar bekleme süresi dolduğunda diskten silinir.
+package storedfile
+
+import "time"
+
+// File, stored_files tablosundaki tek bir disk dosyasıdır.
+//
+// Disk ve Path birlikte tekildir; aynı dosyayı son izleyen kayıt sahibi olur.
+// OrphanedAt, dosyanın kayıttan ayrıldığı (alan değiştirildi, temizlendi veya
+// kayıt silindi) zamandır; nil ise dosya hâlâ kullanılıyordur.
+type File struct {
+	ID         uint       `json:"id" gorm:"primaryKey"`
+	Disk       string     `json:"disk" gorm:"size:64;uniqueIndex:idx_stored_files_disk_path"`
+	Path       string     `json:"path" gorm:"size:512;uniqueIndex:idx_stored_files_disk_path"`
+	Resource   string     `json:"resource" gorm:"size:128;index:idx_stored_files_owner"`
+	RecordID   string     `json:"record_id" gorm:"size:64;index:idx_stored_files_owner"`
+	Field      string     `json:"field" gorm:"size:128;index:idx_stored_files_owner"`
+	OrphanedAt *time.Time `json:"orphaned_at,omitempty" gorm:"index"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// TableName, izlenen dosya tablosunun adını döndürür.
+func (File) TableName() string {
+	return "stored_files"
+}
+
+// Owner, bir dosyanın ait olduğu kaynak, kayıt ve alandır.
+type Owner struct {
+	Resource string
+	RecordID string
+	Field    string
+}
+
+// Ref, bir diskteki dosyanın yoludur.
+type Ref struct {
+	Disk string
+	Path string
+}
@@ -0,0 +1,93 @@
+// Package twofactor, TOTP tabanlı iki faktörlü kimlik doğrulama kayıtlarını tanımlar.
+package twofactor
+
+import (
+	"context"
+	"time"
+)
+
+// Bu yapı, bir kullanıcının TOTP kaydını temsil eder.
+//
+// Önemli Notlar:
+// - Secret, FieldEncryption anahtarlarıyla şifrelenerek saklanır ve JSON çıktısına dahil edilmez
+// - Kayıt, geçerli bir kodla ConfirmedAt doldurulana kadar beklemede (pending) kabul edilir
+// - LastUsedStep, aynı TOTP kodunun tekrar kullanılmasını (replay) engeller
+type TwoFactor struct {
+	// ID: Kaydın benzersiz tanımlayıcısı (Primary Key)
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	// UserID: Kaydın ait olduğu kullanıcının ID'si (kullanıcı başına tek kayıt)
+	UserID uint `json:"user_id" gorm:"uniqueIndex"`
+
+	// Secret: Şifrelenmiş TOTP secret'ı
+	Secret string `json:"-"`
+
+	// LastUsedStep: Son kabul edilen TOTP adımı
+	LastUsedStep int64 `json:"-"`
+
+	// ConfirmedAt: Kaydın onaylandığı zaman; nil ise kayıt beklemededir
+	ConfirmedAt *time.Time `json:"confirmed_at,omitempty"`
+
+	// CreatedAt: Kaydın oluşturulduğu zaman
+	CreatedAt time.Time `json:"created_at"`
+
+	// UpdatedAt: Kaydın son güncellendiği zaman
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Bu metod, TOTP kaydının onaylanıp onaylanmadığını döner.
+func (t *TwoFactor) IsEnabled() bool {
+	return t != nil && t.ConfirmedAt != nil
+}
+
+// Bu yapı, tek kullanımlık bir kurtarma kodunu temsil eder.
+//
+// Önemli Notlar:
+// - Düz kodlar kullanıcıya yalnızca bir kez gösterilir; veritabanında sha256 hash'i saklanır
+// - UsedAt dolu olan kodlar tekrar kullanılamaz
+type RecoveryCode struct {
+	// ID: Kodun benzersiz tanımlayıcısı (Primary Key)
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	// UserID: Kodun ait olduğu kullanıcının ID'si
+	UserID uint `json:"user_id" gorm:"index"`
+
+	// CodeHash: Kodun sha256 hash'i (hex)
+	CodeHash string `json:"-" gorm:"uniqueIndex;size:64"`
+
+	// UsedAt: Kodun kullanıldığı zaman; nil ise kod kullanılabilir
+	UsedAt *time.Time `json:"used_at,omitempty"`
+
+	// CreatedAt: Kodun oluşturulduğu zaman
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Bu metod, kurtarma kodlarının tablo adını two_factors tablosunun yanında tutar.
+func (RecoveryCode) TableName() string {
+	return "two_factor_recovery_codes"
+}
+
+// Bu arayüz, TOTP kayıtlarının ve kurtarma kodlarının kalıcı saklanmasını tanımlar.
+type Repository interface {
+	// FindByUserID, kullanıcının TOTP kaydını (beklemede veya onaylı) döner.
+	FindByUserID(ctx context.Context, userID uint) (*TwoFactor, error)
+
+	// Save, TOTP kaydını oluşturur veya günceller.
+	Save(ctx context.Context, tf *TwoFactor) error
+
+	// DeleteByUserID, kullanıcının TOTP kaydını ve tüm kurtarma kodlarını siler.
+	DeleteByUserID(ctx context.Context, userID uint) error
+
+	// MarkStepUsed, step'i son kabul edilen TOTP adımı olarak kaydeder.
+	// Adım daha önce kullanıldıysa (replay) false döner.
+	MarkStepUsed(ctx context.Context, id uint, step int64) (bool, error)
+
+	// ReplaceRecoveryCodes, mevcut kurtarma kodlarını silip verilen hash'leri kaydeder.
+	ReplaceRecoveryCodes(ctx context.Context, userID uint, hashes []string) error
+
+	// UseRecoveryCode, kullanılmamış kodu kullanıldı olarak işaretler. Eşleşen kod yoksa false döner.
+	UseRecoveryCode(ctx context.Context, userID uint, hash string) (bool, error)
+
+	// CountUnusedRecoveryCodes, kullanılabilir kurtarma kodu sayısını döner.
+	CountUnusedRecoveryCodes(ctx context.Context, userID uint) (int64, error)
+}
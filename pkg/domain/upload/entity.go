@@ -0,0 +1,36 @@
+// Package upload, büyük dosyaların parça parça (tus protokolü) yüklenmesi için
+// devam ettirilebilir yükleme oturumlarını tutan domain katmanını sağlar.
+// Tamamlanan yüklemeler form gönderiminde dosya yerine ID'leriyle kullanılır.
+package upload
+
+import "time"
+
+// Upload, resumable_uploads tablosundaki tek bir devam ettirilebilir yüklemedir.
+//
+// İçerik sunucudaki geçici klasörde ID adlı dosyada biriktirilir; Offset o ana
+// kadar yazılan bayt sayısıdır. Offset Length'e ulaştığında CompletedAt dolar.
+// ExpiresAt her parçada ileri alınır; süresi dolan yüklemeler silinir.
+type Upload struct {
+	ID          string     `json:"id" gorm:"primaryKey;size:32"`
+	UserID      uint       `json:"user_id" gorm:"index"`
+	Resource    string     `json:"resource" gorm:"size:128"`
+	Field       string     `json:"field" gorm:"size:128"`
+	Filename    string     `json:"filename" gorm:"size:255"`
+	ContentType string     `json:"content_type" gorm:"size:255"`
+	Length      int64      `json:"length" gorm:"column:upload_length"`
+	Offset      int64      `json:"offset" gorm:"column:upload_offset"`
+	ExpiresAt   time.Time  `json:"expires_at" gorm:"index"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// TableName, devam ettirilebilir yükleme tablosunun adını döndürür.
+func (Upload) TableName() string {
+	return "resumable_uploads"
+}
+
+// Completed, tüm içeriğin alınıp alınmadığını döner.
+func (u *Upload) Completed() bool {
+	return u.CompletedAt != nil
+}
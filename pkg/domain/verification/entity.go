@@ -77,6 +77,11 @@ type Verification struct {
 	// Örnek: 2024-01-15 14:30:00 UTC
 	ExpiresAt time.Time `json:"expiresAt" gorm:"index"`
 
+	// Attempts: Token'a karşı yapılan başarısız doğrulama denemesi sayısı
+	// İki faktörlü giriş challenge'ı gibi tahmin edilebilir kodlarla kullanılan
+	// kayıtların, eşik aşıldığında silinmesi için tutulur
+	Attempts int `json:"attempts" gorm:"not null;default:0"`
+
 	// CreatedAt: Doğrulama kaydının oluşturulduğu zaman
 	// İndekslenmiş: Zaman bazlı sorgular için
 	// GORM tarafından otomatik olarak ayarlanır
@@ -256,4 +261,42 @@ type Repository interface {
 	//       log.Println("Tüm doğrulama kayıtları silindi")
 	//   }
 	DeleteByIdentifier(ctx context.Context, identifier string) error
+
+	// Bu metod, verilen identifier'a ait en son oluşturulan doğrulama kaydını bulur.
+	//
+	// Parametreler:
+	// - ctx: İşlem bağlamı (zaman aşımı ve iptal için)
+	// - identifier: Aranacak kayıtların identifier'ı
+	//
+	// Dönüş Değeri:
+	// - *Verification: En yeni doğrulama kaydı pointer'ı
+	// - error: Kayıt yoksa gorm.ErrRecordNotFound, aksi takdirde veritabanı hatası
+	//
+	// Önemli Notlar:
+	// - Süresi geçmiş kayıtlar da döndürülür; ExpiresAt çağıran tarafından kontrol edilmelidir
+	// - Yeniden gönderim sıklığını sınırlamak (throttling) için CreatedAt alanı kullanılabilir
+	//
+	// Kullanım Örneği:
+	//   latest, err := repo.FindLatestByIdentifier(ctx, "email_verification:42")
+	//   if err == nil && time.Since(latest.CreatedAt) < time.Minute {
+	//       return ErrTooManyRequests
+	//   }
+	FindLatestByIdentifier(ctx context.Context, identifier string) (*Verification, error)
+
+	// Bu metod, kaydın başarısız deneme sayısını atomik olarak bir artırır.
+	//
+	// Parametreler:
+	// - ctx: İşlem bağlamı (zaman aşımı ve iptal için)
+	// - id: Doğrulama kaydının birincil anahtarı
+	//
+	// Dönüş Değeri:
+	// - int: Artırılmış deneme sayısı
+	// - error: Kayıt yoksa gorm.ErrRecordNotFound, aksi takdirde veritabanı hatası
+	//
+	// Kullanım Örneği:
+	//   attempts, err := repo.IncrementAttempts(ctx, record.ID)
+	//   if err == nil && attempts >= 5 {
+	//       _ = repo.Delete(ctx, record.ID)
+	//   }
+	IncrementAttempts(ctx context.Context, id uint) (int, error)
 }
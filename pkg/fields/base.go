@@ -1,11 +1,13 @@
 package fields
 
 import (
+	"fmt"
 	"reflect"
 	"strings"
 
 	"github.com/ferdiunal/panel.go/pkg/core"
 	"github.com/ferdiunal/panel.go/pkg/i18n"
+	"github.com/ferdiunal/panel.go/pkg/storage"
 	"github.com/gofiber/fiber/v2"
 
 	"github.com/iancoleman/strcase"
@@ -75,6 +77,8 @@ type Schema struct {
 	IsFilterable       bool                                                                `json:"filterable"`
 	IsSortable         bool                                                                `json:"sortable"`
 	GlobalSearch       bool                                                                `json:"searchable"`
+	EncryptAtRest      bool                                                                `json:"encrypted"`
+	SensitiveData      bool                                                                `json:"sensitive"`
 	IsStacked          bool                                                                `json:"stacked"`
 	TextAlign          string                                                              `json:"text_align"`
 	Suggestions        []interface{}                                                       `json:"suggestions"`
@@ -107,6 +111,8 @@ type Schema struct {
 	SuggestionsCallback       func(string) []interface{} `json:"-"`
 	AutoCompleteURL           string                     `json:"autocomplete_url"`
 	MinCharsForSuggestionsVal int                        `json:"min_chars_for_suggestions"`
+	SuggestionsFromColumn     bool                       `json:"-"`
+	SuggestionsDebounceMs     int                        `json:"-"`
 
 	// Attachments (Kategori 5)
 	AcceptedMimeTypes  []string                             `json:"accepted_mime_types"`
@@ -356,7 +362,7 @@ func (s *Schema) JsonSerialize() map[string]interface{} {
 		}
 	}
 
-	return map[string]interface{}{
+	serialized := map[string]interface{}{
 		"view":             view,
 		"type":             s.Type,
 		"key":              s.Key,
@@ -378,6 +384,30 @@ func (s *Schema) JsonSerialize() map[string]interface{} {
 		"stacked":          s.IsStacked,
 		"text_align":       s.TextAlign,
 	}
+
+	// Öneri ayarları arayüzün debounce'lu autocomplete isteği yapabilmesi için gönderilir
+	if s.HasSuggestions() || s.AutoCompleteURL != "" {
+		serialized["autocomplete"] = map[string]interface{}{
+			"url":       s.AutoCompleteURL,
+			"min_chars": s.MinCharsForSuggestionsVal,
+			"debounce":  s.GetSuggestionsDebounce(),
+		}
+	}
+
+	// Repeater alt alanları satır formunu oluşturmak için birlikte gönderilir
+	if len(s.RepeaterFields) > 0 {
+		nested := make([]map[string]interface{}, 0, len(s.RepeaterFields))
+		for _, field := range s.RepeaterFields {
+			if field != nil {
+				nested = append(nested, field.JsonSerialize())
+			}
+		}
+		serialized["fields"] = nested
+		serialized["min_repeats"] = s.MinRepeatsCount
+		serialized["max_repeats"] = s.MaxRepeatsCount
+	}
+
+	return serialized
 }
 
 // Fluent Setters - Zincirleme Metod Çağrıları
@@ -1092,6 +1122,57 @@ func (s *Schema) IsSearchable() bool {
 	return s.GlobalSearch
 }
 
+// Encrypted, alanın değerinin veritabanında şifreli saklanacağını belirtir.
+//
+// Şifreli alanlar GormDataProvider tarafından yazılmadan önce AES-GCM ile
+// şifrelenir ve okunurken çözülür. Liste görünümlerinde değer maskelenir.
+// Şifreli kolonlarda filtreleme/sıralama yapılamadığı için bu metod
+// Filterable, Sortable ve Searchable işaretlerini kaldırır.
+//
+// # Döndürür
+//
+//   - Element: Zincirleme çağrılar için Schema pointer'ı
+//
+// # Önemli Notlar
+//
+//   - Panel Config.FieldEncryption ile anahtar tanımlanmalıdır
+//   - Kolon tipi string/text olmalıdır (şifreli değer düz metinden uzundur)
+//
+// # Örnek
+//
+//	field := Text("TC Kimlik No", "national_id").Encrypted()
+func (s *Schema) Encrypted() Element {
+	s.EncryptAtRest = true
+	s.IsFilterable = false
+	s.IsSortable = false
+	s.GlobalSearch = false
+	return s
+}
+
+// IsEncrypted, alanın değerinin şifreli saklanıp saklanmadığını kontrol eder.
+func (s *Schema) IsEncrypted() bool {
+	return s.EncryptAtRest
+}
+
+// Sensitive, alanın değerinin hassas olduğunu belirtir.
+//
+// Hassas alanların değerleri veri değişikliği audit kayıtlarında maskelenir;
+// kayıtta yalnızca alanın değiştiği görünür. Password alanları ve Encrypted()
+// ile işaretlenen alanlar ayrıca işaretlenmeden hassas kabul edilir.
+//
+// # Örnek
+//
+//	field := Text("IBAN", "iban").Sensitive()
+func (s *Schema) Sensitive() Element {
+	s.SensitiveData = true
+	return s
+}
+
+// IsSensitive, alanın değerinin audit kayıtlarında maskelenip maskelenmeyeceğini kontrol eder.
+func (s *Schema) IsSensitive() bool {
+	return s.SensitiveData || s.EncryptAtRest || s.Type == TYPE_PASSWORD
+}
+
 // Stacked, alanın tam genişlikte görüntüleneceğini belirtir.
 //
 // Stacked alanlar, formda kendi satırını kaplar (100% genişlik).
@@ -2602,6 +2683,11 @@ func (s *Schema) WithSuggestions(fn func(string) []interface{}) core.Element {
 //   - Query parameter: ?q=arama_metni
 //   - Response: JSON array [{value, label}, ...]
 //
+// Metin alanlarında (Text, Textarea, Email, Tel) callback veya sabit öneri
+// listesi tanımlı değilse panelin öneri endpoint'i
+// (GET /api/resource/:resource/fields/:field/suggestions?q=) kolondaki mevcut
+// değerlerden önekle eşleşenleri döner; WithColumnSuggestions çağırmak gerekmez.
+//
 // # Kullanım Senaryoları
 //
 //   - Büyük veri setlerinde arama
@@ -2647,6 +2733,63 @@ func (s *Schema) MinCharsForSuggestions(min int) core.Element {
 	return s
 }
 
+// WithColumnSuggestions, önerilerin kaynağın kendi kolonundaki mevcut
+// değerlerden üretilmesini sağlar.
+//
+// Öneri endpoint'i (GET /api/resource/:resource/fields/:field/suggestions?q=)
+// callback tanımlı değilse kolonda önekle başlayan farklı değerleri döner.
+// Yalnızca metin kolonlarında ve şifrelenmemiş alanlarda kullanılabilir.
+//
+// # Örnek
+//
+//	field := Text("Şehir", "city")
+//	field.MinCharsForSuggestions(2)
+//	field.WithColumnSuggestions()
+func (s *Schema) WithColumnSuggestions() core.Element {
+	s.SuggestionsFromColumn = true
+	return s
+}
+
+// SuggestionsDebounce, arayüzün öneri isteklerini kaç milisaniye bekleterek
+// göndereceğini belirler. Varsayılan değer 300 ms'dir.
+func (s *Schema) SuggestionsDebounce(ms int) core.Element {
+	s.SuggestionsDebounceMs = ms
+	return s
+}
+
+// HasSuggestions, alanın öneri endpoint'i tarafından yanıtlanıp
+// yanıtlanamayacağını döner: callback, sabit öneri listesi veya kolon
+// önerileri tanımlı olmalıdır.
+func (s *Schema) HasSuggestions() bool {
+	return s.SuggestionsCallback != nil || len(s.Suggestions) > 0 || s.UsesColumnSuggestions()
+}
+
+// UsesColumnSuggestions, callback ve sabit liste yokken önerilerin kolondaki
+// mevcut değerlerden üretilip üretilmeyeceğini döner. WithColumnSuggestions ile
+// açılmış alanlarda ve WithAutoComplete tanımlı metin alanlarında true'dur.
+func (s *Schema) UsesColumnSuggestions() bool {
+	if s.SuggestionsFromColumn {
+		return true
+	}
+	if s.AutoCompleteURL == "" {
+		return false
+	}
+	switch s.Type {
+	case TYPE_TEXT, TYPE_TEXTAREA, TYPE_EMAIL, TYPE_TEL:
+		return true
+	}
+	return false
+}
+
+// GetSuggestionsDebounce, öneri istekleri için bekleme süresini milisaniye
+// olarak döner.
+func (s *Schema) GetSuggestionsDebounce() int {
+	if s.SuggestionsDebounceMs > 0 {
+		return s.SuggestionsDebounceMs
+	}
+	return 300
+}
+
 // Attachment Fluent API Methods - Dosya Yükleme Yönetimi
 //
 // Bu bölüm, dosya yükleme alanları için kullanılan metodları içerir.
@@ -2853,7 +2996,7 @@ func (s *Schema) MarkRemoveEXIFData() core.Element {
 //
 // # Örnek
 //
-//	field := Repeater("phones", "Telefon Numaraları").
+//	field := Repeater("Telefon Numaraları", "phones").
 //	    Fields(
 //	        Text("number", "Numara").Required(),
 //	        Select("type", "Tip").Options([]string{"Ev", "İş", "Mobil"}),
@@ -2886,7 +3029,7 @@ func (s *Schema) Fields(fields ...core.Element) core.Element {
 //
 // # Örnek
 //
-//	field := Repeater("addresses", "Adresler").
+//	field := Repeater("Adresler", "addresses").
 //	    Fields(
 //	        Text("street", "Sokak"),
 //	        Text("city", "Şehir"),
@@ -2918,7 +3061,7 @@ func (s *Schema) MinRepeats(min int) core.Element {
 //
 // # Örnek
 //
-//	field := Repeater("emails", "E-posta Adresleri").
+//	field := Repeater("E-posta Adresleri", "emails").
 //	    Fields(
 //	        Text("email", "E-posta").Email(),
 //	    ).
@@ -3223,8 +3366,9 @@ func (s *Schema) GetStoragePath() string {
 
 // ValidateAttachment, dosya yükleme doğrulaması yapar.
 //
-// Bu metod, dosya adı ve boyutuna göre doğrulama yapar.
-// Şu an için placeholder implementasyon içerir.
+// Bu metod, dosya adı ve boyutunu MaxSize ve Accept ayarlarıyla karşılaştırır.
+// Tip uzantıdan belirlenir; panel, multipart yüklemelerde ayrıca dosya içeriğini
+// koklayarak gerçek tipi denetler.
 //
 // # Parametreler
 //
@@ -3235,6 +3379,12 @@ func (s *Schema) GetStoragePath() string {
 //
 //   - error: Doğrulama hatası (nil ise geçerli)
 func (s *Schema) ValidateAttachment(filename string, size int64) error {
+	if s.MaxFileSize > 0 && size > s.MaxFileSize {
+		return fmt.Errorf("%s: file size %d exceeds the limit of %d bytes", filename, size, s.MaxFileSize)
+	}
+	if !storage.MatchesAccept(storage.TypeByExtension(filename), filename, s.AcceptedMimeTypes) {
+		return fmt.Errorf("%s: file type is not accepted (allowed: %s)", filename, strings.Join(s.AcceptedMimeTypes, ", "))
+	}
 	return nil
 }
 
@@ -3342,7 +3492,8 @@ func (s *Schema) GetMaxRepeats() int {
 // ValidateRepeats, tekrar sayısını doğrular.
 //
 // Bu metod, verilen tekrar sayısının min/max sınırları içinde olup olmadığını kontrol eder.
-// Şu an için placeholder implementasyon içerir.
+// Sınır 0 ise o yönde kontrol yapılmaz. Hatalar ErrTooFewRepeats veya
+// ErrTooManyRepeats'i sarar.
 //
 // # Parametreler
 //
@@ -3352,6 +3503,12 @@ func (s *Schema) GetMaxRepeats() int {
 //
 //   - error: Doğrulama hatası (nil ise geçerli)
 func (s *Schema) ValidateRepeats(count int) error {
+	if s.MinRepeatsCount > 0 && count < s.MinRepeatsCount {
+		return fmt.Errorf("%w: %s requires at least %d, got %d", ErrTooFewRepeats, s.Key, s.MinRepeatsCount, count)
+	}
+	if s.MaxRepeatsCount > 0 && count > s.MaxRepeatsCount {
+		return fmt.Errorf("%w: %s allows at most %d, got %d", ErrTooManyRepeats, s.Key, s.MaxRepeatsCount, count)
+	}
 	return nil
 }
 
@@ -52,6 +52,12 @@ const (
 	TYPE_DATE            ElementType = core.TYPE_DATE
 	TYPE_DATETIME        ElementType = core.TYPE_DATETIME
 	TYPE_FILE            ElementType = core.TYPE_FILE
+	TYPE_GALLERY         ElementType = core.TYPE_GALLERY
+	TYPE_REPEATER        ElementType = core.TYPE_REPEATER
+	TYPE_JSON            ElementType = core.TYPE_JSON
+	TYPE_TAGS            ElementType = core.TYPE_TAGS
+	TYPE_SLUG            ElementType = core.TYPE_SLUG
+	TYPE_MARKDOWN        ElementType = core.TYPE_MARKDOWN
 	TYPE_KEY_VALUE       ElementType = core.TYPE_KEY_VALUE
 	TYPE_LINK            ElementType = core.TYPE_LINK
 	TYPE_COLLECTION      ElementType = core.TYPE_COLLECTION
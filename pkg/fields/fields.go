@@ -501,6 +501,29 @@ func File(name string, attribute ...string) *Schema {
 	return f
 }
 
+// IsFileElement, alanın tek dosya tutan bir dosya alanı (resim, dosya, video veya
+// ses) olup olmadığını döner. Galeri alanları bu kapsama girmez; onlar için
+// AsGalleryField kullanılır.
+func IsFileElement(el Element) bool {
+	view := el.GetView()
+	if strings.HasPrefix(view, "file-field") ||
+		strings.HasPrefix(view, "image-field") ||
+		strings.HasPrefix(view, "video-field") ||
+		strings.HasPrefix(view, "audio-field") {
+		return true
+	}
+
+	fieldType := el.JsonSerialize()["type"]
+	switch t := fieldType.(type) {
+	case ElementType:
+		return t == TYPE_FILE || t == TYPE_VIDEO || t == TYPE_AUDIO
+	case string:
+		return t == string(TYPE_FILE) || t == string(TYPE_VIDEO) || t == string(TYPE_AUDIO)
+	default:
+		return false
+	}
+}
+
 // Bu fonksiyon, anahtar-değer ikilisi girişi sağlayan alan oluşturur ve yapılandırır.
 //
 // Kullanım Senaryosu:
@@ -0,0 +1,290 @@
+package fields
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+)
+
+// GalleryItem, çoklu dosya alanındaki tek bir dosyadır.
+//
+// Path diskteki yoldur (veya StoreAs/StoreHandler'ın döndürdüğü değer) ve
+// öğeyi güncellemelerde tanımlar. URL, herkese açık disklerde yükleme anında
+// doldurulur; özel disklerde yanıt oluşturulurken süreli URL üretilir.
+type GalleryItem struct {
+	Path     string `json:"path"`
+	URL      string `json:"url,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Size     int64  `json:"size,omitempty"`
+	MimeType string `json:"mime_type,omitempty"`
+	Caption  string `json:"caption,omitempty"`
+	Position int    `json:"position"`
+}
+
+// GalleryItems, JSON sütununda saklanan sıralı dosya listesidir. Model alanı
+// olarak kullanılabilir; veritabanına JSON metni olarak yazılır.
+//
+//	type Product struct {
+//	    ID     uint
+//	    Images fields.GalleryItems `gorm:"type:text"`
+//	}
+type GalleryItems []GalleryItem
+
+// Value, listeyi JSON metni olarak veritabanına yazar.
+func (g GalleryItems) Value() (driver.Value, error) {
+	if g == nil {
+		g = GalleryItems{}
+	}
+	encoded, err := json.Marshal(g)
+	if err != nil {
+		return nil, err
+	}
+	return string(encoded), nil
+}
+
+// Scan, JSON metnini listeye çözer. Boş ve NULL değerler boş liste olur.
+func (g *GalleryItems) Scan(src interface{}) error {
+	items, err := ParseGalleryItems(src)
+	if err != nil {
+		return err
+	}
+	*g = items
+	return nil
+}
+
+// ParseGalleryItems, model alanından okunan değeri (JSON metni, []byte,
+// GalleryItems veya bunların pointer'ları) Position'a göre sıralı listeye çevirir.
+func ParseGalleryItems(value interface{}) (GalleryItems, error) {
+	var raw []byte
+	switch typed := value.(type) {
+	case nil:
+		return GalleryItems{}, nil
+	case GalleryItems:
+		return sortedGalleryItems(typed), nil
+	case *GalleryItems:
+		if typed == nil {
+			return GalleryItems{}, nil
+		}
+		return sortedGalleryItems(*typed), nil
+	case []GalleryItem:
+		return sortedGalleryItems(typed), nil
+	case string:
+		raw = []byte(typed)
+	case *string:
+		if typed == nil {
+			return GalleryItems{}, nil
+		}
+		raw = []byte(*typed)
+	case []byte:
+		raw = typed
+	case json.RawMessage:
+		raw = typed
+	default:
+		return nil, fmt.Errorf("gallery: unsupported value type %T", value)
+	}
+
+	if strings.TrimSpace(string(raw)) == "" || strings.TrimSpace(string(raw)) == "null" {
+		return GalleryItems{}, nil
+	}
+	var items GalleryItems
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, err
+	}
+	return sortedGalleryItems(items), nil
+}
+
+// sortedGalleryItems, öğeleri Position'a göre sıralar; eşit sıralarda mevcut
+// düzen korunur.
+func sortedGalleryItems(items []GalleryItem) GalleryItems {
+	sorted := make(GalleryItems, len(items))
+	copy(sorted, items)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Position < sorted[j].Position
+	})
+	return sorted
+}
+
+// GalleryField, bir alana birden fazla dosya yüklenmesini sağlar.
+//
+// Dosyalar varsayılan olarak modelin JSON sütununda (string, []byte veya
+// GalleryItems) saklanır. AsAttachments ile modelin has-many ilişkisindeki
+// ek kayıtlarına yazılır. Her iki durumda da alan, index, detail ve API
+// yanıtlarında Position'a göre sıralı GalleryItem listesi olarak serileştirilir.
+//
+// Yükleme, Accept, MaxSize, MarkRemoveEXIFData ve Store ayarları tekli dosya
+// alanlarıyla aynı şekilde her dosyaya ayrı ayrı uygulanır.
+type GalleryField struct {
+	Schema
+	MaxFiles       int
+	UseAttachments bool
+}
+
+// Gallery, resim galerisi alanı oluşturur. Yalnızca resim dosyaları kabul edilir.
+//
+// Örnek Kullanım:
+//
+//	fields.Gallery("Görseller", "images").Limit(10)
+func Gallery(name string, attribute ...string) *GalleryField {
+	g := newGalleryField(name, "gallery-field", attribute...)
+	g.Accept("image/*")
+	return g
+}
+
+// Files, herhangi tipte birden fazla dosya için alan oluşturur.
+//
+// Örnek Kullanım:
+//
+//	fields.Files("Belgeler", "documents").Accept("application/pdf")
+func Files(name string, attribute ...string) *GalleryField {
+	return newGalleryField(name, "files-field", attribute...)
+}
+
+func newGalleryField(name, view string, attribute ...string) *GalleryField {
+	g := &GalleryField{Schema: *NewField(name, attribute...)}
+	g.View = view
+	g.Type = TYPE_GALLERY
+	g.WithProps("multiple", true)
+	return g
+}
+
+// Limit, alana eklenebilecek en fazla dosya sayısını belirler (0: sınırsız).
+func (g *GalleryField) Limit(max int) *GalleryField {
+	g.MaxFiles = max
+	g.WithProps("max_files", max)
+	return g
+}
+
+// AsAttachments, dosyaları JSON sütunu yerine modelin alan key'iyle eşleşen
+// has-many ilişkisine kaydeder. İlişkili model path ve position sütunlarına
+// sahip olmalıdır; url, name, size, mime_type ve caption sütunları varsa doldurulur.
+//
+//	type Product struct {
+//	    ID     uint
+//	    Images []ProductImage
+//	}
+//
+//	type ProductImage struct {
+//	    ID        uint
+//	    ProductID uint
+//	    Path      string
+//	    URL       string
+//	    Caption   string
+//	    Position  int
+//	}
+//
+//	fields.Gallery("Görseller", "images").AsAttachments()
+func (g *GalleryField) AsAttachments() *GalleryField {
+	g.UseAttachments = true
+	g.WithProps("attachments", true)
+	return g
+}
+
+// AsGalleryField, elemanı GalleryField olarak döner. Store gibi Schema
+// metodları zincirin sonunda çağrıldığında eleman *Schema olarak kalır; bu
+// durumda ayarlar tip ve props üzerinden okunur.
+func AsGalleryField(e Element) (*GalleryField, bool) {
+	switch typed := e.(type) {
+	case *GalleryField:
+		return typed, true
+	case *Schema:
+		if typed.Type != TYPE_GALLERY {
+			return nil, false
+		}
+		g := &GalleryField{Schema: *typed}
+		if max, ok := typed.Props["max_files"].(int); ok {
+			g.MaxFiles = max
+		}
+		if attachments, ok := typed.Props["attachments"].(bool); ok {
+			g.UseAttachments = attachments
+		}
+		return g, true
+	default:
+		return nil, false
+	}
+}
+
+// Items, kayıttaki dosyaları Position'a göre sıralı olarak döner.
+func (g *GalleryField) Items(record interface{}) GalleryItems {
+	probe := g.Schema
+	probe.Data = nil
+	probe.Extract(record)
+	if probe.Data == nil {
+		return GalleryItems{}
+	}
+
+	v := reflect.ValueOf(probe.Data)
+	if v.Kind() == reflect.Slice && v.Type().Elem() != reflect.TypeOf(GalleryItem{}) && v.Type().Elem().Kind() != reflect.Uint8 {
+		items := make([]GalleryItem, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			if item, ok := galleryItemFromStruct(v.Index(i)); ok {
+				items = append(items, item)
+			}
+		}
+		return sortedGalleryItems(items)
+	}
+
+	items, err := ParseGalleryItems(probe.Data)
+	if err != nil {
+		return GalleryItems{}
+	}
+	return items
+}
+
+// galleryItemFromStruct, ek modeli kaydını sütun adlarına göre GalleryItem'a çevirir.
+func galleryItemFromStruct(v reflect.Value) (GalleryItem, bool) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return GalleryItem{}, false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return GalleryItem{}, false
+	}
+
+	var item GalleryItem
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		value := v.Field(i)
+		text := ""
+		if value.Kind() == reflect.String {
+			text = value.String()
+		}
+		switch strcase.ToSnake(field.Name) {
+		case "path":
+			item.Path = text
+		case "url":
+			item.URL = text
+		case "name":
+			item.Name = text
+		case "mime_type":
+			item.MimeType = text
+		case "caption":
+			item.Caption = text
+		case "size":
+			if value.CanInt() {
+				item.Size = value.Int()
+			}
+		case "position":
+			if value.CanInt() {
+				item.Position = int(value.Int())
+			} else if value.CanUint() {
+				item.Position = int(value.Uint())
+			}
+		}
+	}
+	return item, item.Path != ""
+}
+
+// Extract, kayıttaki dosyaları sıralı GalleryItem listesi olarak Data'ya yazar.
+func (g *GalleryField) Extract(resource interface{}) {
+	g.Schema.Data = g.Items(resource)
+}
@@ -0,0 +1,90 @@
+package fields
+
+import (
+	"testing"
+)
+
+// TestGalleryCreation tests Gallery and Files field creation
+func TestGalleryCreation(t *testing.T) {
+	gallery := Gallery("Images", "images").Limit(5)
+	if gallery.View != "gallery-field" || gallery.Type != TYPE_GALLERY {
+		t.Errorf("Expected gallery view and type, got '%s' / '%s'", gallery.View, gallery.Type)
+	}
+	if accept := gallery.GetAcceptedMimeTypes(); len(accept) != 1 || accept[0] != "image/*" {
+		t.Errorf("Expected gallery to accept images only, got %v", accept)
+	}
+	if gallery.MaxFiles != 5 || gallery.Props["max_files"] != 5 {
+		t.Errorf("Expected max files 5, got %d", gallery.MaxFiles)
+	}
+
+	files := Files("Documents", "documents").AsAttachments()
+	if files.View != "files-field" || !files.UseAttachments {
+		t.Errorf("Expected attachment files field, got view '%s'", files.View)
+	}
+}
+
+// TestAsGalleryField tests resolving gallery settings from a plain Schema
+func TestAsGalleryField(t *testing.T) {
+	files := Files("Documents", "documents").Limit(2).AsAttachments()
+	element := files.Store("private", "documents")
+
+	gallery, ok := AsGalleryField(element)
+	if !ok {
+		t.Fatal("Expected Store result to resolve as a gallery field")
+	}
+	if gallery.MaxFiles != 2 || !gallery.UseAttachments {
+		t.Errorf("Expected settings from props, got max %d attachments %v", gallery.MaxFiles, gallery.UseAttachments)
+	}
+
+	if _, ok := AsGalleryField(Text("Name", "name")); ok {
+		t.Error("Expected text field not to resolve as a gallery field")
+	}
+}
+
+// TestGalleryItemsFromJSONColumn tests reading items from a JSON column in position order
+func TestGalleryItemsFromJSONColumn(t *testing.T) {
+	type product struct {
+		Images string
+	}
+	record := &product{Images: `[{"path":"b.png","position":1},{"path":"a.png","caption":"Front","position":0}]`}
+
+	items := Gallery("Images", "images").Items(record)
+	if len(items) != 2 || items[0].Path != "a.png" || items[0].Caption != "Front" || items[1].Path != "b.png" {
+		t.Errorf("Expected items sorted by position, got %+v", items)
+	}
+
+	value, err := items.Value()
+	if err != nil {
+		t.Fatalf("Value failed: %v", err)
+	}
+	var scanned GalleryItems
+	if err := scanned.Scan(value); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if len(scanned) != 2 || scanned[0] != items[0] {
+		t.Errorf("Expected Value/Scan round trip, got %+v", scanned)
+	}
+}
+
+// TestGalleryItemsFromAttachments tests reading items from a has-many relation
+func TestGalleryItemsFromAttachments(t *testing.T) {
+	type image struct {
+		ID       uint
+		Path     string
+		Caption  string
+		Position uint
+	}
+	type product struct {
+		Images []*image
+	}
+	record := &product{Images: []*image{
+		{ID: 1, Path: "second.png", Position: 1},
+		{ID: 2, Path: "first.png", Caption: "Cover", Position: 0},
+		nil,
+	}}
+
+	items := Gallery("Images", "images").AsAttachments().Items(record)
+	if len(items) != 2 || items[0].Path != "first.png" || items[0].Caption != "Cover" || items[1].Position != 1 {
+		t.Errorf("Expected attachment rows sorted by position, got %+v", items)
+	}
+}
@@ -0,0 +1,96 @@
+package fields
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/ferdiunal/panel.go/pkg/storage"
+)
+
+// conversionNamePattern, dönüşüm adlarının dosya yoluna güvenle eklenebilmesini sağlar.
+var conversionNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// ImageConversion, resim alanına yüklenen dosyadan üretilen türevdir (küçük
+// resim, önizleme, WebP kopyası vb.).
+//
+// Dönüşümler yüklemeden sonra orijinalle aynı diske, orijinalin yanına
+// ("products/abc.jpg" → "products/abc-thumb.webp") yazılır ve alanın
+// serileştirmesinde "conversions" altında ek URL olarak döner. Boyutlandırma
+// kuralları için storage.ImageOptions'a bakınız.
+type ImageConversion struct {
+	Name string `json:"name"`
+	storage.ImageOptions
+}
+
+// Conversion, verilen boyutlarda bir dönüşüm oluşturur. Boyutlardan biri sıfır
+// olabilir; bu durumda oran korunur.
+//
+//	fields.Conversion("thumb", 200, 200).Cropped()
+//	fields.Conversion("preview", 1200, 0).As("webp")
+func Conversion(name string, width, height int) ImageConversion {
+	return ImageConversion{
+		Name:         name,
+		ImageOptions: storage.ImageOptions{Width: width, Height: height},
+	}
+}
+
+// Cropped, resmi kutuyu dolduracak şekilde ölçekleyip ortadan kırpar.
+func (c ImageConversion) Cropped() ImageConversion {
+	c.Crop = true
+	return c
+}
+
+// As, çıktı biçimini belirler: "jpeg", "png", "gif" veya "webp".
+func (c ImageConversion) As(format string) ImageConversion {
+	c.Format = format
+	return c
+}
+
+// WithQuality, JPEG çıktısının kalitesini (1-100) belirler.
+func (c ImageConversion) WithQuality(quality int) ImageConversion {
+	c.Quality = quality
+	return c
+}
+
+// Path, orijinal dosya yolu için dönüşümün disk yolunu döner.
+func (c ImageConversion) Path(original string) string {
+	return storage.ConversionPath(original, c.Name, c.Format)
+}
+
+// Conversions, resim alanına yüklemeden sonra üretilecek dönüşümleri tanımlar.
+// Yalnızca Store(disk, path) ile diske yazan tekli dosya alanlarında uygulanır;
+// galeri alanları ve StoreAs callback'leri dönüşüm üretmez.
+//
+// Geçersiz ad ("a-z", "0-9", "_", "-"), aynı adın tekrarı veya desteklenmeyen
+// biçim yapılandırma hatasıdır ve panic'e yol açar.
+//
+//	fields.Image("Kapak", "cover").
+//	    Store("public", "products").
+//	    Conversions(
+//	        fields.Conversion("thumb", 200, 200).Cropped(),
+//	        fields.Conversion("preview", 1200, 0).As("webp"),
+//	    )
+func (s *Schema) Conversions(conversions ...ImageConversion) *Schema {
+	seen := make(map[string]bool, len(conversions))
+	for _, conversion := range conversions {
+		if !conversionNamePattern.MatchString(conversion.Name) || seen[conversion.Name] {
+			panic(fmt.Sprintf("fields.Conversions: invalid or duplicate conversion name %q", conversion.Name))
+		}
+		if conversion.Format != "" && !storage.IsImageFormat(conversion.Format) {
+			panic(fmt.Sprintf("fields.Conversions: unsupported format %q", conversion.Format))
+		}
+		seen[conversion.Name] = true
+	}
+	s.Props["conversions"] = conversions
+	return s
+}
+
+// ImageConversions, tekli dosya alanına tanımlanmış dönüşümleri döner.
+func ImageConversions(e Element) []ImageConversion {
+	s, ok := e.(*Schema)
+	if !ok || !IsFileElement(s) {
+		return nil
+	}
+	conversions, _ := s.Props["conversions"].([]ImageConversion)
+	return conversions
+}
@@ -0,0 +1,52 @@
+package fields
+
+import (
+	"testing"
+)
+
+// TestImageConversions tests declaring conversions on image fields
+func TestImageConversions(t *testing.T) {
+	cover := Image("Cover", "cover").Conversions(
+		Conversion("thumb", 200, 200).Cropped(),
+		Conversion("preview", 1200, 0).As("webp").WithQuality(90),
+	)
+
+	conversions := ImageConversions(cover)
+	if len(conversions) != 2 {
+		t.Fatalf("Expected 2 conversions, got %d", len(conversions))
+	}
+	if thumb := conversions[0]; thumb.Name != "thumb" || !thumb.Crop || thumb.Width != 200 || thumb.Height != 200 {
+		t.Errorf("Unexpected thumb conversion %+v", thumb)
+	}
+	if got := conversions[1].Path("products/abc.jpg"); got != "products/abc-preview.webp" {
+		t.Errorf("Expected preview next to the original, got %q", got)
+	}
+
+	if ImageConversions(Text("Name", "name")) != nil {
+		t.Error("Expected text field to have no conversions")
+	}
+	gallery := Gallery("Images", "images")
+	gallery.Conversions(Conversion("thumb", 100, 100))
+	if ImageConversions(gallery) != nil {
+		t.Error("Expected gallery field not to report conversions")
+	}
+}
+
+// TestImageConversionsRejectInvalidConfiguration tests configuration errors
+func TestImageConversionsRejectInvalidConfiguration(t *testing.T) {
+	tests := map[string][]ImageConversion{
+		"invalid name":   {Conversion("../thumb", 10, 10)},
+		"duplicate name": {Conversion("thumb", 10, 10), Conversion("thumb", 20, 20)},
+		"unknown format": {Conversion("thumb", 10, 10).As("tiff")},
+	}
+	for name, conversions := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("Expected Conversions to panic")
+				}
+			}()
+			Image("Cover", "cover").Conversions(conversions...)
+		})
+	}
+}
@@ -0,0 +1,336 @@
+package fields
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ferdiunal/panel.go/pkg/query"
+)
+
+// ErrInvalidFilter, index filtresi alanın tipine uymadığında döner.
+var ErrInvalidFilter = errors.New("invalid filter")
+
+// FilterType, Filterable alandan üretilen index filtresinin arayüzdeki tipidir.
+type FilterType string
+
+const (
+	// FilterTypeText, metni içeren kayıtları arar (like).
+	FilterTypeText FilterType = "text"
+	// FilterTypeNumber, sayısal aralık filtresidir (gte/lte/between).
+	FilterTypeNumber FilterType = "number"
+	// FilterTypeDate, tarih aralığı filtresidir (gte/lte/between).
+	FilterTypeDate FilterType = "date"
+	// FilterTypeBoolean, evet/hayır filtresidir.
+	FilterTypeBoolean FilterType = "boolean"
+	// FilterTypeSelect, alanın Options değerlerinden seçim yapılan filtredir.
+	FilterTypeSelect FilterType = "select"
+	// FilterTypeBelongsTo, ilişkili kaynakta arama yapılan seçim filtresidir.
+	FilterTypeBelongsTo FilterType = "belongs_to"
+)
+
+// FilterOption, select filtresinin bir seçeneğidir.
+type FilterOption struct {
+	Value interface{} `json:"value"`
+	Label string      `json:"label"`
+}
+
+// IndexFilter, Filterable işaretli bir alandan otomatik üretilen filtre
+// tanımıdır. Index yanıtının meta.filters listesinde döner; arayüz değerleri
+// "<resource>[filters][<key>][<operator>]=<value>" biçiminde gönderir.
+type IndexFilter struct {
+	Key       string                 `json:"key"`
+	Column    string                 `json:"-"`
+	Name      string                 `json:"name"`
+	Type      FilterType             `json:"type"`
+	Operators []query.FilterOperator `json:"operators"`
+	Options   []FilterOption         `json:"options,omitempty"`
+	// Resource ve DisplayKey, BelongsTo filtresinde seçeneklerin aranacağı
+	// ilişkili kaynağı ve gösterilecek kolonu belirtir.
+	Resource   string `json:"resource,omitempty"`
+	DisplayKey string `json:"display_key,omitempty"`
+}
+
+var (
+	textFilterOperators   = []query.FilterOperator{query.OpLike, query.OpNotLike, query.OpEqual, query.OpNotEqual, query.OpIsNull, query.OpIsNotNull}
+	rangeFilterOperators  = []query.FilterOperator{query.OpEqual, query.OpNotEqual, query.OpGreaterThan, query.OpGreaterEq, query.OpLessThan, query.OpLessEq, query.OpBetween, query.OpIsNull, query.OpIsNotNull}
+	dateFilterOperators   = []query.FilterOperator{query.OpEqual, query.OpGreaterThan, query.OpGreaterEq, query.OpLessThan, query.OpLessEq, query.OpBetween, query.OpIsNull, query.OpIsNotNull}
+	choiceFilterOperators = []query.FilterOperator{query.OpEqual, query.OpNotEqual, query.OpIn, query.OpNotIn, query.OpIsNull, query.OpIsNotNull}
+)
+
+// IndexFilters, elemanlardan Filterable olanların filtre tanımlarını döner.
+// Filtrelenemeyen tipler (dosya, repeater, has-many vb.) atlanır.
+func IndexFilters(elements []Element) []IndexFilter {
+	filters := make([]IndexFilter, 0)
+	for _, element := range elements {
+		if filter, ok := IndexFilterFor(element); ok {
+			filters = append(filters, filter)
+		}
+	}
+	return filters
+}
+
+// IndexFilterFor, alanın tipinden filtre tanımı üretir. Alan Filterable
+// değilse veya tipi otomatik filtreye uygun değilse false döner.
+func IndexFilterFor(element Element) (IndexFilter, bool) {
+	if element == nil {
+		return IndexFilter{}, false
+	}
+	metadata := element.GetMetadata()
+	if filterable, _ := metadata["filterable"].(bool); !filterable {
+		return IndexFilter{}, false
+	}
+
+	filter := IndexFilter{
+		Key:    element.GetKey(),
+		Column: element.GetKey(),
+		Name:   element.GetName(),
+	}
+	props, _ := metadata["props"].(map[string]interface{})
+	options := filterOptions(props["options"])
+
+	switch element.GetType() {
+	case TYPE_RELATIONSHIP:
+		// Filterable gibi Schema metodları zincirin sonunda çağrıldığında
+		// BelongsTo alanı *Schema olarak kalır; ilişki bilgisi props'tan okunur.
+		if element.GetView() != "belongs-to-field" {
+			return IndexFilter{}, false
+		}
+		filter.Type = FilterTypeBelongsTo
+		filter.Operators = choiceFilterOperators
+		filter.Resource, _ = props["related_resource"].(string)
+		if belongsTo, ok := element.(*BelongsToField); ok {
+			if foreignKey := belongsTo.GetForeignKey(); foreignKey != "" {
+				filter.Column = foreignKey
+			}
+			filter.DisplayKey = belongsTo.GetDisplayKey()
+		}
+	case TYPE_SELECT, TYPE_BADGE:
+		if element.GetType() == TYPE_BADGE && len(options) == 0 {
+			filter.Type = FilterTypeText
+			filter.Operators = textFilterOperators
+			break
+		}
+		filter.Type = FilterTypeSelect
+		filter.Operators = choiceFilterOperators
+		filter.Options = options
+	case TYPE_TEXT, TYPE_TEXTAREA, TYPE_EMAIL, TYPE_TEL:
+		filter.Type = FilterTypeText
+		filter.Operators = textFilterOperators
+	case TYPE_NUMBER, TYPE_MONEY:
+		filter.Type = FilterTypeNumber
+		filter.Operators = rangeFilterOperators
+	case TYPE_DATE, TYPE_DATETIME:
+		filter.Type = FilterTypeDate
+		filter.Operators = dateFilterOperators
+	case TYPE_BOOLEAN:
+		filter.Type = FilterTypeBoolean
+		filter.Operators = []query.FilterOperator{query.OpEqual, query.OpIsNull, query.OpIsNotNull}
+	default:
+		return IndexFilter{}, false
+	}
+	return filter, true
+}
+
+// Normalize, sorgudan gelen filtreyi tanıma göre doğrular, değerini alan
+// tipine çevirir (sayı, tarih, bool, seçenek değeri) ve alanın kolonuna
+// yönlendirir. Desteklenmeyen operatör veya çözümlenemeyen değer
+// ErrInvalidFilter ile sarılı hata döndürür.
+func (f IndexFilter) Normalize(filter query.Filter) (query.Filter, error) {
+	if !f.supports(filter.Operator) {
+		return filter, fmt.Errorf("%w: %s does not support %q", ErrInvalidFilter, f.Key, filter.Operator)
+	}
+	filter.Field = f.Column
+	if filter.Operator == query.OpIsNull || filter.Operator == query.OpIsNotNull {
+		return filter, nil
+	}
+
+	var err error
+	switch f.Type {
+	case FilterTypeNumber:
+		filter.Value, err = mapFilterValues(filter.Value, func(raw string, _ int) (interface{}, error) {
+			return strconv.ParseFloat(raw, 64)
+		})
+	case FilterTypeDate:
+		filter, err = f.normalizeDate(filter)
+	case FilterTypeBoolean:
+		filter.Value, err = parseFilterBool(filter.Value)
+	case FilterTypeSelect:
+		filter.Value, err = mapFilterValues(filter.Value, func(raw string, _ int) (interface{}, error) {
+			return f.optionValue(raw)
+		})
+	case FilterTypeText:
+		if raw, ok := filter.Value.(string); !ok || strings.TrimSpace(raw) == "" {
+			err = errors.New("value is required")
+		}
+	}
+	if err != nil {
+		return filter, fmt.Errorf("%w: %s: %v", ErrInvalidFilter, f.Key, err)
+	}
+	return filter, nil
+}
+
+func (f IndexFilter) supports(operator query.FilterOperator) bool {
+	for _, supported := range f.Operators {
+		if supported == operator {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeDate, tarih değerlerini time.Time'a çevirir. Yalnızca tarih
+// verilen üst sınırlar günün sonunu kapsar; eşitlik ise günün tamamını
+// kapsayan bir aralığa dönüşür.
+func (f IndexFilter) normalizeDate(filter query.Filter) (query.Filter, error) {
+	upper := filter.Operator == query.OpLessEq || filter.Operator == query.OpGreaterThan
+	value, err := mapFilterValues(filter.Value, func(raw string, index int) (interface{}, error) {
+		return parseFilterTime(raw, upper || index == 1)
+	})
+	if err != nil {
+		return filter, err
+	}
+
+	if t, ok := value.(time.Time); ok && filter.Operator == query.OpEqual && isDateOnly(filter.Value) {
+		filter.Operator = query.OpBetween
+		value = []interface{}{t, endOfDay(t)}
+	}
+	filter.Value = value
+	return filter, nil
+}
+
+func (f IndexFilter) optionValue(raw string) (interface{}, error) {
+	if len(f.Options) == 0 {
+		return raw, nil
+	}
+	for _, option := range f.Options {
+		if fmt.Sprint(option.Value) == raw {
+			return option.Value, nil
+		}
+	}
+	return nil, fmt.Errorf("%q is not an option", raw)
+}
+
+// mapFilterValues, tekli değeri veya in/between listesini dönüştürür.
+func mapFilterValues(value interface{}, convert func(raw string, index int) (interface{}, error)) (interface{}, error) {
+	switch typed := value.(type) {
+	case string:
+		return convert(strings.TrimSpace(typed), 0)
+	case []string:
+		converted := make([]interface{}, 0, len(typed))
+		for i, raw := range typed {
+			v, err := convert(strings.TrimSpace(raw), i)
+			if err != nil {
+				return nil, err
+			}
+			converted = append(converted, v)
+		}
+		return converted, nil
+	default:
+		return nil, fmt.Errorf("unsupported value %v", value)
+	}
+}
+
+var filterDateLayouts = []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02 15:04:05", "2006-01-02"}
+
+func parseFilterTime(raw string, endOfDate bool) (time.Time, error) {
+	for _, layout := range filterDateLayouts {
+		if t, err := time.Parse(layout, raw); err == nil {
+			if layout == "2006-01-02" && endOfDate {
+				return endOfDay(t), nil
+			}
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q is not a date", raw)
+}
+
+func isDateOnly(value interface{}) bool {
+	raw, ok := value.(string)
+	if !ok {
+		return false
+	}
+	_, err := time.Parse("2006-01-02", strings.TrimSpace(raw))
+	return err == nil
+}
+
+func endOfDay(t time.Time) time.Time {
+	return t.Add(24*time.Hour - time.Nanosecond)
+}
+
+func parseFilterBool(value interface{}) (bool, error) {
+	raw, _ := value.(string)
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "1", "true", "yes", "on":
+		return true, nil
+	case "0", "false", "no", "off":
+		return false, nil
+	}
+	return false, fmt.Errorf("%v is not a boolean", value)
+}
+
+// filterOptions, Options ile verilen seçenekleri (map, value/label listesi,
+// düz liste veya bunları döndüren fonksiyon) sıralı listeye çevirir.
+func filterOptions(raw interface{}) []FilterOption {
+	switch typed := raw.(type) {
+	case nil:
+		return nil
+	case func() map[string]string:
+		return filterOptions(typed())
+	case func() []map[string]interface{}:
+		return filterOptions(typed())
+	case map[string]string:
+		options := make([]FilterOption, 0, len(typed))
+		for value, label := range typed {
+			options = append(options, FilterOption{Value: value, Label: label})
+		}
+		sortFilterOptions(options)
+		return options
+	case map[string]interface{}:
+		options := make([]FilterOption, 0, len(typed))
+		for value, label := range typed {
+			options = append(options, FilterOption{Value: value, Label: fmt.Sprint(label)})
+		}
+		sortFilterOptions(options)
+		return options
+	case []map[string]interface{}:
+		options := make([]FilterOption, 0, len(typed))
+		for _, item := range typed {
+			options = append(options, filterOptionFromMap(item))
+		}
+		return options
+	}
+
+	v := reflect.ValueOf(raw)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil
+	}
+	options := make([]FilterOption, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		item := v.Index(i).Interface()
+		if m, ok := item.(map[string]interface{}); ok {
+			options = append(options, filterOptionFromMap(m))
+			continue
+		}
+		options = append(options, FilterOption{Value: item, Label: fmt.Sprint(item)})
+	}
+	return options
+}
+
+func filterOptionFromMap(item map[string]interface{}) FilterOption {
+	option := FilterOption{Value: item["value"], Label: fmt.Sprint(item["value"])}
+	if label, ok := item["label"]; ok {
+		option.Label = fmt.Sprint(label)
+	}
+	return option
+}
+
+func sortFilterOptions(options []FilterOption) {
+	sort.Slice(options, func(i, j int) bool {
+		return fmt.Sprint(options[i].Value) < fmt.Sprint(options[j].Value)
+	})
+}
@@ -0,0 +1,89 @@
+package fields
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ferdiunal/panel.go/pkg/query"
+)
+
+// TestIndexFilters tests filter definitions generated from filterable fields
+func TestIndexFilters(t *testing.T) {
+	elements := []Element{
+		Text("Name", "name").Filterable(),
+		Number("Price", "price").Filterable(),
+		DateTime("Published At", "published_at").Filterable(),
+		Switch("Active", "active").Filterable(),
+		Select("Status", "status").Options(map[string]string{"published": "Published", "draft": "Draft"}).Filterable(),
+		BelongsTo("Author", "author_id", "authors").Filterable(),
+		Text("Notes", "notes"),
+		File("Attachment", "attachment").Filterable(),
+	}
+
+	filters := IndexFilters(elements)
+	if len(filters) != 6 {
+		t.Fatalf("Expected 6 filters, got %d: %+v", len(filters), filters)
+	}
+	expected := []FilterType{FilterTypeText, FilterTypeNumber, FilterTypeDate, FilterTypeBoolean, FilterTypeSelect, FilterTypeBelongsTo}
+	for i, filterType := range expected {
+		if filters[i].Type != filterType {
+			t.Errorf("Expected filter %d to be %s, got %s", i, filterType, filters[i].Type)
+		}
+	}
+	if len(filters[4].Options) != 2 || filters[4].Options[0].Value != "draft" || filters[4].Options[0].Label != "Draft" {
+		t.Errorf("Expected sorted select options, got %+v", filters[4].Options)
+	}
+	if filters[5].Column != "author_id" || filters[5].Resource != "authors" {
+		t.Errorf("Expected belongs-to filter to target the foreign key, got %+v", filters[5])
+	}
+
+	author, ok := IndexFilterFor(BelongsTo("Author", "author_id", "authors").WithSearchableColumns("name"))
+	if ok {
+		t.Errorf("Expected non-filterable belongs-to to be skipped, got %+v", author)
+	}
+}
+
+// TestIndexFilterNormalize tests operator validation and value conversion
+func TestIndexFilterNormalize(t *testing.T) {
+	price, _ := IndexFilterFor(Number("Price", "price").Filterable())
+	filter, err := price.Normalize(query.Filter{Field: "price", Operator: query.OpBetween, Value: []string{"10", "20.5"}})
+	if err != nil {
+		t.Fatalf("Expected number range to be valid, got %v", err)
+	}
+	if values := filter.Value.([]interface{}); values[0] != float64(10) || values[1] != 20.5 {
+		t.Errorf("Expected numeric values, got %v", filter.Value)
+	}
+	if _, err := price.Normalize(query.Filter{Field: "price", Operator: query.OpGreaterEq, Value: "abc"}); !errors.Is(err, ErrInvalidFilter) {
+		t.Errorf("Expected ErrInvalidFilter for non-numeric value, got %v", err)
+	}
+	if _, err := price.Normalize(query.Filter{Field: "price", Operator: query.OpLike, Value: "1"}); !errors.Is(err, ErrInvalidFilter) {
+		t.Errorf("Expected ErrInvalidFilter for unsupported operator, got %v", err)
+	}
+
+	date, _ := IndexFilterFor(Date("Day", "day").Filterable())
+	filter, err = date.Normalize(query.Filter{Field: "day", Operator: query.OpEqual, Value: "2024-03-01"})
+	if err != nil || filter.Operator != query.OpBetween {
+		t.Fatalf("Expected date-only equality to become a day range, got %+v (%v)", filter, err)
+	}
+	if values := filter.Value.([]interface{}); !values[1].(time.Time).Equal(time.Date(2024, 3, 1, 23, 59, 59, 999999999, time.UTC)) {
+		t.Errorf("Expected range to end at the end of the day, got %v", values)
+	}
+	filter, _ = date.Normalize(query.Filter{Field: "day", Operator: query.OpLessEq, Value: "2024-03-01"})
+	if !filter.Value.(time.Time).Equal(time.Date(2024, 3, 1, 23, 59, 59, 999999999, time.UTC)) {
+		t.Errorf("Expected date-only upper bound to include the whole day, got %v", filter.Value)
+	}
+
+	active, _ := IndexFilterFor(Switch("Active", "active").Filterable())
+	if filter, err := active.Normalize(query.Filter{Field: "active", Operator: query.OpEqual, Value: "false"}); err != nil || filter.Value != false {
+		t.Errorf("Expected boolean value, got %v (%v)", filter.Value, err)
+	}
+
+	status, _ := IndexFilterFor(Select("Status", "status").Options([]map[string]interface{}{{"value": 1, "label": "Open"}}).Filterable())
+	if filter, err := status.Normalize(query.Filter{Field: "status", Operator: query.OpIn, Value: []string{"1"}}); err != nil || filter.Value.([]interface{})[0] != 1 {
+		t.Errorf("Expected option value to be used, got %v (%v)", filter.Value, err)
+	}
+	if _, err := status.Normalize(query.Filter{Field: "status", Operator: query.OpEqual, Value: "2"}); !errors.Is(err, ErrInvalidFilter) {
+		t.Errorf("Expected unknown option to be rejected, got %v", err)
+	}
+}
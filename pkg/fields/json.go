@@ -0,0 +1,100 @@
+package fields
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// ErrInvalidJSON, JSON alanına gönderilen metin geçerli JSON olmadığında döner.
+var ErrInvalidJSON = errors.New("json: invalid document")
+
+// JSON, serbest yapılı JSON belgesi alanı oluşturur.
+//
+// Değer istekte JSON metni veya doğrudan nesne/dizi olarak gönderilebilir;
+// kaydedilmeden önce doğrulanır ve sıkıştırılmış JSON metni olarak yazılır.
+// Model alanı string, []byte, json.RawMessage veya json/jsonb sütunu olabilir.
+// Yanıtta değer çözülmüş haliyle döner; detay görünümünde girintili metni
+// "pretty" anahtarıyla eklenir.
+//
+// Örnek Kullanım:
+//
+//	fields.JSON("Ayarlar", "settings")
+func JSON(name string, attribute ...string) *Schema {
+	f := NewField(name, attribute...)
+	f.View = "json-field"
+	f.Type = TYPE_JSON
+	return f
+}
+
+// NormalizeJSON, istekten veya model alanından okunan değeri sıkıştırılmış
+// JSON metnine çevirir. Metin değerler geçerli JSON olmalıdır; diğer değerler
+// JSON'a kodlanır. Boş değerler için ok false döner.
+func NormalizeJSON(value interface{}) (document string, ok bool, err error) {
+	var raw []byte
+	switch typed := value.(type) {
+	case nil:
+		return "", false, nil
+	case string:
+		raw = []byte(typed)
+	case *string:
+		if typed == nil {
+			return "", false, nil
+		}
+		raw = []byte(*typed)
+	case []byte:
+		raw = typed
+	case json.RawMessage:
+		raw = typed
+	default:
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return "", false, ErrInvalidJSON
+		}
+		raw = encoded
+	}
+
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return "", false, nil
+	}
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, raw); err != nil {
+		return "", false, ErrInvalidJSON
+	}
+	return compacted.String(), true, nil
+}
+
+// DecodeJSON, JSON alanının değerini nesne, dizi veya skaler olarak çözer.
+// Geçersiz JSON metinleri olduğu gibi döner.
+func DecodeJSON(value interface{}) interface{} {
+	document, ok, err := NormalizeJSON(value)
+	if err != nil {
+		if text, isText := value.(string); isText {
+			return text
+		}
+		return value
+	}
+	if !ok {
+		return nil
+	}
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(document), &decoded); err != nil {
+		return document
+	}
+	return decoded
+}
+
+// PrettyJSON, JSON alanının değerini iki boşlukla girintilenmiş metin olarak
+// döner.
+func PrettyJSON(value interface{}) (string, error) {
+	document, ok, err := NormalizeJSON(value)
+	if err != nil || !ok {
+		return "", err
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, []byte(document), "", "  "); err != nil {
+		return "", ErrInvalidJSON
+	}
+	return strings.TrimSpace(indented.String()), nil
+}
@@ -0,0 +1,57 @@
+package fields
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// TestNormalizeJSON tests JSON validation and compaction
+func TestNormalizeJSON(t *testing.T) {
+	field := JSON("Settings", "settings")
+	if field.View != "json-field" || field.Type != TYPE_JSON {
+		t.Errorf("Expected json view and type, got '%s' / '%s'", field.View, field.Type)
+	}
+
+	cases := []struct {
+		value interface{}
+		want  string
+	}{
+		{`{ "a": [1, 2], "b": null }`, `{"a":[1,2],"b":null}`},
+		{[]byte(`[true]`), `[true]`},
+		{json.RawMessage(`"text"`), `"text"`},
+		{map[string]interface{}{"enabled": true}, `{"enabled":true}`},
+	}
+	for _, tc := range cases {
+		document, ok, err := NormalizeJSON(tc.value)
+		if err != nil || !ok || document != tc.want {
+			t.Errorf("Expected %v to normalize to %s, got %q (%v, %v)", tc.value, tc.want, document, ok, err)
+		}
+	}
+
+	if _, ok, err := NormalizeJSON("  "); ok || err != nil {
+		t.Errorf("Expected blank value to be empty, got %v / %v", ok, err)
+	}
+	if _, _, err := NormalizeJSON(`{"a":`); !errors.Is(err, ErrInvalidJSON) {
+		t.Errorf("Expected ErrInvalidJSON for malformed document, got %v", err)
+	}
+}
+
+// TestPrettyJSON tests decoding and indentation for responses
+func TestPrettyJSON(t *testing.T) {
+	pretty, err := PrettyJSON(`{"a":{"b":1}}`)
+	if err != nil || pretty != "{\n  \"a\": {\n    \"b\": 1\n  }\n}" {
+		t.Errorf("Expected indented document, got %q (%v)", pretty, err)
+	}
+
+	decoded, ok := DecodeJSON(`{"a":[1]}`).(map[string]interface{})
+	if !ok || decoded["a"].([]interface{})[0] != float64(1) {
+		t.Errorf("Expected decoded object, got %v", decoded)
+	}
+	if DecodeJSON("not json") != "not json" {
+		t.Error("Expected invalid stored text to be returned as is")
+	}
+	if DecodeJSON(nil) != nil {
+		t.Error("Expected nil for empty value")
+	}
+}
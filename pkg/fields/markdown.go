@@ -0,0 +1,374 @@
+package fields
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	markdownHeading     = regexp.MustCompile(`^(#{1,6})[ \t]+(.*?)(?:[ \t]+#+)?[ \t]*$`)
+	markdownRule        = regexp.MustCompile(`^ {0,3}(?:(?:-[ \t]*){3,}|(?:\*[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	markdownBullet      = regexp.MustCompile(`^ {0,3}[-*+][ \t]+(.*)$`)
+	markdownOrdered     = regexp.MustCompile(`^ {0,3}\d{1,9}[.)][ \t]+(.*)$`)
+	markdownFence       = regexp.MustCompile("^ {0,3}(```+|~~~+)[ \t]*([A-Za-z0-9_+-]*)")
+	markdownURLScheme   = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9+.-]*):`)
+	markdownPunctuation = "\\`*_{}[]()#+-.!~>|"
+)
+
+const (
+	// MaxMarkdownLength, RenderMarkdown'ın Markdown olarak işlediği en uzun
+	// metindir (bayt). Daha uzun metinler işlenmeden, kaçırılmış düz metin
+	// olarak döner.
+	MaxMarkdownLength = 128 << 10
+
+	// maxMarkdownDepth, iç içe alıntılar ile satır içi vurgu ve bağlantılar için
+	// en fazla derinliktir. Daha derin içerik düz metin olarak yazılır.
+	maxMarkdownDepth = 16
+
+	// maxMarkdownLinkScan, bağlantı etiketi, adresi ve <otomatik bağlantı>
+	// sonu aranırken ileriye bakılan en fazla bayt sayısıdır.
+	maxMarkdownLinkScan = 1000
+)
+
+// Markdown, Markdown metin alanı oluşturur.
+//
+// Değer ham Markdown olarak saklanır. Detay görünümünde RenderMarkdown ile
+// güvenli HTML'e çevrilmiş hali "html" anahtarıyla döner.
+//
+// Örnek Kullanım:
+//
+//	fields.Markdown("İçerik", "body")
+func Markdown(name string, attribute ...string) *Schema {
+	f := NewField(name, attribute...)
+	f.View = "markdown-field"
+	f.Type = TYPE_MARKDOWN
+	return f
+}
+
+// RenderMarkdown, Markdown metnini HTML'e çevirir.
+//
+// Başlıklar, paragraflar, alıntılar, sıralı/sırasız listeler, çizgiler, kod
+// blokları, satır içi kod, kalın/italik/üstü çizili metin, bağlantılar ve
+// görseller desteklenir. Çıktı güvenlidir: metindeki ham HTML kaçırılır,
+// yalnızca renderer'ın ürettiği etiketler yer alır ve bağlantılarda yalnızca
+// http, https, mailto ile göreli adresler kabul edilir.
+//
+// Kötü niyetli girdiler yığını tüketemesin ve işlem süresi metin uzunluğuyla
+// doğrusal kalsın diye MaxMarkdownLength'ten uzun metinler düz metin olarak
+// döner, iç içe yapılar maxMarkdownDepth seviyesinde kesilir.
+func RenderMarkdown(source string) string {
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+	source = strings.ReplaceAll(source, "\r", "\n")
+	if len(source) > MaxMarkdownLength {
+		return "<p>" + html.EscapeString(strings.TrimSpace(source)) + "</p>"
+	}
+	var b strings.Builder
+	renderMarkdownBlocks(&b, strings.Split(source, "\n"), 0)
+	return strings.TrimSpace(b.String())
+}
+
+func renderMarkdownBlocks(b *strings.Builder, lines []string, depth int) {
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) == 0 {
+			return
+		}
+		b.WriteString("<p>")
+		for i, line := range paragraph {
+			if i > 0 {
+				if strings.HasSuffix(paragraph[i-1], "  ") {
+					b.WriteString("<br>")
+				}
+				b.WriteString("\n")
+			}
+			b.WriteString(renderMarkdownInline(strings.TrimSpace(line), depth))
+		}
+		b.WriteString("</p>\n")
+		paragraph = nil
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			flush()
+
+		case markdownFence.MatchString(line):
+			flush()
+			match := markdownFence.FindStringSubmatch(line)
+			fence, language := match[1], match[2]
+			var code []string
+			for i++; i < len(lines); i++ {
+				if strings.HasPrefix(strings.TrimSpace(lines[i]), fence[:3]) && strings.Trim(strings.TrimSpace(lines[i]), fence[:1]) == "" {
+					break
+				}
+				code = append(code, lines[i])
+			}
+			b.WriteString("<pre><code")
+			if language != "" {
+				b.WriteString(` class="language-` + language + `"`)
+			}
+			b.WriteString(">")
+			b.WriteString(html.EscapeString(strings.Join(code, "\n")))
+			b.WriteString("</code></pre>\n")
+
+		case markdownHeading.MatchString(trimmed):
+			flush()
+			match := markdownHeading.FindStringSubmatch(trimmed)
+			level := string(rune('0' + len(match[1])))
+			b.WriteString("<h" + level + ">" + renderMarkdownInline(match[2], depth) + "</h" + level + ">\n")
+
+		case markdownRule.MatchString(line):
+			flush()
+			b.WriteString("<hr>\n")
+
+		case strings.HasPrefix(trimmed, ">") && depth < maxMarkdownDepth:
+			flush()
+			var quoted []string
+			for ; i < len(lines); i++ {
+				current := strings.TrimSpace(lines[i])
+				if !strings.HasPrefix(current, ">") {
+					i--
+					break
+				}
+				current = strings.TrimPrefix(current, ">")
+				quoted = append(quoted, strings.TrimPrefix(current, " "))
+			}
+			b.WriteString("<blockquote>\n")
+			renderMarkdownBlocks(b, quoted, depth+1)
+			b.WriteString("</blockquote>\n")
+
+		case markdownBullet.MatchString(line), markdownOrdered.MatchString(line):
+			flush()
+			pattern, tag := markdownBullet, "ul"
+			if !markdownBullet.MatchString(line) {
+				pattern, tag = markdownOrdered, "ol"
+			}
+			var items []string
+			for ; i < len(lines); i++ {
+				current := lines[i]
+				if match := pattern.FindStringSubmatch(current); match != nil && !markdownRule.MatchString(current) {
+					items = append(items, strings.TrimSpace(match[1]))
+					continue
+				}
+				// Girintili satırlar önceki maddenin devamıdır
+				if strings.TrimSpace(current) != "" && (strings.HasPrefix(current, " ") || strings.HasPrefix(current, "\t")) {
+					items[len(items)-1] += "\n" + strings.TrimSpace(current)
+					continue
+				}
+				i--
+				break
+			}
+			b.WriteString("<" + tag + ">\n")
+			for _, item := range items {
+				b.WriteString("<li>" + renderMarkdownInline(item, depth) + "</li>\n")
+			}
+			b.WriteString("</" + tag + ">\n")
+
+		default:
+			paragraph = append(paragraph, line)
+		}
+	}
+	flush()
+}
+
+// renderMarkdownInline, satır içi Markdown'ı HTML'e çevirir. Tanınmayan her
+// karakter kaçırılarak yazılır. depth maxMarkdownDepth'e ulaştığında metin
+// olduğu gibi kaçırılır.
+func renderMarkdownInline(text string, depth int) string {
+	if depth >= maxMarkdownDepth {
+		return html.EscapeString(text)
+	}
+	// unclosed, metnin kalanında kapanışı bulunmayan ayraçları tutar; aynı
+	// ayraç için metnin sonuna kadar tekrar tekrar arama yapılmaz.
+	unclosed := map[string]bool{}
+	var b strings.Builder
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == '\\' && i+1 < len(text) && strings.IndexByte(markdownPunctuation, text[i+1]) >= 0:
+			b.WriteString(html.EscapeString(text[i+1 : i+2]))
+			i += 2
+			continue
+
+		case c == '`':
+			run := countMarkdownRun(text[i:], '`')
+			delimiter := strings.Repeat("`", run)
+			if end := markdownIndex(text[i+run:], delimiter, unclosed); end >= 0 {
+				b.WriteString("<code>" + html.EscapeString(strings.TrimSpace(text[i+run:i+run+end])) + "</code>")
+				i += run + end + run
+				continue
+			}
+			b.WriteString(delimiter)
+			i += run
+			continue
+
+		case c == '!' && i+1 < len(text) && text[i+1] == '[':
+			if label, target, width, ok := parseMarkdownLink(text[i+1:]); ok {
+				if src, safe := safeMarkdownURL(target, false); safe {
+					b.WriteString(`<img src="` + html.EscapeString(src) + `" alt="` + html.EscapeString(label) + `">`)
+				} else {
+					b.WriteString(html.EscapeString(label))
+				}
+				i += 1 + width
+				continue
+			}
+
+		case c == '[':
+			if label, target, width, ok := parseMarkdownLink(text[i:]); ok {
+				if href, safe := safeMarkdownURL(target, true); safe {
+					b.WriteString(`<a href="` + html.EscapeString(href) + `" rel="nofollow noopener noreferrer">` + renderMarkdownInline(label, depth+1) + `</a>`)
+				} else {
+					b.WriteString(renderMarkdownInline(label, depth+1))
+				}
+				i += width
+				continue
+			}
+
+		case c == '<':
+			if end := strings.IndexByte(markdownWindow(text[i:]), '>'); end > 0 {
+				target := text[i+1 : i+end]
+				if markdownURLScheme.MatchString(target) && !strings.ContainsAny(target, " \t") {
+					if href, safe := safeMarkdownURL(target, true); safe {
+						b.WriteString(`<a href="` + html.EscapeString(href) + `" rel="nofollow noopener noreferrer">` + html.EscapeString(target) + `</a>`)
+						i += end + 1
+						continue
+					}
+				}
+			}
+
+		case c == '*' || c == '_' || c == '~':
+			run := countMarkdownRun(text[i:], c)
+			if c == '~' && run != 2 {
+				break
+			}
+			if run > 2 {
+				run = 2
+			}
+			// Kelime içindeki alt çizgiler (snake_case) vurgu sayılmaz
+			if c == '_' && i > 0 && isMarkdownWordByte(text[i-1]) {
+				break
+			}
+			delimiter := strings.Repeat(string(c), run)
+			rest := text[i+run:]
+			end := markdownIndex(rest, delimiter, unclosed)
+			if end > 0 && rest[0] != ' ' && rest[end-1] != ' ' {
+				tag := "em"
+				switch {
+				case c == '~':
+					tag = "del"
+				case run == 2:
+					tag = "strong"
+				}
+				b.WriteString("<" + tag + ">" + renderMarkdownInline(rest[:end], depth+1) + "</" + tag + ">")
+				i += run + end + run
+				continue
+			}
+		}
+
+		if c == '\n' {
+			b.WriteByte('\n')
+		} else {
+			b.WriteString(html.EscapeString(text[i : i+1]))
+		}
+		i++
+	}
+	return b.String()
+}
+
+// parseMarkdownLink, "[etiket](adres "başlık")" biçimini ayrıştırır ve
+// tüketilen bayt sayısını döner. Başlık yok sayılır. Etiket ve adres en fazla
+// maxMarkdownLinkScan bayt uzunluğunda olabilir.
+func parseMarkdownLink(text string) (label string, target string, width int, ok bool) {
+	depth := 0
+	closing := -1
+	for i := 0; i < len(text) && i < maxMarkdownLinkScan && closing < 0; i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				closing = i
+			}
+		}
+	}
+	if closing < 0 || closing+1 >= len(text) || text[closing+1] != '(' {
+		return "", "", 0, false
+	}
+	end := strings.IndexByte(markdownWindow(text[closing+2:]), ')')
+	if end < 0 {
+		return "", "", 0, false
+	}
+	destination := strings.TrimSpace(text[closing+2 : closing+2+end])
+	if parts := strings.Fields(destination); len(parts) > 0 {
+		destination = parts[0]
+	}
+	destination = strings.TrimSuffix(strings.TrimPrefix(destination, "<"), ">")
+	return text[1:closing], destination, closing + 2 + end + 1, true
+}
+
+// safeMarkdownURL, adresin bağlantı veya görsel olarak kullanılabilir olup
+// olmadığını döner. Şema içeren adreslerde yalnızca http ve https (bağlantılar
+// için mailto da) kabul edilir; kontrol karakteri içeren adresler reddedilir.
+func safeMarkdownURL(target string, link bool) (string, bool) {
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return "", false
+	}
+	for _, r := range target {
+		if r < 0x20 || r == 0x7f {
+			return "", false
+		}
+	}
+	if match := markdownURLScheme.FindStringSubmatch(target); match != nil {
+		switch strings.ToLower(match[1]) {
+		case "http", "https":
+			return target, true
+		case "mailto":
+			return target, link
+		default:
+			return "", false
+		}
+	}
+	return target, true
+}
+
+// markdownIndex, delimiter'ın text içindeki ilk konumunu döner. Bulunamayan
+// ayraçlar unclosed'a eklenir; text her çağrıda öncekinin bir son eki olduğu
+// için aynı ayraç bir daha aranmaz.
+func markdownIndex(text, delimiter string, unclosed map[string]bool) int {
+	if unclosed[delimiter] {
+		return -1
+	}
+	end := strings.Index(text, delimiter)
+	if end < 0 {
+		unclosed[delimiter] = true
+	}
+	return end
+}
+
+// markdownWindow, text'in en fazla maxMarkdownLinkScan baytlık başını döner.
+func markdownWindow(text string) string {
+	if len(text) > maxMarkdownLinkScan {
+		return text[:maxMarkdownLinkScan]
+	}
+	return text
+}
+
+func countMarkdownRun(text string, c byte) int {
+	run := 0
+	for run < len(text) && text[run] == c {
+		run++
+	}
+	return run
+}
+
+func isMarkdownWordByte(c byte) bool {
+	return c == '_' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
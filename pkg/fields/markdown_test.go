@@ -0,0 +1,96 @@
+package fields
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestRenderMarkdown tests supported markdown blocks and inline elements
+func TestRenderMarkdown(t *testing.T) {
+	field := Markdown("Body", "body")
+	if field.View != "markdown-field" || field.Type != TYPE_MARKDOWN {
+		t.Errorf("Expected markdown view and type, got '%s' / '%s'", field.View, field.Type)
+	}
+
+	cases := map[string]string{
+		"# Title #":                             "<h1>Title</h1>",
+		"Some **bold**, *em* and ~~x~~.":        "<p>Some <strong>bold</strong>, <em>em</em> and <del>x</del>.</p>",
+		"snake_case_name":                       "<p>snake_case_name</p>",
+		"Use `a < b` here":                      "<p>Use <code>a &lt; b</code> here</p>",
+		"- one\n- two":                          "<ul>\n<li>one</li>\n<li>two</li>\n</ul>",
+		"1. first\n2. second":                   "<ol>\n<li>first</li>\n<li>second</li>\n</ol>",
+		"> quoted":                              "<blockquote>\n<p>quoted</p>\n</blockquote>",
+		"---":                                   "<hr>",
+		"```go\nfmt.Println(\"<hi>\")\n```":     "<pre><code class=\"language-go\">fmt.Println(&#34;&lt;hi&gt;&#34;)</code></pre>",
+		"[Docs](https://example.com/a?b=1&c=2)": "<p><a href=\"https://example.com/a?b=1&amp;c=2\" rel=\"nofollow noopener noreferrer\">Docs</a></p>",
+		"![Logo](/img/logo.png)":                "<p><img src=\"/img/logo.png\" alt=\"Logo\"></p>",
+		"line one  \nline two":                  "<p>line one<br>\nline two</p>",
+		"\\*not em\\*":                          "<p>*not em*</p>",
+	}
+	for input, want := range cases {
+		if got := RenderMarkdown(input); got != want {
+			t.Errorf("RenderMarkdown(%q) =\n%s\nwant\n%s", input, got, want)
+		}
+	}
+}
+
+// TestRenderMarkdownSanitizes tests that raw HTML and unsafe URLs are neutralized
+func TestRenderMarkdownSanitizes(t *testing.T) {
+	inputs := []string{
+		"<script>alert(1)</script>",
+		"<img src=x onerror=alert(1)>",
+		"[click](javascript:alert(1))",
+		"[click](JaVaScRiPt:alert(1))",
+		"![x](data:text/html;base64,PHNjcmlwdD4=)",
+		"[x](\"onmouseover=\"alert(1))",
+		"<javascript:alert(1)>",
+		"**<b onclick=alert(1)>bold</b>**",
+	}
+	for _, input := range inputs {
+		got := RenderMarkdown(input)
+		lowered := strings.ToLower(got)
+		for _, unsafe := range []string{"<script", "<b ", "<img src=x", `href="javascript`, `src="data`, `onmouseover="`} {
+			if strings.Contains(lowered, unsafe) {
+				t.Errorf("RenderMarkdown(%q) produced unsafe output: %s", input, got)
+			}
+		}
+	}
+	if got := RenderMarkdown("[mail](mailto:a@b.c) <https://example.com>"); !strings.Contains(got, `href="mailto:a@b.c"`) || !strings.Contains(got, `href="https://example.com"`) {
+		t.Errorf("Expected mailto and autolinks to be kept, got %s", got)
+	}
+}
+
+// TestRenderMarkdownLimits tests the input length and nesting depth limits
+func TestRenderMarkdownLimits(t *testing.T) {
+	quotes := RenderMarkdown(strings.Repeat(">", 10000) + " deep")
+	if got := strings.Count(quotes, "<blockquote>"); got != maxMarkdownDepth {
+		t.Errorf("Expected blockquotes to stop at depth %d, got %d", maxMarkdownDepth, got)
+	}
+
+	link := "x"
+	for i := 0; i < 100; i++ {
+		link = "[" + link + "](/a)"
+	}
+	if got := strings.Count(RenderMarkdown(link), "<a "); got > maxMarkdownDepth {
+		t.Errorf("Expected nested links to stop at depth %d, got %d", maxMarkdownDepth, got)
+	}
+
+	long := "# Title\n\n<b>" + strings.Repeat("a", MaxMarkdownLength)
+	got := RenderMarkdown(long)
+	if strings.Contains(got, "<h1>") || strings.Contains(got, "<b>") || !strings.HasPrefix(got, "<p># Title") {
+		t.Errorf("Expected input above MaxMarkdownLength to be escaped plain text, got %.40s", got)
+	}
+
+	// Unclosed delimiters must not make rendering quadratic or overflow the stack.
+	for _, input := range []string{
+		strings.Repeat("[", MaxMarkdownLength),
+		strings.Repeat("<", MaxMarkdownLength),
+		strings.Repeat("*a", MaxMarkdownLength/2),
+		strings.Repeat("` ``", MaxMarkdownLength/4),
+		strings.Repeat("[a](", MaxMarkdownLength/4),
+	} {
+		if got := RenderMarkdown(input); got == "" {
+			t.Errorf("Expected output for pathological input starting %.8q", input)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package fields
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ferdiunal/panel.go/pkg/core"
+	"github.com/iancoleman/strcase"
+)
+
+var (
+	// ErrTooFewRepeats, repeater satır sayısı MinRepeats değerinin altında kaldığında döner.
+	ErrTooFewRepeats = errors.New("repeater: too few rows")
+	// ErrTooManyRepeats, repeater satır sayısı MaxRepeats değerini aştığında döner.
+	ErrTooManyRepeats = errors.New("repeater: too many rows")
+)
+
+// RepeaterRows, repeater alanının sıralı satırlarıdır. Her satır alt alan
+// key'lerinden değerlerine bir map'tir; has-many modunda mevcut satırlar "id"
+// ile tanımlanır. Model alanı olarak kullanılabilir; veritabanına JSON metni
+// olarak yazılır.
+//
+//	type Order struct {
+//	    ID    uint
+//	    Items fields.RepeaterRows `gorm:"type:text"`
+//	}
+type RepeaterRows []map[string]interface{}
+
+// Value, satırları JSON metni olarak veritabanına yazar.
+func (r RepeaterRows) Value() (driver.Value, error) {
+	if r == nil {
+		r = RepeaterRows{}
+	}
+	encoded, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	return string(encoded), nil
+}
+
+// Scan, JSON metnini satırlara çözer. Boş ve NULL değerler boş liste olur.
+func (r *RepeaterRows) Scan(src interface{}) error {
+	rows, err := ParseRepeaterRows(src)
+	if err != nil {
+		return err
+	}
+	*r = rows
+	return nil
+}
+
+// ParseRepeaterRows, istekten veya model alanından okunan değeri (JSON dizisi
+// metni, []byte, nesne listesi veya RepeaterRows) satırlara çevirir. Nesne
+// olmayan bir satır hata döndürür.
+func ParseRepeaterRows(value interface{}) (RepeaterRows, error) {
+	var raw []byte
+	switch typed := value.(type) {
+	case nil:
+		return RepeaterRows{}, nil
+	case RepeaterRows:
+		return typed, nil
+	case *RepeaterRows:
+		if typed == nil {
+			return RepeaterRows{}, nil
+		}
+		return *typed, nil
+	case []map[string]interface{}:
+		return RepeaterRows(typed), nil
+	case []interface{}:
+		rows := make(RepeaterRows, 0, len(typed))
+		for i, item := range typed {
+			row, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("repeater: row %d is not an object", i)
+			}
+			rows = append(rows, row)
+		}
+		return rows, nil
+	case string:
+		raw = []byte(typed)
+	case *string:
+		if typed == nil {
+			return RepeaterRows{}, nil
+		}
+		raw = []byte(*typed)
+	case []byte:
+		raw = typed
+	case json.RawMessage:
+		raw = typed
+	default:
+		return nil, fmt.Errorf("repeater: unsupported value type %T", value)
+	}
+
+	if strings.TrimSpace(string(raw)) == "" || strings.TrimSpace(string(raw)) == "null" {
+		return RepeaterRows{}, nil
+	}
+	var rows RepeaterRows
+	if err := json.Unmarshal(raw, &rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// RepeaterField, aynı alt alan grubunun birden fazla kez girilmesini sağlar.
+//
+// Satırlar varsayılan olarak modelin JSON sütununda (string, []byte veya
+// RepeaterRows) saklanır. AsHasMany ile modelin alan key'iyle eşleşen has-many
+// ilişkisindeki alt kayıtlara yazılır. Her satır, alt alanların doğrulama
+// kurallarıyla ayrı ayrı doğrulanır; hatalar "items.2.price" gibi satır sırası
+// ve alt alan key'iyle döner.
+type RepeaterField struct {
+	Schema
+	UseHasMany bool
+}
+
+// Repeater, tekrarlayan alan grubu oluşturur.
+//
+// Örnek Kullanım:
+//
+//	fields.Repeater("Kalemler", "items").
+//	    Fields(
+//	        fields.Text("Ürün", "product").Required(),
+//	        fields.Number("Fiyat", "price").Required(),
+//	    ).
+//	    MinRepeats(1).
+//	    MaxRepeats(20)
+func Repeater(name string, attribute ...string) *RepeaterField {
+	r := &RepeaterField{Schema: *NewField(name, attribute...)}
+	r.View = "repeater-field"
+	r.Type = TYPE_REPEATER
+	return r
+}
+
+// Fields, her satırda gösterilecek alt alanları belirler.
+func (r *RepeaterField) Fields(fields ...core.Element) *RepeaterField {
+	r.Schema.Fields(fields...)
+	return r
+}
+
+// MinRepeats, en az satır sayısını belirler (0: sınırsız).
+func (r *RepeaterField) MinRepeats(min int) *RepeaterField {
+	r.Schema.MinRepeats(min)
+	return r
+}
+
+// MaxRepeats, en fazla satır sayısını belirler (0: sınırsız).
+func (r *RepeaterField) MaxRepeats(max int) *RepeaterField {
+	r.Schema.MaxRepeats(max)
+	return r
+}
+
+// AsHasMany, satırları JSON sütunu yerine modelin alan key'iyle eşleşen
+// has-many ilişkisine kaydeder. Alt modelin sütunları alt alan key'leriyle
+// eşleşmelidir; position sütunu varsa satır sırası yazılır.
+//
+//	type Order struct {
+//	    ID    uint
+//	    Items []OrderItem
+//	}
+//
+//	type OrderItem struct {
+//	    ID       uint
+//	    OrderID  uint
+//	    Product  string
+//	    Price    float64
+//	    Position int
+//	}
+//
+//	fields.Repeater("Kalemler", "items").Fields(...).AsHasMany()
+func (r *RepeaterField) AsHasMany() *RepeaterField {
+	r.UseHasMany = true
+	r.WithProps("has_many", true)
+	return r
+}
+
+// AsRepeaterField, elemanı RepeaterField olarak döner. Required gibi Schema
+// metodları zincirin sonunda çağrıldığında eleman *Schema olarak kalır; bu
+// durumda ayarlar tip ve props üzerinden okunur.
+func AsRepeaterField(e Element) (*RepeaterField, bool) {
+	switch typed := e.(type) {
+	case *RepeaterField:
+		return typed, true
+	case *Schema:
+		if typed.Type != TYPE_REPEATER && !typed.IsRepeaterField() {
+			return nil, false
+		}
+		r := &RepeaterField{Schema: *typed}
+		if hasMany, ok := typed.Props["has_many"].(bool); ok {
+			r.UseHasMany = hasMany
+		}
+		return r, true
+	default:
+		return nil, false
+	}
+}
+
+// Entries, kayıttaki satırları döner. Has-many ilişkisindeki alt kayıtlar alt
+// alan key'leri ve "id" ile map'e çevrilir, position sütunu varsa ona göre
+// sıralanır.
+func (r *RepeaterField) Entries(record interface{}) RepeaterRows {
+	probe := r.Schema
+	probe.Data = nil
+	probe.Extract(record)
+	if probe.Data == nil {
+		return RepeaterRows{}
+	}
+
+	v := reflect.ValueOf(probe.Data)
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 && v.Type().Elem().Kind() != reflect.Map && v.Type().Elem().Kind() != reflect.Interface {
+		rows := make(RepeaterRows, 0, v.Len())
+		positions := make([]int, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			if row, position, ok := r.rowFromStruct(v.Index(i)); ok {
+				rows = append(rows, row)
+				positions = append(positions, position)
+			}
+		}
+		order := make([]int, len(rows))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(i, j int) bool {
+			return positions[order[i]] < positions[order[j]]
+		})
+		sorted := make(RepeaterRows, len(rows))
+		for i, index := range order {
+			sorted[i] = rows[index]
+		}
+		return sorted
+	}
+
+	rows, err := ParseRepeaterRows(probe.Data)
+	if err != nil {
+		return RepeaterRows{}
+	}
+	return rows
+}
+
+// rowFromStruct, alt kaydı sütun adlarına göre satıra çevirir. gorm.Model
+// gibi gömülü struct'ların alanları da okunur.
+func (r *RepeaterField) rowFromStruct(v reflect.Value) (map[string]interface{}, int, bool) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, 0, false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, 0, false
+	}
+
+	keys := map[string]string{"id": "id"}
+	for _, nested := range r.RepeaterFields {
+		if nested != nil {
+			keys[strcase.ToSnake(nested.GetKey())] = nested.GetKey()
+		}
+	}
+
+	row := make(map[string]interface{}, len(keys))
+	position := 0
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			value := v.Field(i)
+			if field.Anonymous && value.Kind() == reflect.Struct {
+				walk(value)
+				continue
+			}
+			if !field.IsExported() {
+				continue
+			}
+			name := strcase.ToSnake(field.Name)
+			if name == "position" {
+				if value.CanInt() {
+					position = int(value.Int())
+				} else if value.CanUint() {
+					position = int(value.Uint())
+				}
+			}
+			if key, ok := keys[name]; ok {
+				row[key] = value.Interface()
+			}
+		}
+	}
+	walk(v)
+	return row, position, true
+}
+
+// Extract, kayıttaki satırları Data'ya yazar.
+func (r *RepeaterField) Extract(resource interface{}) {
+	r.Schema.Data = r.Entries(resource)
+}
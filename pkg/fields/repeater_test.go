@@ -0,0 +1,101 @@
+package fields
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestRepeaterCreation tests Repeater field creation and nested field serialization
+func TestRepeaterCreation(t *testing.T) {
+	repeater := Repeater("Items", "items").
+		Fields(Text("Product", "product").Required(), Number("Price", "price")).
+		MinRepeats(1).
+		MaxRepeats(3).
+		AsHasMany()
+	if repeater.View != "repeater-field" || repeater.Type != TYPE_REPEATER {
+		t.Errorf("Expected repeater view and type, got '%s' / '%s'", repeater.View, repeater.Type)
+	}
+
+	serialized := repeater.JsonSerialize()
+	nested, ok := serialized["fields"].([]map[string]interface{})
+	if !ok || len(nested) != 2 || nested[0]["key"] != "product" || nested[0]["required"] != true {
+		t.Fatalf("Expected nested fields in serialization, got %v", serialized["fields"])
+	}
+	if serialized["min_repeats"] != 1 || serialized["max_repeats"] != 3 {
+		t.Errorf("Expected repeat limits in serialization, got %v / %v", serialized["min_repeats"], serialized["max_repeats"])
+	}
+
+	resolved, ok := AsRepeaterField(repeater.Required())
+	if !ok || !resolved.UseHasMany || len(resolved.GetRepeaterFields()) != 2 {
+		t.Errorf("Expected Required result to resolve as a has-many repeater, got %+v", resolved)
+	}
+	if _, ok := AsRepeaterField(Text("Name", "name")); ok {
+		t.Error("Expected text field not to resolve as a repeater field")
+	}
+}
+
+// TestValidateRepeats tests row count limits
+func TestValidateRepeats(t *testing.T) {
+	repeater := Repeater("Items", "items").MinRepeats(1).MaxRepeats(2)
+
+	if err := repeater.ValidateRepeats(0); !errors.Is(err, ErrTooFewRepeats) {
+		t.Errorf("Expected ErrTooFewRepeats, got %v", err)
+	}
+	if err := repeater.ValidateRepeats(3); !errors.Is(err, ErrTooManyRepeats) {
+		t.Errorf("Expected ErrTooManyRepeats, got %v", err)
+	}
+	if err := repeater.ValidateRepeats(2); err != nil {
+		t.Errorf("Expected 2 rows to be valid, got %v", err)
+	}
+	if err := Repeater("Tags", "tags").ValidateRepeats(100); err != nil {
+		t.Errorf("Expected no limits by default, got %v", err)
+	}
+}
+
+// TestRepeaterEntries tests reading rows from a JSON column and from has-many records
+func TestRepeaterEntries(t *testing.T) {
+	type order struct {
+		Items string
+	}
+	repeater := Repeater("Items", "items").Fields(Text("Product", "product"), Number("Price", "price"))
+
+	rows := repeater.Entries(&order{Items: `[{"product":"Pen","price":2.5}]`})
+	if len(rows) != 1 || rows[0]["product"] != "Pen" || rows[0]["price"] != 2.5 {
+		t.Errorf("Expected rows from JSON column, got %v", rows)
+	}
+
+	value, err := rows.Value()
+	if err != nil {
+		t.Fatalf("Value failed: %v", err)
+	}
+	var scanned RepeaterRows
+	if err := scanned.Scan(value); err != nil || len(scanned) != 1 || scanned[0]["product"] != "Pen" {
+		t.Errorf("Expected rows to round-trip through Value/Scan, got %v (%v)", scanned, err)
+	}
+
+	type orderItem struct {
+		ID       uint
+		OrderID  uint
+		Product  string
+		Price    float64
+		Position int
+	}
+	type hasManyOrder struct {
+		Items []orderItem
+	}
+	record := &hasManyOrder{Items: []orderItem{
+		{ID: 7, OrderID: 1, Product: "Book", Price: 10, Position: 1},
+		{ID: 3, OrderID: 1, Product: "Pen", Price: 2.5, Position: 0},
+	}}
+	rows = repeater.Entries(record)
+	if len(rows) != 2 || rows[0]["id"] != uint(3) || rows[0]["product"] != "Pen" || rows[1]["price"] != float64(10) {
+		t.Errorf("Expected has-many rows sorted by position, got %v", rows)
+	}
+	if _, ok := rows[0]["order_id"]; ok {
+		t.Errorf("Expected foreign key to be left out of rows, got %v", rows[0])
+	}
+
+	if _, err := ParseRepeaterRows([]interface{}{"not-a-row"}); err == nil {
+		t.Error("Expected non-object rows to be rejected")
+	}
+}
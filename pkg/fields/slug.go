@@ -0,0 +1,125 @@
+package fields
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/gofiber/fiber/v2"
+	"golang.org/x/text/unicode/norm"
+)
+
+// slugTransliterations, ayrıştırıldığında ASCII harfe inmeyen karakterlerin
+// karşılıklarıdır.
+var slugTransliterations = map[rune]string{
+	'ı': "i", 'ß': "ss", 'æ': "ae", 'ø': "o", 'œ': "oe", 'đ': "d", 'ł': "l", 'þ': "th",
+}
+
+// SlugField, kaynak alandan üretilen URL dostu tanımlayıcı alanıdır.
+//
+// From ile kaynak alan belirlendiğinde oluşturma formunda kaynak alan
+// değiştikçe slug bağımlılık çözümüyle yeniden üretilir; güncellemede mevcut
+// slug korunur. Slug boş gönderilirse kayıt sırasında kaynak alandan türetilir
+// ve çakışma varsa "-2", "-3" gibi ekler eklenir. Elle girilen slug'ın
+// biçimi ve tablodaki benzersizliği doğrulanır.
+type SlugField struct {
+	Schema
+	Source    string
+	Separator string
+}
+
+// Slug, slug alanı oluşturur.
+//
+// Örnek Kullanım:
+//
+//	fields.Text("Başlık", "title").Required()
+//	fields.Slug("Slug", "slug").From("title")
+func Slug(name string, attribute ...string) *SlugField {
+	s := &SlugField{Schema: *NewField(name, attribute...), Separator: "-"}
+	s.View = "slug-field"
+	s.Type = TYPE_SLUG
+	s.WithProps("separator", s.Separator)
+	return s
+}
+
+// From, slug'ın üretileceği kaynak alanı belirler.
+func (s *SlugField) From(field string) *SlugField {
+	s.Source = strings.TrimSpace(field)
+	s.WithProps("source", s.Source)
+	s.DependsOn(s.Source)
+	s.DependencyCallbackOnCreate = func(field *Schema, formData map[string]interface{}, _ *fiber.Ctx) *FieldUpdate {
+		source, _ := formData[s.Source].(string)
+		return NewFieldUpdate().SetValue(Slugify(source, s.Separator))
+	}
+	return s
+}
+
+// WithSeparator, kelimeler arasında kullanılacak ayracı belirler (varsayılan "-").
+func (s *SlugField) WithSeparator(separator string) *SlugField {
+	s.Separator = separator
+	s.WithProps("separator", separator)
+	return s
+}
+
+// AsSlugField, elemanı SlugField olarak döner. Required gibi Schema metodları
+// zincirin sonunda çağrıldığında eleman *Schema olarak kalır; bu durumda
+// ayarlar tip ve props üzerinden okunur.
+func AsSlugField(e Element) (*SlugField, bool) {
+	switch typed := e.(type) {
+	case *SlugField:
+		return typed, true
+	case *Schema:
+		if typed.Type != TYPE_SLUG {
+			return nil, false
+		}
+		s := &SlugField{Schema: *typed, Separator: "-"}
+		if source, ok := typed.Props["source"].(string); ok {
+			s.Source = source
+		}
+		if separator, ok := typed.Props["separator"].(string); ok {
+			s.Separator = separator
+		}
+		return s, true
+	default:
+		return nil, false
+	}
+}
+
+// IsValid, değerin bu alanın ayracıyla üretilmiş bir slug olup olmadığını
+// döner: küçük harf ve rakamlardan oluşan, ayraçla ayrılmış kelimeler.
+func (s *SlugField) IsValid(value string) bool {
+	return value != "" && Slugify(value, s.Separator) == value
+}
+
+// Slugify, metni URL dostu slug'a çevirir. Aksanlı harfler ASCII
+// karşılıklarına indirilir, kesme işaretleri atılır, harf ve rakam dışındaki
+// karakter dizileri tek ayraca dönüşür, baştaki ve sondaki ayraçlar atılır.
+//
+//	fields.Slugify("Çok Güzel Ürünler!", "-") // "cok-guzel-urunler"
+func Slugify(value string, separator string) string {
+	var b strings.Builder
+	pending := false
+	for _, r := range norm.NFD.String(strings.ToLower(value)) {
+		// Birleşik işaretler ve kesme işaretleri kelimeyi bölmez
+		if unicode.Is(unicode.Mn, r) || r == '\'' || r == '’' {
+			continue
+		}
+		if replacement, ok := slugTransliterations[r]; ok {
+			if pending && b.Len() > 0 {
+				b.WriteString(separator)
+			}
+			pending = false
+			b.WriteString(replacement)
+			continue
+		}
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if pending && b.Len() > 0 {
+				b.WriteString(separator)
+			}
+			pending = false
+			b.WriteRune(r)
+			continue
+		}
+		pending = true
+	}
+	return b.String()
+}
@@ -0,0 +1,54 @@
+package fields
+
+import "testing"
+
+// TestSlugify tests slug generation from text
+func TestSlugify(t *testing.T) {
+	cases := map[string]string{
+		"Hello World":             "hello-world",
+		"  Çok Güzel Ürünler! ":   "cok-guzel-urunler",
+		"İstanbul'da Işık":        "istanbulda-isik",
+		"C++ & Go -- 2024":        "c-go-2024",
+		"Straße ØRE":              "strasse-ore",
+		"---":                     "",
+		"already-a-slug":          "already-a-slug",
+		"Crème brûlée, s'il vous": "creme-brulee-sil-vous",
+	}
+	for input, want := range cases {
+		if got := Slugify(input, "-"); got != want {
+			t.Errorf("Slugify(%q) = %q, want %q", input, got, want)
+		}
+	}
+	if got := Slugify("Hello World", "_"); got != "hello_world" {
+		t.Errorf("Expected custom separator, got %q", got)
+	}
+}
+
+// TestSlugField tests slug field dependency wiring and validation
+func TestSlugField(t *testing.T) {
+	field := Slug("Slug", "slug").From("title")
+	if field.View != "slug-field" || field.Type != TYPE_SLUG {
+		t.Errorf("Expected slug view and type, got '%s' / '%s'", field.View, field.Type)
+	}
+	if len(field.DependsOnFields) != 1 || field.DependsOnFields[0] != "title" {
+		t.Fatalf("Expected slug to depend on title, got %v", field.DependsOnFields)
+	}
+
+	update := field.GetDependencyCallback("create")(&field.Schema, map[string]interface{}{"title": "Yeni Ürün"}, nil)
+	if update == nil || update.Value != "yeni-urun" {
+		t.Errorf("Expected generated slug on create, got %+v", update)
+	}
+	if field.GetDependencyCallback("update") != nil {
+		t.Error("Expected existing slugs not to be regenerated on update")
+	}
+
+	resolved, ok := AsSlugField(field.Required())
+	if !ok || resolved.Source != "title" || resolved.Separator != "-" {
+		t.Fatalf("Expected Required result to resolve as a slug field, got %+v", resolved)
+	}
+	for value, valid := range map[string]bool{"hello-world": true, "Hello-World": false, "hello--world": false, "-hello": false, "hello world": false} {
+		if resolved.IsValid(value) != valid {
+			t.Errorf("Expected IsValid(%q) to be %v", value, valid)
+		}
+	}
+}
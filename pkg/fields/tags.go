@@ -0,0 +1,276 @@
+package fields
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+)
+
+var (
+	// ErrInvalidTags, etiket listesi metin olmayan bir öğe içerdiğinde döner.
+	ErrInvalidTags = errors.New("tags: invalid tag list")
+	// ErrTooManyTags, etiket sayısı MaxTags değerini aştığında döner.
+	ErrTooManyTags = errors.New("tags: too many tags")
+)
+
+// TagList, etiket alanının sıralı etiketleridir. Model alanı olarak
+// kullanılabilir; veritabanına JSON dizisi metni olarak yazılır.
+//
+//	type Post struct {
+//	    ID   uint
+//	    Tags fields.TagList `gorm:"type:text"`
+//	}
+type TagList []string
+
+// Value, etiketleri JSON dizisi metni olarak veritabanına yazar.
+func (t TagList) Value() (driver.Value, error) {
+	if t == nil {
+		t = TagList{}
+	}
+	encoded, err := json.Marshal([]string(t))
+	if err != nil {
+		return nil, err
+	}
+	return string(encoded), nil
+}
+
+// Scan, JSON dizisi metnini etiketlere çözer. Boş ve NULL değerler boş liste
+// olur.
+func (t *TagList) Scan(src interface{}) error {
+	tags, err := ParseTagList(src)
+	if err != nil {
+		return err
+	}
+	*t = tags
+	return nil
+}
+
+// ParseTagList, istekten veya model alanından okunan değeri (JSON dizisi
+// metni, virgülle ayrılmış metin, []byte veya metin listesi) etiketlere
+// çevirir. Etiketlerin başındaki ve sonundaki boşluklar kırpılır, boş
+// etiketler atılır ve büyük/küçük harf duyarsız tekrarlar ilk haliyle tutulur.
+func ParseTagList(value interface{}) (TagList, error) {
+	var raw []string
+	switch typed := value.(type) {
+	case nil:
+		return TagList{}, nil
+	case TagList:
+		raw = typed
+	case *TagList:
+		if typed == nil {
+			return TagList{}, nil
+		}
+		raw = *typed
+	case []string:
+		raw = typed
+	case []interface{}:
+		raw = make([]string, 0, len(typed))
+		for _, item := range typed {
+			tag, ok := item.(string)
+			if !ok {
+				return nil, ErrInvalidTags
+			}
+			raw = append(raw, tag)
+		}
+	case string:
+		return parseTagText(typed)
+	case *string:
+		if typed == nil {
+			return TagList{}, nil
+		}
+		return parseTagText(*typed)
+	case []byte:
+		return parseTagText(string(typed))
+	default:
+		return nil, fmt.Errorf("%w: unsupported value type %T", ErrInvalidTags, value)
+	}
+	return normalizeTags(raw), nil
+}
+
+// parseTagText, JSON dizisi veya virgülle ayrılmış metni etiketlere çevirir.
+func parseTagText(text string) (TagList, error) {
+	text = strings.TrimSpace(text)
+	if text == "" || text == "null" {
+		return TagList{}, nil
+	}
+	if strings.HasPrefix(text, "[") {
+		var items []interface{}
+		if err := json.Unmarshal([]byte(text), &items); err != nil {
+			return nil, ErrInvalidTags
+		}
+		return ParseTagList(items)
+	}
+	return normalizeTags(strings.Split(text, ",")), nil
+}
+
+// normalizeTags, etiketleri kırpar, boşları atar ve tekrarları ayıklar.
+func normalizeTags(raw []string) TagList {
+	tags := make(TagList, 0, len(raw))
+	seen := make(map[string]struct{}, len(raw))
+	for _, tag := range raw {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		folded := strings.ToLower(tag)
+		if _, ok := seen[folded]; ok {
+			continue
+		}
+		seen[folded] = struct{}{}
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// TagsField, serbest metin etiket listesi alanıdır.
+//
+// Etiketler varsayılan olarak modelin JSON sütununda (string, []byte veya
+// TagList) saklanır. AsRelation ile modelin alan key'iyle eşleşen
+// many-to-many etiket ilişkisine yazılır; bilinmeyen etiketler ilişkili
+// tabloda oluşturulur. Öneri endpoint'i mevcut etiketleri önek eşleşmesiyle
+// döner.
+type TagsField struct {
+	Schema
+	UseRelation    bool
+	RelationColumn string
+}
+
+// Tags, etiket alanı oluşturur.
+//
+// Örnek Kullanım:
+//
+//	fields.Tags("Etiketler", "tags").MaxTags(10)
+func Tags(name string, attribute ...string) *TagsField {
+	t := &TagsField{Schema: *NewField(name, attribute...)}
+	t.View = "tags-field"
+	t.Type = TYPE_TAGS
+	t.SuggestionsFromColumn = true
+	return t
+}
+
+// MaxTags, en fazla etiket sayısını belirler (0: sınırsız).
+func (t *TagsField) MaxTags(max int) *TagsField {
+	t.WithProps("max_tags", max)
+	return t
+}
+
+// AsRelation, etiketleri JSON sütunu yerine modelin alan key'iyle eşleşen
+// many-to-many ilişkisine kaydeder. column, etiket modelinde etiket adının
+// tutulduğu sütundur (varsayılan "name").
+//
+//	type Post struct {
+//	    ID   uint
+//	    Tags []Tag `gorm:"many2many:post_tags"`
+//	}
+//
+//	type Tag struct {
+//	    ID   uint
+//	    Name string `gorm:"uniqueIndex"`
+//	}
+//
+//	fields.Tags("Etiketler", "tags").AsRelation()
+func (t *TagsField) AsRelation(column ...string) *TagsField {
+	t.UseRelation = true
+	t.RelationColumn = "name"
+	if len(column) > 0 && strings.TrimSpace(column[0]) != "" {
+		t.RelationColumn = strings.TrimSpace(column[0])
+	}
+	t.WithProps("relation", true)
+	t.WithProps("relation_column", t.RelationColumn)
+	return t
+}
+
+// GetMaxTags, en fazla etiket sayısını döner (0: sınırsız).
+func (t *TagsField) GetMaxTags() int {
+	switch max := t.Props["max_tags"].(type) {
+	case int:
+		return max
+	case float64:
+		return int(max)
+	}
+	return 0
+}
+
+// ValidateTags, etiket sayısını MaxTags sınırına göre doğrular.
+func (t *TagsField) ValidateTags(tags TagList) error {
+	if max := t.GetMaxTags(); max > 0 && len(tags) > max {
+		return ErrTooManyTags
+	}
+	return nil
+}
+
+// AsTagsField, elemanı TagsField olarak döner. Required gibi Schema metodları
+// zincirin sonunda çağrıldığında eleman *Schema olarak kalır; bu durumda
+// ayarlar tip ve props üzerinden okunur.
+func AsTagsField(e Element) (*TagsField, bool) {
+	switch typed := e.(type) {
+	case *TagsField:
+		return typed, true
+	case *Schema:
+		if typed.Type != TYPE_TAGS {
+			return nil, false
+		}
+		t := &TagsField{Schema: *typed}
+		if relation, ok := typed.Props["relation"].(bool); ok {
+			t.UseRelation = relation
+		}
+		if column, ok := typed.Props["relation_column"].(string); ok {
+			t.RelationColumn = column
+		}
+		return t, true
+	default:
+		return nil, false
+	}
+}
+
+// Entries, kayıttaki etiketleri döner. İlişki modunda ilişkili kayıtların
+// RelationColumn sütunu okunur.
+func (t *TagsField) Entries(record interface{}) TagList {
+	probe := t.Schema
+	probe.Data = nil
+	probe.Extract(record)
+	if probe.Data == nil {
+		return TagList{}
+	}
+
+	v := reflect.ValueOf(probe.Data)
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 && v.Type().Elem().Kind() != reflect.String && v.Type().Elem().Kind() != reflect.Interface {
+		name := strcase.ToCamel(t.RelationColumn)
+		if name == "" {
+			name = "Name"
+		}
+		raw := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			item := v.Index(i)
+			for item.Kind() == reflect.Ptr || item.Kind() == reflect.Interface {
+				if item.IsNil() {
+					break
+				}
+				item = item.Elem()
+			}
+			if item.Kind() != reflect.Struct {
+				continue
+			}
+			if tag := item.FieldByName(name); tag.IsValid() && tag.Kind() == reflect.String {
+				raw = append(raw, tag.String())
+			}
+		}
+		return normalizeTags(raw)
+	}
+
+	tags, err := ParseTagList(probe.Data)
+	if err != nil {
+		return TagList{}
+	}
+	return tags
+}
+
+// Extract, kayıttaki etiketleri Data'ya yazar.
+func (t *TagsField) Extract(resource interface{}) {
+	t.Schema.Data = t.Entries(resource)
+}
@@ -0,0 +1,77 @@
+package fields
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestParseTagList tests tag parsing from request and column values
+func TestParseTagList(t *testing.T) {
+	cases := []struct {
+		value interface{}
+		want  []string
+	}{
+		{[]interface{}{" go ", "Panel", "go", ""}, []string{"go", "Panel"}},
+		{`["admin","Admin","ui"]`, []string{"admin", "ui"}},
+		{"news, sports ,,news", []string{"news", "sports"}},
+		{[]byte(`["a"]`), []string{"a"}},
+		{nil, []string{}},
+	}
+	for _, tc := range cases {
+		tags, err := ParseTagList(tc.value)
+		if err != nil || len(tags) != len(tc.want) {
+			t.Fatalf("Expected %v from %v, got %v (%v)", tc.want, tc.value, tags, err)
+		}
+		for i := range tags {
+			if tags[i] != tc.want[i] {
+				t.Errorf("Expected %v from %v, got %v", tc.want, tc.value, tags)
+			}
+		}
+	}
+
+	for _, value := range []interface{}{[]interface{}{"a", 1}, `["a"`, 42} {
+		if _, err := ParseTagList(value); !errors.Is(err, ErrInvalidTags) {
+			t.Errorf("Expected ErrInvalidTags for %v, got %v", value, err)
+		}
+	}
+
+	var scanned TagList
+	if err := scanned.Scan(`["x","y"]`); err != nil || len(scanned) != 2 {
+		t.Errorf("Expected tags to be scanned, got %v (%v)", scanned, err)
+	}
+	if value, err := (TagList{"x"}).Value(); err != nil || value != `["x"]` {
+		t.Errorf("Expected JSON array value, got %v (%v)", value, err)
+	}
+}
+
+type taggedPost struct {
+	Tags []taggedPostTag
+}
+
+type taggedPostTag struct {
+	ID    uint
+	Label string
+}
+
+// TestTagsField tests tags field options and entries from relations
+func TestTagsField(t *testing.T) {
+	field := Tags("Tags", "tags").MaxTags(2).AsRelation("label")
+	if field.View != "tags-field" || field.Type != TYPE_TAGS || !field.HasSuggestions() {
+		t.Errorf("Expected tags view, type and suggestions, got '%s' / '%s'", field.View, field.Type)
+	}
+	if err := field.ValidateTags(TagList{"a", "b", "c"}); !errors.Is(err, ErrTooManyTags) {
+		t.Errorf("Expected ErrTooManyTags, got %v", err)
+	}
+
+	resolved, ok := AsTagsField(field.Required())
+	if !ok || !resolved.UseRelation || resolved.RelationColumn != "label" || resolved.GetMaxTags() != 2 {
+		t.Fatalf("Expected Required result to resolve as a relation tags field, got %+v", resolved)
+	}
+	entries := resolved.Entries(&taggedPost{Tags: []taggedPostTag{{ID: 1, Label: "go"}, {ID: 2, Label: "web"}}})
+	if len(entries) != 2 || entries[0] != "go" || entries[1] != "web" {
+		t.Errorf("Expected tags from relation, got %v", entries)
+	}
+	if _, ok := AsTagsField(Text("Name", "name")); ok {
+		t.Error("Expected text field not to resolve as a tags field")
+	}
+}
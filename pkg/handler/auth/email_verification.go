@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/ferdiunal/panel.go/pkg/context"
+	"github.com/ferdiunal/panel.go/pkg/service/auth"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ResendVerificationRequest, doğrulama e-postasının yeniden gönderileceği adresi taşır.
+type ResendVerificationRequest struct {
+	Email string `json:"email"`
+}
+
+// SetEmailVerificationRedirect, doğrulama bağlantısı açıldıktan sonra tarayıcının
+// yönlendirileceği adresi ayarlar. Sonuç `verified=1` veya `error=invalid_token`
+// query parametresi olarak eklenir. Boş bırakılırsa endpoint JSON döner.
+func (h *Handler) SetEmailVerificationRedirect(redirectURL string) {
+	h.emailVerificationRedirect = strings.TrimSpace(redirectURL)
+}
+
+// VerifyEmail, doğrulama e-postasındaki bağlantıyı karşılar.
+//
+// # HTTP Endpoint
+//
+// ```
+// GET /auth/verify-email?token=...
+// {"verified": true, "user": {...}}
+// ```
+//
+// # Önemli Notlar
+//
+// - Token tek kullanımlıktır; veritabanında yalnızca sha256 hash'i saklanır
+// - Süresi dolmuş veya bilinmeyen token'lar için 400 döner
+func (h *Handler) VerifyEmail(c *context.Context) error {
+	u, err := h.service.VerifyEmail(c.Context(), c.Query("token"))
+	if err != nil {
+		if !errors.Is(err, auth.ErrInvalidEmailVerificationToken) && !errors.Is(err, auth.ErrUserNotFound) {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to verify email"})
+		}
+		if h.emailVerificationRedirect != "" {
+			return c.Redirect(appendQuery(h.emailVerificationRedirect, "error", "invalid_token"), fiber.StatusSeeOther)
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": auth.ErrInvalidEmailVerificationToken.Error(),
+			"code":  "invalid_token",
+		})
+	}
+
+	if h.emailVerificationRedirect != "" {
+		return c.Redirect(appendQuery(h.emailVerificationRedirect, "verified", "1"), fiber.StatusSeeOther)
+	}
+	return c.JSON(fiber.Map{"verified": true, "user": u})
+}
+
+// ResendEmailVerification, doğrulama e-postasını yeniden gönderir.
+//
+// # HTTP Endpoint
+//
+// ```
+// POST /auth/verify-email/resend
+// {"email": "ada@example.com"}
+// ```
+//
+// # Güvenlik
+//
+// - Hesabın var olup olmadığı veya zaten doğrulandığı yanıttan anlaşılmaz; her durumda 202 döner
+// - Aynı kullanıcıya ResendInterval içinde ikinci e-posta gönderilmez (sessizce atlanır)
+func (h *Handler) ResendEmailVerification(c *context.Context) error {
+	if !h.service.EmailVerificationEnabled() {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": auth.ErrEmailVerificationNotConfigured.Error()})
+	}
+
+	var req ResendVerificationRequest
+	if err := c.BodyParser(&req); err != nil || strings.TrimSpace(req.Email) == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	err := h.service.ResendEmailVerification(c.Context(), strings.TrimSpace(req.Email))
+	if err != nil && !errors.Is(err, auth.ErrEmailVerificationThrottled) {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to send verification email"})
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"message": "If the account exists and is not verified, a verification email has been sent",
+	})
+}
+
+func appendQuery(rawURL, key, value string) string {
+	separator := "?"
+	if strings.Contains(rawURL, "?") {
+		separator = "&"
+	}
+	return rawURL + separator + url.QueryEscape(key) + "=" + url.QueryEscape(value)
+}
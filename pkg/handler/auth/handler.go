@@ -30,9 +30,15 @@
 package auth
 
 import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+
 	"github.com/ferdiunal/panel.go/pkg/context"
 	"github.com/ferdiunal/panel.go/pkg/domain/session"
 	"github.com/ferdiunal/panel.go/pkg/domain/user"
+	paneli18n "github.com/ferdiunal/panel.go/pkg/i18n"
 	"github.com/ferdiunal/panel.go/pkg/middleware"
 	"github.com/ferdiunal/panel.go/pkg/service/auth"
 	"github.com/gofiber/fiber/v2"
@@ -82,6 +88,33 @@ type Handler struct {
 	service        *auth.Service
 	accountLockout *middleware.AccountLockout
 	environment    string
+	sessionCookie  *SessionCookieConfig
+
+	// emailVerificationRedirect, doğrulama bağlantısı açıldıktan sonra yönlendirilecek adres (boşsa JSON döner)
+	emailVerificationRedirect string
+
+	// impersonationAuthorizer, impersonation başlatma yetkisini kontrol eder (nil ise endpoint kapalıdır)
+	impersonationAuthorizer ImpersonationAuthorizer
+
+	// auditLogger, impersonation gibi olayların ayrıntılı kaydı için kullanılır (nil olabilir)
+	auditLogger middleware.AuditLogger
+
+	// avatarStorer, profil resmi yüklemelerini saklar (nil ise avatar endpoint'i kapalıdır)
+	avatarStorer AvatarStorer
+}
+
+// SessionCookieConfig, oturum cookie'sinin özniteliklerini tanımlar.
+//
+// Handler'a SetSessionCookie ile verilmezse environment'a göre varsayılanlar kullanılır:
+// `__Host-session_token`, Secure, HttpOnly, SameSite=Strict, Path=/ (test ortamında
+// `session_token` ve Secure=false).
+type SessionCookieConfig struct {
+	Name     string
+	Secure   bool
+	HTTPOnly bool
+	SameSite string
+	Domain   string
+	Path     string
 }
 
 // NewHandler, yeni bir kimlik doğrulama handler'ı oluşturur ve yapılandırır.
@@ -131,6 +164,44 @@ func NewHandler(service *auth.Service, accountLockout *middleware.AccountLockout
 	}
 }
 
+// SetSessionCookie, oturum cookie'sinin adını ve özniteliklerini ayarlar.
+//
+// Panel, Config.Security tanımlıysa bu metodu SessionConfig değerleriyle çağırır.
+// nil verilirse environment'a göre varsayılan cookie ayarlarına dönülür.
+func (h *Handler) SetSessionCookie(cfg *SessionCookieConfig) {
+	h.sessionCookie = cfg
+}
+
+// sessionCookieConfig, etkin oturum cookie ayarlarını döndürür.
+func (h *Handler) sessionCookieConfig() SessionCookieConfig {
+	if h.sessionCookie != nil {
+		return *h.sessionCookie
+	}
+
+	// Use __Host- prefix for additional security (requires Secure=true, Path=/, no Domain)
+	// In test environment, use regular cookie name to allow HTTP
+	if h.environment == "test" {
+		return SessionCookieConfig{Name: "session_token", HTTPOnly: true, SameSite: "Strict", Path: "/"}
+	}
+	return SessionCookieConfig{Name: "__Host-session_token", Secure: true, HTTPOnly: true, SameSite: "Strict", Path: "/"}
+}
+
+// clearSessionCookie, oturum cookie'sini yazıldığı Domain/Path ile temizler.
+func (h *Handler) clearSessionCookie(c *context.Context) {
+	cfg := h.sessionCookieConfig()
+	c.Cookie(&fiber.Cookie{
+		Name:     cfg.Name,
+		Value:    "",
+		Expires:  time.Unix(0, 0),
+		MaxAge:   -1,
+		HTTPOnly: cfg.HTTPOnly,
+		Secure:   cfg.Secure,
+		SameSite: cfg.SameSite,
+		Domain:   cfg.Domain,
+		Path:     cfg.Path,
+	})
+}
+
 // RegisterRequest, kullanıcı kayıt işlemi için gerekli bilgileri içeren istek yapısıdır.
 //
 // Bu yapı, yeni bir kullanıcının sisteme kaydolması için gerekli minimum bilgileri tanımlar.
@@ -229,15 +300,17 @@ type RegisterRequest struct {
 // **Hata Durumları:**
 // - `400 Bad Request`: Geçersiz request body formatı
 // - `409 Conflict`: Email adresi zaten kullanımda
+// - `422 Unprocessable Entity`: Şifre PasswordPolicy'ye uymuyor (`errors.password` altında tüm ihlaller)
 // - `500 Internal Server Error`: Sunucu hatası
 //
 // # İş Akışı
 //
 // 1. Request body'den RegisterRequest parse edilir
 // 2. Email benzersizliği kontrol edilir
-// 3. Şifre bcrypt ile hash'lenir
-// 4. Kullanıcı veritabanına kaydedilir
-// 5. Kullanıcı bilgileri döndürülür (şifre hariç)
+// 3. Şifre PasswordPolicy ile doğrulanır (uzunluk, karakter sınıfları, sızıntı listesi)
+// 4. Şifre bcrypt ile hash'lenir
+// 5. Kullanıcı veritabanına kaydedilir
+// 6. Kullanıcı bilgileri döndürülür (şifre hariç)
 //
 // # Güvenlik Özellikleri
 //
@@ -278,7 +351,6 @@ type RegisterRequest struct {
 // - Email doğrulama sistemi
 // - Sosyal medya ile kayıt (OAuth)
 // - İki faktörlü kimlik doğrulama (2FA)
-// - Şifre güçlülük kontrolü
 func (h *Handler) RegisterEmail(c *context.Context) error {
 	var req RegisterRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -290,9 +362,20 @@ func (h *Handler) RegisterEmail(c *context.Context) error {
 		if err == auth.ErrEmailAlreadyExists {
 			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
 		}
+		var policyErr *auth.PasswordPolicyError
+		if errors.As(err, &policyErr) {
+			return validationError(c, "password", passwordViolationMessages(c, policyErr)...)
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
 
+	// Doğrulama e-postası gönderilemezse kayıt geri alınmaz; kullanıcı yeniden gönderim isteyebilir
+	if h.service.EmailVerificationEnabled() {
+		if err := h.service.SendEmailVerification(c.Context(), user); err != nil {
+			log.Printf("[auth] verification email failed user=%d error=%v", user.ID, err)
+		}
+	}
+
 	// Auto login after register? For now just return user.
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"user": user,
@@ -546,7 +629,14 @@ func (h *Handler) LoginEmail(c *context.Context) error {
 		ip = forwarded
 	}
 
-	session, err := h.service.LoginEmail(c.Context(), req.Email, req.Password, ip, c.Get("User-Agent"))
+	result, err := h.service.BeginLogin(c.Context(), req.Email, req.Password, ip, c.Get("User-Agent"))
+	if errors.Is(err, auth.ErrEmailNotVerified) {
+		// Şifre doğru olduğu için başarısız deneme olarak sayılmaz
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+			"code":  "email_not_verified",
+		})
+	}
 	if err != nil {
 		// SECURITY: Record failed login attempt
 		if h.accountLockout != nil {
@@ -562,37 +652,60 @@ func (h *Handler) LoginEmail(c *context.Context) error {
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
 	}
 
+	// SECURITY: Second factor pending. Failed attempts are not reset until the
+	// challenge succeeds, so password + code guessing shares the same lockout budget.
+	if result.Challenge != nil {
+		return c.JSON(fiber.Map{
+			"two_factor_required": true,
+			"challenge_token":     result.Challenge.Token,
+			"expires":             result.Challenge.ExpiresAt,
+		})
+	}
+
 	// SECURITY: Reset failed attempts on successful login
 	if h.accountLockout != nil {
 		h.accountLockout.ResetAttempts(req.Email)
 	}
 
-	// SECURITY: Set secure session cookie
-	// Use __Host- prefix for additional security (requires Secure=true, Path=/, no Domain)
-	// In test environment, use regular cookie name to allow HTTP
-	cookieName := "__Host-session_token"
-	secure := true
-	if h.environment == "test" {
-		cookieName = "session_token"
-		secure = false
-	}
+	return h.respondWithSession(c, result.Session, result.EnrollmentRequired)
+}
 
-	c.Cookie(&fiber.Cookie{
-		Name:     cookieName,
-		Value:    session.Token,
-		Expires:  session.ExpiresAt,
-		HTTPOnly: true,
-		Secure:   secure,
-		SameSite: "Strict", // Strict for admin panels to prevent CSRF
-		Path:     "/",
-	})
+// respondWithSession, oturum cookie'sini yazar ve giriş yanıtını döndürür.
+func (h *Handler) respondWithSession(c *context.Context, sess *session.Session, enrollmentRequired bool) error {
+	h.setSessionCookie(c, sess.Token, sess.ExpiresAt)
 
-	return c.JSON(fiber.Map{
+	response := fiber.Map{
 		"session": fiber.Map{
-			"token":   session.Token,
-			"expires": session.ExpiresAt,
+			"token":   sess.Token,
+			"expires": sess.ExpiresAt,
 		},
-		"user": session.User,
+		"user": sess.User,
+	}
+	if enrollmentRequired {
+		response["two_factor_enrollment_required"] = true
+	}
+	if expired, err := h.service.PasswordExpired(c.Context(), sess.User); err != nil {
+		log.Printf("[auth] password age check failed user=%d error=%v", sess.UserID, err)
+	} else if expired {
+		response["password_expired"] = true
+	}
+	return c.JSON(response)
+}
+
+// setSessionCookie, oturum cookie'sini verilen bitiş zamanıyla yazar.
+// Giriş yanıtında ve SessionMiddleware oturumu uzattığında kullanılır.
+func (h *Handler) setSessionCookie(c *context.Context, token string, expires time.Time) {
+	// SECURITY: Set secure session cookie
+	cookie := h.sessionCookieConfig()
+	c.Cookie(&fiber.Cookie{
+		Name:     cookie.Name,
+		Value:    token,
+		Expires:  expires,
+		HTTPOnly: cookie.HTTPOnly,
+		Secure:   cookie.Secure,
+		SameSite: cookie.SameSite, // Strict for admin panels to prevent CSRF
+		Domain:   cookie.Domain,
+		Path:     cookie.Path,
 	})
 }
 
@@ -702,17 +815,14 @@ func (h *Handler) LoginEmail(c *context.Context) error {
 // - Session veritabanından tamamen silinir (geri alınamaz)
 // - Cookie temizleme işlemi tarayıcı tarafından garanti edilir
 func (h *Handler) SignOut(c *context.Context) error {
-	cookieName := "__Host-session_token"
-	if h.environment == "test" {
-		cookieName = "session_token"
-	}
+	cookieName := h.sessionCookieConfig().Name
 
 	token := c.Cookies(cookieName)
 	if token != "" {
 		h.service.Logout(c.Context(), token)
 	}
 
-	c.ClearCookie(cookieName)
+	h.clearSessionCookie(c)
 	return c.JSON(fiber.Map{"message": "Signed out"})
 }
 
@@ -854,17 +964,12 @@ func (h *Handler) SignOut(c *context.Context) error {
 // - Session süresi dolmuşsa: Cookie temizlenir, `{"session": null}` döner
 // - Veritabanı hatası: Cookie temizlenir, `{"session": null}` döner
 //
-// # Gelecek Geliştirmeler
+// # İlgili Endpoint'ler
 //
-// - Session refresh mekanizması (sliding expiration)
-// - Multiple device tracking (session listesi)
-// - Session revocation (uzaktan çıkış)
-// - Activity tracking (son aktivite zamanı)
+// - Cihaz listesi: `GET /auth/sessions`
+// - Uzaktan çıkış: `DELETE /auth/sessions/:id`, `POST /auth/sessions/revoke-others`
 func (h *Handler) GetSession(c *context.Context) error {
-	cookieName := "__Host-session_token"
-	if h.environment == "test" {
-		cookieName = "session_token"
-	}
+	cookieName := h.sessionCookieConfig().Name
 
 	token := c.Cookies(cookieName)
 	if token == "" {
@@ -873,7 +978,7 @@ func (h *Handler) GetSession(c *context.Context) error {
 
 	session, err := h.service.ValidateSession(c.Context(), token)
 	if err != nil {
-		c.ClearCookie(cookieName)
+		h.clearSessionCookie(c)
 		return c.JSON(fiber.Map{"session": nil})
 	}
 
@@ -1071,10 +1176,7 @@ func (h *Handler) SessionMiddleware(c *context.Context) error {
 		return c.Next()
 	}
 
-	cookieName := "__Host-session_token"
-	if h.environment == "test" {
-		cookieName = "session_token"
-	}
+	cookieName := h.sessionCookieConfig().Name
 
 	token := c.Cookies(cookieName)
 	if token == "" {
@@ -1083,10 +1185,74 @@ func (h *Handler) SessionMiddleware(c *context.Context) error {
 
 	session, err := h.service.ValidateSession(c.Context(), token)
 	if err != nil {
-		c.ClearCookie(cookieName)
+		h.clearSessionCookie(c)
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
 	}
 
+	// Email verification and 2FA enrollment are obligations of the account owner; an
+	// impersonator signed in with their own credentials and could not satisfy them anyway.
+	if !session.IsImpersonated() {
+		// SECURITY: Unverified users may not use the API when Enforcement is "restrict".
+		// Verification and resend endpoints are public and do not pass through here; the
+		// profile endpoint stays open so a mistyped address can be corrected.
+		if h.service.EmailVerificationRestricted(session.User) && !isProfilePath(c.Path()) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "Email address must be verified",
+				"code":  "email_not_verified",
+			})
+		}
+
+		// SECURITY: Users in roles that require 2FA may only reach the enrollment endpoints
+		// until enrollment is confirmed.
+		if !isTwoFactorPath(c.Path()) {
+			required, err := h.service.TwoFactorEnrollmentRequired(c.Context(), session.User)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to check two-factor status"})
+			}
+			if required {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"error": "Two-factor authentication must be enabled for your role",
+					"code":  "two_factor_enrollment_required",
+				})
+			}
+		}
+
+		// SECURITY: Passwords older than PasswordPolicy.MaxAge must be changed before the
+		// API can be used again; only the password change endpoint stays open.
+		if !isPasswordPath(c.Path()) {
+			expired, err := h.service.PasswordExpired(c.Context(), session.User)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to check password age"})
+			}
+			if expired {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"error": paneli18n.TransWithFallback(c.Ctx, "auth.password.expired", "Your password has expired and must be changed"),
+					"code":  "password_expired",
+				})
+			}
+		}
+	}
+
+	// SECURITY: Impersonated sessions may not touch credentials, 2FA or other sessions.
+	if session.IsImpersonated() {
+		if impersonationForbidden(c.Method(), c.Path()) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "This action is not available while impersonating a user",
+				"code":  "impersonation_forbidden",
+			})
+		}
+		c.Locals(middleware.ImpersonatorIDLocalKey, *session.ImpersonatorID)
+	}
+
+	// Sliding expiry: refresh last-seen and, when the idle timeout moved the expiry,
+	// re-issue the cookie so the browser keeps it for the new lifetime.
+	renewed, err := h.service.TouchSession(c.Context(), session)
+	if err != nil {
+		log.Printf("[auth] session touch failed: %v", err)
+	} else if renewed {
+		h.setSessionCookie(c, token, session.ExpiresAt)
+	}
+
 	c.Locals("session", session)
 	c.Locals("user", session.User)
 
@@ -1271,15 +1437,15 @@ type ForgotPasswordRequest struct {
 // 1. **Forgot Password**: Kullanıcı email adresini girer
 // 2. **Email Sent**: Şifre sıfırlama linki gönderilir
 // 3. **Click Link**: Kullanıcı email'deki linke tıklar
-// 4. **Validate Token**: Token doğrulanır (GET /reset-password?token=xxx)
-// 5. **New Password Form**: Yeni şifre formu gösterilir
-// 6. **Submit New Password**: Yeni şifre gönderilir (POST /reset-password)
-// 7. **Password Updated**: Şifre güncellenir, token silinir
-// 8. **Auto Login**: Kullanıcı otomatik giriş yapabilir (opsiyonel)
+// 4. **New Password Form**: ResetURL sayfası yeni şifre formunu gösterir
+// 5. **Submit New Password**: Token ve yeni şifre gönderilir (POST /auth/reset-password)
+// 6. **Password Updated**: Şifre politikası uygulanır, şifre güncellenir, token silinir
+// 7. **Sessions Revoked**: Kullanıcının tüm oturumları sonlandırılır; yeniden giriş yapılır
 //
 // # Hata Durumları
 //
 // - `400 Bad Request`: Geçersiz request body formatı
+// - `404 Not Found`: Şifre sıfırlama yapılandırılmamış (Config.PasswordReset)
 // - `500 Internal Server Error`: Email gönderimi başarısız (nadiren döner)
 //
 // **Not**: Email bulunamadığında bile 200 OK döner (güvenlik)
@@ -1347,8 +1513,6 @@ type ForgotPasswordRequest struct {
 // # İlgili Endpoint'ler
 //
 // - `POST /auth/reset-password`: Token ile şifre sıfırlama
-// - `GET /auth/validate-reset-token`: Token geçerliliği kontrolü
-// - `POST /auth/resend-reset-email`: Reset email'i tekrar gönderme
 //
 // # Örnek Kullanım Senaryoları
 //
@@ -1381,8 +1545,12 @@ func (h *Handler) ForgotPassword(c *context.Context) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
 	}
 
-	if err := h.service.ForgotPassword(c.Context(), req.Email); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	err := h.service.ForgotPassword(c.Context(), strings.TrimSpace(req.Email))
+	if errors.Is(err, auth.ErrPasswordResetNotConfigured) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to send password reset email"})
 	}
 
 	// Always return success for security (don't reveal if email exists)
@@ -0,0 +1,190 @@
+package auth
+
+import (
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ferdiunal/panel.go/pkg/context"
+	"github.com/ferdiunal/panel.go/pkg/domain/session"
+	"github.com/ferdiunal/panel.go/pkg/domain/user"
+	"github.com/ferdiunal/panel.go/pkg/middleware"
+	"github.com/ferdiunal/panel.go/pkg/service/auth"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ImpersonationAuthorizer, oturumdaki kullanıcının target adına oturum açıp açamayacağına karar verir.
+// Panel, izin kontrolünü ve users kaynağının policy'sini bu fonksiyonla bağlar.
+type ImpersonationAuthorizer func(c *context.Context, target *user.User) bool
+
+// ImpersonateRequest, kimliğine bürünülecek kullanıcıyı taşır.
+type ImpersonateRequest struct {
+	UserID uint `json:"user_id"`
+}
+
+// SetImpersonationAuthorizer, impersonation endpoint'lerini etkinleştirir.
+// nil verilirse POST /auth/impersonate 404 döner.
+func (h *Handler) SetImpersonationAuthorizer(authorizer ImpersonationAuthorizer) {
+	h.impersonationAuthorizer = authorizer
+}
+
+// SetAuditLogger, impersonation başlangıç/bitiş olaylarının yazılacağı logger'ı ayarlar.
+func (h *Handler) SetAuditLogger(logger middleware.AuditLogger) {
+	h.auditLogger = logger
+}
+
+// StartImpersonation, destek personelinin başka bir kullanıcı adına oturum açmasını sağlar.
+//
+// # HTTP Endpoint
+//
+// ```
+// POST /auth/impersonate
+// {"user_id": 42}
+// ```
+//
+// # Güvenlik
+//
+// - Yetki, ImpersonationAuthorizer ile (izin + hedef kullanıcı policy'si) kontrol edilir
+// - Mevcut oturum sonlandırılır; cookie, orijinal kullanıcı ID'sini taşıyan yeni oturumla değiştirilir
+// - Impersonation oturumları en fazla auth.ImpersonationLifetime geçerlidir
+// - Başlangıç `impersonation_start` olarak audit log'a yazılır
+func (h *Handler) StartImpersonation(c *context.Context) error {
+	if h.impersonationAuthorizer == nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Impersonation is not enabled"})
+	}
+	current, err := sessionOwner(c)
+	if err != nil {
+		return err
+	}
+
+	var req ImpersonateRequest
+	if err := c.BodyParser(&req); err != nil || req.UserID == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	target, err := h.service.FindUser(c.Context(), req.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	if !h.impersonationAuthorizer(c, target) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": auth.ErrImpersonationNotAllowed.Error()})
+	}
+
+	sess, err := h.service.StartImpersonation(c.Context(), current, target, c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return impersonationError(c, err)
+	}
+
+	h.logImpersonation(c, "impersonation_start", current.User, target)
+	return h.respondWithImpersonation(c, sess, current.User)
+}
+
+// StopImpersonation, impersonation oturumunu sonlandırır ve orijinal kullanıcıya geri döner.
+//
+// # HTTP Endpoint
+//
+// ```
+// POST /auth/impersonate/stop
+// ```
+func (h *Handler) StopImpersonation(c *context.Context) error {
+	current, err := sessionOwner(c)
+	if err != nil {
+		return err
+	}
+
+	sess, err := h.service.StopImpersonation(c.Context(), current, c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return impersonationError(c, err)
+	}
+
+	h.logImpersonation(c, "impersonation_stop", sess.User, current.User)
+	return h.respondWithImpersonation(c, sess, nil)
+}
+
+// CurrentImpersonator, istek cookie'sindeki oturum bir impersonation oturumuysa orijinal
+// kullanıcıyı döndürür; aksi halde nil döner. Oturum gerektirmeyen /api/init gibi
+// endpoint'lerde banner bilgisini üretmek için kullanılır.
+func (h *Handler) CurrentImpersonator(c *context.Context) *user.User {
+	token := c.Cookies(h.sessionCookieConfig().Name)
+	if token == "" {
+		return nil
+	}
+	sess, err := h.service.ValidateSession(c.Context(), token)
+	if err != nil || !sess.IsImpersonated() {
+		return nil
+	}
+	impersonator, err := h.service.FindUser(c.Context(), *sess.ImpersonatorID)
+	if err != nil {
+		return nil
+	}
+	return impersonator
+}
+
+func (h *Handler) respondWithImpersonation(c *context.Context, sess *session.Session, impersonator *user.User) error {
+	h.setSessionCookie(c, sess.Token, sess.ExpiresAt)
+	return c.JSON(fiber.Map{
+		"session": fiber.Map{
+			"token":   sess.Token,
+			"expires": sess.ExpiresAt,
+		},
+		"user":          sess.User,
+		"impersonating": impersonator != nil,
+		"impersonator":  impersonator,
+	})
+}
+
+// logImpersonation, impersonation olayını hedef kullanıcı bilgisiyle audit log'a yazar.
+func (h *Handler) logImpersonation(c *context.Context, eventType string, impersonator, target *user.User) {
+	if h.auditLogger == nil || impersonator == nil || target == nil {
+		return
+	}
+	event := middleware.AuditEvent{
+		Timestamp:  time.Now(),
+		EventType:  eventType,
+		UserID:     strconv.FormatUint(uint64(impersonator.ID), 10),
+		Email:      impersonator.Email,
+		IP:         c.IP(),
+		UserAgent:  c.Get("User-Agent"),
+		Method:     c.Method(),
+		Path:       c.Path(),
+		StatusCode: fiber.StatusOK,
+		Success:    true,
+		Resource:   "users",
+		Metadata: map[string]interface{}{
+			"target_user_id": target.ID,
+			"target_email":   target.Email,
+		},
+	}
+	if err := h.auditLogger.Log(event); err != nil {
+		log.Printf("[auth] audit log failed for %s: %v", eventType, err)
+	}
+}
+
+func impersonationError(c *context.Context, err error) error {
+	switch {
+	case errors.Is(err, auth.ErrAlreadyImpersonating), errors.Is(err, auth.ErrNotImpersonating):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, auth.ErrImpersonationNotAllowed):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, auth.ErrUserNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to switch user"})
+	}
+}
+
+// impersonationForbidden, impersonation oturumlarına kapalı olan istekleri belirler:
+// API key yönetimi, şifre ve 2FA değişiklikleri, oturum iptali ve zincirleme impersonation.
+func impersonationForbidden(method, path string) bool {
+	switch {
+	case strings.Contains(path, "/api-keys"), isPasswordPath(path):
+		return true
+	case isTwoFactorPath(path), strings.Contains(path, "/auth/sessions"):
+		return method != fiber.MethodGet
+	case strings.HasSuffix(strings.TrimSuffix(path, "/"), "/auth/impersonate"):
+		return true
+	}
+	return false
+}
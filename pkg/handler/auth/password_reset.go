@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"errors"
+
+	"github.com/ferdiunal/panel.go/pkg/context"
+	paneli18n "github.com/ferdiunal/panel.go/pkg/i18n"
+	"github.com/ferdiunal/panel.go/pkg/service/auth"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ResetPasswordRequest, sıfırlama bağlantısındaki token'ı ve yeni şifreyi taşır.
+type ResetPasswordRequest struct {
+	Token           string `json:"token"`
+	Password        string `json:"password"`
+	ConfirmPassword string `json:"confirm_password"`
+}
+
+// ResetPassword, şifre sıfırlama e-postasındaki token ile yeni şifreyi belirler.
+//
+// # HTTP Endpoint
+//
+// ```
+// POST /auth/reset-password
+// {"token": "...", "password": "...", "confirm_password": "..."}
+// {"reset": true}
+// ```
+//
+// # Önemli Notlar
+//
+// - Yeni şifre yapılandırılan PasswordPolicy'ye ve şifre geçmişine uymalıdır; ihlaller `password` altında 422 döner
+// - Politika ihlalinde token geçerliliğini korur, başarılı sıfırlamada silinir
+// - Süresi dolmuş veya bilinmeyen token'lar için 400 döner
+// - Kullanıcının tüm oturumları sonlandırılır; yeni şifreyle yeniden giriş yapılmalıdır
+func (h *Handler) ResetPassword(c *context.Context) error {
+	if !h.service.PasswordResetEnabled() {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": auth.ErrPasswordResetNotConfigured.Error()})
+	}
+
+	var req ResetPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if req.ConfirmPassword != "" && req.ConfirmPassword != req.Password {
+		return validationError(c, "confirm_password", paneli18n.TransWithFallback(c.Ctx, "auth.password.mismatch", "Passwords do not match"))
+	}
+
+	var policyErr *auth.PasswordPolicyError
+	err := h.service.ResetPassword(c.Context(), req.Token, req.Password)
+	switch {
+	case errors.Is(err, auth.ErrInvalidPasswordResetToken):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": auth.ErrInvalidPasswordResetToken.Error(),
+			"code":  "invalid_token",
+		})
+	case errors.As(err, &policyErr):
+		return validationError(c, "password", passwordViolationMessages(c, policyErr)...)
+	case err != nil:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to reset password"})
+	}
+	return c.JSON(fiber.Map{"reset": true})
+}
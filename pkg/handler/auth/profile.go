@@ -0,0 +1,228 @@
+package auth
+
+import (
+	"errors"
+	"log"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+
+	"github.com/ferdiunal/panel.go/pkg/context"
+	paneli18n "github.com/ferdiunal/panel.go/pkg/i18n"
+	"github.com/ferdiunal/panel.go/pkg/service/auth"
+	"github.com/gofiber/fiber/v2"
+)
+
+// MaxAvatarSize, POST /auth/profile/avatar ile yüklenebilecek en büyük dosya boyutudur (2 MB).
+const MaxAvatarSize = 2 << 20
+
+// avatarExtensions, profil resmi olarak kabul edilen dosya uzantılarıdır.
+var avatarExtensions = map[string]bool{".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".webp": true}
+
+// AvatarStorer, yüklenen profil resmini saklar ve erişim URL'ini döndürür.
+// Panel, users kaynağının storage handler'ını (Image alanının StoreAs callback'i
+// veya Resource.StoreHandler) bu fonksiyonla bağlar.
+type AvatarStorer func(c *context.Context, file *multipart.FileHeader) (string, error)
+
+// UpdateProfileRequest, kullanıcının kendi profilinde değiştirmek istediği alanları taşır.
+// Gönderilmeyen alanlar değiştirilmez.
+type UpdateProfileRequest struct {
+	Name  *string `json:"name"`
+	Email *string `json:"email"`
+}
+
+// ChangePasswordRequest, şifre değişikliği için mevcut ve yeni şifreyi taşır.
+// Alan adları Account sayfasındaki field key'leriyle aynıdır.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+	ConfirmPassword string `json:"confirm_password"`
+}
+
+// SetAvatarStorer, POST /auth/profile/avatar endpoint'ini etkinleştirir.
+// nil verilirse endpoint 404 döner.
+func (h *Handler) SetAvatarStorer(storer AvatarStorer) {
+	h.avatarStorer = storer
+}
+
+// UpdateProfile, oturumdaki kullanıcının adını ve e-posta adresini günceller.
+//
+// # HTTP Endpoint
+//
+// ```
+// PUT /auth/profile
+// {"name": "Ada Lovelace", "email": "ada@example.com"}
+// {"user": {...}, "emailVerificationSent": true}
+// ```
+//
+// # Önemli Notlar
+//
+//   - E-posta değişikliğinde kullanıcı doğrulanmamış olarak işaretlenir ve e-posta doğrulama
+//     açıksa yeni adrese doğrulama bağlantısı gönderilir
+//   - Enforcement "restrict" iken doğrulanmamış kullanıcılar da bu endpoint'i kullanabilir;
+//     böylece hatalı girilen adres düzeltilebilir
+//   - Impersonation oturumlarında e-posta değiştirilemez
+//   - Geçersiz alanlar için 422 ve `errors` alanında field bazlı mesajlar döner
+func (h *Handler) UpdateProfile(c *context.Context) error {
+	current, err := sessionOwner(c)
+	if err != nil {
+		return err
+	}
+
+	var req UpdateProfileRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if current.IsImpersonated() && req.Email != nil && !strings.EqualFold(strings.TrimSpace(*req.Email), current.User.Email) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "This action is not available while impersonating a user",
+			"code":  "impersonation_forbidden",
+		})
+	}
+
+	u, emailChanged, err := h.service.UpdateProfile(c.Context(), current.UserID, auth.ProfileUpdate{
+		Name:  req.Name,
+		Email: req.Email,
+	})
+	switch {
+	case errors.Is(err, auth.ErrInvalidName):
+		return validationError(c, "name", paneli18n.TransWithFallback(c.Ctx, "auth.profile.nameRequired", "Name is required"))
+	case errors.Is(err, auth.ErrInvalidEmail):
+		return validationError(c, "email", paneli18n.TransWithFallback(c.Ctx, "auth.profile.emailInvalid", "Enter a valid email address"))
+	case errors.Is(err, auth.ErrEmailAlreadyExists):
+		return validationError(c, "email", paneli18n.TransWithFallback(c.Ctx, "auth.profile.emailTaken", "This email address is already in use"))
+	case err != nil:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to update profile"})
+	}
+
+	// Doğrulama e-postası gönderilemezse değişiklik geri alınmaz; kullanıcı yeniden gönderim isteyebilir
+	sent := false
+	if emailChanged && h.service.EmailVerificationEnabled() {
+		if err := h.service.SendEmailVerification(c.Context(), u); err != nil {
+			log.Printf("[auth] verification email failed user=%d error=%v", u.ID, err)
+		} else {
+			sent = true
+		}
+	}
+	return c.JSON(fiber.Map{"user": u, "emailVerificationSent": sent})
+}
+
+// ChangePassword, oturumdaki kullanıcının şifresini değiştirir.
+//
+// # HTTP Endpoint
+//
+// ```
+// PUT /auth/password
+// {"current_password": "...", "new_password": "...", "confirm_password": "..."}
+// ```
+//
+// # Güvenlik
+//
+// - Mevcut şifre doğrulanır; yanlışsa 422 döner
+// - Yeni şifre yapılandırılan PasswordPolicy'ye uymalıdır; tüm ihlaller `new_password` altında döner
+// - Süresi dolmuş (PasswordPolicy.MaxAge) şifreler bu endpoint ile yenilenir
+// - Mevcut oturum korunur, diğer tüm oturumlar sonlandırılır
+// - Impersonation oturumlarında kullanılamaz
+func (h *Handler) ChangePassword(c *context.Context) error {
+	current, err := sessionOwner(c)
+	if err != nil {
+		return err
+	}
+
+	var req ChangePasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if req.CurrentPassword == "" {
+		return validationError(c, "current_password", paneli18n.TransWithFallback(c.Ctx, "auth.password.currentRequired", "Current password is required"))
+	}
+	if req.ConfirmPassword != "" && req.ConfirmPassword != req.NewPassword {
+		return validationError(c, "confirm_password", paneli18n.TransWithFallback(c.Ctx, "auth.password.mismatch", "Passwords do not match"))
+	}
+
+	var policyErr *auth.PasswordPolicyError
+	err = h.service.ChangePassword(c.Context(), current.UserID, req.CurrentPassword, req.NewPassword, current.ID)
+	switch {
+	case errors.Is(err, auth.ErrCurrentPasswordInvalid):
+		return validationError(c, "current_password", paneli18n.TransWithFallback(c.Ctx, "auth.password.currentInvalid", "Current password is incorrect"))
+	case errors.As(err, &policyErr):
+		return validationError(c, "new_password", passwordViolationMessages(c, policyErr)...)
+	case err != nil:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to change password"})
+	}
+	return c.JSON(fiber.Map{"updated": true})
+}
+
+// UploadAvatar, oturumdaki kullanıcının profil resmini yükler.
+//
+// # HTTP Endpoint
+//
+// ```
+// POST /auth/profile/avatar (multipart/form-data, alan adı: "avatar")
+// {"user": {..., "image": "/storage/1712.png"}}
+// ```
+//
+// # Önemli Notlar
+//
+// - Dosya, users kaynağının storage handler'ı ile saklanır (Image alanı StoreAs veya StoreHandler)
+// - Yalnızca png, jpg, gif ve webp dosyaları kabul edilir; en fazla MaxAvatarSize
+func (h *Handler) UploadAvatar(c *context.Context) error {
+	if h.avatarStorer == nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Avatar upload is not enabled"})
+	}
+	current, err := sessionOwner(c)
+	if err != nil {
+		return err
+	}
+
+	file, err := c.FormFile("avatar")
+	if err != nil {
+		return validationError(c, "avatar", paneli18n.TransWithFallback(c.Ctx, "auth.avatar.required", "Select an image to upload"))
+	}
+	if !avatarExtensions[strings.ToLower(filepath.Ext(file.Filename))] {
+		return validationError(c, "avatar", paneli18n.TransWithFallback(c.Ctx, "auth.avatar.type", "The avatar must be a PNG, JPG, GIF or WebP image"))
+	}
+	if file.Size > MaxAvatarSize {
+		return validationError(c, "avatar", paneli18n.TransWithFallback(c.Ctx, "auth.avatar.size", "The avatar may not be larger than 2 MB"))
+	}
+
+	imageURL, err := h.avatarStorer(c, file)
+	if err != nil {
+		log.Printf("[auth] avatar upload failed user=%d error=%v", current.UserID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to store avatar"})
+	}
+	u, err := h.service.UpdateAvatar(c.Context(), current.UserID, imageURL)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to update profile"})
+	}
+	return c.JSON(fiber.Map{"user": u})
+}
+
+// validationError, resource handler'larıyla aynı biçimde tek alanlı bir 422 yanıtı döner.
+func validationError(c *context.Context, field string, messages ...string) error {
+	fieldErrors := map[string][]string{field: messages}
+	return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+		"error":   paneli18n.TransWithFallback(c.Ctx, "error.validationError", "Validation error"),
+		"code":    "VALIDATION_ERROR",
+		"errors":  fieldErrors,
+		"details": fieldErrors,
+	})
+}
+
+// passwordViolationMessages, şifre politikası ihlallerini isteğin diline çevirir.
+// Çeviri yoksa servisin İngilizce mesajı kullanılır.
+func passwordViolationMessages(c *context.Context, policyErr *auth.PasswordPolicyError) []string {
+	messages := make([]string, 0, len(policyErr.Violations))
+	for _, v := range policyErr.Violations {
+		messages = append(messages, paneli18n.TransWithFallback(c.Ctx, v.Key, v.Message, v.Params))
+	}
+	return messages
+}
+
+func isProfilePath(path string) bool {
+	return strings.HasSuffix(strings.TrimSuffix(path, "/"), "/auth/profile")
+}
+
+func isPasswordPath(path string) bool {
+	return strings.HasSuffix(strings.TrimSuffix(path, "/"), "/auth/password")
+}
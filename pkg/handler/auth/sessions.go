@@ -0,0 +1,165 @@
+package auth
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ferdiunal/panel.go/pkg/context"
+	"github.com/ferdiunal/panel.go/pkg/domain/session"
+	"github.com/ferdiunal/panel.go/pkg/service/auth"
+	"github.com/gofiber/fiber/v2"
+)
+
+// SessionInfo, "oturumlarım" listesindeki tek bir cihazı temsil eder.
+// Token veya hash'i yanıtta yer almaz.
+type SessionInfo struct {
+	ID         uint      `json:"id"`
+	Device     string    `json:"device"`
+	IPAddress  string    `json:"ipAddress"`
+	UserAgent  string    `json:"userAgent"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastSeenAt time.Time `json:"lastSeenAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	Current    bool      `json:"current"`
+}
+
+// ListSessions, oturumdaki kullanıcının aktif oturumlarını listeler.
+//
+// # HTTP Endpoint
+//
+// ```
+// GET /auth/sessions
+// {"sessions": [{"id": 12, "device": "Chrome on macOS", "ipAddress": "...", "current": true, ...}]}
+// ```
+func (h *Handler) ListSessions(c *context.Context) error {
+	current, err := sessionOwner(c)
+	if err != nil {
+		return err
+	}
+
+	sessions, err := h.service.ListSessions(c.Context(), current.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to list sessions"})
+	}
+
+	items := make([]SessionInfo, 0, len(sessions))
+	for _, sess := range sessions {
+		items = append(items, SessionInfo{
+			ID:         sess.ID,
+			Device:     describeDevice(sess.UserAgent),
+			IPAddress:  sess.IPAddress,
+			UserAgent:  sess.UserAgent,
+			CreatedAt:  sess.CreatedAt,
+			LastSeenAt: sess.LastSeenAt,
+			ExpiresAt:  sess.ExpiresAt,
+			Current:    sess.ID == current.ID,
+		})
+	}
+	return c.JSON(fiber.Map{"sessions": items})
+}
+
+// RevokeSession, kullanıcının oturumlarından birini sonlandırır (uzaktan çıkış).
+//
+// # HTTP Endpoint
+//
+// ```
+// DELETE /auth/sessions/:id
+// ```
+//
+// # Önemli Notlar
+//
+// - Başka bir kullanıcıya ait oturum ID'leri için 404 döner
+// - Mevcut oturum sonlandırılırsa cookie de temizlenir
+func (h *Handler) RevokeSession(c *context.Context) error {
+	current, err := sessionOwner(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid session id"})
+	}
+
+	if err := h.service.RevokeSession(c.Context(), current.UserID, uint(id)); err != nil {
+		if errors.Is(err, auth.ErrSessionNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to revoke session"})
+	}
+
+	if uint(id) == current.ID {
+		h.clearSessionCookie(c)
+	}
+	return c.JSON(fiber.Map{"revoked": true})
+}
+
+// RevokeOtherSessions, mevcut oturum dışındaki tüm oturumları sonlandırır.
+//
+// # HTTP Endpoint
+//
+// ```
+// POST /auth/sessions/revoke-others
+// ```
+func (h *Handler) RevokeOtherSessions(c *context.Context) error {
+	current, err := sessionOwner(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.RevokeOtherSessions(c.Context(), current.UserID, current.ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to revoke sessions"})
+	}
+	return c.JSON(fiber.Map{"revoked": true})
+}
+
+// sessionOwner, SessionMiddleware'in yerleştirdiği oturumu döndürür.
+// API key ile gelen sentetik oturumun yönetilecek cihaz listesi yoktur.
+func sessionOwner(c *context.Context) (*session.Session, error) {
+	sess, ok := c.Locals("session").(*session.Session)
+	if !ok || sess == nil || sess.ID == 0 || sess.UserID == 0 {
+		return nil, c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Session management is only available for signed-in users",
+		})
+	}
+	return sess, nil
+}
+
+// describeDevice, User-Agent değerinden "Chrome on macOS" gibi kısa bir açıklama üretir.
+func describeDevice(userAgent string) string {
+	if strings.TrimSpace(userAgent) == "" {
+		return "Unknown device"
+	}
+
+	browser := "Unknown browser"
+	switch {
+	case strings.Contains(userAgent, "Edg/"):
+		browser = "Edge"
+	case strings.Contains(userAgent, "OPR/"):
+		browser = "Opera"
+	case strings.Contains(userAgent, "Firefox/"):
+		browser = "Firefox"
+	case strings.Contains(userAgent, "Chrome/"), strings.Contains(userAgent, "CriOS/"):
+		browser = "Chrome"
+	case strings.Contains(userAgent, "Safari/"):
+		browser = "Safari"
+	case strings.HasPrefix(userAgent, "curl/"):
+		return "curl"
+	}
+
+	switch {
+	case strings.Contains(userAgent, "iPhone"), strings.Contains(userAgent, "iPad"):
+		return browser + " on iOS"
+	case strings.Contains(userAgent, "Android"):
+		return browser + " on Android"
+	case strings.Contains(userAgent, "Windows"):
+		return browser + " on Windows"
+	case strings.Contains(userAgent, "Mac OS X"):
+		return browser + " on macOS"
+	case strings.Contains(userAgent, "Linux"):
+		return browser + " on Linux"
+	}
+	return browser
+}
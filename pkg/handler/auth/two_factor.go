@@ -0,0 +1,262 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/ferdiunal/panel.go/pkg/context"
+	"github.com/ferdiunal/panel.go/pkg/domain/user"
+	"github.com/ferdiunal/panel.go/pkg/service/auth"
+	"github.com/gofiber/fiber/v2"
+)
+
+// TwoFactorChallengeRequest, şifre doğrulamasından sonra ikinci faktörün gönderildiği istektir.
+// Code alanı authenticator uygulamasındaki 6 haneli kod veya bir kurtarma kodu olabilir.
+type TwoFactorChallengeRequest struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+}
+
+// TwoFactorCodeRequest, 2FA kaydını onaylamak veya kurtarma kodlarını yenilemek için kullanılır.
+type TwoFactorCodeRequest struct {
+	Code string `json:"code"`
+}
+
+// TwoFactorDisableRequest, 2FA'yı kapatmak için mevcut şifreyi taşır.
+type TwoFactorDisableRequest struct {
+	Password string `json:"password"`
+}
+
+// isTwoFactorPath, 2FA kaydı zorunlu kullanıcıların erişebildiği endpoint'leri belirler.
+func isTwoFactorPath(path string) bool {
+	return strings.Contains(path, "/auth/two-factor")
+}
+
+// TwoFactorChallenge, LoginEmail'in döndürdüğü challenge token'ı ile TOTP veya kurtarma
+// kodunu doğrular ve oturum açar.
+//
+// # HTTP Endpoint
+//
+// ```
+// POST /auth/sign-in/two-factor
+// {"challenge_token": "...", "code": "123456"}
+// ```
+//
+// # Güvenlik
+//
+//   - Başarısız kodlar AccountLockout'a kullanıcının e-postası ile kaydedilir; şifre ve kod
+//     denemeleri aynı deneme hakkını paylaşır
+//   - Challenge tek kullanımlıktır ve 5 dakika geçerlidir
+//   - Aynı TOTP kodu (zaman adımı) ikinci kez kabul edilmez
+func (h *Handler) TwoFactorChallenge(c *context.Context) error {
+	var req TwoFactorChallengeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	challenge, err := h.service.FindTwoFactorChallenge(c.Context(), req.ChallengeToken)
+	if err != nil {
+		return h.twoFactorError(c, err)
+	}
+
+	// SECURITY: Check if account is locked
+	if h.accountLockout != nil && h.accountLockout.IsLocked(challenge.Email) {
+		return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+			"error": "Account temporarily locked due to too many failed login attempts. Please try again later.",
+		})
+	}
+
+	// Get IP with fallback to X-Forwarded-For
+	ip := c.IP()
+	if forwarded := c.Get("X-Forwarded-For"); forwarded != "" {
+		ip = forwarded
+	}
+
+	session, err := h.service.CompleteTwoFactorChallenge(c.Context(), challenge, req.Code, ip, c.Get("User-Agent"))
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidTwoFactorCode) {
+			return h.recordTwoFactorFailure(c, challenge.Email, err)
+		}
+		return h.twoFactorError(c, err)
+	}
+
+	if h.accountLockout != nil {
+		h.accountLockout.ResetAttempts(challenge.Email)
+	}
+	return h.respondWithSession(c, session, false)
+}
+
+// TwoFactorStatus, oturumdaki kullanıcının 2FA durumunu döndürür.
+//
+// ```
+// GET /auth/two-factor
+// {"enabled": true, "pending": false, "required": true, "recovery_codes_remaining": 8}
+// ```
+func (h *Handler) TwoFactorStatus(c *context.Context) error {
+	u, err := twoFactorUser(c)
+	if err != nil {
+		return err
+	}
+
+	status, err := h.service.TwoFactorStatus(c.Context(), u)
+	if err != nil {
+		return h.twoFactorError(c, err)
+	}
+	return c.JSON(fiber.Map{
+		"enabled":                  status.Enabled,
+		"pending":                  status.Pending,
+		"required":                 status.Required,
+		"recovery_codes_remaining": status.RecoveryCodesRemaining,
+	})
+}
+
+// TwoFactorEnroll, yeni bir TOTP secret'ı üretir ve otpauth URI'sini döndürür.
+// Kayıt, TwoFactorConfirm ile geçerli bir kod gönderilene kadar etkin olmaz.
+//
+// ```
+// POST /auth/two-factor/enroll
+// {"secret": "JBSW...", "otpauth_url": "otpauth://totp/Panel.go:ada@example.com?..."}
+// ```
+func (h *Handler) TwoFactorEnroll(c *context.Context) error {
+	u, err := twoFactorUser(c)
+	if err != nil {
+		return err
+	}
+
+	enrollment, err := h.service.StartTwoFactorEnrollment(c.Context(), u)
+	if err != nil {
+		return h.twoFactorError(c, err)
+	}
+	return c.JSON(fiber.Map{
+		"secret":      enrollment.Secret,
+		"otpauth_url": enrollment.OTPAuthURI,
+	})
+}
+
+// TwoFactorConfirm, authenticator uygulamasındaki kodla kaydı etkinleştirir ve
+// kurtarma kodlarını döndürür. Kurtarma kodları bir daha gösterilmez.
+//
+// ```
+// POST /auth/two-factor/confirm
+// {"code": "123456"}
+// ```
+func (h *Handler) TwoFactorConfirm(c *context.Context) error {
+	u, err := twoFactorUser(c)
+	if err != nil {
+		return err
+	}
+	var req TwoFactorCodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	codes, err := h.service.ConfirmTwoFactorEnrollment(c.Context(), u.ID, req.Code)
+	if err != nil {
+		return h.twoFactorError(c, err)
+	}
+	return c.JSON(fiber.Map{"enabled": true, "recovery_codes": codes})
+}
+
+// TwoFactorRecoveryCodes, geçerli bir TOTP koduyla kurtarma kodlarını yeniler.
+// Eski kodlar geçersiz olur; başarısız kodlar AccountLockout'a kaydedilir.
+//
+// ```
+// POST /auth/two-factor/recovery-codes
+// {"code": "123456"}
+// ```
+func (h *Handler) TwoFactorRecoveryCodes(c *context.Context) error {
+	u, err := twoFactorUser(c)
+	if err != nil {
+		return err
+	}
+	var req TwoFactorCodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	if h.accountLockout != nil && h.accountLockout.IsLocked(u.Email) {
+		return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+			"error": "Account temporarily locked due to too many failed attempts. Please try again later.",
+		})
+	}
+
+	codes, err := h.service.RegenerateRecoveryCodes(c.Context(), u.ID, req.Code)
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidTwoFactorCode) {
+			return h.recordTwoFactorFailure(c, u.Email, err)
+		}
+		return h.twoFactorError(c, err)
+	}
+	return c.JSON(fiber.Map{"recovery_codes": codes})
+}
+
+// TwoFactorDisable, mevcut şifre doğrulanarak 2FA'yı kapatır.
+// Rolü 2FA zorunlu olan kullanıcılar için 403 döner.
+//
+// ```
+// POST /auth/two-factor/disable
+// {"password": "..."}
+// ```
+func (h *Handler) TwoFactorDisable(c *context.Context) error {
+	u, err := twoFactorUser(c)
+	if err != nil {
+		return err
+	}
+	var req TwoFactorDisableRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	if err := h.service.DisableTwoFactor(c.Context(), u, req.Password); err != nil {
+		return h.twoFactorError(c, err)
+	}
+	return c.JSON(fiber.Map{"enabled": false})
+}
+
+// twoFactorUser, SessionMiddleware'in yerleştirdiği kullanıcıyı döndürür.
+// API key ile gelen sentetik kullanıcının 2FA kaydı olamaz.
+func twoFactorUser(c *context.Context) (*user.User, error) {
+	u, ok := c.Locals("user").(*user.User)
+	if !ok || u == nil || u.ID == 0 {
+		return nil, c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Two-factor settings are only available for signed-in users",
+		})
+	}
+	return u, nil
+}
+
+// recordTwoFactorFailure, başarısız kodu hesap kilitleme sayacına ekler.
+func (h *Handler) recordTwoFactorFailure(c *context.Context, email string, err error) error {
+	if h.accountLockout != nil {
+		h.accountLockout.RecordFailedAttempt(email)
+		if remaining := h.accountLockout.GetRemainingAttempts(email); remaining > 0 {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error":              err.Error(),
+				"remaining_attempts": remaining,
+			})
+		}
+	}
+	return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+}
+
+// twoFactorError, servis hatalarını HTTP durum kodlarına çevirir.
+func (h *Handler) twoFactorError(c *context.Context, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, auth.ErrInvalidTwoFactorChallenge), errors.Is(err, auth.ErrInvalidCredentials):
+		status = fiber.StatusUnauthorized
+	case errors.Is(err, auth.ErrInvalidTwoFactorCode):
+		status = fiber.StatusUnprocessableEntity
+	case errors.Is(err, auth.ErrTwoFactorEnforced):
+		status = fiber.StatusForbidden
+	case errors.Is(err, auth.ErrTwoFactorNotConfigured):
+		status = fiber.StatusNotFound
+	case errors.Is(err, auth.ErrTwoFactorAlreadyEnabled), errors.Is(err, auth.ErrTwoFactorNotEnabled):
+		status = fiber.StatusConflict
+	case errors.Is(err, auth.ErrTwoFactorKeyringMissing):
+		status = fiber.StatusServiceUnavailable
+	default:
+		return c.Status(status).JSON(fiber.Map{"error": "Two-factor request failed"})
+	}
+	return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+}
@@ -0,0 +1,312 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ferdiunal/panel.go/pkg/context"
+	"github.com/ferdiunal/panel.go/pkg/core"
+	"github.com/ferdiunal/panel.go/pkg/fields"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// maxSlugAttempts, benzersiz slug üretilirken denenecek en fazla ek sayısıdır.
+const maxSlugAttempts = 100
+
+// resolveContentFields, JSON, etiket ve slug alanlarının istekteki değerlerini
+// kaydedilecek biçime çevirir. JSON değerleri doğrulanıp sıkıştırılmış JSON
+// metnine, etiketler fields.TagList'e çevrilir. Boş gönderilen (oluşturmada
+// hiç gönderilmeyen) slug'lar kaynak alandan üretilir ve tabloda çakışıyorsa
+// sonuna sayı eklenir. recordID, güncellenen kaydın kendi slug'ıyla
+// çakışmaması için kullanılır.
+func (h *FieldHandler) resolveContentFields(c *context.Context, data map[string]interface{}, recordID string) *requestValidationErrors {
+	validationErrors := newRequestValidationErrors()
+	for _, element := range h.getElements(c) {
+		if element == nil {
+			continue
+		}
+		key := element.GetKey()
+		value, ok := data[key]
+
+		switch element.GetType() {
+		case fields.TYPE_JSON:
+			if !ok {
+				continue
+			}
+			document, present, err := fields.NormalizeJSON(value)
+			if err != nil {
+				validationErrors.add(key, contentFieldMessage(c, element, key, "validation.jsonInvalid", "{{.Field}} must be valid JSON", nil))
+				delete(data, key)
+				continue
+			}
+			if present {
+				data[key] = document
+			} else {
+				data[key] = nil
+			}
+
+		case fields.TYPE_TAGS:
+			if !ok {
+				continue
+			}
+			tags, err := fields.ParseTagList(value)
+			if err != nil {
+				validationErrors.add(key, contentFieldMessage(c, element, key, "validation.tagsInvalid", "{{.Field}} must be a list of text tags", nil))
+				delete(data, key)
+				continue
+			}
+			data[key] = tags
+
+		case fields.TYPE_SLUG:
+			slug, isSlug := fields.AsSlugField(element)
+			if !isSlug {
+				continue
+			}
+			// Güncellemede gönderilmeyen slug'lar korunur
+			text, _ := value.(string)
+			text = strings.TrimSpace(text)
+			if text == "" && slug.Source != "" && (ok || recordID == "") {
+				source, _ := data[slug.Source].(string)
+				if base := fields.Slugify(source, slug.Separator); base != "" {
+					text = h.uniqueSlug(c, key, base, slug.Separator, recordID)
+				}
+			}
+			if ok || text != "" {
+				data[key] = text
+			}
+		}
+	}
+
+	if validationErrors.hasAny() {
+		return validationErrors
+	}
+	return nil
+}
+
+// validateSlug, slug'ın alanın ayracıyla biçimlendirildiğini ve tabloda başka
+// bir kayıtta kullanılmadığını doğrular.
+func (h *FieldHandler) validateSlug(
+	c *context.Context,
+	db *gorm.DB,
+	slug *fields.SlugField,
+	key string,
+	value interface{},
+	recordID string,
+	validationErrors *requestValidationErrors,
+) {
+	text, _ := value.(string)
+	if isEmptyValidationValue(text) {
+		return
+	}
+	if !slug.IsValid(text) {
+		validationErrors.add(key, contentFieldMessage(c, slug, key, "validation.slug", "{{.Field}} may only contain lowercase letters, numbers and dashes", nil))
+		return
+	}
+	if h.slugTaken(db, key, text, recordID) {
+		validationErrors.add(key, contentFieldMessage(c, slug, key, "validation.unique", "{{.Field}} has already been taken", nil))
+	}
+}
+
+// validateTagCount, etiket sayısını alanın MaxTags sınırına göre doğrular.
+func validateTagCount(c *context.Context, tags *fields.TagsField, key string, value interface{}, validationErrors *requestValidationErrors) {
+	list, err := fields.ParseTagList(value)
+	if err != nil {
+		return
+	}
+	if errors.Is(tags.ValidateTags(list), fields.ErrTooManyTags) {
+		templateData := map[string]interface{}{"Max": tags.GetMaxTags()}
+		validationErrors.add(key, contentFieldMessage(c, tags, key, "validation.tagsMax", "{{.Field}} may not have more than {{.Max}} tags", templateData))
+	}
+}
+
+// contentFieldMessage, alan etiketiyle yerelleştirilmiş doğrulama mesajı üretir.
+func contentFieldMessage(c *context.Context, element fields.Element, key, messageID, fallback string, templateData map[string]interface{}) string {
+	if templateData == nil {
+		templateData = make(map[string]interface{}, 2)
+	}
+	templateData["Field"] = resolveValidationFieldLabel(element, element.JsonSerialize(), key)
+	templateData["Key"] = key
+	return uploadValidationMessage(c, messageID, fallback, templateData)
+}
+
+// uniqueSlug, tabloda kullanılmayan ilk slug'ı döner: önce base, ardından
+// "base-2", "base-3" gibi ekli halleri denenir.
+func (h *FieldHandler) uniqueSlug(c *context.Context, column, base, separator, recordID string) string {
+	db := h.resolveValidationDB(c)
+	candidate := base
+	for attempt := 2; attempt <= maxSlugAttempts && h.slugTaken(db, column, candidate, recordID); attempt++ {
+		candidate = base + separator + strconv.Itoa(attempt)
+	}
+	return candidate
+}
+
+// slugTaken, slug'ın modelin tablosunda recordID dışındaki bir kayıtta
+// kullanılıp kullanılmadığını döner. Tablo çözülemezse false döner.
+func (h *FieldHandler) slugTaken(db *gorm.DB, column, value, recordID string) bool {
+	table, primaryColumn, ok := h.resolveProviderTableSchema(db)
+	if !ok || !safeIdentifierRegexp.MatchString(column) {
+		return false
+	}
+	query := db.Table(table).Where(clause.Eq{Column: clause.Column{Name: column}, Value: value})
+	if recordID != "" && primaryColumn != "" {
+		query = query.Where(clause.Neq{Column: clause.Column{Name: primaryColumn}, Value: recordID})
+	}
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return false
+	}
+	return count > 0
+}
+
+// takeRelationTags, ilişki modundaki (AsRelation) etiket alanlarını veriden
+// çıkarır; bu alanlar provider yerine ana kayıtla aynı transaction'da
+// syncTagRelations ile kaydedilir.
+func (h *FieldHandler) takeRelationTags(c *context.Context, data map[string]interface{}) map[string]fields.TagList {
+	var taken map[string]fields.TagList
+	for _, element := range h.getElements(c) {
+		tags, ok := fields.AsTagsField(element)
+		if !ok || !tags.UseRelation {
+			continue
+		}
+		list, ok := data[tags.GetKey()].(fields.TagList)
+		if !ok {
+			continue
+		}
+		if taken == nil {
+			taken = make(map[string]fields.TagList)
+		}
+		taken[tags.GetKey()] = list
+		delete(data, tags.GetKey())
+	}
+	return taken
+}
+
+// syncTagRelations, etiketleri kaydın many-to-many ilişkisine yazar. Etiket
+// tablosunda (büyük/küçük harf duyarsız) bulunmayan etiketler oluşturulur ve
+// ilişki gönderilen etiketlerle değiştirilir. Sonuç ilişki alanına yazılır.
+// db, ana kaydın yazıldığı transaction'dır.
+func (h *FieldHandler) syncTagRelations(c *context.Context, db *gorm.DB, record interface{}, tags map[string]fields.TagList) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	recordValue := reflect.ValueOf(record)
+	if recordValue.Kind() != reflect.Ptr || recordValue.IsNil() {
+		return fmt.Errorf("tags: record must be a pointer, got %T", record)
+	}
+
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(record); err != nil {
+		return err
+	}
+	ctx := c.UserContext()
+	columns := make(map[string]string, len(tags))
+	for _, element := range h.getElements(c) {
+		if field, ok := fields.AsTagsField(element); ok {
+			columns[field.GetKey()] = field.RelationColumn
+		}
+	}
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for key, list := range tags {
+			rel := galleryRelationship(stmt.Schema, key)
+			if rel == nil || rel.Type != schema.Many2Many {
+				return fmt.Errorf("tags: field %q requires a many-to-many relationship on %s", key, stmt.Schema.Name)
+			}
+			column := rel.FieldSchema.LookUpField(columns[key])
+			if column == nil || column.DBName == "" {
+				return fmt.Errorf("tags: field %q: %s has no %q column", key, rel.FieldSchema.Name, columns[key])
+			}
+			related, err := findOrCreateTags(tx, rel, column, list)
+			if err != nil {
+				return fmt.Errorf("tags: field %q: %w", key, err)
+			}
+			if err := tx.Model(record).Association(rel.Name).Replace(related.Interface()); err != nil {
+				return fmt.Errorf("tags: field %q: %w", key, err)
+			}
+			if err := rel.Field.Set(ctx, recordValue.Elem(), related.Interface()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// findOrCreateTags, etiketlere karşılık gelen kayıtları ilişki alanının
+// tipinde ve etiket sırasıyla döner; eksik etiketler için kayıt oluşturur.
+func findOrCreateTags(tx *gorm.DB, rel *schema.Relationship, column *schema.Field, list fields.TagList) (reflect.Value, error) {
+	related := rel.FieldSchema
+	result := reflect.MakeSlice(rel.Field.FieldType, 0, len(list))
+	if len(list) == 0 {
+		return result, nil
+	}
+
+	lowered := make([]interface{}, 0, len(list))
+	for _, tag := range list {
+		lowered = append(lowered, strings.ToLower(tag))
+	}
+	existing := reflect.New(reflect.SliceOf(reflect.PointerTo(related.ModelType)))
+	col := clause.Column{Name: column.DBName}
+	if err := tx.Where(clause.Expr{SQL: "LOWER(?) IN ?", Vars: []interface{}{col, lowered}}).Find(existing.Interface()).Error; err != nil {
+		return reflect.Value{}, err
+	}
+	byName := make(map[string]reflect.Value, existing.Elem().Len())
+	for i := 0; i < existing.Elem().Len(); i++ {
+		row := existing.Elem().Index(i)
+		if name, ok := column.ReflectValueOf(tx.Statement.Context, row.Elem()).Interface().(string); ok {
+			byName[strings.ToLower(name)] = row
+		}
+	}
+
+	pointerElems := rel.Field.FieldType.Elem().Kind() == reflect.Ptr
+	for _, tag := range list {
+		row, ok := byName[strings.ToLower(tag)]
+		if !ok {
+			row = reflect.New(related.ModelType)
+			if err := column.Set(tx.Statement.Context, row.Elem(), tag); err != nil {
+				return reflect.Value{}, err
+			}
+			if err := tx.Create(row.Interface()).Error; err != nil {
+				return reflect.Value{}, err
+			}
+			byName[strings.ToLower(tag)] = row
+		}
+		if pointerElems {
+			result = reflect.Append(result, row)
+		} else {
+			result = reflect.Append(result, row.Elem())
+		}
+	}
+	return result, nil
+}
+
+// resolveContentFieldData, JSON ve Markdown alanlarının yanıt değerlerini
+// hazırlar. JSON değeri çözülmüş haliyle döner; detay görünümünde JSON'ın
+// girintili metni "pretty", Markdown'ın güvenli HTML'i "html" anahtarıyla
+// eklenir.
+func resolveContentFieldData(ctx *core.ResourceContext, element fields.Element, serialized map[string]interface{}) {
+	detail := ctx != nil && ctx.VisibilityCtx == core.ContextDetail
+	switch element.GetType() {
+	case fields.TYPE_JSON:
+		raw := serialized["data"]
+		serialized["data"] = fields.DecodeJSON(raw)
+		if detail {
+			if pretty, err := fields.PrettyJSON(raw); err == nil {
+				serialized["pretty"] = pretty
+			}
+		}
+	case fields.TYPE_MARKDOWN:
+		if !detail {
+			return
+		}
+		source, _ := serialized["data"].(string)
+		if pointer, ok := serialized["data"].(*string); ok && pointer != nil {
+			source = *pointer
+		}
+		serialized["html"] = fields.RenderMarkdown(source)
+	}
+}
@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/ferdiunal/panel.go/pkg/context"
+	"github.com/ferdiunal/panel.go/pkg/fields"
+	"github.com/ferdiunal/panel.go/pkg/middleware"
+	"github.com/iancoleman/strcase"
+)
+
+// sensitiveAuditMask, hassas alanların audit kayıtlarında eski/yeni değerinin yerine yazılır.
+const sensitiveAuditMask = encryptedFieldMask
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// auditsDataChanges, resource veri değişikliği audit'ini açtıysa ve logger tanımlıysa true döner.
+func (h *FieldHandler) auditsDataChanges() bool {
+	return h != nil && h.AuditLogger != nil && h.AuditDataChanges
+}
+
+// auditedElements, audit'e girecek alanları anahtarlarıyla döndürür. keys boş değilse
+// yalnızca istekte gönderilen alanlar alınır; böylece formda olmayan alanlar
+// değişmiş gibi görünmez.
+func (h *FieldHandler) auditedElements(c *context.Context, keys map[string]interface{}) map[string]fields.Element {
+	elements := make(map[string]fields.Element)
+	for _, element := range h.getElements(c) {
+		if element == nil {
+			continue
+		}
+		key := element.GetKey()
+		if key == "" {
+			continue
+		}
+		if keys != nil {
+			if _, ok := keys[key]; !ok {
+				continue
+			}
+		}
+		elements[key] = element
+	}
+	return elements
+}
+
+// auditSnapshot, kaydın verilen alanlardaki değerlerini okur. Değeri okunamayan veya
+// ilişki gibi skaler olmayan alanlar atlanır.
+func auditSnapshot(record interface{}, elements map[string]fields.Element) map[string]interface{} {
+	snapshot := make(map[string]interface{}, len(elements))
+	for key := range elements {
+		if value, ok := auditRecordValue(record, key); ok {
+			snapshot[key] = value
+		}
+	}
+	return snapshot
+}
+
+// diffAuditSnapshots, önceki ve sonraki değerleri karşılaştırır ve değişen alanları döndürür.
+// Hassas alanların değerleri maskelenir.
+func diffAuditSnapshots(before, after map[string]interface{}, elements map[string]fields.Element) map[string]middleware.FieldChange {
+	changes := make(map[string]middleware.FieldChange)
+	for key, element := range elements {
+		oldValue, hadOld := before[key]
+		newValue, hasNew := after[key]
+		if !hadOld && !hasNew {
+			continue
+		}
+		if hadOld && hasNew && equalAuditValues(oldValue, newValue) {
+			continue
+		}
+
+		change := middleware.FieldChange{Old: oldValue, New: newValue}
+		if isSensitiveAuditField(key, element) {
+			change = maskFieldChange(change)
+		}
+		changes[key] = change
+	}
+	return changes
+}
+
+// logDataChange, değişiklik varsa veya kayıt oluşturulup silindiyse audit olayını yazar.
+// status, controller'ın dönecek olduğu HTTP durum kodudur; yanıt henüz yazılmadığı için açıkça verilir.
+func (h *FieldHandler) logDataChange(c *context.Context, action, recordID string, status int, changes map[string]middleware.FieldChange) {
+	if action == "update" && len(changes) == 0 {
+		return
+	}
+
+	slug := ""
+	if h.Resource != nil {
+		slug = h.Resource.Slug()
+	}
+	middleware.LogDataChange(h.AuditLogger, c.Ctx, middleware.DataChange{
+		Resource:   slug,
+		Action:     action,
+		RecordID:   recordID,
+		Changes:    changes,
+		StatusCode: status,
+	})
+}
+
+func isSensitiveAuditField(key string, element fields.Element) bool {
+	if strings.EqualFold(key, "password") {
+		return true
+	}
+	return element != nil && element.IsSensitive()
+}
+
+func maskFieldChange(change middleware.FieldChange) middleware.FieldChange {
+	if change.Old != nil {
+		change.Old = sensitiveAuditMask
+	}
+	if change.New != nil {
+		change.New = sensitiveAuditMask
+	}
+	return change
+}
+
+func equalAuditValues(a, b interface{}) bool {
+	if at, ok := a.(time.Time); ok {
+		bt, ok := b.(time.Time)
+		return ok && at.Equal(bt)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// auditRecordID, oluşturulan kaydın ID'sini okur.
+func auditRecordID(record interface{}) string {
+	value, ok := auditRecordValue(record, "id")
+	if !ok || value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// auditRecordValue, fields.Schema.Extract ile aynı kurallarla (alan adı, ID son eki,
+// json tag, map anahtarı) kayıttaki değeri okur ve audit'e yazılabilir hale getirir.
+func auditRecordValue(record interface{}, key string) (interface{}, bool) {
+	v := reflect.ValueOf(record)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+
+	var field reflect.Value
+	switch v.Kind() {
+	case reflect.Struct:
+		camelKey := strcase.ToCamel(key)
+		field = v.FieldByName(camelKey)
+		if !field.IsValid() && strings.HasSuffix(camelKey, "Id") {
+			field = v.FieldByName(strings.TrimSuffix(camelKey, "Id") + "ID")
+		}
+		if !field.IsValid() {
+			for i := 0; i < v.NumField(); i++ {
+				if strings.Split(v.Type().Field(i).Tag.Get("json"), ",")[0] == key {
+					field = v.Field(i)
+					break
+				}
+			}
+		}
+	case reflect.Map:
+		if v.Type().Key().Kind() == reflect.String {
+			field = v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key()))
+		}
+	}
+	if !field.IsValid() || !field.CanInterface() {
+		return nil, false
+	}
+	return normalizeAuditValue(field)
+}
+
+// normalizeAuditValue, pointer'ları çözer ve yalnızca skaler değerleri (metin, sayı,
+// bool, zaman ve bunların dizileri) kabul eder.
+func normalizeAuditValue(v reflect.Value) (interface{}, bool) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			// Nil ilişki pointer'ı (*Author gibi) skaler değildir
+			elem := v.Type()
+			for elem.Kind() == reflect.Ptr {
+				elem = elem.Elem()
+			}
+			if elem.Kind() == reflect.Struct && elem != timeType {
+				return nil, false
+			}
+			return nil, true
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return v.Interface(), true
+	case reflect.Struct:
+		if v.Type() == timeType {
+			return v.Interface(), true
+		}
+		return nil, false
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			return string(v.Bytes()), true
+		}
+		values := make([]interface{}, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			item, ok := normalizeAuditValue(v.Index(i))
+			if !ok {
+				return nil, false
+			}
+			values = append(values, item)
+		}
+		return values, true
+	}
+	return nil, false
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ferdiunal/panel.go/pkg/fields"
+)
+
+type auditTestRecord struct {
+	ID        uint       `json:"id"`
+	Title     string     `json:"title"`
+	Price     *float64   `json:"price"`
+	AuthorID  uint       `json:"author_id"`
+	Password  string     `json:"password"`
+	Tags      []string   `json:"tags"`
+	Author    *struct{}  `json:"author"`
+	Published *time.Time `json:"published_at"`
+}
+
+func TestAuditRecordValue(t *testing.T) {
+	price := 9.5
+	record := &auditTestRecord{ID: 7, Title: "Book", Price: &price, AuthorID: 3, Tags: []string{"a"}}
+
+	cases := map[string]interface{}{"id": uint(7), "title": "Book", "price": 9.5, "author_id": uint(3)}
+	for key, want := range cases {
+		got, ok := auditRecordValue(record, key)
+		if !ok || got != want {
+			t.Errorf("auditRecordValue(%q) = %v, %v; want %v", key, got, ok, want)
+		}
+	}
+	if got, ok := auditRecordValue(record, "published_at"); !ok || got != nil {
+		t.Errorf("expected nil pointer to read as nil, got %v, %v", got, ok)
+	}
+	if _, ok := auditRecordValue(record, "author"); ok {
+		t.Error("expected relationship struct to be skipped")
+	}
+	if _, ok := auditRecordValue(record, "missing"); ok {
+		t.Error("expected unknown key to be skipped")
+	}
+	if got, ok := auditRecordValue(map[string]interface{}{"title": "Map"}, "title"); !ok || got != "Map" {
+		t.Errorf("expected map lookup, got %v, %v", got, ok)
+	}
+	if auditRecordID(record) != "7" {
+		t.Errorf("expected record id 7, got %q", auditRecordID(record))
+	}
+}
+
+func TestDiffAuditSnapshotsMasksSensitiveFields(t *testing.T) {
+	elements := map[string]fields.Element{
+		"title":    fields.Text("Title", "title"),
+		"price":    fields.Number("Price", "price"),
+		"password": fields.Password("Password", "password"),
+		"iban":     fields.Text("IBAN", "iban").Sensitive(),
+		"ssn":      fields.Text("SSN", "ssn").Encrypted(),
+	}
+	now := time.Now()
+	before := map[string]interface{}{"title": "Book", "price": 10.0, "password": "old", "iban": "TR1", "ssn": "1", "published": now}
+	after := map[string]interface{}{"title": "Book", "price": 12.0, "password": "new", "iban": "TR2", "ssn": "2"}
+
+	changes := diffAuditSnapshots(before, after, elements)
+	if _, ok := changes["title"]; ok {
+		t.Error("expected unchanged title to be omitted")
+	}
+	if changes["price"].Old != 10.0 || changes["price"].New != 12.0 {
+		t.Errorf("unexpected price change %+v", changes["price"])
+	}
+	for _, key := range []string{"password", "iban", "ssn"} {
+		if changes[key].Old != sensitiveAuditMask || changes[key].New != sensitiveAuditMask {
+			t.Errorf("expected %s to be masked, got %+v", key, changes[key])
+		}
+	}
+
+	// Created records have no old value, deleted records no new value
+	created := diffAuditSnapshots(nil, map[string]interface{}{"password": "secret"}, elements)
+	if created["password"].Old != nil || created["password"].New != sensitiveAuditMask {
+		t.Errorf("unexpected created password change %+v", created["password"])
+	}
+
+	if !equalAuditValues(now, now.UTC()) {
+		t.Error("expected equal instants in different locations to compare equal")
+	}
+}
@@ -378,6 +378,18 @@ func HandleResolveDependencies(h *FieldHandler, c *context.Context) error {
 		})
 	}
 
+	// Repeater satırlarındaki alt alanlar satır verisiyle ayrıca çözülür
+	rowUpdates, err := resolveRepeaterDependencies(elements, req.FormData, req.ChangedFields, req.Context, c.Ctx)
+	if err != nil {
+		log.Printf("[depends][controller] repeater-resolve-failed resource=%s error=%v", c.Params("resource"), err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	for key, update := range rowUpdates {
+		updates[key] = update
+	}
+
 	log.Printf(
 		"[depends][controller] response resource=%s updatedFields=%d payload=%s",
 		c.Params("resource"),
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/ferdiunal/panel.go/pkg/core"
+	"github.com/ferdiunal/panel.go/pkg/data"
+	"github.com/ferdiunal/panel.go/pkg/fields"
+	"github.com/ferdiunal/panel.go/shared/encrypt"
+	"github.com/gofiber/fiber/v2"
+)
+
+// encryptedFieldMask, liste görünümlerinde şifreli alan değerlerinin yerine gösterilir.
+const encryptedFieldMask = "••••••••"
+
+// encryptedColumnsSetter, şifreli kolon desteği olan provider'lar için opsiyonel interface'dir.
+type encryptedColumnsSetter interface {
+	SetEncryptedColumns(cols []string)
+}
+
+// fieldKeyringSetter, şifreli kolonlar için keyring kabul eden provider'lar için opsiyonel interface'dir.
+type fieldKeyringSetter interface {
+	SetFieldKeyring(keyring *encrypt.Keyring)
+}
+
+func collectEncryptedColumns(elements []fields.Element) []string {
+	columns := make([]string, 0)
+	seen := make(map[string]struct{})
+
+	for _, element := range elements {
+		if element == nil || !element.IsEncrypted() {
+			continue
+		}
+
+		key := element.GetKey()
+		if key == "" {
+			continue
+		}
+		if _, exists := seen[key]; exists {
+			continue
+		}
+
+		seen[key] = struct{}{}
+		columns = append(columns, key)
+	}
+
+	return columns
+}
+
+func applyEncryptedColumns(provider data.DataProvider, elements []fields.Element) {
+	setter, ok := provider.(encryptedColumnsSetter)
+	if !ok {
+		return
+	}
+	setter.SetEncryptedColumns(collectEncryptedColumns(elements))
+}
+
+// SetFieldKeyring, Encrypted() alanların şifrelenip çözüleceği keyring'i handler'a ve
+// provider'ına aktarır. Panel bu keyring'i Config.FieldEncryption anahtarlarından oluşturur.
+func (h *FieldHandler) SetFieldKeyring(keyring *encrypt.Keyring) {
+	h.FieldKeyring = keyring
+	if setter, ok := h.Provider.(fieldKeyringSetter); ok {
+		setter.SetFieldKeyring(keyring)
+	}
+}
+
+// maskEncryptedFieldValue, şifreli alanların değerini index/grid görünümlerinde maskeler.
+// Detay ve form görünümleri çözülmüş değeri almaya devam eder.
+func maskEncryptedFieldValue(ctx *core.ResourceContext, element fields.Element, serialized map[string]interface{}) {
+	if ctx == nil || element == nil || !element.IsEncrypted() {
+		return
+	}
+	if ctx.VisibilityCtx != fields.ContextIndex && ctx.VisibilityCtx != fields.ContextGrid {
+		return
+	}
+
+	switch value := serialized["data"].(type) {
+	case nil:
+		return
+	case string:
+		if value == "" {
+			return
+		}
+	case *string:
+		if value == nil || *value == "" {
+			return
+		}
+	}
+	serialized["data"] = encryptedFieldMask
+}
+
+// indexQueryErrorStatus, liste sorgusu hatasını HTTP durum koduna çevirir.
+// Şifreli kolonda filtreleme/sıralama bir istemci hatasıdır (400).
+func indexQueryErrorStatus(err error) int {
+	if errors.Is(err, data.ErrEncryptedColumnQuery) {
+		return fiber.StatusBadRequest
+	}
+	return fiber.StatusInternalServerError
+}
@@ -15,9 +15,12 @@ import (
 	"github.com/ferdiunal/panel.go/pkg/data"
 	"github.com/ferdiunal/panel.go/pkg/fields"
 	internalconcurrency "github.com/ferdiunal/panel.go/pkg/internal/concurrency"
+	"github.com/ferdiunal/panel.go/pkg/middleware"
 	"github.com/ferdiunal/panel.go/pkg/notification"
 	"github.com/ferdiunal/panel.go/pkg/resource"
+	"github.com/ferdiunal/panel.go/pkg/storage"
 	"github.com/ferdiunal/panel.go/pkg/widget"
+	"github.com/ferdiunal/panel.go/shared/encrypt"
 	"github.com/iancoleman/strcase"
 	"gorm.io/gorm"
 
@@ -99,6 +102,12 @@ type FieldHandler struct {
 	IndexReorderConfig  resource.IndexReorderConfig
 	NotificationService *notification.Service
 	Concurrency         ConcurrencyConfig
+	AuditLogger         middleware.AuditLogger // Veri değişikliği olaylarının yazılacağı logger (nil: kapalı)
+	AuditDataChanges    bool                   // Resource SetAuditDataChanges(true) ile açtıysa true
+	Disks               *storage.Manager       // Store(disk, path) ile disk seçen dosya alanlarının diskleri
+	FileTracker         FileTracker            // Dosya alanlarının sahipliğini izleyip yetim dosyaları temizler (nil: kapalı)
+	Uploads             *ResumableUploads      // Parça parça (tus) gönderilen yüklemeler (nil: kapalı)
+	FieldKeyring        *encrypt.Keyring       // Encrypted() alanların şifrelendiği keyring (SetFieldKeyring ile ayarlanır)
 }
 
 func collectSearchableColumns(elements []fields.Element) []string {
@@ -137,7 +146,7 @@ func collectRelationshipPreloads(
 
 	for _, element := range elements {
 		switch element.GetView() {
-		case "has-many-field", "has-one-field", "belongs-to-many-field", "belongs-to-field":
+		case "has-many-field", "has-one-field", "belongs-to-many-field", "belongs-to-field", "gallery-field", "files-field", "repeater-field", "tags-field":
 			key := strings.TrimSpace(element.GetKey())
 			if key == "" {
 				continue
@@ -271,6 +280,17 @@ type indexGridEnabledProvider interface {
 	IsGridEnabled() bool
 }
 
+type dataChangeAuditProvider interface {
+	AuditsDataChanges() bool
+}
+
+func resolveAuditDataChanges(res resource.Resource) bool {
+	if provider, ok := res.(dataChangeAuditProvider); ok {
+		return provider.AuditsDataChanges()
+	}
+	return false
+}
+
 func resolveIndexGridEnabled(res resource.Resource) bool {
 	if provider, ok := res.(indexGridEnabledProvider); ok {
 		return provider.IsGridEnabled()
@@ -507,6 +527,7 @@ func NewResourceHandler(client interface{}, res resource.Resource, storagePath,
 	preloads := collectRelationshipPreloads(db, res.Model(), res.With(), res.Fields())
 	provider.SetWith(preloads)
 	provider.SetSearchColumns(collectSearchableColumns(res.Fields()))
+	applyEncryptedColumns(provider, res.Fields())
 
 	// Initialize notification service with provider
 	notificationService := notification.NewService(provider)
@@ -530,6 +551,7 @@ func NewResourceHandler(client interface{}, res resource.Resource, storagePath,
 		Concurrency: ConcurrencyConfig{
 			FailFast: true,
 		},
+		AuditDataChanges: resolveAuditDataChanges(res),
 	}
 }
 
@@ -633,6 +655,7 @@ func NewLensHandler(client interface{}, res resource.Resource, lens resource.Len
 	provider.SetWith(preloads)
 	provider.SetBaseQuery(lens.GetQuery())
 	provider.SetSearchColumns(collectSearchableColumns(lens.Fields()))
+	applyEncryptedColumns(provider, preloadElements)
 
 	return &FieldHandler{
 		Provider:            provider,
@@ -1032,6 +1055,19 @@ func (h *FieldHandler) resolveResourceFields(c *fiber.Ctx, ctx *core.ResourceCon
 		element.Extract(item)
 		serialized := element.JsonSerialize()
 		normalizeRelationshipCollectionData(element.GetView(), serialized)
+		if gallery, ok := fields.AsGalleryField(element); ok && c != nil {
+			serialized["data"] = h.galleryResponseItems(c.UserContext(), gallery, item)
+		} else if repeater, ok := fields.AsRepeaterField(element); ok {
+			serialized["data"] = repeater.Entries(item)
+		} else if tags, ok := fields.AsTagsField(element); ok {
+			serialized["data"] = tags.Entries(item)
+		} else if c != nil {
+			if urls := h.conversionURLs(c.UserContext(), element, serialized["data"]); urls != nil {
+				serialized["conversions"] = urls
+			}
+		}
+
+		resolveContentFieldData(ctx, element, serialized)
 
 		// Resolve options
 		h.ResolveFieldOptions(element, serialized, item)
@@ -1076,6 +1112,7 @@ func (h *FieldHandler) resolveResourceFields(c *fiber.Ctx, ctx *core.ResourceCon
 			}
 		}
 		applyDisplayCallback(element, serialized, item)
+		maskEncryptedFieldValue(ctx, element, serialized)
 
 		key, ok := serialized["key"].(string)
 		if !ok || strings.TrimSpace(key) == "" {
@@ -1709,25 +1746,7 @@ func (h *FieldHandler) ResolveFieldOptions(element fields.Element, serialized ma
 func (h *FieldHandler) parseBody(c *context.Context) (map[string]interface{}, error) {
 	var body = make(map[string]interface{})
 	elements := h.getElements(c)
-	isFileElement := func(el fields.Element) bool {
-		view := el.GetView()
-		if strings.HasPrefix(view, "file-field") ||
-			strings.HasPrefix(view, "image-field") ||
-			strings.HasPrefix(view, "video-field") ||
-			strings.HasPrefix(view, "audio-field") {
-			return true
-		}
-
-		fieldType := el.JsonSerialize()["type"]
-		switch t := fieldType.(type) {
-		case fields.ElementType:
-			return t == fields.TYPE_FILE || t == fields.TYPE_VIDEO || t == fields.TYPE_AUDIO
-		case string:
-			return t == string(fields.TYPE_FILE) || t == string(fields.TYPE_VIDEO) || t == string(fields.TYPE_AUDIO)
-		default:
-			return false
-		}
-	}
+	isFileElement := fields.IsFileElement
 
 	// Check content type
 	ctype := c.Ctx.Get("Content-Type")
@@ -1755,7 +1774,17 @@ func (h *FieldHandler) parseBody(c *context.Context) (map[string]interface{}, er
 		}
 	}
 
+	// JSON bodies may reference completed resumable uploads instead of files
+	if !strings.Contains(ctype, "multipart/form-data") && h.Uploads != nil {
+		if err := h.storeReferencedUploads(c, elements, body); err != nil {
+			return nil, err
+		}
+	}
+
 	// Handle Form Data (Multipart)
+	galleries := galleryElements(elements)
+	galleryUploads := make(map[string][]fields.GalleryItem)
+	uploadRefs := make(map[string]string)
 	if form, err := c.Ctx.MultipartForm(); err == nil {
 		for key, values := range form.Value {
 			if len(values) > 0 {
@@ -1778,6 +1807,10 @@ func (h *FieldHandler) parseBody(c *context.Context) (map[string]interface{}, er
 					if len(values) == 1 && strings.TrimSpace(values[0]) == "" {
 						body[normalizedKey] = nil
 					}
+					// Completed resumable uploads are referenced as {"upload_id": "..."}
+					if id, ok := uploadReferenceID(values[0]); ok && h.Uploads != nil {
+						uploadRefs[normalizedKey] = id
+					}
 					continue
 				}
 
@@ -1788,40 +1821,49 @@ func (h *FieldHandler) parseBody(c *context.Context) (map[string]interface{}, er
 				}
 			}
 		}
+		references, err := h.inspectUploadReferences(c, elements, uploadRefs, form.File)
+		if err != nil {
+			return nil, err
+		}
+		// Constraints are enforced for every file before any of them is stored
+		if err := h.validateUploads(c, elements, form.File); err != nil {
+			return nil, err
+		}
 		for key, files := range form.File {
-			if len(files) > 0 {
-				file := files[0]
-				if file == nil || strings.TrimSpace(file.Filename) == "" {
-					continue
-				}
-				var path string
-
-				// Check for matching element and callback
-				var callback fields.StorageCallbackFunc
-				for _, el := range elements {
-					if el.GetKey() == key {
-						callback = el.GetStorageCallback()
-						break
-					}
-				}
-
-				if callback != nil {
-					// User defined storage logic (Assuming it takes *fiber.Ctx)
-					path, err = callback(c.Ctx, file)
-					if err != nil {
-						return nil, err
+			// Gallery fields take every file sent under "key" or "key[]"
+			if gallery, ok := galleries[strings.TrimSuffix(key, "[]")]; ok {
+				normalizedKey := strings.TrimSuffix(key, "[]")
+				for _, file := range files {
+					if file == nil || strings.TrimSpace(file.Filename) == "" {
+						continue
 					}
-				} else {
-					// Use Resource StoreHandler (Takes *context.Context)
-					path, err = h.Resource.StoreHandler(c, file, h.StoragePath, h.StorageURL)
+					item, err := h.storeGalleryFile(c, gallery, file)
 					if err != nil {
 						return nil, err
 					}
+					galleryUploads[normalizedKey] = append(galleryUploads[normalizedKey], item)
 				}
+				continue
+			}
 
+			if len(files) > 0 {
+				file := files[0]
+				if file == nil || strings.TrimSpace(file.Filename) == "" {
+					continue
+				}
+				path, err := h.storeFieldFile(c, elements, key, file)
+				if err != nil {
+					return nil, err
+				}
 				body[key] = path
 			}
 		}
+		if err := h.storeUploadReferences(c, elements, references, body); err != nil {
+			return nil, err
+		}
+		if err := h.consumeUploads(c, uploadRefs); err != nil {
+			return nil, err
+		}
 
 		// Handle missing BelongsToMany fields in multipart/form-data
 		// If a BelongsToMany field is missing from the request, it implies the user unchecked all options.
@@ -1938,6 +1980,9 @@ func (h *FieldHandler) parseBody(c *context.Context) (map[string]interface{}, er
 		}
 	}
 
+	// Gallery values are merged with the record's files by resolveGalleries
+	wrapGalleryInputs(elements, body, galleryUploads)
+
 	return body, nil
 }
 
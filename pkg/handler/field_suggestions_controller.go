@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ferdiunal/panel.go/pkg/context"
+	"github.com/ferdiunal/panel.go/pkg/data"
+	"github.com/ferdiunal/panel.go/pkg/fields"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// defaultSuggestionLimit, limit parametresi verilmediğinde dönen öneri sayısıdır.
+	defaultSuggestionLimit = 10
+	// maxSuggestionLimit, tek istekte dönebilecek en fazla öneri sayısıdır.
+	maxSuggestionLimit = 50
+)
+
+// HandleFieldSuggestions, GET /api/resource/:resource/fields/:field/suggestions
+// endpoint'ini işler.
+//
+// Öneriler sırasıyla alanın WithSuggestions callback'inden, sabit Suggestions
+// listesinden (önek eşleşmesiyle) veya kolondaki mevcut farklı değerlerden
+// üretilir. Kolon araması WithAutoComplete tanımlı metin alanlarında varsayılan
+// yedektir; diğer alanlarda WithColumnSuggestions ile açılır. Etiket alanlarında
+// mevcut etiketler JSON kolonundan veya etiket ilişkisinin tablosundan okunur.
+//
+// # Query Parametreleri
+//
+//   - q: Kullanıcının yazdığı metin. MinCharsForSuggestions değerinden kısaysa
+//     sorgu çalıştırılmadan boş liste döner.
+//   - limit: Dönecek öneri sayısı (varsayılan 10, en fazla 50).
+//
+// # Yanıt Formatı
+//
+//	{
+//	    "query": "ist",
+//	    "data": [{"value": "Istanbul", "label": "Istanbul"}]
+//	}
+//
+// "query" alanı, debounce edilen isteklerin yanıtları sırasız geldiğinde
+// arayüzün eski yanıtları ayıklayabilmesi için geri gönderilir.
+//
+// # Hata Durumları
+//
+//   - 403 Forbidden: Kullanıcının ViewAny yetkisi yoksa
+//   - 404 Not Found: Alan yoksa veya alanda öneri tanımlı değilse
+//   - 422 Unprocessable Entity: Kolon önerileri bu kolonda kullanılamıyorsa
+func HandleFieldSuggestions(h *FieldHandler, c *context.Context) error {
+	if h.Policy != nil && !h.Policy.ViewAny(c) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	fieldKey := c.Params("field")
+	schema := findSuggestionField(h, c, fieldKey)
+	if schema == nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Field suggestions not found"})
+	}
+
+	query := strings.TrimSpace(c.Query("q"))
+	limit := c.QueryInt("limit", defaultSuggestionLimit)
+	if limit <= 0 {
+		limit = defaultSuggestionLimit
+	} else if limit > maxSuggestionLimit {
+		limit = maxSuggestionLimit
+	}
+
+	c.Set(fiber.HeaderCacheControl, "private, no-store")
+	if utf8.RuneCountInString(query) < schema.GetMinCharsForSuggestions() {
+		return c.JSON(fiber.Map{"query": query, "data": []interface{}{}})
+	}
+
+	var suggestions []interface{}
+	switch {
+	case schema.GetSuggestionsCallback() != nil:
+		suggestions = schema.GetSuggestionsCallback()(query)
+	case len(schema.Suggestions) > 0:
+		suggestions = filterStaticSuggestions(schema.Suggestions, query)
+	default:
+		var values []string
+		var err error
+		if tags, isTags := fields.AsTagsField(schema); isTags {
+			suggester, ok := h.Provider.(data.TagSuggester)
+			if !ok {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Field suggestions not found"})
+			}
+			values, err = suggester.SuggestTags(c, schema.GetKey(), tags.RelationColumn, query, limit)
+		} else {
+			suggester, ok := h.Provider.(data.ValueSuggester)
+			if !ok {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Field suggestions not found"})
+			}
+			values, err = suggester.SuggestValues(c, schema.GetKey(), query, limit)
+		}
+		if err != nil {
+			if errors.Is(err, data.ErrSuggestionColumn) || errors.Is(err, data.ErrEncryptedColumnQuery) {
+				return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"error": err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch suggestions"})
+		}
+		for _, value := range values {
+			suggestions = append(suggestions, value)
+		}
+	}
+
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	items := make([]interface{}, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		items = append(items, normalizeSuggestion(suggestion))
+	}
+	return c.JSON(fiber.Map{"query": query, "data": items})
+}
+
+// findSuggestionField, key'i eşleşen ve öneri tanımlı alanın Schema'sını döner.
+func findSuggestionField(h *FieldHandler, c *context.Context, key string) *fields.Schema {
+	for _, element := range h.getElements(c) {
+		if element == nil || element.GetKey() != key {
+			continue
+		}
+		if schema := schemaFromElement(element); schema != nil && schema.HasSuggestions() {
+			return schema
+		}
+	}
+	return nil
+}
+
+// filterStaticSuggestions, sabit öneri listesinden sorguyla (büyük/küçük harf
+// duyarsız) başlayanları döner.
+func filterStaticSuggestions(suggestions []interface{}, query string) []interface{} {
+	prefix := strings.ToLower(query)
+	filtered := make([]interface{}, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		if strings.HasPrefix(strings.ToLower(suggestionLabel(suggestion)), prefix) {
+			filtered = append(filtered, suggestion)
+		}
+	}
+	return filtered
+}
+
+// normalizeSuggestion, düz değerleri {value, label} nesnesine çevirir;
+// callback'in döndüğü nesneler olduğu gibi bırakılır.
+func normalizeSuggestion(suggestion interface{}) interface{} {
+	switch suggestion.(type) {
+	case map[string]interface{}, fiber.Map:
+		return suggestion
+	}
+	return fiber.Map{"value": suggestion, "label": suggestionLabel(suggestion)}
+}
+
+func suggestionLabel(suggestion interface{}) string {
+	switch typed := suggestion.(type) {
+	case string:
+		return typed
+	case map[string]interface{}:
+		if label, ok := typed["label"]; ok {
+			return fmt.Sprint(label)
+		}
+		return fmt.Sprint(typed["value"])
+	case fiber.Map:
+		return suggestionLabel(map[string]interface{}(typed))
+	}
+	return fmt.Sprint(suggestion)
+}
@@ -0,0 +1,167 @@
+package handler
+
+import (
+	stdcontext "context"
+	"log"
+
+	"github.com/ferdiunal/panel.go/pkg/context"
+	"github.com/ferdiunal/panel.go/pkg/data"
+	"github.com/ferdiunal/panel.go/pkg/domain/storedfile"
+	"github.com/ferdiunal/panel.go/pkg/fields"
+	"github.com/ferdiunal/panel.go/pkg/storage"
+	"gorm.io/gorm/schema"
+)
+
+// FileTracker, dosya alanlarının disklere yazdığı dosyaları kayıt ve alan
+// sahipliğiyle izler ve kayıttan ayrılan (yetim) dosyaları temizler.
+type FileTracker interface {
+	// TrackFiles, başarılı bir oluşturma veya güncellemeden sonra çağrılır. current
+	// ve previous alan anahtarına göre kaydın işlemden sonraki ve önceki dosyalarıdır;
+	// bu alanlarda daha önce izlenen veya previous içinde olup artık kullanılmayan
+	// dosyalar yetim olarak işaretlenir.
+	TrackFiles(ctx stdcontext.Context, resource, recordID string, current, previous map[string][]storedfile.Ref) error
+	// ReleaseFiles, kalıcı olarak silinen kaydın dosyalarını yetim olarak işaretler.
+	ReleaseFiles(ctx stdcontext.Context, resource, recordID string, files map[string][]storedfile.Ref) error
+}
+
+// trackedField, dosyaları izlenen bir alanın elemanı ve diskidir.
+type trackedField struct {
+	element fields.Element
+	disk    storage.Disk
+	name    string
+}
+
+// trackedFields, Store(disk, path) ile diske yazan dosya ve galeri alanlarını
+// döner. keys nil değilse yalnızca istekte gönderilen alanlar alınır. StoreAs
+// callback'i kullanan veya Resource.StoreHandler'a düşen alanların dosyaları
+// panelin yönetiminde olmadığı için izlenmez.
+func (h *FieldHandler) trackedFields(c *context.Context, keys map[string]interface{}) map[string]trackedField {
+	if h.FileTracker == nil || h.Disks == nil {
+		return nil
+	}
+
+	tracked := make(map[string]trackedField)
+	for _, element := range h.getElements(c) {
+		if element == nil || !IsTrackedFileElement(element) {
+			continue
+		}
+		key := element.GetKey()
+		if keys != nil {
+			if _, ok := keys[key]; !ok {
+				continue
+			}
+		}
+		disk, _, ok, err := h.fieldDisk(element)
+		if !ok || err != nil {
+			continue
+		}
+		name := element.GetStorageDisk()
+		if name == "" {
+			name = h.Disks.Default()
+		}
+		tracked[key] = trackedField{element: element, disk: disk, name: name}
+	}
+	return tracked
+}
+
+// trackedRefs, kaydın izlenen alanlardaki dosyalarını alan anahtarına göre döner.
+// Dosyası olmayan alanlar da boş listeyle yer alır; böylece temizlenen alanın
+// önceki dosyası yetim kalır.
+func trackedRefs(record interface{}, tracked map[string]trackedField) map[string][]storedfile.Ref {
+	if len(tracked) == 0 || record == nil {
+		return nil
+	}
+
+	refs := make(map[string][]storedfile.Ref, len(tracked))
+	for key, field := range tracked {
+		values, _ := FileFieldValues(record, field.element)
+		list := make([]storedfile.Ref, 0, len(values))
+		for _, value := range values {
+			if path, ok := storage.PathFromValue(field.disk, value); ok {
+				for _, stored := range StoredPaths(field.element, path) {
+					list = append(list, storedfile.Ref{Disk: field.name, Path: stored})
+				}
+			}
+		}
+		refs[key] = list
+	}
+	return refs
+}
+
+// trackFiles, oluşturma veya güncelleme sonrası kaydın dosyalarını FileTracker'a
+// bildirir. İstek başarılı olduğu için hatalar yalnızca loglanır.
+func (h *FieldHandler) trackFiles(c *context.Context, recordID string, current, previous map[string][]storedfile.Ref) {
+	if h.FileTracker == nil || len(current) == 0 || recordID == "" {
+		return
+	}
+	if err := h.FileTracker.TrackFiles(c.UserContext(), h.resourceSlug(), recordID, current, previous); err != nil {
+		log.Printf("[storage] tracking files of %s/%s failed: %v", h.resourceSlug(), recordID, err)
+	}
+}
+
+// releaseFiles, silinen kaydın dosyalarını FileTracker'a bildirir. Soft delete
+// edilen kayıtlar geri yüklenebileceği için dosyaları korunur.
+func (h *FieldHandler) releaseFiles(c *context.Context, recordID string, record interface{}) {
+	if h.FileTracker == nil {
+		return
+	}
+	if soft, ok := h.Provider.(data.SoftDeleter); ok && soft.UsesSoftDelete() {
+		return
+	}
+	files := trackedRefs(record, h.trackedFields(c, nil))
+	if err := h.FileTracker.ReleaseFiles(c.UserContext(), h.resourceSlug(), recordID, files); err != nil {
+		log.Printf("[storage] releasing files of %s/%s failed: %v", h.resourceSlug(), recordID, err)
+	}
+}
+
+func (h *FieldHandler) resourceSlug() string {
+	if h.Resource == nil {
+		return ""
+	}
+	return h.Resource.Slug()
+}
+
+// IsTrackedFileElement, alanın dosyalarının panel tarafından izlenebilecek bir
+// dosya veya galeri alanı olup olmadığını döner. StoreAs callback'i kullanan
+// alanların dosyaları uygulamanın sorumluluğundadır.
+func IsTrackedFileElement(element fields.Element) bool {
+	if element.GetStorageCallback() != nil {
+		return false
+	}
+	_, isGallery := fields.AsGalleryField(element)
+	return isGallery || fields.IsFileElement(element)
+}
+
+// FileFieldValues, dosya veya galeri alanının kayıttaki değerlerini (disk yolu
+// ya da herkese açık URL) döner. Alan izlenen bir dosya alanı değilse ok false
+// döner. Değerler storage.PathFromValue ile disk yoluna çevrilir.
+func FileFieldValues(record interface{}, element fields.Element) ([]string, bool) {
+	if element == nil || !IsTrackedFileElement(element) {
+		return nil, false
+	}
+	var values []string
+	if gallery, ok := fields.AsGalleryField(element); ok {
+		for _, item := range gallery.Items(record) {
+			values = append(values, item.Path)
+		}
+	} else if value, ok := auditRecordValue(record, element.GetKey()); ok {
+		if s, ok := value.(string); ok && s != "" {
+			values = append(values, s)
+		}
+	}
+	return values, true
+}
+
+// AttachmentRelation, ek tablosuna yazan galeri alanının ilişki adını döner.
+// Kayıtlar toplu okunurken bu ilişki Preload edilmelidir; alan ek tablosu
+// kullanmıyorsa boş döner.
+func AttachmentRelation(modelSchema *schema.Schema, element fields.Element) string {
+	gallery, ok := fields.AsGalleryField(element)
+	if !ok || !gallery.UseAttachments {
+		return ""
+	}
+	if rel := galleryRelationship(modelSchema, element.GetKey()); rel != nil {
+		return rel.Name
+	}
+	return ""
+}
@@ -0,0 +1,480 @@
+package handler
+
+import (
+	stdcontext "context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ferdiunal/panel.go/pkg/context"
+	"github.com/ferdiunal/panel.go/pkg/fields"
+	"github.com/ferdiunal/panel.go/pkg/storage"
+	"github.com/iancoleman/strcase"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// galleryTemporaryURLExpiry, özel disklerdeki galeri dosyaları için yanıtta
+// üretilen süreli URL'lerin geçerlilik süresidir.
+const galleryTemporaryURLExpiry = 30 * time.Minute
+
+// galleryInput, parseBody'nin galeri alanları için ürettiği ara değerdir.
+// raw istemcinin gönderdiği sıralama listesidir (manifest); uploads aynı
+// istekte yüklenip diske yazılmış dosyalardır. resolveGalleries bunu
+// fields.GalleryItems'a çevirir.
+type galleryInput struct {
+	raw     interface{}
+	hasRaw  bool
+	uploads []fields.GalleryItem
+}
+
+// galleryManifestEntry, manifestteki tek bir öğedir: mevcut bir dosyanın yolu
+// veya bu istekte yüklenen dosyanın sırası (upload) ve isteğe bağlı açıklama.
+type galleryManifestEntry struct {
+	path    string
+	upload  int
+	caption *string
+}
+
+// galleryElements, elemanlar içindeki galeri alanlarını key'leriyle döner.
+func galleryElements(elements []fields.Element) map[string]*fields.GalleryField {
+	galleries := make(map[string]*fields.GalleryField)
+	for _, element := range elements {
+		if element == nil {
+			continue
+		}
+		if gallery, ok := fields.AsGalleryField(element); ok {
+			galleries[element.GetKey()] = gallery
+		}
+	}
+	return galleries
+}
+
+// storeGalleryFile, galeri alanına yüklenen tek bir dosyayı tekli dosya
+// alanlarıyla aynı sırayla (StoreAs callback'i, alan diski, Resource.StoreHandler)
+// saklar ve dosyanın GalleryItem karşılığını döner.
+func (h *FieldHandler) storeGalleryFile(c *context.Context, element fields.Element, file *multipart.FileHeader) (fields.GalleryItem, error) {
+	item := fields.GalleryItem{
+		Name:     file.Filename,
+		Size:     file.Size,
+		MimeType: file.Header.Get("Content-Type"),
+	}
+
+	if callback := element.GetStorageCallback(); callback != nil {
+		path, err := callback(c.Ctx, file)
+		if err != nil {
+			return item, err
+		}
+		item.Path, item.URL = path, path
+		return item, nil
+	}
+
+	if disk, dir, ok, err := h.fieldDisk(element); ok {
+		if err != nil {
+			return item, err
+		}
+		path, err := storage.PutUpload(c.UserContext(), disk, dir, file)
+		if err != nil {
+			return item, err
+		}
+		item.Path, item.URL = path, disk.URL(path)
+		return item, nil
+	}
+
+	path, err := h.Resource.StoreHandler(c, file, h.StoragePath, h.StorageURL)
+	if err != nil {
+		return item, err
+	}
+	item.Path, item.URL = path, path
+	return item, nil
+}
+
+// wrapGalleryInputs, gövdedeki galeri değerlerini yüklenen dosyalarla birlikte
+// galleryInput'a sarar. Ne manifest ne dosya gönderilen alanlara dokunulmaz.
+func wrapGalleryInputs(elements []fields.Element, body map[string]interface{}, uploads map[string][]fields.GalleryItem) {
+	for key := range galleryElements(elements) {
+		raw, hasRaw := body[key]
+		if !hasRaw && len(uploads[key]) == 0 {
+			continue
+		}
+		body[key] = &galleryInput{raw: raw, hasRaw: hasRaw, uploads: uploads[key]}
+	}
+}
+
+// resolveGalleries, galeri alanlarının istekteki değerlerini kaydın mevcut
+// dosyalarıyla birleştirerek sıralı fields.GalleryItems'a çevirir.
+//
+// Manifest gönderilmezse mevcut dosyalar korunur ve yeni yüklemeler sona
+// eklenir. Manifest gönderilirse yalnızca listelenen dosyalar listelendiği
+// sırayla tutulur; listede olmayan mevcut dosyalar kaldırılır, listede
+// olmayan yeni yüklemeler sona eklenir. Manifest JSON dizisi veya JSON metni
+// olabilir; öğeleri dosya yolu ya da {"path"|"upload", "caption"} nesnesidir.
+// Boş manifest (null, "" veya []) alanı temizler.
+func (h *FieldHandler) resolveGalleries(c *context.Context, data map[string]interface{}, existing interface{}) *requestValidationErrors {
+	validationErrors := newRequestValidationErrors()
+	for key, gallery := range galleryElements(h.getElements(c)) {
+		value, ok := data[key]
+		if !ok {
+			continue
+		}
+		input, isInput := value.(*galleryInput)
+		if !isInput {
+			input = &galleryInput{raw: value, hasRaw: true}
+		}
+
+		current := fields.GalleryItems{}
+		if existing != nil {
+			current = gallery.Items(existing)
+		}
+
+		label := resolveValidationFieldLabel(gallery, gallery.JsonSerialize(), key)
+		templateData := map[string]interface{}{"Field": label, "Key": key}
+
+		items, valid := mergeGalleryItems(current, input)
+		if !valid {
+			validationErrors.add(key, uploadValidationMessage(c, "validation.fileUnknown", "{{.Field}} references a file that does not belong to this record", templateData))
+			delete(data, key)
+			continue
+		}
+		if gallery.MaxFiles > 0 && len(items) > gallery.MaxFiles {
+			templateData["Max"] = gallery.MaxFiles
+			validationErrors.add(key, uploadValidationMessage(c, "validation.fileCount", "{{.Field}} may not have more than {{.Max}} files", templateData))
+		}
+		data[key] = items
+	}
+
+	if validationErrors.hasAny() {
+		return validationErrors
+	}
+	return nil
+}
+
+// mergeGalleryItems, mevcut dosyalara manifesti ve yüklemeleri uygular.
+// Manifest kayda ait olmayan bir yola veya olmayan bir yüklemeye başvurursa
+// valid false döner.
+func mergeGalleryItems(current fields.GalleryItems, input *galleryInput) (fields.GalleryItems, bool) {
+	items := make(fields.GalleryItems, 0, len(current)+len(input.uploads))
+	usedUploads := make(map[int]bool, len(input.uploads))
+
+	if !input.hasRaw {
+		items = append(items, current...)
+	} else {
+		entries, ok := parseGalleryManifest(input.raw)
+		if !ok {
+			return nil, false
+		}
+		byPath := make(map[string]fields.GalleryItem, len(current))
+		for _, item := range current {
+			byPath[item.Path] = item
+		}
+		seenPaths := make(map[string]bool, len(entries))
+
+		for _, entry := range entries {
+			var item fields.GalleryItem
+			if entry.upload >= 0 {
+				if entry.upload >= len(input.uploads) {
+					return nil, false
+				}
+				if usedUploads[entry.upload] {
+					continue
+				}
+				usedUploads[entry.upload] = true
+				item = input.uploads[entry.upload]
+			} else {
+				existing, found := byPath[entry.path]
+				if !found {
+					return nil, false
+				}
+				if seenPaths[entry.path] {
+					continue
+				}
+				seenPaths[entry.path] = true
+				item = existing
+			}
+			if entry.caption != nil {
+				item.Caption = *entry.caption
+			}
+			items = append(items, item)
+		}
+	}
+
+	for i, upload := range input.uploads {
+		if !usedUploads[i] {
+			items = append(items, upload)
+		}
+	}
+	for i := range items {
+		items[i].Position = i
+	}
+	return items, true
+}
+
+// parseGalleryManifest, istemcinin gönderdiği sıralama listesini çözer.
+func parseGalleryManifest(raw interface{}) ([]galleryManifestEntry, bool) {
+	var list []interface{}
+	switch typed := raw.(type) {
+	case nil:
+		return nil, true
+	case string:
+		trimmed := strings.TrimSpace(typed)
+		if trimmed == "" || trimmed == "null" {
+			return nil, true
+		}
+		if !strings.HasPrefix(trimmed, "[") {
+			list = []interface{}{trimmed}
+		} else if err := json.Unmarshal([]byte(trimmed), &list); err != nil {
+			return nil, false
+		}
+	case []string:
+		for _, path := range typed {
+			list = append(list, path)
+		}
+	case []interface{}:
+		list = typed
+	default:
+		return nil, false
+	}
+
+	entries := make([]galleryManifestEntry, 0, len(list))
+	for _, rawEntry := range list {
+		entry := galleryManifestEntry{upload: -1}
+		switch typed := rawEntry.(type) {
+		case string:
+			entry.path = strings.TrimSpace(typed)
+		case map[string]interface{}:
+			if path, ok := typed["path"].(string); ok {
+				entry.path = strings.TrimSpace(path)
+			}
+			if upload, exists := typed["upload"]; exists && upload != nil {
+				index, ok := galleryUploadIndex(upload)
+				if !ok {
+					return nil, false
+				}
+				entry.upload = index
+			}
+			if caption, ok := typed["caption"].(string); ok {
+				entry.caption = &caption
+			}
+		default:
+			return nil, false
+		}
+		if entry.upload < 0 && entry.path == "" {
+			return nil, false
+		}
+		entries = append(entries, entry)
+	}
+	return entries, true
+}
+
+// galleryUploadIndex, manifestteki upload sırasını sayıya çevirir.
+func galleryUploadIndex(value interface{}) (int, bool) {
+	switch typed := value.(type) {
+	case float64:
+		if typed < 0 || typed != float64(int(typed)) {
+			return 0, false
+		}
+		return int(typed), true
+	case int:
+		return typed, typed >= 0
+	case string:
+		index, err := strconv.Atoi(strings.TrimSpace(typed))
+		return index, err == nil && index >= 0
+	default:
+		return 0, false
+	}
+}
+
+// takeAttachmentGalleries, ek tablosuna yazılan (AsAttachments) galeri
+// alanlarını veriden çıkarır; bu alanlar provider yerine ana kayıtla aynı
+// transaction'da syncGalleryAttachments ile kaydedilir.
+func (h *FieldHandler) takeAttachmentGalleries(c *context.Context, data map[string]interface{}) map[string]fields.GalleryItems {
+	var taken map[string]fields.GalleryItems
+	for key, gallery := range galleryElements(h.getElements(c)) {
+		if !gallery.UseAttachments {
+			continue
+		}
+		items, ok := data[key].(fields.GalleryItems)
+		if !ok {
+			continue
+		}
+		if taken == nil {
+			taken = make(map[string]fields.GalleryItems)
+		}
+		taken[key] = items
+		delete(data, key)
+	}
+	return taken
+}
+
+// syncGalleryAttachments, galeri öğelerini kaydın has-many ilişkisindeki ek
+// kayıtlarına yazar. Yolu eşleşen ek kayıtları güncellenir, yeni dosyalar için
+// kayıt oluşturulur, listede olmayan kayıtlar silinir. Sonuç, ilişki alanına
+// yazılarak yanıtın yeniden sorgu yapılmadan oluşturulması sağlanır. db, ana
+// kaydın yazıldığı transaction'dır.
+func (h *FieldHandler) syncGalleryAttachments(c *context.Context, db *gorm.DB, record interface{}, galleries map[string]fields.GalleryItems) error {
+	if len(galleries) == 0 {
+		return nil
+	}
+	recordValue := reflect.ValueOf(record)
+	if recordValue.Kind() != reflect.Ptr || recordValue.IsNil() {
+		return fmt.Errorf("gallery: record must be a pointer, got %T", record)
+	}
+
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(record); err != nil {
+		return err
+	}
+	ctx := c.UserContext()
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for key, items := range galleries {
+			rel := galleryRelationship(stmt.Schema, key)
+			if rel == nil || rel.Type != schema.HasMany {
+				return fmt.Errorf("gallery: field %q requires a has-many relationship on %s", key, stmt.Schema.Name)
+			}
+			rows, err := syncGalleryRelation(ctx, tx, rel, recordValue.Elem(), items)
+			if err != nil {
+				return fmt.Errorf("gallery: field %q: %w", key, err)
+			}
+			if err := rel.Field.Set(ctx, recordValue.Elem(), rows.Interface()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// galleryRelationship, alan key'ine karşılık gelen ilişkiyi bulur.
+func galleryRelationship(modelSchema *schema.Schema, key string) *schema.Relationship {
+	for _, candidate := range []string{key, strcase.ToCamel(key), strings.ToLower(key)} {
+		if rel, ok := modelSchema.Relationships.Relations[candidate]; ok {
+			return rel
+		}
+	}
+	for name, rel := range modelSchema.Relationships.Relations {
+		if strcase.ToSnake(name) == strcase.ToSnake(key) {
+			return rel
+		}
+	}
+	return nil
+}
+
+// hasManyConditions, has-many ilişkisinin alt kayıtlarını sahibine bağlayan
+// yabancı anahtar değerlerini döner.
+func hasManyConditions(ctx stdcontext.Context, rel *schema.Relationship, owner reflect.Value) (map[string]interface{}, error) {
+	conditions := make(map[string]interface{}, len(rel.References))
+	for _, ref := range rel.References {
+		if ref.OwnPrimaryKey {
+			value, zero := ref.PrimaryKey.ValueOf(ctx, owner)
+			if zero {
+				return nil, fmt.Errorf("owner primary key is empty")
+			}
+			conditions[ref.ForeignKey.DBName] = value
+		} else if ref.PrimaryValue != "" {
+			conditions[ref.ForeignKey.DBName] = ref.PrimaryValue
+		}
+	}
+	return conditions, nil
+}
+
+// syncGalleryRelation, tek bir ilişkinin ek kayıtlarını items ile eşitler ve
+// ilişki alanının tipinde sıralı kayıt listesini döner.
+func syncGalleryRelation(ctx stdcontext.Context, tx *gorm.DB, rel *schema.Relationship, owner reflect.Value, items fields.GalleryItems) (reflect.Value, error) {
+	related := rel.FieldSchema
+	pathField := related.LookUpField("path")
+	if pathField == nil || related.LookUpField("position") == nil {
+		return reflect.Value{}, fmt.Errorf("%s must have path and position columns", related.Name)
+	}
+
+	conditions, err := hasManyConditions(ctx, rel, owner)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	existing := reflect.New(reflect.SliceOf(reflect.PointerTo(related.ModelType)))
+	if err := tx.Where(conditions).Find(existing.Interface()).Error; err != nil {
+		return reflect.Value{}, err
+	}
+	byPath := make(map[string]reflect.Value, existing.Elem().Len())
+	for i := 0; i < existing.Elem().Len(); i++ {
+		row := existing.Elem().Index(i)
+		if path, _ := pathField.ValueOf(ctx, row.Elem()); path != nil {
+			byPath[fmt.Sprint(path)] = row
+		}
+	}
+
+	result := reflect.MakeSlice(rel.Field.FieldType, 0, len(items))
+	pointerElems := rel.Field.FieldType.Elem().Kind() == reflect.Ptr
+	for _, item := range items {
+		row, ok := byPath[item.Path]
+		if ok {
+			delete(byPath, item.Path)
+		} else {
+			row = reflect.New(related.ModelType)
+		}
+
+		columns := map[string]interface{}{
+			"path":      item.Path,
+			"url":       item.URL,
+			"name":      item.Name,
+			"size":      item.Size,
+			"mime_type": item.MimeType,
+			"caption":   item.Caption,
+			"position":  item.Position,
+		}
+		for column, value := range columns {
+			if field := related.LookUpField(column); field != nil {
+				if err := field.Set(ctx, row.Elem(), value); err != nil {
+					return reflect.Value{}, err
+				}
+			}
+		}
+		for column, value := range conditions {
+			if err := related.LookUpField(column).Set(ctx, row.Elem(), value); err != nil {
+				return reflect.Value{}, err
+			}
+		}
+		if err := tx.Save(row.Interface()).Error; err != nil {
+			return reflect.Value{}, err
+		}
+
+		if pointerElems {
+			result = reflect.Append(result, row)
+		} else {
+			result = reflect.Append(result, row.Elem())
+		}
+	}
+
+	for _, stale := range byPath {
+		if err := tx.Delete(stale.Interface()).Error; err != nil {
+			return reflect.Value{}, err
+		}
+	}
+	return result, nil
+}
+
+// galleryResponseItems, yanıta yazılacak galeri öğelerini hazırlar. URL'i
+// olmayan öğeler için alan diskinin herkese açık adresi, özel disklerde ise
+// süreli URL üretilir.
+func (h *FieldHandler) galleryResponseItems(ctx stdcontext.Context, gallery *fields.GalleryField, item interface{}) fields.GalleryItems {
+	items := gallery.Items(item)
+	disk, _, ok, err := h.fieldDisk(gallery)
+	if !ok || err != nil {
+		return items
+	}
+	for i := range items {
+		if items[i].URL != "" || items[i].Path == "" {
+			continue
+		}
+		if url := disk.URL(items[i].Path); url != "" {
+			items[i].URL = url
+		} else if url, err := disk.TemporaryURL(ctx, items[i].Path, galleryTemporaryURLExpiry); err == nil {
+			items[i].URL = url
+		}
+	}
+	return items
+}
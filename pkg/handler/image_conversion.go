@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"bytes"
+	stdcontext "context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ferdiunal/panel.go/pkg/context"
+	"github.com/ferdiunal/panel.go/pkg/fields"
+	"github.com/ferdiunal/panel.go/pkg/storage"
+)
+
+// GenerateImageConversions, diskteki orijinal resimden dönüşümleri üretir ve
+// orijinalin yanına yazar. Var olan dönüşümlerin üzerine yazılır. Uzantısı
+// dönüştürülebilir bir resim olmayan dosyalar (SVG, PDF vb.) atlanır.
+func GenerateImageConversions(ctx stdcontext.Context, disk storage.Disk, original string, conversions []fields.ImageConversion) error {
+	if len(conversions) == 0 || !storage.CanConvertImage(original) {
+		return nil
+	}
+	r, err := disk.Get(ctx, original)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return putImageConversions(ctx, disk, original, content, conversions)
+}
+
+func putImageConversions(ctx stdcontext.Context, disk storage.Disk, original string, content []byte, conversions []fields.ImageConversion) error {
+	for _, conversion := range conversions {
+		converted, contentType, err := storage.ConvertImage(content, conversion.ImageOptions)
+		if err != nil {
+			return fmt.Errorf("conversion %q of %s: %w", conversion.Name, original, err)
+		}
+		err = disk.Put(ctx, conversion.Path(original), bytes.NewReader(converted), storage.PutOptions{
+			ContentType: contentType,
+			Size:        int64(len(converted)),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StoredPaths, alana yazılan dosyanın diskte kapladığı yolları döner: orijinal
+// ve (resimlerde) dönüşümleri. Dosya izleme ve temizlik dönüşümleri orijinalle
+// birlikte ele almak için bunu kullanır.
+func StoredPaths(element fields.Element, original string) []string {
+	paths := []string{original}
+	if !storage.CanConvertImage(original) {
+		return paths
+	}
+	for _, conversion := range fields.ImageConversions(element) {
+		paths = append(paths, conversion.Path(original))
+	}
+	return paths
+}
+
+// storeImageConversions, alan diskine yazılan yüklemenin dönüşümlerini üretir.
+// Resim çözümlenemezse orijinal ve üretilen dönüşümler silinir ve yükleme
+// doğrulama hatasıyla (422) reddedilir.
+func (h *FieldHandler) storeImageConversions(c *context.Context, element fields.Element, disk storage.Disk, original string, file storage.Upload) error {
+	conversions := fields.ImageConversions(element)
+	if len(conversions) == 0 || !storage.CanConvertImage(original) {
+		return nil
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	content, err := io.ReadAll(src)
+	src.Close()
+	if err != nil {
+		return err
+	}
+
+	err = putImageConversions(c.UserContext(), disk, original, content, conversions)
+	if err == nil {
+		return nil
+	}
+	for _, path := range StoredPaths(element, original) {
+		_ = disk.Delete(c.UserContext(), path)
+	}
+	if !errors.Is(err, storage.ErrInvalidImage) && !errors.Is(err, storage.ErrUnsupportedImage) {
+		return err
+	}
+
+	key := element.GetKey()
+	templateData := map[string]interface{}{
+		"Field": resolveValidationFieldLabel(element, element.JsonSerialize(), key),
+		"Key":   key,
+	}
+	validationErrors := newRequestValidationErrors()
+	validationErrors.add(key, uploadValidationMessage(c, "validation.fileInvalid", "{{.Field}} could not be processed", templateData))
+	return &uploadValidationError{errors: validationErrors}
+}
+
+// conversionURLs, resim alanının değeri için dönüşüm adından URL'e eşlemeyi
+// döner. Herkese açık disklerde diskin adresi, özel disklerde süreli URL
+// kullanılır. Alanın dönüşümü yoksa veya değer bu diske ait değilse nil döner.
+func (h *FieldHandler) conversionURLs(ctx stdcontext.Context, element fields.Element, value interface{}) map[string]string {
+	conversions := fields.ImageConversions(element)
+	if len(conversions) == 0 || element.GetStorageCallback() != nil {
+		return nil
+	}
+	stored, ok := value.(string)
+	if !ok || stored == "" {
+		return nil
+	}
+	disk, _, ok, err := h.fieldDisk(element)
+	if !ok || err != nil {
+		return nil
+	}
+	original, ok := storage.PathFromValue(disk, stored)
+	if !ok || !storage.CanConvertImage(original) {
+		return nil
+	}
+
+	urls := make(map[string]string, len(conversions))
+	for _, conversion := range conversions {
+		path := conversion.Path(original)
+		if url := disk.URL(path); url != "" {
+			urls[conversion.Name] = url
+		} else if url, err := disk.TemporaryURL(ctx, path, galleryTemporaryURLExpiry); err == nil {
+			urls[conversion.Name] = url
+		}
+	}
+	return urls
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"github.com/ferdiunal/panel.go/pkg/fields"
+	"github.com/ferdiunal/panel.go/pkg/query"
+)
+
+// resolveIndexFilters, Filterable alanlardan filtre tanımlarını üretir ve bu
+// alanlara gelen filtreleri tanımlarına göre doğrulayıp dönüştürür. Tanımı
+// olmayan filtreler olduğu gibi provider'a bırakılır; kolon doğrulaması
+// provider tarafında yapılır.
+func resolveIndexFilters(elements []fields.Element, filters []query.Filter) ([]fields.IndexFilter, []query.Filter, error) {
+	definitions := fields.IndexFilters(elements)
+	if len(definitions) == 0 || len(filters) == 0 {
+		return definitions, filters, nil
+	}
+
+	byKey := make(map[string]fields.IndexFilter, len(definitions))
+	for _, definition := range definitions {
+		byKey[definition.Key] = definition
+	}
+
+	resolved := make([]query.Filter, 0, len(filters))
+	for _, filter := range filters {
+		definition, ok := byKey[filter.Field]
+		if !ok {
+			resolved = append(resolved, filter)
+			continue
+		}
+		normalized, err := definition.Normalize(filter)
+		if err != nil {
+			return definitions, nil, err
+		}
+		resolved = append(resolved, normalized)
+	}
+	return definitions, resolved, nil
+}
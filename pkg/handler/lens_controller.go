@@ -321,6 +321,13 @@ func HandleLens(h *FieldHandler, c *context.Context) error {
 		})
 	}
 
+	filterDefinitions, filters, err := resolveIndexFilters(elements, queryParams.Filters)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
 	var sorts []data.Sort
 	for _, s := range queryParams.Sorts {
 		sorts = append(sorts, data.Sort{
@@ -351,7 +358,7 @@ func HandleLens(h *FieldHandler, c *context.Context) error {
 		PerPage:         queryParams.PerPage,
 		Sorts:           sorts,
 		Search:          queryParams.Search,
-		Filters:         queryParams.Filters,
+		Filters:         filters,
 		ViaResource:     queryParams.ViaResource,
 		ViaResourceId:   queryParams.ViaResourceId,
 		ViaRelationship: queryParams.ViaRelationship,
@@ -371,7 +378,7 @@ func HandleLens(h *FieldHandler, c *context.Context) error {
 
 	result, err := h.Provider.Index(c, req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		return c.Status(indexQueryErrorStatus(err)).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
@@ -447,6 +454,7 @@ func HandleLens(h *FieldHandler, c *context.Context) error {
 		"grid_enabled":     h.IndexGridEnabled,
 		"record_title_key": recordTitleKey,
 		"headers":          headers,
+		"filters":          filterDefinitions,
 	})
 }
 
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"fmt"
+
+	"github.com/ferdiunal/panel.go/pkg/context"
+	"github.com/ferdiunal/panel.go/pkg/data"
+	"github.com/ferdiunal/panel.go/pkg/fields"
+	"gorm.io/gorm"
+)
+
+// pendingRelations, provider yazımından sonra kaydın ilişkilerine eşitlenecek
+// alanları taşır. Bu alanlar provider'a gitmeden önce veriden çıkarılır.
+type pendingRelations struct {
+	galleries map[string]fields.GalleryItems
+	repeaters map[string]fields.RepeaterRows
+	tags      map[string]fields.TagList
+}
+
+// takePendingRelations, ilişki tablolarına yazılan alanları veriden çıkarır.
+func (h *FieldHandler) takePendingRelations(c *context.Context, payload map[string]interface{}) pendingRelations {
+	return pendingRelations{
+		galleries: h.takeAttachmentGalleries(c, payload),
+		repeaters: h.takeHasManyRepeaters(c, payload),
+		tags:      h.takeRelationTags(c, payload),
+	}
+}
+
+func (r pendingRelations) empty() bool {
+	return len(r.galleries) == 0 && len(r.repeaters) == 0 && len(r.tags) == 0
+}
+
+// createWithRelations, kaydı oluşturur ve bekleyen ilişkileri aynı transaction
+// içinde eşitler.
+func (h *FieldHandler) createWithRelations(c *context.Context, payload map[string]interface{}, relations pendingRelations) (interface{}, error) {
+	return h.writeWithRelations(c, relations, func(provider data.DataProvider) (interface{}, error) {
+		return provider.Create(c, payload)
+	})
+}
+
+// updateWithRelations, kaydı günceller ve bekleyen ilişkileri aynı transaction
+// içinde eşitler.
+func (h *FieldHandler) updateWithRelations(c *context.Context, id string, payload map[string]interface{}, relations pendingRelations) (interface{}, error) {
+	return h.writeWithRelations(c, relations, func(provider data.DataProvider) (interface{}, error) {
+		return provider.Update(c, id, payload)
+	})
+}
+
+// writeWithRelations, write ile yapılan provider yazımını ve ilişki
+// eşitlemelerini tek transaction içinde çalıştırır. Eşitlemelerden biri
+// başarısız olursa ana kayıt da geri alınır; böylece istemci hata aldığında
+// yarım kalmış bir kayıt bırakılmaz. Eşitlenecek ilişki yoksa yazım doğrudan
+// provider üzerinden yapılır.
+func (h *FieldHandler) writeWithRelations(c *context.Context, relations pendingRelations, write func(data.DataProvider) (interface{}, error)) (interface{}, error) {
+	if relations.empty() {
+		return write(h.Provider)
+	}
+
+	txProvider, err := h.Provider.BeginTx(c)
+	if err != nil {
+		return nil, err
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = txProvider.Rollback()
+		}
+	}()
+
+	db, ok := txProvider.GetClient().(*gorm.DB)
+	if !ok || db == nil {
+		return nil, fmt.Errorf("relations: database is not available")
+	}
+
+	result, err := write(txProvider)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.syncGalleryAttachments(c, db, result, relations.galleries); err != nil {
+		return nil, err
+	}
+	if err := h.syncRepeaterRelations(c, db, result, relations.repeaters); err != nil {
+		return nil, err
+	}
+	if err := h.syncTagRelations(c, db, result, relations.tags); err != nil {
+		return nil, err
+	}
+
+	if err := txProvider.Commit(); err != nil {
+		return nil, err
+	}
+	committed = true
+	return result, nil
+}
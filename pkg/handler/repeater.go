@@ -0,0 +1,372 @@
+package handler
+
+import (
+	stdcontext "context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ferdiunal/panel.go/pkg/context"
+	"github.com/ferdiunal/panel.go/pkg/fields"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// repeaterElements, elemanlar içindeki repeater alanlarını key'leriyle döner.
+func repeaterElements(elements []fields.Element) map[string]*fields.RepeaterField {
+	repeaters := make(map[string]*fields.RepeaterField)
+	for _, element := range elements {
+		if element == nil {
+			continue
+		}
+		if repeater, ok := fields.AsRepeaterField(element); ok {
+			repeaters[element.GetKey()] = repeater
+		}
+	}
+	return repeaters
+}
+
+// resolveRepeaters, repeater alanlarının istekteki değerlerini (JSON dizisi
+// veya multipart formlarda JSON metni) fields.RepeaterRows'a çevirir. Satırlarda
+// yalnızca alt alan key'leri tutulur; has-many modunda mevcut satırı
+// tanımlayan "id" de korunur.
+func (h *FieldHandler) resolveRepeaters(c *context.Context, data map[string]interface{}) *requestValidationErrors {
+	validationErrors := newRequestValidationErrors()
+	for key, repeater := range repeaterElements(h.getElements(c)) {
+		value, ok := data[key]
+		if !ok {
+			continue
+		}
+
+		rows, err := fields.ParseRepeaterRows(value)
+		if err != nil {
+			label := resolveValidationFieldLabel(repeater, repeater.JsonSerialize(), key)
+			templateData := map[string]interface{}{"Field": label, "Key": key}
+			validationErrors.add(key, uploadValidationMessage(c, "validation.repeaterInvalid", "{{.Field}} must be a list of rows", templateData))
+			delete(data, key)
+			continue
+		}
+
+		keys := repeaterRowKeys(repeater)
+		normalized := make(fields.RepeaterRows, 0, len(rows))
+		for _, row := range rows {
+			clean := make(map[string]interface{}, len(keys))
+			for _, nestedKey := range keys {
+				if nestedValue, exists := row[nestedKey]; exists {
+					clean[nestedKey] = nestedValue
+				}
+			}
+			normalized = append(normalized, clean)
+		}
+		data[key] = normalized
+	}
+
+	if validationErrors.hasAny() {
+		return validationErrors
+	}
+	return nil
+}
+
+// repeaterRowKeys, satırlarda kabul edilen key'leri döner.
+func repeaterRowKeys(repeater *fields.RepeaterField) []string {
+	keys := make([]string, 0, len(repeater.RepeaterFields)+1)
+	if repeater.UseHasMany {
+		keys = append(keys, "id")
+	}
+	for _, nested := range repeater.RepeaterFields {
+		if nested == nil || strings.TrimSpace(nested.GetKey()) == "" {
+			continue
+		}
+		keys = append(keys, strings.TrimSpace(nested.GetKey()))
+	}
+	return keys
+}
+
+// validateRepeaterRows, satır sayısını MinRepeats/MaxRepeats sınırlarına göre
+// ve her satırı alt alanların kurallarıyla doğrular. Alt alan hataları
+// "items.2.price" biçimindeki key'lere yazılır. Alt alanlarda unique kuralı,
+// satırlar ana tablonun sütunu olmadığından uygulanmaz.
+func (h *FieldHandler) validateRepeaterRows(
+	c *context.Context,
+	db *gorm.DB,
+	repeater *fields.RepeaterField,
+	serialized map[string]interface{},
+	key string,
+	value interface{},
+	visibilityCtx fields.VisibilityContext,
+	validationErrors *requestValidationErrors,
+) {
+	label := resolveValidationFieldLabel(repeater, serialized, key)
+	templateData := map[string]interface{}{"Field": label, "Key": key}
+
+	rows, err := fields.ParseRepeaterRows(value)
+	if err != nil {
+		validationErrors.add(key, uploadValidationMessage(c, "validation.repeaterInvalid", "{{.Field}} must be a list of rows", templateData))
+		return
+	}
+
+	if err := repeater.ValidateRepeats(len(rows)); err != nil {
+		switch {
+		case errors.Is(err, fields.ErrTooFewRepeats):
+			templateData["Min"] = repeater.GetMinRepeats()
+			validationErrors.add(key, uploadValidationMessage(c, "validation.repeaterMin", "{{.Field}} must have at least {{.Min}} rows", templateData))
+		case errors.Is(err, fields.ErrTooManyRepeats):
+			templateData["Max"] = repeater.GetMaxRepeats()
+			validationErrors.add(key, uploadValidationMessage(c, "validation.repeaterMax", "{{.Field}} may not have more than {{.Max}} rows", templateData))
+		}
+	}
+
+	for i, row := range rows {
+		for _, nested := range repeater.RepeaterFields {
+			if nested == nil {
+				continue
+			}
+			if setter, ok := nested.(interface{ SetContextForI18n(*fiber.Ctx) }); ok {
+				setter.SetContextForI18n(c.Ctx)
+			}
+			nestedKey := strings.TrimSpace(nested.GetKey())
+			if nestedKey == "" {
+				continue
+			}
+
+			nestedSerialized := nested.JsonSerialize()
+			if readOnly, ok := nestedSerialized["read_only"].(bool); ok && readOnly {
+				continue
+			}
+			if disabled, ok := nestedSerialized["disabled"].(bool); ok && disabled {
+				continue
+			}
+
+			rules := make([]fields.ValidationRule, 0)
+			for _, rule := range collectFieldValidationRules(nested, nestedSerialized, visibilityCtx) {
+				if normalizeValidationRuleName(rule.Name) != "unique" {
+					rules = append(rules, rule)
+				}
+			}
+
+			// Satır bütün olarak gönderildiğinden eksik alt alan boş değer sayılır
+			errorKey := key + "." + strconv.Itoa(i) + "." + nestedKey
+			for _, message := range h.validateElementValue(c, db, nested, nestedSerialized, rules, nestedKey, row[nestedKey], true, visibilityCtx, "") {
+				validationErrors.add(errorKey, message)
+			}
+		}
+	}
+}
+
+// takeHasManyRepeaters, has-many modundaki (AsHasMany) repeater alanlarını
+// veriden çıkarır; bu alanlar provider yerine ana kayıtla aynı transaction'da
+// syncRepeaterRelations ile kaydedilir.
+func (h *FieldHandler) takeHasManyRepeaters(c *context.Context, data map[string]interface{}) map[string]fields.RepeaterRows {
+	var taken map[string]fields.RepeaterRows
+	for key, repeater := range repeaterElements(h.getElements(c)) {
+		if !repeater.UseHasMany {
+			continue
+		}
+		rows, ok := data[key].(fields.RepeaterRows)
+		if !ok {
+			continue
+		}
+		if taken == nil {
+			taken = make(map[string]fields.RepeaterRows)
+		}
+		taken[key] = rows
+		delete(data, key)
+	}
+	return taken
+}
+
+// syncRepeaterRelations, repeater satırlarını kaydın has-many ilişkisindeki alt
+// kayıtlarına yazar. id'si kayda ait bir alt kayıtla eşleşen satırlar
+// güncellenir, diğerleri için yeni kayıt oluşturulur, listede olmayan alt
+// kayıtlar silinir. Sonuç ilişki alanına yazılır. db, ana kaydın yazıldığı
+// transaction'dır.
+func (h *FieldHandler) syncRepeaterRelations(c *context.Context, db *gorm.DB, record interface{}, repeaters map[string]fields.RepeaterRows) error {
+	if len(repeaters) == 0 {
+		return nil
+	}
+	recordValue := reflect.ValueOf(record)
+	if recordValue.Kind() != reflect.Ptr || recordValue.IsNil() {
+		return fmt.Errorf("repeater: record must be a pointer, got %T", record)
+	}
+
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(record); err != nil {
+		return err
+	}
+	ctx := c.UserContext()
+	elements := repeaterElements(h.getElements(c))
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for key, rows := range repeaters {
+			rel := galleryRelationship(stmt.Schema, key)
+			if rel == nil || rel.Type != schema.HasMany {
+				return fmt.Errorf("repeater: field %q requires a has-many relationship on %s", key, stmt.Schema.Name)
+			}
+			result, err := syncRepeaterRelation(ctx, tx, rel, recordValue.Elem(), repeaterRowKeys(elements[key]), rows)
+			if err != nil {
+				return fmt.Errorf("repeater: field %q: %w", key, err)
+			}
+			if err := rel.Field.Set(ctx, recordValue.Elem(), result.Interface()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// syncRepeaterRelation, tek bir ilişkinin alt kayıtlarını satırlarla eşitler ve
+// ilişki alanının tipinde sıralı kayıt listesini döner.
+func syncRepeaterRelation(ctx stdcontext.Context, tx *gorm.DB, rel *schema.Relationship, owner reflect.Value, keys []string, rows fields.RepeaterRows) (reflect.Value, error) {
+	related := rel.FieldSchema
+	primary := related.PrioritizedPrimaryField
+	if primary == nil {
+		return reflect.Value{}, fmt.Errorf("%s must have a primary key", related.Name)
+	}
+
+	conditions, err := hasManyConditions(ctx, rel, owner)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	existing := reflect.New(reflect.SliceOf(reflect.PointerTo(related.ModelType)))
+	if err := tx.Where(conditions).Find(existing.Interface()).Error; err != nil {
+		return reflect.Value{}, err
+	}
+	byID := make(map[string]reflect.Value, existing.Elem().Len())
+	for i := 0; i < existing.Elem().Len(); i++ {
+		row := existing.Elem().Index(i)
+		if id, zero := primary.ValueOf(ctx, row.Elem()); !zero {
+			byID[repeaterRowID(id)] = row
+		}
+	}
+
+	result := reflect.MakeSlice(rel.Field.FieldType, 0, len(rows))
+	pointerElems := rel.Field.FieldType.Elem().Kind() == reflect.Ptr
+	positionField := related.LookUpField("position")
+	for i, values := range rows {
+		// Başka bir kayda ait veya bilinmeyen id'ler yeni satır olarak eklenir
+		var record reflect.Value
+		if id, ok := values["id"]; ok && id != nil {
+			if record, ok = byID[repeaterRowID(id)]; ok {
+				delete(byID, repeaterRowID(id))
+			}
+		}
+		if !record.IsValid() {
+			record = reflect.New(related.ModelType)
+		}
+
+		for _, key := range keys {
+			value, exists := values[key]
+			if key == "id" || !exists {
+				continue
+			}
+			if field := related.LookUpField(key); field != nil && field.DBName != "" {
+				if err := field.Set(ctx, record.Elem(), value); err != nil {
+					return reflect.Value{}, fmt.Errorf("row %d: %s: %w", i, key, err)
+				}
+			}
+		}
+		if positionField != nil {
+			if err := positionField.Set(ctx, record.Elem(), i); err != nil {
+				return reflect.Value{}, err
+			}
+		}
+		for column, value := range conditions {
+			if err := related.LookUpField(column).Set(ctx, record.Elem(), value); err != nil {
+				return reflect.Value{}, err
+			}
+		}
+		if err := tx.Save(record.Interface()).Error; err != nil {
+			return reflect.Value{}, err
+		}
+
+		if pointerElems {
+			result = reflect.Append(result, record)
+		} else {
+			result = reflect.Append(result, record.Elem())
+		}
+	}
+
+	for _, stale := range byID {
+		if err := tx.Delete(stale.Interface()).Error; err != nil {
+			return reflect.Value{}, err
+		}
+	}
+	return result, nil
+}
+
+// repeaterRowID, satır id'sini karşılaştırma için metne çevirir. JSON'dan gelen
+// sayılar float64 olduğundan tam sayılar üslü gösterim olmadan yazılır.
+func repeaterRowID(id interface{}) string {
+	if number, ok := id.(float64); ok {
+		return strconv.FormatFloat(number, 'f', -1, 64)
+	}
+	return fmt.Sprint(id)
+}
+
+// resolveRepeaterDependencies, repeater satırlarındaki alt alanların
+// bağımlılıklarını çözer. "items.2.qty" gibi değişen alanlar yalnızca o satırı,
+// üst seviye alanlar ise bütün satırları etkiler. Her satır, form verisinin
+// üzerine satır değerleri yazılarak çözülür ve güncellemeler
+// "items.2.total" biçimindeki key'lerle döner.
+func resolveRepeaterDependencies(
+	elements []fields.Element,
+	formData map[string]interface{},
+	changedFields []string,
+	mode string,
+	ctx *fiber.Ctx,
+) (map[string]*fields.FieldUpdate, error) {
+	updates := make(map[string]*fields.FieldUpdate)
+	for key, repeater := range repeaterElements(elements) {
+		rows, err := fields.ParseRepeaterRows(formData[key])
+		if err != nil || len(rows) == 0 {
+			continue
+		}
+
+		nestedFields := make([]*fields.Schema, 0, len(repeater.RepeaterFields))
+		for _, nested := range repeater.RepeaterFields {
+			if nestedSchema := schemaFromElement(nested); nestedSchema != nil {
+				nestedFields = append(nestedFields, nestedSchema)
+			}
+		}
+		resolver := fields.NewDependencyResolver(nestedFields, mode)
+		if err := resolver.DetectCircularDependencies(); err != nil {
+			return nil, err
+		}
+
+		for i, row := range rows {
+			prefix := key + "." + strconv.Itoa(i) + "."
+			rowChanged := make([]string, 0, len(changedFields))
+			for _, changed := range changedFields {
+				if strings.HasPrefix(changed, prefix) {
+					rowChanged = append(rowChanged, strings.TrimPrefix(changed, prefix))
+				} else if !strings.Contains(changed, ".") {
+					rowChanged = append(rowChanged, changed)
+				}
+			}
+			if len(rowChanged) == 0 {
+				continue
+			}
+
+			rowData := make(map[string]interface{}, len(formData)+len(row))
+			for name, value := range formData {
+				rowData[name] = value
+			}
+			for name, value := range row {
+				rowData[name] = value
+			}
+
+			rowUpdates, err := resolver.ResolveDependencies(rowData, rowChanged, ctx)
+			if err != nil {
+				return nil, err
+			}
+			for nestedKey, update := range rowUpdates {
+				updates[prefix+nestedKey] = update
+			}
+		}
+	}
+	return updates, nil
+}
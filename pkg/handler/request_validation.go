@@ -147,42 +147,74 @@ func (h *FieldHandler) validateRequestPayload(
 		}
 
 		value, hasValue := payload[key]
+		if repeater, ok := fields.AsRepeaterField(element); ok && (hasValue || visibilityCtx == fields.ContextCreate) {
+			h.validateRepeaterRows(c, db, repeater, serialized, key, value, visibilityCtx, validationErrors)
+		}
+		if slug, ok := fields.AsSlugField(element); ok && hasValue {
+			h.validateSlug(c, db, slug, key, value, recordID, validationErrors)
+		}
+		if tags, ok := fields.AsTagsField(element); ok && hasValue {
+			validateTagCount(c, tags, key, value, validationErrors)
+		}
+
 		rules := collectFieldValidationRules(element, serialized, visibilityCtx)
-		customValidators := collectFieldCustomValidators(element)
-		if len(rules) == 0 && len(customValidators) == 0 {
-			continue
+		for _, message := range h.validateElementValue(c, db, element, serialized, rules, key, value, hasValue, visibilityCtx, recordID) {
+			validationErrors.add(key, message)
 		}
+	}
 
-		fieldLabel := resolveValidationFieldLabel(element, serialized, key)
-		messageOverrides := resolveValidationMessageOverrides(serialized["props"])
+	if !validationErrors.hasAny() {
+		return nil
+	}
 
-		for _, rule := range rules {
-			if shouldSkipValidationRule(rule, hasValue, value, visibilityCtx) {
-				continue
-			}
+	return validationErrors
+}
 
-			message := h.runValidationRule(c, db, element, rule, fieldLabel, key, value, recordID, messageOverrides)
-			if message != "" {
-				validationErrors.add(key, message)
-			}
-		}
+// validateElementValue, tek bir alan değerini kurallara ve özel doğrulayıcılara
+// göre doğrular ve hata mesajlarını döner. Repeater satırlarındaki alt alanlar
+// da bu yolla doğrulanır.
+func (h *FieldHandler) validateElementValue(
+	c *panelcontext.Context,
+	db *gorm.DB,
+	element fields.Element,
+	serialized map[string]interface{},
+	rules []fields.ValidationRule,
+	key string,
+	value interface{},
+	hasValue bool,
+	visibilityCtx fields.VisibilityContext,
+	recordID string,
+) []string {
+	customValidators := collectFieldCustomValidators(element)
+	if len(rules) == 0 && len(customValidators) == 0 {
+		return nil
+	}
 
-		if !hasValue || isEmptyValidationValue(value) {
+	fieldLabel := resolveValidationFieldLabel(element, serialized, key)
+	messageOverrides := resolveValidationMessageOverrides(serialized["props"])
+
+	var messages []string
+	for _, rule := range rules {
+		if shouldSkipValidationRule(rule, hasValue, value, visibilityCtx) {
 			continue
 		}
 
-		for _, customValidator := range customValidators {
-			if err := customValidator(value, c); err != nil {
-				validationErrors.add(key, err.Error())
-			}
+		message := h.runValidationRule(c, db, element, rule, fieldLabel, key, value, recordID, messageOverrides)
+		if message != "" {
+			messages = append(messages, message)
 		}
 	}
 
-	if !validationErrors.hasAny() {
-		return nil
+	if !hasValue || isEmptyValidationValue(value) {
+		return messages
 	}
 
-	return validationErrors
+	for _, customValidator := range customValidators {
+		if err := customValidator(value, c); err != nil {
+			messages = append(messages, err.Error())
+		}
+	}
+	return messages
 }
 
 func (h *FieldHandler) resolveValidationElements(c *panelcontext.Context, resourceCtx *core.ResourceContext) []fields.Element {
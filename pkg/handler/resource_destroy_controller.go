@@ -225,6 +225,13 @@ func HandleResourceDestroy(h *FieldHandler, c *context.Context) error {
 	if err := h.Provider.Delete(c, id); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
+	h.releaseFiles(c, id, item)
+
+	// Silinen kaydın son değerleri audit'e yazılır
+	if h.auditsDataChanges() {
+		auditElements := h.auditedElements(c, nil)
+		h.logDataChange(c, "delete", id, fiber.StatusOK, diffAuditSnapshots(auditSnapshot(item, auditElements), nil, auditElements))
+	}
 
 	// Add default success notification if none exists
 	if c.Resource() != nil {
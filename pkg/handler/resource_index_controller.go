@@ -301,6 +301,15 @@ func HandleResourceIndex(h *FieldHandler, c *context.Context) error {
 		})
 	}
 
+	// Filterable fields produce typed filter definitions; matching filters are
+	// validated and converted to the field type before reaching the provider.
+	filterDefinitions, filters, err := resolveIndexFilters(elements, queryParams.Filters)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
 	// Convert query.Sort to data.Sort
 	var sorts []data.Sort
 	for _, s := range queryParams.Sorts {
@@ -335,7 +344,7 @@ func HandleResourceIndex(h *FieldHandler, c *context.Context) error {
 		PerPage:         queryParams.PerPage,
 		Sorts:           sorts,
 		Search:          queryParams.Search,
-		Filters:         queryParams.Filters,
+		Filters:         filters,
 		ViaResource:     queryParams.ViaResource,
 		ViaResourceId:   queryParams.ViaResourceId,
 		ViaRelationship: queryParams.ViaRelationship,
@@ -373,7 +382,7 @@ func HandleResourceIndex(h *FieldHandler, c *context.Context) error {
 	// Fetch Data
 	result, err := h.Provider.Index(c, req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		return c.Status(indexQueryErrorStatus(err)).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
@@ -438,6 +447,7 @@ func HandleResourceIndex(h *FieldHandler, c *context.Context) error {
 			"record_title_key": recordTitleKey,
 			"grid_enabled":     h.IndexGridEnabled,
 			"headers":          headers,
+			"filters":          filterDefinitions,
 			"policy": fiber.Map{
 				"create":   h.Policy == nil || h.Policy.Create(c),
 				"view_any": h.Policy == nil || h.Policy.ViewAny(c),
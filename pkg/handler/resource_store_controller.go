@@ -2,6 +2,7 @@ package handler
 
 import (
 	"github.com/ferdiunal/panel.go/pkg/context"
+	"github.com/ferdiunal/panel.go/pkg/fields"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -224,21 +225,49 @@ import (
 func HandleResourceStore(h *FieldHandler, c *context.Context) error {
 	data, err := h.parseBody(c)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+		return respondParseBodyError(c, err)
 	}
 
 	if h.Policy != nil && !h.Policy.Create(c) {
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Unauthorized"})
 	}
 
+	if validationErrors := h.resolveGalleries(c, data, nil); validationErrors != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(validationErrors.response(c.Ctx))
+	}
+
+	if validationErrors := h.resolveRepeaters(c, data); validationErrors != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(validationErrors.response(c.Ctx))
+	}
+
+	if validationErrors := h.resolveContentFields(c, data, ""); validationErrors != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(validationErrors.response(c.Ctx))
+	}
+
 	if validationErrors := h.validateCreatePayload(c, data); validationErrors != nil {
 		return c.Status(fiber.StatusUnprocessableEntity).JSON(validationErrors.response(c.Ctx))
 	}
 
-	result, err := h.Provider.Create(c, data)
+	// Ek tablosuna yazılan galeriler, has-many repeater'lar ve ilişkili etiketler kayıtla aynı transaction'da eşitlenir
+	relations := h.takePendingRelations(c, data)
+	trackedFiles := h.trackedFields(c, nil)
+
+	// Audit edilecek alanlar provider veriyi işlemeden önce belirlenir
+	var auditElements map[string]fields.Element
+	if h.auditsDataChanges() {
+		auditElements = h.auditedElements(c, data)
+	}
+
+	result, err := h.createWithRelations(c, data, relations)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
+	h.trackFiles(c, auditRecordID(result), trackedRefs(result, trackedFiles), nil)
+
+	if auditElements != nil {
+		changes := diffAuditSnapshots(nil, auditSnapshot(result, auditElements), auditElements)
+		h.logDataChange(c, "create", auditRecordID(result), fiber.StatusCreated, changes)
+	}
 
 	// Add default success notification if none exists
 	if c.Resource() != nil {
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"github.com/ferdiunal/panel.go/pkg/context"
+	"github.com/ferdiunal/panel.go/pkg/fields"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -193,7 +194,7 @@ func HandleResourceUpdate(h *FieldHandler, c *context.Context) error {
 	id := c.Params("id")
 	data, err := h.parseBody(c)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+		return respondParseBodyError(c, err)
 	}
 
 	// Fetch existing to check policy
@@ -206,14 +207,45 @@ func HandleResourceUpdate(h *FieldHandler, c *context.Context) error {
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Unauthorized"})
 	}
 
+	if validationErrors := h.resolveGalleries(c, data, item); validationErrors != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(validationErrors.response(c.Ctx))
+	}
+
+	if validationErrors := h.resolveRepeaters(c, data); validationErrors != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(validationErrors.response(c.Ctx))
+	}
+
+	if validationErrors := h.resolveContentFields(c, data, id); validationErrors != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(validationErrors.response(c.Ctx))
+	}
+
 	if validationErrors := h.validateUpdatePayload(c, id, data); validationErrors != nil {
 		return c.Status(fiber.StatusUnprocessableEntity).JSON(validationErrors.response(c.Ctx))
 	}
 
-	result, err := h.Provider.Update(c, id, data)
+	// Gönderilen dosya alanlarının önceki dosyaları, güncellemeden sonra yetim kalanları bulmak için okunur
+	trackedFiles := h.trackedFields(c, data)
+	previousFiles := trackedRefs(item, trackedFiles)
+
+	relations := h.takePendingRelations(c, data)
+
+	// Önceki değerler güncellemeden önce okunur; yalnızca gönderilen alanlar karşılaştırılır
+	var auditElements map[string]fields.Element
+	var before map[string]interface{}
+	if h.auditsDataChanges() {
+		auditElements = h.auditedElements(c, data)
+		before = auditSnapshot(item, auditElements)
+	}
+
+	result, err := h.updateWithRelations(c, id, data, relations)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
+	h.trackFiles(c, id, trackedRefs(result, trackedFiles), previousFiles)
+
+	if auditElements != nil {
+		h.logDataChange(c, "update", id, fiber.StatusOK, diffAuditSnapshots(before, auditSnapshot(result, auditElements), auditElements))
+	}
 
 	// Add default success notification if none exists
 	if c.Resource() != nil {
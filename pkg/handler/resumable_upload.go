@@ -0,0 +1,503 @@
+package handler
+
+import (
+	"bytes"
+	stdcontext "context"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ferdiunal/panel.go/pkg/context"
+	"github.com/ferdiunal/panel.go/pkg/data/orm"
+	"github.com/ferdiunal/panel.go/pkg/domain/upload"
+	"github.com/ferdiunal/panel.go/pkg/fields"
+	"github.com/ferdiunal/panel.go/pkg/storage"
+	"gorm.io/gorm"
+)
+
+const (
+	// TusVersion, desteklenen tus protokol sürümüdür.
+	TusVersion = "1.0.0"
+
+	// DefaultUploadExpiration, son parçadan sonra yarım kalan veya form
+	// gönderiminde kullanılmayan yüklemelerin silinmesine kadar geçen süredir.
+	DefaultUploadExpiration = 24 * time.Hour
+
+	// DefaultUploadMaxSize, Storage.Uploads.MaxSize verilmediğinde kabul edilen en
+	// büyük yüklemedir. Upload-Length geçici klasörde yer ayırdığından sınırsız
+	// yüklemeye izin verilmez.
+	DefaultUploadMaxSize int64 = 2 << 30
+
+	// uploadSweepBatchSize, süresi dolan yüklemeler silinirken tek seferde okunan kayıt sayısıdır.
+	uploadSweepBatchSize = 100
+)
+
+var (
+	// ErrUploadNotFound, yükleme bulunamadığında döner.
+	ErrUploadNotFound = errors.New("upload not found")
+	// ErrUploadExpired, süresi dolan yüklemeye parça gönderildiğinde döner.
+	ErrUploadExpired = errors.New("upload expired")
+	// ErrUploadOffsetMismatch, parçanın offset'i yüklemenin offset'iyle uyuşmadığında döner.
+	ErrUploadOffsetMismatch = errors.New("upload offset mismatch")
+	// ErrUploadTooLarge, yükleme izin verilen veya bildirilen boyutu aştığında döner.
+	ErrUploadTooLarge = errors.New("upload exceeds its length")
+	// ErrChecksumMismatch, parçanın sağlama toplamı tutmadığında döner.
+	ErrChecksumMismatch = errors.New("upload checksum mismatch")
+	// ErrUnsupportedChecksum, Upload-Checksum bilinmeyen bir algoritma kullandığında döner.
+	ErrUnsupportedChecksum = errors.New("unsupported checksum algorithm")
+)
+
+// uploadChecksums, Upload-Checksum başlığında kabul edilen algoritmalardır.
+var uploadChecksums = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+}
+
+// ResumableUploadOptions, devam ettirilebilir yüklemelerin ayarlarıdır.
+type ResumableUploadOptions struct {
+	// Dir, parçaların biriktirildiği geçici klasördür.
+	Dir string
+	// MaxSize, kabul edilen en büyük yüklemedir; alanın MaxSize değeri daha
+	// küçükse o uygulanır (0: DefaultUploadMaxSize).
+	MaxSize int64
+	// Expiration, son parçadan sonra yüklemenin silinmesine kadar geçen süredir.
+	Expiration time.Duration
+}
+
+// ResumableUploads, tus protokolüyle parça parça gönderilen yüklemeleri
+// resumable_uploads tablosunda izler ve içeriklerini geçici klasörde biriktirir.
+//
+// Tamamlanan yükleme, form gönderiminde dosya alanına {"upload_id": "..."}
+// olarak verilir; parseBody onu multipart dosyalarıyla aynı kısıtlara göre
+// doğrular, geçici dosyadan doğrudan alanın diskine yazar ve yüklemeyi siler.
+type ResumableUploads struct {
+	repo       *orm.UploadRepository
+	dir        string
+	maxSize    int64
+	expiration time.Duration
+	locks      [64]sync.Mutex
+}
+
+// NewResumableUploads, geçici klasörü oluşturur ve servisi döner.
+func NewResumableUploads(db *gorm.DB, opts ResumableUploadOptions) (*ResumableUploads, error) {
+	if opts.Dir == "" {
+		opts.Dir = filepath.Join(os.TempDir(), "panel-uploads")
+	}
+	if opts.MaxSize <= 0 {
+		opts.MaxSize = DefaultUploadMaxSize
+	}
+	if opts.Expiration <= 0 {
+		opts.Expiration = DefaultUploadExpiration
+	}
+	if err := os.MkdirAll(opts.Dir, 0o700); err != nil {
+		return nil, fmt.Errorf("upload directory: %w", err)
+	}
+	return &ResumableUploads{
+		repo:       orm.NewUploadRepository(db),
+		dir:        opts.Dir,
+		maxSize:    opts.MaxSize,
+		expiration: opts.Expiration,
+	}, nil
+}
+
+// MaxSize, alanın MaxSize değerinden bağımsız olarak kabul edilen en büyük yüklemedir.
+func (s *ResumableUploads) MaxSize() int64 {
+	return s.maxSize
+}
+
+// Expiration, yüklemelerin son parçadan sonraki ömrüdür.
+func (s *ResumableUploads) Expiration() time.Duration {
+	return s.expiration
+}
+
+// Create, boş bir yükleme başlatır. Length 0 ise yükleme hemen tamamlanmış sayılır.
+func (s *ResumableUploads) Create(ctx stdcontext.Context, u *upload.Upload) error {
+	id, err := newUploadID()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	u.ID = id
+	u.Offset = 0
+	u.ExpiresAt = now.Add(s.expiration)
+	if u.Length == 0 {
+		u.CompletedAt = &now
+	}
+
+	file, err := os.OpenFile(s.path(id), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	file.Close()
+
+	if err := s.repo.Create(ctx, u); err != nil {
+		_ = os.Remove(s.path(id))
+		return err
+	}
+	return nil
+}
+
+// Find, yüklemeyi ID'siyle döner. Geçersiz ID'ler ve silinmiş yüklemeler için
+// ErrUploadNotFound döner.
+func (s *ResumableUploads) Find(ctx stdcontext.Context, id string) (*upload.Upload, error) {
+	if !validUploadID(id) {
+		return nil, ErrUploadNotFound
+	}
+	u, err := s.repo.FindByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUploadNotFound
+	}
+	return u, err
+}
+
+// Append, chunk'ı yüklemenin offset konumuna yazar ve güncel yüklemeyi döner.
+// checksum boş değilse "<algoritma> <base64 özet>" biçiminde olmalıdır ve parça
+// yazılmadan önce doğrulanır. Aynı yüklemeye gelen parçalar sırayla işlenir.
+func (s *ResumableUploads) Append(ctx stdcontext.Context, id string, offset int64, chunk []byte, checksum string) (*upload.Upload, error) {
+	lock := s.lock(id)
+	lock.Lock()
+	defer lock.Unlock()
+
+	u, err := s.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if !u.ExpiresAt.After(now) {
+		_ = s.Remove(ctx, id)
+		return nil, ErrUploadExpired
+	}
+	if offset != u.Offset {
+		return u, ErrUploadOffsetMismatch
+	}
+	if offset+int64(len(chunk)) > u.Length {
+		return u, ErrUploadTooLarge
+	}
+	if checksum != "" {
+		if err := verifyUploadChecksum(checksum, chunk); err != nil {
+			return u, err
+		}
+	}
+	if len(chunk) == 0 {
+		return u, nil
+	}
+
+	file, err := os.OpenFile(s.path(id), os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	_, err = file.WriteAt(chunk, offset)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	to := offset + int64(len(chunk))
+	expiresAt := now.Add(s.expiration)
+	advanced, err := s.repo.Advance(ctx, u, offset, to, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	if !advanced {
+		return u, ErrUploadOffsetMismatch
+	}
+	u.Offset = to
+	u.ExpiresAt = expiresAt
+	if to == u.Length {
+		u.CompletedAt = &now
+	}
+	return u, nil
+}
+
+// Remove, yüklemeyi ve geçici dosyasını siler.
+func (s *ResumableUploads) Remove(ctx stdcontext.Context, id string) error {
+	if !validUploadID(id) {
+		return ErrUploadNotFound
+	}
+	if err := os.Remove(s.path(id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// Sweep, now itibarıyla süresi dolan yüklemeleri siler ve silinen sayısını döner.
+func (s *ResumableUploads) Sweep(ctx stdcontext.Context, now time.Time) (int, error) {
+	removed := 0
+	for {
+		expired, err := s.repo.Expired(ctx, now, uploadSweepBatchSize)
+		if err != nil {
+			return removed, err
+		}
+		for _, u := range expired {
+			if err := s.Remove(ctx, u.ID); err != nil {
+				return removed, err
+			}
+			removed++
+		}
+		if len(expired) < uploadSweepBatchSize {
+			return removed, nil
+		}
+	}
+}
+
+// file, tamamlanan yüklemeyi storage.Upload olarak döner. İçerik form dosyasına
+// çevrilmeden doğrudan geçici dosyadan okunur; böylece büyük dosyalar diske
+// yazılmadan önce ikinci bir geçici kopyaya aktarılmaz.
+func (s *ResumableUploads) file(u *upload.Upload) storage.Upload {
+	filename := filepath.Base(strings.ReplaceAll(u.Filename, "\\", "/"))
+	if filename == "." || filename == "/" || filename == "" {
+		filename = u.ID
+	}
+	path := s.path(u.ID)
+	return storage.Upload{
+		Filename:    filename,
+		ContentType: u.ContentType,
+		Size:        u.Length,
+		Open: func() (io.ReadCloser, error) {
+			return os.Open(path)
+		},
+	}
+}
+
+func (s *ResumableUploads) path(id string) string {
+	return filepath.Join(s.dir, id)
+}
+
+func (s *ResumableUploads) lock(id string) *sync.Mutex {
+	h := fnv.New32a()
+	h.Write([]byte(id))
+	return &s.locks[h.Sum32()%uint32(len(s.locks))]
+}
+
+func newUploadID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// validUploadID, ID'nin newUploadID biçiminde olduğunu doğrular; ID dosya yolu
+// olarak kullanıldığından başka değerler reddedilir.
+func validUploadID(id string) bool {
+	if len(id) != 32 {
+		return false
+	}
+	_, err := hex.DecodeString(id)
+	return err == nil
+}
+
+// verifyUploadChecksum, Upload-Checksum başlığını chunk ile karşılaştırır.
+func verifyUploadChecksum(header string, chunk []byte) error {
+	algorithm, encoded, ok := strings.Cut(strings.TrimSpace(header), " ")
+	newHash, known := uploadChecksums[strings.ToLower(algorithm)]
+	if !ok || !known {
+		return ErrUnsupportedChecksum
+	}
+	expected, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return ErrUnsupportedChecksum
+	}
+	sum := newHash()
+	sum.Write(chunk)
+	if !bytes.Equal(sum.Sum(nil), expected) {
+		return ErrChecksumMismatch
+	}
+	return nil
+}
+
+// uploadReferenceID, dosya alanına gönderilen {"upload_id": "..."} referansının
+// yükleme ID'sini döner. Değer JSON gövdesinde nesne, multipart formda metin
+// olarak gelebilir.
+func uploadReferenceID(value interface{}) (string, bool) {
+	var id interface{}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		id = v["upload_id"]
+	case string:
+		if !strings.HasPrefix(strings.TrimSpace(v), "{") {
+			return "", false
+		}
+		var ref struct {
+			UploadID string `json:"upload_id"`
+		}
+		if err := json.Unmarshal([]byte(v), &ref); err != nil {
+			return "", false
+		}
+		id = ref.UploadID
+	}
+	s, ok := id.(string)
+	if !ok || strings.TrimSpace(s) == "" {
+		return "", false
+	}
+	return strings.TrimSpace(s), true
+}
+
+// uploadOwnerID, yüklemeleri oturum kullanıcısına bağlamak için kullanılan ID'dir.
+func uploadOwnerID(c *context.Context) uint {
+	if u := c.User(); u != nil {
+		return u.ID
+	}
+	return 0
+}
+
+// storesOnDisk, alanın dosyalarını StoreAs callback'i olmadan Store(disk, path)
+// ile bir diske yazıp yazmadığını döner. Tamamlanan yüklemeler geçici dosyadan
+// doğrudan diske aktarıldığından yalnızca bu alanlarda kullanılabilir.
+func (h *FieldHandler) storesOnDisk(element fields.Element) bool {
+	if element == nil || element.GetStorageCallback() != nil {
+		return false
+	}
+	_, _, ok, err := h.fieldDisk(element)
+	return ok && err == nil
+}
+
+// inspectUploadReferences, refs içindeki tamamlanmış yüklemeleri çözer ve
+// alanların Accept, MaxSize ve MarkRemoveEXIFData ayarlarına göre denetler.
+// Aynı alana ayrıca dosya gönderildiyse referans refs'ten çıkarılır. Yükleme
+// bulunamaz, başka bir kullanıcıya, kaynağa veya alana aitse, tamamlanmamışsa
+// ya da süresi dolmuşsa 422 doğrulama hatası döner.
+func (h *FieldHandler) inspectUploadReferences(c *context.Context, elements []fields.Element, refs map[string]string, files map[string][]*multipart.FileHeader) (map[string]storage.Upload, error) {
+	if h.Uploads == nil || len(refs) == 0 {
+		return nil, nil
+	}
+
+	validationErrors := newRequestValidationErrors()
+	uploads := make(map[string]storage.Upload, len(refs))
+	now := time.Now()
+	for key, id := range refs {
+		if headers := files[key]; len(headers) > 0 && headers[0] != nil && strings.TrimSpace(headers[0].Filename) != "" {
+			delete(refs, key)
+			continue
+		}
+		var element fields.Element
+		for _, el := range elements {
+			if el.GetKey() == key {
+				element = el
+				break
+			}
+		}
+
+		u, err := h.Uploads.Find(c.UserContext(), id)
+		if err != nil && !errors.Is(err, ErrUploadNotFound) {
+			return nil, err
+		}
+		if u == nil || u.UserID != uploadOwnerID(c) || u.Resource != h.Resource.Slug() || u.Field != key ||
+			!u.Completed() || !u.ExpiresAt.After(now) || !h.storesOnDisk(element) {
+			label := key
+			if element != nil {
+				label = resolveValidationFieldLabel(element, element.JsonSerialize(), key)
+			}
+			templateData := map[string]interface{}{"Field": label, "Key": key}
+			validationErrors.add(key, uploadValidationMessage(c, "validation.uploadUnavailable", "{{.Field}} references an upload that is missing, incomplete or expired", templateData))
+			continue
+		}
+
+		file := h.Uploads.file(u)
+		contentType, stripped, message, err := checkUpload(c, element, key, file)
+		if err != nil {
+			return nil, err
+		}
+		if message != "" {
+			validationErrors.add(key, message)
+			continue
+		}
+		file.ContentType = contentType
+		if stripped != nil {
+			file.Size = int64(len(stripped))
+			file.Open = func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(stripped)), nil
+			}
+		}
+		uploads[key] = file
+	}
+
+	if validationErrors.hasAny() {
+		return nil, &uploadValidationError{errors: validationErrors}
+	}
+	return uploads, nil
+}
+
+// storeUploadReferences, denetlenen yüklemeleri alanların diskine yazar ve
+// body'deki değerleri saklanan dosyaların yoluyla değiştirir.
+func (h *FieldHandler) storeUploadReferences(c *context.Context, elements []fields.Element, uploads map[string]storage.Upload, body map[string]interface{}) error {
+	for key, file := range uploads {
+		var element fields.Element
+		for _, el := range elements {
+			if el.GetKey() == key {
+				element = el
+				break
+			}
+		}
+		disk, dir, _, err := h.fieldDisk(element)
+		if err != nil {
+			return err
+		}
+		path, err := h.putFieldFile(c, element, disk, dir, file)
+		if err != nil {
+			return err
+		}
+		body[key] = path
+	}
+	return nil
+}
+
+// storeReferencedUploads, JSON gövdesindeki dosya alanlarına verilen yükleme
+// referanslarını denetleyip alanın diskine yazar, body'deki değeri saklanan
+// dosyanın yoluyla değiştirir ve kullanılan yüklemeleri siler.
+func (h *FieldHandler) storeReferencedUploads(c *context.Context, elements []fields.Element, body map[string]interface{}) error {
+	refs := make(map[string]string)
+	galleries := galleryElements(elements)
+	for _, el := range elements {
+		key := el.GetKey()
+		if !fields.IsFileElement(el) || galleries[key] != nil {
+			continue
+		}
+		if id, ok := uploadReferenceID(body[key]); ok {
+			refs[key] = id
+		}
+	}
+	if len(refs) == 0 {
+		return nil
+	}
+
+	uploads, err := h.inspectUploadReferences(c, elements, refs, nil)
+	if err != nil {
+		return err
+	}
+	if err := h.storeUploadReferences(c, elements, uploads, body); err != nil {
+		return err
+	}
+	return h.consumeUploads(c, refs)
+}
+
+// consumeUploads, dosyası alana yazılan yüklemeleri siler.
+func (h *FieldHandler) consumeUploads(c *context.Context, refs map[string]string) error {
+	if h.Uploads == nil {
+		return nil
+	}
+	for _, id := range refs {
+		if err := h.Uploads.Remove(c.UserContext(), id); err != nil && !errors.Is(err, ErrUploadNotFound) {
+			return err
+		}
+	}
+	return nil
+}
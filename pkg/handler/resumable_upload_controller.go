@@ -0,0 +1,241 @@
+package handler
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ferdiunal/panel.go/pkg/context"
+	"github.com/ferdiunal/panel.go/pkg/domain/upload"
+	"github.com/ferdiunal/panel.go/pkg/fields"
+	"github.com/gofiber/fiber/v2"
+)
+
+// StatusChecksumMismatch, tus checksum eklentisinin sağlama toplamı tutmayan
+// parçalar için kullandığı durum kodudur.
+const StatusChecksumMismatch = 460
+
+// tusExtensions, desteklenen tus eklentileridir.
+const tusExtensions = "creation,expiration,checksum,termination"
+
+// HandleResumableUploadOptions, sunucunun tus yeteneklerini bildirir.
+func HandleResumableUploadOptions(h *FieldHandler, c *context.Context) error {
+	if h.Uploads == nil {
+		return resumableUploadsDisabled(c)
+	}
+	c.Set("Tus-Resumable", TusVersion)
+	c.Set("Tus-Version", TusVersion)
+	c.Set("Tus-Extension", tusExtensions)
+	c.Set("Tus-Checksum-Algorithm", "md5,sha1,sha256")
+	c.Set("Tus-Max-Size", strconv.FormatInt(h.Uploads.MaxSize(), 10))
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// HandleResumableUploadCreate, Upload-Length ve Upload-Metadata başlıklarıyla yeni
+// bir yükleme başlatır. Metadata'daki field, kaynağın Store(disk, path) ile diske
+// yazan tekli dosya alanı olmalıdır; record verilirse kaydın güncelleme, verilmezse oluşturma yetkisi aranır.
+// Upload-Length, alanın MaxSize değerini veya genel sınırı aşarsa 413 döner.
+func HandleResumableUploadCreate(h *FieldHandler, c *context.Context) error {
+	if ok, err := checkTusRequest(h, c); !ok {
+		return err
+	}
+	if c.Get("Upload-Defer-Length") != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Upload-Defer-Length is not supported"})
+	}
+	length, err := strconv.ParseInt(c.Get("Upload-Length"), 10, 64)
+	if err != nil || length < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Upload-Length is required"})
+	}
+	metadata, err := parseUploadMetadata(c.Get("Upload-Metadata"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid Upload-Metadata"})
+	}
+
+	key := metadata["field"]
+	var element fields.Element
+	for _, el := range h.getElements(c) {
+		if el.GetKey() == key {
+			element = el
+			break
+		}
+	}
+	if _, gallery := fields.AsGalleryField(element); element == nil || !fields.IsFileElement(element) || gallery {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Upload-Metadata field must be a file field"})
+	}
+	if !h.storesOnDisk(element) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Upload-Metadata field must store files on a disk"})
+	}
+
+	if record := metadata["record"]; record != "" {
+		item, err := h.Provider.Show(c, record)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Not found"})
+		}
+		if h.Policy != nil && !h.Policy.Update(c, item) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Unauthorized"})
+		}
+	} else if h.Policy != nil && !h.Policy.Create(c) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	maxSize := h.Uploads.MaxSize()
+	if fieldMax := element.GetMaxFileSize(); fieldMax > 0 && fieldMax < maxSize {
+		maxSize = fieldMax
+	}
+	if length > maxSize {
+		return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{"error": "Upload exceeds the maximum size"})
+	}
+
+	u := &upload.Upload{
+		UserID:      uploadOwnerID(c),
+		Resource:    h.Resource.Slug(),
+		Field:       key,
+		Filename:    metadata["filename"],
+		ContentType: metadata["filetype"],
+		Length:      length,
+	}
+	if err := h.Uploads.Create(c.UserContext(), u); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	c.Set("Location", strings.TrimSuffix(c.Path(), "/")+"/"+u.ID)
+	c.Set("Upload-Expires", u.ExpiresAt.UTC().Format(http.TimeFormat))
+	return c.SendStatus(fiber.StatusCreated)
+}
+
+// HandleResumableUploadHead, yüklemenin alınan bayt sayısını (Upload-Offset) döner;
+// istemci kesintiden sonra göndermeye buradan devam eder.
+func HandleResumableUploadHead(h *FieldHandler, c *context.Context) error {
+	if ok, err := checkTusRequest(h, c); !ok {
+		return err
+	}
+	u, err := findOwnedUpload(h, c)
+	if err != nil {
+		return respondUploadError(c, err)
+	}
+
+	c.Set("Cache-Control", "no-store")
+	c.Set("Upload-Offset", strconv.FormatInt(u.Offset, 10))
+	c.Set("Upload-Length", strconv.FormatInt(u.Length, 10))
+	c.Set("Upload-Expires", u.ExpiresAt.UTC().Format(http.TimeFormat))
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// HandleResumableUploadPatch, Upload-Offset konumundan başlayan bir parçayı yüklemeye
+// ekler. Upload-Checksum verilirse parça yazılmadan önce doğrulanır.
+func HandleResumableUploadPatch(h *FieldHandler, c *context.Context) error {
+	if ok, err := checkTusRequest(h, c); !ok {
+		return err
+	}
+	if !strings.HasPrefix(c.Get("Content-Type"), "application/offset+octet-stream") {
+		return c.Status(fiber.StatusUnsupportedMediaType).JSON(fiber.Map{"error": "Content-Type must be application/offset+octet-stream"})
+	}
+	offset, err := strconv.ParseInt(c.Get("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Upload-Offset is required"})
+	}
+	u, err := findOwnedUpload(h, c)
+	if err != nil {
+		return respondUploadError(c, err)
+	}
+
+	u, err = h.Uploads.Append(c.UserContext(), u.ID, offset, c.Body(), c.Get("Upload-Checksum"))
+	if err != nil {
+		return respondUploadError(c, err)
+	}
+	c.Set("Upload-Offset", strconv.FormatInt(u.Offset, 10))
+	c.Set("Upload-Expires", u.ExpiresAt.UTC().Format(http.TimeFormat))
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// HandleResumableUploadDelete, yüklemeyi iptal eder ve geçici dosyasını siler.
+func HandleResumableUploadDelete(h *FieldHandler, c *context.Context) error {
+	if ok, err := checkTusRequest(h, c); !ok {
+		return err
+	}
+	u, err := findOwnedUpload(h, c)
+	if err != nil {
+		return respondUploadError(c, err)
+	}
+	if err := h.Uploads.Remove(c.UserContext(), u.ID); err != nil {
+		return respondUploadError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// checkTusRequest, yüklemeler kapalıysa veya istek desteklenen tus sürümünü
+// bildirmiyorsa hata yanıtını yazar ve false döner.
+func checkTusRequest(h *FieldHandler, c *context.Context) (bool, error) {
+	if h.Uploads == nil {
+		return false, resumableUploadsDisabled(c)
+	}
+	c.Set("Tus-Resumable", TusVersion)
+	if c.Get("Tus-Resumable") != TusVersion {
+		c.Set("Tus-Version", TusVersion)
+		return false, c.Status(fiber.StatusPreconditionFailed).JSON(fiber.Map{"error": "Unsupported Tus-Resumable version"})
+	}
+	return true, nil
+}
+
+// findOwnedUpload, :upload parametresindeki yüklemeyi döner. Yükleme başka bir
+// kullanıcıya veya kaynağa aitse bulunamamış sayılır; süresi dolmuşsa silinir.
+func findOwnedUpload(h *FieldHandler, c *context.Context) (*upload.Upload, error) {
+	u, err := h.Uploads.Find(c.UserContext(), c.Params("upload"))
+	if err != nil {
+		return nil, err
+	}
+	if u.UserID != uploadOwnerID(c) || u.Resource != h.Resource.Slug() {
+		return nil, ErrUploadNotFound
+	}
+	if !u.ExpiresAt.After(time.Now()) {
+		_ = h.Uploads.Remove(c.UserContext(), u.ID)
+		return nil, ErrUploadExpired
+	}
+	return u, nil
+}
+
+// respondUploadError, yükleme hatasını tus durum koduna çevirir.
+func respondUploadError(c *context.Context, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrUploadNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, ErrUploadExpired):
+		status = fiber.StatusGone
+	case errors.Is(err, ErrUploadOffsetMismatch):
+		status = fiber.StatusConflict
+	case errors.Is(err, ErrUploadTooLarge):
+		status = fiber.StatusRequestEntityTooLarge
+	case errors.Is(err, ErrUnsupportedChecksum):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, ErrChecksumMismatch):
+		status = StatusChecksumMismatch
+	}
+	return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+}
+
+func resumableUploadsDisabled(c *context.Context) error {
+	return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Resumable uploads are not enabled"})
+}
+
+// parseUploadMetadata, "anahtar base64,anahtar2 base64" biçimindeki
+// Upload-Metadata başlığını çözer.
+func parseUploadMetadata(header string) (map[string]string, error) {
+	metadata := make(map[string]string)
+	for _, pair := range strings.Split(header, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, encoded, _ := strings.Cut(pair, " ")
+		value, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			return nil, err
+		}
+		metadata[key] = string(value)
+	}
+	return metadata, nil
+}
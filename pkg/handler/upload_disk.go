@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"fmt"
+	"mime/multipart"
+
+	"github.com/ferdiunal/panel.go/pkg/context"
+	"github.com/ferdiunal/panel.go/pkg/fields"
+	"github.com/ferdiunal/panel.go/pkg/storage"
+)
+
+// storeFieldFile, tekli dosya alanına yüklenen dosyayı sırasıyla alanın StoreAs
+// callback'i, Store(disk, path) ile tanımlanan disk veya Resource.StoreHandler ile
+// saklar ve alanın veritabanına yazılacak değerini döner.
+func (h *FieldHandler) storeFieldFile(c *context.Context, elements []fields.Element, key string, file *multipart.FileHeader) (string, error) {
+	var element fields.Element
+	for _, el := range elements {
+		if el.GetKey() == key {
+			element = el
+			break
+		}
+	}
+
+	if element != nil {
+		if callback := element.GetStorageCallback(); callback != nil {
+			return callback(c.Ctx, file)
+		}
+	}
+	if stored, ok, err := h.storeOnFieldDisk(c, element, file); ok {
+		return stored, err
+	}
+	return h.Resource.StoreHandler(c, file, h.StoragePath, h.StorageURL)
+}
+
+// storeOnFieldDisk, Store(disk, path) ile disk veya klasör tanımlayan dosya alanlarının
+// yüklemesini ilgili diske yazar ve alanın resim dönüşümlerini üretir. Dönen değer
+// storage.StoreUpload'daki gibi herkese açık URL veya disk yoludur. Alan disk
+// tanımlamıyorsa veya handler'a disk verilmemişse ok false döner ve
+// Resource.StoreHandler kullanılır.
+func (h *FieldHandler) storeOnFieldDisk(c *context.Context, element fields.Element, file *multipart.FileHeader) (string, bool, error) {
+	disk, dir, ok, err := h.fieldDisk(element)
+	if !ok || err != nil {
+		return "", ok, err
+	}
+	stored, err := h.putFieldFile(c, element, disk, dir, storage.FileHeaderUpload(file))
+	return stored, true, err
+}
+
+// putFieldFile, dosyayı alanın diskine yazar, resim dönüşümlerini üretir ve
+// alanın değerini (herkese açık URL veya disk yolu) döner.
+func (h *FieldHandler) putFieldFile(c *context.Context, element fields.Element, disk storage.Disk, dir string, file storage.Upload) (string, error) {
+	path, err := storage.PutFile(c.UserContext(), disk, dir, file)
+	if err != nil {
+		return "", err
+	}
+	if err := h.storeImageConversions(c, element, disk, path, file); err != nil {
+		return "", err
+	}
+	if url := disk.URL(path); url != "" {
+		return url, nil
+	}
+	return path, nil
+}
+
+// fieldDisk, alanın Store(disk, path) ile tanımladığı diski ve klasörü döner.
+// Alan disk tanımlamıyorsa veya handler'a disk verilmemişse ok false döner.
+func (h *FieldHandler) fieldDisk(element fields.Element) (storage.Disk, string, bool, error) {
+	if h.Disks == nil || element == nil {
+		return nil, "", false, nil
+	}
+	diskName, dir := element.GetStorageDisk(), element.GetStoragePath()
+	if diskName == "" && dir == "" {
+		return nil, "", false, nil
+	}
+
+	disk, err := h.Disks.Disk(diskName)
+	if err != nil {
+		return nil, "", true, fmt.Errorf("field %q: %w", element.GetKey(), err)
+	}
+	return disk, dir, true, nil
+}
@@ -0,0 +1,220 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"mime/multipart"
+	"net/textproto"
+	"strconv"
+	"strings"
+
+	"github.com/ferdiunal/panel.go/pkg/context"
+	"github.com/ferdiunal/panel.go/pkg/fields"
+	paneli18n "github.com/ferdiunal/panel.go/pkg/i18n"
+	"github.com/ferdiunal/panel.go/pkg/storage"
+	"github.com/gofiber/fiber/v2"
+)
+
+// uploadValidationError, yüklenen dosyalar alan kısıtlarına uymadığında parseBody
+// tarafından döner. Store ve update controller'ları bunu standart 422 doğrulama
+// yanıtına çevirir; hiçbir dosya diske yazılmadan istek reddedilir.
+type uploadValidationError struct {
+	errors *requestValidationErrors
+}
+
+func (e *uploadValidationError) Error() string {
+	return "upload validation failed"
+}
+
+// respondParseBodyError, parseBody hatasını yanıta çevirir: dosya kısıtı
+// ihlalleri 422 doğrulama yanıtı, diğer hatalar 400 döner.
+func respondParseBodyError(c *context.Context, err error) error {
+	var uploadErr *uploadValidationError
+	if errors.As(err, &uploadErr) {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(uploadErr.errors.response(c.Ctx))
+	}
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+}
+
+// validateUploads, multipart formdaki dosyaları alanların Accept, MaxSize ve
+// MarkRemoveEXIFData ayarlarına göre denetler. Tip, istemcinin Content-Type
+// başlığından değil dosyanın içeriğinden belirlenir ve başlık bu tiple
+// değiştirilir. EXIF temizlenen dosyalar files içinde yenileriyle değiştirilir.
+func (h *FieldHandler) validateUploads(c *context.Context, elements []fields.Element, files map[string][]*multipart.FileHeader) error {
+	validationErrors := newRequestValidationErrors()
+	for key, headers := range files {
+		// Gallery fields send several files under "key[]"
+		fieldKey := strings.TrimSuffix(key, "[]")
+		var element fields.Element
+		for _, el := range elements {
+			if el.GetKey() == fieldKey {
+				element = el
+				break
+			}
+		}
+
+		for i, header := range headers {
+			if header == nil || strings.TrimSpace(header.Filename) == "" {
+				continue
+			}
+			checked, message, err := inspectUpload(c, element, fieldKey, header)
+			if err != nil {
+				return err
+			}
+			if message != "" {
+				validationErrors.add(fieldKey, message)
+				continue
+			}
+			headers[i] = checked
+		}
+	}
+
+	if validationErrors.hasAny() {
+		return &uploadValidationError{errors: validationErrors}
+	}
+	return nil
+}
+
+// inspectUpload, tek bir multipart dosyasını checkUpload ile denetler. Başlıktaki
+// Content-Type içerikten belirlenen tiple değiştirilir; EXIF temizlenen dosya
+// yeni bir FileHeader olarak döner.
+func inspectUpload(c *context.Context, element fields.Element, key string, file *multipart.FileHeader) (*multipart.FileHeader, string, error) {
+	contentType, stripped, message, err := checkUpload(c, element, key, storage.FileHeaderUpload(file))
+	if err != nil || message != "" {
+		return nil, message, err
+	}
+
+	file.Header.Set("Content-Type", contentType)
+	if stripped == nil {
+		return file, "", nil
+	}
+	replaced, err := replaceFileContent(file, stripped)
+	if err != nil {
+		return nil, "", err
+	}
+	return replaced, "", nil
+}
+
+// checkUpload, tek bir dosyayı denetler ve içerikten belirlenen tipi döner.
+// Kısıt ihlalinde yerelleştirilmiş hata mesajı döner; alan tanımlı değilse
+// yalnızca tehlikeli içerik kontrolü yapılır. Alan EXIF temizliği istiyorsa
+// temizlenmiş içerik stripped olarak döner, aksi halde stripped nil'dir.
+// İçerik yalnızca tarama veya temizlik gerektiğinde tamamen okunur.
+func checkUpload(c *context.Context, element fields.Element, key string, file storage.Upload) (contentType string, stripped []byte, message string, err error) {
+	label := key
+	var accept []string
+	var maxSize int64
+	var stripMetadata bool
+	if element != nil {
+		label = resolveValidationFieldLabel(element, element.JsonSerialize(), key)
+		accept = element.GetAcceptedMimeTypes()
+		maxSize = element.GetMaxFileSize()
+		stripMetadata = element.ShouldRemoveEXIFData()
+	}
+	templateData := map[string]interface{}{"Field": label, "Key": key}
+
+	if maxSize > 0 && file.Size > maxSize {
+		templateData["Max"] = formatFileSize(maxSize)
+		return "", nil, uploadValidationMessage(c, "validation.fileTooLarge", "{{.Field}} may not be larger than {{.Max}}", templateData), nil
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return "", nil, "", err
+	}
+	defer src.Close()
+
+	head := make([]byte, storage.SniffLength)
+	n, err := io.ReadFull(src, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", nil, "", err
+	}
+	head = head[:n]
+	contentType = storage.DetectContentType(head, file.Filename)
+
+	if !storage.MatchesAccept(contentType, file.Filename, accept) {
+		templateData["Types"] = strings.Join(accept, ", ")
+		return "", nil, uploadValidationMessage(c, "validation.fileType", "{{.Field}} must be a file of type: {{.Types}}", templateData), nil
+	}
+
+	stripMetadata = stripMetadata && (contentType == "image/jpeg" || contentType == "image/png")
+	content := head
+	if stripMetadata || storage.NeedsContentScan(contentType, file.Filename) {
+		rest, err := io.ReadAll(src)
+		if err != nil {
+			return "", nil, "", err
+		}
+		content = append(head, rest...)
+	}
+
+	if storage.IsDangerous(contentType, file.Filename, content) {
+		return "", nil, uploadValidationMessage(c, "validation.fileUnsafe", "{{.Field}} contains content that is not allowed", templateData), nil
+	}
+	if !stripMetadata {
+		return contentType, nil, "", nil
+	}
+
+	stripped, err = storage.StripImageMetadata(contentType, content)
+	if err != nil {
+		return "", nil, uploadValidationMessage(c, "validation.fileInvalid", "{{.Field}} could not be processed", templateData), nil
+	}
+	return contentType, stripped, "", nil
+}
+
+// replaceFileContent, aynı ad ve başlıklara sahip ama içeriği content olan yeni
+// bir FileHeader üretir. FileHeader içeriği dışarıdan değiştirilemediğinden dosya
+// bellekte tek parçalı bir multipart gövdesi olarak yeniden okunur; böylece
+// StoreAs callback'leri ve Resource.StoreHandler değişmeden çalışır.
+func replaceFileContent(file *multipart.FileHeader, content []byte) (*multipart.FileHeader, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	header := make(textproto.MIMEHeader, len(file.Header))
+	for name, values := range file.Header {
+		header[name] = append([]string(nil), values...)
+	}
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write(content); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	// maxMemory içerikten büyük tutulur; dosya geçici diske yazılmaz
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(int64(len(content)) + 1<<20)
+	if err != nil {
+		return nil, err
+	}
+	for _, headers := range form.File {
+		if len(headers) > 0 {
+			return headers[0], nil
+		}
+	}
+	return nil, io.ErrUnexpectedEOF
+}
+
+// uploadValidationMessage, çeviri varsa onu, yoksa fallback mesajını şablon
+// verisiyle doldurarak döner.
+func uploadValidationMessage(c *context.Context, messageID, fallback string, templateData map[string]interface{}) string {
+	if localized := paneli18n.Trans(c.Ctx, messageID, templateData); localized != messageID {
+		return localized
+	}
+	return interpolateValidationMessage(fallback, templateData)
+}
+
+// formatFileSize, bayt cinsinden boyutu okunabilir biçime çevirir (örn: 5 MB, 1.5 KB).
+func formatFileSize(size int64) string {
+	units := []string{"B", "KB", "MB", "GB"}
+	value := float64(size)
+	unit := 0
+	for value >= 1024 && unit < len(units)-1 {
+		value /= 1024
+		unit++
+	}
+	return strconv.FormatFloat(math.Round(value*10)/10, 'f', -1, 64) + " " + units[unit]
+}
@@ -0,0 +1,210 @@
+// Package mail, panelin gönderdiği e-postalar (doğrulama, bildirim vb.) için
+// değiştirilebilir bir gönderici arayüzü sağlar.
+//
+// Üretimde SMTPSender, geliştirme ve testlerde mesajları .eml dosyası olarak
+// yazan FileSender kullanılabilir. Farklı bir servis (SES, Postmark vb.) için
+// Sender arayüzünü uygulamak veya SenderFunc kullanmak yeterlidir.
+//
+// # Kullanım Örneği
+//
+//	sender := mail.NewFileSender("storage/mail")
+//	err := sender.Send(ctx, mail.Message{
+//	    From:    "panel@example.com",
+//	    To:      []string{"ahmet@example.com"},
+//	    Subject: "Hoş geldiniz",
+//	    Text:    "Merhaba!",
+//	})
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Message, gönderilecek tek bir e-postadır.
+// Text ve HTML birlikte verilirse multipart/alternative olarak gönderilir.
+type Message struct {
+	From    string
+	To      []string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Sender, e-posta gönderen bileşenlerin uyguladığı arayüzdür.
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// SenderFunc, sıradan bir fonksiyonu Sender olarak kullanmayı sağlar.
+type SenderFunc func(ctx context.Context, msg Message) error
+
+// Send, f(ctx, msg) çağırır.
+func (f SenderFunc) Send(ctx context.Context, msg Message) error {
+	return f(ctx, msg)
+}
+
+// Validate, mesajın gönderilebilir olduğunu kontrol eder.
+func (m Message) Validate() error {
+	if m.From == "" {
+		return errors.New("mail: From is required")
+	}
+	if len(m.To) == 0 {
+		return errors.New("mail: at least one recipient is required")
+	}
+	for _, addr := range append([]string{m.From}, m.To...) {
+		if strings.ContainsAny(addr, "\r\n") {
+			return fmt.Errorf("mail: invalid address %q", addr)
+		}
+	}
+	if strings.ContainsAny(m.Subject, "\r\n") {
+		return errors.New("mail: subject cannot contain line breaks")
+	}
+	if m.Text == "" && m.HTML == "" {
+		return errors.New("mail: Text or HTML body is required")
+	}
+	return nil
+}
+
+// Bytes, mesajı RFC 5322 formatında (başlıklar + quoted-printable gövde) döndürür.
+func (m Message) Bytes() []byte {
+	var buf bytes.Buffer
+	header := func(key, value string) {
+		buf.WriteString(key + ": " + value + "\r\n")
+	}
+
+	header("From", m.From)
+	header("To", strings.Join(m.To, ", "))
+	header("Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+
+	switch {
+	case m.Text != "" && m.HTML != "":
+		boundary := randomBoundary()
+		header("Content-Type", `multipart/alternative; boundary="`+boundary+`"`)
+		buf.WriteString("\r\n")
+		writePart(&buf, boundary, "text/plain", m.Text)
+		writePart(&buf, boundary, "text/html", m.HTML)
+		buf.WriteString("--" + boundary + "--\r\n")
+	case m.HTML != "":
+		header("Content-Type", "text/html; charset=utf-8")
+		header("Content-Transfer-Encoding", "quoted-printable")
+		buf.WriteString("\r\n")
+		writeQuotedPrintable(&buf, m.HTML)
+	default:
+		header("Content-Type", "text/plain; charset=utf-8")
+		header("Content-Transfer-Encoding", "quoted-printable")
+		buf.WriteString("\r\n")
+		writeQuotedPrintable(&buf, m.Text)
+	}
+	return buf.Bytes()
+}
+
+func writePart(buf *bytes.Buffer, boundary, contentType, body string) {
+	buf.WriteString("--" + boundary + "\r\n")
+	buf.WriteString("Content-Type: " + contentType + "; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+	writeQuotedPrintable(buf, body)
+}
+
+func writeQuotedPrintable(buf *bytes.Buffer, body string) {
+	w := quotedprintable.NewWriter(buf)
+	_, _ = w.Write([]byte(body))
+	_ = w.Close()
+	buf.WriteString("\r\n")
+}
+
+func randomBoundary() string {
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+	return "panel-" + hex.EncodeToString(b)
+}
+
+// FileSender, her mesajı Dir altında ayrı bir .eml dosyası olarak yazar.
+// Gerçek e-posta gönderilmez; geliştirme ortamı ve testler için tasarlanmıştır.
+type FileSender struct {
+	Dir string
+
+	mu  sync.Mutex
+	seq int
+}
+
+// NewFileSender, mesajları dir dizinine yazan bir FileSender oluşturur.
+func NewFileSender(dir string) *FileSender {
+	return &FileSender{Dir: dir}
+}
+
+// Send, mesajı `<unix-nano>-<sıra>.eml` adıyla diske yazar.
+func (s *FileSender) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return fmt.Errorf("mail: create directory: %w", err)
+	}
+
+	s.mu.Lock()
+	s.seq++
+	name := fmt.Sprintf("%d-%04d.eml", time.Now().UnixNano(), s.seq)
+	s.mu.Unlock()
+
+	if err := os.WriteFile(filepath.Join(s.Dir, name), msg.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("mail: write message: %w", err)
+	}
+	return nil
+}
+
+// SMTPSender, mesajları bir SMTP sunucusu üzerinden gönderir.
+// Sunucu destekliyorsa STARTTLS kullanılır; Username boşsa kimlik doğrulaması yapılmaz.
+type SMTPSender struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+}
+
+// Send, mesajı net/smtp ile gönderir.
+func (s *SMTPSender) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+	if s.Host == "" {
+		return errors.New("mail: SMTP host is required")
+	}
+
+	port := s.Port
+	if port == 0 {
+		port = 587
+	}
+	var auth smtp.Auth
+	if s.Username != "" {
+		auth = smtp.PlainAuth("", s.Username, s.Password, s.Host)
+	}
+
+	addr := net.JoinHostPort(s.Host, strconv.Itoa(port))
+	if err := smtp.SendMail(addr, auth, msg.From, msg.To, msg.Bytes()); err != nil {
+		return fmt.Errorf("mail: smtp send: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package mail
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileSender_WritesMessage(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	sender := NewFileSender(dir)
+
+	err := sender.Send(context.Background(), Message{
+		From:    "panel@example.com",
+		To:      []string{"ada@example.com"},
+		Subject: "Doğrulama",
+		Text:    "Link: https://example.com/verify?token=abc",
+		HTML:    `<a href="https://example.com/verify?token=abc">Verify</a>`,
+	})
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected one message file, got %v (%v)", entries, err)
+	}
+	raw, _ := os.ReadFile(filepath.Join(dir, entries[0].Name()))
+	content := string(raw)
+	for _, want := range []string{
+		"To: ada@example.com",
+		"Subject: =?utf-8?q?Do=C4=9Frulama?=",
+		"multipart/alternative",
+		"token=3Dabc",
+	} {
+		if !strings.Contains(content, want) {
+			t.Fatalf("message missing %q:\n%s", want, content)
+		}
+	}
+}
+
+func TestMessage_ValidateRejectsHeaderInjection(t *testing.T) {
+	cases := []Message{
+		{To: []string{"a@example.com"}, Text: "x"},
+		{From: "p@example.com", Text: "x"},
+		{From: "p@example.com", To: []string{"a@example.com\r\nBcc: b@example.com"}, Text: "x"},
+		{From: "p@example.com", To: []string{"a@example.com"}, Subject: "hi\nBcc: x", Text: "x"},
+		{From: "p@example.com", To: []string{"a@example.com"}},
+	}
+	for i, msg := range cases {
+		if err := msg.Validate(); err == nil {
+			t.Fatalf("case %d: expected validation error", i)
+		}
+	}
+}
@@ -23,6 +23,8 @@ type APIKeyAuth struct {
 	dynamicValidator  APIKeyValidator
 	useAtomicSnapshot bool
 	snapshotState     atomic.Value // *apiKeyAuthSnapshot
+	rateLimitMu       sync.Mutex
+	rateLimits        *SlidingWindowLimiter
 }
 
 type apiKeyAuthSnapshot struct {
@@ -112,6 +114,7 @@ func (a *APIKeyAuth) Enabled() bool {
 // - Missing header: no-op (session auth can still continue)
 // - Invalid header: 401 Unauthorized
 // - Valid header: marks request as API-key authenticated
+// - Managed key outside its IP allow-list: 403; over its rate limit: 429
 func (a *APIKeyAuth) Middleware() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		enabled, header, keys, validator := a.snapshot()
@@ -135,6 +138,10 @@ func (a *APIKeyAuth) Middleware() fiber.Handler {
 			})
 		}
 
+		if ok, err := a.EnforceGrant(c); !ok {
+			return err
+		}
+
 		c.Locals(APIKeyAuthenticatedLocalKey, true)
 		return c.Next()
 	}
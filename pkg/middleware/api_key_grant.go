@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/ferdiunal/panel.go/pkg/domain/apikey"
+	"github.com/gofiber/fiber/v2"
+)
+
+// APIKeyGrantLocalKey, yönetilen key ile doğrulanan isteğin *APIKeyGrant değerini tutar.
+// Statik key ile doğrulanan isteklerin grant'i yoktur ve tam erişimi korurlar.
+const APIKeyGrantLocalKey = "api_key_grant"
+
+// apiKeyRateWindow, key başına hız limitinde kullanılan penceredir (dakikadaki istek).
+const apiKeyRateWindow = time.Minute
+
+// APIKeyGrant, yönetilen bir API key'in neye erişebileceğini tanımlar.
+type APIKeyGrant struct {
+	KeyID      uint
+	Scopes     []string
+	Resources  []string // opsiyonel resource slug izin listesi
+	AllowedIPs []string // opsiyonel IP / CIDR izin listesi
+	RateLimit  int      // dakikadaki istek sayısı, 0 = sınırsız
+}
+
+// Allows, grant'in kaynağa istenen erişime ("read" veya "write") izin verip vermediğini döner.
+func (g *APIKeyGrant) Allows(resource, access string) bool {
+	if g == nil {
+		return true
+	}
+	if len(g.Resources) > 0 && !containsString(g.Resources, resource) {
+		return false
+	}
+	return apikey.HasScope(g.Scopes, resource, access)
+}
+
+// AllowsIP, grant'in ip adresinden kullanılıp kullanılamayacağını döner.
+func (g *APIKeyGrant) AllowsIP(ip string) bool {
+	if g == nil {
+		return true
+	}
+	return apikey.MatchIP(g.AllowedIPs, ip)
+}
+
+// APIKeyGrantFromContext, isteğe bağlı grant'i döner; yoksa nil döner.
+func APIKeyGrantFromContext(c *fiber.Ctx) *APIKeyGrant {
+	if c == nil {
+		return nil
+	}
+	grant, _ := c.Locals(APIKeyGrantLocalKey).(*APIKeyGrant)
+	return grant
+}
+
+// EnforceGrant, isteğin grant'indeki IP izin listesini ve hız limitini uygular.
+// 403 veya 429 yanıtı yazdıysa false döner; grant'i olmayan istekler geçer.
+func (a *APIKeyAuth) EnforceGrant(c *fiber.Ctx) (bool, error) {
+	grant := APIKeyGrantFromContext(c)
+	if grant == nil {
+		return true, nil
+	}
+
+	if !grant.AllowsIP(c.IP()) {
+		return false, c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "API key is not allowed from this IP address",
+			"code":  "ip_not_allowed",
+		})
+	}
+
+	if grant.RateLimit > 0 && a != nil {
+		result, err := a.rateLimiter().AllowN(c.UserContext(), strconv.FormatUint(uint64(grant.KeyID), 10), grant.RateLimit)
+		if err != nil {
+			// Sayaç deposuna ulaşılamazsa diğer limiter'lar gibi isteği geçir
+			return true, nil
+		}
+		c.Set("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Set("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Set("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+		if !result.Allowed {
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			return false, c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"error": "API key rate limit exceeded",
+				"code":  "rate_limited",
+			})
+		}
+	}
+
+	return true, nil
+}
+
+// SetCounterStore, key başına hız limitlerinin store'u kullanmasını sağlar;
+// aynı store'u paylaşan replikalar limitleri de paylaşır.
+func (a *APIKeyAuth) SetCounterStore(store CounterStore) {
+	a.rateLimitMu.Lock()
+	defer a.rateLimitMu.Unlock()
+	a.rateLimits = &SlidingWindowLimiter{Store: store, Window: apiKeyRateWindow, Prefix: "apikey:"}
+}
+
+func (a *APIKeyAuth) rateLimiter() *SlidingWindowLimiter {
+	a.rateLimitMu.Lock()
+	defer a.rateLimitMu.Unlock()
+	if a.rateLimits == nil {
+		a.rateLimits = &SlidingWindowLimiter{Store: NewMemoryCounterStore(), Window: apiKeyRateWindow, Prefix: "apikey:"}
+	}
+	return a.rateLimits
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
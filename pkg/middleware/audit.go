@@ -3,6 +3,9 @@ package middleware
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -127,6 +130,11 @@ type AuditLogger interface {
 	Log(event AuditEvent) error
 }
 
+/// ImpersonatorIDLocalKey, impersonation oturumlarında SessionMiddleware'in orijinal
+/// kullanıcının ID'sini yazdığı Locals anahtarıdır. AuditMiddleware bu değeri
+/// `impersonator_id` metadata alanı olarak her olaya ekler.
+const ImpersonatorIDLocalKey = "impersonator_id"
+
 /// # ConsoleAuditLogger
 ///
 /// Bu yapı, denetim olaylarını konsola yazdıran basit bir logger implementasyonudur.
@@ -213,99 +221,175 @@ func (l *ConsoleAuditLogger) Log(event AuditEvent) error {
 
 /// # FileAuditLogger
 ///
-/// Bu yapı, denetim olaylarını dosyaya yazan production-ready bir logger implementasyonudur.
-/// Log rotation, buffering ve performans optimizasyonları ile production ortamı için tasarlanmıştır.
+/// Bu yapı, denetim olaylarını dosyaya satır başına bir JSON nesnesi (JSON Lines)
+/// olarak ekleyen logger implementasyonudur.
 ///
 /// ## Kullanım Senaryoları
 ///
 /// - Production ortamında kalıcı log saklama
 /// - Uyumluluk (compliance) gereksinimleri için log arşivleme
-/// - Güvenlik denetimi ve forensic analiz
-/// - Log aggregation sistemlerine veri besleme
-/// - Uzun vadeli log saklama ve analiz
-///
-/// ## Planlanan Özellikler (TODO)
+/// - Log aggregation sistemlerine (Filebeat, Fluent Bit vb.) veri besleme
 ///
-/// - **Log Rotation**: Dosya boyutu veya zaman bazlı otomatik rotation
-/// - **Compression**: Eski logların otomatik sıkıştırılması
-/// - **Buffering**: Performans için batch yazma
-/// - **Async Writing**: Non-blocking asenkron yazma
-/// - **Retention Policy**: Eski logların otomatik silinmesi
-/// - **Multiple Files**: Olay tipine göre farklı dosyalara yazma
-///
-/// ## Örnek Kullanım (Gelecek)
+/// ## Örnek Kullanım
 ///
 /// ```go
-/// logger := &FileAuditLogger{
-///     FilePath:       "/var/log/audit/app.log",
-///     MaxSize:        100, // MB
-///     MaxBackups:     10,
-///     MaxAge:         30, // days
-///     Compress:       true,
+/// logger, err := NewFileAuditLogger("/var/log/panel/audit.log")
+/// if err != nil {
+///     log.Fatal(err)
 /// }
+/// defer logger.Close()
 /// app.Use(AuditMiddleware(logger))
 /// ```
 ///
-/// ## Avantajlar
-///
-/// - **Kalıcılık**: Loglar disk üzerinde saklanır
-/// - **Arama**: Geçmiş logları arama ve analiz imkanı
-/// - **Uyumluluk**: Yasal gereksinimleri karşılar
-/// - **Forensics**: Güvenlik olaylarını araştırma
+/// ## Önemli Notlar
 ///
-/// ## Dezavantajlar
+/// - Dosya append modunda ve 0600 izinleriyle açılır, dizin yoksa oluşturulur
+/// - Yazma işlemleri mutex ile korunur (thread-safe)
+/// - Log rotation yapılmaz; logrotate gibi harici bir araçla `copytruncate` kullanın
+type FileAuditLogger struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+}
+
+/// # NewFileAuditLogger
 ///
-/// - **Disk Kullanımı**: Disk alanı gerektirir
-/// - **I/O Overhead**: Dosya yazma performans etkisi
-/// - **Yönetim**: Log rotation ve temizleme gerektirir
+/// Verilen dosya yoluna yazan bir FileAuditLogger oluşturur.
 ///
-/// ## Önemli Notlar
+/// ## Dönüş Değeri
 ///
-/// - **ŞU ANDA IMPLEMENT EDİLMEMİŞTİR** - TODO olarak işaretlenmiştir
-/// - Production kullanımı için önce implement edilmesi gerekir
-/// - Alternatif olarak üçüncü parti kütüphaneler kullanılabilir (lumberjack, zap, vb.)
-/// - Thread-safe implementasyon gereklidir
-type FileAuditLogger struct {
-	// TODO: Implement file-based logging
+/// - `*FileAuditLogger`: Kullanıma hazır logger
+/// - `error`: Dizin oluşturulamaz veya dosya açılamazsa hata
+func NewFileAuditLogger(path string) (*FileAuditLogger, error) {
+	if path == "" {
+		return nil, fmt.Errorf("audit log file path is required")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log file: %w", err)
+	}
+	return &FileAuditLogger{path: path, file: file}, nil
 }
 
 /// # Log
 ///
-/// Bu fonksiyon, denetim olayını dosyaya yazmak için kullanılacaktır.
-/// **ŞU ANDA IMPLEMENT EDİLMEMİŞTİR** - Placeholder implementasyon.
+/// Denetim olayını JSON formatında dosyanın sonuna yeni bir satır olarak ekler.
 ///
-/// ## Parametreler
+/// ## Dönüş Değeri
 ///
-/// - `event`: Loglanacak AuditEvent yapısı
+/// - `error`: Serileştirme veya yazma hatası, logger kapatılmışsa hata
+func (l *FileAuditLogger) Log(event AuditEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return fmt.Errorf("audit log file %s is closed", l.path)
+	}
+	_, err = l.file.Write(append(data, '\n'))
+	return err
+}
+
+/// # Path
 ///
-/// ## Dönüş Değeri
+/// Logların yazıldığı dosya yolunu döndürür.
+func (l *FileAuditLogger) Path() string {
+	return l.path
+}
+
+/// # Close
+///
+/// Log dosyasını kapatır. Birden fazla kez çağrılabilir.
+func (l *FileAuditLogger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+/// # Audit Log Seviyeleri
 ///
-/// - `error`: Şu anda her zaman nil döner (TODO implementasyonu)
+/// AuditConfig.LogLevel değerleri:
 ///
-/// ## Planlanan Davranış
+/// - `AuditLevelAll`: Tüm olaylar loglanır
+/// - `AuditLevelSecurity`: Kimlik doğrulama olayları ve 401/403/429 yanıtları loglanır
+/// - `AuditLevelErrors`: Sadece başarısız (status >= 400) istekler loglanır
+const (
+	AuditLevelAll      = "all"
+	AuditLevelSecurity = "security"
+	AuditLevelErrors   = "errors"
+)
+
+/// # LevelFilteredAuditLogger
 ///
-/// 1. AuditEvent'i JSON formatına dönüştürme
-/// 2. Dosyaya thread-safe şekilde yazma
-/// 3. Buffer'ı periyodik olarak flush etme
-/// 4. Gerekirse log rotation tetikleme
-/// 5. Hata durumunda error döndürme
+/// Olayları seviyeye göre süzerek alttaki logger'a ileten sarmalayıcıdır.
 ///
-/// ## Önemli Notlar
+/// ## Örnek Kullanım
 ///
-/// - **KULLANMAYIN**: Bu implementasyon henüz tamamlanmamıştır
-/// - Production'da kullanmadan önce implement edilmesi gerekir
-/// - Geçici olarak ConsoleAuditLogger kullanın
+/// ```go
+/// logger := NewLevelFilteredAuditLogger(&ConsoleAuditLogger{}, AuditLevelSecurity)
+/// app.Use(AuditMiddleware(logger))
+/// ```
+type LevelFilteredAuditLogger struct {
+	Logger AuditLogger
+	Level  string
+}
+
+/// # NewLevelFilteredAuditLogger
 ///
-/// ## TODO
+/// Verilen seviye için bir LevelFilteredAuditLogger oluşturur.
+/// Boş veya "all" seviyesinde logger olduğu gibi döndürülür.
+func NewLevelFilteredAuditLogger(logger AuditLogger, level string) AuditLogger {
+	if level == "" || level == AuditLevelAll {
+		return logger
+	}
+	return &LevelFilteredAuditLogger{Logger: logger, Level: level}
+}
+
+/// # Log
 ///
-/// - Dosya açma ve yazma mantığı
-/// - Log rotation implementasyonu
-/// - Buffer yönetimi
-/// - Error handling
-/// - Thread-safety (mutex kullanımı)
-func (l *FileAuditLogger) Log(event AuditEvent) error {
-	// TODO: Implement file writing with rotation
-	return nil
+/// Olay seviyeye uyuyorsa alttaki logger'a iletir, aksi halde sessizce atlar.
+func (l *LevelFilteredAuditLogger) Log(event AuditEvent) error {
+	if !AuditEventMatchesLevel(event, l.Level) {
+		return nil
+	}
+	return l.Logger.Log(event)
+}
+
+/// # AuditEventMatchesLevel
+///
+/// Olayın verilen log seviyesinde kaydedilmesi gerekip gerekmediğini bildirir.
+/// Tanınmayan seviyeler "all" gibi davranır.
+func AuditEventMatchesLevel(event AuditEvent, level string) bool {
+	switch level {
+	case AuditLevelErrors:
+		return !event.Success
+	case AuditLevelSecurity:
+		switch event.EventType {
+		case "login_success", "login_failure", "registration", "logout", "password_reset_request",
+			"two_factor_success", "two_factor_failure", "two_factor_change",
+			"email_verification", "email_verification_request", "session_revoke",
+			"impersonation_start", "impersonation_stop", DataChangeEventType:
+			return true
+		}
+		switch event.StatusCode {
+		case fiber.StatusUnauthorized, fiber.StatusForbidden, fiber.StatusTooManyRequests:
+			return true
+		}
+		return false
+	default:
+		return true
+	}
 }
 
 /// # AuditMiddleware
@@ -431,6 +515,18 @@ func AuditMiddleware(logger AuditLogger) fiber.Handler {
 				"user": user,
 			}
 		}
+		if impersonatorID := c.Locals(ImpersonatorIDLocalKey); impersonatorID != nil {
+			if event.Metadata == nil {
+				event.Metadata = map[string]interface{}{}
+			}
+			event.Metadata["impersonator_id"] = impersonatorID
+		}
+		if requestID, ok := c.Locals(RequestIDLocalKey).(string); ok && requestID != "" {
+			if event.Metadata == nil {
+				event.Metadata = map[string]interface{}{}
+			}
+			event.Metadata["request_id"] = requestID
+		}
 
 		// Determine event type based on path and method
 		event.EventType = determineEventType(c.Method(), c.Path(), c.Response().StatusCode())
@@ -858,6 +954,15 @@ func LogDataAccess(logger AuditLogger, userID, resource, action string, recordID
 /// - `registration`: Kullanıcı kaydı (path: /auth/sign-up)
 /// - `logout`: Kullanıcı çıkışı (path: /auth/sign-out)
 /// - `password_reset_request`: Şifre sıfırlama isteği (path: /auth/forgot-password)
+/// - `password_reset`: Token ile yeni şifre belirlenmesi (path: /auth/reset-password)
+/// - `two_factor_success` / `two_factor_failure`: İkinci faktör doğrulaması (path: /auth/sign-in/two-factor)
+/// - `two_factor_change`: 2FA kaydı, onayı, kapatma ve kurtarma kodu yenileme (path: /auth/two-factor, GET hariç)
+/// - `email_verification_request`: Doğrulama e-postasının yeniden gönderilmesi (path: /auth/verify-email/resend)
+/// - `email_verification`: E-posta doğrulama bağlantısının kullanılması (path: /auth/verify-email)
+/// - `session_revoke`: Oturumların uzaktan sonlandırılması (path: /auth/sessions, GET hariç)
+///
+/// `impersonation_start` ve `impersonation_stop` olayları path'ten türetilmez; auth handler
+/// tarafından hedef kullanıcı bilgisiyle birlikte doğrudan AuditLogger'a yazılır.
 ///
 /// ### Kaynak İşlemleri (CRUD)
 /// - `resource_read`: Kaynak okuma (path: /resource/*, method: GET)
@@ -926,6 +1031,24 @@ func LogDataAccess(logger AuditLogger, userID, resource, action string, recordID
 /// - Özel endpoint'ler için fonksiyon genişletilebilir
 func determineEventType(method, path string, statusCode int) string {
 	// Authentication endpoints
+	if contains(path, "/auth/sign-in/two-factor") {
+		if statusCode < 400 {
+			return "two_factor_success"
+		}
+		return "two_factor_failure"
+	}
+	if contains(path, "/auth/two-factor") && method != "GET" {
+		return "two_factor_change"
+	}
+	if contains(path, "/auth/sessions") && method != "GET" {
+		return "session_revoke"
+	}
+	if contains(path, "/auth/verify-email/resend") {
+		return "email_verification_request"
+	}
+	if contains(path, "/auth/verify-email") {
+		return "email_verification"
+	}
 	if contains(path, "/auth/sign-in") {
 		if statusCode < 400 {
 			return "login_success"
@@ -941,6 +1064,9 @@ func determineEventType(method, path string, statusCode int) string {
 	if contains(path, "/auth/forgot-password") {
 		return "password_reset_request"
 	}
+	if contains(path, "/auth/reset-password") {
+		return "password_reset"
+	}
 
 	// Resource operations
 	if contains(path, "/resource/") {
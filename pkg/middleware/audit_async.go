@@ -0,0 +1,215 @@
+package middleware
+
+import (
+	"log"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// / # AuditEventWriter
+// /
+// / Olayları toplu olarak kalıcı bir hedefe (veritabanı, uzak servis) yazan arayüzdür.
+// / AsyncAuditLogger, kuyruktaki olayları bu arayüz üzerinden parti parti yazar.
+type AuditEventWriter interface {
+	WriteAuditEvents(events []AuditEvent) error
+}
+
+// / # AsyncAuditLoggerOptions
+// /
+// / - `BufferSize`: Kuyruk kapasitesi (varsayılan: 1024). Kuyruk doluysa olay düşürülür.
+// / - `BatchSize`: Tek yazmada en fazla olay sayısı (varsayılan: 100)
+// / - `FlushInterval`: Parti dolmasa da yazma aralığı (varsayılan: 1 saniye)
+type AsyncAuditLoggerOptions struct {
+	BufferSize    int
+	BatchSize     int
+	FlushInterval time.Duration
+}
+
+// / # AsyncAuditLogger
+// /
+// / Olayları buffered channel üzerinden arka planda bir AuditEventWriter'a yazan logger'dır.
+// / İstek akışı veritabanı yazmasını beklemez.
+// /
+// / ## Örnek Kullanım
+// /
+// / ```go
+// / logger := NewAsyncAuditLogger(writer, AsyncAuditLoggerOptions{BufferSize: 4096})
+// / defer logger.Close()
+// / app.Use(AuditMiddleware(logger))
+// / ```
+// /
+// / ## Önemli Notlar
+// /
+// / - Log bloklamaz; kuyruk doluysa olay düşürülür ve `Dropped()` sayacı artar
+// / - Yazma hataları loglanır, olaylar tekrar denenmez
+// / - Close kuyrukta kalan olayları yazdıktan sonra döner
+type AsyncAuditLogger struct {
+	writer        AuditEventWriter
+	batchSize     int
+	flushInterval time.Duration
+
+	queue   chan AuditEvent
+	flushCh chan chan struct{}
+	doneCh  chan struct{}
+	dropped atomic.Int64
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// / # NewAsyncAuditLogger
+// /
+// / Arka plan yazıcı goroutine'ini başlatır.
+func NewAsyncAuditLogger(writer AuditEventWriter, opts AsyncAuditLoggerOptions) *AsyncAuditLogger {
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = 1024
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 100
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = time.Second
+	}
+
+	l := &AsyncAuditLogger{
+		writer:        writer,
+		batchSize:     opts.BatchSize,
+		flushInterval: opts.FlushInterval,
+		queue:         make(chan AuditEvent, opts.BufferSize),
+		flushCh:       make(chan chan struct{}),
+		doneCh:        make(chan struct{}),
+	}
+	go l.run()
+	return l
+}
+
+// / # Log
+// /
+// / Olayı kuyruğa ekler. Kuyruk doluysa veya logger kapatılmışsa olay düşürülür.
+func (l *AsyncAuditLogger) Log(event AuditEvent) error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.closed {
+		l.dropped.Add(1)
+		return nil
+	}
+
+	select {
+	case l.queue <- detachAuditEvent(event):
+	default:
+		l.dropped.Add(1)
+	}
+	return nil
+}
+
+// detachAuditEvent, olayın string alanlarını kopyalar. Fiber'ın döndürdüğü Path, IP,
+// header ve header'dan gelen Locals değerleri havuzdaki istek buffer'larını gösterir;
+// handler döndükten sonra bu buffer'lar yeniden kullanıldığı için kuyruğa alınan
+// olay kopyalanmadan saklanamaz.
+func detachAuditEvent(event AuditEvent) AuditEvent {
+	event.EventType = strings.Clone(event.EventType)
+	event.UserID = strings.Clone(event.UserID)
+	event.Email = strings.Clone(event.Email)
+	event.IP = strings.Clone(event.IP)
+	event.UserAgent = strings.Clone(event.UserAgent)
+	event.Method = strings.Clone(event.Method)
+	event.Path = strings.Clone(event.Path)
+	event.ErrorMessage = strings.Clone(event.ErrorMessage)
+	event.Resource = strings.Clone(event.Resource)
+	event.Action = strings.Clone(event.Action)
+	if event.Metadata != nil {
+		metadata := make(map[string]interface{}, len(event.Metadata))
+		for key, value := range event.Metadata {
+			if text, ok := value.(string); ok {
+				value = strings.Clone(text)
+			}
+			metadata[key] = value
+		}
+		event.Metadata = metadata
+	}
+	return event
+}
+
+// / # Dropped
+// /
+// / Kuyruk dolu olduğu için yazılamayan olay sayısını döndürür.
+func (l *AsyncAuditLogger) Dropped() int64 {
+	return l.dropped.Load()
+}
+
+// / # Flush
+// /
+// / Flush çağrısından önce kuyruğa alınan olaylar yazılana kadar bekler.
+func (l *AsyncAuditLogger) Flush() {
+	l.mu.RLock()
+	if l.closed {
+		l.mu.RUnlock()
+		return
+	}
+	done := make(chan struct{})
+	l.flushCh <- done
+	l.mu.RUnlock()
+	<-done
+}
+
+// / # Close
+// /
+// / Yeni olay kabulünü durdurur, kuyruktakileri yazar ve goroutine'in bitmesini bekler.
+// / Birden fazla kez çağrılabilir.
+func (l *AsyncAuditLogger) Close() error {
+	l.mu.Lock()
+	if !l.closed {
+		l.closed = true
+		close(l.queue)
+	}
+	l.mu.Unlock()
+
+	<-l.doneCh
+	return nil
+}
+
+func (l *AsyncAuditLogger) run() {
+	defer close(l.doneCh)
+
+	ticker := time.NewTicker(l.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]AuditEvent, 0, l.batchSize)
+	write := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := l.writer.WriteAuditEvents(batch); err != nil {
+			log.Printf("[audit] failed to write %d audit events: %v", len(batch), err)
+		}
+		batch = make([]AuditEvent, 0, l.batchSize)
+	}
+
+	for {
+		select {
+		case event, ok := <-l.queue:
+			if !ok {
+				write()
+				return
+			}
+			batch = append(batch, event)
+			if len(batch) >= l.batchSize {
+				write()
+			}
+		case done := <-l.flushCh:
+			// Drain what was queued before the flush request
+			for pending := len(l.queue); pending > 0; pending-- {
+				batch = append(batch, <-l.queue)
+				if len(batch) >= l.batchSize {
+					write()
+				}
+			}
+			write()
+			close(done)
+		case <-ticker.C:
+			write()
+		}
+	}
+}
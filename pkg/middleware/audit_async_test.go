@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingAuditWriter struct {
+	mu      sync.Mutex
+	batches [][]AuditEvent
+	block   chan struct{}
+}
+
+func (w *recordingAuditWriter) WriteAuditEvents(events []AuditEvent) error {
+	if w.block != nil {
+		<-w.block
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.batches = append(w.batches, append([]AuditEvent(nil), events...))
+	return nil
+}
+
+func (w *recordingAuditWriter) count() (events, batches int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, batch := range w.batches {
+		events += len(batch)
+	}
+	return events, len(w.batches)
+}
+
+func TestAsyncAuditLoggerBatchesAndFlushes(t *testing.T) {
+	writer := &recordingAuditWriter{}
+	logger := NewAsyncAuditLogger(writer, AsyncAuditLoggerOptions{BatchSize: 3, FlushInterval: time.Hour})
+	defer logger.Close()
+
+	for i := 0; i < 7; i++ {
+		require.NoError(t, logger.Log(AuditEvent{EventType: "request"}))
+	}
+	logger.Flush()
+
+	events, batches := writer.count()
+	assert.Equal(t, 7, events)
+	assert.Equal(t, 3, batches) // 3 + 3 + 1
+	assert.Equal(t, int64(0), logger.Dropped())
+}
+
+func TestAsyncAuditLoggerCloseDrainsQueue(t *testing.T) {
+	writer := &recordingAuditWriter{}
+	logger := NewAsyncAuditLogger(writer, AsyncAuditLoggerOptions{FlushInterval: time.Hour})
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, logger.Log(AuditEvent{EventType: "request"}))
+	}
+	require.NoError(t, logger.Close())
+	require.NoError(t, logger.Close())
+
+	events, _ := writer.count()
+	assert.Equal(t, 5, events)
+
+	// Events logged after Close are dropped
+	require.NoError(t, logger.Log(AuditEvent{EventType: "late"}))
+	assert.Equal(t, int64(1), logger.Dropped())
+	logger.Flush()
+}
+
+func TestAsyncAuditLoggerDropsWhenBufferFull(t *testing.T) {
+	writer := &recordingAuditWriter{block: make(chan struct{})}
+	logger := NewAsyncAuditLogger(writer, AsyncAuditLoggerOptions{BufferSize: 2, BatchSize: 1, FlushInterval: time.Hour})
+
+	// The first event occupies the blocked writer, the next two fill the buffer
+	require.NoError(t, logger.Log(AuditEvent{EventType: "request"}))
+	require.Eventually(t, func() bool { return len(logger.queue) == 0 }, time.Second, time.Millisecond)
+	for i := 0; i < 4; i++ {
+		require.NoError(t, logger.Log(AuditEvent{EventType: "request"}))
+	}
+	assert.Equal(t, int64(2), logger.Dropped())
+
+	close(writer.block)
+	require.NoError(t, logger.Close())
+	events, _ := writer.count()
+	assert.Equal(t, 3, events)
+}
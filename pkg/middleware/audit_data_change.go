@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ferdiunal/panel.go/pkg/domain/session"
+	"github.com/ferdiunal/panel.go/pkg/domain/user"
+	"github.com/gofiber/fiber/v2"
+)
+
+// / RequestIDLocalKey, RequestID middleware'inin istek kimliğini yazdığı Locals anahtarıdır.
+// / AuditMiddleware ve LogDataChange bu değeri `request_id` metadata alanı olarak ekler.
+const RequestIDLocalKey = "requestid"
+
+// / DataChangeEventType, resource create/update/delete işlemleri için yazılan olay tipidir.
+const DataChangeEventType = "data_change"
+
+// / # FieldChange
+// /
+// / Bir alanın işlem öncesi ve sonrası değeridir. Create işleminde Old, delete
+// / işleminde New boştur. Hassas alanlarda her iki değer de maskelenmiş olarak gelir.
+type FieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// / # DataChange
+// /
+// / Tek bir kayıt üzerindeki değişikliği tanımlar.
+// /
+// / - `Resource`: Resource slug'ı (örn: "products")
+// / - `Action`: "create", "update" veya "delete"
+// / - `RecordID`: Değişen kaydın ID'si
+// / - `Changes`: Alan anahtarına göre eski/yeni değerler
+// / - `StatusCode`: İsteğe dönülecek HTTP durum kodu; 0 ise yanıtın mevcut durum kodu kullanılır
+type DataChange struct {
+	Resource   string
+	Action     string
+	RecordID   string
+	Changes    map[string]FieldChange
+	StatusCode int
+}
+
+// / # LogDataChange
+// /
+// / Veri değişikliğini, isteği yapan kullanıcı ve kimlik bilgisiyle birlikte loglar.
+// / "42 numaralı ürünün fiyatını 10'dan 12'ye kim değiştirdi?" sorusunu yanıtlamak
+// / için resource store/update/destroy controller'ları tarafından çağrılır.
+// /
+// / ## Metadata Alanları
+// /
+// / - `record_id`: Değişen kayıt
+// / - `changes`: `{"price": {"old": 10, "new": 12}}`
+// / - `request_id`: İstek kimliği (RequestID middleware'i kayıtlıysa)
+// / - `auth_method`: "session" veya "api_key"
+// / - `session_id` / `api_key_id`: Değişikliği yapan oturum veya yönetilen API key
+// / - `impersonator_id`: Impersonation sırasında asıl kullanıcı
+// /
+// / ## Önemli Notlar
+// /
+// / - Logger nil ise hiçbir şey yapmaz
+// / - Loglama hatası isteği durdurmaz
+func LogDataChange(logger AuditLogger, c *fiber.Ctx, change DataChange) {
+	if logger == nil || c == nil {
+		return
+	}
+
+	metadata := map[string]interface{}{
+		"record_id": change.RecordID,
+		"changes":   change.Changes,
+	}
+	if requestID, ok := c.Locals(RequestIDLocalKey).(string); ok && requestID != "" {
+		metadata["request_id"] = requestID
+	}
+	if apiKeyAuth, ok := c.Locals(APIKeyAuthenticatedLocalKey).(bool); ok && apiKeyAuth {
+		metadata["auth_method"] = "api_key"
+		if grant := APIKeyGrantFromContext(c); grant != nil {
+			metadata["api_key_id"] = grant.KeyID
+		}
+	} else if sess, ok := c.Locals("session").(*session.Session); ok && sess != nil {
+		metadata["auth_method"] = "session"
+		metadata["session_id"] = sess.ID
+	}
+	if impersonatorID := c.Locals(ImpersonatorIDLocalKey); impersonatorID != nil {
+		metadata["impersonator_id"] = impersonatorID
+	}
+
+	statusCode := change.StatusCode
+	if statusCode == 0 {
+		statusCode = c.Response().StatusCode()
+	}
+
+	event := AuditEvent{
+		Timestamp:  time.Now(),
+		EventType:  DataChangeEventType,
+		IP:         c.IP(),
+		UserAgent:  c.Get("User-Agent"),
+		Method:     c.Method(),
+		Path:       c.Path(),
+		StatusCode: statusCode,
+		Success:    true,
+		Resource:   change.Resource,
+		Action:     change.Action,
+		Metadata:   metadata,
+	}
+	if u, ok := c.Locals("user").(*user.User); ok && u != nil {
+		event.UserID = strconv.FormatUint(uint64(u.ID), 10)
+		event.Email = u.Email
+	}
+
+	if err := logger.Log(event); err != nil {
+		fmt.Printf("[AUDIT ERROR] Failed to log data change: %v\n", err)
+	}
+}
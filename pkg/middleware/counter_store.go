@@ -0,0 +1,197 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// CounterStore, rate limiting ve hesap kilitleme için süreli sayaçları tutar.
+//
+// Uygulamalar eşzamanlı kullanıma uygun olmalıdır. Paylaşılan uygulamalar
+// (SQL tablosu, Redis) birden fazla panel replikasının aynı limitleri
+// uygulamasını sağlar.
+type CounterStore interface {
+	// Increment, key'e delta ekler ve yeni değeri döner. Olmayan veya süresi
+	// dolmuş key sıfırdan başlar ve bu çağrıdan ttl sonra sona erer; sonraki
+	// artışlar süreyi uzatmaz.
+	Increment(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error)
+
+	// Get, key'in değerini döner; key yoksa veya süresi dolmuşsa 0 döner.
+	Get(ctx context.Context, key string) (int64, error)
+
+	// Delete, key'i siler.
+	Delete(ctx context.Context, key string) error
+}
+
+// memorySweepInterval, MemoryCounterStore'un süresi dolmuş key'leri ne sıklıkla sildiğidir.
+const memorySweepInterval = time.Minute
+
+// MemoryCounterStore, süreç içi CounterStore'dur. Sayaçlar yeniden başlatmada
+// kaybolur ve replikalar arasında paylaşılmaz.
+type MemoryCounterStore struct {
+	mu        sync.Mutex
+	counters  map[string]memoryCounter
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type memoryCounter struct {
+	value     int64
+	expiresAt time.Time
+}
+
+// NewMemoryCounterStore, boş bir bellek içi store oluşturur.
+func NewMemoryCounterStore() *MemoryCounterStore {
+	return &MemoryCounterStore{
+		counters: make(map[string]memoryCounter),
+		now:      time.Now,
+	}
+}
+
+// Increment, CounterStore arayüzünü uygular.
+func (s *MemoryCounterStore) Increment(_ context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweepLocked(now)
+
+	counter, ok := s.counters[key]
+	if !ok || !now.Before(counter.expiresAt) {
+		counter = memoryCounter{expiresAt: now.Add(ttl)}
+	}
+	counter.value += delta
+	s.counters[key] = counter
+	return counter.value, nil
+}
+
+// Get, CounterStore arayüzünü uygular.
+func (s *MemoryCounterStore) Get(_ context.Context, key string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counter, ok := s.counters[key]
+	if !ok || !s.now().Before(counter.expiresAt) {
+		return 0, nil
+	}
+	return counter.value, nil
+}
+
+// Delete, CounterStore arayüzünü uygular.
+func (s *MemoryCounterStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.counters, key)
+	return nil
+}
+
+// sweepLocked, süresi dolmuş sayaçları dakikada en fazla bir kez siler.
+// Çağıran s.mu kilidini tutmalıdır.
+func (s *MemoryCounterStore) sweepLocked(now time.Time) {
+	if now.Sub(s.lastSweep) < memorySweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for key, counter := range s.counters {
+		if !now.Before(counter.expiresAt) {
+			delete(s.counters, key)
+		}
+	}
+}
+
+// RateLimitResult, kayan pencere kararını açıklar.
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	ResetAfter time.Duration // mevcut sabit pencerenin bitmesine kalan süre
+	RetryAfter time.Duration // sonraki isteğe izin verilmesine kalan süre; Allowed ise 0
+}
+
+// SlidingWindowLimiter, kayan pencere sayaç algoritmasını bir CounterStore
+// üzerinde uygular.
+//
+// Her key için sabit pencere başına bir sayaç tutulur. İstek hızı, mevcut
+// pencerenin sayısına önceki pencerenin sayısının kayan pencereyle hâlâ örtüşen
+// oranı kadarı eklenerek tahmin edilir. Reddedilen istekler de sayılır; böylece
+// sürekli yeniden deneyen istemciler sınırlı kalır.
+type SlidingWindowLimiter struct {
+	Store  CounterStore
+	Limit  int
+	Window time.Duration
+	Prefix string
+
+	now func() time.Time
+}
+
+// Allow, key için bir isteği kaydeder ve isteğin limite sığıp sığmadığını döner.
+func (l *SlidingWindowLimiter) Allow(ctx context.Context, key string) (RateLimitResult, error) {
+	return l.AllowN(ctx, key, l.Limit)
+}
+
+// AllowN, limiti çağrı başına verilen Allow'dur (örn. tek limiter'ı paylaşan
+// API key bazlı limitler).
+func (l *SlidingWindowLimiter) AllowN(ctx context.Context, key string, limit int) (RateLimitResult, error) {
+	nowFn := l.now
+	if nowFn == nil {
+		nowFn = time.Now
+	}
+	now := nowFn()
+	window := l.Window
+	if window <= 0 {
+		window = time.Minute
+	}
+
+	index := now.UnixNano() / int64(window)
+	elapsed := time.Duration(now.UnixNano() - index*int64(window))
+	base := l.Prefix + key + ":"
+
+	current, err := l.Store.Increment(ctx, base+strconv.FormatInt(index, 10), 1, 2*window)
+	if err != nil {
+		return RateLimitResult{Allowed: true, Limit: limit, Remaining: limit}, err
+	}
+	previous, err := l.Store.Get(ctx, base+strconv.FormatInt(index-1, 10))
+	if err != nil {
+		return RateLimitResult{Allowed: true, Limit: limit, Remaining: limit}, err
+	}
+
+	weight := 1 - float64(elapsed)/float64(window)
+	estimate := float64(previous)*weight + float64(current)
+
+	result := RateLimitResult{
+		Allowed:    estimate <= float64(limit),
+		Limit:      limit,
+		Remaining:  int(math.Max(0, math.Floor(float64(limit)-estimate))),
+		ResetAfter: window - elapsed,
+	}
+	if !result.Allowed {
+		result.RetryAfter = retryAfter(float64(previous), float64(current), float64(limit), elapsed, window)
+	}
+	return result, nil
+}
+
+// retryAfter, yeni istek gelmediği varsayılarak bir isteğin daha sığmasına
+// kalan süreyi döner.
+func retryAfter(previous, current, limit float64, elapsed, window time.Duration) time.Duration {
+	target := limit - 1
+	if current <= target && previous > 0 {
+		// Önceki pencerenin payı azalmalıdır: previous*(1-e/window) + current <= target
+		wait := time.Duration(float64(window)*(1-(target-current)/previous)) - elapsed
+		if wait > 0 {
+			return wait
+		}
+		return time.Second
+	}
+	// Mevcut pencere önceki pencere olur ve sonraki pencerede azalması gerekir
+	wait := window - elapsed
+	if current > 0 && target > 0 {
+		wait += time.Duration(float64(window) * (1 - target/current))
+	} else {
+		wait += window
+	}
+	return wait
+}
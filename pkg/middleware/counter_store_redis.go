@@ -0,0 +1,294 @@
+package middleware
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// redisIncrementScript, key'i artırır ve süreyi yalnızca key oluşturulurken
+// ayarlar; böylece pencere her istekte ileri kaymaz.
+const redisIncrementScript = `local v = redis.call('INCRBY', KEYS[1], ARGV[1])
+if v == tonumber(ARGV[1]) then redis.call('PEXPIRE', KEYS[1], ARGV[2]) end
+return v`
+
+// RedisCounterStoreOptions, RedisCounterStore ayarlarıdır.
+type RedisCounterStoreOptions struct {
+	Addr     string // host:port
+	Password string
+	DB       int
+	Prefix   string // her key'in başına eklenir, örn. "panel:"
+
+	DialTimeout time.Duration // varsayılan 5sn
+	IOTimeout   time.Duration // komut başına, varsayılan 3sn
+	PoolSize    int           // tutulan boşta bağlantı sayısı, varsayılan 10
+}
+
+// ParseRedisURL, redis://[:parola@]host[:port][/db] adresini ayarlara çevirir.
+func ParseRedisURL(raw string) (RedisCounterStoreOptions, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return RedisCounterStoreOptions{}, fmt.Errorf("invalid redis url: %w", err)
+	}
+	if u.Scheme != "redis" {
+		return RedisCounterStoreOptions{}, fmt.Errorf("invalid redis url: unsupported scheme %q", u.Scheme)
+	}
+
+	opts := RedisCounterStoreOptions{Addr: u.Host}
+	if u.Port() == "" {
+		opts.Addr = net.JoinHostPort(u.Hostname(), "6379")
+	}
+	if u.User != nil {
+		if password, ok := u.User.Password(); ok {
+			opts.Password = password
+		} else {
+			opts.Password = u.User.Username()
+		}
+	}
+	if db := strings.Trim(u.Path, "/"); db != "" {
+		opts.DB, err = strconv.Atoi(db)
+		if err != nil {
+			return RedisCounterStoreOptions{}, fmt.Errorf("invalid redis url: database %q is not a number", db)
+		}
+	}
+	return opts, nil
+}
+
+// RedisCounterStore, Redis protokolünü konuşan herhangi bir sunucu (Redis,
+// Valkey, KeyDB, Dragonfly) üzerinde çalışan CounterStore'dur. Küçük bir
+// yerleşik RESP istemcisi kullanır.
+type RedisCounterStore struct {
+	opts RedisCounterStoreOptions
+
+	mu     sync.Mutex
+	idle   []*redisConn
+	closed bool
+}
+
+// NewRedisCounterStore, store oluşturur ve bağlantıyı PING ile doğrular.
+func NewRedisCounterStore(opts RedisCounterStoreOptions) (*RedisCounterStore, error) {
+	if opts.Addr == "" {
+		return nil, errors.New("redis counter store: address is required")
+	}
+	if opts.DialTimeout <= 0 {
+		opts.DialTimeout = 5 * time.Second
+	}
+	if opts.IOTimeout <= 0 {
+		opts.IOTimeout = 3 * time.Second
+	}
+	if opts.PoolSize <= 0 {
+		opts.PoolSize = 10
+	}
+
+	store := &RedisCounterStore{opts: opts}
+	if _, err := store.do(context.Background(), "PING"); err != nil {
+		return nil, fmt.Errorf("redis counter store: %w", err)
+	}
+	return store, nil
+}
+
+// Increment, CounterStore arayüzünü uygular.
+func (s *RedisCounterStore) Increment(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	ms := ttl.Milliseconds()
+	if ms <= 0 {
+		ms = 1
+	}
+	reply, err := s.do(ctx, "EVAL", redisIncrementScript, "1", s.opts.Prefix+key,
+		strconv.FormatInt(delta, 10), strconv.FormatInt(ms, 10))
+	if err != nil {
+		return 0, err
+	}
+	value, ok := reply.(int64)
+	if !ok {
+		return 0, fmt.Errorf("redis counter store: unexpected reply %T", reply)
+	}
+	return value, nil
+}
+
+// Get, CounterStore arayüzünü uygular.
+func (s *RedisCounterStore) Get(ctx context.Context, key string) (int64, error) {
+	reply, err := s.do(ctx, "GET", s.opts.Prefix+key)
+	if err != nil {
+		return 0, err
+	}
+	switch value := reply.(type) {
+	case nil:
+		return 0, nil
+	case string:
+		return strconv.ParseInt(value, 10, 64)
+	default:
+		return 0, fmt.Errorf("redis counter store: unexpected reply %T", reply)
+	}
+}
+
+// Delete, CounterStore arayüzünü uygular.
+func (s *RedisCounterStore) Delete(ctx context.Context, key string) error {
+	_, err := s.do(ctx, "DEL", s.opts.Prefix+key)
+	return err
+}
+
+// Close, boştaki bağlantıları kapatır; sonraki çağrılar hata döner.
+func (s *RedisCounterStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	for _, conn := range s.idle {
+		_ = conn.Close()
+	}
+	s.idle = nil
+	return nil
+}
+
+// do, havuzdan alınan bağlantıda komutu çalıştırır ve yanıtı döner.
+func (s *RedisCounterStore) do(ctx context.Context, args ...string) (interface{}, error) {
+	conn, err := s.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(s.opts.IOTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	_ = conn.SetDeadline(deadline)
+
+	reply, err := conn.command(args...)
+	var replyErr redisError
+	if err != nil && !errors.As(err, &replyErr) {
+		// G/Ç hatasından sonra bağlantının durumu bilinmez
+		_ = conn.Close()
+		return nil, err
+	}
+	s.put(conn)
+	return reply, err
+}
+
+func (s *RedisCounterStore) get(ctx context.Context) (*redisConn, error) {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil, errors.New("redis counter store: closed")
+	}
+	if n := len(s.idle); n > 0 {
+		conn := s.idle[n-1]
+		s.idle = s.idle[:n-1]
+		s.mu.Unlock()
+		return conn, nil
+	}
+	s.mu.Unlock()
+
+	dialer := net.Dialer{Timeout: s.opts.DialTimeout}
+	netConn, err := dialer.DialContext(ctx, "tcp", s.opts.Addr)
+	if err != nil {
+		return nil, err
+	}
+	conn := &redisConn{Conn: netConn, reader: bufio.NewReader(netConn)}
+	_ = conn.SetDeadline(time.Now().Add(s.opts.IOTimeout))
+
+	if s.opts.Password != "" {
+		if _, err := conn.command("AUTH", s.opts.Password); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+	}
+	if s.opts.DB != 0 {
+		if _, err := conn.command("SELECT", strconv.Itoa(s.opts.DB)); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+func (s *RedisCounterStore) put(conn *redisConn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed || len(s.idle) >= s.opts.PoolSize {
+		_ = conn.Close()
+		return
+	}
+	s.idle = append(s.idle, conn)
+}
+
+// redisError, sunucunun gönderdiği hata yanıtıdır.
+type redisError string
+
+func (e redisError) Error() string { return "redis: " + string(e) }
+
+type redisConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+// command, argümanları RESP dizisi olarak yazar ve tek bir yanıt okur.
+func (c *redisConn) command(args ...string) (interface{}, error) {
+	var b strings.Builder
+	b.WriteString("*" + strconv.Itoa(len(args)) + "\r\n")
+	for _, arg := range args {
+		b.WriteString("$" + strconv.Itoa(len(arg)) + "\r\n" + arg + "\r\n")
+	}
+	if _, err := io.WriteString(c.Conn, b.String()); err != nil {
+		return nil, err
+	}
+	return c.readReply()
+}
+
+func (c *redisConn) readReply() (interface{}, error) {
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, errors.New("redis: empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, redisError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(c.reader, buf); err != nil {
+			return nil, err
+		}
+		return string(buf[:size]), nil
+	case '*':
+		count, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if count < 0 {
+			return nil, nil
+		}
+		items := make([]interface{}, count)
+		for i := range items {
+			if items[i], err = c.readReply(); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("redis: unexpected reply %q", line)
+	}
+}
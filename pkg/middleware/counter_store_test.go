@@ -0,0 +1,172 @@
+package middleware
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCounterStore checks the CounterStore contract shared by every implementation.
+func testCounterStore(t *testing.T, store CounterStore, prefix string) {
+	ctx := context.Background()
+	key := prefix + "counter"
+	defer func() { _ = store.Delete(ctx, key) }()
+
+	value, err := store.Get(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), value)
+
+	for want := int64(1); want <= 3; want++ {
+		value, err = store.Increment(ctx, key, 1, time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, want, value)
+	}
+	value, err = store.Increment(ctx, key, 5, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), value)
+
+	value, err = store.Get(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), value)
+
+	require.NoError(t, store.Delete(ctx, key))
+	value, err = store.Get(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), value)
+
+	// Expired counters read as zero and restart
+	short := prefix + "short"
+	defer func() { _ = store.Delete(ctx, short) }()
+	_, err = store.Increment(ctx, short, 4, 50*time.Millisecond)
+	require.NoError(t, err)
+	time.Sleep(120 * time.Millisecond)
+	value, err = store.Get(ctx, short)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), value)
+	value, err = store.Increment(ctx, short, 1, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), value)
+}
+
+func TestMemoryCounterStore(t *testing.T) {
+	testCounterStore(t, NewMemoryCounterStore(), "")
+}
+
+// The Redis store runs against a local server, e.g. PANEL_TEST_REDIS_URL=redis://localhost:6379/15
+func TestRedisCounterStore(t *testing.T) {
+	raw := os.Getenv("PANEL_TEST_REDIS_URL")
+	if raw == "" {
+		t.Skip("PANEL_TEST_REDIS_URL is not set")
+	}
+	opts, err := ParseRedisURL(raw)
+	require.NoError(t, err)
+	opts.Prefix = "panel-test:"
+	store, err := NewRedisCounterStore(opts)
+	require.NoError(t, err)
+	defer store.Close()
+
+	testCounterStore(t, store, strconv.FormatInt(time.Now().UnixNano(), 10)+":")
+}
+
+func TestParseRedisURL(t *testing.T) {
+	opts, err := ParseRedisURL("redis://:secret@cache.internal/2")
+	require.NoError(t, err)
+	assert.Equal(t, "cache.internal:6379", opts.Addr)
+	assert.Equal(t, "secret", opts.Password)
+	assert.Equal(t, 2, opts.DB)
+
+	_, err = ParseRedisURL("http://localhost:6379")
+	assert.Error(t, err)
+	_, err = ParseRedisURL("redis://localhost:6379/cache")
+	assert.Error(t, err)
+}
+
+func TestSlidingWindowLimiter(t *testing.T) {
+	now := time.Unix(0, 0).Add(10 * time.Minute)
+	limiter := &SlidingWindowLimiter{
+		Store:  NewMemoryCounterStore(),
+		Limit:  4,
+		Window: time.Minute,
+		now:    func() time.Time { return now },
+	}
+	ctx := context.Background()
+
+	for i := 0; i < 4; i++ {
+		result, err := limiter.Allow(ctx, "client")
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 3-i, result.Remaining)
+	}
+	result, err := limiter.Allow(ctx, "client")
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Greater(t, result.RetryAfter, time.Duration(0))
+
+	// Half way into the next window half of the previous window still counts
+	now = now.Add(90 * time.Second)
+	result, err = limiter.Allow(ctx, "client")
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 30*time.Second, result.ResetAfter)
+	assert.Equal(t, 0, result.Remaining) // 5*0.5 + 1 = 3.5
+
+	result, err = limiter.Allow(ctx, "client")
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+
+	// Other keys are independent
+	result, err = limiter.Allow(ctx, "other")
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+}
+
+func TestRateLimiterHeadersAndSharedStore(t *testing.T) {
+	store := NewMemoryCounterStore()
+	newReplica := func() *fiber.App {
+		app := fiber.New()
+		app.Use(RateLimiter(RateLimitConfig{Max: 3, Expiration: time.Minute, Store: store}))
+		app.Get("/", func(c *fiber.Ctx) error { return c.SendString("OK") })
+		return app
+	}
+	replicas := []*fiber.App{newReplica(), newReplica()}
+
+	for i := 0; i < 3; i++ {
+		resp, err := replicas[i%2].Test(httptest.NewRequest("GET", "/", nil))
+		require.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, "3", resp.Header.Get("X-RateLimit-Limit"))
+		assert.Equal(t, strconv.Itoa(2-i), resp.Header.Get("X-RateLimit-Remaining"))
+		assert.NotEmpty(t, resp.Header.Get("X-RateLimit-Reset"))
+	}
+
+	// The limit is shared, so the other replica rejects the fourth request
+	resp, err := replicas[1].Test(httptest.NewRequest("GET", "/", nil))
+	require.NoError(t, err)
+	assert.Equal(t, 429, resp.StatusCode)
+	assert.NotEmpty(t, resp.Header.Get("Retry-After"))
+}
+
+func TestAccountLockoutSharedStore(t *testing.T) {
+	store := NewMemoryCounterStore()
+	first := NewAccountLockoutWithStore(store, 3, time.Minute)
+	second := NewAccountLockoutWithStore(store, 3, time.Minute)
+
+	first.RecordFailedAttempt("user@example.com")
+	second.RecordFailedAttempt("user@example.com")
+	assert.Equal(t, 1, first.GetRemainingAttempts("user@example.com"))
+
+	first.RecordFailedAttempt("user@example.com")
+	assert.True(t, second.IsLocked("user@example.com"))
+	assert.Equal(t, 0, second.GetRemainingAttempts("user@example.com"))
+
+	second.ResetAttempts("user@example.com")
+	assert.False(t, first.IsLocked("user@example.com"))
+	assert.Equal(t, 3, first.GetRemainingAttempts("user@example.com"))
+}
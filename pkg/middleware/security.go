@@ -1,13 +1,13 @@
 package middleware
 
 import (
+	"context"
 	"fmt"
+	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/limiter"
 )
 
 // / # RateLimitConfig
@@ -22,6 +22,7 @@ import (
 // / * `Expiration` - Zaman penceresi süresi (örn: 1 dakika, 1 saat)
 // / * `KeyGenerator` - İstemciyi tanımlamak için özel anahtar üreteci (varsayılan: IP adresi)
 // / * `LimitReached` - Limit aşıldığında çalıştırılacak özel handler
+// / * `Store` - Sayaçların tutulduğu CounterStore (varsayılan: bellek içi store)
 // /
 // / ## Kullanım Senaryoları
 // /
@@ -66,7 +67,8 @@ import (
 // / * `Max` değeri 0 ise, varsayılan olarak 100 kullanılır
 // / * `Expiration` değeri 0 ise, varsayılan olarak 1 dakika kullanılır
 // / * `KeyGenerator` nil ise, varsayılan olarak IP adresi kullanılır
-// / * Rate limit bilgileri bellekte tutulur, sunucu yeniden başlatıldığında sıfırlanır
+// / * `Store` nil ise sayaçlar bellekte tutulur; birden fazla replika için paylaşılan
+// /   bir store (SQL tablosu, Redis) verilmelidir
 type RateLimitConfig struct {
 	// Max number of requests per window
 	Max int
@@ -76,6 +78,10 @@ type RateLimitConfig struct {
 	KeyGenerator func(*fiber.Ctx) string
 	// Custom response when limit exceeded
 	LimitReached fiber.Handler
+	// Counter storage (default: in-memory)
+	Store CounterStore
+	// Counter key prefix, separates limiters sharing one store (default: "ratelimit:")
+	Prefix string
 }
 
 // / # SecurityHeadersConfig
@@ -324,9 +330,11 @@ func SecurityHeaders(config SecurityHeadersConfig) fiber.Handler {
 // / ## Çalışma Mantığı
 // /
 // / 1. Her istek için KeyGenerator ile benzersiz bir anahtar üretilir (varsayılan: IP adresi)
-// / 2. Bu anahtar için istek sayacı artırılır
-// / 3. Sayaç Max değerini aşarsa LimitReached handler çalıştırılır
-// / 4. Expiration süresi sonunda sayaç sıfırlanır
+// / 2. Bu anahtar için içinde bulunulan pencerenin sayacı Store üzerinde artırılır
+// / 3. Kayan pencere tahmini (önceki pencerenin ağırlıklı sayısı + mevcut sayı) hesaplanır
+// / 4. Tahmin Max değerini aşarsa `Retry-After` başlığı eklenir ve LimitReached handler çalıştırılır
+// / 5. Her yanıta `X-RateLimit-Limit`, `X-RateLimit-Remaining` ve `X-RateLimit-Reset` başlıkları eklenir
+// / 6. Store'a ulaşılamazsa istek engellenmez (fail-open)
 // /
 // / ## Kullanım Senaryoları
 // /
@@ -398,17 +406,11 @@ func SecurityHeaders(config SecurityHeadersConfig) fiber.Handler {
 // / * **Adil Kullanım**: Sunucu kaynaklarının adil dağılımı
 // / * **Kolay Entegrasyon**: Tek satırda eklenebilir
 // /
-// / ## Dezavantajlar
-// /
-// / * **Bellek Kullanımı**: Rate limit bilgileri bellekte tutulur
-// / * **Dağıtık Sistemler**: Tek sunucuda çalışır, load balancer arkasında senkronizasyon gerekir
-// / * **Yeniden Başlatma**: Sunucu yeniden başlatıldığında sayaçlar sıfırlanır
-// /
 // / ## Önemli Notlar
 // /
-// / * Rate limit bilgileri bellekte tutulur, Redis gibi dış bir store kullanmaz
-// / * Load balancer arkasında her sunucu kendi rate limit'ini tutar
-// / * Dağıtık sistemlerde Redis tabanlı rate limiting kullanın
+// / * Store verilmezse sayaçlar bellekte tutulur ve her replika kendi limitini uygular
+// / * Load balancer arkasında paylaşılan bir store (SQL tablosu, Redis) kullanın
+// / * Aynı store'u paylaşan limiter'lar farklı `Prefix` değerleri kullanmalıdır
 // / * KeyGenerator fonksiyonu hızlı olmalı, ağır işlemler yapmayın
 // / * IP bazlı rate limiting proxy arkasında doğru IP'yi almayabilir
 // / * X-Forwarded-For başlığını kontrol edin (proxy kullanıyorsanız)
@@ -425,14 +427,50 @@ func RateLimiter(config RateLimitConfig) fiber.Handler {
 		}
 	}
 
-	return limiter.New(limiter.Config{
-		Max:        config.Max,
-		Expiration: config.Expiration,
-		KeyGenerator: func(c *fiber.Ctx) string {
-			return config.KeyGenerator(c)
-		},
-		LimitReached: config.LimitReached,
-	})
+	if config.Store == nil {
+		config.Store = NewMemoryCounterStore()
+	}
+	if config.Prefix == "" {
+		config.Prefix = "ratelimit:"
+	}
+	if config.LimitReached == nil {
+		config.LimitReached = func(c *fiber.Ctx) error {
+			return c.SendStatus(fiber.StatusTooManyRequests)
+		}
+	}
+
+	window := &SlidingWindowLimiter{
+		Store:  config.Store,
+		Limit:  config.Max,
+		Window: config.Expiration,
+		Prefix: config.Prefix,
+	}
+
+	return func(c *fiber.Ctx) error {
+		result, err := window.Allow(c.UserContext(), config.KeyGenerator(c))
+		if err != nil {
+			// Fail open: erişilemeyen bir store paneli devre dışı bırakmamalı
+			return c.Next()
+		}
+
+		c.Set("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Set("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Set("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+
+		if !result.Allowed {
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			return config.LimitReached(c)
+		}
+		return c.Next()
+	}
+}
+
+// / ceilSeconds, HTTP header'ları için d süresini tam saniyeye yukarı yuvarlar.
+func ceilSeconds(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+	return int((d + time.Second - 1) / time.Second)
 }
 
 // / # AuthRateLimiter
@@ -604,19 +642,18 @@ func APIRateLimiter() fiber.Handler {
 // /
 // / ## Alanlar
 // /
-// / * `mu` - Thread-safe erişim için okuma/yazma mutex'i
-// / * `attempts` - Her identifier için deneme bilgilerini tutan map
+// / * `store` - Deneme ve kilit sayaçlarının tutulduğu CounterStore
 // / * `maxAttempts` - Kilitleme öncesi maksimum başarısız deneme sayısı
 // / * `lockoutDuration` - Hesabın kilitli kalacağı süre
 // /
 // / ## Çalışma Mantığı
 // /
-// / 1. Her başarısız giriş denemesi kaydedilir
-// / 2. Deneme sayısı maxAttempts'e ulaştığında hesap kilitlenir
+// / 1. Her başarısız giriş denemesi `lockout:attempts:<identifier>` sayacına kaydedilir
+// / 2. Deneme sayısı maxAttempts'e ulaştığında `lockout:locked:<identifier>` anahtarı yazılır
 // / 3. Kilitleme süresi boyunca giriş yapılamaz
-// / 4. Süre dolduğunda hesap otomatik olarak açılır
+// / 4. Süre dolduğunda anahtarlar store tarafından düşürülür ve hesap açılır
 // / 5. Başarılı girişte deneme sayacı sıfırlanır
-// / 6. Arka planda çalışan cleanup goroutine'i eski kayıtları temizler
+// / 6. Paylaşılan bir store ile tüm replikalar aynı kilidi görür
 // /
 // / ## Kullanım Senaryoları
 // /
@@ -672,70 +709,34 @@ func APIRateLimiter() fiber.Handler {
 // / ## Thread Safety
 // /
 // / * Tüm public methodlar thread-safe'dir
-// / * sync.RWMutex ile eşzamanlı erişim koruması sağlanır
-// / * Okuma işlemleri RLock, yazma işlemleri Lock kullanır
+// / * Sayaçlar store üzerinde atomik olarak artırılır
 // /
 // / ## Avantajlar
 // /
 // / * **Güçlü Koruma**: Rate limiting'den daha güçlü brute-force koruması
 // / * **Esnek Yapılandırma**: Deneme sayısı ve kilitleme süresi özelleştirilebilir
 // / * **Thread-Safe**: Eşzamanlı isteklerde güvenli çalışır
-// / * **Otomatik Temizlik**: Eski kayıtlar otomatik olarak temizlenir
+// / * **Otomatik Temizlik**: Sayaçların süresi store tarafından yönetilir
 // / * **Kullanıcı Dostu**: Kalan deneme sayısını gösterebilirsiniz
 // /
-// / ## Dezavantajlar
-// /
-// / * **Bellek Kullanımı**: Tüm denemeler bellekte tutulur
-// / * **Dağıtık Sistemler**: Tek sunucuda çalışır, load balancer arkasında senkronizasyon gerekir
-// / * **Kalıcılık Yok**: Sunucu yeniden başlatıldığında veriler kaybolur
-// /
 // / ## Önemli Notlar
 // /
 // / * Rate limiting ile birlikte kullanılması önerilir (çift koruma)
-// / * Dağıtık sistemlerde Redis gibi merkezi bir store kullanın
+// / * NewAccountLockout bellek içi store kullanır; replikalar için NewAccountLockoutWithStore
+// /   ile paylaşılan bir store (SQL tablosu, Redis) verin
 // / * Email/username yerine hash kullanarak gizlilik koruyun
-// / * Cleanup goroutine'i 5 dakikada bir çalışır
+// / * Store'a ulaşılamazsa giriş engellenmez (fail-open)
 // / * Başarılı girişte mutlaka ResetAttempts çağırın
 // / * Üretim ortamında mutlaka kullanın
 type AccountLockout struct {
-	mu              sync.RWMutex
-	attempts        map[string]*lockoutEntry
+	store           CounterStore
 	maxAttempts     int
 	lockoutDuration time.Duration
-	stopCh          chan struct{}
-	doneCh          chan struct{}
-	closeOnce       sync.Once
-}
-
-// / # lockoutEntry
-// /
-// / Bu yapı, bir identifier (email, IP, vb.) için kilitleme bilgilerini tutar.
-// / AccountLockout tarafından dahili olarak kullanılır.
-// /
-// / ## Alanlar
-// /
-// / * `count` - Başarısız deneme sayısı
-// / * `lockedUntil` - Hesabın kilitli kalacağı son zaman (zero value = kilitli değil)
-// /
-// / ## Kullanım
-// /
-// / Bu yapı AccountLockout tarafından dahili olarak kullanılır ve dışarıdan erişilmez.
-// / Doğrudan kullanılması gerekmez.
-// /
-// / ## Önemli Notlar
-// /
-// / * `lockedUntil` zero value ise hesap hiç kilitlenmemiş demektir
-// / * `count` her başarısız denemede artırılır
-// / * `lockedUntil` geçmişse kilitleme süresi dolmuş demektir
-type lockoutEntry struct {
-	count       int
-	lockedUntil time.Time
 }
 
 // / # NewAccountLockout
 // /
-// / Bu fonksiyon, yeni bir AccountLockout yöneticisi oluşturur ve arka planda çalışan
-// / temizlik goroutine'ini başlatır.
+// / Bu fonksiyon, sayaçları bellekte tutan yeni bir AccountLockout yöneticisi oluşturur.
 // /
 // / ## Parametreler
 // /
@@ -744,10 +745,8 @@ type lockoutEntry struct {
 // /
 // / ## Çalışma Mantığı
 // /
-// / 1. Yeni bir AccountLockout instance'ı oluşturur
-// / 2. Boş bir attempts map'i başlatır
-// / 3. Arka planda cleanup goroutine'ini başlatır
-// / 4. Cleanup goroutine'i 5 dakikada bir eski kayıtları temizler
+// / 1. Yeni bir MemoryCounterStore oluşturur
+// / 2. NewAccountLockoutWithStore ile AccountLockout instance'ını döndürür
 // /
 // / ## Kullanım Senaryoları
 // /
@@ -790,48 +789,38 @@ type lockoutEntry struct {
 // /
 // / ## Önemli Notlar
 // /
-// / * Cleanup goroutine'i otomatik olarak başlatılır
-// / * Goroutine uygulama yaşam döngüsü boyunca çalışır
-// / * Sunucu kapatıldığında goroutine otomatik olarak sonlanır
+// / * Sayaçlar process belleğindedir, her replika kendi kilidini tutar
 // / * maxAttempts 0 veya negatif olmamalı (kontrol yapılmaz)
 // / * lockoutDuration 0 veya negatif olmamalı (kontrol yapılmaz)
 // / * Thread-safe olarak tasarlanmıştır
 func NewAccountLockout(maxAttempts int, lockoutDuration time.Duration) *AccountLockout {
-	al := &AccountLockout{
-		attempts:        make(map[string]*lockoutEntry),
+	return NewAccountLockoutWithStore(NewMemoryCounterStore(), maxAttempts, lockoutDuration)
+}
+
+// / # NewAccountLockoutWithStore
+// /
+// / Sayaçlarını verilen store'da tutan bir AccountLockout oluşturur.
+// / Aynı store'u paylaşan replikalar deneme sayılarını ve kilitleri de paylaşır.
+func NewAccountLockoutWithStore(store CounterStore, maxAttempts int, lockoutDuration time.Duration) *AccountLockout {
+	return &AccountLockout{
+		store:           store,
 		maxAttempts:     maxAttempts,
 		lockoutDuration: lockoutDuration,
-		stopCh:          make(chan struct{}),
-		doneCh:          make(chan struct{}),
 	}
-
-	// Cleanup goroutine
-	go al.cleanup()
-
-	return al
 }
 
-// Close stops the background cleanup goroutine and waits until it exits.
-func (al *AccountLockout) Close() {
-	if al == nil {
-		return
-	}
+// / # Close
+// /
+// / API uyumluluğu için korunur; süre dolumunu store yönetir, durdurulacak bir şey yoktur.
+func (al *AccountLockout) Close() {}
 
-	al.closeOnce.Do(func() {
-		if al.stopCh != nil {
-			close(al.stopCh)
-		}
-	})
+func lockoutAttemptsKey(identifier string) string { return "lockout:attempts:" + identifier }
 
-	if al.doneCh != nil {
-		<-al.doneCh
-	}
-}
+func lockoutLockedKey(identifier string) string { return "lockout:locked:" + identifier }
 
 // / # IsLocked
 // /
 // / Bu method, belirtilen identifier (email, IP, vb.) için hesabın kilitli olup olmadığını kontrol eder.
-// / Thread-safe okuma işlemi yapar.
 // /
 // / ## Parametreler
 // /
@@ -839,12 +828,9 @@ func (al *AccountLockout) Close() {
 // /
 // / ## Çalışma Mantığı
 // /
-// / 1. RLock ile thread-safe okuma kilidi alır
-// / 2. identifier için kayıt var mı kontrol eder
-// / 3. Kayıt yoksa false döner (hiç deneme yapılmamış)
-// / 4. Kayıt varsa lockedUntil zamanını kontrol eder
-// / 5. Şu anki zaman lockedUntil'den önceyse true döner (hala kilitli)
-// / 6. Aksi halde false döner (kilitleme süresi dolmuş)
+// / 1. Store'dan `lockout:locked:<identifier>` anahtarını okur
+// / 2. Anahtar varsa true döner (hala kilitli)
+// / 3. Anahtar yoksa veya süresi dolmuşsa false döner
 // /
 // / ## Kullanım Senaryoları
 // /
@@ -893,9 +879,8 @@ func (al *AccountLockout) Close() {
 // /
 // / ## Thread Safety
 // /
-// / * RLock kullanarak thread-safe okuma sağlar
+// / * Store'dan tek bir okuma yapar
 // / * Birden fazla goroutine aynı anda güvenle çağırabilir
-// / * Yazma işlemleriyle (RecordFailedAttempt, ResetAttempts) çakışmaz
 // /
 // / ## Önemli Notlar
 // /
@@ -905,19 +890,8 @@ func (al *AccountLockout) Close() {
 // / * Her login denemesinden önce çağırılmalıdır
 // / * Kilitli hesaplara yapılan denemeleri loglamayı unutmayın
 func (al *AccountLockout) IsLocked(identifier string) bool {
-	al.mu.RLock()
-	defer al.mu.RUnlock()
-
-	entry, exists := al.attempts[identifier]
-	if !exists {
-		return false
-	}
-
-	if time.Now().Before(entry.lockedUntil) {
-		return true
-	}
-
-	return false
+	locked, err := al.store.Get(context.Background(), lockoutLockedKey(identifier))
+	return err == nil && locked > 0
 }
 
 // / # RecordFailedAttempt
@@ -931,13 +905,10 @@ func (al *AccountLockout) IsLocked(identifier string) bool {
 // /
 // / ## Çalışma Mantığı
 // /
-// / 1. Lock ile thread-safe yazma kilidi alır
-// / 2. identifier için kayıt var mı kontrol eder
-// / 3. Kayıt yoksa yeni bir lockoutEntry oluşturur
-// / 4. Kayıt varsa ve kilitleme süresi dolmuşsa sayacı sıfırlar
-// / 5. Deneme sayacını 1 artırır
-// / 6. Sayaç maxAttempts'e ulaştıysa lockedUntil zamanını ayarlar
-// / 7. Kilitleme süresi şu andan itibaren lockoutDuration kadar ileri ayarlanır
+// / 1. Deneme sayacını store üzerinde atomik olarak 1 artırır
+// / 2. Sayaç ilk denemede oluşur ve lockoutDuration sonra düşer
+// / 3. Sayaç maxAttempts'e ulaştıysa kilit anahtarını yazar
+// / 4. Kilit şu andan itibaren lockoutDuration kadar sürer
 // /
 // / ## Kullanım Senaryoları
 // /
@@ -1002,9 +973,8 @@ func (al *AccountLockout) IsLocked(identifier string) bool {
 // /
 // / ## Thread Safety
 // /
-// / * Lock kullanarak thread-safe yazma sağlar
-// / * Birden fazla goroutine aynı anda güvenle çağırabilir
-// / * Okuma işlemleriyle (IsLocked, GetRemainingAttempts) çakışmaz
+// / * Sayaçlar store üzerinde atomik olarak güncellenir
+// / * Birden fazla goroutine (ve replika) aynı anda güvenle çağırabilir
 // /
 // / ## Önemli Notlar
 // /
@@ -1015,25 +985,14 @@ func (al *AccountLockout) IsLocked(identifier string) bool {
 // / * Kalan deneme sayısını kullanıcıya göstermek için GetRemainingAttempts kullanın
 // / * Güvenlik loglarına başarısız denemeleri kaydedin
 func (al *AccountLockout) RecordFailedAttempt(identifier string) {
-	al.mu.Lock()
-	defer al.mu.Unlock()
-
-	entry, exists := al.attempts[identifier]
-	if !exists {
-		entry = &lockoutEntry{count: 0}
-		al.attempts[identifier] = entry
-	}
-
-	// Reset if lockout expired (only check if lockedUntil was previously set)
-	if !entry.lockedUntil.IsZero() && time.Now().After(entry.lockedUntil) {
-		entry.count = 0
+	ctx := context.Background()
+	count, err := al.store.Increment(ctx, lockoutAttemptsKey(identifier), 1, al.lockoutDuration)
+	if err != nil || count < int64(al.maxAttempts) {
+		return
 	}
 
-	entry.count++
-
-	if entry.count >= al.maxAttempts {
-		entry.lockedUntil = time.Now().Add(al.lockoutDuration)
-	}
+	// Kilitliyken yapılan yeni denemeler kilitleme süresini uzatmaz
+	_, _ = al.store.Increment(ctx, lockoutLockedKey(identifier), 1, al.lockoutDuration)
 }
 
 // / # ResetAttempts
@@ -1047,10 +1006,9 @@ func (al *AccountLockout) RecordFailedAttempt(identifier string) {
 // /
 // / ## Çalışma Mantığı
 // /
-// / 1. Lock ile thread-safe yazma kilidi alır
-// / 2. identifier için kaydı map'ten tamamen siler
-// / 3. Kilitleme ve deneme bilgileri tamamen temizlenir
-// / 4. Kullanıcı tekrar baştan deneme hakkına sahip olur
+// / 1. identifier için deneme ve kilit anahtarlarını store'dan siler
+// / 2. Kilitleme ve deneme bilgileri tamamen temizlenir
+// / 3. Kullanıcı tekrar baştan deneme hakkına sahip olur
 // /
 // / ## Kullanım Senaryoları
 // /
@@ -1118,9 +1076,8 @@ func (al *AccountLockout) RecordFailedAttempt(identifier string) {
 // /
 // / ## Thread Safety
 // /
-// / * Lock kullanarak thread-safe yazma sağlar
-// / * Birden fazla goroutine aynı anda güvenle çağırabilir
-// / * Okuma işlemleriyle (IsLocked, GetRemainingAttempts) çakışmaz
+// / * Sayaçlar store üzerinde atomik olarak güncellenir
+// / * Birden fazla goroutine (ve replika) aynı anda güvenle çağırabilir
 // /
 // / ## Önemli Notlar
 // /
@@ -1130,10 +1087,9 @@ func (al *AccountLockout) RecordFailedAttempt(identifier string) {
 // / * Kullanıcı tekrar maxAttempts kadar deneme hakkına sahip olur
 // / * Güvenlik loglarına başarılı girişleri kaydedin
 func (al *AccountLockout) ResetAttempts(identifier string) {
-	al.mu.Lock()
-	defer al.mu.Unlock()
-
-	delete(al.attempts, identifier)
+	ctx := context.Background()
+	_ = al.store.Delete(ctx, lockoutAttemptsKey(identifier))
+	_ = al.store.Delete(ctx, lockoutLockedKey(identifier))
 }
 
 // / # GetRemainingAttempts
@@ -1147,12 +1103,10 @@ func (al *AccountLockout) ResetAttempts(identifier string) {
 // /
 // / ## Çalışma Mantığı
 // /
-// / 1. RLock ile thread-safe okuma kilidi alır
-// / 2. identifier için kayıt var mı kontrol eder
-// / 3. Kayıt yoksa maxAttempts döner (hiç deneme yapılmamış)
-// / 4. Kayıt varsa ve kilitleme süresi dolmuşsa maxAttempts döner
-// / 5. Kayıt varsa kalan deneme sayısını hesaplar (maxAttempts - count)
-// / 6. Negatif değer varsa 0 döner (hesap kilitli)
+// / 1. Hesap kilitliyse 0 döner
+// / 2. Deneme sayacını store'dan okur (yoksa veya süresi dolmuşsa 0)
+// / 3. Kalan deneme sayısını hesaplar (maxAttempts - count)
+// / 4. Negatif değer varsa 0 döner
 // /
 // / ## Kullanım Senaryoları
 // /
@@ -1228,9 +1182,8 @@ func (al *AccountLockout) ResetAttempts(identifier string) {
 // /
 // / ## Thread Safety
 // /
-// / * RLock kullanarak thread-safe okuma sağlar
+// / * Store'dan tek bir okuma yapar
 // / * Birden fazla goroutine aynı anda güvenle çağırabilir
-// / * Yazma işlemleriyle (RecordFailedAttempt, ResetAttempts) çakışmaz
 // /
 // / ## Önemli Notlar
 // /
@@ -1241,80 +1194,22 @@ func (al *AccountLockout) ResetAttempts(identifier string) {
 // / * Bazı güvenlik uzmanları bu bilgiyi göstermeyi önermez (saldırgana bilgi verir)
 // / * Kullanıcı deneyimi için göstermek faydalı olabilir
 func (al *AccountLockout) GetRemainingAttempts(identifier string) int {
-	al.mu.RLock()
-	defer al.mu.RUnlock()
-
-	entry, exists := al.attempts[identifier]
-	if !exists {
-		return al.maxAttempts
+	if al.IsLocked(identifier) {
+		return 0
 	}
 
-	// Only check expiration if lockout was previously set
-	if !entry.lockedUntil.IsZero() && time.Now().After(entry.lockedUntil) {
+	count, err := al.store.Get(context.Background(), lockoutAttemptsKey(identifier))
+	if err != nil {
 		return al.maxAttempts
 	}
 
-	remaining := al.maxAttempts - entry.count
+	remaining := al.maxAttempts - int(count)
 	if remaining < 0 {
 		return 0
 	}
 	return remaining
 }
 
-// / # cleanup
-// /
-// / Bu method, arka planda çalışan bir goroutine olarak periyodik olarak eski ve süresi dolmuş
-// / kilitleme kayıtlarını temizler. Bellek kullanımını optimize eder. Thread-safe yazma işlemi yapar.
-// /
-// / ## Çalışma Mantığı
-// /
-// / 1. 5 dakikada bir çalışan bir ticker oluşturur
-// / 2. Her tick'te tüm kayıtları tarar
-// / 3. Kilitleme süresi dolmuş ve deneme sayısı maxAttempts'in altında olan kayıtları siler
-// / 4. Bu sayede bellekte gereksiz kayıt birikmesi önlenir
-// / 5. Goroutine uygulama yaşam döngüsü boyunca çalışır
-// /
-// / ## Kullanım
-// /
-// / Bu method NewAccountLockout tarafından otomatik olarak başlatılır.
-// / Doğrudan çağırılması gerekmez ve public olarak erişilemez.
-// /
-// / ## Temizleme Kriterleri
-// /
-// / Bir kayıt şu koşullarda temizlenir:
-// / * Şu anki zaman lockedUntil'den sonra (kilitleme süresi dolmuş)
-// / * VE deneme sayısı maxAttempts'in altında (henüz kilitlenmemiş)
-// /
-// / ## Önemli Notlar
-// /
-// / * Goroutine 5 dakikada bir çalışır (sabit interval)
-// / * Lock kullanarak thread-safe yazma sağlar
-// / * Uygulama kapatıldığında goroutine otomatik olarak sonlanır
-// / * Kilitli hesaplar (count >= maxAttempts) temizlenmez
-// / * Sadece süresi dolmuş ve kilitlenmemiş kayıtlar temizlenir
-// / * Bellek optimizasyonu için kritik öneme sahiptir
-func (al *AccountLockout) cleanup() {
-	ticker := time.NewTicker(5 * time.Minute)
-	defer ticker.Stop()
-	defer close(al.doneCh)
-
-	for {
-		select {
-		case <-al.stopCh:
-			return
-		case <-ticker.C:
-			al.mu.Lock()
-			now := time.Now()
-			for key, entry := range al.attempts {
-				if now.After(entry.lockedUntil) && entry.count < al.maxAttempts {
-					delete(al.attempts, key)
-				}
-			}
-			al.mu.Unlock()
-		}
-	}
-}
-
 // / # RequestSizeLimit
 // /
 // / Bu fonksiyon, HTTP istek gövdesi (body) boyutunu sınırlayan bir middleware oluşturur.
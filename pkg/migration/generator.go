@@ -465,6 +465,15 @@ func (mg *MigrationGenerator) applyFieldConstraints(r resource.Resource) error {
 
 		// Normal field'lar için mevcut logic
 		schema, ok := field.(*fields.Schema)
+		if slug, isSlug := fields.AsSlugField(field); isSlug {
+			// Slug'lar tabloda benzersiz olmalıdır
+			schema, ok = &slug.Schema, true
+			if !mg.hasUniqueIndexWithModel(model, schema.Key) {
+				if err := mg.createIndexWithModel(model, schema.Key, true); err != nil {
+					return err
+				}
+			}
+		}
 		if !ok {
 			continue
 		}
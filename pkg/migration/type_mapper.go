@@ -419,7 +419,7 @@ func (tm *TypeMapper) MapFieldTypeToGo(fieldType fields.ElementType, nullable bo
 
 	switch fieldType {
 	// Metin Tipleri
-	case fields.TYPE_TEXT, fields.TYPE_PASSWORD, fields.TYPE_TEXTAREA, fields.TYPE_RICHTEXT:
+	case fields.TYPE_TEXT, fields.TYPE_PASSWORD, fields.TYPE_TEXTAREA, fields.TYPE_RICHTEXT, fields.TYPE_SLUG, fields.TYPE_MARKDOWN, fields.TYPE_JSON:
 		baseType = reflect.TypeOf("")
 	case fields.TYPE_EMAIL:
 		baseType = reflect.TypeOf("")
@@ -450,6 +450,18 @@ func (tm *TypeMapper) MapFieldTypeToGo(fieldType fields.ElementType, nullable bo
 	case fields.TYPE_KEY_VALUE:
 		baseType = reflect.TypeOf(map[string]interface{}{})
 
+	// Galeri (sıralı dosya listesi JSON olarak saklanır)
+	case fields.TYPE_GALLERY:
+		baseType = reflect.TypeOf(fields.GalleryItems{})
+
+	// Repeater (satırlar JSON olarak saklanır)
+	case fields.TYPE_REPEATER:
+		baseType = reflect.TypeOf(fields.RepeaterRows{})
+
+	// Etiketler (JSON dizisi olarak saklanır)
+	case fields.TYPE_TAGS:
+		baseType = reflect.TypeOf(fields.TagList{})
+
 	// İlişki Tipleri
 	case fields.TYPE_LINK: // BelongsTo -> Foreign Key
 		baseType = reflect.TypeOf(uint(0))
@@ -703,14 +715,14 @@ func (tm *TypeMapper) MapFieldTypeToSQL(fieldType fields.ElementType, size int)
 
 	switch fieldType {
 	// Metin Tipleri
-	case fields.TYPE_TEXT, fields.TYPE_EMAIL, fields.TYPE_TEL, fields.TYPE_PASSWORD:
+	case fields.TYPE_TEXT, fields.TYPE_EMAIL, fields.TYPE_TEL, fields.TYPE_PASSWORD, fields.TYPE_SLUG:
 		if size > 0 {
 			return "varchar(" + itoa(size) + ")"
 		}
 		return "varchar(255)"
 
 	// Uzun Metin Tipleri (TEXT column)
-	case fields.TYPE_TEXTAREA, fields.TYPE_RICHTEXT:
+	case fields.TYPE_TEXTAREA, fields.TYPE_RICHTEXT, fields.TYPE_MARKDOWN:
 		return "text"
 
 	// Sayısal Tipler
@@ -762,8 +774,8 @@ func (tm *TypeMapper) MapFieldTypeToSQL(fieldType fields.ElementType, size int)
 	case fields.TYPE_SELECT:
 		return "varchar(100)"
 
-	// Key-Value
-	case fields.TYPE_KEY_VALUE:
+	// Key-Value, galeri, repeater, JSON ve etiketler (JSON)
+	case fields.TYPE_KEY_VALUE, fields.TYPE_GALLERY, fields.TYPE_REPEATER, fields.TYPE_JSON, fields.TYPE_TAGS:
 		switch dialect {
 		case "postgres":
 			return "jsonb"
@@ -23,6 +23,10 @@ func TestTypeMapperFieldToGoType(t *testing.T) {
 		{"boolean", fields.TYPE_BOOLEAN, false, "bool"},
 		{"email", fields.TYPE_EMAIL, false, "string"},
 		{"select", fields.TYPE_SELECT, false, "string"},
+		{"json", fields.TYPE_JSON, false, "string"},
+		{"tags", fields.TYPE_TAGS, false, "fields.TagList"},
+		{"slug", fields.TYPE_SLUG, false, "string"},
+		{"markdown", fields.TYPE_MARKDOWN, false, "string"},
 	}
 
 	for _, tt := range tests {
@@ -54,6 +58,10 @@ func TestTypeMapperFieldToSQLType(t *testing.T) {
 		{"file", fields.TYPE_FILE, 0, "text"},
 		{"select", fields.TYPE_SELECT, 0, "varchar(100)"},
 		{"key_value", fields.TYPE_KEY_VALUE, 0, "jsonb"},
+		{"json", fields.TYPE_JSON, 0, "jsonb"},
+		{"tags", fields.TYPE_TAGS, 0, "jsonb"},
+		{"slug", fields.TYPE_SLUG, 0, "varchar(255)"},
+		{"markdown", fields.TYPE_MARKDOWN, 0, "text"},
 		{"link (FK)", fields.TYPE_LINK, 0, "bigint"},
 	}
 
@@ -12,9 +12,13 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strings"
 
 	"github.com/ferdiunal/panel.go/pkg/core"
+	"github.com/ferdiunal/panel.go/pkg/domain/apikey"
+	"github.com/ferdiunal/panel.go/pkg/fields"
 	internalconcurrency "github.com/ferdiunal/panel.go/pkg/internal/concurrency"
+	"github.com/ferdiunal/panel.go/pkg/query"
 	"github.com/ferdiunal/panel.go/pkg/resource"
 )
 
@@ -60,16 +64,16 @@ func (g *DynamicSpecGenerator) GenerateResourcePaths(resources map[string]resour
 
 		// Collection endpoint: GET /api/{slug}
 		collectionPath := fmt.Sprintf("/api/%s", slug)
-		paths[collectionPath] = *g.generateCollectionPathItem(res)
+		paths[collectionPath] = withAPIKeyScopes(*g.generateCollectionPathItem(res), slug)
 
 		// Item endpoint: GET /api/{slug}/{id}
 		itemPath := fmt.Sprintf("/api/%s/{id}", slug)
-		paths[itemPath] = *g.generateItemPathItem(res)
+		paths[itemPath] = withAPIKeyScopes(*g.generateItemPathItem(res), slug)
 
 		// Action endpoints
 		for _, action := range res.GetActions() {
 			actionPath := fmt.Sprintf("/api/%s/actions/%s", slug, action.GetSlug())
-			paths[actionPath] = *g.generateActionPathItem(res, action)
+			paths[actionPath] = withAPIKeyScopes(*g.generateActionPathItem(res, action), slug)
 		}
 	}
 
@@ -103,14 +107,14 @@ func (g *DynamicSpecGenerator) GenerateResourcePathsParallel(resources map[strin
 
 			result := make(map[string]PathItem, 2+len(res.GetActions()))
 			collectionPath := fmt.Sprintf("/api/%s", slug)
-			result[collectionPath] = *g.generateCollectionPathItem(res)
+			result[collectionPath] = withAPIKeyScopes(*g.generateCollectionPathItem(res), slug)
 
 			itemPath := fmt.Sprintf("/api/%s/{id}", slug)
-			result[itemPath] = *g.generateItemPathItem(res)
+			result[itemPath] = withAPIKeyScopes(*g.generateItemPathItem(res), slug)
 
 			for _, action := range res.GetActions() {
 				actionPath := fmt.Sprintf("/api/%s/actions/%s", slug, action.GetSlug())
-				result[actionPath] = *g.generateActionPathItem(res, action)
+				result[actionPath] = withAPIKeyScopes(*g.generateActionPathItem(res, action), slug)
 			}
 
 			return result, nil
@@ -130,6 +134,30 @@ func (g *DynamicSpecGenerator) GenerateResourcePathsParallel(resources map[strin
 	return paths
 }
 
+// withAPIKeyScopes, path'teki her operation'a gerektirdiği API key scope'unu ekler.
+// GET istekleri "<slug>:read", diğer metodlar "<slug>:write" scope'u ister.
+func withAPIKeyScopes(item PathItem, slug string) PathItem {
+	for _, entry := range []struct {
+		op     *Operation
+		access string
+	}{
+		{item.Get, apikey.AccessRead},
+		{item.Post, apikey.AccessWrite},
+		{item.Put, apikey.AccessWrite},
+		{item.Patch, apikey.AccessWrite},
+		{item.Delete, apikey.AccessWrite},
+	} {
+		if entry.op == nil {
+			continue
+		}
+		entry.op.Security = []SecurityRequirement{{
+			"apiKeyAuth":   []string{},
+			"apiKeyScopes": []string{apikey.Scope(slug, entry.access)},
+		}}
+	}
+	return item
+}
+
 // generateCollectionPathItem, collection endpoint için PathItem oluşturur.
 func (g *DynamicSpecGenerator) generateCollectionPathItem(res resource.Resource) *PathItem {
 	schemaName := g.getSchemaName(res)
@@ -385,6 +413,22 @@ func (g *DynamicSpecGenerator) generateListParameters(res resource.Resource) []P
 		},
 	}
 
+	// Filterable alanlardan üretilen filtreleri ekle
+	for _, filter := range fields.IndexFilters(res.Fields()) {
+		operators := make([]string, 0, len(filter.Operators))
+		for _, operator := range filter.Operators {
+			operators = append(operators, string(operator))
+		}
+		params = append(params, Parameter{
+			Name:        fmt.Sprintf("%s[filters][%s][%s]", res.Slug(), filter.Key, indexFilterParameterOperator(filter.Type)),
+			In:          "query",
+			Description: fmt.Sprintf("%s (%s filter, operators: %s)", filter.Name, filter.Type, strings.Join(operators, ", ")),
+			Schema: &Schema{
+				Type: "string",
+			},
+		})
+	}
+
 	// Filter parametrelerini ekle
 	for _, filter := range res.GetFilters() {
 		params = append(params, Parameter{
@@ -400,6 +444,20 @@ func (g *DynamicSpecGenerator) generateListParameters(res resource.Resource) []P
 	return params
 }
 
+// indexFilterParameterOperator, filtre tipi için dokümante edilecek varsayılan operatörü döner.
+func indexFilterParameterOperator(filterType fields.FilterType) query.FilterOperator {
+	switch filterType {
+	case fields.FilterTypeText:
+		return query.OpLike
+	case fields.FilterTypeNumber, fields.FilterTypeDate:
+		return query.OpBetween
+	case fields.FilterTypeSelect, fields.FilterTypeBelongsTo:
+		return query.OpIn
+	default:
+		return query.OpEqual
+	}
+}
+
 // GenerateResourceSchemas, tüm resource'lar için OpenAPI schema'ları oluşturur.
 //
 // ## Parametreler
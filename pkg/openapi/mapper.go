@@ -9,6 +9,7 @@ package openapi
 
 import (
 	"fmt"
+	"regexp"
 
 	"github.com/ferdiunal/panel.go/pkg/core"
 	"github.com/ferdiunal/panel.go/pkg/fields"
@@ -135,6 +136,38 @@ func (m *FieldTypeMapper) getDefaultMapping(field fields.Element) Schema {
 			Description: "Code content",
 		}
 
+	case core.TYPE_MARKDOWN:
+		return Schema{
+			Type:        "string",
+			Description: "Markdown content",
+		}
+
+	case core.TYPE_SLUG:
+		pattern := "^[a-z0-9]+(?:-[a-z0-9]+)*$"
+		if slug, ok := fields.AsSlugField(field); ok {
+			separator := regexp.QuoteMeta(slug.Separator)
+			pattern = "^[a-z0-9]+(?:" + separator + "[a-z0-9]+)*$"
+		}
+		return Schema{
+			Type:    "string",
+			Pattern: pattern,
+		}
+
+	// JSON type - any valid JSON document
+	case core.TYPE_JSON:
+		return Schema{
+			Description: "JSON document",
+		}
+
+	// Tags type - list of text tags
+	case core.TYPE_TAGS:
+		return Schema{
+			Type: "array",
+			Items: &Schema{
+				Type: "string",
+			},
+		}
+
 	// Number types
 	case core.TYPE_NUMBER, core.TYPE_MONEY:
 		return Schema{
@@ -168,6 +201,50 @@ func (m *FieldTypeMapper) getDefaultMapping(field fields.Element) Schema {
 			Format: "binary",
 		}
 
+	// Gallery type - ordered list of files
+	case core.TYPE_GALLERY:
+		return Schema{
+			Type: "array",
+			Items: &Schema{
+				Type: "object",
+				Properties: map[string]Schema{
+					"path":      {Type: "string"},
+					"url":       {Type: "string"},
+					"name":      {Type: "string"},
+					"size":      {Type: "integer", Format: "int64"},
+					"mime_type": {Type: "string"},
+					"caption":   {Type: "string"},
+					"position":  {Type: "integer"},
+				},
+			},
+		}
+
+	// Repeater type - rows of nested fields
+	case core.TYPE_REPEATER:
+		properties, required := m.MapFieldsToProperties(field.GetRepeaterFields())
+		for _, nested := range field.GetRepeaterFields() {
+			if nested == nil || nested.GetKey() == "" {
+				continue
+			}
+			if isRequired, _ := nested.JsonSerialize()["required"].(bool); isRequired {
+				required = append(required, nested.GetKey())
+			}
+		}
+		if repeater, ok := fields.AsRepeaterField(field); ok && repeater.UseHasMany {
+			properties["id"] = Schema{Type: "integer", Description: "Existing row ID"}
+		}
+		itemSchema := &Schema{
+			Type:       "object",
+			Properties: properties,
+		}
+		if len(required) > 0 {
+			itemSchema.Required = required
+		}
+		return Schema{
+			Type:  "array",
+			Items: itemSchema,
+		}
+
 	// Color type
 	case core.TYPE_COLOR:
 		return Schema{
@@ -457,6 +534,23 @@ func (m *FieldTypeMapper) GetFieldExample(fieldType core.ElementType) interface{
 		return []string{"option1", "option2"}
 	case core.TYPE_KEY_VALUE:
 		return map[string]string{"key": "value"}
+	case core.TYPE_JSON:
+		return map[string]interface{}{"enabled": true, "limits": []int{10, 20}}
+	case core.TYPE_TAGS:
+		return []string{"go", "admin"}
+	case core.TYPE_SLUG:
+		return "example-title"
+	case core.TYPE_MARKDOWN:
+		return "# Title\n\nSome **bold** text."
+	case core.TYPE_GALLERY:
+		return []map[string]interface{}{
+			{"path": "products/cover.jpg", "url": "https://example.com/storage/products/cover.jpg", "caption": "Cover", "position": 0},
+		}
+	case core.TYPE_REPEATER:
+		return []map[string]interface{}{
+			{"product": "Pencil", "price": 12.5},
+			{"product": "Notebook", "price": 40},
+		}
 	case core.TYPE_LINK, core.TYPE_DETAIL:
 		return 123
 	case core.TYPE_COLLECTION, core.TYPE_CONNECT:
@@ -13,6 +13,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ferdiunal/panel.go/pkg/domain/apikey"
 	"github.com/ferdiunal/panel.go/pkg/resource"
 	"golang.org/x/sync/singleflight"
 )
@@ -241,6 +242,27 @@ func (g *SpecGenerator) generateSpec() (*OpenAPISpec, error) {
 		Description: "API key authentication",
 	}
 
+	// Managed API key scope'ları OAuth tarzı scope olarak belgelenir. Key'ler panelin
+	// API sayfasında oluşturulur; token değişimi yoktur, key apiKeyAuth header'ında gönderilir.
+	spec.Components.SecuritySchemes["apiKeyScopes"] = SecurityScheme{
+		Type:        "oauth2",
+		Description: fmt.Sprintf("Scopes granted to managed API keys. Keys are issued on the panel API page and sent in the %s header; \"*\" grants every scope and write scopes include read access.", g.config.APIKeyHeader),
+		Flows: &OAuthFlows{
+			ClientCredentials: &OAuthFlow{
+				TokenURL: "/api/internal/api-keys",
+				Scopes:   g.apiKeyScopes(),
+			},
+		},
+	}
+
+	// Hesap endpoint'leri (profil, şifre) yalnızca oturum cookie'si ile çalışır
+	spec.Components.SecuritySchemes["sessionCookie"] = SecurityScheme{
+		Type:        "apiKey",
+		In:          "cookie",
+		Name:        "session_token",
+		Description: "Session cookie (production: __Host-session_token)",
+	}
+
 	// Global security requirement ekle
 	spec.Security = []SecurityRequirement{
 		{"apiKeyAuth": []string{}},
@@ -257,6 +279,21 @@ func (g *SpecGenerator) generateSpec() (*OpenAPISpec, error) {
 	return spec, nil
 }
 
+// apiKeyScopes, OpenAPI'de görünen resource'lar için API key scope'larını döndürür.
+func (g *SpecGenerator) apiKeyScopes() map[string]string {
+	scopes := map[string]string{
+		apikey.ScopeAll: "Full access to every resource",
+	}
+	for slug, res := range g.resources {
+		if res == nil || !res.OpenAPIEnabled() {
+			continue
+		}
+		scopes[apikey.Scope(slug, apikey.AccessRead)] = fmt.Sprintf("Read %s", res.Title())
+		scopes[apikey.Scope(slug, apikey.AccessWrite)] = fmt.Sprintf("Create, update and delete %s", res.Title())
+	}
+	return scopes
+}
+
 // addStaticEndpoints, statik endpoint'leri spec'e ekler.
 //
 // ## Eklenen Endpoint'ler
@@ -395,6 +432,29 @@ func (g *SpecGenerator) addCommonSchemas(spec *OpenAPISpec) {
 		Required: []string{"error"},
 	}
 
+	// ValidationErrorResponse schema
+	spec.Components.Schemas["ValidationErrorResponse"] = Schema{
+		Type: "object",
+		Properties: map[string]Schema{
+			"error": {
+				Type:        "string",
+				Description: "Hata mesajı",
+				Example:     "Validation error",
+			},
+			"code": {
+				Type:        "string",
+				Description: "Hata kodu",
+				Example:     "VALIDATION_ERROR",
+			},
+			"errors": {
+				Type:        "object",
+				Description: "Alan adı -> hata mesajları listesi",
+				Example:     map[string][]string{"new_password": {"Password must be at least 8 characters"}},
+			},
+		},
+		Required: []string{"error", "errors"},
+	}
+
 	// SuccessResponse schema
 	spec.Components.Schemas["SuccessResponse"] = Schema{
 		Type: "object",
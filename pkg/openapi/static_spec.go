@@ -8,7 +8,8 @@ package openapi
 //
 // ## Statik Endpoint'ler
 //   - Authentication: sign-in, sign-up, sign-out, forgot-password, session
-//   - System: init, navigation
+//   - Account: profile, avatar, password
+//   - System: init, navigation, search
 //
 // ## Kullanım Örneği
 //
@@ -35,8 +36,12 @@ func NewStaticSpecGenerator() *StaticSpecGenerator {
 //   - POST /api/auth/sign-out
 //   - POST /api/auth/forgot-password
 //   - GET /api/auth/session
+//   - PUT /api/auth/profile
+//   - POST /api/auth/profile/avatar
+//   - PUT /api/auth/password
 //   - GET /api/init
 //   - GET /api/navigation
+//   - GET /api/search
 func (g *StaticSpecGenerator) GenerateStaticPaths() map[string]PathItem {
 	paths := make(map[string]PathItem)
 
@@ -47,9 +52,15 @@ func (g *StaticSpecGenerator) GenerateStaticPaths() map[string]PathItem {
 	paths["/api/auth/forgot-password"] = g.generateForgotPasswordPath()
 	paths["/api/auth/session"] = g.generateSessionPath()
 
+	// Account (self-service) endpoints
+	paths["/api/auth/profile"] = g.generateProfilePath()
+	paths["/api/auth/profile/avatar"] = g.generateAvatarPath()
+	paths["/api/auth/password"] = g.generatePasswordPath()
+
 	// System endpoints
 	paths["/api/init"] = g.generateInitPath()
 	paths["/api/navigation"] = g.generateNavigationPath()
+	paths["/api/search"] = g.generateGlobalSearchPath()
 
 	return paths
 }
@@ -485,3 +496,301 @@ func (g *StaticSpecGenerator) generateNavigationPath() PathItem {
 		},
 	}
 }
+
+// generateGlobalSearchPath, global search endpoint'i için PathItem oluşturur.
+//
+// ## Endpoint
+//   - GET /api/search
+//
+// ## Query Parameters
+//   - q: string (required, minimum 2 karakter)
+//   - limit: integer (resource başına sonuç sayısı)
+//
+// ## Responses
+//   - 200: Resource bazında gruplanmış arama sonuçları
+//   - 401: Unauthorized
+func (g *StaticSpecGenerator) generateGlobalSearchPath() PathItem {
+	return PathItem{
+		Get: &Operation{
+			Summary:     "Global arama",
+			Description: "Global aramaya açık ve görüntüleme yetkisi olan tüm resource'larda eşzamanlı arama yapar. Süre sınırını aşan resource'lar meta.timed_out içinde döner.",
+			OperationID: "globalSearch",
+			Tags:        []string{"system"},
+			Parameters: []Parameter{
+				{
+					Name:        "q",
+					In:          "query",
+					Description: "Arama terimi",
+					Required:    true,
+					Schema:      &Schema{Type: "string", MinLength: ptr(2)},
+					Example:     "john",
+				},
+				{
+					Name:        "limit",
+					In:          "query",
+					Description: "Resource başına döndürülecek maksimum kayıt sayısı",
+					Schema:      &Schema{Type: "integer", Minimum: ptr(1.0), Maximum: ptr(20.0)},
+				},
+			},
+			Responses: map[string]Response{
+				"200": {
+					Description: "Gruplanmış arama sonuçları",
+					Content: map[string]MediaType{
+						"application/json": {
+							Schema: &Schema{
+								Type: "object",
+								Properties: map[string]Schema{
+									"data": {
+										Type: "array",
+										Items: &Schema{
+											Type: "object",
+											Properties: map[string]Schema{
+												"resource": {Type: "string", Description: "Resource slug", Example: "users"},
+												"title":    {Type: "string", Description: "Resource başlığı", Example: "Kullanıcılar"},
+												"icon":     {Type: "string", Description: "İkon adı", Example: "users"},
+												"total":    {Type: "integer", Description: "Eşleşen toplam kayıt sayısı", Example: 3},
+												"results": {
+													Type: "array",
+													Items: &Schema{
+														Type: "object",
+														Properties: map[string]Schema{
+															"id":       {Type: "string", Description: "Kayıt ID", Example: "1"},
+															"title":    {Type: "string", Description: "Kayıt başlığı (RecordTitle)", Example: "John Doe"},
+															"subtitle": {Type: "string", Description: "Alt başlık", Example: "john@example.com"},
+															"icon":     {Type: "string", Description: "İkon adı", Example: "users"},
+															"url":      {Type: "string", Description: "Detay sayfası URL'i", Example: "/resource/users/1"},
+														},
+													},
+												},
+											},
+										},
+									},
+									"meta": {
+										Type: "object",
+										Properties: map[string]Schema{
+											"query":     {Type: "string", Example: "john"},
+											"total":     {Type: "integer", Example: 3},
+											"timed_out": {Type: "array", Items: &Schema{Type: "string"}, Description: "Süre sınırını aşan resource'lar"},
+											"failed":    {Type: "array", Items: &Schema{Type: "string"}, Description: "Hata veren resource'lar"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				"401": {
+					Description: "Yetkisiz erişim",
+					Content: map[string]MediaType{
+						"application/json": {
+							Schema: &Schema{Ref: "#/components/schemas/ErrorResponse"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// sessionSecurity, yalnızca oturum cookie'si ile çağrılabilen endpoint'lerin güvenlik gereksinimidir.
+var sessionSecurity = []SecurityRequirement{{"sessionCookie": []string{}}}
+
+// profileUserSchema, hesap endpoint'lerinin döndürdüğü kullanıcı şemasıdır.
+func profileUserSchema() Schema {
+	return Schema{
+		Type: "object",
+		Properties: map[string]Schema{
+			"id":            {Type: "integer", Example: 1},
+			"name":          {Type: "string", Example: "John Doe"},
+			"email":         {Type: "string", Format: "email", Example: "user@example.com"},
+			"emailVerified": {Type: "boolean", Example: true},
+			"image":         {Type: "string", Example: "/storage/1712345678.png"},
+		},
+	}
+}
+
+// refResponse, verilen component şemasına referans veren JSON yanıtı oluşturur.
+func refResponse(description, schema string) Response {
+	return Response{
+		Description: description,
+		Content: map[string]MediaType{
+			"application/json": {
+				Schema: &Schema{Ref: "#/components/schemas/" + schema},
+			},
+		},
+	}
+}
+
+// generateProfilePath, profil güncelleme endpoint'i için PathItem oluşturur.
+//
+// ## Endpoint
+//   - PUT /api/auth/profile
+//
+// ## Request Body
+//   - name: string
+//   - email: string (değişirse yeniden doğrulama gerekir)
+//
+// ## Responses
+//   - 200: Updated user
+//   - 401: Not authenticated
+//   - 403: Not available for API keys or impersonated email changes
+//   - 422: Validation error
+func (g *StaticSpecGenerator) generateProfilePath() PathItem {
+	return PathItem{
+		Put: &Operation{
+			Summary:     "Profili güncelle",
+			Description: "Oturumdaki kullanıcının adını ve e-posta adresini günceller. E-posta değişirse hesap doğrulanmamış olarak işaretlenir ve e-posta doğrulama açıksa yeni adrese doğrulama bağlantısı gönderilir.",
+			OperationID: "updateProfile",
+			Tags:        []string{"auth"},
+			RequestBody: &RequestBody{
+				Description: "Değiştirilecek profil alanları (gönderilmeyenler değişmez)",
+				Required:    true,
+				Content: map[string]MediaType{
+					"application/json": {
+						Schema: &Schema{
+							Type: "object",
+							Properties: map[string]Schema{
+								"name":  {Type: "string", Description: "Kullanıcı adı", Example: "John Doe"},
+								"email": {Type: "string", Format: "email", Description: "Yeni email adresi", Example: "user@example.com"},
+							},
+						},
+					},
+				},
+			},
+			Responses: map[string]Response{
+				"200": {
+					Description: "Profil güncellendi",
+					Content: map[string]MediaType{
+						"application/json": {
+							Schema: &Schema{
+								Type: "object",
+								Properties: map[string]Schema{
+									"user":                  profileUserSchema(),
+									"emailVerificationSent": {Type: "boolean", Description: "Yeni adrese doğrulama e-postası gönderildi mi?"},
+								},
+							},
+						},
+					},
+				},
+				"401": refResponse("Kimlik doğrulanmadı", "ErrorResponse"),
+				"403": refResponse("API key veya impersonation oturumu ile kullanılamaz", "ErrorResponse"),
+				"422": refResponse("Validasyon hatası", "ValidationErrorResponse"),
+			},
+			Security: sessionSecurity,
+		},
+	}
+}
+
+// generateAvatarPath, profil resmi yükleme endpoint'i için PathItem oluşturur.
+//
+// ## Endpoint
+//   - POST /api/auth/profile/avatar
+//
+// ## Request Body
+//   - avatar: binary (multipart/form-data; png, jpg, gif, webp; max 2 MB)
+//
+// ## Responses
+//   - 200: Updated user
+//   - 401: Not authenticated
+//   - 422: Validation error
+func (g *StaticSpecGenerator) generateAvatarPath() PathItem {
+	return PathItem{
+		Post: &Operation{
+			Summary:     "Profil resmi yükle",
+			Description: "Oturumdaki kullanıcının profil resmini yükler. Dosya, users kaynağının storage handler'ı ile saklanır.",
+			OperationID: "uploadAvatar",
+			Tags:        []string{"auth"},
+			RequestBody: &RequestBody{
+				Description: "Profil resmi",
+				Required:    true,
+				Content: map[string]MediaType{
+					"multipart/form-data": {
+						Schema: &Schema{
+							Type: "object",
+							Properties: map[string]Schema{
+								"avatar": {Type: "string", Format: "binary", Description: "PNG, JPG, GIF veya WebP (en fazla 2 MB)"},
+							},
+							Required: []string{"avatar"},
+						},
+					},
+				},
+			},
+			Responses: map[string]Response{
+				"200": {
+					Description: "Profil resmi güncellendi",
+					Content: map[string]MediaType{
+						"application/json": {
+							Schema: &Schema{
+								Type:       "object",
+								Properties: map[string]Schema{"user": profileUserSchema()},
+							},
+						},
+					},
+				},
+				"401": refResponse("Kimlik doğrulanmadı", "ErrorResponse"),
+				"422": refResponse("Validasyon hatası", "ValidationErrorResponse"),
+			},
+			Security: sessionSecurity,
+		},
+	}
+}
+
+// generatePasswordPath, şifre değiştirme endpoint'i için PathItem oluşturur.
+//
+// ## Endpoint
+//   - PUT /api/auth/password
+//
+// ## Request Body
+//   - current_password: string (required)
+//   - new_password: string (required, şifre politikasına uymalı)
+//   - confirm_password: string
+//
+// ## Responses
+//   - 200: Password changed, other sessions revoked
+//   - 401: Not authenticated
+//   - 403: Not available while impersonating
+//   - 422: Wrong current password or policy violation
+func (g *StaticSpecGenerator) generatePasswordPath() PathItem {
+	return PathItem{
+		Put: &Operation{
+			Summary:     "Şifre değiştir",
+			Description: "Mevcut şifreyi doğrulayıp yeni şifreyi kaydeder. Yeni şifre yapılandırılan şifre politikasına uymalıdır; mevcut oturum dışındaki tüm oturumlar sonlandırılır.",
+			OperationID: "changePassword",
+			Tags:        []string{"auth"},
+			RequestBody: &RequestBody{
+				Description: "Mevcut ve yeni şifre",
+				Required:    true,
+				Content: map[string]MediaType{
+					"application/json": {
+						Schema: &Schema{
+							Type: "object",
+							Properties: map[string]Schema{
+								"current_password": {Type: "string", Format: "password", Description: "Mevcut şifre", Example: "********"},
+								"new_password":     {Type: "string", Format: "password", Description: "Yeni şifre (varsayılan en az 8 karakter)", MinLength: ptr(8), Example: "********"},
+								"confirm_password": {Type: "string", Format: "password", Description: "Yeni şifre tekrarı", Example: "********"},
+							},
+							Required: []string{"current_password", "new_password"},
+						},
+					},
+				},
+			},
+			Responses: map[string]Response{
+				"200": {
+					Description: "Şifre değiştirildi",
+					Content: map[string]MediaType{
+						"application/json": {
+							Schema: &Schema{
+								Type:       "object",
+								Properties: map[string]Schema{"updated": {Type: "boolean", Example: true}},
+							},
+						},
+					},
+				},
+				"401": refResponse("Kimlik doğrulanmadı", "ErrorResponse"),
+				"403": refResponse("Impersonation oturumunda kullanılamaz", "ErrorResponse"),
+				"422": refResponse("Mevcut şifre yanlış veya yeni şifre politikaya uymuyor", "ValidationErrorResponse"),
+			},
+			Security: sessionSecurity,
+		},
+	}
+}
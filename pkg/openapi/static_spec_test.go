@@ -0,0 +1,28 @@
+package openapi
+
+import "testing"
+
+func TestStaticPathsIncludeAccountEndpoints(t *testing.T) {
+	paths := NewStaticSpecGenerator().GenerateStaticPaths()
+
+	cases := map[string]*Operation{
+		"/api/auth/profile":        paths["/api/auth/profile"].Put,
+		"/api/auth/profile/avatar": paths["/api/auth/profile/avatar"].Post,
+		"/api/auth/password":       paths["/api/auth/password"].Put,
+	}
+	for path, op := range cases {
+		if op == nil {
+			t.Fatalf("expected operation for %s", path)
+		}
+		if len(op.Security) != 1 || op.Security[0]["sessionCookie"] == nil {
+			t.Fatalf("%s should require the session cookie, got %v", path, op.Security)
+		}
+		if _, ok := op.Responses["422"]; !ok {
+			t.Fatalf("%s should document validation errors", path)
+		}
+	}
+
+	if _, ok := paths["/api/auth/profile/avatar"].Post.RequestBody.Content["multipart/form-data"]; !ok {
+		t.Fatal("avatar upload should accept multipart/form-data")
+	}
+}
@@ -408,6 +408,7 @@ type Components struct {
 //   - Scheme: HTTP authentication şeması (basic, bearer)
 //   - BearerFormat: Bearer token formatı (JWT)
 //   - Description: Açıklama
+//   - Flows: OAuth2 akışları ve scope tanımları (yalnızca type "oauth2")
 //
 // ## Kullanım Örneği
 //
@@ -427,12 +428,38 @@ type Components struct {
 //	    Description:  "JWT bearer token authentication",
 //	}
 type SecurityScheme struct {
-	Type         string `json:"type"`                   // Güvenlik tipi
-	In           string `json:"in,omitempty"`           // API key konumu
-	Name         string `json:"name,omitempty"`         // API key adı
-	Scheme       string `json:"scheme,omitempty"`       // HTTP authentication şeması
-	BearerFormat string `json:"bearerFormat,omitempty"` // Bearer token formatı
-	Description  string `json:"description,omitempty"`  // Açıklama
+	Type         string      `json:"type"`                   // Güvenlik tipi
+	In           string      `json:"in,omitempty"`           // API key konumu
+	Name         string      `json:"name,omitempty"`         // API key adı
+	Scheme       string      `json:"scheme,omitempty"`       // HTTP authentication şeması
+	BearerFormat string      `json:"bearerFormat,omitempty"` // Bearer token formatı
+	Description  string      `json:"description,omitempty"`  // Açıklama
+	Flows        *OAuthFlows `json:"flows,omitempty"`        // OAuth2 akışları
+}
+
+// OAuthFlows, bir oauth2 güvenlik şemasının desteklediği akışları tanımlar.
+type OAuthFlows struct {
+	Implicit          *OAuthFlow `json:"implicit,omitempty"`
+	Password          *OAuthFlow `json:"password,omitempty"`
+	ClientCredentials *OAuthFlow `json:"clientCredentials,omitempty"`
+	AuthorizationCode *OAuthFlow `json:"authorizationCode,omitempty"`
+}
+
+// OAuthFlow, tek bir OAuth2 akışını ve kullanılabilir scope'ları tanımlar.
+//
+// ## Kullanım Örneği
+//
+//	flow := OAuthFlow{
+//	    TokenURL: "/api/internal/api-keys",
+//	    Scopes: map[string]string{
+//	        "products:read": "Read Products",
+//	    },
+//	}
+type OAuthFlow struct {
+	AuthorizationURL string            `json:"authorizationUrl,omitempty"`
+	TokenURL         string            `json:"tokenUrl,omitempty"`
+	RefreshURL       string            `json:"refreshUrl,omitempty"`
+	Scopes           map[string]string `json:"scopes"`
 }
 
 // SecurityRequirement, bir güvenlik gereksinimini tanımlar.
@@ -13,21 +13,32 @@ import (
 
 	"github.com/ferdiunal/panel.go/pkg/context"
 	"github.com/ferdiunal/panel.go/pkg/domain/apikey"
+	"github.com/ferdiunal/panel.go/pkg/domain/session"
 	"github.com/ferdiunal/panel.go/pkg/middleware"
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 )
 
 type createManagedAPIKeyRequest struct {
-	Name         string  `json:"name"`
-	ExpiresAt    *string `json:"expires_at"`
-	ExpiresAtAlt *string `json:"expiresAt"`
+	Name          string   `json:"name"`
+	ExpiresAt     *string  `json:"expires_at"`
+	ExpiresAtAlt  *string  `json:"expiresAt"`
+	Scopes        []string `json:"scopes"`
+	Resources     []string `json:"resources"`
+	AllowedIPs    []string `json:"allowed_ips"`
+	AllowedIPsAlt []string `json:"allowedIps"`
+	RateLimit     int      `json:"rate_limit"`
+	RateLimitAlt  int      `json:"rateLimit"`
 }
 
 type managedAPIKeyResponse struct {
 	ID              uint       `json:"id"`
 	Name            string     `json:"name"`
 	Prefix          string     `json:"prefix"`
+	Scopes          []string   `json:"scopes"`
+	Resources       []string   `json:"resources,omitempty"`
+	AllowedIPs      []string   `json:"allowed_ips,omitempty"`
+	RateLimit       int        `json:"rate_limit"`
 	CreatedByUserID *uint      `json:"created_by_user_id,omitempty"`
 	LastUsedAt      *time.Time `json:"last_used_at,omitempty"`
 	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
@@ -57,6 +68,9 @@ func (p *Panel) handleAPIKeyList(c *context.Context) error {
 
 	return c.JSON(fiber.Map{
 		"data": items,
+		"meta": fiber.Map{
+			"scopes": p.availableAPIKeyScopes(),
+		},
 	})
 }
 
@@ -86,6 +100,34 @@ func (p *Panel) handleAPIKeyCreate(c *context.Context) error {
 		})
 	}
 
+	scopes, resources, err := p.parseManagedAPIKeyScopes(req.Scopes, req.Resources)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	rawIPs := req.AllowedIPs
+	if len(rawIPs) == 0 {
+		rawIPs = req.AllowedIPsAlt
+	}
+	allowedIPs, err := apikey.ParseAllowedIPs(rawIPs)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	rateLimit := req.RateLimit
+	if rateLimit == 0 {
+		rateLimit = req.RateLimitAlt
+	}
+	if rateLimit < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "rate_limit must not be negative",
+		})
+	}
+
 	rawKey, prefix, keyHash, err := generateManagedAPIKey()
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -103,6 +145,10 @@ func (p *Panel) handleAPIKeyCreate(c *context.Context) error {
 		Name:            name,
 		Prefix:          prefix,
 		KeyHash:         keyHash,
+		Scopes:          scopes,
+		Resources:       resources,
+		AllowedIPs:      allowedIPs,
+		RateLimit:       rateLimit,
 		CreatedByUserID: createdByUserID,
 		ExpiresAt:       expiresAt,
 	}
@@ -187,17 +233,30 @@ func (p *Panel) validateManagedAPIKey(c *fiber.Ctx, incoming string) bool {
 		Update("last_used_at", now).Error
 
 	c.Locals("api_key_id", record.ID)
+	c.Locals(middleware.APIKeyGrantLocalKey, newAPIKeyGrant(record))
 	return true
 }
 
 func requireAPIKeyAdminSession(c *context.Context) error {
+	return requireAdminSession(c, "API key management")
+}
+
+// requireAdminSession, feature için oturumla giriş yapmış ve impersonation
+// dışındaki bir admin kullanıcı ister; aksi halde uygun hata yanıtını yazar.
+func requireAdminSession(c *context.Context, feature string) error {
 	if c == nil {
 		return fiber.ErrUnauthorized
 	}
 
 	if apiKeyAuth, ok := c.Locals(middleware.APIKeyAuthenticatedLocalKey).(bool); ok && apiKeyAuth {
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"error": "API key management requires session authentication",
+			"error": feature + " requires session authentication",
+		})
+	}
+
+	if sess, ok := c.Locals("session").(*session.Session); ok && sess.IsImpersonated() {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": feature + " is not available while impersonating",
 		})
 	}
 
@@ -222,6 +281,10 @@ func toManagedAPIKeyResponse(record apikey.APIKey, now time.Time) managedAPIKeyR
 		ID:              record.ID,
 		Name:            record.Name,
 		Prefix:          record.Prefix,
+		Scopes:          record.EffectiveScopes(),
+		Resources:       record.Resources,
+		AllowedIPs:      record.AllowedIPs,
+		RateLimit:       record.RateLimit,
 		CreatedByUserID: record.CreatedByUserID,
 		LastUsedAt:      record.LastUsedAt,
 		ExpiresAt:       record.ExpiresAt,
@@ -0,0 +1,139 @@
+package panel
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ferdiunal/panel.go/pkg/context"
+	"github.com/ferdiunal/panel.go/pkg/domain/apikey"
+	"github.com/ferdiunal/panel.go/pkg/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+// apiKeyScopeOption, API key yönetim sayfasında seçilebilen bir scope'tur.
+type apiKeyScopeOption struct {
+	Scope       string `json:"scope"`
+	Resource    string `json:"resource,omitempty"`
+	Access      string `json:"access,omitempty"`
+	Description string `json:"description"`
+}
+
+// availableAPIKeyScopes, yönetilen key'lere verilebilecek scope'ları listeler.
+// Dahili resource'lara API key ile erişilemez, bu yüzden listelenmezler.
+func (p *Panel) availableAPIKeyScopes() []apiKeyScopeOption {
+	options := []apiKeyScopeOption{{Scope: apikey.ScopeAll, Description: "Full access to every resource"}}
+
+	snapshot := p.loadRegistrySnapshot()
+	if snapshot == nil {
+		return options
+	}
+
+	slugs := make([]string, 0, len(snapshot.publicResources))
+	for slug := range snapshot.publicResources {
+		slugs = append(slugs, slug)
+	}
+	sort.Strings(slugs)
+
+	for _, slug := range slugs {
+		title := snapshot.publicResources[slug].Title()
+		options = append(options,
+			apiKeyScopeOption{
+				Scope:       apikey.Scope(slug, apikey.AccessRead),
+				Resource:    slug,
+				Access:      apikey.AccessRead,
+				Description: fmt.Sprintf("Read %s", title),
+			},
+			apiKeyScopeOption{
+				Scope:       apikey.Scope(slug, apikey.AccessWrite),
+				Resource:    slug,
+				Access:      apikey.AccessWrite,
+				Description: fmt.Sprintf("Create, update and delete %s", title),
+			},
+		)
+	}
+	return options
+}
+
+// parseManagedAPIKeyScopes, istenen scope'ları ve resource izin listesini kayıtlı resource'lara göre doğrular.
+// Scope verilmeden oluşturulan key'ler "*" alır.
+func (p *Panel) parseManagedAPIKeyScopes(rawScopes, rawResources []string) ([]string, []string, error) {
+	scopes, err := apikey.ParseScopes(rawScopes)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(scopes) == 0 {
+		scopes = []string{apikey.ScopeAll}
+	}
+
+	for _, scope := range scopes {
+		slug, _, _ := strings.Cut(scope, ":")
+		if slug != apikey.ScopeAll && !p.isAPIKeyResource(slug) {
+			return nil, nil, fmt.Errorf("unknown resource in scope %q", scope)
+		}
+	}
+
+	resources := make([]string, 0, len(rawResources))
+	for _, slug := range rawResources {
+		slug = strings.TrimSpace(slug)
+		if slug == "" {
+			continue
+		}
+		if !p.isAPIKeyResource(slug) {
+			return nil, nil, fmt.Errorf("unknown resource %q", slug)
+		}
+		resources = append(resources, slug)
+	}
+
+	return scopes, resources, nil
+}
+
+func (p *Panel) isAPIKeyResource(slug string) bool {
+	snapshot := p.loadRegistrySnapshot()
+	if snapshot == nil {
+		return false
+	}
+	_, ok := snapshot.publicResources[slug]
+	return ok
+}
+
+// newAPIKeyGrant, yönetilen key kaydını istek başına uygulanan grant'e dönüştürür.
+func newAPIKeyGrant(record apikey.APIKey) *middleware.APIKeyGrant {
+	return &middleware.APIKeyGrant{
+		KeyID:      record.ID,
+		Scopes:     record.EffectiveScopes(),
+		Resources:  record.Resources,
+		AllowedIPs: record.AllowedIPs,
+		RateLimit:  record.RateLimit,
+	}
+}
+
+// apiKeyAccessForMethod, HTTP metodunu gerektirdiği erişim seviyesine eşler.
+func apiKeyAccessForMethod(method string) string {
+	switch method {
+	case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions:
+		return apikey.AccessRead
+	default:
+		return apikey.AccessWrite
+	}
+}
+
+// authorizeAPIKeyScope, isteğin API key grant'ini resource ve HTTP metoduna göre denetler.
+// 403 yanıtı yazdıysa false döner.
+func authorizeAPIKeyScope(c *context.Context, slug string) (bool, error) {
+	grant := middleware.APIKeyGrantFromContext(c.Ctx)
+	if grant == nil {
+		return true, nil
+	}
+
+	access := apiKeyAccessForMethod(c.Method())
+	if grant.Allows(slug, access) {
+		return true, nil
+	}
+
+	return false, c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+		"error":          "API key does not have the required scope",
+		"code":           "insufficient_scope",
+		"required_scope": apikey.Scope(slug, access),
+	})
+}
@@ -0,0 +1,206 @@
+package panel
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func setupScopedAPIKeyPanel(t *testing.T) (*Panel, *http.Cookie) {
+	t.Helper()
+
+	p := setupInternalRESTAPIPanel(t, Config{
+		Features: FeatureConfig{ExternalAPI: true},
+		APIKey:   APIKeyConfig{Enabled: true},
+	})
+	return p, registerAndLoginTestUser(t, p, "scoped-key-admin@example.com")
+}
+
+func createScopedAPIKey(t *testing.T, p *Panel, admin *http.Cookie, body map[string]interface{}) string {
+	t.Helper()
+
+	resp, payload := twoFactorRequest(t, p, "POST", "/api/internal/api-keys", admin, body)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("create key: expected 201, got %d (%v)", resp.StatusCode, payload)
+	}
+	key, _ := payload["key"].(string)
+	return key
+}
+
+func apiKeyRequest(t *testing.T, p *Panel, method, path, key string) (*http.Response, map[string]interface{}) {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader("{}"))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-Key", key)
+	resp, err := testFiberRequest(p.Fiber, req)
+	if err != nil {
+		t.Fatalf("%s %s failed: %v", method, path, err)
+	}
+	payload := map[string]interface{}{}
+	_ = json.NewDecoder(resp.Body).Decode(&payload)
+	return resp, payload
+}
+
+func TestScopedAPIKey_ReadScopeBlocksWrites(t *testing.T) {
+	p, admin := setupScopedAPIKeyPanel(t)
+	key := createScopedAPIKey(t, p, admin, map[string]interface{}{
+		"name":   "Reader",
+		"scopes": []string{"internal-rest-users:read"},
+	})
+
+	for _, path := range []string{"/api/internal-rest-users", "/api/internal/resource/internal-rest-users"} {
+		if resp, payload := apiKeyRequest(t, p, "GET", path, key); resp.StatusCode != http.StatusOK {
+			t.Fatalf("GET %s: expected 200, got %d (%v)", path, resp.StatusCode, payload)
+		}
+	}
+
+	resp, payload := apiKeyRequest(t, p, "DELETE", "/api/internal-rest-users/1", key)
+	if resp.StatusCode != http.StatusForbidden || payload["code"] != "insufficient_scope" || payload["required_scope"] != "internal-rest-users:write" {
+		t.Fatalf("external delete: expected 403 insufficient_scope, got %d (%v)", resp.StatusCode, payload)
+	}
+	if resp, _ := apiKeyRequest(t, p, "DELETE", "/api/internal/resource/internal-rest-users/1", key); resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("internal delete: expected 403, got %d", resp.StatusCode)
+	}
+}
+
+func TestScopedAPIKey_ResourceAllowList(t *testing.T) {
+	p, admin := setupScopedAPIKeyPanel(t)
+	key := createScopedAPIKey(t, p, admin, map[string]interface{}{
+		"name":      "Restricted",
+		"scopes":    []string{"*"},
+		"resources": []string{"internal-rest-users"},
+	})
+	if resp, _ := apiKeyRequest(t, p, "GET", "/api/internal-rest-users", key); resp.StatusCode != http.StatusOK {
+		t.Fatalf("allowed resource: expected 200, got %d", resp.StatusCode)
+	}
+
+	// Navigation only lists resources the key can read
+	other := createScopedAPIKey(t, p, admin, map[string]interface{}{
+		"name":      "Other",
+		"scopes":    []string{"*:read"},
+		"resources": []string{"internal-rest-users"},
+	})
+	resp, payload := apiKeyRequest(t, p, "GET", "/api/internal/navigation", other)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("navigation: expected 200, got %d", resp.StatusCode)
+	}
+	var resources []interface{}
+	items, _ := payload["data"].([]interface{})
+	for _, item := range items {
+		if entry, ok := item.(map[string]interface{}); ok && entry["type"] == "resource" {
+			resources = append(resources, entry["slug"])
+		}
+	}
+	if len(resources) != 1 || resources[0] != "internal-rest-users" {
+		t.Fatalf("expected only the allowed resource in navigation, got %v", resources)
+	}
+}
+
+func TestScopedAPIKey_IPAllowListAndRateLimit(t *testing.T) {
+	p, admin := setupScopedAPIKeyPanel(t)
+
+	blocked := createScopedAPIKey(t, p, admin, map[string]interface{}{
+		"name":        "Office only",
+		"allowed_ips": []string{"10.0.0.0/8"},
+	})
+	resp, payload := apiKeyRequest(t, p, "GET", "/api/internal-rest-users", blocked)
+	if resp.StatusCode != http.StatusForbidden || payload["code"] != "ip_not_allowed" {
+		t.Fatalf("external: expected 403 ip_not_allowed, got %d (%v)", resp.StatusCode, payload)
+	}
+	if resp, _ := apiKeyRequest(t, p, "GET", "/api/internal/resource/internal-rest-users", blocked); resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("internal: expected 403, got %d", resp.StatusCode)
+	}
+
+	limited := createScopedAPIKey(t, p, admin, map[string]interface{}{
+		"name":       "Limited",
+		"rate_limit": 2,
+	})
+	for i := 0; i < 2; i++ {
+		if resp, _ := apiKeyRequest(t, p, "GET", "/api/internal-rest-users", limited); resp.StatusCode != http.StatusOK {
+			t.Fatalf("request %d: expected 200, got %d", i+1, resp.StatusCode)
+		}
+	}
+	resp, payload = apiKeyRequest(t, p, "GET", "/api/internal/resource/internal-rest-users", limited)
+	if resp.StatusCode != http.StatusTooManyRequests || payload["code"] != "rate_limited" || resp.Header.Get("Retry-After") == "" {
+		t.Fatalf("expected 429 rate_limited with Retry-After, got %d (%v)", resp.StatusCode, payload)
+	}
+}
+
+func TestScopedAPIKey_ManagementValidatesAndListsScopes(t *testing.T) {
+	p, admin := setupScopedAPIKeyPanel(t)
+
+	for _, body := range []map[string]interface{}{
+		{"name": "Bad scope", "scopes": []string{"internal-rest-users:admin"}},
+		{"name": "Unknown resource", "scopes": []string{"orders:read"}},
+		{"name": "Internal resource", "resources": []string{"users"}},
+		{"name": "Bad IP", "allowed_ips": []string{"not-an-ip"}},
+		{"name": "Bad limit", "rate_limit": -1},
+	} {
+		if resp, payload := twoFactorRequest(t, p, "POST", "/api/internal/api-keys", admin, body); resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("%v: expected 400, got %d (%v)", body["name"], resp.StatusCode, payload)
+		}
+	}
+
+	createScopedAPIKey(t, p, admin, map[string]interface{}{"name": "Legacy style"})
+	resp, payload := twoFactorRequest(t, p, "GET", "/api/internal/api-keys", admin, nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("list: expected 200, got %d", resp.StatusCode)
+	}
+	items, _ := payload["data"].([]interface{})
+	first, _ := items[0].(map[string]interface{})
+	if scopes, _ := first["scopes"].([]interface{}); len(scopes) != 1 || scopes[0] != "*" {
+		t.Fatalf("keys without scopes should default to *, got %v", first["scopes"])
+	}
+
+	meta, _ := payload["meta"].(map[string]interface{})
+	options, _ := meta["scopes"].([]interface{})
+	found := map[string]bool{}
+	for _, option := range options {
+		if entry, ok := option.(map[string]interface{}); ok {
+			found[entry["scope"].(string)] = true
+		}
+	}
+	if !found["*"] || !found["internal-rest-users:read"] || !found["internal-rest-users:write"] || found["users:read"] {
+		t.Fatalf("unexpected selectable scopes: %v", found)
+	}
+}
+
+func TestScopedAPIKey_OpenAPIDocumentsScopes(t *testing.T) {
+	p, _ := setupScopedAPIKeyPanel(t)
+
+	resp, err := testFiberRequest(p.Fiber, httptest.NewRequest("GET", "/api/openapi.json", nil))
+	if err != nil {
+		t.Fatalf("openapi request failed: %v", err)
+	}
+	var spec struct {
+		Components struct {
+			SecuritySchemes map[string]struct {
+				Type  string `json:"type"`
+				Flows struct {
+					ClientCredentials struct {
+						Scopes map[string]string `json:"scopes"`
+					} `json:"clientCredentials"`
+				} `json:"flows"`
+			} `json:"securitySchemes"`
+		} `json:"components"`
+		Paths map[string]map[string]struct {
+			Security []map[string][]string `json:"security"`
+		} `json:"paths"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&spec); err != nil {
+		t.Fatalf("decode spec: %v", err)
+	}
+
+	scheme := spec.Components.SecuritySchemes["apiKeyScopes"]
+	if scheme.Type != "oauth2" || scheme.Flows.ClientCredentials.Scopes["internal-rest-users:write"] == "" {
+		t.Fatalf("expected oauth2 scope scheme, got %+v", scheme)
+	}
+	get := spec.Paths["/api/internal-rest-users"]["get"].Security
+	post := spec.Paths["/api/internal-rest-users"]["post"].Security
+	if len(get) != 1 || get[0]["apiKeyScopes"][0] != "internal-rest-users:read" || post[0]["apiKeyScopes"][0] != "internal-rest-users:write" {
+		t.Fatalf("unexpected operation security: get=%v post=%v", get, post)
+	}
+}
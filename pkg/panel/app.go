@@ -29,6 +29,7 @@ import (
 	stdcontext "context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/fs"
 	"net"
 	"net/http"
@@ -47,9 +48,14 @@ import (
 	"github.com/ferdiunal/panel.go/pkg/data/orm"
 	"github.com/ferdiunal/panel.go/pkg/domain/account"
 	"github.com/ferdiunal/panel.go/pkg/domain/apikey"
+	auditDomain "github.com/ferdiunal/panel.go/pkg/domain/audit"
 	notificationDomain "github.com/ferdiunal/panel.go/pkg/domain/notification"
+	"github.com/ferdiunal/panel.go/pkg/domain/ratelimit"
 	"github.com/ferdiunal/panel.go/pkg/domain/session"
 	"github.com/ferdiunal/panel.go/pkg/domain/setting"
+	"github.com/ferdiunal/panel.go/pkg/domain/storedfile"
+	"github.com/ferdiunal/panel.go/pkg/domain/twofactor"
+	"github.com/ferdiunal/panel.go/pkg/domain/upload"
 	"github.com/ferdiunal/panel.go/pkg/domain/user"
 	"github.com/ferdiunal/panel.go/pkg/domain/verification"
 	"github.com/ferdiunal/panel.go/pkg/handler"
@@ -62,8 +68,11 @@ import (
 	"github.com/ferdiunal/panel.go/pkg/permission"
 	"github.com/ferdiunal/panel.go/pkg/plugin"
 	"github.com/ferdiunal/panel.go/pkg/resource"
+	resourceAudit "github.com/ferdiunal/panel.go/pkg/resource/audit"
 	resourceUser "github.com/ferdiunal/panel.go/pkg/resource/user"
 	"github.com/ferdiunal/panel.go/pkg/service/auth"
+	"github.com/ferdiunal/panel.go/pkg/storage"
+	"github.com/ferdiunal/panel.go/shared/encrypt"
 	"github.com/gofiber/contrib/circuitbreaker"
 	"github.com/gofiber/contrib/fiberi18n/v2"
 	"github.com/gofiber/fiber/v2"
@@ -75,6 +84,7 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/etag"
 	"github.com/gofiber/fiber/v2/middleware/filesystem"
 	"github.com/gofiber/fiber/v2/middleware/helmet"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
 	"golang.org/x/text/language"
 	"gorm.io/gorm"
 )
@@ -118,6 +128,20 @@ type Panel struct {
 	openAPIHandler        *handler.OpenAPIHandler
 	apiKeyAuth            *middleware.APIKeyAuth
 	accountLockout        *middleware.AccountLockout
+	counterStoreCloser    io.Closer // Redis sayaç store'u (nil: kapatılacak bağlantı yok)
+	sessionSweeper        *auth.SessionSweeper
+	authHandler           *authHandler.Handler
+	auditLogger           middleware.AuditLogger    // Security.Audit kapalıysa nil
+	auditCloser           io.Closer                 // Dosya veya veritabanı audit logger'ı (nil: kapatılacak kaynak yok)
+	auditPruner           *auditPruner              // Security.Audit.Retention ile eski olayları siler
+	fileJanitor           *fileJanitor              // Storage.OrphanGracePeriod ile yetim dosyaları siler
+	uploads               *handler.ResumableUploads // Parça parça (tus) gönderilen yüklemeler
+	uploadSweeper         *uploadSweeper            // Storage.Uploads.Expiration ile süresi dolan yüklemeleri siler
+	disks                 *storage.Manager          // Config.Storage ile tanımlanan depolama diskleri
+	storageSigningKey     []byte                    // Yerel disklerin süreli URL imza anahtarı
+	readDb                *gorm.DB                  // ReadReplicaDSN ile açılan okuma bağlantısı (nil: primary)
+	fieldKeyring          *encrypt.Keyring          // Config.FieldEncryption anahtarları (nil: alan şifreleme kapalı)
+	dbConns               *databaseConnections
 	closeOnce             sync.Once
 }
 
@@ -376,6 +400,21 @@ func New(config Config) *Panel {
 		config.Concurrency.FailFast = true
 	}
 
+	// SECURITY: Etkin güvenlik profilini çöz ve çelişkili ayarları başlangıçta reddet
+	securityCfg := resolveSecurityConfig(config)
+	if err := validateSecurityConfig(config.Environment, securityCfg); err != nil {
+		panic(fmt.Errorf("güvenlik yapılandırması geçersiz: %w", err))
+	}
+	fmt.Println(describeSecurityProfile(config.Environment, securityCfg, config.Security != nil))
+
+	// Veritabanı: Instance verilmemişse DSN/Driver ile bağlantıyı aç, havuz ve
+	// yavaş sorgu ayarlarını uygula, varsa read replica'yı hazırla
+	dbConns, err := openDatabaseConnections(config.Database)
+	if err != nil {
+		panic(fmt.Errorf("veritabanı bağlantısı açılamadı: %w", err))
+	}
+	config.Database.Instance = dbConns.primary
+
 	// configRef, closure'ların her zaman güncel config'i okumasını sağlar.
 	// Panel oluşturulduktan sonra p.Config'e yeniden atanır.
 	configRef := &config
@@ -402,18 +441,78 @@ func New(config Config) *Panel {
 		return c.Next()
 	})
 
+	// Request ID: gelen X-Request-ID başlığı korunur, yoksa üretilir ve yanıta eklenir.
+	// Audit olayları bu değeri request_id metadata alanı olarak taşır
+	app.Use(requestid.New(requestid.Config{ContextKey: middleware.RequestIDLocalKey}))
+
 	// Auth Components
 	userRepo := orm.NewUserRepository(db)
 	sessionRepo := orm.NewSessionRepository(db)
 	accountRepo := orm.NewAccountRepository(db)
 
 	authService := auth.NewService(userRepo, sessionRepo, accountRepo)
-	// SECURITY: Account lockout after 5 failed attempts, 15 minute lockout duration
-	accountLockout := middleware.NewAccountLockout(5, 15*time.Minute)
+	if securityCfg.Session.MaxAge > 0 {
+		authService.SetSessionLifetime(time.Duration(securityCfg.Session.MaxAge) * time.Second)
+	}
+	if securityCfg.Session.IdleTimeout > 0 {
+		authService.SetSessionIdleTimeout(time.Duration(securityCfg.Session.IdleTimeout) * time.Second)
+	}
+	// Depolama diskleri (Config.Storage.Disks); "public" diski Path/URL ile oluşturulur
+	disks, storageSigningKey, err := newStorageManager(config.Storage)
+	if err != nil {
+		panic(fmt.Errorf("depolama yapılandırması geçersiz: %w", err))
+	}
+	// SECURITY: Rate limit, lockout ve API key sayaçları tek store'da tutulur
+	// (Config.Security.CounterStore); paylaşılan store ile limitler replikalar arasında ortaktır
+	counterStore, counterStoreCloser, err := newSecurityCounterStore(securityCfg.CounterStore, db)
+	if err != nil {
+		panic(fmt.Errorf("sayaç store'u başlatılamadı: %w", err))
+	}
+	// SECURITY: Account lockout (default: 5 failed attempts, 15 minute lockout duration)
+	var accountLockout *middleware.AccountLockout
+	if securityCfg.AccountLockout.Enabled {
+		accountLockout = middleware.NewAccountLockoutWithStore(counterStore, securityCfg.AccountLockout.MaxAttempts, securityCfg.AccountLockout.LockoutDuration)
+	}
+	// Two-factor authentication (TOTP + recovery codes)
+	authService.SetTwoFactorStore(orm.NewTwoFactorRepository(db), orm.NewVerificationRepository(db))
+	authService.SetTwoFactorIssuer(config.TwoFactor.Issuer)
+	authService.SetTwoFactorRequiredRoles(config.TwoFactor.RequiredRoles)
 	authH := authHandler.NewHandler(authService, accountLockout, config.Environment)
+	authH.SetSessionCookie(newSessionCookieConfig(securityCfg.Session))
+	// Email verification (sign-up link, resend, login/access enforcement)
+	if err := validateEmailVerificationConfig(config.EmailVerification, config.Mail); err != nil {
+		panic(fmt.Errorf("e-posta doğrulama yapılandırması geçersiz: %w", err))
+	}
+	configureEmailVerification(config, orm.NewVerificationRepository(db), authService, authH)
+	// Password reset (forgot-password link, token-based reset with the password policy)
+	if err := validatePasswordResetConfig(config.PasswordReset, config.Mail); err != nil {
+		panic(fmt.Errorf("şifre sıfırlama yapılandırması geçersiz: %w", err))
+	}
+	configurePasswordReset(config, orm.NewVerificationRepository(db), authService)
+	// Password policy (registration, change, admin reset) with reuse history
+	passwordPolicy, err := newPasswordPolicy(config.Password)
+	if err != nil {
+		panic(fmt.Errorf("şifre politikası yapılandırması geçersiz: %w", err))
+	}
+	authService.SetPasswordPolicy(passwordPolicy)
+	authService.SetPasswordHistoryStore(orm.NewPasswordHistoryRepository(db))
 
 	// Auto Migrate Auth Domains
-	db.AutoMigrate(&user.User{}, &session.Session{}, &account.Account{}, &verification.Verification{}, &setting.Setting{}, &notificationDomain.Notification{}, &apikey.APIKey{})
+	db.AutoMigrate(&user.User{}, &session.Session{}, &account.Account{}, &verification.Verification{}, &setting.Setting{}, &notificationDomain.Notification{}, &apikey.APIKey{}, &twofactor.TwoFactor{}, &twofactor.RecoveryCode{}, &account.PasswordHistory{})
+	// Sessions opened before tokens were hashed keep working: their plaintext tokens are hashed once here.
+	if migrated, err := authService.HashLegacySessionTokens(stdcontext.Background()); err != nil {
+		db.Logger.Warn(stdcontext.Background(), "session token migration failed, older sessions will need to sign in again: %v", err)
+	} else if migrated > 0 {
+		db.Logger.Info(stdcontext.Background(), "hashed %d plaintext session tokens", migrated)
+	}
+	if securityCfg.CounterStore.Driver == "database" {
+		db.AutoMigrate(&ratelimit.Counter{})
+	}
+	auditToDatabase := securityCfg.Audit.Enabled && securityCfg.Audit.Destination == "database"
+	if auditToDatabase {
+		db.AutoMigrate(&auditDomain.Event{}, &auditDomain.ChainHead{})
+	}
+	db.AutoMigrate(&storedfile.File{}, &upload.Upload{})
 
 	// Middleware Registration
 	// SECURITY: EncryptCookie middleware - MUST be registered BEFORE other cookie middleware
@@ -449,20 +548,8 @@ func New(config Config) *Panel {
 	}))
 
 	// SECURITY: CORS Configuration - NEVER use "*" in production
-	// Configure allowed origins in your config
-	allowedOrigins := config.CORS.AllowedOrigins
-	if len(allowedOrigins) == 0 {
-		// Default to localhost for development
-		allowedOrigins = []string{"http://localhost:3000", "http://localhost:5173"}
-	}
-	app.Use(cors.New(cors.Config{
-		AllowOrigins:     strings.Join(allowedOrigins, ","),
-		AllowMethods:     "GET,POST,PUT,DELETE,OPTIONS",
-		AllowHeaders:     "Content-Type,Authorization,X-CSRF-Token,X-API-Key",
-		AllowCredentials: true,
-		ExposeHeaders:    "Content-Length,X-CSRF-Token",
-		MaxAge:           3600,
-	}))
+	// Configure allowed origins in Config.Security.CORS or Config.CORS
+	app.Use(cors.New(newSecurityCORSConfig(securityCfg.CORS)))
 
 	// SECURITY: CSRF Protection - ALWAYS enabled (except in test environment)
 	// Uses Double Submit Cookie pattern: token in cookie + header
@@ -500,22 +587,22 @@ func New(config Config) *Panel {
 	}))
 
 	// SECURITY: Additional security headers (helmet doesn't support all of these)
-	cspValue := buildContentSecurityPolicy(config.Environment)
-	app.Use(func(c *fiber.Ctx) error {
-		c.Set("Content-Security-Policy", cspValue)
-		c.Set("X-Frame-Options", "DENY")
-		c.Set("X-Content-Type-Options", "nosniff")
-		c.Set("Referrer-Policy", "no-referrer")
-		c.Set("Permissions-Policy", "geolocation=(), microphone=(), camera=()")
-		return c.Next()
-	})
+	securityHeaders := middleware.DefaultSecurityHeaders()
+	securityHeaders.ContentSecurityPolicy = buildContentSecurityPolicy(config.Environment)
+	app.Use(middleware.SecurityHeaders(securityHeaders))
 
 	// SECURITY: Request size limits to prevent DoS attacks
 	app.Use(middleware.RequestSizeLimit(10 * 1024 * 1024)) // 10MB limit
 
 	// SECURITY: Audit logging for security events
-	auditLogger := &middleware.ConsoleAuditLogger{}
-	app.Use(middleware.AuditMiddleware(auditLogger))
+	auditLogger, auditCloser, err := newSecurityAuditLogger(securityCfg.Audit, db)
+	if err != nil {
+		panic(fmt.Errorf("audit log başlatılamadı: %w", err))
+	}
+	if auditLogger != nil {
+		app.Use(middleware.AuditMiddleware(auditLogger))
+		authH.SetAuditLogger(auditLogger)
+	}
 
 	// I18N: Çoklu dil desteği (Internationalization)
 	// Uygulamanın farklı dillerde gösterilmesini sağlar
@@ -567,13 +654,10 @@ func New(config Config) *Panel {
 		},
 	})
 
-	// Storage serving (common for all priorities)
-	if config.Storage.URL != "" && config.Storage.Path != "" {
-		app.Use(config.Storage.URL, staticCompress)
-		app.Static(config.Storage.URL, config.Storage.Path)
-	} else {
-		app.Use("/storage", staticCompress)
-		app.Static("/storage", "./storage/public")
+	// Storage serving (common for all priorities): göreli URL'li yerel diskler
+	for mountURL, root := range staticStorageMounts(config.Storage) {
+		app.Use(mountURL, staticCompress)
+		app.Static(mountURL, root)
 	}
 
 	// Priority 1: Check assets/ui/index.html (project-specific build)
@@ -752,9 +836,22 @@ func New(config Config) *Panel {
 		}
 	}
 
+	// Alan şifreleme anahtarlarını yükle (Encrypted() alanlar ve 2FA secret'ları için)
+	var fieldKeyring *encrypt.Keyring
+	if primaryKeyID, keys := securityFieldEncryptionKeys(config, securityCfg); len(keys) > 0 {
+		keyring, err := encrypt.NewKeyringFromHex(primaryKeyID, keys)
+		if err != nil {
+			panic(fmt.Errorf("alan şifreleme anahtarları yüklenemedi: %w", err))
+		}
+		fieldKeyring = keyring
+		authService.SetTwoFactorKeyring(keyring)
+	}
+
 	p := &Panel{
 		Config:                config,
 		Db:                    db,
+		readDb:                dbConns.replica,
+		dbConns:               dbConns,
 		Fiber:                 app,
 		Auth:                  authService,
 		resources:             make(map[string]resource.Resource),
@@ -763,7 +860,32 @@ func New(config Config) *Panel {
 		pages:                 make(map[string]page.Page),
 		plugins:               make([]interface{}, 0),
 		accountLockout:        accountLockout,
+		counterStoreCloser:    counterStoreCloser,
+		fieldKeyring:          fieldKeyring,
+		sessionSweeper:        auth.NewSessionSweeper(authService, auth.DefaultSessionSweepInterval),
+		authHandler:           authH,
+		auditLogger:           auditLogger,
+		auditCloser:           auditCloser,
+		disks:                 disks,
+		storageSigningKey:     storageSigningKey,
+	}
+	if auditToDatabase && securityCfg.Audit.Retention > 0 {
+		p.auditPruner = newAuditPruner(orm.NewAuditRepository(db), securityCfg.Audit.Retention, DefaultAuditPruneInterval)
+	}
+	p.fileJanitor = newFileJanitor(orm.NewStoredFileRepository(db), disks, config.Storage.OrphanGracePeriod)
+	uploads, err := handler.NewResumableUploads(db, handler.ResumableUploadOptions{
+		Dir:        config.Storage.Uploads.TempDir,
+		MaxSize:    config.Storage.Uploads.MaxSize,
+		Expiration: config.Storage.Uploads.Expiration,
+	})
+	if err != nil {
+		panic(fmt.Errorf("yükleme klasörü oluşturulamadı: %w", err))
 	}
+	p.uploads = uploads
+	p.uploadSweeper = newUploadSweeper(uploads)
+
+	authH.SetImpersonationAuthorizer(p.authorizeImpersonation)
+	authH.SetAvatarStorer(p.storeAvatar)
 
 	p.registryMu.Lock()
 	p.publishRegistrySnapshotLocked()
@@ -789,7 +911,15 @@ func New(config Config) *Panel {
 	if p.Config.UserResource != nil {
 		p.registerSystemResource(p.Config.UserResource)
 	} else {
-		p.registerSystemResource(resourceUser.GetUserResource())
+		userResource := resourceUser.NewUserResource()
+		if authService.EmailVerificationEnabled() {
+			userResource.SetEmailVerifier(authService)
+		}
+		userResource.SetPasswordService(authService)
+		p.registerSystemResource(userResource)
+	}
+	if auditToDatabase {
+		p.registerSystemResource(resourceAudit.NewAuditEventResource())
 	}
 
 	// Register Additional Resources
@@ -830,6 +960,7 @@ func New(config Config) *Panel {
 	p.apiKeyAuth = middleware.NewAPIKeyAuth(apiKeyConfig.Enabled, apiKeyConfig.Header, apiKeyConfig.Keys)
 	p.apiKeyAuth.SetAtomicSnapshotEnabled(config.Concurrency.EnableMiddlewareV2)
 	p.apiKeyAuth.SetDynamicValidator(p.validateManagedAPIKey)
+	p.apiKeyAuth.SetCounterStore(counterStore)
 
 	externalAPIConfig := p.resolveExternalAPIRuntimeConfig()
 
@@ -845,6 +976,8 @@ func New(config Config) *Panel {
 	}
 	p.openAPIHandler = handler.NewOpenAPIHandler(p.publicResources, openAPIConfig)
 	app.Get("/api/openapi.json", p.openAPIHandler.GetSpec)
+	// Süreli dosya bağlantıları imzayla doğrulanır; oturum gerektirmez
+	app.Get(temporaryFileRoute+"/:disk/*", p.handleTemporaryFile)
 	app.Get("/api/docs", p.openAPIHandler.SwaggerUI)
 	if config.I18n.Enabled && config.I18n.UseURLPrefix {
 		app.Get("/api/:lang/openapi.json", p.openAPIHandler.GetSpec)
@@ -914,19 +1047,29 @@ func New(config Config) *Panel {
 
 	// registerAPIRoutes: Tüm API route'larını kaydet
 	// Bu closure fonksiyon, dual route registration için kullanılır
+	// SECURITY: Rate limiting (Config.Security.RateLimit). Limiter'lar bir kez oluşturulur
+	// ki dil önekli route'lar da aynı sayaçları paylaşsın.
+	authRateLimiter, apiRateLimiter := newSecurityRateLimiters(securityCfg.RateLimit, counterStore)
+
 	registerAPIRoutes := func(apiGroup fiber.Router) {
 		// Auth Routes
 		authRoutes := apiGroup.Group("/auth")
-		// SECURITY: Strict rate limiting for authentication endpoints (10 req/min)
-		// TEMPORARY: Rate limiting disabled for development
-		// authRoutes.Use(middleware.AuthRateLimiter())
+		// SECURITY: Strict rate limiting for authentication endpoints
+		if authRateLimiter != nil {
+			authRoutes.Use(authRateLimiter)
+		}
 		authRoutes.Post("/sign-in/email", context.Wrap(authH.LoginEmail))
+		authRoutes.Post("/sign-in/two-factor", context.Wrap(authH.TwoFactorChallenge))
 		authRoutes.Post("/sign-up/email", context.Wrap(authH.RegisterEmail))
 		authRoutes.Post("/sign-out", context.Wrap(authH.SignOut))
 		authRoutes.Post("/forgot-password", context.Wrap(authH.ForgotPassword))
+		authRoutes.Post("/reset-password", context.Wrap(authH.ResetPassword))
 		authRoutes.Get("/session", context.Wrap(authH.GetSession))
+		authRoutes.Get("/verify-email", context.Wrap(authH.VerifyEmail))
+		authRoutes.Post("/verify-email/resend", context.Wrap(authH.ResendEmailVerification))
 
-		apiGroup.Get("/init", context.Wrap(p.handleInit)) // App Initialization
+		apiGroup.Get("/init", context.Wrap(p.handleInit))     // App Initialization
+		apiGroup.Get("/health", context.Wrap(p.handleHealth)) // Database health check
 
 		// Middleware
 		// NOTE: /api/internal/rest/* path'i dedicated internal REST servisine aittir.
@@ -958,15 +1101,43 @@ func New(config Config) *Panel {
 			}
 			return sessionMiddleware(c)
 		})
-		// SECURITY: Rate limiting for general API endpoints (100 req/min)
-		// TEMPORARY: Rate limiting disabled for development
-		// apiGroup.Use(middleware.APIRateLimiter())
+		// SECURITY: Rate limiting for general API endpoints
+		if apiRateLimiter != nil {
+			apiGroup.Use(apiRateLimiter)
+		}
+
+		// Two-factor management routes (session only).
+		apiGroup.Get("/auth/two-factor", context.Wrap(authH.TwoFactorStatus))
+		apiGroup.Post("/auth/two-factor/enroll", context.Wrap(authH.TwoFactorEnroll))
+		apiGroup.Post("/auth/two-factor/confirm", context.Wrap(authH.TwoFactorConfirm))
+		apiGroup.Post("/auth/two-factor/recovery-codes", context.Wrap(authH.TwoFactorRecoveryCodes))
+		apiGroup.Post("/auth/two-factor/disable", context.Wrap(authH.TwoFactorDisable))
+
+		// Session (device) management routes (session only).
+		apiGroup.Get("/auth/sessions", context.Wrap(authH.ListSessions))
+		apiGroup.Post("/auth/sessions/revoke-others", context.Wrap(authH.RevokeOtherSessions))
+		apiGroup.Delete("/auth/sessions/:id", context.Wrap(authH.RevokeSession))
+
+		// Self-service profile routes (session only).
+		apiGroup.Put("/auth/profile", context.Wrap(authH.UpdateProfile))
+		apiGroup.Post("/auth/profile/avatar", context.Wrap(authH.UploadAvatar))
+		apiGroup.Put("/auth/password", context.Wrap(authH.ChangePassword))
+
+		// Impersonation routes (session only, users.impersonate + UserPolicy.Impersonate).
+		apiGroup.Post("/auth/impersonate", context.Wrap(authH.StartImpersonation))
+		apiGroup.Post("/auth/impersonate/stop", context.Wrap(authH.StopImpersonation))
 
 		// Managed API key lifecycle routes (admin + session only).
 		apiGroup.Get("/api-keys", context.Wrap(p.handleAPIKeyList))
 		apiGroup.Post("/api-keys", context.Wrap(p.handleAPIKeyCreate))
 		apiGroup.Delete("/api-keys/:id", context.Wrap(p.handleAPIKeyRevoke))
 
+		// Audit log export and hash chain verification (admin + session only).
+		if securityCfg.Audit.Enabled && securityCfg.Audit.Destination == "database" {
+			apiGroup.Get("/audit-events/export", context.Wrap(p.handleAuditExport))
+			apiGroup.Get("/audit-events/verify", context.Wrap(p.handleAuditVerify))
+		}
+
 		// Page Routes
 		apiGroup.Get("/pages", context.Wrap(p.handlePages))
 		apiGroup.Get("/pages/:slug", context.Wrap(p.handlePageDetail))
@@ -986,12 +1157,18 @@ func New(config Config) *Panel {
 		apiGroup.Get("/resource/:resource", context.Wrap(p.handleResourceIndex))
 		apiGroup.Post("/resource/:resource", context.Wrap(p.handleResourceStore))
 		apiGroup.Post("/resource/:resource/reorder", context.Wrap(p.handleResourceReorder))
+		apiGroup.Options("/resource/:resource/uploads", context.Wrap(p.handleResumableUploadOptions)) // Resumable (tus) uploads
+		apiGroup.Post("/resource/:resource/uploads", context.Wrap(p.handleResumableUploadCreate))
+		apiGroup.Head("/resource/:resource/uploads/:upload", context.Wrap(p.handleResumableUploadHead))
+		apiGroup.Patch("/resource/:resource/uploads/:upload", context.Wrap(p.handleResumableUploadPatch))
+		apiGroup.Delete("/resource/:resource/uploads/:upload", context.Wrap(p.handleResumableUploadDelete))
 		apiGroup.Get("/resource/:resource/create", context.Wrap(p.handleResourceCreate)) // New Route
 		apiGroup.Get("/resource/:resource/:id", context.Wrap(p.handleResourceShow))
 		apiGroup.Get("/resource/:resource/:id/detail", context.Wrap(p.handleResourceDetail))
 		apiGroup.Get("/resource/:resource/:id/edit", context.Wrap(p.handleResourceEdit))
 		apiGroup.Post("/resource/:resource/:id/fields/:field/resolve", context.Wrap(p.handleFieldResolve))          // Field resolver endpoint
 		apiGroup.Post("/resource/:resource/fields/resolve-dependencies", context.Wrap(p.handleResolveDependencies)) // Dependency resolver endpoint
+		apiGroup.Get("/resource/:resource/fields/:field/suggestions", context.Wrap(p.handleFieldSuggestions))       // Field autocomplete suggestions
 
 		// Hover card resolver endpoints - Support GET, POST, PATCH, DELETE
 		apiGroup.Get("/resource/:resource/resolver/:field", context.Wrap(p.handleHoverCardResolve))    // Hover card resolver (GET)
@@ -1001,6 +1178,7 @@ func New(config Config) *Panel {
 		apiGroup.Put("/resource/:resource/:id", context.Wrap(p.handleResourceUpdate))
 		apiGroup.Delete("/resource/:resource/:id", context.Wrap(p.handleResourceDestroy))
 		apiGroup.Get("/navigation", context.Wrap(p.handleNavigation)) // Sidebar Navigation
+		apiGroup.Get("/search", context.Wrap(p.handleGlobalSearch))   // Global search across resources
 
 		// /resolve endpoint for dynamic routing check
 		apiGroup.Get("/resolve", context.Wrap(p.handleResolve))
@@ -1369,6 +1547,25 @@ func (p *Panel) closeBackgroundWorkers() {
 		if p.accountLockout != nil {
 			p.accountLockout.Close()
 		}
+		if p.sessionSweeper != nil {
+			p.sessionSweeper.Close()
+		}
+		if p.auditPruner != nil {
+			p.auditPruner.Close()
+		}
+		if p.fileJanitor != nil {
+			p.fileJanitor.Close()
+		}
+		if p.uploadSweeper != nil {
+			p.uploadSweeper.Close()
+		}
+		if p.auditCloser != nil {
+			_ = p.auditCloser.Close()
+		}
+		if p.counterStoreCloser != nil {
+			_ = p.counterStoreCloser.Close()
+		}
+		p.dbConns.close()
 	})
 }
 
@@ -1455,7 +1652,18 @@ func (p *Panel) withResourceHandler(c *context.Context, fn func(*handler.FieldHa
 			"error": "Resource not found",
 		})
 	}
+	if ok, err := authorizeAPIKeyScope(c, slug); !ok {
+		return err
+	}
 	h := handler.NewResourceHandler(p.Db, res, p.Config.Storage.Path, p.Config.Storage.URL)
+	h.AuditLogger = p.auditLogger
+	h.Disks = p.disks
+	if p.fileJanitor != nil {
+		h.FileTracker = p.fileJanitor
+	}
+	h.Uploads = p.uploads
+	h.SetFieldKeyring(p.fieldKeyring)
+	p.applyReadReplica(h.Provider)
 	h.ResolveResource = func(targetSlug string) resource.Resource {
 		target, ok := p.resolveResourceForRequest(c, targetSlug)
 		if !ok {
@@ -1808,6 +2016,23 @@ func (p *Panel) handleResolveDependencies(c *context.Context) error {
 	})
 }
 
+// / # handleFieldSuggestions Metodu
+// /
+// / Metin alanları için autocomplete önerilerini döndürür.
+// /
+// / ## HTTP Endpoint
+// / `GET /api/resource/:resource/fields/:field/suggestions?q=`
+// /
+// / ## Davranış
+// / 1. Kaynağı çözer ve ViewAny yetkisini kontrol eder
+// / 2. Alanın öneri callback'ini, sabit öneri listesini veya kolon önerilerini kullanır
+// / 3. MinCharsForSuggestions değerinden kısa sorgularda boş liste döner
+func (p *Panel) handleFieldSuggestions(c *context.Context) error {
+	return p.withResourceHandler(c, func(h *handler.FieldHandler) error {
+		return handler.HandleFieldSuggestions(h, c)
+	})
+}
+
 // / # handleResourceCards Metodu
 // /
 // / Kaynağın tüm kartlarını listeler. Kartlar, kaynağın özet görünümüdür.
@@ -1925,6 +2150,9 @@ func (p *Panel) withLensHandler(c *context.Context, fn func(*handler.FieldHandle
 			"error": "Resource not found",
 		})
 	}
+	if ok, err := authorizeAPIKeyScope(c, slug); !ok {
+		return err
+	}
 
 	// Find Lens
 	var targetLens resource.Lens
@@ -1943,6 +2171,8 @@ func (p *Panel) withLensHandler(c *context.Context, fn func(*handler.FieldHandle
 
 	// Create Handler for Lens
 	h := handler.NewLensHandler(p.Db, res, targetLens)
+	h.SetFieldKeyring(p.fieldKeyring)
+	p.applyReadReplica(h.Provider)
 	h.SetConcurrencyConfig(handler.ConcurrencyConfig{
 		EnablePipelineV2: p.Config.Concurrency.EnablePipelineV2,
 		FailFast:         p.Config.Concurrency.FailFast,
@@ -2136,17 +2366,7 @@ func (p *Panel) handleNavigation(c *context.Context) error {
 		URL         string `json:"url"`   // Full URL with language prefix
 	}
 
-	// Dil bilgisini al
-	lang := i18n.GetLocale(c.Ctx)
-	defaultLang := p.Config.I18n.DefaultLanguage.String()
-
-	// URL prefix'ini hesapla
-	urlPrefix := ""
-	if p.Config.I18n.Enabled && p.Config.I18n.UseURLPrefix {
-		if !p.Config.I18n.URLPrefixOptional || lang != defaultLang {
-			urlPrefix = "/" + lang
-		}
-	}
+	urlPrefix := p.navigationURLPrefix(c)
 
 	items := []NavItem{}
 	snapshot := p.loadRegistrySnapshot()
@@ -2204,6 +2424,20 @@ func (p *Panel) handleNavigation(c *context.Context) error {
 	})
 }
 
+// navigationURLPrefix, frontend URL'leri için dil prefix'ini hesaplar (örn: "/en").
+// URL prefix kapalıysa veya varsayılan dil için opsiyonelse boş string döner.
+func (p *Panel) navigationURLPrefix(c *context.Context) string {
+	if !p.Config.I18n.Enabled || !p.Config.I18n.UseURLPrefix {
+		return ""
+	}
+
+	lang := i18n.GetLocale(c.Ctx)
+	if p.Config.I18n.URLPrefixOptional && lang == p.Config.I18n.DefaultLanguage.String() {
+		return ""
+	}
+	return "/" + lang
+}
+
 // / # handleInit Metodu
 // /
 // / Uygulamanın başlatılması için gerekli bilgileri döndürür.
@@ -2310,6 +2544,7 @@ func (p *Panel) handleInit(c *context.Context) error {
 
 	injectionData := GetHTMLInjectionData(c.Ctx, p.Config)
 	initData := GetInitData(c.Ctx, p.Config, injectionData)
+	initData["impersonation"] = p.impersonationBanner(c)
 
 	return c.JSON(initData)
 }
@@ -0,0 +1,294 @@
+package panel
+
+import (
+	"bufio"
+	stdcontext "context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ferdiunal/panel.go/pkg/context"
+	"github.com/ferdiunal/panel.go/pkg/data/orm"
+	"github.com/ferdiunal/panel.go/pkg/domain/audit"
+	"github.com/ferdiunal/panel.go/pkg/domain/user"
+	"github.com/ferdiunal/panel.go/pkg/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+// DefaultAuditPruneInterval, saklama süresi dolan audit olaylarının silinme sıklığıdır.
+const DefaultAuditPruneInterval = time.Hour
+
+// auditExportColumns, CSV dışa aktarımının sütun sırasıdır. prev_hash ve hash
+// sütunları sayesinde dışa aktarılan dosyanın zinciri panel dışında da doğrulanabilir.
+var auditExportColumns = []string{
+	"id", "timestamp", "event_type", "user_id", "email", "ip", "user_agent",
+	"method", "path", "status_code", "success", "error_message",
+	"resource", "action", "metadata", "prev_hash", "hash",
+}
+
+// databaseAuditWriter, AsyncAuditLogger partilerini audit_events tablosuna yazar.
+type databaseAuditWriter struct {
+	repo *orm.AuditRepository
+}
+
+func (w *databaseAuditWriter) WriteAuditEvents(events []middleware.AuditEvent) error {
+	records := make([]audit.Event, 0, len(events))
+	for _, event := range events {
+		records = append(records, toAuditRecord(event))
+	}
+	return w.repo.Append(stdcontext.Background(), records)
+}
+
+// toAuditRecord, middleware olayını veritabanı kaydına dönüştürür. AuditMiddleware'in
+// Metadata["user"] olarak eklediği kullanıcı nesnesi UserID/Email alanlarına taşınır;
+// böylece olaylar kullanıcıya göre filtrelenebilir ve kullanıcı nesnesi saklanmaz.
+func toAuditRecord(event middleware.AuditEvent) audit.Event {
+	metadata := event.Metadata
+	if u, ok := metadata["user"].(*user.User); ok {
+		if event.UserID == "" && u != nil {
+			event.UserID = strconv.FormatUint(uint64(u.ID), 10)
+		}
+		if event.Email == "" && u != nil {
+			event.Email = u.Email
+		}
+		metadata = make(map[string]interface{}, len(event.Metadata))
+		for key, value := range event.Metadata {
+			if key != "user" {
+				metadata[key] = value
+			}
+		}
+	}
+
+	record := audit.Event{
+		Timestamp:    event.Timestamp,
+		EventType:    event.EventType,
+		UserID:       event.UserID,
+		Email:        event.Email,
+		IP:           event.IP,
+		UserAgent:    event.UserAgent,
+		Method:       event.Method,
+		Path:         event.Path,
+		StatusCode:   event.StatusCode,
+		Success:      event.Success,
+		ErrorMessage: event.ErrorMessage,
+		Resource:     event.Resource,
+		Action:       event.Action,
+	}
+	if record.Timestamp.IsZero() {
+		record.Timestamp = time.Now()
+	}
+	if len(metadata) > 0 {
+		if raw, err := json.Marshal(metadata); err == nil {
+			record.Metadata = string(raw)
+		}
+	}
+	return record
+}
+
+// auditPruner, Retention süresinden eski audit olaylarını arka planda periyodik olarak siler.
+type auditPruner struct {
+	repo      *orm.AuditRepository
+	retention time.Duration
+	interval  time.Duration
+	stopCh    chan struct{}
+	doneCh    chan struct{}
+	closeOnce sync.Once
+}
+
+func newAuditPruner(repo *orm.AuditRepository, retention, interval time.Duration) *auditPruner {
+	if interval <= 0 {
+		interval = DefaultAuditPruneInterval
+	}
+	pr := &auditPruner{
+		repo:      repo,
+		retention: retention,
+		interval:  interval,
+		stopCh:    make(chan struct{}),
+		doneCh:    make(chan struct{}),
+	}
+	go pr.run()
+	return pr
+}
+
+func (pr *auditPruner) run() {
+	defer close(pr.doneCh)
+
+	ticker := time.NewTicker(pr.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := pr.prune(time.Now()); err != nil {
+				log.Printf("[audit] retention prune failed: %v", err)
+			}
+		case <-pr.stopCh:
+			return
+		}
+	}
+}
+
+func (pr *auditPruner) prune(now time.Time) (int64, error) {
+	return pr.repo.DeleteBefore(stdcontext.Background(), now.Add(-pr.retention))
+}
+
+// Close, arka plandaki budama goroutine'ini durdurur ve çıkmasını bekler.
+// Birden fazla kez çağrılabilir; nil pruner için bir şey yapmaz.
+func (pr *auditPruner) Close() {
+	if pr == nil {
+		return
+	}
+	pr.closeOnce.Do(func() {
+		close(pr.stopCh)
+	})
+	<-pr.doneCh
+}
+
+// handleAuditExport, GET /api/audit-events/export isteğini işler.
+//
+// Sorgu parametreleri:
+//   - from, to: RFC3339 zaman veya 2006-01-02 tarih; tarih verilen "to" o günü kapsar
+//   - format: "jsonl" (varsayılan) veya "csv"
+//
+// Olaylar ID sırasıyla parça parça okunarak yanıta akıtılır.
+func (p *Panel) handleAuditExport(c *context.Context) error {
+	if err := requireAuditAdminSession(c); err != nil {
+		return err
+	}
+
+	from, err := parseAuditExportTime(c.Query("from"), false)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid from parameter"})
+	}
+	to, err := parseAuditExportTime(c.Query("to"), true)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid to parameter"})
+	}
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "from must be before to"})
+	}
+
+	format := strings.ToLower(c.Query("format", "jsonl"))
+	var contentType string
+	switch format {
+	case "jsonl":
+		contentType = "application/x-ndjson"
+	case "csv":
+		contentType = "text/csv; charset=utf-8"
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Unsupported format, use jsonl or csv"})
+	}
+
+	repo := orm.NewAuditRepository(p.Db)
+	filename := fmt.Sprintf("audit-events-%s.%s", time.Now().UTC().Format("20060102-150405"), format)
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	c.Ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		var err error
+		if format == "csv" {
+			err = writeAuditCSV(w, repo, from, to)
+		} else {
+			err = writeAuditJSONL(w, repo, from, to)
+		}
+		if err != nil {
+			log.Printf("[audit] export failed: %v", err)
+		}
+		_ = w.Flush()
+	})
+	return nil
+}
+
+func writeAuditJSONL(w *bufio.Writer, repo *orm.AuditRepository, from, to time.Time) error {
+	encoder := json.NewEncoder(w)
+	return repo.Each(stdcontext.Background(), from, to, func(events []audit.Event) error {
+		for _, event := range events {
+			if err := encoder.Encode(event); err != nil {
+				return err
+			}
+		}
+		return w.Flush()
+	})
+}
+
+func writeAuditCSV(w *bufio.Writer, repo *orm.AuditRepository, from, to time.Time) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(auditExportColumns); err != nil {
+		return err
+	}
+	err := repo.Each(stdcontext.Background(), from, to, func(events []audit.Event) error {
+		for _, e := range events {
+			row := []string{
+				strconv.FormatUint(uint64(e.ID), 10), e.Timestamp.UTC().Format(time.RFC3339Nano),
+				e.EventType, e.UserID, e.Email, e.IP, e.UserAgent,
+				e.Method, e.Path, strconv.Itoa(e.StatusCode), strconv.FormatBool(e.Success), e.ErrorMessage,
+				e.Resource, e.Action, e.Metadata, e.PrevHash, e.Hash,
+			}
+			if err := writer.Write(row); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	})
+	writer.Flush()
+	if err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+// parseAuditExportTime, boş değer için sıfır zaman döndürür. endOfDay true ise
+// yalnızca tarih içeren değer ertesi günün başlangıcına çevrilir (aralık o günü kapsar).
+func parseAuditExportTime(raw string, endOfDay bool) (time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", raw)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+// handleAuditVerify, GET /api/audit-events/verify isteğini işler ve hash
+// zincirinin bütünlüğünü raporlar.
+func (p *Panel) handleAuditVerify(c *context.Context) error {
+	if err := requireAuditAdminSession(c); err != nil {
+		return err
+	}
+
+	result, err := orm.NewAuditRepository(p.Db).Verify(c.Context())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to verify audit log",
+		})
+	}
+
+	response := fiber.Map{
+		"valid":   result.BrokenID == 0,
+		"checked": result.Checked,
+	}
+	if result.BrokenID != 0 {
+		response["broken_id"] = result.BrokenID
+		response["reason"] = result.Reason
+	}
+	return c.JSON(response)
+}
+
+// requireAuditAdminSession, audit dışa aktarma ve doğrulama uçlarını API key
+// yönetimiyle aynı kurala bağlar: oturumla giriş yapmış, impersonation dışı admin.
+func requireAuditAdminSession(c *context.Context) error {
+	return requireAdminSession(c, "Audit log access")
+}
@@ -0,0 +1,227 @@
+package panel
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	appConfig "github.com/ferdiunal/panel.go/pkg/config"
+	"github.com/ferdiunal/panel.go/pkg/data/orm"
+	"github.com/ferdiunal/panel.go/pkg/domain/audit"
+	"github.com/ferdiunal/panel.go/pkg/domain/user"
+	"github.com/ferdiunal/panel.go/pkg/middleware"
+)
+
+func setupDatabaseAuditPanel(t *testing.T) *Panel {
+	t.Helper()
+
+	security := appConfig.DevelopmentSecurityConfig()
+	security.Audit.Enabled = true
+	security.Audit.Destination = "database"
+	security.Audit.LogLevel = middleware.AuditLevelAll
+	security.Audit.Retention = 24 * time.Hour
+	return setupSecurityPanel(t, security)
+}
+
+// flushAudit waits until the events logged so far are written to the database.
+func flushAudit(t *testing.T, p *Panel) {
+	t.Helper()
+
+	logger, ok := p.auditCloser.(*middleware.AsyncAuditLogger)
+	if !ok {
+		t.Fatalf("expected async database audit logger, got %T", p.auditCloser)
+	}
+	logger.Flush()
+}
+
+func auditRequest(t *testing.T, p *Panel, path string, cookie *http.Cookie) *http.Response {
+	t.Helper()
+
+	req := httptest.NewRequest("GET", path, nil)
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	resp, err := testFiberRequest(p.Fiber, req)
+	if err != nil {
+		t.Fatalf("GET %s failed: %v", path, err)
+	}
+	return resp
+}
+
+func TestDatabaseAudit_PersistsAndListsEventsByUser(t *testing.T) {
+	p := setupDatabaseAuditPanel(t)
+	cookie := registerAndLoginTestUser(t, p, "auditor@example.com")
+	auditRequest(t, p, "/api/internal/auth/sessions", cookie).Body.Close()
+	flushAudit(t, p)
+
+	var admin user.User
+	if err := p.Db.Where("email = ?", "auditor@example.com").First(&admin).Error; err != nil {
+		t.Fatalf("failed to load user: %v", err)
+	}
+	userID := strconv.FormatUint(uint64(admin.ID), 10)
+
+	var total, owned int64
+	p.Db.Model(&audit.Event{}).Count(&total)
+	p.Db.Model(&audit.Event{}).Where("user_id = ?", userID).Count(&owned)
+	if total == 0 || owned == 0 {
+		t.Fatalf("expected persisted audit events for the user, total=%d owned=%d", total, owned)
+	}
+	var sample audit.Event
+	p.Db.Where("user_id = ?", userID).First(&sample)
+	if sample.Email != "auditor@example.com" || sample.Hash == "" {
+		t.Fatalf("expected email and hash on stored event, got %+v", sample)
+	}
+
+	resp := auditRequest(t, p, "/api/internal/resource/audit-events?audit-events[filters][user_id][eq]="+userID, cookie)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected audit resource index 200, got %d", resp.StatusCode)
+	}
+	payload := map[string]interface{}{}
+	_ = json.NewDecoder(resp.Body).Decode(&payload)
+	items, _ := payload["data"].([]interface{})
+	if int64(len(items)) != owned {
+		t.Fatalf("expected %d filtered audit events, got %d", owned, len(items))
+	}
+}
+
+func TestDatabaseAudit_ExportAndVerify(t *testing.T) {
+	p := setupDatabaseAuditPanel(t)
+	cookie := registerAndLoginTestUser(t, p, "export@example.com")
+	flushAudit(t, p)
+
+	var stored int64
+	p.Db.Model(&audit.Event{}).Count(&stored)
+	if stored == 0 {
+		t.Fatal("expected audit events before export")
+	}
+
+	day := time.Now().UTC().Format("2006-01-02")
+	resp := auditRequest(t, p, "/api/internal/audit-events/export?format=jsonl&from="+day+"&to="+day, cookie)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected jsonl export 200, got %d", resp.StatusCode)
+	}
+	if got := resp.Header.Get("Content-Disposition"); got == "" {
+		t.Fatal("expected attachment Content-Disposition")
+	}
+	var verifier audit.ChainVerifier
+	var exported int64
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		var event audit.Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("invalid jsonl line: %v", err)
+		}
+		if !verifier.Add(event) {
+			t.Fatalf("exported chain broken at %d: %s", verifier.BrokenID, verifier.Reason)
+		}
+		exported++
+	}
+	resp.Body.Close()
+	if exported != stored {
+		t.Fatalf("expected %d exported events, got %d", stored, exported)
+	}
+
+	// A range before any event is empty
+	resp = auditRequest(t, p, "/api/internal/audit-events/export?format=csv&to=2000-01-01", cookie)
+	rows, err := csv.NewReader(resp.Body).ReadAll()
+	resp.Body.Close()
+	if err != nil || len(rows) != 1 || rows[0][0] != "id" {
+		t.Fatalf("expected header-only csv export, got %v (err=%v)", rows, err)
+	}
+
+	resp = auditRequest(t, p, "/api/internal/audit-events/export?format=xml", cookie)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400 for unsupported format, got %d", resp.StatusCode)
+	}
+
+	resp = auditRequest(t, p, "/api/internal/audit-events/verify", cookie)
+	result := map[string]interface{}{}
+	_ = json.NewDecoder(resp.Body).Decode(&result)
+	resp.Body.Close()
+	if result["valid"] != true {
+		t.Fatalf("expected valid chain, got %v", result)
+	}
+
+	// Tampering with a stored row breaks the chain
+	var target audit.Event
+	p.Db.Order("id ASC").Offset(1).First(&target)
+	p.Db.Model(&audit.Event{}).Where("id = ?", target.ID).Update("path", "/tampered")
+
+	resp = auditRequest(t, p, "/api/internal/audit-events/verify", cookie)
+	result = map[string]interface{}{}
+	_ = json.NewDecoder(resp.Body).Decode(&result)
+	resp.Body.Close()
+	if result["valid"] != false || result["reason"] != "hash_mismatch" || result["broken_id"] != float64(target.ID) {
+		t.Fatalf("expected hash_mismatch at %d, got %v", target.ID, result)
+	}
+}
+
+func TestDatabaseAudit_NonAdminForbidden(t *testing.T) {
+	p := setupDatabaseAuditPanel(t)
+	cookie := registerAndLoginTestUser(t, p, "viewer@example.com")
+	p.Db.Model(&user.User{}).Where("email = ?", "viewer@example.com").Update("role", "user")
+
+	for _, path := range []string{
+		"/api/internal/audit-events/export",
+		"/api/internal/audit-events/verify",
+		"/api/internal/resource/audit-events",
+	} {
+		resp := auditRequest(t, p, path, cookie)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusForbidden {
+			t.Fatalf("expected 403 for %s, got %d", path, resp.StatusCode)
+		}
+	}
+}
+
+func TestAuditPruner_DeletesEventsOlderThanRetention(t *testing.T) {
+	p := setupDatabaseAuditPanel(t)
+	repo := orm.NewAuditRepository(p.Db)
+	now := time.Now()
+
+	events := []audit.Event{
+		{Timestamp: now.Add(-72 * time.Hour), EventType: "old"},
+		{Timestamp: now.Add(-48 * time.Hour), EventType: "old"},
+		{Timestamp: now.Add(-time.Hour), EventType: "recent"},
+	}
+	if err := repo.Append(t.Context(), events); err != nil {
+		t.Fatalf("append failed: %v", err)
+	}
+
+	if p.auditPruner == nil {
+		t.Fatal("expected audit pruner when Retention is set")
+	}
+	deleted, err := p.auditPruner.prune(now)
+	if err != nil || deleted != 2 {
+		t.Fatalf("expected 2 pruned events, got %d (err=%v)", deleted, err)
+	}
+
+	// The remaining chain still verifies from its first surviving event
+	result, err := repo.Verify(t.Context())
+	if err != nil || result.BrokenID != 0 || result.Checked != 1 {
+		t.Fatalf("expected intact chain after prune, got %+v (err=%v)", result, err)
+	}
+}
+
+func TestValidateSecurityConfig_DatabaseAudit(t *testing.T) {
+	security := appConfig.DevelopmentSecurityConfig()
+	security.Audit.Enabled = true
+	security.Audit.Destination = "database"
+	if err := validateSecurityConfig("development", security); err != nil {
+		t.Fatalf("expected database audit destination to be valid, got %v", err)
+	}
+
+	security.Audit.Retention = -time.Hour
+	if err := validateSecurityConfig("development", security); err == nil {
+		t.Fatal("expected negative retention to be rejected")
+	}
+}
@@ -0,0 +1,141 @@
+package panel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Commands, uygulamanın kendi CLI'ına eklenebilecek bakım komutlarını döndürür.
+//
+// Bu komutlar kayıtlı resource'lara ve veritabanı bağlantısına ihtiyaç duyduğu için
+// code generator olan `panel` binary'sinde değil, Panel örneğini oluşturan uygulamada
+// çalıştırılır.
+//
+// ## Kullanım
+//
+//	app := panel.New(cfg)
+//	rootCmd.AddCommand(app.Commands()...)
+//
+// ## Komutlar
+//   - fields:reencrypt: Şifreli alanları birincil anahtarla yeniden şifreler
+//   - storage:prune: Hiçbir kaydın kullanmadığı yüklenmiş dosyaları siler
+//   - media:regenerate: Resim alanlarının dönüşümlerini orijinallerden yeniden üretir
+func (p *Panel) Commands() []*cobra.Command {
+	return []*cobra.Command{
+		p.newFieldsReencryptCommand(),
+		p.newStoragePruneCommand(),
+		p.newMediaRegenerateCommand(),
+	}
+}
+
+// newFieldsReencryptCommand, fields:reencrypt komutunu oluşturur.
+func (p *Panel) newFieldsReencryptCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fields:reencrypt",
+		Short: "Şifreli alanları birincil anahtarla yeniden şifreler",
+		Long:  "Encrypted() alanlara sahip resource'lardaki eski anahtarla şifrelenmiş veya düz metin değerleri FieldEncryption.PrimaryKeyID anahtarıyla yeniden yazar.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resources, _ := cmd.Flags().GetStringSlice("resource")
+			results, err := p.ReencryptFields(cmd.Context(), resources...)
+			for _, result := range results {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s [%s]: %d scanned, %d updated\n",
+					result.Resource, strings.Join(result.Columns, ", "), result.Scanned, result.Updated)
+			}
+			if err != nil {
+				return err
+			}
+			if len(results) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No encrypted fields found")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceP("resource", "r", nil, "Sadece belirtilen resource slug'larını işle")
+	return cmd
+}
+
+// newStoragePruneCommand, storage:prune komutunu oluşturur.
+func (p *Panel) newStoragePruneCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "storage:prune",
+		Short: "Hiçbir kaydın kullanmadığı yüklenmiş dosyaları siler",
+		Long:  "Store(disk, klasör) ile diske yazan dosya alanlarının klasörlerini tarar ve soft delete edilmişler dahil hiçbir resource kaydının referans vermediği dosyaları siler.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			olderThan, _ := cmd.Flags().GetDuration("older-than")
+			results, err := p.PruneStorage(cmd.Context(), StoragePruneOptions{OlderThan: olderThan, DryRun: dryRun})
+			verb := "removed"
+			if dryRun {
+				verb = "would be removed"
+			}
+			for _, result := range results {
+				if result.Skipped != "" {
+					fmt.Fprintf(cmd.OutOrStdout(), "%s: skipped (%s)\n", result.Disk, result.Skipped)
+					continue
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "%s [%s]: %d scanned, %d %s\n",
+					result.Disk, strings.Join(result.Dirs, ", "), result.Scanned, len(result.Removed), verb)
+				if dryRun {
+					for _, path := range result.Removed {
+						fmt.Fprintf(cmd.OutOrStdout(), "  %s\n", path)
+					}
+				}
+			}
+			if err != nil {
+				return err
+			}
+			if len(results) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No file fields with a storage folder found")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("dry-run", false, "Dosyaları silmeden yalnızca listele")
+	cmd.Flags().Duration("older-than", DefaultStoragePruneAge, "Bu süreden yeni dosyalara dokunma")
+	return cmd
+}
+
+// newMediaRegenerateCommand, media:regenerate komutunu oluşturur.
+func (p *Panel) newMediaRegenerateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "media:regenerate",
+		Short: "Resim alanlarının dönüşümlerini orijinallerden yeniden üretir",
+		Long:  "Conversions() tanımlı resim alanlarına yüklenmiş orijinallerden küçük resim, önizleme ve biçim dönüşümlerini yeniden üretir ve orijinalin yanına yazar.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resources, _ := cmd.Flags().GetStringSlice("resource")
+			conversions, _ := cmd.Flags().GetStringSlice("conversion")
+			onlyMissing, _ := cmd.Flags().GetBool("only-missing")
+			results, err := p.RegenerateMedia(cmd.Context(), MediaRegenerateOptions{
+				Resources:   resources,
+				Conversions: conversions,
+				OnlyMissing: onlyMissing,
+			})
+			for _, result := range results {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s [%s]: %d scanned, %d generated, %d failed\n",
+					result.Resource, strings.Join(result.Fields, ", "), result.Scanned, result.Generated, len(result.Failed))
+				for _, path := range result.Failed {
+					fmt.Fprintf(cmd.OutOrStdout(), "  failed: %s\n", path)
+				}
+			}
+			if err != nil {
+				return err
+			}
+			if len(results) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No image fields with conversions found")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceP("resource", "r", nil, "Sadece belirtilen resource slug'larını işle")
+	cmd.Flags().StringSlice("conversion", nil, "Sadece belirtilen dönüşümleri üret")
+	cmd.Flags().Bool("only-missing", false, "Diskte bulunan dönüşümleri atla")
+	return cmd
+}
@@ -3,6 +3,8 @@ package panel
 import (
 	"time"
 
+	appConfig "github.com/ferdiunal/panel.go/pkg/config"
+	"github.com/ferdiunal/panel.go/pkg/mail"
 	"github.com/ferdiunal/panel.go/pkg/page"
 	"github.com/ferdiunal/panel.go/pkg/resource"
 	"github.com/gofiber/fiber/v2/middleware/encryptcookie"
@@ -464,6 +466,41 @@ type Config struct {
 	/// Plugins, plugin sistemi yapılandırmasını tutar
 	/// Plugin'lerin otomatik keşfi ve yüklenmesi için kullanılır
 	Plugins PluginConfig
+
+	/// GlobalSearch, tüm resource'lar üzerinde arama yapan /api/search endpoint'ini yapılandırır
+	GlobalSearch GlobalSearchConfig
+
+	/// FieldEncryption, Encrypted() ile işaretlenen alanların şifreleme anahtarlarını tutar
+	FieldEncryption FieldEncryptionConfig
+
+	/// TwoFactor, TOTP tabanlı iki faktörlü doğrulama ayarlarını tutar.
+	/// TOTP secret'ları FieldEncryption anahtarlarıyla şifrelenir; anahtar yoksa kayıt yapılamaz.
+	TwoFactor TwoFactorConfig
+
+	/// Mail, panelin gönderdiği e-postalar (doğrulama vb.) için gönderici ayarlarını tutar.
+	/// Sender, SMTP veya FileDir'den ilk tanımlı olan kullanılır.
+	Mail MailConfig
+
+	/// EmailVerification, kayıt sonrası e-posta doğrulama akışını ve doğrulanmamış
+	/// kullanıcılara uygulanacak kısıtlamayı yapılandırır.
+	EmailVerification EmailVerificationConfig
+
+	/// PasswordReset, POST /auth/forgot-password ile e-postayla gönderilen sıfırlama
+	/// bağlantısını ve POST /auth/reset-password ile yeni şifrenin belirlenmesini yapılandırır.
+	PasswordReset PasswordResetConfig
+
+	/// Password, kayıt, PUT /auth/password ile şifre değişikliği ve yöneticinin kullanıcı
+	/// formundan yaptığı şifre sıfırlamalarında uygulanan şifre politikasını tanımlar
+	/// (uzunluk, karakter sınıfları, azami yaş, şifre geçmişi ve sızıntı listesi).
+	Password PasswordConfig
+
+	/// Security, CORS, rate limit, hesap kilitleme, oturum cookie'si, şifreleme ve
+	/// audit log ayarlarını pkg/config.SecurityConfig üzerinden tek yerden yapılandırır.
+	/// nil ise önceki varsayılanlar kullanılır (CORS alanı, 5 deneme/15 dk kilitleme,
+	/// 7 günlük oturum, konsol audit log, rate limit kapalı).
+	/// Çelişkili ayarlar panel.New sırasında panic ile raporlanır.
+	/// Örnek: sec := config.ProductionSecurityConfig(); cfg.Security = &sec
+	Security *appConfig.SecurityConfig
 }
 
 // / # SettingsConfig - Dinamik Ayarlar Yapılandırması
@@ -571,6 +608,119 @@ type StorageConfig struct {
 	/// Örnek: "/uploads" veya "https://cdn.example.com/uploads"
 	/// Tarayıcıda erişim: https://example.com/uploads/filename.jpg
 	URL string
+
+	/// Default, Store("", "klasör") ile disk adı vermeyen alanların kullandığı disktir.
+	/// Varsayılan: "public" (Path/URL ile tanımlanan yerel disk)
+	Default string
+
+	/// Disks, isimlendirilmiş depolama diskleridir. Alanlar Store("s3", "products")
+	/// ile hangi diske ve klasöre yazılacağını seçer. "public" adlı disk tanımlanmazsa
+	/// Path/URL ile yerel bir disk oluşturulur.
+	/// Örnek: map[string]DiskConfig{"s3": {Driver: "s3", Bucket: "media", Region: "eu-central-1"}}
+	Disks map[string]DiskConfig
+
+	/// SigningKey, yerel ve bellek disklerinin süreli (imzalı) URL'leri için HMAC anahtarıdır.
+	/// Boşsa her başlangıçta rastgele üretilir; bu durumda URL'ler yeniden başlatma
+	/// sonrasında ve replikalar arasında geçersiz olur.
+	SigningKey string
+
+	/// OrphanGracePeriod, değiştirilen veya silinen kayıtlardan ayrılan dosyaların
+	/// diskten silinmeden önce bekletildiği süredir.
+	/// 0: işlem tamamlanınca hemen silinir, negatif: otomatik silme kapalı (yalnızca storage:prune)
+	/// Örnek: 24 * time.Hour
+	OrphanGracePeriod time.Duration
+
+	/// Uploads, Video/Audio gibi büyük dosyaların parça parça (tus) yüklenmesinin ayarlarıdır.
+	Uploads UploadConfig
+}
+
+// / # UploadConfig - Devam Ettirilebilir Yükleme Yapılandırması
+// /
+// / /api/internal/resource/:resource/uploads tus endpoint'inin ayarlarını tutar.
+// / Parçalar geçici klasörde birleştirilir; tamamlanan yükleme form gönderiminde
+// / dosya yerine {"upload_id": "..."} ile kullanılır.
+// /
+// / ## Örnek Kullanım
+// / ```yaml
+// / storage:
+// /   uploads:
+// /     temp_dir: /var/lib/panel/uploads
+// /     max_size: 10737418240
+// /     expiration: 12h
+// / ```
+// /
+// / ## Önemli Notlar
+// / - Geçici klasör her sunucuda ayrıdır; birden fazla replika varsa paylaşılan bir
+// /   volume veya sticky session kullanılmalıdır
+// / - Parça boyutu sunucunun istek gövdesi sınırının altında tutulmalıdır
+type UploadConfig struct {
+	/// TempDir, parçaların biriktirildiği klasördür.
+	/// Varsayılan: işletim sisteminin geçici klasöründe "panel-uploads"
+	TempDir string
+
+	/// MaxSize, kabul edilen en büyük yükleme boyutudur (bayt). Alanın MaxSize
+	/// değeri daha küçükse o uygulanır.
+	/// Varsayılan: 2 GB (handler.DefaultUploadMaxSize)
+	MaxSize int64
+
+	/// Expiration, son parçadan sonra yarım kalan veya kullanılmayan yüklemelerin
+	/// silinmesine kadar geçen süredir. Varsayılan: 24 saat
+	Expiration time.Duration
+}
+
+// / # DiskConfig - Depolama Diski Yapılandırması
+// /
+// / Tek bir depolama diskinin sürücüsünü ve ayarlarını tutar.
+// /
+// / ## Sürücüler
+// / - `local`: Root dizinine yazar; URL verilirse dosyalar bu adresten sunulur
+// / - `s3`: S3 uyumlu object storage (AWS S3, MinIO, R2, Spaces)
+// / - `memory`: Bellek içi disk (testler ve geçici kurulumlar)
+// /
+// / ## Örnek Kullanım
+// / ```yaml
+// / storage:
+// /   disks:
+// /     private:
+// /       driver: local
+// /       root: ./storage/private
+// /     s3:
+// /       driver: s3
+// /       endpoint: http://localhost:9000
+// /       bucket: panel
+// /       access_key: ${env:S3_ACCESS_KEY}
+// /       secret_key: ${env:S3_SECRET_KEY}
+// /       path_style: true
+// / ```
+// /
+// / ## Önemli Notlar
+// / - URL'i olmayan diskler özeldir; dosyalara yalnızca süreli URL ile erişilir
+// / - Göreli URL'e ("/media") sahip yerel diskler panel tarafından statik olarak sunulur
+type DiskConfig struct {
+	/// Driver, disk sürücüsüdür: "local", "s3" veya "memory"
+	Driver string
+
+	/// Root, yerel diskin dizini veya S3 anahtarlarının önüne eklenen klasördür
+	Root string
+
+	/// URL, dosyaların herkese açık temel adresidir (örn: "/media", "https://cdn.example.com")
+	URL string
+
+	/// Endpoint, S3 uyumlu servisin adresidir. Boşsa AWS S3 kullanılır.
+	Endpoint string
+
+	/// Region, S3 bölgesidir. Varsayılan: "us-east-1"
+	Region string
+
+	/// Bucket, S3 bucket adıdır (s3 sürücüsü için zorunlu)
+	Bucket string
+
+	/// AccessKey ve SecretKey, S3 kimlik bilgileridir
+	AccessKey string
+	SecretKey string
+
+	/// PathStyle, bucket'ı yolda kullanır (MinIO ve çoğu self-hosted servis için gerekli)
+	PathStyle bool
 }
 
 // / # PermissionConfig - İzin Yönetimi Yapılandırması
@@ -774,8 +924,9 @@ type ShardingConfig struct {
 // /
 // / ## Önemli Notlar
 // / - DSN formatı veritabanı türüne göre değişir
-// / - Instance nil ise DSN ve Driver kullanılır
-// / - Bağlantı havuzu ayarları DSN'de yapılabilir
+// / - Instance nil ise panel, DSN ve Driver ile bağlantıyı kendisi açar
+// / - Havuz ayarları (MaxOpenConns, MaxIdleConns, ConnMaxLifetime, ConnMaxIdleTime)
+// /   Instance verildiğinde de uygulanır
 // / - Zaman dilimi ayarlarını DSN'ye ekleyin
 // / - Sharding etkinleştirildiğinde performans ve migration stratejisi değişir
 // /
@@ -806,6 +957,33 @@ type DatabaseConfig struct {
 	/// Büyük tablolar için horizontal partitioning desteği sağlar.
 	/// Kullanım: Yüksek trafikli uygulamalarda performans optimizasyonu
 	Sharding ShardingConfig
+
+	/// ReadReplicaDSN, okuma sorguları için replica bağlantı dizesidir (Opsiyonel).
+	/// Ayarlanırsa resource Index/Show sorguları replica'dan okunur,
+	/// yazma işlemleri ve transaction'lar primary üzerinde kalır.
+	/// Driver ile aynı veritabanı türünde olmalıdır.
+	ReadReplicaDSN string
+
+	/// MaxOpenConns, havuzdaki en fazla açık bağlantı sayısıdır (0: sınırsız).
+	MaxOpenConns int
+
+	/// MaxIdleConns, havuzda bekletilecek en fazla boşta bağlantı sayısıdır
+	/// (0: database/sql varsayılanı).
+	MaxIdleConns int
+
+	/// ConnMaxLifetime, bir bağlantının yeniden kullanılabileceği en uzun süredir (0: sınırsız).
+	ConnMaxLifetime time.Duration
+
+	/// ConnMaxIdleTime, bir bağlantının boşta kalabileceği en uzun süredir (0: sınırsız).
+	ConnMaxIdleTime time.Duration
+
+	/// SlowQueryThreshold, bu süreyi aşan sorguların uyarı olarak loglanma eşiğidir.
+	/// 0 ise panelin açtığı bağlantılarda 200ms kullanılır.
+	SlowQueryThreshold time.Duration
+
+	/// LogLevel, GORM log seviyesidir.
+	/// Değerler: "silent", "error", "warn" (varsayılan), "info"
+	LogLevel string
 }
 
 // / # CircuitBreakerConfig - Circuit Breaker Yapılandırması
@@ -1021,3 +1199,169 @@ type PluginConfig struct {
 	/// UYARI: AutoDiscover true olmalıdır
 	Path string
 }
+
+// GlobalSearchConfig, global arama endpoint'ini (/api/search) yapılandırır.
+// Varsayılanlar:
+// - Timeout: 3s (tüm resource'larda yapılan aramanın toplam süresi)
+// - MaxLimit: 20 (?limit= ile istenebilecek resource başına üst sınır)
+// - MinQueryLength: 2
+// - Workers (0): min(2*NumCPU, 16) olarak otomatik ayarlanır
+type GlobalSearchConfig struct {
+	// Disabled, global arama endpoint'ini kapatır.
+	Disabled bool
+
+	// Timeout, tüm resource'larda arama için toplam süre bütçesidir.
+	Timeout time.Duration
+
+	// MaxLimit, istemcinin isteyebileceği resource başına sonuç sayısını sınırlar.
+	MaxLimit int
+
+	// MinQueryLength, aramayı tetikleyen kırpılmış sorgunun asgari uzunluğudur.
+	MinQueryLength int
+
+	// Workers, aynı anda kaç resource'ta arama yapılacağını belirler.
+	Workers int
+}
+
+// FieldEncryptionConfig configures at-rest encryption for fields marked with Encrypted().
+//
+// Values are encrypted with AES-GCM and tagged with the key ID that produced them,
+// so old keys can stay in Keys while new writes use PrimaryKeyID. After rotating
+// the primary key, run the fields:reencrypt command (see Panel.Commands) to rewrite
+// existing rows with the new key.
+type FieldEncryptionConfig struct {
+	// Keys maps key IDs to hex-encoded AES keys (16, 24 or 32 bytes).
+	// Generate a key with: openssl rand -hex 32
+	Keys map[string]string
+
+	// PrimaryKeyID selects the key used for new writes.
+	// Optional when Keys contains a single key.
+	PrimaryKeyID string
+}
+
+// MailConfig, panelin e-posta gönderim ayarlarıdır.
+//
+// İlk tanımlı seçenek kullanılır: Sender, ardından SMTP.Host, ardından FileDir.
+// FileDir her mesajı göndermek yerine .eml dosyası olarak yazar; geliştirme ve
+// testler içindir.
+type MailConfig struct {
+	// From, gönderen adresidir (örn: "Panel <no-reply@example.com>").
+	From string
+
+	// Sender, yerleşik göndericilerin yerine kullanılır (SES, Postmark, kuyruk, ...).
+	Sender mail.Sender
+
+	// SMTP, e-postaları SMTP sunucusu üzerinden gönderir (destekleniyorsa STARTTLS).
+	SMTP SMTPConfig
+
+	// FileDir, mesajları göndermek yerine bu dizine yazar.
+	FileDir string
+}
+
+// SMTPConfig, SMTP sunucusu bağlantı bilgileridir.
+type SMTPConfig struct {
+	Host     string
+	Port     int // varsayılan 587
+	Username string
+	Password string
+}
+
+// EmailVerificationConfig, e-posta doğrulama akışının ayarlarıdır.
+//
+// Etkinleştirildiğinde kayıt sonrası GET /auth/verify-email adresine tek
+// kullanımlık bağlantı gönderilir. Kullanıcılar POST /auth/verify-email/resend
+// ile yeni bağlantı isteyebilir; yöneticiler users kaynağından kullanıcıyı
+// doğrulanmış işaretleyebilir veya bağlantıyı yeniden gönderebilir.
+type EmailVerificationConfig struct {
+	// Enabled, kayıt sonrası doğrulama e-postası gönderir. Mail ve VerifyURL gerektirir.
+	Enabled bool
+
+	// Enforcement, doğrulanmamış kullanıcılara uygulanacak kısıtlamadır: ""
+	// (kısıtlama yok), "login" (giriş reddedilir) veya "restrict" (giriş
+	// yapılabilir, oturum gerektiren API istekleri doğrulanana kadar 403 döner).
+	Enforcement string
+
+	// TokenLifetime, bağlantının geçerlilik süresidir. Varsayılan 24 saat.
+	TokenLifetime time.Duration
+
+	// ResendInterval, aynı kullanıcıya iki e-posta arasında geçmesi gereken en
+	// kısa süredir. Varsayılan 1 dakika.
+	ResendInterval time.Duration
+
+	// VerifyURL, bağlantılarda kullanılan doğrulama endpoint'inin genel
+	// adresidir (örn: "https://admin.example.com/api/internal/auth/verify-email").
+	// Enabled true ise zorunludur; token içeren bağlantılar istek başlıklarından
+	// üretilmez.
+	VerifyURL string
+
+	// RedirectURL, bağlantı açıldıktan sonra tarayıcının yönlendirileceği
+	// adrestir; sonuç ?verified=1 veya ?error=invalid_token olarak eklenir.
+	// Boşsa JSON döner.
+	RedirectURL string
+}
+
+// PasswordResetConfig, şifre sıfırlama akışının ayarlarıdır.
+//
+// Etkinleştirildiğinde POST /auth/forgot-password, hesap varsa ResetURL'e tek
+// kullanımlık bir bağlantı gönderir. ResetURL sayfası token'ı ve yeni şifreyi
+// POST /auth/reset-password'e iletir; yeni şifre Password politikasına ve şifre
+// geçmişine göre doğrulanır ve kullanıcının tüm oturumları sonlandırılır.
+// Kapalıyken her iki endpoint de 404 döner.
+type PasswordResetConfig struct {
+	// Enabled, şifre sıfırlama e-postalarını açar. Mail ve ResetURL gerektirir.
+	Enabled bool
+
+	// TokenLifetime, bağlantının geçerlilik süresidir. Varsayılan 1 saat.
+	TokenLifetime time.Duration
+
+	// ResendInterval, aynı kullanıcıya iki e-posta arasında geçmesi gereken en
+	// kısa süredir. Varsayılan 1 dakika.
+	ResendInterval time.Duration
+
+	// ResetURL, bağlantılarda kullanılan ve yeni şifrenin girildiği sayfanın genel
+	// adresidir (örn: "https://admin.example.com/reset-password"); token ?token=
+	// olarak eklenir. Enabled true ise zorunludur.
+	ResetURL string
+}
+
+// PasswordConfig, kayıt, şifre değişikliği, şifre sıfırlama ve yönetici
+// sıfırlamalarında uygulanan şifre politikasıdır. İhlaller 422 alan hatası olarak döner.
+type PasswordConfig struct {
+	// MinLength, en az karakter sayısıdır. Varsayılan 8.
+	MinLength int
+
+	// RequireUppercase, RequireLowercase, RequireDigit ve RequireSymbol, etkin olan
+	// her sınıftan en az bir karakter ister.
+	RequireUppercase bool
+	RequireLowercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+
+	// MaxAge, şifre bu süreden eskiyse değiştirilmesini zorunlu kılar. Değiştirilene
+	// kadar API 403 ve "password_expired" kodu döner. Sıfır süre sınırını kapatır.
+	MaxAge time.Duration
+
+	// HistorySize, mevcut şifre dahil kullanıcının son HistorySize şifresinden biriyle
+	// eşleşen yeni şifreyi reddeder. Sıfır kontrolü kapatır.
+	HistorySize int
+
+	// BreachedHashesPath, Have I Been Pwned şifre listesinin yerel kopyasını gösterir:
+	// SHA-1 ön ekiyle adlandırılmış k-anonymity aralık dosyalarından oluşan bir dizin
+	// (SUFFIX:COUNT satırlı "21BD1.txt") ya da sıralı tek bir HASH:COUNT dosyası.
+	// Listede bulunan şifreler reddedilir; ağ üzerinden hiçbir şey gönderilmez. Boşsa kapalıdır.
+	BreachedHashesPath string
+}
+
+// TwoFactorConfig, TOTP tabanlı iki faktörlü doğrulama ayarlarıdır.
+//
+// Kullanıcılar /auth/two-factor/enroll ile kayıt olur ve doğrulayıcı uygulamadaki
+// kodla onaylar. Etkinleştirildikten sonra giriş bir challenge token döner; oturum
+// açılmadan önce bu token /auth/sign-in/two-factor üzerinden tamamlanmalıdır.
+type TwoFactorConfig struct {
+	// Issuer, doğrulayıcı uygulamalarda görünen etikettir. Varsayılan: "Panel.go".
+	Issuer string
+
+	// RequiredRoles, 2FA'yı etkinleştirmesi zorunlu olan rolleri listeler. Bu rollerdeki
+	// kullanıcılar kayıt onaylanana kadar yalnızca /auth/two-factor endpoint'lerine erişebilir.
+	RequiredRoles []string
+}
@@ -0,0 +1,945 @@
+package panel
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	appConfig "github.com/ferdiunal/panel.go/pkg/config"
+	"github.com/ferdiunal/panel.go/shared/encrypt"
+	"github.com/gofiber/fiber/v2/middleware/encryptcookie"
+	"github.com/pelletier/go-toml/v2"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ConfigEnvPrefix, yapılandırma dosyasındaki anahtarları ezen environment değişkenlerinin önekidir.
+	// Anahtar yolu büyük harfe çevrilip segmentleri "_" ile birleştirilerek değişken adına dönüşür:
+	// server.port -> PANEL_SERVER_PORT, security.rate_limit.enabled -> PANEL_SECURITY_RATE_LIMIT_ENABLED.
+	ConfigEnvPrefix = "PANEL_"
+
+	// ConfigPathEnv, LoadConfig boş path ile çağrıldığında yapılandırma dosyasını
+	// gösteren environment değişkeninin adıdır.
+	ConfigPathEnv = "PANEL_CONFIG"
+
+	// redactedConfigValue, RenderConfig çıktısında secret değerlerin yerine yazılır.
+	redactedConfigValue = "******"
+)
+
+// defaultConfigFiles, path verilmediğinde sırayla denenen dosyalardır.
+var defaultConfigFiles = []string{"panel.yaml", "panel.yml", "panel.toml"}
+
+// configSecretRef, değerin tamamını kaplayan secret referansını eşler: ${env:NAME} veya ${file:/path}.
+var configSecretRef = regexp.MustCompile(`^\$\{(env|file):([^}]+)\}$`)
+
+// configSecretKeys, değerleri RenderConfig tarafından maskelenen anahtar yollarını listeler.
+var configSecretKeys = map[string]bool{
+	"database.dsn":                     true,
+	"database.read_replica_dsn":        true,
+	"oauth.google.client_secret":       true,
+	"api_key.keys":                     true,
+	"rest_api.keys":                    true,
+	"external_api.keys":                true,
+	"cookie_encryption_key":            true,
+	"field_encryption.keys":            true,
+	"security.encryption.key_hex":      true,
+	"security.audit.siem_endpoint":     true,
+	"security.counter_store.redis_url": true,
+	"mail.smtp.password":               true,
+	"storage.signing_key":              true,
+	"storage.disks.*.secret_key":       true,
+}
+
+// configFile, panel.yaml / panel.toml dosyasının diskteki şeklidir.
+//
+// Anahtarlar, `config` tag'i ile ezilmedikçe Go alan adlarının snake_case halidir.
+type configFile struct {
+	Environment         string
+	Server              ServerConfig
+	Database            configFileDatabase
+	Storage             StorageConfig
+	Permissions         PermissionConfig
+	I18n                I18nConfig
+	Features            FeatureConfig
+	OAuth               OAuthConfig `config:"oauth"`
+	CORS                CORSConfig
+	APIKey              APIKeyConfig
+	RESTAPI             RESTAPIConfig `config:"rest_api"`
+	ExternalAPI         ExternalAPIConfig
+	CookieEncryptionKey string
+	FieldEncryption     FieldEncryptionConfig
+	TwoFactor           TwoFactorConfig
+	Mail                configFileMail
+	EmailVerification   EmailVerificationConfig
+	PasswordReset       PasswordResetConfig
+	Password            PasswordConfig
+	Security            *configFileSecurity
+}
+
+type configFileMail struct {
+	From    string
+	SMTP    SMTPConfig
+	FileDir string
+}
+
+type configFileDatabase struct {
+	Driver             string
+	DSN                string `config:"dsn"`
+	ReadReplicaDSN     string `config:"read_replica_dsn"`
+	MaxOpenConns       int
+	MaxIdleConns       int
+	ConnMaxLifetime    time.Duration
+	ConnMaxIdleTime    time.Duration
+	SlowQueryThreshold time.Duration
+	LogLevel           string
+}
+
+// configFileSecurity, adı verilen preset'ten başlar; dosyadaki anahtarlar preset değerlerini ezer.
+type configFileSecurity struct {
+	Preset string
+	appConfig.SecurityConfig
+}
+
+// loadedConfig, çözümlenen dosyayı uygulanan override'larla birlikte tutar.
+type loadedConfig struct {
+	path         string
+	file         configFile
+	envOverrides []string
+}
+
+// / # LoadConfig Fonksiyonu
+// /
+// / YAML veya TOML yapılandırma dosyasını okuyup panel.Config üretir.
+// /
+// / ## Yükleme Sırası
+// / 1. Dosya okunur (`.yaml`, `.yml` veya `.toml`; path boşsa PANEL_CONFIG, ardından
+// /    çalışma dizinindeki panel.yaml / panel.yml / panel.toml denenir)
+// / 2. `PANEL_*` environment değişkenleri dosyadaki değerleri ezer
+// /    (örn: `PANEL_SERVER_PORT`, `PANEL_SECURITY_RATE_LIMIT_ENABLED`)
+// / 3. `${env:NAME}` ve `${file:/path}` secret referansları çözülür
+// / 4. Değerler doğrulanır; hatalar sorunlu anahtarı içerir (örn: `server.port: ...`)
+// /
+// / ## Örnek
+// / ```go
+// / cfg, err := panel.LoadConfig("panel.yaml")
+// / if err != nil {
+// /     log.Fatal(err)
+// / }
+// / cfg.Pages = []page.Page{pages.NewDashboard()}
+// / app := panel.New(cfg)
+// / ```
+// /
+// / ## Önemli Notlar
+// / - Veritabanı için DSN ve Driver doldurulur; Instance ayarlanmaz
+// / - `security` bölümü varsa `preset` (default, development, production) üzerine uygulanır
+// / - Pages, Resources gibi Go değerleri dosyadan okunamaz, koddan eklenmelidir
+func LoadConfig(path string) (Config, error) {
+	loaded, err := loadConfigFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+	return loaded.file.toConfig(), nil
+}
+
+// / # RenderConfig Fonksiyonu
+// /
+// / Etkin yapılandırmayı (dosya + environment override'ları) secret değerleri
+// / maskelenmiş olarak `yaml` veya `toml` formatında döndürür. `panel config:show`
+// / komutu bu fonksiyonu kullanır.
+func RenderConfig(path, format string) ([]byte, error) {
+	loaded, err := loadConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	values := encodeConfigValue("", reflect.ValueOf(loaded.file)).(map[string]interface{})
+
+	var header bytes.Buffer
+	fmt.Fprintf(&header, "# source: %s\n", loaded.path)
+	if len(loaded.envOverrides) > 0 {
+		fmt.Fprintf(&header, "# environment overrides: %s\n", strings.Join(loaded.envOverrides, ", "))
+	}
+
+	var body []byte
+	switch strings.ToLower(format) {
+	case "", "yaml", "yml":
+		body, err = yaml.Marshal(values)
+	case "toml":
+		body, err = toml.Marshal(values)
+	default:
+		return nil, fmt.Errorf("unsupported output format %q (use yaml or toml)", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return append(header.Bytes(), body...), nil
+}
+
+// / # ResolveConfigPath Fonksiyonu
+// /
+// / LoadConfig'in verilen path için okuyacağı yapılandırma dosyasını döndürür.
+// / Path boşsa önce PANEL_CONFIG, ardından çalışma dizinindeki panel.yaml /
+// / panel.yml / panel.toml denenir; hiçbiri yoksa hata döner.
+func ResolveConfigPath(path string) (string, error) {
+	if path = strings.TrimSpace(path); path != "" {
+		return path, nil
+	}
+	if path = strings.TrimSpace(os.Getenv(ConfigPathEnv)); path != "" {
+		return path, nil
+	}
+	for _, candidate := range defaultConfigFiles {
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("no config file found (tried %s; set %s or pass a path)", strings.Join(defaultConfigFiles, ", "), ConfigPathEnv)
+}
+
+func loadConfigFile(path string) (*loadedConfig, error) {
+	path, err := ResolveConfigPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	raw := make(map[string]interface{})
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &raw)
+	case ".toml":
+		err = toml.Unmarshal(content, &raw)
+	default:
+		return nil, fmt.Errorf("%s: unsupported config format (use .yaml, .yml or .toml)", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if raw == nil {
+		raw = make(map[string]interface{})
+	}
+
+	loaded := &loadedConfig{path: path}
+	loaded.envOverrides = applyConfigEnvOverrides(raw, reflect.TypeOf(configFile{}), "")
+
+	var errs []error
+	if section, ok := raw["security"]; ok && section != nil {
+		preset := ""
+		if values, ok := section.(map[string]interface{}); ok {
+			if value, ok := values["preset"].(string); ok {
+				preset = value
+			}
+		}
+		base, err := securityPreset(preset)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("security.preset: %w", err))
+		}
+		loaded.file.Security = &configFileSecurity{Preset: preset, SecurityConfig: base}
+	}
+
+	errs = append(errs, decodeConfigValue("", raw, reflect.ValueOf(&loaded.file).Elem())...)
+	if loaded.file.Environment == "" {
+		loaded.file.Environment = "development"
+	}
+	if len(errs) == 0 {
+		errs = loaded.file.validate()
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("%s: invalid config:\n%w", path, errors.Join(errs...))
+	}
+
+	return loaded, nil
+}
+
+func securityPreset(name string) (appConfig.SecurityConfig, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "default":
+		return appConfig.DefaultSecurityConfig(), nil
+	case "production":
+		return appConfig.ProductionSecurityConfig(), nil
+	case "development":
+		return appConfig.DevelopmentSecurityConfig(), nil
+	default:
+		return appConfig.DefaultSecurityConfig(), fmt.Errorf("unknown preset %q (use default, development or production)", name)
+	}
+}
+
+// validate, sorunsuz çözümlenen ancak birlikte kullanılamayan değerleri denetler.
+func (f *configFile) validate() []error {
+	var errs []error
+	fail := func(key, format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+	}
+
+	switch f.Environment {
+	case "development", "production", "test":
+	default:
+		fail("environment", "must be one of development, production, test (got %q)", f.Environment)
+	}
+
+	if f.Server.Port != "" {
+		if port, err := strconv.Atoi(f.Server.Port); err != nil || port < 1 || port > 65535 {
+			fail("server.port", "must be a number between 1 and 65535 (got %q)", f.Server.Port)
+		}
+	}
+
+	switch f.Database.Driver {
+	case "", "sqlite", "postgres", "mysql":
+	default:
+		fail("database.driver", "must be one of sqlite, postgres, mysql (got %q)", f.Database.Driver)
+	}
+	if f.Database.Driver != "" && f.Database.DSN == "" {
+		fail("database.dsn", "is required when database.driver is set")
+	}
+	if f.Database.DSN != "" && f.Database.Driver == "" {
+		fail("database.driver", "is required when database.dsn is set")
+	}
+	if f.Database.ReadReplicaDSN != "" && f.Database.Driver == "" {
+		fail("database.driver", "is required when database.read_replica_dsn is set")
+	}
+	if f.Database.MaxOpenConns < 0 {
+		fail("database.max_open_conns", "cannot be negative")
+	}
+	if f.Database.MaxIdleConns < 0 {
+		fail("database.max_idle_conns", "cannot be negative")
+	}
+	if f.Database.MaxOpenConns > 0 && f.Database.MaxIdleConns > f.Database.MaxOpenConns {
+		fail("database.max_idle_conns", "cannot exceed database.max_open_conns (%d > %d)", f.Database.MaxIdleConns, f.Database.MaxOpenConns)
+	}
+	if _, ok := databaseLogLevels[strings.ToLower(f.Database.LogLevel)]; !ok && f.Database.LogLevel != "" {
+		fail("database.log_level", "must be one of silent, error, warn, info (got %q)", f.Database.LogLevel)
+	}
+
+	switch f.I18n.FormatBundleFile {
+	case "", "yaml", "json", "toml":
+	default:
+		fail("i18n.format_bundle_file", "must be one of yaml, json, toml (got %q)", f.I18n.FormatBundleFile)
+	}
+
+	if f.CookieEncryptionKey != "" {
+		key, err := base64.StdEncoding.DecodeString(f.CookieEncryptionKey)
+		if err != nil || (len(key) != 16 && len(key) != 24 && len(key) != 32) {
+			fail("cookie_encryption_key", "must be a base64 encoded 16, 24 or 32 byte key (openssl rand -base64 32)")
+		}
+	}
+
+	if len(f.FieldEncryption.Keys) > 0 {
+		if _, err := encrypt.NewKeyringFromHex(f.FieldEncryption.PrimaryKeyID, f.FieldEncryption.Keys); err != nil {
+			fail("field_encryption.keys", "%v", err)
+		}
+	}
+
+	if err := validateEmailVerificationConfig(f.EmailVerification, f.toConfig().Mail); err != nil {
+		for _, line := range strings.Split(err.Error(), "\n") {
+			errs = append(errs, errors.New(line))
+		}
+	}
+
+	if err := validatePasswordResetConfig(f.PasswordReset, f.toConfig().Mail); err != nil {
+		for _, line := range strings.Split(err.Error(), "\n") {
+			errs = append(errs, errors.New(line))
+		}
+	}
+
+	if err := validatePasswordConfig(f.Password); err != nil {
+		for _, line := range strings.Split(err.Error(), "\n") {
+			errs = append(errs, errors.New(line))
+		}
+	}
+
+	if err := validateStorageConfig(f.Storage); err != nil {
+		for _, line := range strings.Split(err.Error(), "\n") {
+			errs = append(errs, errors.New(line))
+		}
+	}
+
+	if f.Security != nil {
+		cfg := f.toConfig()
+		if err := validateSecurityConfig(cfg.Environment, resolveSecurityConfig(cfg)); err != nil {
+			for _, line := range strings.Split(err.Error(), "\n") {
+				errs = append(errs, fmt.Errorf("security.%s", line))
+			}
+		}
+	}
+
+	return errs
+}
+
+func (f *configFile) toConfig() Config {
+	cfg := Config{
+		Environment: f.Environment,
+		Server:      f.Server,
+		Database: DatabaseConfig{
+			Driver:             f.Database.Driver,
+			DSN:                f.Database.DSN,
+			ReadReplicaDSN:     f.Database.ReadReplicaDSN,
+			MaxOpenConns:       f.Database.MaxOpenConns,
+			MaxIdleConns:       f.Database.MaxIdleConns,
+			ConnMaxLifetime:    f.Database.ConnMaxLifetime,
+			ConnMaxIdleTime:    f.Database.ConnMaxIdleTime,
+			SlowQueryThreshold: f.Database.SlowQueryThreshold,
+			LogLevel:           f.Database.LogLevel,
+		},
+		Storage:         f.Storage,
+		Permissions:     f.Permissions,
+		I18n:            f.I18n,
+		Features:        f.Features,
+		OAuth:           f.OAuth,
+		CORS:            f.CORS,
+		APIKey:          f.APIKey,
+		RESTAPI:         f.RESTAPI,
+		ExternalAPI:     f.ExternalAPI,
+		FieldEncryption: f.FieldEncryption,
+		TwoFactor:       f.TwoFactor,
+		Mail: MailConfig{
+			From:    f.Mail.From,
+			SMTP:    f.Mail.SMTP,
+			FileDir: f.Mail.FileDir,
+		},
+		EmailVerification: f.EmailVerification,
+		PasswordReset:     f.PasswordReset,
+		Password:          f.Password,
+	}
+	if f.CookieEncryptionKey != "" {
+		cfg.EncryptionCookie = encryptcookie.Config{
+			Key:    f.CookieEncryptionKey,
+			Except: []string{"csrf_token"},
+		}
+	}
+	if f.Security != nil {
+		security := f.Security.SecurityConfig
+		cfg.Security = &security
+	}
+	return cfg
+}
+
+var (
+	durationType    = reflect.TypeOf(time.Duration(0))
+	languageTagType = reflect.TypeOf(language.Tag{})
+)
+
+// configFieldKey, struct alanının dosyadaki anahtarını döner (gömülü struct'lar için "").
+func configFieldKey(field reflect.StructField) string {
+	if tag := field.Tag.Get("config"); tag != "" {
+		return tag
+	}
+	if field.Anonymous {
+		return ""
+	}
+	return toSnakeCase(field.Name)
+}
+
+// toSnakeCase, Go alan adlarını kısaltmaları bölmeden snake_case'e çevirir:
+// AllowedOrigins -> allowed_origins, HTTPOnly -> http_only, SIEMEndpoint -> siem_endpoint.
+func toSnakeCase(name string) string {
+	runes := []rune(name)
+	var out strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prevLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (unicode.IsUpper(runes[i-1]) && nextLower) {
+				out.WriteByte('_')
+			}
+		}
+		out.WriteRune(unicode.ToLower(r))
+	}
+	return out.String()
+}
+
+func joinConfigKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	if key == "" {
+		return prefix
+	}
+	return prefix + "." + key
+}
+
+func isConfigLeaf(t reflect.Type) bool {
+	return t == durationType || t == languageTagType || t.Kind() != reflect.Struct
+}
+
+// isConfigStructMap, storage.disks.<name> gibi isimli bölümleri (string anahtarlı
+// config struct map'leri) tanır. Anahtar yollarında girdi adı yerine "*" kullanılır.
+func isConfigStructMap(t reflect.Type) bool {
+	return t.Kind() == reflect.Map && t.Key().Kind() == reflect.String && t.Elem().Kind() == reflect.Struct
+}
+
+// applyConfigEnvOverrides, şemayı dolaşıp PANEL_* değişkenlerini raw'a kopyalar.
+// Uygulanan değişkenlerin adlarını döner.
+func applyConfigEnvOverrides(raw map[string]interface{}, t reflect.Type, prefix string) []string {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var applied []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		key := configFieldKey(field)
+		path := joinConfigKey(prefix, key)
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		if !isConfigLeaf(fieldType) {
+			applied = append(applied, applyConfigEnvOverrides(raw, fieldType, path)...)
+			continue
+		}
+		// Girdi adları önceden bilinmez; bunun yerine dosyada ${env:NAME} referansı kullanılmalı.
+		if isConfigStructMap(fieldType) {
+			continue
+		}
+
+		name := ConfigEnvPrefix + strings.ToUpper(strings.ReplaceAll(path, ".", "_"))
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		setConfigPath(raw, strings.Split(path, "."), value)
+		applied = append(applied, name)
+	}
+	return applied
+}
+
+func setConfigPath(raw map[string]interface{}, path []string, value interface{}) {
+	for _, segment := range path[:len(path)-1] {
+		next, ok := raw[segment].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			raw[segment] = next
+		}
+		raw = next
+	}
+	raw[path[len(path)-1]] = value
+}
+
+// decodeConfigValue, raw'ı dst'ye atar; hataları anahtar yoluyla birlikte raporlar.
+func decodeConfigValue(path string, raw interface{}, dst reflect.Value) []error {
+	if raw == nil {
+		return nil
+	}
+
+	if dst.Kind() == reflect.Ptr {
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return decodeConfigValue(path, raw, dst.Elem())
+	}
+
+	if !isConfigLeaf(dst.Type()) {
+		values, ok := raw.(map[string]interface{})
+		if !ok {
+			return []error{fmt.Errorf("%s: expected a table/mapping, got %T", path, raw)}
+		}
+		return decodeConfigStruct(path, values, dst)
+	}
+
+	if isConfigStructMap(dst.Type()) {
+		entries, ok := raw.(map[string]interface{})
+		if !ok {
+			return []error{fmt.Errorf("%s: expected a table/mapping, got %T", path, raw)}
+		}
+		result := reflect.MakeMapWithSize(dst.Type(), len(entries))
+		var errs []error
+		for name, entry := range entries {
+			values, ok := entry.(map[string]interface{})
+			if !ok {
+				errs = append(errs, fmt.Errorf("%s: expected a table/mapping, got %T", joinConfigKey(path, name), entry))
+				continue
+			}
+			item := reflect.New(dst.Type().Elem()).Elem()
+			errs = append(errs, decodeConfigStruct(joinConfigKey(path, name), values, item)...)
+			result.SetMapIndex(reflect.ValueOf(name), item)
+		}
+		dst.Set(result)
+		return errs
+	}
+
+	if err := decodeConfigLeaf(path, raw, dst); err != nil {
+		return []error{fmt.Errorf("%s: %w", path, err)}
+	}
+	return nil
+}
+
+func decodeConfigStruct(path string, values map[string]interface{}, dst reflect.Value) []error {
+	fields := make(map[string]reflect.Value)
+	collectConfigFields(dst, fields)
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	for _, key := range keys {
+		target, ok := fields[key]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: unknown key", joinConfigKey(path, key)))
+			continue
+		}
+		errs = append(errs, decodeConfigValue(joinConfigKey(path, key), values[key], target)...)
+	}
+	return errs
+}
+
+func collectConfigFields(v reflect.Value, fields map[string]reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if key := configFieldKey(field); key != "" {
+			fields[key] = v.Field(i)
+			continue
+		}
+		collectConfigFields(v.Field(i), fields)
+	}
+}
+
+func decodeConfigLeaf(path string, raw interface{}, dst reflect.Value) error {
+	switch dst.Type() {
+	case durationType:
+		text, ok := raw.(string)
+		if !ok {
+			return fmt.Errorf("expected a duration string such as \"15m\", got %v", raw)
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(text))
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", text)
+		}
+		dst.SetInt(int64(d))
+		return nil
+	case languageTagType:
+		tag, err := parseConfigLanguage(raw)
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(tag))
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.String:
+		text, err := configScalarString(raw)
+		if err != nil {
+			return err
+		}
+		text, err = resolveConfigSecret(text)
+		if err != nil {
+			return err
+		}
+		dst.SetString(text)
+	case reflect.Bool:
+		switch v := raw.(type) {
+		case bool:
+			dst.SetBool(v)
+		case string:
+			b, err := strconv.ParseBool(strings.TrimSpace(v))
+			if err != nil {
+				return fmt.Errorf("expected true or false, got %q", v)
+			}
+			dst.SetBool(b)
+		default:
+			return fmt.Errorf("expected true or false, got %v", raw)
+		}
+	case reflect.Int, reflect.Int64:
+		n, err := configInt(raw)
+		if err != nil {
+			return err
+		}
+		dst.SetInt(n)
+	case reflect.Slice:
+		items, err := configList(raw)
+		if err != nil {
+			return err
+		}
+		slice := reflect.MakeSlice(dst.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := decodeConfigLeaf(path, item, slice.Index(i)); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+		}
+		dst.Set(slice)
+	case reflect.Map:
+		if dst.Type().Key().Kind() != reflect.String || dst.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported map type %s", dst.Type())
+		}
+		entries, err := configStringMap(raw)
+		if err != nil {
+			return err
+		}
+		result := reflect.MakeMapWithSize(dst.Type(), len(entries))
+		for key, value := range entries {
+			resolved, err := resolveConfigSecret(value)
+			if err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+			result.SetMapIndex(reflect.ValueOf(key), reflect.ValueOf(resolved))
+		}
+		dst.Set(result)
+	default:
+		return fmt.Errorf("unsupported type %s", dst.Type())
+	}
+	return nil
+}
+
+func configScalarString(raw interface{}) (string, error) {
+	switch v := raw.(type) {
+	case string:
+		return v, nil
+	case int, int64, uint64, float64, bool:
+		return fmt.Sprint(v), nil
+	default:
+		return "", fmt.Errorf("expected a string, got %T", raw)
+	}
+}
+
+func configInt(raw interface{}) (int64, error) {
+	switch v := raw.(type) {
+	case int:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case uint64:
+		return int64(v), nil
+	case float64:
+		if v != float64(int64(v)) {
+			return 0, fmt.Errorf("expected an integer, got %v", v)
+		}
+		return int64(v), nil
+	case string:
+		n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("expected an integer, got %q", v)
+		}
+		return n, nil
+	default:
+		return 0, fmt.Errorf("expected an integer, got %T", raw)
+	}
+}
+
+// configList, liste veya environment override'ları için virgülle ayrılmış string kabul eder.
+func configList(raw interface{}) ([]interface{}, error) {
+	switch v := raw.(type) {
+	case []interface{}:
+		return v, nil
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return nil, nil
+		}
+		parts := strings.Split(v, ",")
+		items := make([]interface{}, 0, len(parts))
+		for _, part := range parts {
+			items = append(items, strings.TrimSpace(part))
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("expected a list, got %T", raw)
+	}
+}
+
+// configStringMap, map veya environment override'ları için "key=value,key2=value2" kabul eder.
+func configStringMap(raw interface{}) (map[string]string, error) {
+	entries := make(map[string]string)
+	switch v := raw.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			text, err := configScalarString(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			entries[key] = text
+		}
+	case string:
+		for _, pair := range strings.Split(v, ",") {
+			if strings.TrimSpace(pair) == "" {
+				continue
+			}
+			key, value, ok := strings.Cut(pair, "=")
+			if !ok {
+				return nil, fmt.Errorf("expected key=value pairs, got %q", pair)
+			}
+			entries[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	default:
+		return nil, fmt.Errorf("expected a mapping, got %T", raw)
+	}
+	return entries, nil
+}
+
+func parseConfigLanguage(raw interface{}) (language.Tag, error) {
+	text, ok := raw.(string)
+	if !ok {
+		return language.Und, fmt.Errorf("expected a language tag such as \"tr\", got %v", raw)
+	}
+	tag, err := language.Parse(strings.TrimSpace(text))
+	if err != nil {
+		return language.Und, fmt.Errorf("invalid language tag %q", text)
+	}
+	return tag, nil
+}
+
+// resolveConfigSecret, ${env:NAME} ve ${file:/path} referanslarını değerleriyle değiştirir.
+func resolveConfigSecret(value string) (string, error) {
+	match := configSecretRef.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return value, nil
+	}
+
+	source, ref := match[1], strings.TrimSpace(match[2])
+	switch source {
+	case "env":
+		resolved, ok := os.LookupEnv(ref)
+		if !ok {
+			return "", fmt.Errorf("secret reference: environment variable %s is not set", ref)
+		}
+		return resolved, nil
+	default:
+		content, err := os.ReadFile(ref)
+		if err != nil {
+			return "", fmt.Errorf("secret reference: %w", err)
+		}
+		return strings.TrimRight(string(content), "\r\n"), nil
+	}
+}
+
+// encodeConfigValue, çözümlenen yapılandırmayı RenderConfig için düz değerlere çevirir;
+// configSecretKeys'teki anahtarları maskeler.
+func encodeConfigValue(path string, v reflect.Value) interface{} {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	if configSecretKeys[path] {
+		return redactConfigValue(path, v)
+	}
+
+	switch {
+	case v.Type() == durationType:
+		return time.Duration(v.Int()).String()
+	case v.Type() == languageTagType:
+		return v.Interface().(language.Tag).String()
+	case v.Kind() == reflect.Struct:
+		values := make(map[string]interface{})
+		encodeConfigStruct(path, v, values)
+		return values
+	case v.Kind() == reflect.Slice:
+		items := make([]interface{}, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			items = append(items, encodeConfigValue(path, v.Index(i)))
+		}
+		return items
+	case isConfigStructMap(v.Type()):
+		values := make(map[string]interface{}, v.Len())
+		for _, key := range v.MapKeys() {
+			entry := make(map[string]interface{})
+			encodeConfigStruct(joinConfigKey(path, "*"), v.MapIndex(key), entry)
+			values[key.String()] = entry
+		}
+		return values
+	case v.Kind() == reflect.Map:
+		values := make(map[string]interface{}, v.Len())
+		for _, key := range v.MapKeys() {
+			values[key.String()] = v.MapIndex(key).Interface()
+		}
+		return values
+	default:
+		return v.Interface()
+	}
+}
+
+func encodeConfigStruct(path string, v reflect.Value, values map[string]interface{}) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		key := configFieldKey(field)
+		if key == "" {
+			encodeConfigStruct(path, v.Field(i), values)
+			continue
+		}
+		if encoded := encodeConfigValue(joinConfigKey(path, key), v.Field(i)); encoded != nil {
+			values[key] = encoded
+		}
+	}
+}
+
+func redactConfigValue(path string, v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.String:
+		if v.String() == "" {
+			return ""
+		}
+		if path == "database.dsn" || path == "database.read_replica_dsn" {
+			return redactDSN(v.String())
+		}
+		return redactedConfigValue
+	case reflect.Slice:
+		items := make([]interface{}, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			items = append(items, redactedConfigValue)
+		}
+		return items
+	case reflect.Map:
+		values := make(map[string]interface{}, v.Len())
+		for _, key := range v.MapKeys() {
+			values[key.String()] = redactedConfigValue
+		}
+		return values
+	default:
+		return redactedConfigValue
+	}
+}
+
+var dsnPasswordPattern = regexp.MustCompile(`(?i)(password=)(\S+)`)
+
+// redactDSN, URL, MySQL ve key=value biçimli DSN'lerde parolayı gizler; host ve
+// veritabanı adı görünür kalır.
+func redactDSN(dsn string) string {
+	if parsed, err := url.Parse(dsn); err == nil && parsed.User != nil {
+		if _, hasPassword := parsed.User.Password(); hasPassword {
+			parsed.User = url.UserPassword(parsed.User.Username(), "xxxxx")
+			return strings.Replace(parsed.String(), "xxxxx", redactedConfigValue, 1)
+		}
+		return dsn
+	}
+	if dsnPasswordPattern.MatchString(dsn) {
+		return dsnPasswordPattern.ReplaceAllString(dsn, "${1}"+redactedConfigValue)
+	}
+	// MySQL: user:password@tcp(host:port)/db
+	if at := strings.Index(dsn, "@"); at > 0 {
+		if colon := strings.Index(dsn[:at], ":"); colon >= 0 {
+			return dsn[:colon+1] + redactedConfigValue + dsn[at:]
+		}
+	}
+	return dsn
+}
@@ -0,0 +1,317 @@
+package panel
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	return path
+}
+
+func TestLoadConfig_YAMLWithEnvOverridesAndSecrets(t *testing.T) {
+	secretPath := filepath.Join(t.TempDir(), "api-key")
+	if err := os.WriteFile(secretPath, []byte("file-secret\n"), 0o600); err != nil {
+		t.Fatalf("failed to write secret file: %v", err)
+	}
+
+	path := writeConfigFile(t, "panel.yaml", `
+environment: production
+server:
+  host: 0.0.0.0
+  port: 8080
+database:
+  driver: postgres
+  dsn: ${env:TEST_PANEL_DSN}
+storage:
+  path: ./storage/public
+  url: /storage
+i18n:
+  enabled: true
+  default_language: tr
+  accept_languages: [tr, en]
+features:
+  register: true
+api_key:
+  enabled: true
+  keys:
+    - ${file:`+secretPath+`}
+security:
+  preset: production
+  cors:
+    allowed_origins: [https://admin.example.com]
+  rate_limit:
+    auth_window: 30s
+  audit:
+    destination: console
+`)
+	t.Setenv("TEST_PANEL_DSN", "postgres://panel:s3cret@db:5432/panel")
+	t.Setenv("PANEL_SERVER_PORT", "9090")
+	t.Setenv("PANEL_SECURITY_RATE_LIMIT_AUTH_MAX_REQUESTS", "3")
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if cfg.Environment != "production" || cfg.Server.Host != "0.0.0.0" || cfg.Server.Port != "9090" {
+		t.Fatalf("unexpected server/environment: %q %+v", cfg.Environment, cfg.Server)
+	}
+	if cfg.Database.Driver != "postgres" || cfg.Database.DSN != "postgres://panel:s3cret@db:5432/panel" {
+		t.Fatalf("expected DSN from env secret, got %+v", cfg.Database)
+	}
+	if len(cfg.APIKey.Keys) != 1 || cfg.APIKey.Keys[0] != "file-secret" {
+		t.Fatalf("expected API key from file secret, got %+v", cfg.APIKey.Keys)
+	}
+	if cfg.I18n.DefaultLanguage != language.Turkish || len(cfg.I18n.AcceptLanguages) != 2 || cfg.I18n.AcceptLanguages[1] != language.English {
+		t.Fatalf("unexpected i18n config: %+v", cfg.I18n)
+	}
+	if cfg.Security == nil {
+		t.Fatal("expected security config")
+	}
+	if cfg.Security.RateLimit.AuthMaxRequests != 3 || cfg.Security.RateLimit.AuthWindow != 30*time.Second {
+		t.Fatalf("expected env and file overrides on rate limit, got %+v", cfg.Security.RateLimit)
+	}
+	if cfg.Security.RateLimit.APIMaxRequests != 100 || !cfg.Security.Session.Secure {
+		t.Fatalf("expected production preset defaults to be kept, got %+v", cfg.Security)
+	}
+	if cfg.Security.Audit.Destination != "console" {
+		t.Fatalf("expected audit destination override, got %q", cfg.Security.Audit.Destination)
+	}
+}
+
+func TestLoadConfig_TOML(t *testing.T) {
+	path := writeConfigFile(t, "panel.toml", `
+environment = "development"
+cookie_encryption_key = "MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTIzNDU2Nzg5MDE="
+
+[server]
+port = "3000"
+
+[database]
+driver = "sqlite"
+dsn = "panel.db"
+
+[field_encryption]
+primary_key_id = "2025"
+
+[field_encryption.keys]
+2025 = "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+`)
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Server.Port != "3000" || cfg.Database.DSN != "panel.db" || cfg.Security != nil {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+	if cfg.EncryptionCookie.Key == "" || len(cfg.EncryptionCookie.Except) != 1 {
+		t.Fatalf("expected cookie encryption config, got %+v", cfg.EncryptionCookie)
+	}
+	if cfg.FieldEncryption.PrimaryKeyID != "2025" || len(cfg.FieldEncryption.Keys) != 1 {
+		t.Fatalf("unexpected field encryption config: %+v", cfg.FieldEncryption)
+	}
+}
+
+func TestLoadConfig_ErrorsNameOffendingKey(t *testing.T) {
+	path := writeConfigFile(t, "panel.yaml", `
+environment: staging
+server:
+  prot: 8080
+  port: http
+database:
+  driver: oracle
+  dsn: ${env:TEST_PANEL_MISSING_DSN}
+i18n:
+  accept_languages: [tr, "not a language"]
+security:
+  session:
+    same_site: sometimes
+  rate_limit:
+    api_window: 10
+`)
+
+	_, err := LoadConfig(path)
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+
+	for _, want := range []string{
+		"server.prot: unknown key",
+		"database.dsn: secret reference: environment variable TEST_PANEL_MISSING_DSN is not set",
+		"i18n.accept_languages: [1]: invalid language tag",
+		"security.rate_limit.api_window: expected a duration string",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected error to contain %q, got:\n%v", want, err)
+		}
+	}
+
+	path = writeConfigFile(t, "panel.yaml", `
+environment: staging
+server:
+  port: http
+database:
+  driver: oracle
+  dsn: oracle://db
+security:
+  session:
+    same_site: sometimes
+`)
+	_, err = LoadConfig(path)
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	for _, want := range []string{
+		`environment: must be one of development, production, test (got "staging")`,
+		`server.port: must be a number between 1 and 65535 (got "http")`,
+		`database.driver: must be one of sqlite, postgres, mysql (got "oracle")`,
+		`security.session: unsupported SameSite value "sometimes"`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected error to contain %q, got:\n%v", want, err)
+		}
+	}
+}
+
+func TestRenderConfig_RedactsSecrets(t *testing.T) {
+	path := writeConfigFile(t, "panel.yaml", `
+database:
+  driver: mysql
+  dsn: panel:hunter2@tcp(localhost:3306)/panel
+api_key:
+  keys: [plain-api-key]
+oauth:
+  google:
+    client_id: google-client
+    client_secret: google-secret
+security:
+  preset: development
+  encryption:
+    key_hex: 000102030405060708090a0b0c0d0e0f
+`)
+	t.Setenv("PANEL_SERVER_HOST", "127.0.0.1")
+
+	out, err := RenderConfig(path, "yaml")
+	if err != nil {
+		t.Fatalf("RenderConfig failed: %v", err)
+	}
+	rendered := string(out)
+
+	for _, secret := range []string{"hunter2", "plain-api-key", "google-secret", "000102030405060708090a0b0c0d0e0f"} {
+		if strings.Contains(rendered, secret) {
+			t.Fatalf("expected %q to be redacted:\n%s", secret, rendered)
+		}
+	}
+	for _, want := range []string{
+		"panel:******@tcp(localhost:3306)/panel",
+		"client_id: google-client",
+		"# environment overrides: PANEL_SERVER_HOST",
+		"host: 127.0.0.1",
+		"auth_window: 1m0s",
+	} {
+		if !strings.Contains(rendered, want) {
+			t.Fatalf("expected rendered config to contain %q:\n%s", want, rendered)
+		}
+	}
+
+	if _, err := RenderConfig(path, "toml"); err != nil {
+		t.Fatalf("RenderConfig toml failed: %v", err)
+	}
+}
+
+func TestLoadConfig_StorageDisks(t *testing.T) {
+	path := writeConfigFile(t, "panel.yaml", `
+storage:
+  default: s3
+  signing_key: ${env:TEST_PANEL_SIGNING_KEY}
+  disks:
+    private:
+      driver: local
+      root: ./storage/private
+    s3:
+      driver: s3
+      endpoint: http://localhost:9000
+      bucket: panel
+      access_key: minio
+      secret_key: ${env:TEST_PANEL_S3_SECRET}
+      path_style: true
+`)
+	t.Setenv("TEST_PANEL_SIGNING_KEY", "signing-secret")
+	t.Setenv("TEST_PANEL_S3_SECRET", "s3-secret")
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	s3 := cfg.Storage.Disks["s3"]
+	if cfg.Storage.Default != "s3" || s3.Driver != "s3" || s3.SecretKey != "s3-secret" || !s3.PathStyle {
+		t.Fatalf("unexpected storage config: %+v", cfg.Storage)
+	}
+	if cfg.Storage.Disks["private"].Root != "./storage/private" {
+		t.Fatalf("expected private disk root, got %+v", cfg.Storage.Disks["private"])
+	}
+
+	out, err := RenderConfig(path, "yaml")
+	if err != nil {
+		t.Fatalf("RenderConfig failed: %v", err)
+	}
+	for _, secret := range []string{"s3-secret", "signing-secret"} {
+		if strings.Contains(string(out), secret) {
+			t.Fatalf("expected %q to be redacted:\n%s", secret, out)
+		}
+	}
+	if !strings.Contains(string(out), "bucket: panel") {
+		t.Fatalf("expected disk settings in rendered config:\n%s", out)
+	}
+
+	path = writeConfigFile(t, "panel.yaml", `
+storage:
+  disks:
+    s3:
+      driver: s3
+      bukcet: panel
+`)
+	if _, err = LoadConfig(path); err == nil || !strings.Contains(err.Error(), "storage.disks.s3.bukcet: unknown key") {
+		t.Fatalf("expected unknown disk key error, got:\n%v", err)
+	}
+
+	path = writeConfigFile(t, "panel.yaml", `
+storage:
+  disks:
+    s3:
+      driver: s3
+`)
+	if _, err = LoadConfig(path); err == nil || !strings.Contains(err.Error(), "storage.disks.s3.bucket: is required") {
+		t.Fatalf("expected missing bucket error, got:\n%v", err)
+	}
+}
+
+func TestToSnakeCase(t *testing.T) {
+	cases := map[string]string{
+		"AllowedOrigins":  "allowed_origins",
+		"HTTPOnly":        "http_only",
+		"SIEMEndpoint":    "siem_endpoint",
+		"KeyHex":          "key_hex",
+		"APIKey":          "api_key",
+		"I18n":            "i18n",
+		"AuthMaxRequests": "auth_max_requests",
+	}
+	for input, want := range cases {
+		if got := toSnakeCase(input); got != want {
+			t.Fatalf("toSnakeCase(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
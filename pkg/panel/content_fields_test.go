@@ -0,0 +1,235 @@
+package panel
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/ferdiunal/panel.go/pkg/fields"
+)
+
+type contentArticle struct {
+	ID       uint           `json:"id" gorm:"primaryKey"`
+	Title    string         `json:"title"`
+	Slug     string         `json:"slug" gorm:"uniqueIndex"`
+	Settings string         `json:"settings"`
+	Tags     fields.TagList `json:"tags" gorm:"type:text"`
+	Body     string         `json:"body"`
+}
+
+func contentArticleFields() []fields.Element {
+	return []fields.Element{
+		fields.ID(),
+		fields.Text("Title", "title"),
+		fields.Slug("Slug", "slug").From("title"),
+		fields.JSON("Settings", "settings"),
+		fields.Tags("Tags", "tags").MaxTags(3),
+		fields.Markdown("Body", "body"),
+	}
+}
+
+type contentPost struct {
+	ID    uint         `json:"id" gorm:"primaryKey"`
+	Title string       `json:"title"`
+	Tags  []contentTag `json:"tags" gorm:"many2many:content_post_tags"`
+}
+
+type contentTag struct {
+	ID   uint   `gorm:"primaryKey"`
+	Name string `gorm:"uniqueIndex"`
+}
+
+func contentPostFields() []fields.Element {
+	return []fields.Element{
+		fields.ID(),
+		fields.Text("Title", "title"),
+		fields.Tags("Tags", "tags").AsRelation(),
+	}
+}
+
+func setupContentFieldsPanel(t *testing.T) (*Panel, *http.Cookie) {
+	t.Helper()
+	p := setupStoragePanel(t)
+	migrateTestModels(t, p, &contentTag{})
+	registerTestResource(t, p, &contentArticle{}, "content-articles", contentArticleFields)
+	registerTestResource(t, p, &contentPost{}, "content-posts", contentPostFields)
+	return p, registerAndLoginTestUser(t, p, "content@example.com")
+}
+
+func TestContentFields_PersistAndGenerateSlugs(t *testing.T) {
+	p, cookie := setupContentFieldsPanel(t)
+
+	for i := 0; i < 2; i++ {
+		status := testJSONRequest(t, p, cookie, "POST", "/api/internal/resource/content-articles", map[string]interface{}{
+			"title":    "Merhaba Dünya",
+			"settings": map[string]interface{}{"featured": true},
+			"tags":     []interface{}{"go", " Go ", "gorm"},
+			"body":     "# Hello",
+		}, nil).StatusCode
+		if status != http.StatusCreated {
+			t.Fatalf("expected article to be created, got %d", status)
+		}
+	}
+
+	var articles []contentArticle
+	p.Db.Order("id").Find(&articles)
+	if len(articles) != 2 || articles[0].Slug != "merhaba-dunya" || articles[1].Slug != "merhaba-dunya-2" {
+		t.Fatalf("expected unique generated slugs, got %+v", articles)
+	}
+	if articles[0].Settings != `{"featured":true}` || len(articles[0].Tags) != 2 || articles[0].Tags[1] != "gorm" {
+		t.Fatalf("expected compact JSON and normalized tags, got %+v", articles[0])
+	}
+
+	// Slug gönderilmediğinde başlık değişse de korunur
+	status := testJSONRequest(t, p, cookie, "PUT", fmt.Sprintf("/api/internal/resource/content-articles/%d", articles[0].ID), map[string]interface{}{
+		"title": "Yeni Başlık",
+	}, nil).StatusCode
+	if status != http.StatusOK {
+		t.Fatalf("expected update to succeed, got %d", status)
+	}
+	var updated contentArticle
+	p.Db.First(&updated, articles[0].ID)
+	if updated.Title != "Yeni Başlık" || updated.Slug != "merhaba-dunya" {
+		t.Fatalf("expected slug to be kept on update, got %+v", updated)
+	}
+}
+
+func TestContentFields_Validation(t *testing.T) {
+	p, cookie := setupContentFieldsPanel(t)
+	p.Db.Create(&contentArticle{Title: "Taken", Slug: "taken"})
+
+	cases := []struct {
+		name string
+		body map[string]interface{}
+		key  string
+	}{
+		{"invalid json", map[string]interface{}{"title": "A", "settings": `{"a":`}, "settings"},
+		{"non text tags", map[string]interface{}{"title": "A", "tags": []interface{}{"go", 1}}, "tags"},
+		{"too many tags", map[string]interface{}{"title": "A", "tags": "a,b,c,d"}, "tags"},
+		{"malformed slug", map[string]interface{}{"title": "A", "slug": "Not A Slug"}, "slug"},
+		{"duplicate slug", map[string]interface{}{"title": "A", "slug": "taken"}, "slug"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var payload struct {
+				Errors map[string][]string `json:"errors"`
+			}
+			status := testJSONRequest(t, p, cookie, "POST", "/api/internal/resource/content-articles", tc.body, &payload).StatusCode
+			if status != http.StatusUnprocessableEntity || len(payload.Errors[tc.key]) == 0 {
+				t.Fatalf("expected %s error, got %d %v", tc.key, status, payload.Errors)
+			}
+		})
+	}
+}
+
+func TestContentFields_DetailRendering(t *testing.T) {
+	p, cookie := setupContentFieldsPanel(t)
+	article := contentArticle{
+		Title:    "Doc",
+		Slug:     "doc",
+		Settings: `{"a":[1,2]}`,
+		Tags:     fields.TagList{"go"},
+		Body:     "**Bold** <script>alert(1)</script>",
+	}
+	p.Db.Create(&article)
+
+	var payload struct {
+		Data map[string]map[string]interface{} `json:"data"`
+	}
+	status := testJSONRequest(t, p, cookie, "GET", fmt.Sprintf("/api/internal/resource/content-articles/%d", article.ID), nil, &payload).StatusCode
+	if status != http.StatusOK {
+		t.Fatalf("expected detail to load, got %d", status)
+	}
+	byKey := payload.Data
+
+	if html := byKey["body"]["html"]; html != "<p><strong>Bold</strong> &lt;script&gt;alert(1)&lt;/script&gt;</p>" {
+		t.Errorf("expected sanitized markdown HTML, got %v", html)
+	}
+	if settings, ok := byKey["settings"]["data"].(map[string]interface{}); !ok || len(settings["a"].([]interface{})) != 2 {
+		t.Errorf("expected decoded JSON data, got %v", byKey["settings"]["data"])
+	}
+	if pretty := byKey["settings"]["pretty"]; pretty != "{\n  \"a\": [\n    1,\n    2\n  ]\n}" {
+		t.Errorf("expected pretty JSON, got %v", pretty)
+	}
+	if tags, ok := byKey["tags"]["data"].([]interface{}); !ok || len(tags) != 1 || tags[0] != "go" {
+		t.Errorf("expected tag list, got %v", byKey["tags"]["data"])
+	}
+}
+
+func TestContentFields_TagRelationAndSuggestions(t *testing.T) {
+	p, cookie := setupContentFieldsPanel(t)
+	p.Db.Create(&contentTag{Name: "Golang"})
+	p.Db.Create(&contentArticle{Title: "A", Slug: "a", Tags: fields.TagList{"gorm", "fiber", "go"}})
+
+	var created struct {
+		Data map[string]struct {
+			Data json.RawMessage `json:"data"`
+		} `json:"data"`
+	}
+	status := testJSONRequest(t, p, cookie, "POST", "/api/internal/resource/content-posts", map[string]interface{}{
+		"title": "Post",
+		"tags":  []interface{}{"golang", "web"},
+	}, &created).StatusCode
+	if status != http.StatusCreated {
+		t.Fatalf("expected post to be created, got %d", status)
+	}
+
+	var post contentPost
+	p.Db.Preload("Tags").First(&post)
+	var tagCount int64
+	p.Db.Model(&contentTag{}).Count(&tagCount)
+	if len(post.Tags) != 2 || post.Tags[0].Name != "Golang" || post.Tags[1].Name != "web" || tagCount != 2 {
+		t.Fatalf("expected existing tag reused and new tag created, got %+v (%d tags)", post.Tags, tagCount)
+	}
+	var tags []string
+	if err := json.Unmarshal(created.Data["tags"].Data, &tags); err != nil || len(tags) != 2 || tags[0] != "Golang" {
+		t.Errorf("expected tag names in response, got %s", created.Data["tags"].Data)
+	}
+
+	suggestions := func(slug, query string) []string {
+		var payload struct {
+			Data []struct {
+				Value string `json:"value"`
+			} `json:"data"`
+		}
+		status := testJSONRequest(t, p, cookie, "GET", "/api/internal/resource/"+slug+"/fields/tags/suggestions?q="+query, nil, &payload).StatusCode
+		if status != http.StatusOK {
+			t.Fatalf("expected suggestions, got %d", status)
+		}
+		values := make([]string, 0, len(payload.Data))
+		for _, item := range payload.Data {
+			values = append(values, item.Value)
+		}
+		return values
+	}
+	if values := suggestions("content-articles", "go"); len(values) != 2 || values[0] != "go" || values[1] != "gorm" {
+		t.Errorf("expected JSON column tag suggestions, got %v", values)
+	}
+	if values := suggestions("content-posts", "GO"); len(values) != 1 || values[0] != "Golang" {
+		t.Errorf("expected relation tag suggestions, got %v", values)
+	}
+}
+
+func TestContentFields_FailedTagSyncRollsBackRecord(t *testing.T) {
+	p, cookie := setupContentFieldsPanel(t)
+	if err := p.Db.Migrator().DropTable("content_post_tags"); err != nil {
+		t.Fatalf("failed to drop tag join table: %v", err)
+	}
+
+	resp := testJSONRequest(t, p, cookie, "POST", "/api/internal/resource/content-posts", map[string]interface{}{
+		"title": "Post",
+		"tags":  []interface{}{"golang"},
+	}, nil)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("expected failed tag sync to return 500, got %d", resp.StatusCode)
+	}
+
+	var posts, tags int64
+	p.Db.Model(&contentPost{}).Count(&posts)
+	p.Db.Model(&contentTag{}).Count(&tags)
+	if posts != 0 || tags != 0 {
+		t.Fatalf("expected post and new tags to be rolled back, got %d posts and %d tags", posts, tags)
+	}
+}
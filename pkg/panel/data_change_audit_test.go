@@ -0,0 +1,135 @@
+package panel
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/ferdiunal/panel.go/pkg/domain/audit"
+	"github.com/ferdiunal/panel.go/pkg/fields"
+	"github.com/ferdiunal/panel.go/pkg/middleware"
+	"github.com/ferdiunal/panel.go/pkg/resource"
+	"github.com/gofiber/fiber/v2"
+)
+
+type auditedProduct struct {
+	ID        uint    `json:"id" gorm:"primaryKey"`
+	Name      string  `json:"name"`
+	Price     float64 `json:"price"`
+	CostPrice float64 `json:"cost_price"`
+}
+
+func auditedProductFields() []fields.Element {
+	return []fields.Element{
+		fields.ID(),
+		fields.Text("Name", "name"),
+		fields.Number("Price", "price"),
+		fields.Number("Cost Price", "cost_price").Sensitive(),
+	}
+}
+
+// dataChangeMetadata decodes the metadata of the data_change event for action.
+func dataChangeMetadata(t *testing.T, p *Panel, action string) (audit.Event, map[string]interface{}) {
+	t.Helper()
+
+	var event audit.Event
+	err := p.Db.Where("event_type = ? AND resource = ? AND action = ?", middleware.DataChangeEventType, "audited-products", action).
+		First(&event).Error
+	if err != nil {
+		t.Fatalf("expected %s data change event: %v", action, err)
+	}
+	metadata := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(event.Metadata), &metadata); err != nil {
+		t.Fatalf("invalid metadata %q: %v", event.Metadata, err)
+	}
+	return event, metadata
+}
+
+func TestDataChangeAudit_RecordsFieldChangesWithActor(t *testing.T) {
+	p := setupDatabaseAuditPanel(t)
+	registerTestResource(t, p, &auditedProduct{}, "audited-products", auditedProductFields,
+		func(res *resource.OptimizedBase) { res.SetAuditDataChanges(true) })
+	cookie := registerAndLoginTestUser(t, p, "changes@example.com")
+
+	// send, isteği gönderir ve RequestID middleware'inin atadığı istek kimliğini döner.
+	send := func(method, path string, body interface{}) string {
+		t.Helper()
+		resp := testJSONRequest(t, p, cookie, method, path, body, nil)
+		resp.Body.Close()
+		if resp.StatusCode >= http.StatusBadRequest {
+			t.Fatalf("%s %s returned %d", method, path, resp.StatusCode)
+		}
+		return resp.Header.Get(fiber.HeaderXRequestID)
+	}
+
+	createID := send("POST", "/api/internal/resource/audited-products",
+		map[string]interface{}{"name": "Widget", "price": 10, "cost_price": 4})
+	var product auditedProduct
+	if err := p.Db.First(&product).Error; err != nil {
+		t.Fatalf("expected created product: %v", err)
+	}
+	path := fmt.Sprintf("/api/internal/resource/audited-products/%d", product.ID)
+	updateID := send("PUT", path,
+		map[string]interface{}{"name": "Widget", "price": 12, "cost_price": 5})
+	send("DELETE", path, nil)
+	flushAudit(t, p)
+
+	event, metadata := dataChangeMetadata(t, p, "update")
+	if event.Email != "changes@example.com" || event.UserID == "" {
+		t.Fatalf("expected acting user on event, got user_id=%q email=%q", event.UserID, event.Email)
+	}
+	if metadata["record_id"] != fmt.Sprint(product.ID) || updateID == "" || metadata["request_id"] != updateID {
+		t.Fatalf("expected record and request id, got %v", metadata)
+	}
+	if metadata["auth_method"] != "session" || metadata["session_id"] == nil {
+		t.Fatalf("expected session actor, got %v", metadata)
+	}
+	changes, _ := metadata["changes"].(map[string]interface{})
+	price, _ := changes["price"].(map[string]interface{})
+	if price["old"] != float64(10) || price["new"] != float64(12) {
+		t.Fatalf("expected price change 10 -> 12, got %v", changes)
+	}
+	if _, ok := changes["name"]; ok {
+		t.Fatalf("expected unchanged name to be omitted, got %v", changes)
+	}
+	cost, _ := changes["cost_price"].(map[string]interface{})
+	if cost["old"] != "••••••••" || cost["new"] != "••••••••" {
+		t.Fatalf("expected sensitive cost_price to be masked, got %v", cost)
+	}
+
+	event, metadata = dataChangeMetadata(t, p, "create")
+	if event.StatusCode != http.StatusCreated {
+		t.Fatalf("expected create event status %d, got %d", http.StatusCreated, event.StatusCode)
+	}
+	changes, _ = metadata["changes"].(map[string]interface{})
+	name, _ := changes["name"].(map[string]interface{})
+	if metadata["request_id"] != createID || name["old"] != nil || name["new"] != "Widget" {
+		t.Fatalf("expected created values, got %v", metadata)
+	}
+
+	_, metadata = dataChangeMetadata(t, p, "delete")
+	changes, _ = metadata["changes"].(map[string]interface{})
+	price, _ = changes["price"].(map[string]interface{})
+	if price["old"] != float64(12) || price["new"] != nil {
+		t.Fatalf("expected deleted values, got %v", changes)
+	}
+}
+
+func TestDataChangeAudit_DisabledByDefault(t *testing.T) {
+	p := setupDatabaseAuditPanel(t)
+	registerTestResource(t, p, &auditedProduct{}, "audited-products", auditedProductFields,
+		func(res *resource.OptimizedBase) { res.SetAuditDataChanges(false) })
+	cookie := registerAndLoginTestUser(t, p, "quiet@example.com")
+
+	resp := testJSONRequest(t, p, cookie, "POST", "/api/internal/resource/audited-products",
+		map[string]interface{}{"name": "Widget", "price": 10}, nil)
+	resp.Body.Close()
+	flushAudit(t, p)
+
+	var count int64
+	p.Db.Model(&audit.Event{}).Where("event_type = ?", middleware.DataChangeEventType).Count(&count)
+	if count != 0 {
+		t.Fatalf("expected no data change events, got %d", count)
+	}
+}
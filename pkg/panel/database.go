@@ -0,0 +1,319 @@
+package panel
+
+import (
+	stdcontext "context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ferdiunal/panel.go/pkg/context"
+	"github.com/ferdiunal/panel.go/pkg/data"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// defaultSlowQueryThreshold, panelin açtığı bağlantılarda yavaş sorgu eşiğidir.
+const defaultSlowQueryThreshold = 200 * time.Millisecond
+
+// healthCheckTimeout, /health endpoint'inde her ping için beklenecek en uzun süredir.
+const healthCheckTimeout = 2 * time.Second
+
+// databaseConnections, New içinde çözülen primary ve replica bağlantılarını tutar.
+// owns* alanları bağlantının panel tarafından açıldığını ve Close ile kapatılacağını belirtir.
+type databaseConnections struct {
+	primary     *gorm.DB
+	replica     *gorm.DB
+	ownsPrimary bool
+	ownsReplica bool
+}
+
+// / # openDatabaseConnections Fonksiyonu
+// /
+// / DatabaseConfig'ten primary ve (varsa) read replica bağlantılarını hazırlar.
+// / Instance verilmişse yeniden kullanılır; aksi halde DSN ve Driver ile açılır.
+// / Havuz ayarları her iki durumda da uygulanır, bağlantılar ping ile doğrulanır.
+func openDatabaseConnections(cfg DatabaseConfig) (*databaseConnections, error) {
+	if err := validateDatabaseConfig(cfg); err != nil {
+		return nil, err
+	}
+
+	conns := &databaseConnections{primary: cfg.Instance}
+	if conns.primary == nil {
+		db, err := openDatabase(cfg.Driver, cfg.DSN, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("primary: %w", err)
+		}
+		conns.primary, conns.ownsPrimary = db, true
+	} else {
+		if cfg.SlowQueryThreshold > 0 || cfg.LogLevel != "" {
+			conns.primary.Logger = newDatabaseLogger(cfg)
+		}
+		if err := configureConnectionPool(conns.primary, cfg); err != nil {
+			return nil, fmt.Errorf("primary: %w", err)
+		}
+	}
+
+	if cfg.ReadReplicaDSN != "" {
+		replica, err := openDatabase(cfg.Driver, cfg.ReadReplicaDSN, cfg)
+		if err != nil {
+			conns.close()
+			return nil, fmt.Errorf("read replica: %w", err)
+		}
+		conns.replica, conns.ownsReplica = replica, true
+	}
+
+	return conns, nil
+}
+
+// close, yalnızca panelin açtığı bağlantıları kapatır.
+func (c *databaseConnections) close() {
+	if c == nil {
+		return
+	}
+	if c.ownsReplica {
+		closeGormDB(c.replica)
+	}
+	if c.ownsPrimary {
+		closeGormDB(c.primary)
+	}
+}
+
+func closeGormDB(db *gorm.DB) {
+	if db == nil {
+		return
+	}
+	if sqlDB, err := db.DB(); err == nil {
+		_ = sqlDB.Close()
+	}
+}
+
+// validateDatabaseConfig, bağlantı açılmadan önce çelişkili ayarları reddeder.
+func validateDatabaseConfig(cfg DatabaseConfig) error {
+	var errs []error
+	fail := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("database: "+format, args...))
+	}
+
+	if cfg.Instance == nil {
+		switch {
+		case cfg.DSN == "" && cfg.Driver == "":
+			fail("either Instance or DSN and Driver must be set")
+		case cfg.DSN == "":
+			fail("DSN is required when Instance is nil")
+		case cfg.Driver == "":
+			fail("Driver is required when Instance is nil")
+		}
+	}
+	if cfg.Driver != "" {
+		if _, err := databaseDialector(cfg.Driver, ""); err != nil {
+			fail("%v", err)
+		}
+	}
+	if cfg.ReadReplicaDSN != "" && cfg.Driver == "" {
+		fail("Driver is required when ReadReplicaDSN is set")
+	}
+	if cfg.MaxOpenConns < 0 || cfg.MaxIdleConns < 0 {
+		fail("MaxOpenConns and MaxIdleConns cannot be negative")
+	}
+	if cfg.MaxOpenConns > 0 && cfg.MaxIdleConns > cfg.MaxOpenConns {
+		fail("MaxIdleConns (%d) cannot exceed MaxOpenConns (%d)", cfg.MaxIdleConns, cfg.MaxOpenConns)
+	}
+	if cfg.ConnMaxLifetime < 0 || cfg.ConnMaxIdleTime < 0 || cfg.SlowQueryThreshold < 0 {
+		fail("ConnMaxLifetime, ConnMaxIdleTime and SlowQueryThreshold cannot be negative")
+	}
+	if _, ok := databaseLogLevels[strings.ToLower(cfg.LogLevel)]; !ok && cfg.LogLevel != "" {
+		fail("unsupported LogLevel %q (expected silent, error, warn or info)", cfg.LogLevel)
+	}
+
+	return errors.Join(errs...)
+}
+
+// databaseDialector, Driver adına göre GORM dialector'ünü döndürür.
+func databaseDialector(driver, dsn string) (gorm.Dialector, error) {
+	switch strings.ToLower(strings.TrimSpace(driver)) {
+	case "sqlite", "sqlite3":
+		return sqlite.Open(dsn), nil
+	case "postgres", "postgresql":
+		return postgres.Open(dsn), nil
+	case "mysql":
+		return mysql.Open(dsn), nil
+	default:
+		return nil, fmt.Errorf("unsupported driver %q (expected sqlite, postgres or mysql)", driver)
+	}
+}
+
+func openDatabase(driver, dsn string, cfg DatabaseConfig) (*gorm.DB, error) {
+	dialector, err := databaseDialector(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{Logger: newDatabaseLogger(cfg)})
+	if err != nil {
+		return nil, err
+	}
+	if err := configureConnectionPool(db, cfg); err != nil {
+		closeGormDB(db)
+		return nil, err
+	}
+
+	ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), healthCheckTimeout)
+	defer cancel()
+	if err := pingDatabase(ctx, db); err != nil {
+		closeGormDB(db)
+		return nil, err
+	}
+	return db, nil
+}
+
+func configureConnectionPool(db *gorm.DB, cfg DatabaseConfig) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	if cfg.MaxOpenConns > 0 {
+		sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	}
+	if cfg.MaxIdleConns > 0 {
+		sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	}
+	if cfg.ConnMaxLifetime > 0 {
+		sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	}
+	if cfg.ConnMaxIdleTime > 0 {
+		sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	}
+	return nil
+}
+
+var databaseLogLevels = map[string]logger.LogLevel{
+	"silent": logger.Silent,
+	"error":  logger.Error,
+	"warn":   logger.Warn,
+	"info":   logger.Info,
+}
+
+// newDatabaseLogger, SlowQueryThreshold'u aşan sorguları uyarı olarak loglayan GORM logger'ı oluşturur.
+func newDatabaseLogger(cfg DatabaseConfig) logger.Interface {
+	level, ok := databaseLogLevels[strings.ToLower(cfg.LogLevel)]
+	if !ok {
+		level = logger.Warn
+	}
+	threshold := cfg.SlowQueryThreshold
+	if threshold <= 0 {
+		threshold = defaultSlowQueryThreshold
+	}
+
+	return logger.New(log.New(os.Stdout, "\r\n", log.LstdFlags), logger.Config{
+		SlowThreshold:             threshold,
+		LogLevel:                  level,
+		IgnoreRecordNotFoundError: true,
+		Colorful:                  false,
+	})
+}
+
+func pingDatabase(ctx stdcontext.Context, db *gorm.DB) error {
+	if db == nil {
+		return errors.New("not configured")
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// / # ReadDB Metodu
+// /
+// / Okuma sorguları için kullanılacak bağlantıyı döndürür.
+// / ReadReplicaDSN ayarlanmamışsa primary bağlantıyı (Db) döndürür.
+func (p *Panel) ReadDB() *gorm.DB {
+	if p.readDb != nil {
+		return p.readDb
+	}
+	return p.Db
+}
+
+// readReplicaSetter, read replica destekleyen provider'lar için opsiyonel interface'dir.
+type readReplicaSetter interface {
+	SetReadDB(db *gorm.DB)
+}
+
+// applyReadReplica, replica tanımlıysa provider'ın Index/Show okumalarını replica'ya yönlendirir.
+func (p *Panel) applyReadReplica(provider data.DataProvider) {
+	if p.readDb == nil || provider == nil {
+		return
+	}
+	if setter, ok := provider.(readReplicaSetter); ok {
+		setter.SetReadDB(p.readDb)
+	}
+}
+
+// DatabaseHealth, veritabanı bağlantılarının ping sonuçlarını tutar.
+type DatabaseHealth struct {
+	Primary error
+	Replica error
+	// HasReplica, ReadReplicaDSN ile bir replica yapılandırıldığını belirtir.
+	HasReplica bool
+}
+
+// Err, başarısız kontrolleri tek bir hatada birleştirir.
+func (h DatabaseHealth) Err() error {
+	var errs []error
+	if h.Primary != nil {
+		errs = append(errs, fmt.Errorf("primary: %w", h.Primary))
+	}
+	if h.Replica != nil {
+		errs = append(errs, fmt.Errorf("read replica: %w", h.Replica))
+	}
+	return errors.Join(errs...)
+}
+
+// / # CheckDatabase Metodu
+// /
+// / Primary ve (varsa) read replica bağlantılarını ping ile kontrol eder.
+// / Load balancer / orchestrator health check'leri için `/api/internal/health`
+// / endpoint'i bu metodu kullanır.
+func (p *Panel) CheckDatabase(ctx stdcontext.Context) DatabaseHealth {
+	health := DatabaseHealth{HasReplica: p.readDb != nil}
+	health.Primary = pingDatabase(ctx, p.Db)
+	if health.HasReplica {
+		health.Replica = pingDatabase(ctx, p.readDb)
+	}
+	return health
+}
+
+// handleHealth, veritabanı bağlantılarının durumunu döndürür.
+// Hata detayları yanıtta paylaşılmaz, yalnızca loglanır.
+func (p *Panel) handleHealth(c *context.Context) error {
+	ctx, cancel := stdcontext.WithTimeout(c.Context(), healthCheckTimeout)
+	defer cancel()
+
+	health := p.CheckDatabase(ctx)
+	checks := fiber.Map{"database": healthStatus(health.Primary)}
+	if health.HasReplica {
+		checks["database_replica"] = healthStatus(health.Replica)
+	}
+
+	if err := health.Err(); err != nil {
+		if p.Db != nil && p.Db.Logger != nil {
+			p.Db.Logger.Error(ctx, "health check failed: %v", err)
+		}
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"status": "unavailable", "checks": checks})
+	}
+	return c.JSON(fiber.Map{"status": "ok", "checks": checks})
+}
+
+func healthStatus(err error) string {
+	if err != nil {
+		return "down"
+	}
+	return "up"
+}
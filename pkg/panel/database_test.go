@@ -0,0 +1,113 @@
+package panel
+
+import (
+	stdcontext "context"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateDatabaseConfig(t *testing.T) {
+	cases := []struct {
+		name string
+		cfg  DatabaseConfig
+		want string
+	}{
+		{name: "nothing configured", cfg: DatabaseConfig{}, want: "either Instance or DSN and Driver must be set"},
+		{name: "dsn without driver", cfg: DatabaseConfig{DSN: "panel.db"}, want: "Driver is required"},
+		{name: "unsupported driver", cfg: DatabaseConfig{DSN: "db", Driver: "oracle"}, want: `unsupported driver "oracle"`},
+		{name: "idle above open", cfg: DatabaseConfig{DSN: "panel.db", Driver: "sqlite", MaxOpenConns: 2, MaxIdleConns: 5}, want: "MaxIdleConns (5) cannot exceed MaxOpenConns (2)"},
+		{name: "negative lifetime", cfg: DatabaseConfig{DSN: "panel.db", Driver: "sqlite", ConnMaxLifetime: -time.Second}, want: "cannot be negative"},
+		{name: "bad log level", cfg: DatabaseConfig{DSN: "panel.db", Driver: "sqlite", LogLevel: "debug"}, want: `unsupported LogLevel "debug"`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateDatabaseConfig(tc.cfg)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+
+	if err := validateDatabaseConfig(DatabaseConfig{DSN: "panel.db", Driver: "postgres", ReadReplicaDSN: "replica"}); err != nil {
+		t.Fatalf("expected valid config, got %v", err)
+	}
+}
+
+func TestNew_OpensDatabaseFromDSN(t *testing.T) {
+	dir := t.TempDir()
+	p := New(Config{
+		Environment: "test",
+		Database: DatabaseConfig{
+			Driver:             "sqlite",
+			DSN:                filepath.Join(dir, "primary.db"),
+			ReadReplicaDSN:     filepath.Join(dir, "replica.db"),
+			MaxOpenConns:       4,
+			MaxIdleConns:       2,
+			ConnMaxLifetime:    time.Minute,
+			SlowQueryThreshold: 50 * time.Millisecond,
+		},
+	})
+
+	if p.Db == nil || p.Config.Database.Instance != p.Db {
+		t.Fatal("expected panel to open the primary connection")
+	}
+	if p.ReadDB() == nil || p.ReadDB() == p.Db {
+		t.Fatal("expected a separate read replica connection")
+	}
+	sqlDB, err := p.Db.DB()
+	if err != nil {
+		t.Fatalf("failed to get sql.DB: %v", err)
+	}
+	if got := sqlDB.Stats().MaxOpenConnections; got != 4 {
+		t.Fatalf("expected MaxOpenConns to be applied, got %d", got)
+	}
+
+	health := p.CheckDatabase(stdcontext.Background())
+	if err := health.Err(); err != nil || !health.HasReplica {
+		t.Fatalf("expected healthy primary and replica, got %+v", health)
+	}
+
+	resp, err := testFiberRequest(p.Fiber, httptest.NewRequest("GET", "/api/internal/health", nil))
+	if err != nil {
+		t.Fatalf("health request failed: %v", err)
+	}
+	var body struct {
+		Status string            `json:"status"`
+		Checks map[string]string `json:"checks"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode health response: %v", err)
+	}
+	if resp.StatusCode != 200 || body.Status != "ok" || body.Checks["database"] != "up" || body.Checks["database_replica"] != "up" {
+		t.Fatalf("unexpected health response %d %+v", resp.StatusCode, body)
+	}
+
+	p.Close()
+	if err := sqlDB.Ping(); err == nil {
+		t.Fatal("expected Close to close the connection opened by the panel")
+	}
+	resp, err = testFiberRequest(p.Fiber, httptest.NewRequest("GET", "/api/internal/health", nil))
+	if err != nil {
+		t.Fatalf("health request failed: %v", err)
+	}
+	if resp.StatusCode != 503 {
+		t.Fatalf("expected 503 after connections are closed, got %d", resp.StatusCode)
+	}
+}
+
+func TestNew_PanicsWithoutDatabase(t *testing.T) {
+	defer func() {
+		recovered := recover()
+		if recovered == nil || !strings.Contains(fmt.Sprint(recovered), "veritabanı bağlantısı açılamadı") {
+			t.Fatalf("expected database panic, got %v", recovered)
+		}
+	}()
+
+	New(Config{Environment: "test", Database: DatabaseConfig{Driver: "sqlite"}})
+}
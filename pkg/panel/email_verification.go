@@ -0,0 +1,90 @@
+package panel
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/ferdiunal/panel.go/pkg/domain/verification"
+	authHandler "github.com/ferdiunal/panel.go/pkg/handler/auth"
+	"github.com/ferdiunal/panel.go/pkg/mail"
+	"github.com/ferdiunal/panel.go/pkg/service/auth"
+)
+
+// newMailSender, MailConfig'ten gönderici oluşturur. Hiçbiri tanımlı değilse nil döner.
+func newMailSender(cfg MailConfig) mail.Sender {
+	switch {
+	case cfg.Sender != nil:
+		return cfg.Sender
+	case cfg.SMTP.Host != "":
+		return &mail.SMTPSender{
+			Host:     cfg.SMTP.Host,
+			Port:     cfg.SMTP.Port,
+			Username: cfg.SMTP.Username,
+			Password: cfg.SMTP.Password,
+		}
+	case cfg.FileDir != "":
+		return mail.NewFileSender(cfg.FileDir)
+	default:
+		return nil
+	}
+}
+
+// validateEmailVerificationConfig, doğrulama akışının gönderici veya genel
+// doğrulama adresi (VerifyURL) olmadan ya da bilinmeyen bir enforcement
+// değeriyle açılmasını engeller. Token içeren bağlantılar istek başlıklarından
+// üretilmediği için VerifyURL zorunludur.
+func validateEmailVerificationConfig(ev EmailVerificationConfig, m MailConfig) error {
+	var errs []error
+	fail := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("email_verification: "+format, args...))
+	}
+
+	switch auth.EmailVerificationEnforcement(strings.ToLower(ev.Enforcement)) {
+	case auth.EmailVerificationOptional, auth.EmailVerificationBlockLogin, auth.EmailVerificationRestrictAccess:
+	default:
+		fail("unsupported enforcement %q (expected login or restrict)", ev.Enforcement)
+	}
+	if ev.Enforcement != "" && !ev.Enabled {
+		fail("enforcement requires enabled: true")
+	}
+	if ev.TokenLifetime < 0 || ev.ResendInterval < 0 {
+		fail("token_lifetime and resend_interval cannot be negative")
+	}
+	if ev.Enabled {
+		if newMailSender(m) == nil {
+			fail("requires a mail sender (mail.smtp.host, mail.file_dir or Mail.Sender)")
+		}
+		if strings.TrimSpace(m.From) == "" {
+			fail("requires mail.from")
+		}
+		if u, err := url.Parse(strings.TrimSpace(ev.VerifyURL)); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			fail("requires verify_url as an absolute http(s) address of the verify endpoint")
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// configureEmailVerification, doğrulama akışını auth servisine ve handler'a bağlar.
+// Enabled false ise hiçbir şey yapmaz.
+func configureEmailVerification(config Config, verifications verification.Repository, service *auth.Service, h *authHandler.Handler) {
+	ev := config.EmailVerification
+	if !ev.Enabled {
+		return
+	}
+
+	appName := config.SettingsValues.SiteName
+	if appName == "" {
+		appName = config.TwoFactor.Issuer
+	}
+	service.SetEmailVerification(verifications, newMailSender(config.Mail), auth.EmailVerificationOptions{
+		From:           config.Mail.From,
+		AppName:        appName,
+		Enforcement:    auth.EmailVerificationEnforcement(strings.ToLower(ev.Enforcement)),
+		TokenLifetime:  ev.TokenLifetime,
+		ResendInterval: ev.ResendInterval,
+		VerifyURL:      ev.VerifyURL,
+	})
+	h.SetEmailVerificationRedirect(ev.RedirectURL)
+}
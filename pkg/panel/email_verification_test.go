@@ -0,0 +1,270 @@
+package panel
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/ferdiunal/panel.go/pkg/domain/user"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupEmailVerificationPanel(t *testing.T, ev EmailVerificationConfig) (*Panel, string) {
+	t.Helper()
+
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", strings.ReplaceAll(t.Name(), "/", "_"))
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect db: %v", err)
+	}
+
+	if ev.Enabled && ev.VerifyURL == "" {
+		ev.VerifyURL = testVerifyURL
+	}
+	mailDir := filepath.Join(t.TempDir(), "mail")
+	p := New(Config{
+		Database:          DatabaseConfig{Instance: db},
+		Environment:       "test",
+		Mail:              MailConfig{From: "panel@example.com", FileDir: mailDir},
+		EmailVerification: ev,
+	})
+	t.Cleanup(p.Close)
+	return p, mailDir
+}
+
+// testVerifyURL, testlerde kullanılan genel doğrulama adresidir.
+const testVerifyURL = "https://admin.example.com/api/internal/auth/verify-email"
+
+func registerTestUser(t *testing.T, p *Panel, email string) {
+	t.Helper()
+	resp, payload := twoFactorRequest(t, p, "POST", "/api/internal/auth/sign-up/email", nil, map[string]string{
+		"name":     "Verify Me",
+		"email":    email,
+		"password": "password",
+	})
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("register %s: expected 201, got %d (%v)", email, resp.StatusCode, payload)
+	}
+}
+
+var verificationLinkPattern = regexp.MustCompile(`https?://\S+/auth/verify-email\?token=[0-9a-f]+`)
+
+// sentVerificationLinks returns the verification links found in the .eml files in dir, oldest first.
+func sentVerificationLinks(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatalf("read mail dir: %v", err)
+	}
+	// Undo quoted-printable soft line breaks and "=" escaping in the message body.
+	unquote := strings.NewReplacer("=\r\n", "", "=3D", "=")
+	var links []string
+	for _, entry := range entries {
+		raw, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			t.Fatalf("read message: %v", err)
+		}
+		if link := verificationLinkPattern.FindString(unquote.Replace(string(raw))); link != "" {
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+func verifyLinkPath(t *testing.T, link string) string {
+	t.Helper()
+	u, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("invalid link %q: %v", link, err)
+	}
+	return u.RequestURI()
+}
+
+func TestEmailVerification_BlocksLoginUntilVerified(t *testing.T) {
+	p, mailDir := setupEmailVerificationPanel(t, EmailVerificationConfig{Enabled: true, Enforcement: "login"})
+	email := "verify-login@example.com"
+	registerTestUser(t, p, email)
+
+	links := sentVerificationLinks(t, mailDir)
+	if len(links) != 1 {
+		t.Fatalf("expected one verification email, got %d", len(links))
+	}
+	if !strings.Contains(links[0], "/api/internal/auth/verify-email?token=") {
+		t.Fatalf("unexpected link %q", links[0])
+	}
+
+	resp, payload := signInWithPassword(t, p, email, "password")
+	if resp.StatusCode != http.StatusForbidden || payload["code"] != "email_not_verified" {
+		t.Fatalf("expected login to be blocked, got %d (%v)", resp.StatusCode, payload)
+	}
+
+	resp, payload = twoFactorRequest(t, p, "GET", verifyLinkPath(t, links[0]), nil, nil)
+	if resp.StatusCode != http.StatusOK || payload["verified"] != true {
+		t.Fatalf("verify: expected 200, got %d (%v)", resp.StatusCode, payload)
+	}
+
+	var stored user.User
+	if err := p.Db.Where("email = ?", email).First(&stored).Error; err != nil || !stored.EmailVerified {
+		t.Fatalf("expected EmailVerified=true, got %+v (%v)", stored, err)
+	}
+
+	resp, _ = signInWithPassword(t, p, email, "password")
+	if resp.StatusCode != http.StatusOK || sessionCookie(resp) == nil {
+		t.Fatalf("expected login after verification, got %d", resp.StatusCode)
+	}
+
+	resp, payload = twoFactorRequest(t, p, "GET", verifyLinkPath(t, links[0]), nil, nil)
+	if resp.StatusCode != http.StatusBadRequest || payload["code"] != "invalid_token" {
+		t.Fatalf("reused token: expected 400, got %d (%v)", resp.StatusCode, payload)
+	}
+}
+
+func TestEmailVerification_LinksIgnoreRequestHost(t *testing.T) {
+	p, mailDir := setupEmailVerificationPanel(t, EmailVerificationConfig{Enabled: true})
+
+	body, _ := json.Marshal(map[string]string{
+		"name":     "Verify Me",
+		"email":    "verify-host@example.com",
+		"password": "password",
+	})
+	req := httptest.NewRequest("POST", "/api/internal/auth/sign-up/email", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Host = "attacker.example"
+	resp, err := testFiberRequest(p.Fiber, req)
+	if err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("register: expected 201, got %d", resp.StatusCode)
+	}
+
+	links := sentVerificationLinks(t, mailDir)
+	if len(links) != 1 || !strings.HasPrefix(links[0], testVerifyURL+"?token=") {
+		t.Fatalf("expected link on the configured verify url, got %v", links)
+	}
+}
+
+func TestEmailVerification_ResendIsThrottledAndDoesNotLeakAccounts(t *testing.T) {
+	p, mailDir := setupEmailVerificationPanel(t, EmailVerificationConfig{Enabled: true})
+	email := "verify-resend@example.com"
+	registerTestUser(t, p, email)
+
+	for _, target := range []string{email, "unknown@example.com"} {
+		resp, _ := twoFactorRequest(t, p, "POST", "/api/internal/auth/verify-email/resend", nil, map[string]string{"email": target})
+		if resp.StatusCode != http.StatusAccepted {
+			t.Fatalf("resend %s: expected 202, got %d", target, resp.StatusCode)
+		}
+	}
+	if links := sentVerificationLinks(t, mailDir); len(links) != 1 {
+		t.Fatalf("resend within interval must not send another email, got %d", len(links))
+	}
+
+	// Once the interval has passed a new link is sent and the previous one is revoked.
+	if err := p.Db.Exec("UPDATE verifications SET created_at = ?", "2000-01-01 00:00:00").Error; err != nil {
+		t.Fatalf("failed to age verification: %v", err)
+	}
+	resp, _ := twoFactorRequest(t, p, "POST", "/api/internal/auth/verify-email/resend", nil, map[string]string{"email": email})
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("resend: expected 202, got %d", resp.StatusCode)
+	}
+	links := sentVerificationLinks(t, mailDir)
+	if len(links) != 2 {
+		t.Fatalf("expected a second email after the interval, got %d", len(links))
+	}
+	if resp, _ := twoFactorRequest(t, p, "GET", verifyLinkPath(t, links[0]), nil, nil); resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("previous link: expected 400, got %d", resp.StatusCode)
+	}
+	if resp, _ := twoFactorRequest(t, p, "GET", verifyLinkPath(t, links[1]), nil, nil); resp.StatusCode != http.StatusOK {
+		t.Fatalf("new link: expected 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestEmailVerification_RestrictModeAndAdminAction(t *testing.T) {
+	p, mailDir := setupEmailVerificationPanel(t, EmailVerificationConfig{Enabled: true, Enforcement: "restrict"})
+
+	admin := registerAndLoginTestUser(t, p, "verify-admin@example.com")
+	resp, payload := twoFactorRequest(t, p, "GET", "/api/internal/pages", admin, nil)
+	if resp.StatusCode != http.StatusForbidden || payload["code"] != "email_not_verified" {
+		t.Fatalf("expected restricted access, got %d (%v)", resp.StatusCode, payload)
+	}
+	if err := p.Db.Model(&user.User{}).Where("email = ?", "verify-admin@example.com").Update("email_verified", true).Error; err != nil {
+		t.Fatalf("failed to verify admin: %v", err)
+	}
+
+	memberEmail := "verify-member@example.com"
+	registerTestUser(t, p, memberEmail)
+	var member user.User
+	if err := p.Db.Where("email = ?", memberEmail).First(&member).Error; err != nil {
+		t.Fatalf("failed to load member: %v", err)
+	}
+
+	resp, payload = twoFactorRequest(t, p, "GET", "/api/internal/resource/users/actions", admin, nil)
+	if resp.StatusCode != http.StatusOK || !strings.Contains(fmt.Sprint(payload), "mark-email-verified") {
+		t.Fatalf("expected verification actions, got %d (%v)", resp.StatusCode, payload)
+	}
+
+	resp, payload = twoFactorRequest(t, p, "POST", "/api/internal/resource/users/actions/mark-email-verified", admin, map[string]interface{}{
+		"ids": []string{fmt.Sprint(member.ID)},
+	})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("mark verified action: expected 200, got %d (%v)", resp.StatusCode, payload)
+	}
+
+	resp, _ = signInWithPassword(t, p, memberEmail, "password")
+	memberCookie := sessionCookie(resp)
+	if memberCookie == nil {
+		t.Fatalf("member login failed: %d", resp.StatusCode)
+	}
+	resp, _ = twoFactorRequest(t, p, "GET", "/api/internal/pages", memberCookie, nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected access after admin verification, got %d", resp.StatusCode)
+	}
+
+	// The pending link was revoked when the admin verified the account.
+	links := sentVerificationLinks(t, mailDir)
+	resp, _ = twoFactorRequest(t, p, "GET", verifyLinkPath(t, links[len(links)-1]), nil, nil)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected revoked link, got %d", resp.StatusCode)
+	}
+}
+
+func TestValidateEmailVerificationConfig(t *testing.T) {
+	cases := []struct {
+		name string
+		ev   EmailVerificationConfig
+		mail MailConfig
+		want string
+	}{
+		{name: "disabled", ev: EmailVerificationConfig{}},
+		{name: "file sink", ev: EmailVerificationConfig{Enabled: true, Enforcement: "login", VerifyURL: testVerifyURL}, mail: MailConfig{From: "a@example.com", FileDir: "mail"}},
+		{name: "no sender", ev: EmailVerificationConfig{Enabled: true, VerifyURL: testVerifyURL}, mail: MailConfig{From: "a@example.com"}, want: "requires a mail sender"},
+		{name: "no from", ev: EmailVerificationConfig{Enabled: true, VerifyURL: testVerifyURL}, mail: MailConfig{FileDir: "mail"}, want: "requires mail.from"},
+		{name: "no verify url", ev: EmailVerificationConfig{Enabled: true}, mail: MailConfig{From: "a@example.com", FileDir: "mail"}, want: "requires verify_url"},
+		{name: "relative verify url", ev: EmailVerificationConfig{Enabled: true, VerifyURL: "/api/internal/auth/verify-email"}, mail: MailConfig{From: "a@example.com", FileDir: "mail"}, want: "requires verify_url"},
+		{name: "bad enforcement", ev: EmailVerificationConfig{Enabled: true, Enforcement: "always", VerifyURL: testVerifyURL}, mail: MailConfig{From: "a@example.com", FileDir: "mail"}, want: "unsupported enforcement"},
+		{name: "enforcement without enabled", ev: EmailVerificationConfig{Enforcement: "login"}, want: "requires enabled"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateEmailVerificationConfig(tc.ev, tc.mail)
+			if tc.want == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
@@ -115,6 +115,13 @@ func (p *Panel) registerExternalAPIRoutes(app *fiber.App) {
 					"error": "Unauthorized",
 				})
 			}
+			// Yönetilen key'ler: önce IP izin listesi ve hız limiti, sonra resource scope'u
+			if ok, err := p.apiKeyAuth.EnforceGrant(c.Ctx); !ok {
+				return err
+			}
+			if ok, err := authorizeAPIKeyScope(c, c.Params("resource")); !ok {
+				return err
+			}
 			return next(c)
 		})
 	}
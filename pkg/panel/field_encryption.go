@@ -0,0 +1,167 @@
+package panel
+
+import (
+	stdcontext "context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/ferdiunal/panel.go/pkg/resource"
+	"github.com/ferdiunal/panel.go/shared/encrypt"
+	"gorm.io/gorm"
+)
+
+// / fieldReencryptBatchSize, yeniden şifreleme sırasında her batch'te okunan satır sayısıdır.
+const fieldReencryptBatchSize = 200
+
+// / # FieldReencryptResult
+// /
+// / Tek bir resource için yapılan yeniden şifreleme çalışmasının özetidir.
+type FieldReencryptResult struct {
+	Resource string   `json:"resource"`
+	Columns  []string `json:"columns"`
+	Scanned  int      `json:"scanned"`
+	Updated  int      `json:"updated"`
+}
+
+// / # ReencryptFields Metodu
+// /
+// / Encrypted() alanlara sahip resource'lardaki kayıtları birincil anahtarla yeniden şifreler.
+// / Anahtar rotasyonundan sonra eski anahtarla şifrelenmiş değerleri ve alan şifrelemesi
+// / açılmadan önce yazılmış düz metin değerleri günceller.
+// /
+// / ## Parametreler
+// / - `ctx`: İptal/timeout için context
+// / - `slugs`: Sadece belirtilen resource'lar işlenir (boşsa tümü)
+// /
+// / ## Önemli Notlar
+// / - Soft delete edilmiş kayıtlar da işlenir
+// / - Kayıtlar UpdateColumns ile güncellenir (hook ve updated_at tetiklenmez)
+// / - Config.FieldEncryption tanımlı değilse hata döner
+func (p *Panel) ReencryptFields(ctx stdcontext.Context, slugs ...string) ([]FieldReencryptResult, error) {
+	keyring := p.fieldKeyring
+	if keyring == nil {
+		return nil, fmt.Errorf("field encryption keys are not configured")
+	}
+	if ctx == nil {
+		ctx = stdcontext.Background()
+	}
+
+	snapshot := p.loadRegistrySnapshot()
+	if snapshot == nil {
+		return nil, nil
+	}
+
+	selected := make([]string, 0, len(snapshot.resources))
+	if len(slugs) > 0 {
+		for _, slug := range slugs {
+			if _, ok := snapshot.resources[slug]; !ok {
+				return nil, fmt.Errorf("resource not found: %s", slug)
+			}
+			selected = append(selected, slug)
+		}
+	} else {
+		for slug := range snapshot.resources {
+			selected = append(selected, slug)
+		}
+	}
+	sort.Strings(selected)
+
+	results := make([]FieldReencryptResult, 0, len(selected))
+	for _, slug := range selected {
+		res := snapshot.resources[slug]
+		if res == nil || res.Model() == nil {
+			continue
+		}
+
+		result, err := p.reencryptResourceFields(ctx, keyring, slug, res)
+		if err != nil {
+			return results, fmt.Errorf("%s: %w", slug, err)
+		}
+		if result != nil {
+			results = append(results, *result)
+		}
+	}
+
+	return results, nil
+}
+
+func (p *Panel) reencryptResourceFields(ctx stdcontext.Context, keyring *encrypt.Keyring, slug string, res resource.Resource) (*FieldReencryptResult, error) {
+	stmt := &gorm.Statement{DB: p.Db}
+	if err := stmt.Parse(res.Model()); err != nil {
+		return nil, err
+	}
+	if stmt.Schema.PrioritizedPrimaryField == nil {
+		return nil, fmt.Errorf("model has no primary key")
+	}
+	primaryKey := stmt.Schema.PrioritizedPrimaryField.DBName
+
+	columns := make([]string, 0)
+	seen := make(map[string]struct{})
+	for _, element := range res.Fields() {
+		if element == nil || !element.IsEncrypted() {
+			continue
+		}
+		field := stmt.Schema.LookUpField(element.GetKey())
+		if field == nil || field.DBName == "" {
+			continue
+		}
+		if _, exists := seen[field.DBName]; exists {
+			continue
+		}
+		seen[field.DBName] = struct{}{}
+		columns = append(columns, field.DBName)
+	}
+	if len(columns) == 0 {
+		return nil, nil
+	}
+
+	result := &FieldReencryptResult{Resource: slug, Columns: columns}
+	rows := make([]map[string]interface{}, 0, fieldReencryptBatchSize)
+	query := p.Db.WithContext(ctx).Unscoped().Model(reflect.New(stmt.Schema.ModelType).Interface()).Select(append([]string{primaryKey}, columns...))
+
+	err := query.FindInBatches(&rows, fieldReencryptBatchSize, func(tx *gorm.DB, _ int) error {
+		for _, row := range rows {
+			result.Scanned++
+
+			updates := make(map[string]interface{})
+			for _, column := range columns {
+				var value string
+				switch raw := row[column].(type) {
+				case string:
+					value = raw
+				case []byte:
+					value = string(raw)
+				}
+				if value == "" {
+					continue
+				}
+				rotated, changed, err := keyring.Rotate(value)
+				if err != nil {
+					return fmt.Errorf("row %v column %s: %w", row[primaryKey], column, err)
+				}
+				if changed {
+					updates[column] = rotated
+				}
+			}
+			if len(updates) == 0 {
+				continue
+			}
+
+			// Yeni model örneği, GORM'un resource'un şablon modeline yazmasını engeller
+			model := reflect.New(stmt.Schema.ModelType).Interface()
+			if err := p.Db.WithContext(ctx).Unscoped().Model(model).
+				Where(fmt.Sprintf("%s = ?", primaryKey), row[primaryKey]).
+				UpdateColumns(updates).Error; err != nil {
+				return err
+			}
+			result.Updated++
+		}
+		return nil
+	}).Error
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
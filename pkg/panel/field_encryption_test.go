@@ -0,0 +1,146 @@
+package panel
+
+import (
+	"testing"
+
+	"github.com/ferdiunal/panel.go/pkg/fields"
+	"github.com/ferdiunal/panel.go/shared/encrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	fieldEncryptionOldKey = "000102030405060708090a0b0c0d0e0f000102030405060708090a0b0c0d0e0f"
+	fieldEncryptionNewKey = "0f0e0d0c0b0a090807060504030201000f0e0d0c0b0a09080706050403020100"
+)
+
+type fieldEncryptionCustomer struct {
+	ID         uint   `json:"id" gorm:"primaryKey"`
+	Name       string `json:"name"`
+	NationalID string `json:"national_id"`
+}
+
+func setupFieldEncryptionPanel(t *testing.T, encryption FieldEncryptionConfig) (*Panel, *gorm.DB) {
+	t.Helper()
+	p := newIsolatedTestPanel(t, Config{FieldEncryption: encryption})
+	registerTestResource(t, p, &fieldEncryptionCustomer{}, "customers", func() []fields.Element {
+		return []fields.Element{
+			fields.ID(),
+			fields.Text("Name", "name"),
+			fields.Text("National ID", "national_id").Encrypted(),
+		}
+	})
+	return p, p.Db
+}
+
+func TestReencryptFields_RotatesOldKeyAndPlaintext(t *testing.T) {
+	p, db := setupFieldEncryptionPanel(t, FieldEncryptionConfig{
+		PrimaryKeyID: "new",
+		Keys:         map[string]string{"old": fieldEncryptionOldKey, "new": fieldEncryptionNewKey},
+	})
+
+	oldKeyring, err := encrypt.NewKeyringFromHex("old", map[string]string{"old": fieldEncryptionOldKey})
+	if err != nil {
+		t.Fatalf("failed to create old keyring: %v", err)
+	}
+	oldCiphertext, err := oldKeyring.Encrypt("11111111111")
+	if err != nil {
+		t.Fatalf("failed to encrypt seed value: %v", err)
+	}
+	seed := []fieldEncryptionCustomer{
+		{Name: "Old key", NationalID: oldCiphertext},
+		{Name: "Plaintext", NationalID: "22222222222"},
+		{Name: "Empty"},
+	}
+	if err := db.Create(&seed).Error; err != nil {
+		t.Fatalf("failed to seed customers: %v", err)
+	}
+
+	results, err := p.ReencryptFields(nil)
+	if err != nil {
+		t.Fatalf("reencrypt failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Resource != "customers" || results[0].Scanned != 3 || results[0].Updated != 2 {
+		t.Fatalf("unexpected reencrypt results: %+v", results)
+	}
+
+	var stored []fieldEncryptionCustomer
+	if err := db.Order("id").Find(&stored).Error; err != nil {
+		t.Fatalf("failed to reload customers: %v", err)
+	}
+	keyring := p.fieldKeyring
+	for i, want := range []string{"11111111111", "22222222222"} {
+		if encrypt.EncryptedValueKeyID(stored[i].NationalID) != "new" {
+			t.Fatalf("expected row %d to use primary key, got %q", i, stored[i].NationalID)
+		}
+		if got, err := keyring.Decrypt(stored[i].NationalID); err != nil || got != want {
+			t.Fatalf("expected row %d to decrypt to %q, got %q (%v)", i, want, got, err)
+		}
+	}
+	if stored[2].NationalID != "" {
+		t.Fatalf("expected empty value to stay empty, got %q", stored[2].NationalID)
+	}
+
+	if _, err := p.ReencryptFields(nil, "missing"); err == nil {
+		t.Fatal("expected unknown resource to be rejected")
+	}
+}
+
+func TestFieldEncryption_IndexMasksAndRejectsSort(t *testing.T) {
+	p, db := setupFieldEncryptionPanel(t, FieldEncryptionConfig{
+		Keys: map[string]string{"primary": fieldEncryptionNewKey},
+	})
+	sessionCookie := registerAndLoginTestUser(t, p, "field-encryption@example.com")
+
+	ciphertext, err := p.fieldKeyring.Encrypt("33333333333")
+	if err != nil {
+		t.Fatalf("failed to encrypt seed value: %v", err)
+	}
+	if err := db.Create(&fieldEncryptionCustomer{Name: "Ada", NationalID: ciphertext}).Error; err != nil {
+		t.Fatalf("failed to seed customer: %v", err)
+	}
+
+	var payload struct {
+		Data []map[string]map[string]interface{} `json:"data"`
+	}
+	resp := testJSONRequest(t, p, sessionCookie, "GET", "/api/internal/resource/customers", nil, &payload)
+	if resp.StatusCode != 200 {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if len(payload.Data) != 1 {
+		t.Fatalf("expected one row, got %+v", payload.Data)
+	}
+	if got := payload.Data[0]["national_id"]["data"]; got != "••••••••" {
+		t.Fatalf("expected masked value on index, got %v", got)
+	}
+
+	sortResp := testJSONRequest(t, p, sessionCookie, "GET", "/api/internal/resource/customers?sort_column=national_id&sort_direction=asc", nil, nil)
+	if sortResp.StatusCode != 400 {
+		t.Fatalf("expected status 400 when sorting by encrypted column, got %d", sortResp.StatusCode)
+	}
+}
+
+func TestFieldEncryption_KeyringIsScopedToPanel(t *testing.T) {
+	encrypted, db := setupFieldEncryptionPanel(t, FieldEncryptionConfig{
+		Keys: map[string]string{"primary": fieldEncryptionNewKey},
+	})
+	if err := db.Create(&fieldEncryptionCustomer{Name: "Ada", NationalID: "44444444444"}).Error; err != nil {
+		t.Fatalf("failed to seed customer: %v", err)
+	}
+
+	// Aynı süreçte anahtarsız kurulan ikinci panel, ilk panelin keyring'ini devralmamalı
+	plain, _ := setupFieldEncryptionPanel(t, FieldEncryptionConfig{})
+	if plain.fieldKeyring != nil {
+		t.Fatal("expected panel without field encryption keys to have no keyring")
+	}
+	if _, err := plain.ReencryptFields(nil); err == nil {
+		t.Fatal("expected reencrypt to fail without field encryption keys")
+	}
+
+	results, err := encrypted.ReencryptFields(nil)
+	if err != nil {
+		t.Fatalf("reencrypt failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Updated != 1 {
+		t.Fatalf("unexpected reencrypt results: %+v", results)
+	}
+}
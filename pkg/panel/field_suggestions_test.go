@@ -0,0 +1,150 @@
+package panel
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ferdiunal/panel.go/pkg/context"
+	"github.com/ferdiunal/panel.go/pkg/fields"
+	"github.com/ferdiunal/panel.go/pkg/resource"
+)
+
+type suggestionCity struct {
+	ID      uint   `json:"id" gorm:"primaryKey"`
+	Name    string `json:"name"`
+	Country string `json:"country"`
+	Region  string `json:"region"`
+}
+
+func suggestionCityFields() []fields.Element {
+	name := fields.Text("Name", "name")
+	name.MinCharsForSuggestions(2)
+	name.WithColumnSuggestions()
+
+	country := fields.Text("Country", "country")
+	country.WithSuggestions(func(query string) []interface{} {
+		return []interface{}{map[string]interface{}{"value": "TR", "label": "Türkiye (" + query + ")"}}
+	})
+
+	region := fields.Text("Region", "region")
+	region.Suggestions = []interface{}{"Marmara", "Ege", "Akdeniz"}
+
+	return []fields.Element{fields.ID(), name, country, region}
+}
+
+type denyViewAnyPolicy struct{}
+
+func (denyViewAnyPolicy) ViewAny(*context.Context) bool                  { return false }
+func (denyViewAnyPolicy) View(*context.Context, interface{}) bool        { return false }
+func (denyViewAnyPolicy) Create(*context.Context) bool                   { return false }
+func (denyViewAnyPolicy) Update(*context.Context, interface{}) bool      { return false }
+func (denyViewAnyPolicy) Delete(*context.Context, interface{}) bool      { return false }
+func (denyViewAnyPolicy) Impersonate(*context.Context, interface{}) bool { return false }
+
+func suggestionRequest(t *testing.T, p *Panel, cookie *http.Cookie, path string) (int, []map[string]interface{}) {
+	t.Helper()
+	var payload struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	resp := testJSONRequest(t, p, cookie, "GET", path, nil, &payload)
+	return resp.StatusCode, payload.Data
+}
+
+func TestFieldSuggestions_LimitBounds(t *testing.T) {
+	p := setupStoragePanel(t)
+	registerTestResource(t, p, &suggestionCity{}, "suggestion-cities", suggestionCityFields)
+	for i := 0; i < 60; i++ {
+		p.Db.Create(&suggestionCity{Name: fmt.Sprintf("City %02d", i)})
+	}
+	cookie := registerAndLoginTestUser(t, p, "suggestion-limits@example.com")
+
+	for limit, want := range map[string]int{"": 10, "0": 10, "-5": 10, "20": 20, "100": 50} {
+		_, items := suggestionRequest(t, p, cookie, "/api/internal/resource/suggestion-cities/fields/name/suggestions?q=city&limit="+limit)
+		if len(items) != want {
+			t.Fatalf("limit=%q: expected %d suggestions, got %d", limit, want, len(items))
+		}
+	}
+}
+
+func TestFieldSuggestions_Endpoint(t *testing.T) {
+	p := setupStoragePanel(t)
+	registerTestResource(t, p, &suggestionCity{}, "suggestion-cities", suggestionCityFields)
+	registerTestResource(t, p, &suggestionCity{}, "suggestion-cities-denied", suggestionCityFields,
+		func(res *resource.OptimizedBase) { res.SetPolicy(denyViewAnyPolicy{}) })
+	for _, name := range []string{"Istanbul", "Istanbul", "Isparta", "Izmir", "Ankara"} {
+		p.Db.Create(&suggestionCity{Name: name})
+	}
+	cookie := registerAndLoginTestUser(t, p, "suggestions@example.com")
+
+	status, items := suggestionRequest(t, p, cookie, "/api/internal/resource/suggestion-cities/fields/name/suggestions?q=is")
+	if status != http.StatusOK || len(items) != 2 || items[0]["value"] != "Isparta" || items[1]["label"] != "Istanbul" {
+		t.Fatalf("expected distinct column suggestions, got %d %v", status, items)
+	}
+
+	status, items = suggestionRequest(t, p, cookie, "/api/internal/resource/suggestion-cities/fields/name/suggestions?q=i&limit=1")
+	if status != http.StatusOK || len(items) != 0 {
+		t.Fatalf("expected short query to return no suggestions, got %d %v", status, items)
+	}
+
+	_, items = suggestionRequest(t, p, cookie, "/api/internal/resource/suggestion-cities/fields/name/suggestions?q=is&limit=1")
+	if len(items) != 1 {
+		t.Fatalf("expected limit to apply, got %v", items)
+	}
+
+	_, items = suggestionRequest(t, p, cookie, "/api/internal/resource/suggestion-cities/fields/country/suggestions?q=tu")
+	if len(items) != 1 || !strings.Contains(items[0]["label"].(string), "(tu)") {
+		t.Fatalf("expected callback suggestions, got %v", items)
+	}
+
+	_, items = suggestionRequest(t, p, cookie, "/api/internal/resource/suggestion-cities/fields/region/suggestions?q=MAR")
+	if len(items) != 1 || items[0]["value"] != "Marmara" {
+		t.Fatalf("expected static suggestions filtered by prefix, got %v", items)
+	}
+
+	if status, _ = suggestionRequest(t, p, cookie, "/api/internal/resource/suggestion-cities/fields/id/suggestions?q=1"); status != http.StatusNotFound {
+		t.Fatalf("expected field without suggestions to return 404, got %d", status)
+	}
+	if status, _ = suggestionRequest(t, p, cookie, "/api/internal/resource/suggestion-cities-denied/fields/name/suggestions?q=is"); status != http.StatusForbidden {
+		t.Fatalf("expected ViewAny policy to be enforced, got %d", status)
+	}
+}
+
+func TestFieldSuggestions_AutoCompleteFallsBackToColumn(t *testing.T) {
+	p := setupStoragePanel(t)
+	registerTestResource(t, p, &suggestionCity{}, "suggestion-autocomplete", func() []fields.Element {
+		id := fields.ID()
+		id.WithAutoComplete("/api/internal/resource/suggestion-autocomplete/fields/id/suggestions")
+		name := fields.Text("Name", "name")
+		name.WithAutoComplete("/api/internal/resource/suggestion-autocomplete/fields/name/suggestions")
+		return []fields.Element{id, name, fields.Text("Region", "region")}
+	})
+	for _, name := range []string{"Bursa", "Bursa", "Bodrum", "Ankara"} {
+		p.Db.Create(&suggestionCity{Name: name, Region: name})
+	}
+	cookie := registerAndLoginTestUser(t, p, "suggestions-autocomplete@example.com")
+
+	status, items := suggestionRequest(t, p, cookie, "/api/internal/resource/suggestion-autocomplete/fields/name/suggestions?q=b")
+	if status != http.StatusOK || len(items) != 2 || items[0]["value"] != "Bodrum" || items[1]["value"] != "Bursa" {
+		t.Fatalf("expected autocomplete text field to use column suggestions, got %d %v", status, items)
+	}
+	if status, _ = suggestionRequest(t, p, cookie, "/api/internal/resource/suggestion-autocomplete/fields/region/suggestions?q=b"); status != http.StatusNotFound {
+		t.Fatalf("expected text field without autocomplete to return 404, got %d", status)
+	}
+	if status, _ = suggestionRequest(t, p, cookie, "/api/internal/resource/suggestion-autocomplete/fields/id/suggestions?q=1"); status != http.StatusNotFound {
+		t.Fatalf("expected non-text autocomplete field to return 404, got %d", status)
+	}
+}
+
+func TestFieldSuggestions_Serialization(t *testing.T) {
+	elements := suggestionCityFields()
+
+	autocomplete, ok := elements[1].JsonSerialize()["autocomplete"].(map[string]interface{})
+	if !ok || autocomplete["min_chars"] != 2 || autocomplete["debounce"] != 300 {
+		t.Fatalf("expected autocomplete settings, got %v", autocomplete)
+	}
+	if _, ok := elements[0].JsonSerialize()["autocomplete"]; ok {
+		t.Fatal("expected fields without suggestions to omit autocomplete settings")
+	}
+}
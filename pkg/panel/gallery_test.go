@@ -0,0 +1,250 @@
+package panel
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ferdiunal/panel.go/pkg/fields"
+)
+
+type galleryProduct struct {
+	ID        uint                `json:"id" gorm:"primaryKey"`
+	Name      string              `json:"name"`
+	Images    fields.GalleryItems `json:"images" gorm:"type:text"`
+	Documents []galleryDocument   `json:"documents" gorm:"foreignKey:ProductID"`
+}
+
+type galleryDocument struct {
+	ID        uint `gorm:"primaryKey"`
+	ProductID uint `gorm:"index"`
+	Path      string
+	URL       string
+	Name      string
+	Caption   string
+	Position  int
+}
+
+func galleryProductFields() []fields.Element {
+	images := fields.Gallery("Images", "images").Limit(3)
+	images.Store("media", "gallery")
+
+	documents := fields.Files("Documents", "documents").AsAttachments()
+	documents.Store("private", "documents")
+
+	return []fields.Element{
+		fields.ID(),
+		fields.Text("Name", "name"),
+		images,
+		documents,
+	}
+}
+
+func setupGalleryPanel(t *testing.T) *Panel {
+	t.Helper()
+	p := setupStoragePanel(t)
+	migrateTestModels(t, p, &galleryDocument{})
+	registerTestResource(t, p, &galleryProduct{}, "gallery-products", galleryProductFields)
+	return p
+}
+
+// galleryResponseItems, kaynak yanıtındaki alanın sıralı dosya listesini döner.
+func galleryResponseItems(t *testing.T, resp *http.Response, key string) []fields.GalleryItem {
+	t.Helper()
+	var payload struct {
+		Data map[string]struct {
+			Data json.RawMessage `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	var items []fields.GalleryItem
+	if err := json.Unmarshal(payload.Data[key].Data, &items); err != nil {
+		t.Fatalf("decode %s failed: %v", key, err)
+	}
+	return items
+}
+
+func TestGallery_UploadReorderCaptionAndRemove(t *testing.T) {
+	p := setupGalleryPanel(t)
+	cookie := registerAndLoginTestUser(t, p, "gallery@example.com")
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	_ = writer.WriteField("name", "Widget")
+	for _, name := range []string{"front.png", "back.png"} {
+		part, _ := writer.CreateFormFile("images[]", name)
+		part.Write(pngWithComment(t))
+	}
+	for _, name := range []string{"manual.pdf", "warranty.pdf"} {
+		part, _ := writer.CreateFormFile("documents[]", name)
+		part.Write([]byte("%PDF-1.4 " + name))
+	}
+	writer.Close()
+
+	req := httptest.NewRequest("POST", "/api/internal/resource/gallery-products", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.AddCookie(cookie)
+	resp, err := testFiberRequest(p.Fiber, req)
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusCreated {
+		resp.Body.Close()
+		t.Fatalf("expected created product, got %d", resp.StatusCode)
+	}
+	documents := galleryResponseItems(t, resp, "documents")
+	resp.Body.Close()
+	if len(documents) != 2 || documents[0].Name != "manual.pdf" || !strings.Contains(documents[0].URL, "/api/storage/private/documents/") {
+		t.Fatalf("expected private documents with temporary URLs, got %+v", documents)
+	}
+
+	var product galleryProduct
+	if err := p.Db.Preload("Documents").First(&product).Error; err != nil {
+		t.Fatalf("expected stored product: %v", err)
+	}
+	if len(product.Images) != 2 || product.Images[0].Name != "front.png" || product.Images[1].Name != "back.png" {
+		t.Fatalf("expected two images in upload order, got %+v", product.Images)
+	}
+	if !strings.HasPrefix(product.Images[0].URL, "https://cdn.example.com/gallery/") {
+		t.Fatalf("expected public image URL, got %q", product.Images[0].URL)
+	}
+	if len(product.Documents) != 2 {
+		t.Fatalf("expected two document attachments, got %+v", product.Documents)
+	}
+	if paths := memoryDisk(t, p, "media").Paths(); len(paths) != 2 {
+		t.Fatalf("expected two images on media disk, got %v", paths)
+	}
+
+	front, back := product.Images[0].Path, product.Images[1].Path
+	keep := product.Documents[1].Path
+	resp = testJSONRequest(t, p, cookie, "PUT", fmt.Sprintf("/api/internal/resource/gallery-products/%d", product.ID), map[string]interface{}{
+		"name":      "Widget",
+		"images":    []interface{}{map[string]interface{}{"path": back, "caption": "Back side"}, front},
+		"documents": []interface{}{keep},
+	}, nil)
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		t.Fatalf("expected update to succeed, got %d", resp.StatusCode)
+	}
+	images := galleryResponseItems(t, resp, "images")
+	resp.Body.Close()
+	if len(images) != 2 || images[0].Path != back || images[0].Caption != "Back side" || images[1].Path != front || images[1].Position != 1 {
+		t.Fatalf("expected reordered images in response, got %+v", images)
+	}
+
+	var updated galleryProduct
+	if err := p.Db.Preload("Documents").First(&updated, product.ID).Error; err != nil {
+		t.Fatalf("expected updated product: %v", err)
+	}
+	if len(updated.Images) != 2 || updated.Images[0].Path != back || updated.Images[0].Caption != "Back side" {
+		t.Fatalf("expected reordered images to be stored, got %+v", updated.Images)
+	}
+	if len(updated.Documents) != 1 || updated.Documents[0].Path != keep || updated.Documents[0].Position != 0 {
+		t.Fatalf("expected only the kept document, got %+v", updated.Documents)
+	}
+	var documentCount int64
+	p.Db.Model(&galleryDocument{}).Count(&documentCount)
+	if documentCount != 1 {
+		t.Fatalf("expected removed attachment row to be deleted, got %d rows", documentCount)
+	}
+
+	resp = testJSONRequest(t, p, cookie, "PUT", fmt.Sprintf("/api/internal/resource/gallery-products/%d", product.ID), map[string]interface{}{
+		"name": "Widget",
+	}, nil)
+	resp.Body.Close()
+	if err := p.Db.First(&updated, product.ID).Error; err != nil || len(updated.Images) != 2 {
+		t.Fatalf("expected images to be kept when not sent, got %+v (%v)", updated.Images, err)
+	}
+}
+
+func TestGallery_FailedAttachmentSyncRollsBackRecord(t *testing.T) {
+	p := setupGalleryPanel(t)
+	cookie := registerAndLoginTestUser(t, p, "gallery-rollback@example.com")
+	if err := p.Db.Migrator().DropTable(&galleryDocument{}); err != nil {
+		t.Fatalf("failed to drop gallery documents: %v", err)
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	_ = writer.WriteField("name", "Widget")
+	part, _ := writer.CreateFormFile("documents[]", "manual.pdf")
+	part.Write([]byte("%PDF-1.4 manual"))
+	writer.Close()
+
+	req := httptest.NewRequest("POST", "/api/internal/resource/gallery-products", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.AddCookie(cookie)
+	resp, err := testFiberRequest(p.Fiber, req)
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("expected failed attachment sync to return 500, got %d", resp.StatusCode)
+	}
+
+	var count int64
+	p.Db.Model(&galleryProduct{}).Count(&count)
+	if count != 0 {
+		t.Fatalf("expected product write to be rolled back, got %d rows", count)
+	}
+}
+
+func TestGallery_RejectsUnknownFilesAndLimit(t *testing.T) {
+	p := setupGalleryPanel(t)
+	cookie := registerAndLoginTestUser(t, p, "gallery-limit@example.com")
+
+	product := galleryProduct{Name: "Widget", Images: fields.GalleryItems{{Path: "gallery/a.png"}}}
+	if err := p.Db.Create(&product).Error; err != nil {
+		t.Fatalf("failed to create product: %v", err)
+	}
+
+	cases := map[string][]interface{}{
+		"unknown path":   {"gallery/a.png", "../other/secret.png"},
+		"unknown upload": {map[string]interface{}{"upload": 0}},
+	}
+	for name, manifest := range cases {
+		resp := testJSONRequest(t, p, cookie, "PUT", fmt.Sprintf("/api/internal/resource/gallery-products/%d", product.ID), map[string]interface{}{"images": manifest}, nil)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusUnprocessableEntity {
+			t.Fatalf("%s: expected 422, got %d", name, resp.StatusCode)
+		}
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	_ = writer.WriteField("name", "Widget")
+	for i := 0; i < 3; i++ {
+		part, _ := writer.CreateFormFile("images[]", fmt.Sprintf("photo-%d.png", i))
+		part.Write(pngWithComment(t))
+	}
+	writer.Close()
+	req := httptest.NewRequest("PUT", fmt.Sprintf("/api/internal/resource/gallery-products/%d", product.ID), &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.AddCookie(cookie)
+	resp, err := testFiberRequest(p.Fiber, req)
+	if err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	var payload struct {
+		Errors map[string][]string `json:"errors"`
+	}
+	json.NewDecoder(resp.Body).Decode(&payload)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnprocessableEntity || len(payload.Errors["images"]) == 0 {
+		t.Fatalf("expected the file limit to be enforced, got %d %+v", resp.StatusCode, payload)
+	}
+
+	var stored galleryProduct
+	p.Db.First(&stored, product.ID)
+	if len(stored.Images) != 1 || stored.Images[0].Path != "gallery/a.png" {
+		t.Fatalf("expected images to be unchanged, got %+v", stored.Images)
+	}
+}
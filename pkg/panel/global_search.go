@@ -0,0 +1,376 @@
+package panel
+
+import (
+	stdcontext "context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ferdiunal/panel.go/pkg/context"
+	"github.com/ferdiunal/panel.go/pkg/data"
+	"github.com/ferdiunal/panel.go/pkg/fields"
+	"github.com/ferdiunal/panel.go/pkg/handler"
+	internalconcurrency "github.com/ferdiunal/panel.go/pkg/internal/concurrency"
+	"github.com/ferdiunal/panel.go/pkg/resource"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	defaultGlobalSearchTimeout        = 3 * time.Second
+	defaultGlobalSearchMaxLimit       = 20
+	defaultGlobalSearchMinQueryLength = 2
+)
+
+type globalSearchResult struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle"`
+	Icon     string `json:"icon"`
+	URL      string `json:"url"`
+}
+
+type globalSearchGroup struct {
+	Resource string               `json:"resource"`
+	Title    string               `json:"title"`
+	Icon     string               `json:"icon"`
+	Total    int64                `json:"total"`
+	Results  []globalSearchResult `json:"results"`
+	order    int
+}
+
+type globalSearchTarget struct {
+	slug     string
+	res      resource.Resource
+	cfg      resource.GlobalSearchConfig
+	provider data.DataProvider
+	limit    int
+}
+
+type globalSearchOutcome struct {
+	index    int
+	response *data.QueryResponse
+	err      error
+}
+
+// / # handleGlobalSearch Metodu
+// /
+// / Global arama için tüm görünür ve aranabilir resource'larda eşzamanlı arama yapar.
+// /
+// / ## HTTP Endpoint
+// / `GET /api/search?q=<terim>&limit=<resource başına kayıt>`
+// /
+// / ## Davranış
+// / 1. Sorgu MinQueryLength'ten kısaysa boş sonuç döner
+// / 2. Görünür, erişilebilir, ViewAny policy'si geçen ve Searchable() alanı olan resource'lar seçilir
+// / 3. Her resource kendi arama kolonları ve limiti ile eşzamanlı sorgulanır
+// / 4. Toplam süre Timeout'u aşarsa tamamlanmayan resource'lar `timed_out` listesinde döner
+// / 5. Sonuçlar resource bazında gruplanır (RecordTitle, alt başlık, ikon, detay URL'i)
+// /
+// / ## Yanıt Örneği
+// / ```json
+// / {
+// /   "data": [
+// /     {
+// /       "resource": "products",
+// /       "title": "Products",
+// /       "icon": "package",
+// /       "total": 12,
+// /       "results": [
+// /         {"id": "42", "title": "Red Shoe", "subtitle": "SKU-42", "icon": "package", "url": "/resource/products/42"}
+// /       ]
+// /     }
+// /   ],
+// /   "meta": {"query": "shoe", "total": 12, "timed_out": [], "failed": []}
+// / }
+// / ```
+func (p *Panel) handleGlobalSearch(c *context.Context) error {
+	cfg := p.Config.GlobalSearch
+	if cfg.Disabled {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Global search is disabled",
+		})
+	}
+
+	minLength := cfg.MinQueryLength
+	if minLength <= 0 {
+		minLength = defaultGlobalSearchMinQueryLength
+	}
+
+	query := strings.TrimSpace(c.Query("q"))
+	groups := make([]globalSearchGroup, 0)
+	timedOut := make([]string, 0)
+	failed := make([]string, 0)
+
+	if utf8.RuneCountInString(query) < minLength {
+		return c.JSON(fiber.Map{
+			"data": groups,
+			"meta": fiber.Map{
+				"query":     query,
+				"total":     0,
+				"timed_out": timedOut,
+				"failed":    failed,
+			},
+		})
+	}
+
+	targets := p.collectGlobalSearchTargets(c, c.QueryInt("limit", 0))
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultGlobalSearchTimeout
+	}
+	searchCtx, cancel := stdcontext.WithTimeout(stdcontext.Background(), timeout)
+	defer cancel()
+
+	outcomes := runGlobalSearch(searchCtx, targets, query, cfg.Workers)
+
+	urlPrefix := p.navigationURLPrefix(c)
+	var total int64
+	for i, target := range targets {
+		outcome := outcomes[i]
+		if outcome == nil {
+			timedOut = append(timedOut, target.slug)
+			continue
+		}
+		if outcome.err != nil {
+			failed = append(failed, target.slug)
+			continue
+		}
+		if outcome.response == nil || len(outcome.response.Items) == 0 {
+			continue
+		}
+
+		group := globalSearchGroup{
+			Resource: target.slug,
+			Title:    target.res.TitleWithContext(c.Ctx),
+			Icon:     target.res.Icon(),
+			Total:    outcome.response.Total,
+			Results:  make([]globalSearchResult, 0, len(outcome.response.Items)),
+			order:    target.res.NavigationOrder(),
+		}
+		for _, item := range outcome.response.Items {
+			id := globalSearchRecordID(item)
+			title := strings.TrimSpace(target.res.RecordTitle(item))
+			if title == "" {
+				title = id
+			}
+			group.Results = append(group.Results, globalSearchResult{
+				ID:       id,
+				Title:    title,
+				Subtitle: target.cfg.GlobalSearchSubtitle(item),
+				Icon:     target.res.Icon(),
+				URL:      fmt.Sprintf("%s/resource/%s/%s", urlPrefix, target.slug, id),
+			})
+		}
+		total += outcome.response.Total
+		groups = append(groups, group)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].order != groups[j].order {
+			return groups[i].order < groups[j].order
+		}
+		return groups[i].Title < groups[j].Title
+	})
+
+	return c.JSON(fiber.Map{
+		"data": groups,
+		"meta": fiber.Map{
+			"query":     query,
+			"total":     total,
+			"timed_out": timedOut,
+			"failed":    failed,
+		},
+	})
+}
+
+// collectGlobalSearchTargets, mevcut istek için aranabilir resource'ları belirler.
+// Policy kontrolleri ve provider oluşturma, fiber context'ten isteğe özel durum
+// okuyabildikleri için istek goroutine'inde çalışır.
+func (p *Panel) collectGlobalSearchTargets(c *context.Context, requestedLimit int) []globalSearchTarget {
+	snapshot := p.loadRegistrySnapshot()
+	if snapshot == nil {
+		return nil
+	}
+
+	maxLimit := p.Config.GlobalSearch.MaxLimit
+	if maxLimit <= 0 {
+		maxLimit = defaultGlobalSearchMaxLimit
+	}
+
+	slugs := make([]string, 0, len(snapshot.resources))
+	for slug := range snapshot.resources {
+		slugs = append(slugs, slug)
+	}
+	sort.Strings(slugs)
+
+	targets := make([]globalSearchTarget, 0, len(slugs))
+	for _, slug := range slugs {
+		res := snapshot.resources[slug]
+		if res == nil || !res.Visible() || !p.isResourceAccessibleForRequest(c, slug) {
+			continue
+		}
+
+		cfg := resource.ResolveGlobalSearchConfig(res)
+		if !cfg.Enabled {
+			continue
+		}
+		columns := globalSearchColumns(res.Fields())
+		if len(columns) == 0 {
+			continue
+		}
+		if policy := res.Policy(); policy != nil && !policy.ViewAny(c) {
+			continue
+		}
+
+		limit := cfg.Limit
+		if requestedLimit > 0 {
+			limit = requestedLimit
+		}
+		if limit > maxLimit {
+			limit = maxLimit
+		}
+
+		h := handler.NewResourceHandler(p.Db, res, p.Config.Storage.Path, p.Config.Storage.URL)
+		h.SetFieldKeyring(p.fieldKeyring)
+		p.applyReadReplica(h.Provider)
+		h.Provider.SetSearchColumns(columns)
+		h.Provider.SetWith(nil)
+		h.Provider.SetRelationshipFields(nil)
+
+		targets = append(targets, globalSearchTarget{
+			slug:     slug,
+			res:      res,
+			cfg:      cfg,
+			provider: h.Provider,
+			limit:    limit,
+		})
+	}
+
+	return targets
+}
+
+// runGlobalSearch, tüm hedefleri eşzamanlı sorgular. Dönen slice targets ile aynı
+// sıradadır; nil girdi, hedefin ctx süresi dolmadan bitmediğini gösterir.
+func runGlobalSearch(ctx stdcontext.Context, targets []globalSearchTarget, query string, workers int) []*globalSearchOutcome {
+	outcomes := make([]*globalSearchOutcome, len(targets))
+	if len(targets) == 0 {
+		return outcomes
+	}
+
+	workers = internalconcurrency.ClampWorkers(workers, len(targets))
+	jobs := make(chan int, len(targets))
+	for i := range targets {
+		jobs <- i
+	}
+	close(jobs)
+
+	// Süre dolduktan sonra biten worker'lar bloklanmasın diye buffer'lı.
+	results := make(chan globalSearchOutcome, len(targets))
+	for worker := 0; worker < workers; worker++ {
+		go func() {
+			for index := range jobs {
+				if ctx.Err() != nil {
+					return
+				}
+				results <- searchGlobalTarget(ctx, index, targets[index], query)
+			}
+		}()
+	}
+
+	for received := 0; received < len(targets); received++ {
+		select {
+		case outcome := <-results:
+			outcomes[outcome.index] = &outcome
+		case <-ctx.Done():
+			return outcomes
+		}
+	}
+
+	return outcomes
+}
+
+func searchGlobalTarget(ctx stdcontext.Context, index int, target globalSearchTarget, query string) (outcome globalSearchOutcome) {
+	outcome.index = index
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			outcome.err = fmt.Errorf("global search panicked for %s: %v", target.slug, recovered)
+		}
+	}()
+
+	if scoped, ok := target.provider.(interface {
+		SetQueryContext(stdcontext.Context)
+	}); ok {
+		scoped.SetQueryContext(ctx)
+	}
+
+	// Fiber context worker goroutine'lerle bilerek paylaşılmaz: handler döndüğünde
+	// (timeout'ta da olabilir) yeniden kullanılır.
+	outcome.response, outcome.err = target.provider.Index(nil, data.QueryRequest{
+		Page:    1,
+		PerPage: target.limit,
+		Search:  query,
+	})
+	return outcome
+}
+
+func globalSearchColumns(elements []fields.Element) []string {
+	columns := make([]string, 0)
+	for _, element := range elements {
+		if element == nil {
+			continue
+		}
+		searchable, ok := element.(interface{ IsSearchable() bool })
+		if !ok || !searchable.IsSearchable() {
+			continue
+		}
+		if _, isRelationship := fields.IsRelationshipField(element); isRelationship {
+			continue
+		}
+		// Şifreli değerler SQL'de aranamaz; yalnızca şifreli alanları aranabilir
+		// olan kaynaklar global aramaya dahil edilmez.
+		if element.IsEncrypted() {
+			continue
+		}
+		if key := element.GetKey(); key != "" {
+			columns = append(columns, key)
+		}
+	}
+	return columns
+}
+
+func globalSearchRecordID(record interface{}) string {
+	if values, ok := record.(map[string]interface{}); ok {
+		if id, exists := values["id"]; exists && id != nil {
+			return fmt.Sprint(id)
+		}
+		return ""
+	}
+
+	value := reflect.ValueOf(record)
+	if !value.IsValid() {
+		return ""
+	}
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return ""
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return ""
+	}
+
+	idField := value.FieldByName("ID")
+	if !idField.IsValid() {
+		idField = value.FieldByName("Id")
+	}
+	if !idField.IsValid() || !idField.CanInterface() {
+		return ""
+	}
+
+	return fmt.Sprint(idField.Interface())
+}
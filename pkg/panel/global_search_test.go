@@ -0,0 +1,184 @@
+package panel
+
+import (
+	stdcontext "context"
+	"strings"
+	"testing"
+	"time"
+
+	appContext "github.com/ferdiunal/panel.go/pkg/context"
+	"github.com/ferdiunal/panel.go/pkg/data"
+	"github.com/ferdiunal/panel.go/pkg/fields"
+	"github.com/ferdiunal/panel.go/pkg/resource"
+)
+
+type globalSearchProduct struct {
+	ID   uint   `json:"id" gorm:"primaryKey"`
+	Name string `json:"name"`
+	SKU  string `json:"sku"`
+}
+
+func globalSearchProductFields() []fields.Element {
+	return []fields.Element{
+		fields.ID(),
+		fields.Text("Name", "name").Searchable(),
+		fields.Text("SKU", "sku"),
+	}
+}
+
+// globalSearchProductOptions, ürün resource'larını kayıt başlığı, alt başlık ve ikonla yapılandırır.
+func globalSearchProductOptions(res *resource.OptimizedBase) {
+	res.SetIcon("package")
+	res.SetRecordTitleKey("name")
+	res.SetGlobalSearchSubtitleKey("sku")
+}
+
+func setupGlobalSearchPanel(t *testing.T, cfg Config) *Panel {
+	t.Helper()
+	p := newIsolatedTestPanel(t, cfg)
+	registerTestResource(t, p, &globalSearchProduct{}, "products", globalSearchProductFields, globalSearchProductOptions)
+	registerTestResource(t, p, &globalSearchProduct{}, "archived-products", globalSearchProductFields, globalSearchProductOptions,
+		func(res *resource.OptimizedBase) { res.SetGlobalSearch(false) })
+
+	for _, product := range []globalSearchProduct{
+		{Name: "Red Shoe", SKU: "SKU-1"},
+		{Name: "Blue Shoe", SKU: "SKU-2"},
+		{Name: "Green Hat", SKU: "SKU-3"},
+	} {
+		if err := p.Db.Create(&product).Error; err != nil {
+			t.Fatalf("failed to seed test model: %v", err)
+		}
+	}
+	return p
+}
+
+func TestGlobalSearch_GroupsResultsPerResource(t *testing.T) {
+	p := setupGlobalSearchPanel(t, Config{})
+	sessionCookie := registerAndLoginTestUser(t, p, "global-search@example.com")
+
+	var payload struct {
+		Data []globalSearchGroup `json:"data"`
+		Meta struct {
+			Total    int64    `json:"total"`
+			TimedOut []string `json:"timed_out"`
+		} `json:"meta"`
+	}
+	resp := testJSONRequest(t, p, sessionCookie, "GET", "/api/internal/search?q=shoe", nil, &payload)
+	if resp.StatusCode != 200 {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	if len(payload.Data) != 1 {
+		t.Fatalf("expected only the products group, got %+v", payload.Data)
+	}
+	group := payload.Data[0]
+	if group.Resource != "products" || group.Total != 2 || len(group.Results) != 2 {
+		t.Fatalf("unexpected products group: %+v", group)
+	}
+	if payload.Meta.Total != 2 || len(payload.Meta.TimedOut) != 0 {
+		t.Fatalf("unexpected meta: %+v", payload.Meta)
+	}
+
+	for _, result := range group.Results {
+		if !strings.HasSuffix(result.Title, "Shoe") {
+			t.Fatalf("expected record title from name field, got %q", result.Title)
+		}
+		if !strings.HasPrefix(result.Subtitle, "SKU-") {
+			t.Fatalf("expected subtitle from sku field, got %q", result.Subtitle)
+		}
+		if result.URL != "/resource/products/"+result.ID || result.Icon != "package" {
+			t.Fatalf("unexpected result link/icon: %+v", result)
+		}
+	}
+}
+
+func TestGlobalSearch_ShortQueryAndLimit(t *testing.T) {
+	p := setupGlobalSearchPanel(t, Config{GlobalSearch: GlobalSearchConfig{MaxLimit: 1}})
+	sessionCookie := registerAndLoginTestUser(t, p, "global-search-limit@example.com")
+
+	var shortPayload struct {
+		Data []globalSearchGroup `json:"data"`
+	}
+	testJSONRequest(t, p, sessionCookie, "GET", "/api/internal/search?q=s", nil, &shortPayload)
+	if len(shortPayload.Data) != 0 {
+		t.Fatalf("expected no results for short query, got %+v", shortPayload.Data)
+	}
+
+	var payload struct {
+		Data []globalSearchGroup `json:"data"`
+	}
+	testJSONRequest(t, p, sessionCookie, "GET", "/api/internal/search?q=shoe&limit=10", nil, &payload)
+	if len(payload.Data) != 1 || len(payload.Data[0].Results) != 1 || payload.Data[0].Total != 2 {
+		t.Fatalf("expected limit to be capped by MaxLimit, got %+v", payload.Data)
+	}
+}
+
+func TestGlobalSearch_RequiresSession(t *testing.T) {
+	p := setupGlobalSearchPanel(t, Config{})
+
+	resp := testJSONRequest(t, p, nil, "GET", "/api/internal/search?q=shoe", nil, nil)
+	if resp.StatusCode != 401 {
+		t.Fatalf("expected status 401 without session, got %d", resp.StatusCode)
+	}
+}
+
+func TestGlobalSearch_SkipsEncryptedOnlySearchableResources(t *testing.T) {
+	p := setupGlobalSearchPanel(t, Config{})
+	encrypted := registerTestResource(t, p, &globalSearchProduct{}, "encrypted-products", func() []fields.Element {
+		return []fields.Element{
+			fields.ID(),
+			fields.Text("Name", "name").Searchable().Encrypted(),
+			fields.Text("SKU", "sku"),
+		}
+	}, globalSearchProductOptions)
+	sessionCookie := registerAndLoginTestUser(t, p, "global-search-encrypted@example.com")
+
+	if columns := globalSearchColumns(encrypted.Fields()); len(columns) != 0 {
+		t.Fatalf("expected encrypted fields to be excluded from search columns, got %v", columns)
+	}
+
+	var payload struct {
+		Data []globalSearchGroup `json:"data"`
+	}
+	testJSONRequest(t, p, sessionCookie, "GET", "/api/internal/search?q=no-such-product", nil, &payload)
+	for _, group := range payload.Data {
+		if group.Resource == "encrypted-products" {
+			t.Fatalf("expected encrypted-only resource to be skipped, got %+v", group)
+		}
+	}
+	if len(payload.Data) != 0 {
+		t.Fatalf("expected no results for unmatched query, got %+v", payload.Data)
+	}
+}
+
+type slowGlobalSearchProvider struct {
+	data.DataProvider
+	delay time.Duration
+}
+
+func (p *slowGlobalSearchProvider) Index(_ *appContext.Context, req data.QueryRequest) (*data.QueryResponse, error) {
+	time.Sleep(p.delay)
+	return &data.QueryResponse{Page: req.Page, PerPage: req.PerPage}, nil
+}
+
+func TestRunGlobalSearch_MarksSlowTargetsAsTimedOut(t *testing.T) {
+	targets := []globalSearchTarget{
+		{slug: "fast", provider: &slowGlobalSearchProvider{}},
+		{slug: "slow", provider: &slowGlobalSearchProvider{delay: time.Second}},
+	}
+
+	ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	outcomes := runGlobalSearch(ctx, targets, "query", 2)
+	if elapsed := time.Since(started); elapsed > 500*time.Millisecond {
+		t.Fatalf("expected fan-out to stop at the deadline, took %s", elapsed)
+	}
+	if outcomes[0] == nil || outcomes[0].err != nil {
+		t.Fatalf("expected fast target to complete, got %+v", outcomes[0])
+	}
+	if outcomes[1] != nil {
+		t.Fatalf("expected slow target to be reported as timed out, got %+v", outcomes[1])
+	}
+}
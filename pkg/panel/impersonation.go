@@ -0,0 +1,70 @@
+package panel
+
+import (
+	authPolicy "github.com/ferdiunal/panel.go/pkg/auth"
+	"github.com/ferdiunal/panel.go/pkg/context"
+	"github.com/ferdiunal/panel.go/pkg/domain/session"
+	"github.com/ferdiunal/panel.go/pkg/domain/user"
+	"github.com/ferdiunal/panel.go/pkg/middleware"
+	"github.com/ferdiunal/panel.go/pkg/permission"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ImpersonatePermission, kullanıcıların başka kullanıcıların kimliğine bürünebilmesi
+// için rolünde bulunması gereken izindir (permissions.toml).
+const ImpersonatePermission = "users.impersonate"
+
+// authorizeImpersonation, POST /auth/impersonate isteğini iki aşamada yetkilendirir:
+// oturumdaki kullanıcının rolü ImpersonatePermission iznine sahip olmalı ve "users"
+// kaynağının policy'si auth.ImpersonationPolicy uygulayıp hedef kullanıcı için true
+// döndürmelidir. Permission manager yüklenmemişse izin yalnızca admin rolüne verilir.
+func (p *Panel) authorizeImpersonation(c *context.Context, target *user.User) bool {
+	if apiKeyAuth, ok := c.Locals(middleware.APIKeyAuthenticatedLocalKey).(bool); ok && apiKeyAuth {
+		return false
+	}
+	if sess, ok := c.Locals("session").(*session.Session); !ok || sess.IsImpersonated() {
+		return false
+	}
+
+	current := c.User()
+	if current == nil {
+		return false
+	}
+	if mgr := permission.GetInstance(); mgr != nil {
+		if !mgr.HasPermission(current.Role, ImpersonatePermission) {
+			return false
+		}
+	} else if current.Role != "admin" {
+		return false
+	}
+
+	res, ok := p.resolveResourceForRequest(c, "users")
+	if !ok || res.Policy() == nil {
+		return false
+	}
+	policy, ok := res.Policy().(authPolicy.ImpersonationPolicy)
+	if !ok {
+		return false
+	}
+	return policy.Impersonate(c, target)
+}
+
+// impersonationBanner, /api/init yanıtındaki kalıcı "başka kullanıcı olarak
+// görüntülüyorsunuz" banner'ı için durumu üretir.
+func (p *Panel) impersonationBanner(c *context.Context) fiber.Map {
+	banner := fiber.Map{"active": false}
+	if p.authHandler == nil {
+		return banner
+	}
+	impersonator := p.authHandler.CurrentImpersonator(c)
+	if impersonator == nil {
+		return banner
+	}
+	banner["active"] = true
+	banner["impersonator"] = fiber.Map{
+		"id":    impersonator.ID,
+		"name":  impersonator.Name,
+		"email": impersonator.Email,
+	}
+	return banner
+}
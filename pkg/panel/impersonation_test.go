@@ -0,0 +1,139 @@
+package panel
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	appConfig "github.com/ferdiunal/panel.go/pkg/config"
+	"github.com/ferdiunal/panel.go/pkg/domain/user"
+)
+
+func loadTestUser(t *testing.T, p *Panel, email string) user.User {
+	t.Helper()
+	var stored user.User
+	if err := p.Db.Where("email = ?", email).First(&stored).Error; err != nil {
+		t.Fatalf("failed to load %s: %v", email, err)
+	}
+	return stored
+}
+
+func TestImpersonation_StartGuardAndStop(t *testing.T) {
+	auditPath := filepath.Join(t.TempDir(), "audit.log")
+	security := appConfig.DevelopmentSecurityConfig()
+	security.Audit.Destination = "file"
+	security.Audit.FilePath = auditPath
+	security.Audit.LogLevel = "security"
+	p := setupSecurityPanel(t, security)
+
+	adminEmail := "support@example.com"
+	admin := registerAndLoginTestUser(t, p, adminEmail)
+	memberEmail := "customer@example.com"
+	registerTestUser(t, p, memberEmail)
+	member := loadTestUser(t, p, memberEmail)
+
+	resp, payload := twoFactorRequest(t, p, "POST", "/api/internal/auth/impersonate", admin, map[string]interface{}{"user_id": member.ID})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("impersonate: expected 200, got %d (%v)", resp.StatusCode, payload)
+	}
+	impersonated := sessionCookie(resp)
+	if impersonated == nil || payload["impersonating"] != true {
+		t.Fatalf("expected impersonation session, got %v", payload)
+	}
+	stored := findStoredSession(t, p, impersonated.Value)
+	if stored.UserID != member.ID || stored.ImpersonatorID == nil || *stored.ImpersonatorID != loadTestUser(t, p, adminEmail).ID {
+		t.Fatalf("session must record the original user, got %+v", stored)
+	}
+	if resp, _ := twoFactorRequest(t, p, "GET", "/api/internal/auth/sessions", admin, nil); resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("original session should be replaced, got %d", resp.StatusCode)
+	}
+
+	_, initData := twoFactorRequest(t, p, "GET", "/api/internal/init", impersonated, nil)
+	banner, _ := initData["impersonation"].(map[string]interface{})
+	if banner["active"] != true {
+		t.Fatalf("expected impersonation banner in init data, got %v", initData["impersonation"])
+	}
+	if impersonator, _ := banner["impersonator"].(map[string]interface{}); impersonator["email"] != adminEmail {
+		t.Fatalf("expected impersonator details, got %v", banner)
+	}
+
+	// Impersonated sessions cannot manage API keys, change credentials or chain impersonation.
+	if resp, _ := twoFactorRequest(t, p, "GET", "/api/internal/api-keys", impersonated, nil); resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("api keys while impersonating: expected 403, got %d", resp.StatusCode)
+	}
+	if resp, _ := twoFactorRequest(t, p, "POST", "/api/internal/auth/two-factor/enroll", impersonated, nil); resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("2FA enroll while impersonating: expected 403, got %d", resp.StatusCode)
+	}
+	if resp, _ := twoFactorRequest(t, p, "PUT", "/api/internal/auth/password", impersonated, map[string]string{"current_password": "password", "new_password": "taken-over"}); resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("password change while impersonating: expected 403, got %d", resp.StatusCode)
+	}
+	if resp, _ := twoFactorRequest(t, p, "PUT", "/api/internal/auth/profile", impersonated, map[string]string{"email": "attacker@example.com"}); resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("email change while impersonating: expected 403, got %d", resp.StatusCode)
+	}
+	if resp, _ := twoFactorRequest(t, p, "POST", "/api/internal/auth/impersonate", impersonated, map[string]interface{}{"user_id": member.ID}); resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("chained impersonation: expected 403, got %d", resp.StatusCode)
+	}
+	if resp, _ := twoFactorRequest(t, p, "GET", "/api/internal/auth/sessions", impersonated, nil); resp.StatusCode != http.StatusOK {
+		t.Fatalf("read-only requests should work while impersonating, got %d", resp.StatusCode)
+	}
+
+	resp, payload = twoFactorRequest(t, p, "POST", "/api/internal/auth/impersonate/stop", impersonated, nil)
+	if resp.StatusCode != http.StatusOK || payload["impersonating"] != false {
+		t.Fatalf("stop: expected 200, got %d (%v)", resp.StatusCode, payload)
+	}
+	restored := sessionCookie(resp)
+	if restored == nil {
+		t.Fatal("stop: expected session cookie")
+	}
+	if regular := findStoredSession(t, p, restored.Value); regular.IsImpersonated() {
+		t.Fatal("stop should issue a regular session for the original user")
+	}
+	if resp, _ := twoFactorRequest(t, p, "GET", "/api/internal/auth/sessions", impersonated, nil); resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("impersonation session after stop: expected 401, got %d", resp.StatusCode)
+	}
+	_, initData = twoFactorRequest(t, p, "GET", "/api/internal/init", restored, nil)
+	if banner, _ := initData["impersonation"].(map[string]interface{}); banner["active"] != false {
+		t.Fatalf("banner should be cleared after stop, got %v", banner)
+	}
+	if resp, _ := twoFactorRequest(t, p, "POST", "/api/internal/auth/impersonate/stop", restored, nil); resp.StatusCode != http.StatusConflict {
+		t.Fatalf("stop without impersonation: expected 409, got %d", resp.StatusCode)
+	}
+
+	contents, err := os.ReadFile(auditPath)
+	if err != nil {
+		t.Fatalf("expected audit log file: %v", err)
+	}
+	for _, event := range []string{`"event_type":"impersonation_start"`, `"event_type":"impersonation_stop"`, `"target_email":"customer@example.com"`} {
+		if !strings.Contains(string(contents), event) {
+			t.Fatalf("expected %s in audit log, got %s", event, contents)
+		}
+	}
+}
+
+func TestImpersonation_DeniedByRoleAndPolicy(t *testing.T) {
+	p := setupSecurityPanel(t, appConfig.DevelopmentSecurityConfig())
+
+	adminEmail := "impersonation-admin@example.com"
+	admin := registerAndLoginTestUser(t, p, adminEmail)
+	otherAdminEmail := "impersonation-admin2@example.com"
+	registerAndLoginTestUser(t, p, otherAdminEmail)
+	memberEmail := "impersonation-member@example.com"
+	registerTestUser(t, p, memberEmail)
+	member := signInFrom(t, p, memberEmail, firefoxOnLinux)
+
+	// Without a permission manager only admins hold users.impersonate.
+	otherAdmin := loadTestUser(t, p, otherAdminEmail)
+	if resp, _ := twoFactorRequest(t, p, "POST", "/api/internal/auth/impersonate", member, map[string]interface{}{"user_id": otherAdmin.ID}); resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("member impersonating: expected 403, got %d", resp.StatusCode)
+	}
+
+	self := loadTestUser(t, p, adminEmail)
+	if resp, _ := twoFactorRequest(t, p, "POST", "/api/internal/auth/impersonate", admin, map[string]interface{}{"user_id": self.ID}); resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("self impersonation: expected 403, got %d", resp.StatusCode)
+	}
+	if resp, _ := twoFactorRequest(t, p, "POST", "/api/internal/auth/impersonate", admin, map[string]interface{}{"user_id": 9999}); resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("unknown user: expected 404, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,167 @@
+package panel
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/ferdiunal/panel.go/pkg/fields"
+)
+
+type filterProduct struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	Name        string    `json:"name"`
+	Price       float64   `json:"price"`
+	Active      bool      `json:"active"`
+	Status      string    `json:"status"`
+	AuthorID    uint      `json:"author_id"`
+	PublishedAt time.Time `json:"published_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func filterProductFields() []fields.Element {
+	return []fields.Element{
+		fields.ID(),
+		fields.Text("Name", "name").Filterable(),
+		fields.Number("Price", "price").Filterable(),
+		fields.Switch("Active", "active").Filterable(),
+		fields.Select("Status", "status").Options(map[string]string{"draft": "Draft", "published": "Published"}).Filterable(),
+		fields.BelongsTo("Author", "author_id", "filter-authors").Filterable(),
+		fields.DateTime("Published At", "published_at").Filterable(),
+	}
+}
+
+func setupIndexFilterPanel(t *testing.T) (*Panel, *http.Cookie) {
+	t.Helper()
+	p := setupStoragePanel(t)
+	registerTestResource(t, p, &filterProduct{}, "filter-products", filterProductFields)
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 15, 0, 0, 0, time.UTC) }
+	for _, product := range []filterProduct{
+		{Name: "Blue Pen", Price: 2.5, Active: true, Status: "published", AuthorID: 1, PublishedAt: day(1)},
+		{Name: "Red Pen", Price: 3, Active: false, Status: "draft", AuthorID: 2, PublishedAt: day(2)},
+		{Name: "Notebook", Price: 12, Active: true, Status: "published", AuthorID: 2, PublishedAt: day(3)},
+		{Name: "Backpack", Price: 45, Active: true, Status: "draft", AuthorID: 3, PublishedAt: day(4)},
+	} {
+		if err := p.Db.Create(&product).Error; err != nil {
+			t.Fatalf("failed to seed product: %v", err)
+		}
+	}
+
+	return p, registerAndLoginTestUser(t, p, "filters@example.com")
+}
+
+type indexFilterResponse struct {
+	Data []map[string]struct {
+		Data interface{} `json:"data"`
+	} `json:"data"`
+	Meta struct {
+		Filters []fields.IndexFilter `json:"filters"`
+	} `json:"meta"`
+	Error string `json:"error"`
+}
+
+func indexFilterRequest(t *testing.T, p *Panel, cookie *http.Cookie, filters map[string]string) (int, indexFilterResponse) {
+	t.Helper()
+	values := url.Values{}
+	for key, value := range filters {
+		values.Set("filter-products[filters]"+key, value)
+	}
+	var payload indexFilterResponse
+	resp := testJSONRequest(t, p, cookie, "GET", "/api/internal/resource/filter-products?"+values.Encode(), nil, &payload)
+	return resp.StatusCode, payload
+}
+
+func indexFilterNames(payload indexFilterResponse) []string {
+	names := make([]string, 0, len(payload.Data))
+	for _, row := range payload.Data {
+		if name, ok := row["name"].Data.(string); ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestIndexFilters_MetadataFromFilterableFields(t *testing.T) {
+	p, cookie := setupIndexFilterPanel(t)
+
+	status, payload := indexFilterRequest(t, p, cookie, nil)
+	if status != http.StatusOK {
+		t.Fatalf("expected index to load, got %d (%s)", status, payload.Error)
+	}
+	types := map[string]fields.FilterType{}
+	for _, filter := range payload.Meta.Filters {
+		types[filter.Key] = filter.Type
+	}
+	expected := map[string]fields.FilterType{
+		"name":         fields.FilterTypeText,
+		"price":        fields.FilterTypeNumber,
+		"active":       fields.FilterTypeBoolean,
+		"status":       fields.FilterTypeSelect,
+		"author_id":    fields.FilterTypeBelongsTo,
+		"published_at": fields.FilterTypeDate,
+	}
+	if len(types) != len(expected) {
+		t.Fatalf("expected %d filters, got %v", len(expected), types)
+	}
+	for key, filterType := range expected {
+		if types[key] != filterType {
+			t.Errorf("expected %s to be a %s filter, got %q", key, filterType, types[key])
+		}
+	}
+}
+
+func TestIndexFilters_AppliesTypedFilters(t *testing.T) {
+	p, cookie := setupIndexFilterPanel(t)
+
+	cases := []struct {
+		name    string
+		filters map[string]string
+		want    []string
+	}{
+		{"text contains", map[string]string{"[name][like]": "Pen"}, []string{"Blue Pen", "Red Pen"}},
+		{"number range", map[string]string{"[price][between]": "3,20"}, []string{"Notebook", "Red Pen"}},
+		{"open number range", map[string]string{"[price][between]": "10,"}, []string{"Backpack", "Notebook"}},
+		{"boolean", map[string]string{"[active][eq]": "false"}, []string{"Red Pen"}},
+		{"select options", map[string]string{"[status][in]": "draft"}, []string{"Backpack", "Red Pen"}},
+		{"belongs to", map[string]string{"[author_id][eq]": "2"}, []string{"Notebook", "Red Pen"}},
+		{"date day", map[string]string{"[published_at][eq]": "2024-03-02"}, []string{"Red Pen"}},
+		{"date range", map[string]string{"[published_at][between]": "2024-03-02,2024-03-03"}, []string{"Notebook", "Red Pen"}},
+		{"combined", map[string]string{"[active][eq]": "true", "[price][lte]": "12"}, []string{"Blue Pen", "Notebook"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, payload := indexFilterRequest(t, p, cookie, tc.filters)
+			if status != http.StatusOK {
+				t.Fatalf("expected filtered index, got %d (%s)", status, payload.Error)
+			}
+			names := indexFilterNames(payload)
+			if len(names) != len(tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, names)
+			}
+			for i := range names {
+				if names[i] != tc.want[i] {
+					t.Fatalf("expected %v, got %v", tc.want, names)
+				}
+			}
+		})
+	}
+}
+
+func TestIndexFilters_RejectsInvalidValues(t *testing.T) {
+	p, cookie := setupIndexFilterPanel(t)
+
+	for _, filters := range []map[string]string{
+		{"[price][gte]": "cheap"},
+		{"[status][eq]": "archived"},
+		{"[active][like]": "yes"},
+		{"[published_at][gt]": "yesterday"},
+	} {
+		status, payload := indexFilterRequest(t, p, cookie, filters)
+		if status != http.StatusUnprocessableEntity || payload.Error == "" {
+			t.Errorf("expected %v to be rejected, got %d", filters, status)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package panel
+
+import (
+	stdcontext "context"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+
+	"github.com/ferdiunal/panel.go/pkg/fields"
+	"github.com/ferdiunal/panel.go/pkg/handler"
+	"github.com/ferdiunal/panel.go/pkg/resource"
+	"github.com/ferdiunal/panel.go/pkg/storage"
+	"gorm.io/gorm"
+)
+
+// mediaRegenerateBatchSize, dönüşümler yeniden üretilirken tek seferde okunan kayıt sayısıdır.
+const mediaRegenerateBatchSize = 200
+
+// MediaRegenerateOptions, RegenerateMedia çalışmasının ayarlarıdır.
+type MediaRegenerateOptions struct {
+	// Resources, yalnızca bu resource slug'larını işler (boşsa tümü).
+	Resources []string
+	// Conversions, yalnızca bu adlardaki dönüşümleri üretir (boşsa tümü).
+	Conversions []string
+	// OnlyMissing true ise diskte bulunan dönüşümler yeniden üretilmez.
+	OnlyMissing bool
+}
+
+// MediaRegenerateResult, bir resource için RegenerateMedia çalışmasının özetidir.
+type MediaRegenerateResult struct {
+	Resource  string   `json:"resource"`
+	Fields    []string `json:"fields"`
+	Scanned   int      `json:"scanned"`
+	Generated int      `json:"generated"`
+	Failed    []string `json:"failed"`
+}
+
+// mediaField, dönüşümleri yeniden üretilecek bir resim alanıdır.
+type mediaField struct {
+	element     fields.Element
+	disk        storage.Disk
+	conversions []fields.ImageConversion
+}
+
+// / # RegenerateMedia Metodu
+// /
+// / Conversions() tanımlı resim alanlarının dönüşümlerini diskteki orijinallerden
+// / yeniden üretir. Dönüşüm eklendikten veya boyutları değiştirildikten sonra var
+// / olan yüklemeleri güncellemek için kullanılır.
+// /
+// / ## Önemli Notlar
+// / - Soft delete edilmiş kayıtların resimleri de işlenir
+// / - Dönüşümler orijinalin yanına yazılır; var olanların üzerine yazılır
+// / - Okunamayan veya çözümlenemeyen resimler Failed listesine eklenir ve atlanır
+func (p *Panel) RegenerateMedia(ctx stdcontext.Context, opts MediaRegenerateOptions) ([]MediaRegenerateResult, error) {
+	if ctx == nil {
+		ctx = stdcontext.Background()
+	}
+	snapshot := p.loadRegistrySnapshot()
+	if snapshot == nil || p.disks == nil {
+		return nil, nil
+	}
+
+	selected := make([]string, 0, len(snapshot.resources))
+	if len(opts.Resources) > 0 {
+		for _, slug := range opts.Resources {
+			if _, ok := snapshot.resources[slug]; !ok {
+				return nil, fmt.Errorf("resource not found: %s", slug)
+			}
+			selected = append(selected, slug)
+		}
+	} else {
+		for slug := range snapshot.resources {
+			selected = append(selected, slug)
+		}
+	}
+	sort.Strings(selected)
+
+	results := make([]MediaRegenerateResult, 0, len(selected))
+	for _, slug := range selected {
+		res := snapshot.resources[slug]
+		if res == nil || res.Model() == nil {
+			continue
+		}
+		result, err := p.regenerateResourceMedia(ctx, slug, res, opts)
+		if err != nil {
+			return results, fmt.Errorf("%s: %w", slug, err)
+		}
+		if result != nil {
+			results = append(results, *result)
+		}
+	}
+	return results, nil
+}
+
+func (p *Panel) regenerateResourceMedia(ctx stdcontext.Context, slug string, res resource.Resource, opts MediaRegenerateOptions) (*MediaRegenerateResult, error) {
+	wanted := make(map[string]bool, len(opts.Conversions))
+	for _, name := range opts.Conversions {
+		wanted[name] = true
+	}
+
+	var media []mediaField
+	result := &MediaRegenerateResult{Resource: slug}
+	for _, element := range res.Fields() {
+		if element == nil || !handler.IsTrackedFileElement(element) {
+			continue
+		}
+		var conversions []fields.ImageConversion
+		for _, conversion := range fields.ImageConversions(element) {
+			if len(wanted) == 0 || wanted[conversion.Name] {
+				conversions = append(conversions, conversion)
+			}
+		}
+		name, dir := element.GetStorageDisk(), element.GetStoragePath()
+		if len(conversions) == 0 || (name == "" && dir == "") {
+			continue
+		}
+		disk, err := p.disks.Disk(name)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", element.GetKey(), err)
+		}
+		media = append(media, mediaField{element: element, disk: disk, conversions: conversions})
+		result.Fields = append(result.Fields, element.GetKey())
+	}
+	if len(media) == 0 {
+		return nil, nil
+	}
+
+	stmt := &gorm.Statement{DB: p.Db}
+	if err := stmt.Parse(res.Model()); err != nil {
+		return nil, err
+	}
+	records := reflect.New(reflect.SliceOf(reflect.PointerTo(stmt.Schema.ModelType)))
+	query := p.Db.WithContext(ctx).Unscoped().Model(reflect.New(stmt.Schema.ModelType).Interface())
+	err := query.FindInBatches(records.Interface(), mediaRegenerateBatchSize, func(tx *gorm.DB, _ int) error {
+		rows := records.Elem()
+		for i := 0; i < rows.Len(); i++ {
+			record := rows.Index(i).Interface()
+			for _, field := range media {
+				values, _ := handler.FileFieldValues(record, field.element)
+				for _, value := range values {
+					original, ok := storage.PathFromValue(field.disk, value)
+					if !ok || !storage.CanConvertImage(original) {
+						continue
+					}
+					result.Scanned++
+					generated, err := regenerateConversions(ctx, field.disk, original, field.conversions, opts.OnlyMissing)
+					result.Generated += generated
+					if err != nil {
+						log.Printf("[storage] regenerating conversions of %s failed: %v", original, err)
+						result.Failed = append(result.Failed, original)
+					}
+				}
+			}
+		}
+		return ctx.Err()
+	}).Error
+	return result, err
+}
+
+// regenerateConversions, orijinalin dönüşümlerini üretir ve yazılan dönüşüm sayısını döner.
+func regenerateConversions(ctx stdcontext.Context, disk storage.Disk, original string, conversions []fields.ImageConversion, onlyMissing bool) (int, error) {
+	if onlyMissing {
+		missing := make([]fields.ImageConversion, 0, len(conversions))
+		for _, conversion := range conversions {
+			exists, err := disk.Exists(ctx, conversion.Path(original))
+			if err != nil {
+				return 0, err
+			}
+			if !exists {
+				missing = append(missing, conversion)
+			}
+		}
+		conversions = missing
+	}
+	if len(conversions) == 0 {
+		return 0, nil
+	}
+	if err := handler.GenerateImageConversions(ctx, disk, original, conversions); err != nil {
+		return 0, err
+	}
+	return len(conversions), nil
+}
@@ -0,0 +1,257 @@
+package panel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ferdiunal/panel.go/pkg/fields"
+	"github.com/ferdiunal/panel.go/pkg/storage"
+	"golang.org/x/image/webp"
+)
+
+type mediaProduct struct {
+	ID    uint   `json:"id" gorm:"primaryKey"`
+	Name  string `json:"name"`
+	Cover string `json:"cover"`
+}
+
+func mediaProductFields() []fields.Element {
+	cover := fields.Image("Cover", "cover")
+	cover.Store("media", "covers")
+	cover.Conversions(
+		fields.Conversion("thumb", 20, 20).Cropped(),
+		fields.Conversion("preview", 40, 0).As("webp"),
+	)
+	return []fields.Element{
+		fields.ID(),
+		fields.Text("Name", "name"),
+		cover,
+	}
+}
+
+func setupMediaPanel(t *testing.T) *Panel {
+	t.Helper()
+	p := setupStoragePanel(t)
+	registerTestResource(t, p, &mediaProduct{}, "media-products", mediaProductFields)
+	return p
+}
+
+// mediaPNG, verilen boyutlarda renk geçişli bir PNG üretir.
+func mediaPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: uint8(x * 2), G: uint8(y * 2), B: 90, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png encode failed: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func mediaUpload(t *testing.T, p *Panel, cookie *http.Cookie, content []byte) *http.Response {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	_ = writer.WriteField("name", "Widget")
+	part, _ := writer.CreateFormFile("cover", "cover.png")
+	part.Write(content)
+	writer.Close()
+
+	req := httptest.NewRequest("POST", "/api/internal/resource/media-products", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.AddCookie(cookie)
+	resp, err := testFiberRequest(p.Fiber, req)
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	return resp
+}
+
+func mediaImage(t *testing.T, p *Panel, path string) (image.Image, string) {
+	t.Helper()
+	disk, _ := p.Disks().Disk("media")
+	reader, err := disk.Get(context.Background(), path)
+	if err != nil {
+		t.Fatalf("expected %s: %v", path, err)
+	}
+	defer reader.Close()
+	content, _ := io.ReadAll(reader)
+	if strings.HasSuffix(path, ".webp") {
+		img, err := webp.Decode(bytes.NewReader(content))
+		if err != nil {
+			t.Fatalf("expected decodable webp at %s: %v", path, err)
+		}
+		return img, "webp"
+	}
+	img, format, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("expected image at %s: %v", path, err)
+	}
+	return img, format
+}
+
+func mediaImageSize(t *testing.T, p *Panel, path string) (int, int, string) {
+	t.Helper()
+	img, format := mediaImage(t, p, path)
+	return img.Bounds().Dx(), img.Bounds().Dy(), format
+}
+
+func TestMedia_UploadGeneratesConversions(t *testing.T) {
+	p := setupMediaPanel(t)
+	cookie := registerAndLoginTestUser(t, p, "media@example.com")
+
+	source := mediaPNG(t, 80, 60)
+	resp := mediaUpload(t, p, cookie, source)
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		t.Fatalf("expected created product, got %d", resp.StatusCode)
+	}
+	var payload struct {
+		Data map[string]struct {
+			Data        string            `json:"data"`
+			Conversions map[string]string `json:"conversions"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+
+	var product mediaProduct
+	if err := p.Db.First(&product).Error; err != nil {
+		t.Fatalf("expected stored product: %v", err)
+	}
+	original := strings.TrimPrefix(product.Cover, "https://cdn.example.com/")
+	base := strings.TrimSuffix(original, ".png")
+	paths := memoryDisk(t, p, "media").Paths()
+	want := []string{original, base + "-preview.webp", base + "-thumb.png"}
+	sort.Strings(want)
+	if strings.Join(paths, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected original with conversions, got %v", paths)
+	}
+
+	if w, h, format := mediaImageSize(t, p, base+"-thumb.png"); w != 20 || h != 20 || format != "png" {
+		t.Fatalf("expected 20x20 png thumb, got %dx%d %s", w, h, format)
+	}
+	if w, h, _ := mediaImageSize(t, p, base+"-preview.webp"); w != 40 || h != 30 {
+		t.Fatalf("expected 40x30 webp preview, got %dx%d", w, h)
+	}
+	// Kayıpsız WebP önizleme, aynı boyutlandırmanın PNG çıktısıyla eşleşmeli
+	preview, _ := mediaImage(t, p, base+"-preview.webp")
+	reference, _, err := storage.ConvertImage(source, storage.ImageOptions{Width: 40, Format: "png"})
+	if err != nil {
+		t.Fatalf("reference conversion failed: %v", err)
+	}
+	resized, _ := png.Decode(bytes.NewReader(reference))
+	for _, point := range []image.Point{{0, 0}, {20, 15}, {39, 29}, {7, 22}} {
+		got := color.NRGBAModel.Convert(preview.At(point.X, point.Y))
+		expected := color.NRGBAModel.Convert(resized.At(point.X, point.Y))
+		if got != expected {
+			t.Fatalf("preview pixel %v: expected %v, got %v", point, expected, got)
+		}
+	}
+
+	cover := payload.Data["cover"]
+	if cover.Data != product.Cover ||
+		cover.Conversions["thumb"] != "https://cdn.example.com/"+base+"-thumb.png" ||
+		cover.Conversions["preview"] != "https://cdn.example.com/"+base+"-preview.webp" {
+		t.Fatalf("expected conversion URLs in the response, got %+v", cover)
+	}
+}
+
+func TestMedia_UndecodableImageIsRejected(t *testing.T) {
+	p := setupMediaPanel(t)
+	cookie := registerAndLoginTestUser(t, p, "media-invalid@example.com")
+
+	// PNG imzası taşıyan ama çözümlenemeyen içerik
+	resp := mediaUpload(t, p, cookie, append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 64)...))
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422 for an undecodable image, got %d", resp.StatusCode)
+	}
+	if paths := memoryDisk(t, p, "media").Paths(); len(paths) != 0 {
+		t.Fatalf("expected rejected upload to leave no files, got %v", paths)
+	}
+}
+
+func TestMedia_CleanupRemovesConversionsWithOriginal(t *testing.T) {
+	p := setupMediaPanel(t)
+	cookie := registerAndLoginTestUser(t, p, "media-cleanup@example.com")
+	media := memoryDisk(t, p, "media")
+
+	resp := mediaUpload(t, p, cookie, mediaPNG(t, 80, 60))
+	resp.Body.Close()
+	var product mediaProduct
+	p.Db.First(&product)
+	if paths := media.Paths(); len(paths) != 3 {
+		t.Fatalf("expected original with two conversions, got %v", paths)
+	}
+
+	// Dönüşümler izlendiği için storage:prune onları referanssız saymaz
+	results, err := p.PruneStorage(context.Background(), StoragePruneOptions{OlderThan: 1, DryRun: true})
+	if err != nil {
+		t.Fatalf("prune failed: %v", err)
+	}
+	for _, result := range results {
+		if result.Disk == "media" && len(result.Removed) != 0 {
+			t.Fatalf("expected conversions to be referenced, got %v", result.Removed)
+		}
+	}
+
+	storageCleanupRequest(t, p, cookie, "DELETE", fmt.Sprintf("/api/internal/resource/media-products/%d", product.ID), nil)
+	if paths := media.Paths(); len(paths) != 0 {
+		t.Fatalf("expected conversions to be removed with the original, got %v", paths)
+	}
+}
+
+func TestMediaRegenerateCommand(t *testing.T) {
+	p := setupMediaPanel(t)
+	cookie := registerAndLoginTestUser(t, p, "media-regenerate@example.com")
+	media := memoryDisk(t, p, "media")
+
+	resp := mediaUpload(t, p, cookie, mediaPNG(t, 80, 60))
+	resp.Body.Close()
+	var product mediaProduct
+	p.Db.First(&product)
+	base := strings.TrimSuffix(strings.TrimPrefix(product.Cover, "https://cdn.example.com/"), ".png")
+	if err := media.Delete(context.Background(), base+"-thumb.png"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+
+	var out bytes.Buffer
+	cmd := p.newMediaRegenerateCommand()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--resource", "media-products", "--only-missing"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "media-products [cover]: 1 scanned, 1 generated, 0 failed") {
+		t.Fatalf("unexpected output: %s", out.String())
+	}
+	if w, h, _ := mediaImageSize(t, p, base+"-thumb.png"); w != 20 || h != 20 {
+		t.Fatalf("expected regenerated 20x20 thumb, got %dx%d", w, h)
+	}
+
+	results, err := p.RegenerateMedia(context.Background(), MediaRegenerateOptions{Conversions: []string{"preview"}})
+	if err != nil || len(results) != 1 || results[0].Generated != 1 {
+		t.Fatalf("expected preview to be regenerated, got %+v (err=%v)", results, err)
+	}
+	if _, err := p.RegenerateMedia(context.Background(), MediaRegenerateOptions{Resources: []string{"missing"}}); err == nil {
+		t.Fatal("expected unknown resource to fail")
+	}
+}
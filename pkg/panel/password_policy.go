@@ -0,0 +1,51 @@
+package panel
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ferdiunal/panel.go/pkg/service/auth"
+)
+
+// validatePasswordConfig, negatif sınırları ve bulunamayan sızıntı listesini reddeder.
+func validatePasswordConfig(cfg PasswordConfig) error {
+	var errs []error
+	fail := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("password: "+format, args...))
+	}
+
+	if cfg.MinLength < 0 || cfg.HistorySize < 0 || cfg.MaxAge < 0 {
+		fail("min_length, history_size and max_age cannot be negative")
+	}
+	if cfg.BreachedHashesPath != "" {
+		if _, err := os.Stat(cfg.BreachedHashesPath); err != nil {
+			fail("breached_hashes_path: %v", err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// newPasswordPolicy, PasswordConfig'ten auth servisinin uygulayacağı politikayı oluşturur.
+func newPasswordPolicy(cfg PasswordConfig) (auth.PasswordPolicy, error) {
+	policy := auth.PasswordPolicy{
+		MinLength:        cfg.MinLength,
+		RequireUppercase: cfg.RequireUppercase,
+		RequireLowercase: cfg.RequireLowercase,
+		RequireDigit:     cfg.RequireDigit,
+		RequireSymbol:    cfg.RequireSymbol,
+		MaxAge:           cfg.MaxAge,
+		HistorySize:      cfg.HistorySize,
+	}
+	if err := validatePasswordConfig(cfg); err != nil {
+		return policy, err
+	}
+	if cfg.BreachedHashesPath != "" {
+		checker, err := auth.NewHashRangeChecker(cfg.BreachedHashesPath)
+		if err != nil {
+			return policy, err
+		}
+		policy.Breached = checker
+	}
+	return policy, nil
+}
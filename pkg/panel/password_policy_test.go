@@ -0,0 +1,285 @@
+package panel
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ferdiunal/panel.go/pkg/domain/account"
+	"github.com/ferdiunal/panel.go/pkg/service/auth"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupPasswordPolicyPanel(t *testing.T, password PasswordConfig) *Panel {
+	t.Helper()
+
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", strings.ReplaceAll(t.Name(), "/", "_"))
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect db: %v", err)
+	}
+
+	p := New(Config{
+		Database:    DatabaseConfig{Instance: db},
+		Environment: "test",
+		Password:    password,
+	})
+	t.Cleanup(p.Close)
+	return p
+}
+
+// writeBreachedRange stores password in a k-anonymity range directory next to a padding entry.
+func writeBreachedRange(t *testing.T, password string) string {
+	t.Helper()
+	dir := t.TempDir()
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	body := fmt.Sprintf("00000000000000000000000000000000001:0\r\n%s:42\r\n", hash[5:])
+	if err := os.WriteFile(filepath.Join(dir, hash[:5]+".txt"), []byte(body), 0o600); err != nil {
+		t.Fatalf("write range file: %v", err)
+	}
+	return dir
+}
+
+func signUp(t *testing.T, p *Panel, email, password string) (*http.Response, map[string]interface{}) {
+	t.Helper()
+	return twoFactorRequest(t, p, "POST", "/api/internal/auth/sign-up/email", nil, map[string]string{
+		"name":     "Policy User",
+		"email":    email,
+		"password": password,
+	})
+}
+
+func fieldErrors(payload map[string]interface{}, field string) []string {
+	errs, _ := payload["errors"].(map[string]interface{})
+	raw, _ := errs[field].([]interface{})
+	messages := make([]string, 0, len(raw))
+	for _, m := range raw {
+		if s, ok := m.(string); ok {
+			messages = append(messages, s)
+		}
+	}
+	return messages
+}
+
+func TestPasswordPolicy_RegistrationRejectsWeakAndBreachedPasswords(t *testing.T) {
+	p := setupPasswordPolicyPanel(t, PasswordConfig{
+		MinLength:          10,
+		RequireUppercase:   true,
+		RequireDigit:       true,
+		RequireSymbol:      true,
+		BreachedHashesPath: writeBreachedRange(t, "Summer2024!"),
+	})
+
+	resp, payload := signUp(t, p, "weak@example.com", "weakpass")
+	messages := fieldErrors(payload, "password")
+	if resp.StatusCode != http.StatusUnprocessableEntity || len(messages) != 4 {
+		t.Fatalf("weak password: expected 422 with 4 violations, got %d (%v)", resp.StatusCode, payload)
+	}
+	if !strings.Contains(messages[0], "at least 10") {
+		t.Fatalf("expected configured min length in message, got %v", messages)
+	}
+
+	resp, payload = signUp(t, p, "breached@example.com", "Summer2024!")
+	if messages := fieldErrors(payload, "password"); resp.StatusCode != http.StatusUnprocessableEntity || len(messages) != 1 || !strings.Contains(messages[0], "data breach") {
+		t.Fatalf("breached password: expected 422, got %d (%v)", resp.StatusCode, payload)
+	}
+
+	if resp, payload := signUp(t, p, "strong@example.com", "Correct-Horse-9"); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("strong password: expected 201, got %d (%v)", resp.StatusCode, payload)
+	}
+	var acc account.Account
+	if err := p.Db.Joins("JOIN users ON users.id = accounts.user_id").Where("users.email = ?", "strong@example.com").First(&acc).Error; err != nil {
+		t.Fatalf("credential account not found: %v", err)
+	}
+	if acc.PasswordChangedAt == nil {
+		t.Fatal("registration should record when the password was set")
+	}
+}
+
+func TestPasswordPolicy_HistoryPreventsReuse(t *testing.T) {
+	p := setupPasswordPolicyPanel(t, PasswordConfig{HistorySize: 2})
+
+	email := "history@example.com"
+	if resp, payload := signUp(t, p, email, "first-password"); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("register: expected 201, got %d (%v)", resp.StatusCode, payload)
+	}
+	resp, _ := signInWithPassword(t, p, email, "first-password")
+	cookie := sessionCookie(resp)
+
+	change := func(current, next string) (*http.Response, map[string]interface{}) {
+		t.Helper()
+		return twoFactorRequest(t, p, "PUT", "/api/internal/auth/password", cookie, map[string]string{
+			"current_password": current,
+			"new_password":     next,
+		})
+	}
+
+	resp, payload := change("first-password", "first-password")
+	if messages := fieldErrors(payload, "new_password"); resp.StatusCode != http.StatusUnprocessableEntity || len(messages) != 1 || !strings.Contains(messages[0], "last 2") {
+		t.Fatalf("current password reuse: expected 422, got %d (%v)", resp.StatusCode, payload)
+	}
+	if resp, payload := change("first-password", "second-password"); resp.StatusCode != http.StatusOK {
+		t.Fatalf("change: expected 200, got %d (%v)", resp.StatusCode, payload)
+	}
+	if resp, _ := change("second-password", "first-password"); resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("previous password reuse: expected 422, got %d", resp.StatusCode)
+	}
+	if resp, payload := change("second-password", "third-password"); resp.StatusCode != http.StatusOK {
+		t.Fatalf("change: expected 200, got %d (%v)", resp.StatusCode, payload)
+	}
+
+	// Only the last two passwords are remembered.
+	user := loadTestUser(t, p, email)
+	var remembered int64
+	p.Db.Model(&account.PasswordHistory{}).Where("user_id = ?", user.ID).Count(&remembered)
+	if remembered != 2 {
+		t.Fatalf("expected 2 remembered hashes, got %d", remembered)
+	}
+	if resp, payload := change("third-password", "first-password"); resp.StatusCode != http.StatusOK {
+		t.Fatalf("password outside history: expected 200, got %d (%v)", resp.StatusCode, payload)
+	}
+}
+
+func TestPasswordPolicy_MaxAgeRequiresPasswordChange(t *testing.T) {
+	p := setupPasswordPolicyPanel(t, PasswordConfig{MaxAge: 24 * time.Hour})
+
+	email := "expired@example.com"
+	registerTestUser(t, p, email)
+	resp, payload := signInWithPassword(t, p, email, "password")
+	cookie := sessionCookie(resp)
+	if _, flagged := payload["password_expired"]; flagged {
+		t.Fatalf("fresh password should not be expired, got %v", payload)
+	}
+
+	user := loadTestUser(t, p, email)
+	if err := p.Db.Model(&account.Account{}).Where("user_id = ?", user.ID).
+		Update("password_changed_at", time.Now().Add(-48*time.Hour)).Error; err != nil {
+		t.Fatalf("backdate password: %v", err)
+	}
+
+	resp, payload = twoFactorRequest(t, p, "GET", "/api/internal/auth/sessions", cookie, nil)
+	if resp.StatusCode != http.StatusForbidden || payload["code"] != "password_expired" {
+		t.Fatalf("expired password: expected 403 password_expired, got %d (%v)", resp.StatusCode, payload)
+	}
+	if _, payload := signInWithPassword(t, p, email, "password"); payload["password_expired"] != true {
+		t.Fatalf("sign-in should flag the expired password, got %v", payload)
+	}
+
+	resp, payload = twoFactorRequest(t, p, "PUT", "/api/internal/auth/password", cookie, map[string]string{
+		"current_password": "password",
+		"new_password":     "renewed-password",
+	})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("change expired password: expected 200, got %d (%v)", resp.StatusCode, payload)
+	}
+	if resp, _ := twoFactorRequest(t, p, "GET", "/api/internal/auth/sessions", cookie, nil); resp.StatusCode != http.StatusOK {
+		t.Fatalf("access after change: expected 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestPasswordPolicy_AdminResetUsesPolicy(t *testing.T) {
+	p := setupPasswordPolicyPanel(t, PasswordConfig{HistorySize: 3})
+
+	admin := registerAndLoginTestUser(t, p, "policy-admin@example.com")
+	memberEmail := "policy-member@example.com"
+	registerTestUser(t, p, memberEmail)
+	member := loadTestUser(t, p, memberEmail)
+	path := fmt.Sprintf("/api/internal/resource/users/%d", member.ID)
+
+	resp, payload := twoFactorRequest(t, p, "PUT", path, admin, map[string]interface{}{"password": "short"})
+	if messages := fieldErrors(payload, "password"); resp.StatusCode != http.StatusUnprocessableEntity || len(messages) == 0 || !strings.Contains(messages[0], "at least 8") {
+		t.Fatalf("short reset: expected 422, got %d (%v)", resp.StatusCode, payload)
+	}
+	if resp, payload := twoFactorRequest(t, p, "PUT", path, admin, map[string]interface{}{"password": "password"}); resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("reset to current password: expected 422, got %d (%v)", resp.StatusCode, payload)
+	}
+
+	if resp, payload := twoFactorRequest(t, p, "PUT", path, admin, map[string]interface{}{"password": "reset-by-admin"}); resp.StatusCode != http.StatusOK {
+		t.Fatalf("reset: expected 200, got %d (%v)", resp.StatusCode, payload)
+	}
+	if resp, _ := signInWithPassword(t, p, memberEmail, "reset-by-admin"); resp.StatusCode != http.StatusOK {
+		t.Fatalf("sign-in with reset password: expected 200, got %d", resp.StatusCode)
+	}
+	var remembered int64
+	p.Db.Model(&account.PasswordHistory{}).Where("user_id = ?", member.ID).Count(&remembered)
+	if remembered != 2 {
+		t.Fatalf("expected initial and reset hashes in history, got %d", remembered)
+	}
+}
+
+func TestPasswordPolicy_AdminResetRollsBackWhenPasswordFails(t *testing.T) {
+	p := setupPasswordPolicyPanel(t, PasswordConfig{HistorySize: 3})
+
+	admin := registerAndLoginTestUser(t, p, "rollback-admin@example.com")
+	memberEmail := "rollback-member@example.com"
+	registerTestUser(t, p, memberEmail)
+	member := loadTestUser(t, p, memberEmail)
+	path := fmt.Sprintf("/api/internal/resource/users/%d", member.ID)
+
+	// Make the password write fail after the policy check and the name update succeeded.
+	if err := p.Db.Exec("CREATE TRIGGER reject_password_write BEFORE UPDATE ON accounts BEGIN SELECT RAISE(ABORT, 'password write failed'); END").Error; err != nil {
+		t.Fatalf("create trigger: %v", err)
+	}
+	resp, payload := twoFactorRequest(t, p, "PUT", path, admin, map[string]interface{}{
+		"name":     "Renamed Member",
+		"password": "reset-by-admin",
+	})
+	if resp.StatusCode < http.StatusBadRequest {
+		t.Fatalf("expected the update to fail, got %d (%v)", resp.StatusCode, payload)
+	}
+	if stored := loadTestUser(t, p, memberEmail); stored.Name != member.Name {
+		t.Fatalf("name change should be rolled back with the password, got %q", stored.Name)
+	}
+	if resp, _ := signInWithPassword(t, p, memberEmail, "password"); resp.StatusCode != http.StatusOK {
+		t.Fatalf("old password should still sign in, got %d", resp.StatusCode)
+	}
+}
+
+func TestPasswordPolicy_SortedBreachedList(t *testing.T) {
+	var hashes []string
+	for i := 0; i < 500; i++ {
+		sum := sha1.Sum([]byte(fmt.Sprintf("leaked-%d", i)))
+		hashes = append(hashes, strings.ToUpper(hex.EncodeToString(sum[:])))
+	}
+	sort.Strings(hashes)
+	var body strings.Builder
+	for i, hash := range hashes {
+		fmt.Fprintf(&body, "%s:%d\n", hash, i+1)
+	}
+	path := filepath.Join(t.TempDir(), "pwned-passwords-sha1-ordered-by-hash.txt")
+	if err := os.WriteFile(path, []byte(body.String()), 0o600); err != nil {
+		t.Fatalf("write list: %v", err)
+	}
+
+	policy, err := newPasswordPolicy(PasswordConfig{BreachedHashesPath: path})
+	if err != nil {
+		t.Fatalf("policy: %v", err)
+	}
+	for _, leaked := range []string{"leaked-0", "leaked-137", "leaked-499"} {
+		if err := policy.Validate(leaked); !errors.Is(err, auth.ErrPasswordBreached) {
+			t.Fatalf("%s: expected breached, got %v", leaked, err)
+		}
+	}
+	if err := policy.Validate("not-in-the-list"); err != nil {
+		t.Fatalf("unlisted password: expected no violation, got %v", err)
+	}
+}
+
+func TestPasswordPolicy_InvalidConfig(t *testing.T) {
+	if err := validatePasswordConfig(PasswordConfig{HistorySize: -1}); err == nil {
+		t.Fatal("expected negative history_size to be rejected")
+	}
+	if err := validatePasswordConfig(PasswordConfig{BreachedHashesPath: filepath.Join(t.TempDir(), "missing")}); err == nil {
+		t.Fatal("expected missing breached_hashes_path to be rejected")
+	}
+}
@@ -0,0 +1,58 @@
+package panel
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/ferdiunal/panel.go/pkg/domain/verification"
+	"github.com/ferdiunal/panel.go/pkg/service/auth"
+)
+
+// validatePasswordResetConfig, sıfırlama akışının gönderici veya genel sıfırlama
+// adresi (ResetURL) olmadan açılmasını engeller. Token içeren bağlantılar istek
+// başlıklarından üretilmediği için ResetURL zorunludur.
+func validatePasswordResetConfig(pr PasswordResetConfig, m MailConfig) error {
+	var errs []error
+	fail := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("password_reset: "+format, args...))
+	}
+
+	if pr.TokenLifetime < 0 || pr.ResendInterval < 0 {
+		fail("token_lifetime and resend_interval cannot be negative")
+	}
+	if pr.Enabled {
+		if newMailSender(m) == nil {
+			fail("requires a mail sender (mail.smtp.host, mail.file_dir or Mail.Sender)")
+		}
+		if strings.TrimSpace(m.From) == "" {
+			fail("requires mail.from")
+		}
+		if u, err := url.Parse(strings.TrimSpace(pr.ResetURL)); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			fail("requires reset_url as an absolute http(s) address of the reset page")
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// configurePasswordReset, sıfırlama akışını auth servisine bağlar.
+// Enabled false ise hiçbir şey yapmaz.
+func configurePasswordReset(config Config, verifications verification.Repository, service *auth.Service) {
+	pr := config.PasswordReset
+	if !pr.Enabled {
+		return
+	}
+
+	appName := config.SettingsValues.SiteName
+	if appName == "" {
+		appName = config.TwoFactor.Issuer
+	}
+	service.SetPasswordReset(verifications, newMailSender(config.Mail), auth.PasswordResetOptions{
+		From:           config.Mail.From,
+		AppName:        appName,
+		TokenLifetime:  pr.TokenLifetime,
+		ResendInterval: pr.ResendInterval,
+		ResetURL:       pr.ResetURL,
+	})
+}
@@ -0,0 +1,195 @@
+package panel
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/ferdiunal/panel.go/pkg/domain/account"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// testResetURL, testlerde kullanılan şifre sıfırlama sayfası adresidir.
+const testResetURL = "https://admin.example.com/reset-password"
+
+func setupPasswordResetPanel(t *testing.T, pr PasswordResetConfig, password PasswordConfig) (*Panel, string) {
+	t.Helper()
+
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", strings.ReplaceAll(t.Name(), "/", "_"))
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect db: %v", err)
+	}
+
+	if pr.Enabled && pr.ResetURL == "" {
+		pr.ResetURL = testResetURL
+	}
+	mailDir := filepath.Join(t.TempDir(), "mail")
+	p := New(Config{
+		Database:      DatabaseConfig{Instance: db},
+		Environment:   "test",
+		Mail:          MailConfig{From: "panel@example.com", FileDir: mailDir},
+		PasswordReset: pr,
+		Password:      password,
+	})
+	t.Cleanup(p.Close)
+	return p, mailDir
+}
+
+var resetTokenPattern = regexp.MustCompile(regexp.QuoteMeta(testResetURL) + `\?token=([0-9a-f]+)`)
+
+// sentResetTokens returns the reset tokens found in the .eml files in dir.
+func sentResetTokens(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatalf("read mail dir: %v", err)
+	}
+	unquote := strings.NewReplacer("=\r\n", "", "=3D", "=")
+	var tokens []string
+	for _, entry := range entries {
+		raw, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			t.Fatalf("read message: %v", err)
+		}
+		if m := resetTokenPattern.FindStringSubmatch(unquote.Replace(string(raw))); m != nil {
+			tokens = append(tokens, m[1])
+		}
+	}
+	return tokens
+}
+
+func forgotPassword(t *testing.T, p *Panel, email string) (*http.Response, map[string]interface{}) {
+	t.Helper()
+	return twoFactorRequest(t, p, "POST", "/api/internal/auth/forgot-password", nil, map[string]string{"email": email})
+}
+
+func resetPassword(t *testing.T, p *Panel, token, password string) (*http.Response, map[string]interface{}) {
+	t.Helper()
+	return twoFactorRequest(t, p, "POST", "/api/internal/auth/reset-password", nil, map[string]string{
+		"token":    token,
+		"password": password,
+	})
+}
+
+func TestPasswordReset_AppliesPolicyAndHistory(t *testing.T) {
+	p, mailDir := setupPasswordResetPanel(t, PasswordResetConfig{Enabled: true}, PasswordConfig{MinLength: 10, HistorySize: 2})
+	email := "reset@example.com"
+	if resp, payload := signUp(t, p, email, "first-password"); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("register: expected 201, got %d (%v)", resp.StatusCode, payload)
+	}
+	resp, _ := signInWithPassword(t, p, email, "first-password")
+	cookie := sessionCookie(resp)
+
+	if resp, payload := forgotPassword(t, p, email); resp.StatusCode != http.StatusOK {
+		t.Fatalf("forgot password: expected 200, got %d (%v)", resp.StatusCode, payload)
+	}
+	tokens := sentResetTokens(t, mailDir)
+	if len(tokens) != 1 {
+		t.Fatalf("expected one reset email, got %d", len(tokens))
+	}
+
+	resp, payload := resetPassword(t, p, tokens[0], "short")
+	if messages := fieldErrors(payload, "password"); resp.StatusCode != http.StatusUnprocessableEntity || len(messages) != 1 || !strings.Contains(messages[0], "at least 10") {
+		t.Fatalf("weak password: expected 422, got %d (%v)", resp.StatusCode, payload)
+	}
+	resp, payload = resetPassword(t, p, tokens[0], "first-password")
+	if messages := fieldErrors(payload, "password"); resp.StatusCode != http.StatusUnprocessableEntity || len(messages) != 1 || !strings.Contains(messages[0], "last 2") {
+		t.Fatalf("reused password: expected 422, got %d (%v)", resp.StatusCode, payload)
+	}
+
+	if resp, payload := resetPassword(t, p, tokens[0], "second-password"); resp.StatusCode != http.StatusOK || payload["reset"] != true {
+		t.Fatalf("reset: expected 200, got %d (%v)", resp.StatusCode, payload)
+	}
+	if resp, _ := twoFactorRequest(t, p, "GET", "/api/internal/auth/sessions", cookie, nil); resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("existing session should be revoked after reset, got %d", resp.StatusCode)
+	}
+	if resp, payload := resetPassword(t, p, tokens[0], "third-password"); resp.StatusCode != http.StatusBadRequest || payload["code"] != "invalid_token" {
+		t.Fatalf("reused token: expected 400, got %d (%v)", resp.StatusCode, payload)
+	}
+
+	if resp, _ := signInWithPassword(t, p, email, "first-password"); resp.StatusCode == http.StatusOK {
+		t.Fatal("old password should no longer sign in")
+	}
+	if resp, _ := signInWithPassword(t, p, email, "second-password"); resp.StatusCode != http.StatusOK {
+		t.Fatalf("new password: expected 200, got %d", resp.StatusCode)
+	}
+
+	user := loadTestUser(t, p, email)
+	var remembered int64
+	p.Db.Model(&account.PasswordHistory{}).Where("user_id = ?", user.ID).Count(&remembered)
+	if remembered != 2 {
+		t.Fatalf("expected the reset to be recorded in password history, got %d entries", remembered)
+	}
+}
+
+func TestPasswordReset_DoesNotLeakAccountsAndIsThrottled(t *testing.T) {
+	p, mailDir := setupPasswordResetPanel(t, PasswordResetConfig{Enabled: true}, PasswordConfig{})
+	email := "reset-throttle@example.com"
+	if resp, payload := signUp(t, p, email, "password"); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("register: expected 201, got %d (%v)", resp.StatusCode, payload)
+	}
+
+	known, knownPayload := forgotPassword(t, p, email)
+	unknown, unknownPayload := forgotPassword(t, p, "nobody@example.com")
+	if known.StatusCode != http.StatusOK || unknown.StatusCode != http.StatusOK || knownPayload["message"] != unknownPayload["message"] {
+		t.Fatalf("responses should not reveal accounts: %d %v / %d %v", known.StatusCode, knownPayload, unknown.StatusCode, unknownPayload)
+	}
+	if resp, _ := forgotPassword(t, p, email); resp.StatusCode != http.StatusOK {
+		t.Fatalf("throttled request: expected 200, got %d", resp.StatusCode)
+	}
+	if tokens := sentResetTokens(t, mailDir); len(tokens) != 1 {
+		t.Fatalf("expected a single reset email, got %d", len(tokens))
+	}
+
+	if resp, payload := resetPassword(t, p, "deadbeef", "new-password"); resp.StatusCode != http.StatusBadRequest || payload["code"] != "invalid_token" {
+		t.Fatalf("unknown token: expected 400, got %d (%v)", resp.StatusCode, payload)
+	}
+}
+
+func TestPasswordReset_DisabledByDefault(t *testing.T) {
+	p, _ := setupPasswordResetPanel(t, PasswordResetConfig{}, PasswordConfig{})
+
+	if resp, _ := forgotPassword(t, p, "anyone@example.com"); resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("forgot password: expected 404, got %d", resp.StatusCode)
+	}
+	if resp, _ := resetPassword(t, p, "token", "new-password"); resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("reset password: expected 404, got %d", resp.StatusCode)
+	}
+}
+
+func TestValidatePasswordResetConfig(t *testing.T) {
+	mailCfg := MailConfig{From: "a@example.com", FileDir: "mail"}
+	cases := []struct {
+		name string
+		pr   PasswordResetConfig
+		mail MailConfig
+		want string
+	}{
+		{name: "disabled", pr: PasswordResetConfig{}},
+		{name: "file sink", pr: PasswordResetConfig{Enabled: true, ResetURL: testResetURL}, mail: mailCfg},
+		{name: "no sender", pr: PasswordResetConfig{Enabled: true, ResetURL: testResetURL}, mail: MailConfig{From: "a@example.com"}, want: "requires a mail sender"},
+		{name: "no reset url", pr: PasswordResetConfig{Enabled: true}, mail: mailCfg, want: "requires reset_url"},
+		{name: "relative reset url", pr: PasswordResetConfig{Enabled: true, ResetURL: "/reset-password"}, mail: mailCfg, want: "requires reset_url"},
+		{name: "negative lifetime", pr: PasswordResetConfig{TokenLifetime: -1}, want: "cannot be negative"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validatePasswordResetConfig(tc.pr, tc.mail)
+			if tc.want == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package panel
+
+import (
+	"errors"
+	"mime/multipart"
+
+	"github.com/ferdiunal/panel.go/pkg/context"
+	"github.com/ferdiunal/panel.go/pkg/storage"
+)
+
+// errAvatarStorageUnavailable, users kaynağı kayıtlı olmadığında döndürülür.
+var errAvatarStorageUnavailable = errors.New("users resource is not registered")
+
+// storeAvatar, POST /auth/profile/avatar ile yüklenen dosyayı users kaynağının
+// storage handler'ı ile saklar: Image alanının StoreAs callback'i varsa o, alan
+// Store(disk, path) ile disk seçtiyse o disk, yoksa Resource.StoreHandler (Config.Storage
+// yolu ve URL'i ile) kullanılır. Böylece profil resimleri, admin panelinden yüklenen
+// kullanıcı resimleriyle aynı yere yazılır.
+func (p *Panel) storeAvatar(c *context.Context, file *multipart.FileHeader) (string, error) {
+	snapshot := p.loadRegistrySnapshot()
+	if snapshot == nil {
+		return "", errAvatarStorageUnavailable
+	}
+	res, ok := snapshot.resources["users"]
+	if !ok {
+		return "", errAvatarStorageUnavailable
+	}
+
+	for _, element := range res.GetFields(c) {
+		if element.GetKey() != "image" {
+			continue
+		}
+		if callback := element.GetStorageCallback(); callback != nil {
+			return callback(c.Ctx, file)
+		}
+		if element.GetStorageDisk() != "" || element.GetStoragePath() != "" {
+			disk, err := p.disks.Disk(element.GetStorageDisk())
+			if err != nil {
+				return "", err
+			}
+			return storage.StoreUpload(c.UserContext(), disk, element.GetStoragePath(), file)
+		}
+		break
+	}
+	return res.StoreHandler(c, file, p.Config.Storage.Path, p.Config.Storage.URL)
+}
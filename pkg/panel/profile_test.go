@@ -0,0 +1,181 @@
+package panel
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	appConfig "github.com/ferdiunal/panel.go/pkg/config"
+	"github.com/ferdiunal/panel.go/pkg/domain/user"
+)
+
+func TestProfile_EmailChangeRequiresReverification(t *testing.T) {
+	p, mailDir := setupEmailVerificationPanel(t, EmailVerificationConfig{Enabled: true, Enforcement: "restrict"})
+
+	email := "profile@example.com"
+	cookie := registerAndLoginTestUser(t, p, email)
+	links := sentVerificationLinks(t, mailDir)
+	if resp, _ := twoFactorRequest(t, p, "GET", verifyLinkPath(t, links[0]), nil, nil); resp.StatusCode != http.StatusOK {
+		t.Fatalf("initial verification: expected 200, got %d", resp.StatusCode)
+	}
+
+	other := "profile-other@example.com"
+	registerTestUser(t, p, other)
+	resp, payload := twoFactorRequest(t, p, "PUT", "/api/internal/auth/profile", cookie, map[string]string{"email": other})
+	if resp.StatusCode != http.StatusUnprocessableEntity || !strings.Contains(string(mustJSON(t, payload["errors"])), "email") {
+		t.Fatalf("duplicate email: expected 422 with email error, got %d (%v)", resp.StatusCode, payload)
+	}
+	if resp, _ := twoFactorRequest(t, p, "PUT", "/api/internal/auth/profile", cookie, map[string]string{"name": "  "}); resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("empty name: expected 422, got %d", resp.StatusCode)
+	}
+
+	newEmail := "profile-new@example.com"
+	resp, payload = twoFactorRequest(t, p, "PUT", "/api/internal/auth/profile", cookie, map[string]string{"name": "Renamed", "email": newEmail})
+	if resp.StatusCode != http.StatusOK || payload["emailVerificationSent"] != true {
+		t.Fatalf("update profile: expected 200 with verification mail, got %d (%v)", resp.StatusCode, payload)
+	}
+	var stored user.User
+	if err := p.Db.First(&stored, "email = ?", newEmail).Error; err != nil {
+		t.Fatalf("updated user not found: %v", err)
+	}
+	if stored.Name != "Renamed" || stored.EmailVerified {
+		t.Fatalf("expected renamed, unverified user, got %+v", stored)
+	}
+
+	// Restricted until the new address is confirmed, but the profile stays editable.
+	if resp, _ := twoFactorRequest(t, p, "GET", "/api/internal/pages", cookie, nil); resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected restricted access after email change, got %d", resp.StatusCode)
+	}
+	if resp, _ := twoFactorRequest(t, p, "PUT", "/api/internal/auth/profile", cookie, map[string]string{"name": "Renamed Again"}); resp.StatusCode != http.StatusOK {
+		t.Fatalf("profile should remain editable while unverified, got %d", resp.StatusCode)
+	}
+
+	links = sentVerificationLinks(t, mailDir)
+	last := links[len(links)-1]
+	if resp, _ := twoFactorRequest(t, p, "GET", verifyLinkPath(t, last), nil, nil); resp.StatusCode != http.StatusOK {
+		t.Fatalf("re-verification: expected 200, got %d", resp.StatusCode)
+	}
+	if resp, _ := twoFactorRequest(t, p, "GET", "/api/internal/pages", cookie, nil); resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected access after re-verification, got %d", resp.StatusCode)
+	}
+}
+
+func TestProfile_ChangePasswordEnforcesCurrentPasswordAndPolicy(t *testing.T) {
+	p := setupSecurityPanel(t, appConfig.DevelopmentSecurityConfig())
+
+	email := "password-change@example.com"
+	cookie := registerAndLoginTestUser(t, p, email)
+	phone := signInFrom(t, p, email, firefoxOnLinux)
+
+	resp, payload := twoFactorRequest(t, p, "PUT", "/api/internal/auth/password", cookie, map[string]string{
+		"current_password": "wrong-password",
+		"new_password":     "brand-new-password",
+	})
+	if resp.StatusCode != http.StatusUnprocessableEntity || !strings.Contains(string(mustJSON(t, payload["errors"])), "current_password") {
+		t.Fatalf("wrong current password: expected 422, got %d (%v)", resp.StatusCode, payload)
+	}
+
+	resp, payload = twoFactorRequest(t, p, "PUT", "/api/internal/auth/password", cookie, map[string]string{
+		"current_password": "password",
+		"new_password":     "short",
+	})
+	if resp.StatusCode != http.StatusUnprocessableEntity || !strings.Contains(string(mustJSON(t, payload["errors"])), "at least 8") {
+		t.Fatalf("short password: expected 422 policy error, got %d (%v)", resp.StatusCode, payload)
+	}
+
+	resp, payload = twoFactorRequest(t, p, "PUT", "/api/internal/auth/password", cookie, map[string]string{
+		"current_password": "password",
+		"new_password":     "brand-new-password",
+		"confirm_password": "brand-new-password",
+	})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("change password: expected 200, got %d (%v)", resp.StatusCode, payload)
+	}
+
+	if resp, _ := twoFactorRequest(t, p, "GET", "/api/internal/auth/sessions", cookie, nil); resp.StatusCode != http.StatusOK {
+		t.Fatalf("current session should survive the change, got %d", resp.StatusCode)
+	}
+	if resp, _ := twoFactorRequest(t, p, "GET", "/api/internal/auth/sessions", phone, nil); resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("other sessions should be revoked, got %d", resp.StatusCode)
+	}
+	if resp, _ := signInWithPassword(t, p, email, "brand-new-password"); resp.StatusCode != http.StatusOK {
+		t.Fatalf("new password: expected 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestProfile_ConfiguredPasswordMinLength(t *testing.T) {
+	p := setupSecurityPanel(t, appConfig.DevelopmentSecurityConfig())
+	if got := p.Auth.PasswordPolicy().MinLength; got != 0 {
+		t.Fatalf("expected default policy, got MinLength=%d", got)
+	}
+
+	cfg := p.Config
+	cfg.Password.MinLength = 12
+	strict := New(cfg)
+	t.Cleanup(strict.Close)
+	if got := strict.Auth.PasswordPolicy().MinLength; got != 12 {
+		t.Fatalf("expected Config.Password.MinLength to configure the policy, got %d", got)
+	}
+}
+
+func TestProfile_AvatarUsesUserResourceStorage(t *testing.T) {
+	t.Chdir(t.TempDir())
+	p := setupSecurityPanel(t, appConfig.DevelopmentSecurityConfig())
+	cookie := registerAndLoginTestUser(t, p, "avatar@example.com")
+
+	upload := func(filename string, content []byte) (*http.Response, map[string]interface{}) {
+		t.Helper()
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		part, _ := writer.CreateFormFile("avatar", filename)
+		_, _ = part.Write(content)
+		_ = writer.Close()
+
+		req := httptest.NewRequest("POST", "/api/internal/auth/profile/avatar", &body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		req.AddCookie(cookie)
+		resp, err := testFiberRequest(p.Fiber, req)
+		if err != nil {
+			t.Fatalf("avatar upload failed: %v", err)
+		}
+		payload := map[string]interface{}{}
+		_ = json.NewDecoder(resp.Body).Decode(&payload)
+		return resp, payload
+	}
+
+	if resp, _ := upload("avatar.exe", []byte("MZ")); resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("non-image upload: expected 422, got %d", resp.StatusCode)
+	}
+
+	resp, payload := upload("avatar.png", []byte("\x89PNG\r\n\x1a\n"))
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("avatar upload: expected 200, got %d (%v)", resp.StatusCode, payload)
+	}
+	image, _ := payload["user"].(map[string]interface{})["image"].(string)
+	if !strings.HasPrefix(image, "/storage/") {
+		t.Fatalf("expected storage URL, got %q", image)
+	}
+	// The users resource Image field stores uploads under ./storage/public.
+	stored, err := filepath.Glob(filepath.Join("storage", "public", "*.png"))
+	if err != nil || len(stored) != 1 {
+		t.Fatalf("expected avatar in users resource storage, got %v (%v)", stored, err)
+	}
+	if info, err := os.Stat(stored[0]); err != nil || info.Size() == 0 {
+		t.Fatalf("stored avatar is empty: %v", err)
+	}
+}
+
+func mustJSON(t *testing.T, v interface{}) []byte {
+	t.Helper()
+	raw, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return raw
+}
@@ -0,0 +1,243 @@
+package panel
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/ferdiunal/panel.go/pkg/fields"
+	"github.com/ferdiunal/panel.go/pkg/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+type repeaterOrder struct {
+	ID       uint                `json:"id" gorm:"primaryKey"`
+	Name     string              `json:"name"`
+	Currency string              `json:"currency"`
+	Lines    fields.RepeaterRows `json:"lines" gorm:"type:text"`
+	Items    []repeaterOrderItem `json:"items" gorm:"foreignKey:OrderID"`
+}
+
+type repeaterOrderItem struct {
+	ID       uint `gorm:"primaryKey"`
+	OrderID  uint `gorm:"index"`
+	Product  string
+	Price    float64
+	Quantity int
+	Position int
+}
+
+func repeaterOrderFields() []fields.Element {
+	total := fields.Number("Total", "total")
+	total.DependsOn("price", "quantity")
+	total.OnDependencyChange(func(_ *fields.Schema, formData map[string]interface{}, _ *fiber.Ctx) *fields.FieldUpdate {
+		price, _ := formData["price"].(float64)
+		quantity, _ := formData["quantity"].(float64)
+		return fields.NewFieldUpdate().SetValue(fmt.Sprintf("%s %.2f", formData["currency"], price*quantity))
+	})
+
+	return []fields.Element{
+		fields.ID(),
+		fields.Text("Name", "name"),
+		fields.Text("Currency", "currency"),
+		fields.Repeater("Lines", "lines").
+			Fields(fields.Text("Label", "label").Required()).
+			MaxRepeats(2),
+		fields.Repeater("Items", "items").
+			Fields(
+				fields.Text("Product", "product").Required(),
+				fields.Number("Price", "price").Required().AddValidationRule(fields.ValidationRule{Name: "min", Parameters: []interface{}{0}}),
+				fields.Number("Quantity", "quantity"),
+				total,
+			).
+			MinRepeats(1).
+			AsHasMany(),
+	}
+}
+
+func setupRepeaterPanel(t *testing.T) *Panel {
+	t.Helper()
+	p := setupStoragePanel(t)
+	migrateTestModels(t, p, &repeaterOrderItem{})
+	registerTestResource(t, p, &repeaterOrder{}, "repeater-orders", repeaterOrderFields)
+	return p
+}
+
+func TestRepeater_JSONAndHasManyPersistence(t *testing.T) {
+	p := setupRepeaterPanel(t)
+	cookie := registerAndLoginTestUser(t, p, "repeater@example.com")
+
+	resp := testJSONRequest(t, p, cookie, "POST", "/api/internal/resource/repeater-orders", map[string]interface{}{
+		"name":  "Order 1",
+		"lines": []interface{}{map[string]interface{}{"label": "Gift wrap", "ignored": true}},
+		"items": []interface{}{
+			map[string]interface{}{"product": "Pen", "price": 2.5, "quantity": 4},
+			map[string]interface{}{"product": "Book", "price": 10, "quantity": 1},
+		},
+	}, nil)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected created order, got %d", resp.StatusCode)
+	}
+
+	var order repeaterOrder
+	if err := p.Db.Preload("Items").First(&order).Error; err != nil {
+		t.Fatalf("expected stored order: %v", err)
+	}
+	if len(order.Lines) != 1 || order.Lines[0]["label"] != "Gift wrap" || order.Lines[0]["ignored"] != nil {
+		t.Fatalf("expected JSON rows with nested keys only, got %v", order.Lines)
+	}
+	if len(order.Items) != 2 || order.Items[0].Product != "Pen" || order.Items[0].Quantity != 4 || order.Items[1].Position != 1 {
+		t.Fatalf("expected two child rows, got %+v", order.Items)
+	}
+
+	book := order.Items[1]
+	var payload struct {
+		Data map[string]struct {
+			Data json.RawMessage `json:"data"`
+		} `json:"data"`
+	}
+	resp = testJSONRequest(t, p, cookie, "PUT", fmt.Sprintf("/api/internal/resource/repeater-orders/%d", order.ID), map[string]interface{}{
+		"name": "Order 1",
+		"items": []interface{}{
+			map[string]interface{}{"id": book.ID, "product": "Book", "price": 12, "quantity": 2},
+			map[string]interface{}{"product": "Ink", "price": 3, "quantity": 1},
+		},
+	}, &payload)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected update to succeed, got %d", resp.StatusCode)
+	}
+	var rows []map[string]interface{}
+	if err := json.Unmarshal(payload.Data["items"].Data, &rows); err != nil {
+		t.Fatalf("decode items failed: %v", err)
+	}
+	if len(rows) != 2 || rows[0]["product"] != "Book" || rows[1]["product"] != "Ink" {
+		t.Fatalf("expected updated rows in response, got %v", rows)
+	}
+
+	var items []repeaterOrderItem
+	p.Db.Order("position").Find(&items)
+	if len(items) != 2 || items[0].ID != book.ID || items[0].Price != 12 || items[0].Position != 0 || items[1].Product != "Ink" {
+		t.Fatalf("expected book updated in place, ink added and pen removed, got %+v", items)
+	}
+}
+
+func TestRepeater_FailedSyncRollsBackUpdate(t *testing.T) {
+	p := setupRepeaterPanel(t)
+	cookie := registerAndLoginTestUser(t, p, "repeater-rollback@example.com")
+
+	order := repeaterOrder{Name: "Order 1", Items: []repeaterOrderItem{{Product: "Pen", Price: 2}, {Product: "Book", Price: 10, Position: 1}}}
+	if err := p.Db.Create(&order).Error; err != nil {
+		t.Fatalf("failed to create order: %v", err)
+	}
+	// İkinci satırın eklenmesi, ilk satır güncellendikten sonra başarısız olur
+	if err := p.Db.Exec(`CREATE TRIGGER repeater_order_items_reject BEFORE INSERT ON repeater_order_items
+		WHEN NEW.product = 'Broken' BEGIN SELECT RAISE(ABORT, 'rejected row'); END`).Error; err != nil {
+		t.Fatalf("failed to create trigger: %v", err)
+	}
+
+	resp := testJSONRequest(t, p, cookie, "PUT", fmt.Sprintf("/api/internal/resource/repeater-orders/%d", order.ID), map[string]interface{}{
+		"name": "Order 2",
+		"items": []interface{}{
+			map[string]interface{}{"id": order.Items[1].ID, "product": "Book", "price": 99},
+			map[string]interface{}{"product": "Broken", "price": 1},
+		},
+	}, nil)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("expected failed sync to return 500, got %d", resp.StatusCode)
+	}
+
+	var stored repeaterOrder
+	if err := p.Db.Preload("Items").First(&stored, order.ID).Error; err != nil {
+		t.Fatalf("expected stored order: %v", err)
+	}
+	if stored.Name != "Order 1" {
+		t.Fatalf("expected parent update to be rolled back, got %q", stored.Name)
+	}
+	if len(stored.Items) != 2 || stored.Items[1].Price != 10 {
+		t.Fatalf("expected child rows to be left untouched, got %+v", stored.Items)
+	}
+}
+
+func TestRepeater_ValidatesRowsWithNestedKeys(t *testing.T) {
+	p := setupRepeaterPanel(t)
+	cookie := registerAndLoginTestUser(t, p, "repeater@example.com")
+
+	var payload struct {
+		Errors map[string][]string `json:"errors"`
+	}
+	resp := testJSONRequest(t, p, cookie, "POST", "/api/internal/resource/repeater-orders", map[string]interface{}{
+		"name":  "Order 1",
+		"lines": `[{"label":"a"},{"label":"b"},{"label":""}]`,
+		"items": []interface{}{
+			map[string]interface{}{"product": "Pen", "price": 2.5},
+			map[string]interface{}{"product": "Book", "price": 10},
+			map[string]interface{}{"price": -1},
+		},
+	}, &payload)
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected validation error, got %d", resp.StatusCode)
+	}
+	for _, key := range []string{"items.2.product", "items.2.price", "lines", "lines.2.label"} {
+		if len(payload.Errors[key]) == 0 {
+			t.Errorf("expected error for %s, got %v", key, payload.Errors)
+		}
+	}
+	if _, ok := payload.Errors["items.0.price"]; ok {
+		t.Errorf("expected valid rows to pass, got %v", payload.Errors)
+	}
+
+	payload.Errors = nil
+	resp = testJSONRequest(t, p, cookie, "POST", "/api/internal/resource/repeater-orders", map[string]interface{}{
+		"name":  "Order 2",
+		"items": []interface{}{},
+	}, &payload)
+	if resp.StatusCode != http.StatusUnprocessableEntity || len(payload.Errors["items"]) == 0 {
+		t.Fatalf("expected min repeats error, got %d %v", resp.StatusCode, payload.Errors)
+	}
+}
+
+func TestRepeater_ResolvesNestedDependencies(t *testing.T) {
+	p := setupRepeaterPanel(t)
+	cookie := registerAndLoginTestUser(t, p, "repeater@example.com")
+
+	var payload struct {
+		Fields map[string]fields.FieldUpdate `json:"fields"`
+	}
+	resp := testJSONRequest(t, p, cookie, "POST", "/api/internal/resource/repeater-orders/fields/resolve-dependencies", map[string]interface{}{
+		"formData": map[string]interface{}{
+			"currency": "EUR",
+			"items": []interface{}{
+				map[string]interface{}{"price": 1, "quantity": 1},
+				map[string]interface{}{"price": 2.5, "quantity": 4},
+			},
+		},
+		"context":       "create",
+		"changedFields": []string{"items.1.quantity"},
+	}, &payload)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected dependencies to resolve, got %d", resp.StatusCode)
+	}
+	if len(payload.Fields) != 1 || payload.Fields["items.1.total"].Value != "EUR 10.00" {
+		t.Fatalf("expected only the changed row total to update, got %+v", payload.Fields)
+	}
+}
+
+func TestRepeater_OpenAPIMapping(t *testing.T) {
+	element := repeaterOrderFields()[4]
+	schema := openapi.NewFieldTypeMapper().MapFieldToSchema(element)
+
+	if schema.Type != "array" || schema.Items == nil || schema.Items.Type != "object" {
+		t.Fatalf("expected array of objects, got %+v", schema)
+	}
+	for _, key := range []string{"id", "product", "price", "quantity", "total"} {
+		if _, ok := schema.Items.Properties[key]; !ok {
+			t.Errorf("expected %s property, got %v", key, schema.Items.Properties)
+		}
+	}
+	if len(schema.Items.Required) != 2 || schema.Items.Required[0] != "product" || schema.Items.Required[1] != "price" {
+		t.Errorf("expected product and price to be required, got %v", schema.Items.Required)
+	}
+}
@@ -2,6 +2,7 @@ package panel
 
 import (
 	"github.com/ferdiunal/panel.go/pkg/context"
+	"github.com/ferdiunal/panel.go/pkg/domain/apikey"
 	"github.com/ferdiunal/panel.go/pkg/middleware"
 	"github.com/ferdiunal/panel.go/pkg/page"
 	"github.com/ferdiunal/panel.go/pkg/resource"
@@ -102,6 +103,11 @@ func (p *Panel) isResourceAccessibleForRequest(c *context.Context, slug string)
 		return true
 	}
 
+	// Scope'lu yönetilen key'ler yalnızca en az okuyabildikleri resource'ları görür
+	if grant := middleware.APIKeyGrantFromContext(c.Ctx); grant != nil && !grant.Allows(slug, apikey.AccessRead) {
+		return false
+	}
+
 	if apiKeyAuth, ok := c.Locals(middleware.APIKeyAuthenticatedLocalKey).(bool); ok && apiKeyAuth {
 		return !p.isInternalResourceSlug(slug)
 	}
@@ -0,0 +1,439 @@
+package panel
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	appConfig "github.com/ferdiunal/panel.go/pkg/config"
+	"github.com/ferdiunal/panel.go/pkg/data/orm"
+	authHandler "github.com/ferdiunal/panel.go/pkg/handler/auth"
+	"github.com/ferdiunal/panel.go/pkg/middleware"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+	"gorm.io/gorm"
+)
+
+// / # securityEncryptionKeyID Sabiti
+// /
+// / Alan şifreleme anahtarı Security.Encryption.KeyHex üzerinden verildiğinde
+// / keyring'de kullanılan anahtar ID'sidir.
+const securityEncryptionKeyID = "default"
+
+var (
+	legacyCORSOrigins = []string{"http://localhost:3000", "http://localhost:5173"}
+	legacyCORSMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
+	legacyCORSHeaders = []string{"Content-Type", "Authorization", "X-CSRF-Token", "X-API-Key"}
+	legacyCORSExpose  = []string{"Content-Length", "X-CSRF-Token"}
+)
+
+// / # resolveSecurityConfig Fonksiyonu
+// /
+// / Panel'in kullanacağı etkin güvenlik yapılandırmasını üretir.
+// /
+// / Config.Security nil ise panelin önceki davranışını birebir karşılayan bir
+// / yapılandırma döner. Tanımlıysa boş bırakılan alanlar aynı varsayılanlarla
+// / doldurulur; Config.CORS.AllowedOrigins, Security.CORS.AllowedOrigins boşsa kullanılır.
+func resolveSecurityConfig(cfg Config) appConfig.SecurityConfig {
+	if cfg.Security == nil {
+		return legacySecurityConfig(cfg)
+	}
+
+	sec := *cfg.Security
+	sec.CORS.AllowedOrigins = append([]string(nil), sec.CORS.AllowedOrigins...)
+	if len(sec.CORS.AllowedOrigins) == 0 {
+		sec.CORS.AllowedOrigins = append([]string(nil), cfg.CORS.AllowedOrigins...)
+	}
+	if len(sec.CORS.AllowedMethods) == 0 {
+		sec.CORS.AllowedMethods = legacyCORSMethods
+	}
+	if len(sec.CORS.AllowedHeaders) == 0 {
+		sec.CORS.AllowedHeaders = legacyCORSHeaders
+	}
+	if sec.Session.CookieName == "" {
+		sec.Session.CookieName = "__Host-session_token"
+	}
+	if sec.Session.SameSite == "" {
+		sec.Session.SameSite = "Strict"
+	}
+	if sec.Session.Path == "" {
+		sec.Session.Path = "/"
+	}
+	if sec.Encryption.Algorithm == "" {
+		sec.Encryption.Algorithm = "AES-GCM"
+	}
+	if sec.Audit.Destination == "" {
+		sec.Audit.Destination = "console"
+	}
+	if sec.Audit.LogLevel == "" {
+		sec.Audit.LogLevel = middleware.AuditLevelAll
+	}
+
+	return sec
+}
+
+// legacySecurityConfig, Config.Security tanımlı değilken kullanılan varsayılanlardır.
+func legacySecurityConfig(cfg Config) appConfig.SecurityConfig {
+	origins := append([]string(nil), cfg.CORS.AllowedOrigins...)
+	if len(origins) == 0 {
+		// Geliştirme için varsayılan olarak localhost kullanılır
+		origins = legacyCORSOrigins
+	}
+
+	session := appConfig.SessionConfig{
+		CookieName: "__Host-session_token",
+		Secure:     true,
+		HTTPOnly:   true,
+		SameSite:   "Strict",
+		Path:       "/",
+	}
+	if cfg.Environment == "test" {
+		session.CookieName = "session_token"
+		session.Secure = false
+	}
+
+	return appConfig.SecurityConfig{
+		CORS: appConfig.CORSConfig{
+			AllowedOrigins:   origins,
+			AllowCredentials: true,
+			AllowedMethods:   legacyCORSMethods,
+			AllowedHeaders:   legacyCORSHeaders,
+			ExposeHeaders:    legacyCORSExpose,
+			MaxAge:           3600,
+		},
+		AccountLockout: appConfig.AccountLockoutConfig{
+			Enabled:         true,
+			MaxAttempts:     5,
+			LockoutDuration: 15 * time.Minute,
+		},
+		Session:    session,
+		Encryption: appConfig.EncryptionConfig{Algorithm: "AES-GCM"},
+		Audit: appConfig.AuditConfig{
+			Enabled:     true,
+			LogLevel:    middleware.AuditLevelAll,
+			Destination: "console",
+		},
+	}
+}
+
+// / # validateSecurityConfig Fonksiyonu
+// /
+// / Birbiriyle çelişen veya panelin uygulayamayacağı güvenlik ayarlarını tespit eder.
+// / Tüm sorunlar tek seferde raporlanır (errors.Join).
+// /
+// / ## Kontroller
+// / - CORS: wildcard origin + AllowCredentials, production'da boş/wildcard origin
+// / - Rate limit / lockout: aktifken sıfır veya negatif limit ve süreler
+// / - Counter store: bilinmeyen driver, redis driver'ında eksik/geçersiz RedisURL
+// / - Session: `__Host-`/`__Secure-` prefix kuralları, SameSite=None + Secure=false,
+// /   production'da Secure veya HttpOnly olmayan cookie
+// / - Encryption: desteklenmeyen algoritma, geçersiz KeyHex, aralıksız rotation
+// / - Audit: bilinmeyen seviye/hedef, dosya yolu olmadan file hedefi, negatif Retention
+func validateSecurityConfig(environment string, sec appConfig.SecurityConfig) error {
+	var errs []error
+	fail := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+	production := environment == "production"
+
+	for _, origin := range sec.CORS.AllowedOrigins {
+		if strings.TrimSpace(origin) != "*" {
+			continue
+		}
+		if sec.CORS.AllowCredentials {
+			fail("cors: wildcard origin cannot be combined with AllowCredentials")
+		}
+		if production {
+			fail("cors: wildcard origin is not allowed in production")
+		}
+	}
+	if production && len(sec.CORS.AllowedOrigins) == 0 {
+		fail("cors: AllowedOrigins must be configured in production")
+	}
+	if sec.CORS.MaxAge < 0 {
+		fail("cors: MaxAge cannot be negative")
+	}
+
+	if sec.RateLimit.Enabled {
+		if sec.RateLimit.AuthMaxRequests <= 0 || sec.RateLimit.AuthWindow <= 0 {
+			fail("rate limit: AuthMaxRequests and AuthWindow must be positive when rate limiting is enabled")
+		}
+		if sec.RateLimit.APIMaxRequests <= 0 || sec.RateLimit.APIWindow <= 0 {
+			fail("rate limit: APIMaxRequests and APIWindow must be positive when rate limiting is enabled")
+		}
+	}
+
+	if sec.AccountLockout.Enabled && (sec.AccountLockout.MaxAttempts <= 0 || sec.AccountLockout.LockoutDuration <= 0) {
+		fail("account lockout: MaxAttempts and LockoutDuration must be positive when lockout is enabled")
+	}
+
+	switch sec.CounterStore.Driver {
+	case "", "memory", "database":
+	case "redis":
+		if strings.TrimSpace(sec.CounterStore.RedisURL) == "" {
+			fail("counter store: RedisURL is required for the redis driver")
+		} else if _, err := middleware.ParseRedisURL(sec.CounterStore.RedisURL); err != nil {
+			fail("counter store: %v", err)
+		}
+	default:
+		fail("counter store: unsupported driver %q (use memory, database or redis)", sec.CounterStore.Driver)
+	}
+
+	session := sec.Session
+	switch strings.ToLower(session.SameSite) {
+	case "strict", "lax":
+	case "none":
+		if !session.Secure {
+			fail("session: SameSite=None requires Secure cookies")
+		}
+	default:
+		fail("session: unsupported SameSite value %q", session.SameSite)
+	}
+	if strings.HasPrefix(session.CookieName, "__Host-") {
+		if !session.Secure {
+			fail("session: cookie %q requires Secure (__Host- prefix)", session.CookieName)
+		}
+		if session.Domain != "" {
+			fail("session: cookie %q cannot set Domain (__Host- prefix)", session.CookieName)
+		}
+		if session.Path != "/" {
+			fail("session: cookie %q requires Path=/ (__Host- prefix)", session.CookieName)
+		}
+	}
+	if strings.HasPrefix(session.CookieName, "__Secure-") && !session.Secure {
+		fail("session: cookie %q requires Secure (__Secure- prefix)", session.CookieName)
+	}
+	if session.MaxAge < 0 {
+		fail("session: MaxAge cannot be negative")
+	}
+	if session.IdleTimeout < 0 {
+		fail("session: IdleTimeout cannot be negative")
+	}
+	if production && !session.Secure {
+		fail("session: cookies must be Secure in production")
+	}
+	if production && !session.HTTPOnly {
+		fail("session: cookies must be HttpOnly in production")
+	}
+
+	if !strings.EqualFold(sec.Encryption.Algorithm, "AES-GCM") {
+		fail("encryption: unsupported algorithm %q (only AES-GCM is supported)", sec.Encryption.Algorithm)
+	}
+	if sec.Encryption.KeyHex != "" {
+		key, err := hex.DecodeString(strings.TrimSpace(sec.Encryption.KeyHex))
+		switch {
+		case err != nil:
+			fail("encryption: KeyHex is not valid hex")
+		case len(key) != 16 && len(key) != 24 && len(key) != 32:
+			fail("encryption: KeyHex must decode to 16, 24 or 32 bytes, got %d", len(key))
+		}
+	}
+	if sec.Encryption.RotationEnabled && sec.Encryption.RotationInterval <= 0 {
+		fail("encryption: RotationInterval must be positive when rotation is enabled")
+	}
+
+	if sec.Audit.Enabled {
+		switch sec.Audit.LogLevel {
+		case middleware.AuditLevelAll, middleware.AuditLevelSecurity, middleware.AuditLevelErrors:
+		default:
+			fail("audit: unsupported log level %q", sec.Audit.LogLevel)
+		}
+		switch sec.Audit.Destination {
+		case "console":
+		case "file":
+			if strings.TrimSpace(sec.Audit.FilePath) == "" {
+				fail("audit: FilePath is required for the file destination")
+			}
+		case "database":
+		case "siem":
+			fail("audit: siem destination is not supported yet, use console, file or database")
+		default:
+			fail("audit: unsupported destination %q", sec.Audit.Destination)
+		}
+		if sec.Audit.Retention < 0 {
+			fail("audit: Retention must not be negative")
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// newSecurityAuditLogger, audit ayarlarına göre logger oluşturur.
+// Dosya ve veritabanı hedeflerinde panel kapatılırken kapatılacak kaynak ayrıca döner.
+func newSecurityAuditLogger(audit appConfig.AuditConfig, db *gorm.DB) (middleware.AuditLogger, io.Closer, error) {
+	if !audit.Enabled {
+		return nil, nil, nil
+	}
+
+	switch audit.Destination {
+	case "file":
+		fileLogger, err := middleware.NewFileAuditLogger(audit.FilePath)
+		if err != nil {
+			return nil, nil, err
+		}
+		return middleware.NewLevelFilteredAuditLogger(fileLogger, audit.LogLevel), fileLogger, nil
+	case "database":
+		asyncLogger := middleware.NewAsyncAuditLogger(
+			&databaseAuditWriter{repo: orm.NewAuditRepository(db)},
+			middleware.AsyncAuditLoggerOptions{},
+		)
+		return middleware.NewLevelFilteredAuditLogger(asyncLogger, audit.LogLevel), asyncLogger, nil
+	}
+
+	return middleware.NewLevelFilteredAuditLogger(&middleware.ConsoleAuditLogger{}, audit.LogLevel), nil, nil
+}
+
+// newSecurityCORSConfig, CORS ayarlarını fiber cors yapılandırmasına dönüştürür.
+func newSecurityCORSConfig(c appConfig.CORSConfig) cors.Config {
+	return cors.Config{
+		AllowOrigins:     strings.Join(c.AllowedOrigins, ","),
+		AllowMethods:     strings.Join(c.AllowedMethods, ","),
+		AllowHeaders:     strings.Join(c.AllowedHeaders, ","),
+		AllowCredentials: c.AllowCredentials,
+		ExposeHeaders:    strings.Join(c.ExposeHeaders, ","),
+		MaxAge:           c.MaxAge,
+	}
+}
+
+// newSecurityCounterStore, rate limit ve hesap kilitleme sayaçları için store oluşturur.
+// Redis store'u panel kapatılırken kapatılmak üzere ayrıca döner.
+func newSecurityCounterStore(cs appConfig.CounterStoreConfig, db *gorm.DB) (middleware.CounterStore, io.Closer, error) {
+	switch cs.Driver {
+	case "database":
+		return orm.NewCounterStore(db), nil, nil
+	case "redis":
+		opts, err := middleware.ParseRedisURL(cs.RedisURL)
+		if err != nil {
+			return nil, nil, err
+		}
+		opts.Prefix = "panel:"
+		store, err := middleware.NewRedisCounterStore(opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		return store, store, nil
+	default:
+		return middleware.NewMemoryCounterStore(), nil, nil
+	}
+}
+
+// newSecurityRateLimiters, auth ve API route'ları için rate limiter'ları oluşturur.
+// Rate limit kapalıysa nil döner.
+func newSecurityRateLimiters(rl appConfig.RateLimitConfig, store middleware.CounterStore) (authLimiter, apiLimiter fiber.Handler) {
+	if !rl.Enabled {
+		return nil, nil
+	}
+
+	authLimiter = middleware.RateLimiter(middleware.RateLimitConfig{
+		Max:        rl.AuthMaxRequests,
+		Expiration: rl.AuthWindow,
+		Store:      store,
+		Prefix:     "ratelimit:auth:",
+		LimitReached: func(c *fiber.Ctx) error {
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"error": "Too many authentication attempts. Please try again later.",
+			})
+		},
+	})
+	apiLimiter = middleware.RateLimiter(middleware.RateLimitConfig{
+		Max:        rl.APIMaxRequests,
+		Expiration: rl.APIWindow,
+		Store:      store,
+		Prefix:     "ratelimit:api:",
+		LimitReached: func(c *fiber.Ctx) error {
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"error": "Rate limit exceeded. Please slow down.",
+			})
+		},
+	})
+	return authLimiter, apiLimiter
+}
+
+// newSessionCookieConfig, SessionConfig'i auth handler'ın cookie ayarlarına dönüştürür.
+func newSessionCookieConfig(s appConfig.SessionConfig) *authHandler.SessionCookieConfig {
+	return &authHandler.SessionCookieConfig{
+		Name:     s.CookieName,
+		Secure:   s.Secure,
+		HTTPOnly: s.HTTPOnly,
+		SameSite: s.SameSite,
+		Domain:   s.Domain,
+		Path:     s.Path,
+	}
+}
+
+// securityFieldEncryptionKeys, alan şifrelemesinde kullanılacak anahtarları döndürür.
+// FieldEncryption.Keys öncelikli; boşsa Security.Encryption.KeyHex "default" ID'siyle kullanılır.
+func securityFieldEncryptionKeys(cfg Config, sec appConfig.SecurityConfig) (string, map[string]string) {
+	if len(cfg.FieldEncryption.Keys) > 0 {
+		return cfg.FieldEncryption.PrimaryKeyID, cfg.FieldEncryption.Keys
+	}
+	if cfg.Security != nil && strings.TrimSpace(sec.Encryption.KeyHex) != "" {
+		return securityEncryptionKeyID, map[string]string{securityEncryptionKeyID: sec.Encryption.KeyHex}
+	}
+	return "", nil
+}
+
+// describeSecurityProfile, başlangıçta loglanan tek satırlık güvenlik özetini üretir.
+// Anahtarlar ve endpoint gibi gizli değerler özete dahil edilmez.
+func describeSecurityProfile(environment string, sec appConfig.SecurityConfig, custom bool) string {
+	source := "defaults"
+	if custom {
+		source = "config"
+	}
+
+	rateLimit := "off"
+	if sec.RateLimit.Enabled {
+		rateLimit = fmt.Sprintf("auth=%d/%s api=%d/%s",
+			sec.RateLimit.AuthMaxRequests, sec.RateLimit.AuthWindow,
+			sec.RateLimit.APIMaxRequests, sec.RateLimit.APIWindow)
+	}
+
+	lockout := "off"
+	if sec.AccountLockout.Enabled {
+		lockout = fmt.Sprintf("%d attempts/%s", sec.AccountLockout.MaxAttempts, sec.AccountLockout.LockoutDuration)
+	}
+
+	counters := sec.CounterStore.Driver
+	if counters == "" {
+		counters = "memory"
+	}
+
+	lifetime := "7d"
+	if sec.Session.MaxAge > 0 {
+		lifetime = (time.Duration(sec.Session.MaxAge) * time.Second).String()
+	}
+	if sec.Session.IdleTimeout > 0 {
+		lifetime += " idle=" + (time.Duration(sec.Session.IdleTimeout) * time.Second).String()
+	}
+
+	encryption := "off"
+	if sec.Encryption.KeyHex != "" {
+		encryption = sec.Encryption.Algorithm
+		if sec.Encryption.RotationEnabled {
+			encryption += fmt.Sprintf(" rotate=%s", sec.Encryption.RotationInterval)
+		}
+	}
+
+	audit := "off"
+	if sec.Audit.Enabled {
+		audit = fmt.Sprintf("%s level=%s", sec.Audit.Destination, sec.Audit.LogLevel)
+		if sec.Audit.Destination == "file" {
+			audit += " path=" + sec.Audit.FilePath
+		}
+		if sec.Audit.Destination == "database" && sec.Audit.Retention > 0 {
+			audit += fmt.Sprintf(" retention=%s", sec.Audit.Retention)
+		}
+	}
+
+	return fmt.Sprintf(
+		"Security profile (%s, env=%s): cors=[%s] credentials=%t | rate_limit=%s | lockout=%s | counters=%s | session=%s secure=%t httponly=%t samesite=%s lifetime=%s | encryption=%s | audit=%s",
+		source, environment,
+		strings.Join(sec.CORS.AllowedOrigins, ","), sec.CORS.AllowCredentials,
+		rateLimit, lockout, counters,
+		sec.Session.CookieName, sec.Session.Secure, sec.Session.HTTPOnly, sec.Session.SameSite, lifetime,
+		encryption, audit,
+	)
+}
@@ -0,0 +1,306 @@
+package panel
+
+import (
+	"bytes"
+	stdcontext "context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	appConfig "github.com/ferdiunal/panel.go/pkg/config"
+	"github.com/ferdiunal/panel.go/pkg/data/orm"
+	"github.com/ferdiunal/panel.go/pkg/middleware"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestValidateSecurityConfig_Presets(t *testing.T) {
+	production := appConfig.ProductionSecurityConfig()
+	production.CORS.AllowedOrigins = []string{"https://admin.example.com"}
+
+	cases := []struct {
+		name        string
+		environment string
+		security    appConfig.SecurityConfig
+	}{
+		{name: "default", environment: "development", security: appConfig.DefaultSecurityConfig()},
+		{name: "development", environment: "development", security: appConfig.DevelopmentSecurityConfig()},
+		{name: "production", environment: "production", security: production},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			security := tc.security
+			resolved := resolveSecurityConfig(Config{Environment: tc.environment, Security: &security})
+			if err := validateSecurityConfig(tc.environment, resolved); err != nil {
+				t.Fatalf("expected preset to be valid, got %v", err)
+			}
+		})
+	}
+
+	for _, env := range []string{"test", "development", "production"} {
+		if err := validateSecurityConfig(env, resolveSecurityConfig(Config{Environment: env})); err != nil {
+			t.Fatalf("expected legacy defaults to be valid in %s, got %v", env, err)
+		}
+	}
+}
+
+func TestValidateSecurityConfig_RejectsContradictions(t *testing.T) {
+	cases := []struct {
+		name        string
+		environment string
+		mutate      func(*appConfig.SecurityConfig)
+		want        string
+	}{
+		{
+			name:   "wildcard origin with credentials",
+			mutate: func(s *appConfig.SecurityConfig) { s.CORS.AllowedOrigins = []string{"*"} },
+			want:   "wildcard origin cannot be combined with AllowCredentials",
+		},
+		{
+			name:        "production without origins",
+			environment: "production",
+			mutate:      func(s *appConfig.SecurityConfig) { s.CORS.AllowedOrigins = nil },
+			want:        "AllowedOrigins must be configured in production",
+		},
+		{
+			name:   "host prefix without secure",
+			mutate: func(s *appConfig.SecurityConfig) { s.Session.Secure = false },
+			want:   "requires Secure (__Host- prefix)",
+		},
+		{
+			name:   "host prefix with domain",
+			mutate: func(s *appConfig.SecurityConfig) { s.Session.Domain = "example.com" },
+			want:   "cannot set Domain",
+		},
+		{
+			name: "samesite none without secure",
+			mutate: func(s *appConfig.SecurityConfig) {
+				s.Session.CookieName = "session_token"
+				s.Session.Secure = false
+				s.Session.SameSite = "None"
+			},
+			want: "SameSite=None requires Secure",
+		},
+		{
+			name:        "insecure cookie in production",
+			environment: "production",
+			mutate: func(s *appConfig.SecurityConfig) {
+				s.CORS.AllowedOrigins = []string{"https://admin.example.com"}
+				s.Session.CookieName = "session_token"
+				s.Session.Secure = false
+			},
+			want: "cookies must be Secure in production",
+		},
+		{
+			name:   "rate limit without window",
+			mutate: func(s *appConfig.SecurityConfig) { s.RateLimit.AuthWindow = 0 },
+			want:   "AuthMaxRequests and AuthWindow must be positive",
+		},
+		{
+			name:   "lockout without attempts",
+			mutate: func(s *appConfig.SecurityConfig) { s.AccountLockout.MaxAttempts = 0 },
+			want:   "MaxAttempts and LockoutDuration must be positive",
+		},
+		{
+			name:   "invalid encryption key",
+			mutate: func(s *appConfig.SecurityConfig) { s.Encryption.KeyHex = "abcd" },
+			want:   "KeyHex must decode to 16, 24 or 32 bytes",
+		},
+		{
+			name: "rotation without interval",
+			mutate: func(s *appConfig.SecurityConfig) {
+				s.Encryption.RotationEnabled, s.Encryption.RotationInterval = true, 0
+			},
+			want: "RotationInterval must be positive",
+		},
+		{
+			name:   "file audit without path",
+			mutate: func(s *appConfig.SecurityConfig) { s.Audit.Destination = "file" },
+			want:   "FilePath is required",
+		},
+		{
+			name:   "unsupported audit level",
+			mutate: func(s *appConfig.SecurityConfig) { s.Audit.LogLevel = "verbose" },
+			want:   `unsupported log level "verbose"`,
+		},
+		{
+			name:   "unsupported counter store",
+			mutate: func(s *appConfig.SecurityConfig) { s.CounterStore.Driver = "memcached" },
+			want:   `unsupported driver "memcached"`,
+		},
+		{
+			name:   "redis counter store without url",
+			mutate: func(s *appConfig.SecurityConfig) { s.CounterStore.Driver = "redis" },
+			want:   "RedisURL is required",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			environment := tc.environment
+			if environment == "" {
+				environment = "development"
+			}
+			security := appConfig.DefaultSecurityConfig()
+			tc.mutate(&security)
+
+			err := validateSecurityConfig(environment, resolveSecurityConfig(Config{Environment: environment, Security: &security}))
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestNew_PanicsOnContradictorySecurityConfig(t *testing.T) {
+	security := appConfig.DefaultSecurityConfig()
+	security.Session.Secure = false
+
+	defer func() {
+		recovered := recover()
+		if recovered == nil {
+			t.Fatal("expected panel.New to panic")
+		}
+		if !strings.Contains(fmt.Sprint(recovered), "__Host- prefix") {
+			t.Fatalf("unexpected panic: %v", recovered)
+		}
+	}()
+
+	New(Config{Environment: "development", Security: &security})
+}
+
+func setupSecurityPanel(t *testing.T, security appConfig.SecurityConfig) *Panel {
+	t.Helper()
+
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", strings.ReplaceAll(t.Name(), "/", "_"))
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect db: %v", err)
+	}
+
+	p := New(Config{
+		Database:    DatabaseConfig{Instance: db},
+		Environment: "test",
+		Security:    &security,
+	})
+	t.Cleanup(p.Close)
+	return p
+}
+
+func TestNew_SecurityConfigAppliesSessionRateLimitAndAudit(t *testing.T) {
+	auditPath := filepath.Join(t.TempDir(), "audit", "audit.log")
+
+	security := appConfig.DevelopmentSecurityConfig()
+	security.Session.MaxAge = 3600
+	security.RateLimit.AuthMaxRequests = 2
+	security.Audit.Destination = "file"
+	security.Audit.FilePath = auditPath
+	security.Audit.LogLevel = "all"
+
+	p := setupSecurityPanel(t, security)
+	sessionCookie := registerAndLoginTestUser(t, p, "security@example.com")
+	if sessionCookie == nil {
+		t.Fatal("expected session_token cookie from DevelopmentSecurityConfig")
+	}
+	if sessionCookie.SameSite != http.SameSiteLaxMode {
+		t.Fatalf("expected SameSite=Lax cookie, got %v", sessionCookie.SameSite)
+	}
+	if remaining := time.Until(sessionCookie.Expires); remaining <= 0 || remaining > time.Hour+time.Minute {
+		t.Fatalf("expected session to expire within Session.MaxAge, got %s", remaining)
+	}
+
+	body, _ := json.Marshal(map[string]string{"email": "security@example.com", "password": "password"})
+	req := httptest.NewRequest("POST", "/api/internal/auth/sign-in/email", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := testFiberRequest(p.Fiber, req)
+	if err != nil {
+		t.Fatalf("login request failed: %v", err)
+	}
+	if resp.StatusCode != 429 {
+		t.Fatalf("expected auth rate limit to reject the third request, got %d", resp.StatusCode)
+	}
+
+	contents, err := os.ReadFile(auditPath)
+	if err != nil {
+		t.Fatalf("expected audit log file: %v", err)
+	}
+	if !strings.Contains(string(contents), `"event_type":"login_success"`) {
+		t.Fatalf("expected login event in audit log, got %s", contents)
+	}
+}
+
+func TestNew_DatabaseCounterStoreSharesLimitsAcrossReplicas(t *testing.T) {
+	security := appConfig.DevelopmentSecurityConfig()
+	security.RateLimit.AuthMaxRequests = 2
+	security.CounterStore.Driver = "database"
+
+	// Both panels use the same shared in-memory database, like two replicas
+	replicas := []*Panel{setupSecurityPanel(t, security), setupSecurityPanel(t, security)}
+	signIn := func(p *Panel) *http.Response {
+		body, _ := json.Marshal(map[string]string{"email": "nobody@example.com", "password": "wrong-password"})
+		req := httptest.NewRequest("POST", "/api/internal/auth/sign-in/email", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := testFiberRequest(p.Fiber, req)
+		if err != nil {
+			t.Fatalf("login request failed: %v", err)
+		}
+		return resp
+	}
+
+	for i, p := range replicas {
+		resp := signIn(p)
+		if resp.StatusCode == http.StatusTooManyRequests {
+			t.Fatalf("request %d: unexpected 429", i+1)
+		}
+		if resp.Header.Get("X-RateLimit-Limit") != "2" || resp.Header.Get("X-RateLimit-Remaining") != fmt.Sprint(1-i) {
+			t.Fatalf("request %d: unexpected rate limit headers %v", i+1, resp.Header)
+		}
+	}
+	resp := signIn(replicas[0])
+	if resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") == "" {
+		t.Fatalf("expected the shared limit to reject the third request with Retry-After, got %d", resp.StatusCode)
+	}
+}
+
+func TestCounterStore_DatabaseLockoutAndExpiry(t *testing.T) {
+	p := setupSecurityPanel(t, func() appConfig.SecurityConfig {
+		security := appConfig.DevelopmentSecurityConfig()
+		security.CounterStore.Driver = "database"
+		return security
+	}())
+	store := orm.NewCounterStore(p.Db)
+
+	first := middleware.NewAccountLockoutWithStore(store, 2, time.Minute)
+	second := middleware.NewAccountLockoutWithStore(store, 2, time.Minute)
+	first.RecordFailedAttempt("shared@example.com")
+	second.RecordFailedAttempt("shared@example.com")
+	if !first.IsLocked("shared@example.com") || second.GetRemainingAttempts("shared@example.com") != 0 {
+		t.Fatal("expected the lockout to be shared through the database store")
+	}
+	first.ResetAttempts("shared@example.com")
+	if second.IsLocked("shared@example.com") {
+		t.Fatal("expected reset to unlock on every replica")
+	}
+
+	ctx := stdcontext.Background()
+	if value, err := store.Increment(ctx, "short", 3, 50*time.Millisecond); err != nil || value != 3 {
+		t.Fatalf("increment: got %d, %v", value, err)
+	}
+	if value, err := store.Increment(ctx, "short", 1, 50*time.Millisecond); err != nil || value != 4 {
+		t.Fatalf("second increment: got %d, %v", value, err)
+	}
+	time.Sleep(120 * time.Millisecond)
+	if value, err := store.Get(ctx, "short"); err != nil || value != 0 {
+		t.Fatalf("expired counter: got %d, %v", value, err)
+	}
+	if value, err := store.Increment(ctx, "short", 1, time.Minute); err != nil || value != 1 {
+		t.Fatalf("expired counter should restart: got %d, %v", value, err)
+	}
+}
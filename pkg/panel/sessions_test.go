@@ -0,0 +1,246 @@
+package panel
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	appConfig "github.com/ferdiunal/panel.go/pkg/config"
+	"github.com/ferdiunal/panel.go/pkg/data/orm"
+	"github.com/ferdiunal/panel.go/pkg/domain/session"
+	"github.com/ferdiunal/panel.go/pkg/domain/user"
+	"github.com/ferdiunal/panel.go/pkg/service/auth"
+)
+
+const firefoxOnLinux = "Mozilla/5.0 (X11; Linux x86_64; rv:128.0) Gecko/20100101 Firefox/128.0"
+
+// signInFrom signs in with the given User-Agent and returns the session cookie.
+func signInFrom(t *testing.T, p *Panel, email, userAgent string) *http.Cookie {
+	t.Helper()
+	body, _ := json.Marshal(map[string]string{"email": email, "password": "password"})
+	req := httptest.NewRequest("POST", "/api/internal/auth/sign-in/email", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	resp, err := testFiberRequest(p.Fiber, req)
+	if err != nil {
+		t.Fatalf("sign-in request failed: %v", err)
+	}
+	cookie := sessionCookie(resp)
+	if cookie == nil {
+		t.Fatalf("sign-in: expected session cookie, got %d", resp.StatusCode)
+	}
+	return cookie
+}
+
+func findStoredSession(t *testing.T, p *Panel, token string) session.Session {
+	t.Helper()
+	sum := sha256.Sum256([]byte(token))
+	var stored session.Session
+	if err := p.Db.Where("token = ?", hex.EncodeToString(sum[:])).First(&stored).Error; err != nil {
+		t.Fatalf("session with hashed token not found: %v", err)
+	}
+	return stored
+}
+
+func TestSessions_HashedTokensListAndRevoke(t *testing.T) {
+	p := setupSecurityPanel(t, appConfig.DevelopmentSecurityConfig())
+	email := "sessions@example.com"
+	laptop := registerAndLoginTestUser(t, p, email)
+	phone := signInFrom(t, p, email, firefoxOnLinux)
+
+	var plain int64
+	p.Db.Model(&session.Session{}).Where("token IN ?", []string{laptop.Value, phone.Value}).Count(&plain)
+	if plain != 0 {
+		t.Fatalf("session tokens must not be stored in plain text")
+	}
+	phoneSession := findStoredSession(t, p, phone.Value)
+
+	resp, payload := twoFactorRequest(t, p, "GET", "/api/internal/auth/sessions", laptop, nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("list sessions: expected 200, got %d (%v)", resp.StatusCode, payload)
+	}
+	items, _ := payload["sessions"].([]interface{})
+	if len(items) != 2 {
+		t.Fatalf("expected 2 sessions, got %v", payload)
+	}
+	var currentCount int
+	for _, raw := range items {
+		item := raw.(map[string]interface{})
+		if _, leaked := item["token"]; leaked {
+			t.Fatalf("session list must not expose tokens: %v", item)
+		}
+		if item["current"] == true {
+			currentCount++
+		}
+		if uint(item["id"].(float64)) == phoneSession.ID && item["device"] != "Firefox on Linux" {
+			t.Fatalf("unexpected device description: %v", item["device"])
+		}
+	}
+	if currentCount != 1 {
+		t.Fatalf("expected exactly one current session, got %d", currentCount)
+	}
+
+	// Another user's session IDs are not visible.
+	other := registerAndLoginTestUser(t, p, "sessions-other@example.com")
+	resp, _ = twoFactorRequest(t, p, "DELETE", fmt.Sprintf("/api/internal/auth/sessions/%d", phoneSession.ID), other, nil)
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("revoke foreign session: expected 404, got %d", resp.StatusCode)
+	}
+
+	resp, _ = twoFactorRequest(t, p, "POST", "/api/internal/auth/sessions/revoke-others", laptop, nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("revoke others: expected 200, got %d", resp.StatusCode)
+	}
+	if resp, _ := twoFactorRequest(t, p, "GET", "/api/internal/auth/sessions", phone, nil); resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("revoked session: expected 401, got %d", resp.StatusCode)
+	}
+
+	laptopSession := findStoredSession(t, p, laptop.Value)
+	resp, _ = twoFactorRequest(t, p, "DELETE", fmt.Sprintf("/api/internal/auth/sessions/%d", laptopSession.ID), laptop, nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("revoke current: expected 200, got %d", resp.StatusCode)
+	}
+	if resp, _ := twoFactorRequest(t, p, "GET", "/api/internal/auth/sessions", laptop, nil); resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("signed-out session: expected 401, got %d", resp.StatusCode)
+	}
+}
+
+func TestSessions_IdleTimeoutSlidesWithinAbsoluteLifetime(t *testing.T) {
+	security := appConfig.DevelopmentSecurityConfig()
+	security.Session.MaxAge = 3600
+	security.Session.IdleTimeout = 600
+	p := setupSecurityPanel(t, security)
+
+	cookie := registerAndLoginTestUser(t, p, "idle@example.com")
+	stored := findStoredSession(t, p, cookie.Value)
+	if got := stored.ExpiresAt.Sub(stored.CreatedAt); got > 601*time.Second {
+		t.Fatalf("new session should expire after the idle timeout, got %s", got)
+	}
+
+	// Activity after a quiet period pushes the expiry forward and re-issues the cookie.
+	quiet := time.Now().Add(-5 * time.Minute)
+	p.Db.Model(&session.Session{}).Where("id = ?", stored.ID).Updates(map[string]interface{}{
+		"last_seen_at": quiet,
+		"expires_at":   quiet.Add(10 * time.Minute),
+	})
+	resp, _ := twoFactorRequest(t, p, "GET", "/api/internal/auth/sessions", cookie, nil)
+	if resp.StatusCode != http.StatusOK || sessionCookie(resp) == nil {
+		t.Fatalf("expected renewed cookie, got %d", resp.StatusCode)
+	}
+	renewed := findStoredSession(t, p, cookie.Value)
+	if !renewed.ExpiresAt.After(quiet.Add(10*time.Minute)) || !renewed.LastSeenAt.After(quiet) {
+		t.Fatalf("expected sliding renewal, got expires=%s lastSeen=%s", renewed.ExpiresAt, renewed.LastSeenAt)
+	}
+
+	// Sliding never extends past CreatedAt + MaxAge.
+	created := time.Now().Add(-55 * time.Minute)
+	p.Db.Model(&session.Session{}).Where("id = ?", stored.ID).Updates(map[string]interface{}{
+		"created_at":   created,
+		"last_seen_at": quiet,
+	})
+	twoFactorRequest(t, p, "GET", "/api/internal/auth/sessions", cookie, nil)
+	capped := findStoredSession(t, p, cookie.Value)
+	if capped.ExpiresAt.After(created.Add(time.Hour).Add(time.Second)) {
+		t.Fatalf("expiry %s exceeds absolute lifetime %s", capped.ExpiresAt, created.Add(time.Hour))
+	}
+
+	p.Db.Model(&session.Session{}).Where("id = ?", stored.ID).Update("expires_at", time.Now().Add(-time.Second))
+	if resp, _ := twoFactorRequest(t, p, "GET", "/api/internal/auth/sessions", cookie, nil); resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("idle session: expected 401, got %d", resp.StatusCode)
+	}
+}
+
+func TestSessions_AdminPasswordChangeRevokesSessions(t *testing.T) {
+	p := setupSecurityPanel(t, appConfig.DevelopmentSecurityConfig())
+	admin := registerAndLoginTestUser(t, p, "sessions-admin@example.com")
+
+	memberEmail := "sessions-member@example.com"
+	registerTestUser(t, p, memberEmail)
+	member := signInFrom(t, p, memberEmail, firefoxOnLinux)
+	var stored user.User
+	if err := p.Db.Where("email = ?", memberEmail).First(&stored).Error; err != nil {
+		t.Fatalf("failed to load member: %v", err)
+	}
+
+	resp, payload := twoFactorRequest(t, p, "PUT", fmt.Sprintf("/api/internal/resource/users/%d", stored.ID), admin, map[string]interface{}{
+		"name":     "Renamed Member",
+		"email":    memberEmail,
+		"password": "new-password",
+	})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("update user: expected 200, got %d (%v)", resp.StatusCode, payload)
+	}
+
+	if resp, _ := twoFactorRequest(t, p, "GET", "/api/internal/auth/sessions", member, nil); resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("session after password change: expected 401, got %d", resp.StatusCode)
+	}
+	if resp, _ := signInWithPassword(t, p, memberEmail, "password"); resp.StatusCode == http.StatusOK {
+		t.Fatal("old password must stop working")
+	}
+	if resp, _ := signInWithPassword(t, p, memberEmail, "new-password"); resp.StatusCode != http.StatusOK {
+		t.Fatalf("new password: expected 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestSessions_PruneExpiredSessions(t *testing.T) {
+	p := setupSecurityPanel(t, appConfig.DevelopmentSecurityConfig())
+	cookie := registerAndLoginTestUser(t, p, "sweep@example.com")
+	expired := session.Session{UserID: 1, Token: "expired-hash", ExpiresAt: time.Now().Add(-time.Minute)}
+	if err := p.Db.Create(&expired).Error; err != nil {
+		t.Fatalf("failed to seed expired session: %v", err)
+	}
+
+	service := auth.NewService(orm.NewUserRepository(p.Db), orm.NewSessionRepository(p.Db), orm.NewAccountRepository(p.Db))
+	removed, err := service.PruneExpiredSessions(context.Background())
+	if err != nil || removed != 1 {
+		t.Fatalf("expected one pruned session, got %d (%v)", removed, err)
+	}
+	findStoredSession(t, p, cookie.Value)
+}
+
+func TestSessions_PlaintextTokensAreHashedOnStartup(t *testing.T) {
+	security := appConfig.DevelopmentSecurityConfig()
+	p := setupSecurityPanel(t, security)
+	email := "legacy-session@example.com"
+	registerAndLoginTestUser(t, p, email)
+	u := loadTestUser(t, p, email)
+
+	// Sessions created before tokens were hashed stored the UUID token as is.
+	legacyToken := "0190f2a4-7c1e-7b3a-9d2f-4e5a6b7c8d9e"
+	now := time.Now()
+	if err := p.Db.Create(&session.Session{
+		UserID:    u.ID,
+		Token:     legacyToken,
+		ExpiresAt: now.Add(time.Hour),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}).Error; err != nil {
+		t.Fatalf("create legacy session: %v", err)
+	}
+
+	restarted := New(Config{
+		Database:    DatabaseConfig{Instance: p.Db},
+		Environment: "test",
+		Security:    &security,
+	})
+	t.Cleanup(restarted.Close)
+
+	var plain int64
+	restarted.Db.Model(&session.Session{}).Where("token = ?", legacyToken).Count(&plain)
+	if plain != 0 {
+		t.Fatal("plaintext session token should be replaced by its hash on startup")
+	}
+	findStoredSession(t, restarted, legacyToken)
+
+	cookie := &http.Cookie{Name: "session_token", Value: legacyToken}
+	if resp, payload := twoFactorRequest(t, restarted, "GET", "/api/internal/auth/sessions", cookie, nil); resp.StatusCode != http.StatusOK {
+		t.Fatalf("legacy session should stay signed in, got %d (%v)", resp.StatusCode, payload)
+	}
+}
@@ -0,0 +1,227 @@
+package panel
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"mime"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ferdiunal/panel.go/pkg/storage"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// defaultStorageDisk, Path/URL ile tanımlanan yerel diskin adıdır.
+	defaultStorageDisk = "public"
+
+	// temporaryFileRoute, yerel ve bellek disklerinin süreli URL'lerini sunan route'tur.
+	temporaryFileRoute = "/api/storage"
+)
+
+// validateStorageConfig, disk tanımlarını doğrular; hatalar "storage." önekli anahtar yollarıyla döner.
+func validateStorageConfig(cfg StorageConfig) error {
+	var errs []error
+	fail := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("storage."+format, args...))
+	}
+
+	names := make([]string, 0, len(cfg.Disks))
+	for name := range cfg.Disks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		disk := cfg.Disks[name]
+		if name == "" || strings.ContainsAny(name, "/?#") {
+			fail("disks: invalid disk name %q", name)
+			continue
+		}
+		switch disk.Driver {
+		case "local", "memory":
+		case "s3":
+			if disk.Bucket == "" {
+				fail("disks.%s.bucket: is required for the s3 driver", name)
+			}
+			if disk.AccessKey == "" || disk.SecretKey == "" {
+				fail("disks.%s: access_key and secret_key are required for the s3 driver", name)
+			}
+		default:
+			fail("disks.%s.driver: must be one of local, s3, memory (got %q)", name, disk.Driver)
+		}
+	}
+
+	if cfg.Default != "" && cfg.Default != defaultStorageDisk {
+		if _, ok := cfg.Disks[cfg.Default]; !ok {
+			fail("default: disk %q is not defined in storage.disks", cfg.Default)
+		}
+	}
+	if cfg.Uploads.MaxSize < 0 {
+		fail("uploads.max_size: must not be negative")
+	}
+	if cfg.Uploads.Expiration < 0 {
+		fail("uploads.expiration: must not be negative")
+	}
+	return errors.Join(errs...)
+}
+
+// newStorageManager, Config.Storage'daki diskleri oluşturur. "public" diski
+// tanımlanmamışsa Path/URL (varsayılan ./storage/public ve /storage) ile yerel disk
+// eklenir. Süreli URL'lerin imza anahtarı da döner.
+func newStorageManager(cfg StorageConfig) (*storage.Manager, []byte, error) {
+	if err := validateStorageConfig(cfg); err != nil {
+		return nil, nil, err
+	}
+
+	signingKey := []byte(cfg.SigningKey)
+	if len(signingKey) == 0 {
+		signingKey = make([]byte, 32)
+		if _, err := rand.Read(signingKey); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	defaultDisk := cfg.Default
+	if defaultDisk == "" {
+		defaultDisk = defaultStorageDisk
+	}
+	manager := storage.NewManager(defaultDisk)
+
+	if _, ok := cfg.Disks[defaultStorageDisk]; !ok {
+		root, publicURL := legacyStorageLocation(cfg)
+		manager.Register(defaultStorageDisk, storage.NewLocalDisk(storage.LocalOptions{
+			Root:         root,
+			URL:          publicURL,
+			TemporaryURL: temporaryFileRoute + "/" + defaultStorageDisk,
+			SigningKey:   signingKey,
+		}))
+	}
+
+	for name, disk := range cfg.Disks {
+		switch disk.Driver {
+		case "local":
+			manager.Register(name, storage.NewLocalDisk(storage.LocalOptions{
+				Root:         disk.Root,
+				URL:          disk.URL,
+				TemporaryURL: temporaryFileRoute + "/" + name,
+				SigningKey:   signingKey,
+			}))
+		case "memory":
+			manager.Register(name, storage.NewMemoryDisk(storage.MemoryOptions{
+				URL:          disk.URL,
+				TemporaryURL: temporaryFileRoute + "/" + name,
+				SigningKey:   signingKey,
+			}))
+		case "s3":
+			s3Disk, err := storage.NewS3Disk(storage.S3Options{
+				Endpoint:  disk.Endpoint,
+				Region:    disk.Region,
+				Bucket:    disk.Bucket,
+				AccessKey: disk.AccessKey,
+				SecretKey: disk.SecretKey,
+				PathStyle: disk.PathStyle,
+				Root:      disk.Root,
+				URL:       disk.URL,
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("storage.disks.%s: %w", name, err)
+			}
+			manager.Register(name, s3Disk)
+		}
+	}
+	return manager, signingKey, nil
+}
+
+// legacyStorageLocation, Path/URL alanlarını varsayılanlarıyla döner.
+func legacyStorageLocation(cfg StorageConfig) (string, string) {
+	if cfg.Path != "" && cfg.URL != "" {
+		return cfg.Path, cfg.URL
+	}
+	return "./storage/public", "/storage"
+}
+
+// staticStorageMounts, panelin statik olarak sunacağı yerel diskleri URL -> dizin
+// olarak döner. Yalnızca göreli URL'e ("/media") sahip yerel diskler sunulur;
+// mutlak URL'ler (CDN) ve URL'i olmayan özel diskler atlanır.
+func staticStorageMounts(cfg StorageConfig) map[string]string {
+	mounts := make(map[string]string)
+	if _, ok := cfg.Disks[defaultStorageDisk]; !ok {
+		root, publicURL := legacyStorageLocation(cfg)
+		mounts[publicURL] = root
+	}
+	for _, disk := range cfg.Disks {
+		if disk.Driver != "local" || !strings.HasPrefix(disk.URL, "/") {
+			continue
+		}
+		root := disk.Root
+		if root == "" {
+			root = "./storage/public"
+		}
+		mounts[strings.TrimRight(disk.URL, "/")] = root
+	}
+	return mounts
+}
+
+// Disks, panelin depolama disklerini döner. Özel sürücüler bu Manager'a
+// kaydedilebilir: p.Disks().Register("gcs", myDisk)
+func (p *Panel) Disks() *storage.Manager {
+	return p.disks
+}
+
+// handleTemporaryFile, GET /api/storage/:disk/* isteğini işler. Disk.TemporaryURL
+// ile üretilen imzalı adresleri doğrular ve dosyayı oturum gerektirmeden sunar.
+func (p *Panel) handleTemporaryFile(c *fiber.Ctx) error {
+	requestPath, err := url.PathUnescape(c.Path())
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+	err = storage.VerifySignedURL(p.storageSigningKey, requestPath, c.Query("expires"), c.Query("signature"), time.Now())
+	if err != nil {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Invalid or expired link"})
+	}
+
+	name := c.Params("disk")
+	disk, err := p.disks.Disk(name)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "File not found"})
+	}
+	filePath := strings.TrimPrefix(requestPath, temporaryFileRoute+"/"+name+"/")
+	reader, err := disk.Get(c.UserContext(), filePath)
+	if errors.Is(err, storage.ErrNotFound) || errors.Is(err, storage.ErrInvalidPath) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "File not found"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to read file"})
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(filePath))
+	if contentType == "" {
+		contentType = fiber.MIMEOctetStream
+	}
+	c.Set(fiber.HeaderContentType, contentType)
+	// HTML, SVG gibi betik çalıştırabilen içerikler panel origin'inde açılmaz, indirilir
+	if !isInlineStorageType(contentType) {
+		c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", path.Base(filePath)))
+	}
+	c.Set(fiber.HeaderCacheControl, "private, no-store")
+	c.Set("X-Content-Type-Options", "nosniff")
+	return c.SendStream(reader)
+}
+
+// isInlineStorageType, tarayıcıda güvenle gösterilebilen içerik tiplerini kabul eder.
+func isInlineStorageType(contentType string) bool {
+	mediaType := strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	switch {
+	case mediaType == "image/svg+xml":
+		return false
+	case strings.HasPrefix(mediaType, "image/"), strings.HasPrefix(mediaType, "video/"),
+		strings.HasPrefix(mediaType, "audio/"), mediaType == "application/pdf":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,411 @@
+package panel
+
+import (
+	stdcontext "context"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ferdiunal/panel.go/pkg/data/orm"
+	"github.com/ferdiunal/panel.go/pkg/domain/storedfile"
+	"github.com/ferdiunal/panel.go/pkg/fields"
+	"github.com/ferdiunal/panel.go/pkg/handler"
+	"github.com/ferdiunal/panel.go/pkg/storage"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultOrphanSweepInterval, bekleme süresi dolan yetim dosyaların en fazla
+	// hangi sıklıkla silindiğidir. Daha kısa bekleme sürelerinde süre kadar beklenir.
+	DefaultOrphanSweepInterval = time.Hour
+
+	// DefaultStoragePruneAge, storage:prune'un dokunmadığı yeni dosyaların yaşıdır.
+	// Yüklenip henüz kayda bağlanmamış dosyaların silinmesini önler.
+	DefaultStoragePruneAge = 24 * time.Hour
+
+	// orphanSweepBatchSize, tek seferde silinen yetim dosya sayısıdır.
+	orphanSweepBatchSize = 100
+
+	// storagePruneBatchSize, referans taraması sırasında tek seferde okunan kayıt sayısıdır.
+	storagePruneBatchSize = 200
+)
+
+// fileJanitor, dosya alanlarının dosyalarını stored_files tablosunda izler ve
+// kayıttan ayrılan dosyaları Storage.OrphanGracePeriod dolduktan sonra diskten siler.
+//
+// Bekleme süresi 0 ise dosyalar güncelleme/silme işlemi tamamlanır tamamlanmaz,
+// pozitifse arka planda periyodik olarak silinir; negatifse yalnızca işaretlenir
+// ve storage:prune ile temizlenir.
+type fileJanitor struct {
+	repo      *orm.StoredFileRepository
+	disks     *storage.Manager
+	grace     time.Duration
+	stopCh    chan struct{}
+	doneCh    chan struct{}
+	closeOnce sync.Once
+}
+
+func newFileJanitor(repo *orm.StoredFileRepository, disks *storage.Manager, grace time.Duration) *fileJanitor {
+	j := &fileJanitor{
+		repo:   repo,
+		disks:  disks,
+		grace:  grace,
+		stopCh: make(chan struct{}),
+		doneCh: make(chan struct{}),
+	}
+	if grace > 0 {
+		go j.run()
+	} else {
+		close(j.doneCh)
+	}
+	return j
+}
+
+// TrackFiles, handler.FileTracker arayüzünü uygular.
+func (j *fileJanitor) TrackFiles(ctx stdcontext.Context, resource, recordID string, current, previous map[string][]storedfile.Ref) error {
+	used := make(map[storedfile.Ref]bool)
+	for field, refs := range current {
+		owner := storedfile.Owner{Resource: resource, RecordID: recordID, Field: field}
+		if err := j.repo.Claim(ctx, owner, refs); err != nil {
+			return err
+		}
+		for _, ref := range refs {
+			used[ref] = true
+		}
+	}
+
+	// Yalnızca bu işlemde yazılan alanların eski dosyaları serbest bırakılır
+	released := make(map[string][]storedfile.Ref)
+	owned, err := j.repo.Owned(ctx, resource, recordID)
+	if err != nil {
+		return err
+	}
+	for _, file := range owned {
+		ref := storedfile.Ref{Disk: file.Disk, Path: file.Path}
+		if _, written := current[file.Field]; written && !used[ref] {
+			released[file.Field] = append(released[file.Field], ref)
+		}
+	}
+	for field, refs := range previous {
+		for _, ref := range refs {
+			if !used[ref] {
+				released[field] = append(released[field], ref)
+			}
+		}
+	}
+	return j.release(ctx, resource, recordID, released)
+}
+
+// ReleaseFiles, handler.FileTracker arayüzünü uygular.
+func (j *fileJanitor) ReleaseFiles(ctx stdcontext.Context, resource, recordID string, files map[string][]storedfile.Ref) error {
+	released := make(map[string][]storedfile.Ref, len(files))
+	for field, refs := range files {
+		released[field] = append(released[field], refs...)
+	}
+	owned, err := j.repo.Owned(ctx, resource, recordID)
+	if err != nil {
+		return err
+	}
+	for _, file := range owned {
+		released[file.Field] = append(released[file.Field], storedfile.Ref{Disk: file.Disk, Path: file.Path})
+	}
+	return j.release(ctx, resource, recordID, released)
+}
+
+func (j *fileJanitor) release(ctx stdcontext.Context, resource, recordID string, released map[string][]storedfile.Ref) error {
+	now := time.Now()
+	for field, refs := range released {
+		owner := storedfile.Owner{Resource: resource, RecordID: recordID, Field: field}
+		if err := j.repo.Release(ctx, owner, refs, now); err != nil {
+			return err
+		}
+	}
+	if j.grace == 0 {
+		_, err := j.sweep(ctx, now)
+		return err
+	}
+	return nil
+}
+
+func (j *fileJanitor) run() {
+	defer close(j.doneCh)
+
+	interval := DefaultOrphanSweepInterval
+	if j.grace < interval {
+		interval = j.grace
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := j.sweep(stdcontext.Background(), time.Now()); err != nil {
+				log.Printf("[storage] orphaned file sweep failed: %v", err)
+			}
+		case <-j.stopCh:
+			return
+		}
+	}
+}
+
+// sweep, bekleme süresi now itibarıyla dolan yetim dosyaları siler ve silinen
+// dosya sayısını döner. Dosyası diskte bulunmayan kayıtlar da temizlenir.
+func (j *fileJanitor) sweep(ctx stdcontext.Context, now time.Time) (int, error) {
+	removed := 0
+	for {
+		due, err := j.repo.Due(ctx, now.Add(-j.grace), orphanSweepBatchSize)
+		if err != nil {
+			return removed, err
+		}
+		for _, file := range due {
+			// Kayıt önce alınır; aynı anda çalışan replikalar dosyayı iki kez silmez
+			taken, err := j.repo.Take(ctx, file)
+			if err != nil {
+				return removed, err
+			}
+			if !taken {
+				continue
+			}
+			disk, err := j.disks.Disk(file.Disk)
+			if err != nil {
+				log.Printf("[storage] orphaned file %s on %s skipped: %v", file.Path, file.Disk, err)
+				continue
+			}
+			if err := disk.Delete(ctx, file.Path); err != nil && !errors.Is(err, storage.ErrNotFound) {
+				log.Printf("[storage] deleting orphaned file %s on %s failed: %v", file.Path, file.Disk, err)
+				continue
+			}
+			removed++
+		}
+		if len(due) < orphanSweepBatchSize {
+			return removed, nil
+		}
+	}
+}
+
+// Close, arka plandaki temizleme goroutine'ini durdurur ve çıkmasını bekler.
+// Birden fazla kez çağrılabilir; nil janitor için bir şey yapmaz.
+func (j *fileJanitor) Close() {
+	if j == nil {
+		return
+	}
+	j.closeOnce.Do(func() {
+		close(j.stopCh)
+	})
+	<-j.doneCh
+}
+
+// StoragePruneOptions, PruneStorage çalışmasının ayarlarıdır.
+type StoragePruneOptions struct {
+	// OlderThan, bu süreden yeni dosyalara dokunulmaz (varsayılan DefaultStoragePruneAge).
+	OlderThan time.Duration
+	// DryRun true ise dosyalar silinmez, yalnızca raporlanır.
+	DryRun bool
+}
+
+// StoragePruneResult, bir disk için PruneStorage çalışmasının özetidir.
+type StoragePruneResult struct {
+	Disk    string   `json:"disk"`
+	Dirs    []string `json:"dirs"`
+	Scanned int      `json:"scanned"`
+	Removed []string `json:"removed"`
+	Skipped string   `json:"skipped,omitempty"`
+}
+
+// / # PruneStorage Metodu
+// /
+// / Dosya alanlarının yazdığı klasörleri tarar ve hiçbir resource kaydının
+// / referans vermediği dosyaları siler. Alan değiştirilirken veya kayıt silinirken
+// / izlenmeyen (izleme açılmadan önce yüklenmiş, doğrulamada reddedilmiş) dosyaları
+// / temizlemek için kullanılır.
+// /
+// / ## Önemli Notlar
+// / - Yalnızca Store(disk, klasör) ile klasör tanımlayan alanların klasörleri taranır
+// / - Soft delete edilmiş kayıtların dosyaları referanslı sayılır
+// / - OlderThan'dan yeni dosyalara (yüklemesi süren istekler) dokunulmaz
+// / - storage.Lister uygulamayan diskler atlanır (Skipped)
+func (p *Panel) PruneStorage(ctx stdcontext.Context, opts StoragePruneOptions) ([]StoragePruneResult, error) {
+	if ctx == nil {
+		ctx = stdcontext.Background()
+	}
+	if opts.OlderThan <= 0 {
+		opts.OlderThan = DefaultStoragePruneAge
+	}
+
+	dirs, referenced, err := p.storageReferences(ctx)
+	if err != nil {
+		return nil, err
+	}
+	repo := orm.NewStoredFileRepository(p.Db)
+
+	names := make([]string, 0, len(dirs))
+	for name := range dirs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	cutoff := time.Now().Add(-opts.OlderThan)
+	results := make([]StoragePruneResult, 0, len(names))
+	for _, name := range names {
+		result := StoragePruneResult{Disk: name}
+		for dir := range dirs[name] {
+			result.Dirs = append(result.Dirs, dir)
+		}
+		sort.Strings(result.Dirs)
+
+		disk, err := p.disks.Disk(name)
+		if err != nil {
+			return results, err
+		}
+		lister, ok := disk.(storage.Lister)
+		if !ok {
+			result.Skipped = "disk does not support listing"
+			results = append(results, result)
+			continue
+		}
+
+		// İzleme tablosunda kullanımda görünen dosyalar da korunur
+		active, err := repo.Active(ctx, name)
+		if err != nil {
+			return results, err
+		}
+		for _, path := range active {
+			referenced[name][path] = true
+		}
+
+		seen := make(map[string]bool)
+		for _, dir := range result.Dirs {
+			files, err := lister.List(ctx, dir)
+			if err != nil {
+				return results, fmt.Errorf("%s/%s: %w", name, dir, err)
+			}
+			for _, file := range files {
+				if seen[file.Path] {
+					continue
+				}
+				seen[file.Path] = true
+				result.Scanned++
+				if referenced[name][file.Path] || file.LastModified.After(cutoff) {
+					continue
+				}
+				if !opts.DryRun {
+					if err := disk.Delete(ctx, file.Path); err != nil && !errors.Is(err, storage.ErrNotFound) {
+						return results, fmt.Errorf("%s/%s: %w", name, file.Path, err)
+					}
+				}
+				result.Removed = append(result.Removed, file.Path)
+			}
+		}
+		if !opts.DryRun {
+			if err := repo.Forget(ctx, name, result.Removed); err != nil {
+				return results, err
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// storageReferences, kayıtlı resource'ların dosya alanlarını okuyarak taranacak
+// klasörleri ve kullanılan dosya yollarını disk adına göre döner.
+func (p *Panel) storageReferences(ctx stdcontext.Context) (map[string]map[string]bool, map[string]map[string]bool, error) {
+	dirs := make(map[string]map[string]bool)
+	referenced := make(map[string]map[string]bool)
+	snapshot := p.loadRegistrySnapshot()
+	if snapshot == nil || p.disks == nil {
+		return dirs, referenced, nil
+	}
+
+	slugs := make([]string, 0, len(snapshot.resources))
+	for slug := range snapshot.resources {
+		slugs = append(slugs, slug)
+	}
+	sort.Strings(slugs)
+
+	for _, slug := range slugs {
+		res := snapshot.resources[slug]
+		if res == nil || res.Model() == nil {
+			continue
+		}
+		stmt := &gorm.Statement{DB: p.Db}
+		if err := stmt.Parse(res.Model()); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", slug, err)
+		}
+
+		type fileField struct {
+			element fields.Element
+			disk    storage.Disk
+			name    string
+		}
+		var tracked []fileField
+		var preloads []string
+		for _, element := range res.Fields() {
+			if element == nil {
+				continue
+			}
+			if !handler.IsTrackedFileElement(element) {
+				continue
+			}
+			name, dir := element.GetStorageDisk(), element.GetStoragePath()
+			if name == "" && dir == "" {
+				continue
+			}
+			if name == "" {
+				name = p.disks.Default()
+			}
+			disk, err := p.disks.Disk(name)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s.%s: %w", slug, element.GetKey(), err)
+			}
+			if referenced[name] == nil {
+				referenced[name] = make(map[string]bool)
+			}
+			// Kök klasöre yazan alanlar için disk taranmaz; diskte başka dosyalar olabilir
+			if cleaned, err := storage.CleanPath(dir); err == nil && cleaned != "" {
+				if dirs[name] == nil {
+					dirs[name] = make(map[string]bool)
+				}
+				dirs[name][cleaned] = true
+			}
+			tracked = append(tracked, fileField{element: element, disk: disk, name: name})
+			if relation := handler.AttachmentRelation(stmt.Schema, element); relation != "" {
+				preloads = append(preloads, relation)
+			}
+		}
+		if len(tracked) == 0 {
+			continue
+		}
+
+		records := reflect.New(reflect.SliceOf(reflect.PointerTo(stmt.Schema.ModelType)))
+		query := p.Db.WithContext(ctx).Unscoped().Model(reflect.New(stmt.Schema.ModelType).Interface())
+		for _, relation := range preloads {
+			query = query.Preload(relation)
+		}
+		err := query.FindInBatches(records.Interface(), storagePruneBatchSize, func(tx *gorm.DB, _ int) error {
+			rows := records.Elem()
+			for i := 0; i < rows.Len(); i++ {
+				record := rows.Index(i).Interface()
+				for _, field := range tracked {
+					values, _ := handler.FileFieldValues(record, field.element)
+					for _, value := range values {
+						if path, ok := storage.PathFromValue(field.disk, value); ok {
+							for _, stored := range handler.StoredPaths(field.element, path) {
+								referenced[field.name][stored] = true
+							}
+						}
+					}
+				}
+			}
+			return nil
+		}).Error
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", slug, err)
+		}
+	}
+	return dirs, referenced, nil
+}
@@ -0,0 +1,237 @@
+package panel
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ferdiunal/panel.go/pkg/domain/storedfile"
+	"github.com/ferdiunal/panel.go/pkg/fields"
+	"github.com/ferdiunal/panel.go/pkg/storage"
+	"gorm.io/gorm"
+)
+
+type softStoredProduct struct {
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	Name      string         `json:"name"`
+	Cover     string         `json:"cover"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+}
+
+// storedProductRequest, stored-products kaynağına multipart istek gönderir.
+// files alan anahtarından dosya adına eşlenir; kapak için PNG, diğerleri için düz metin yazılır.
+func storedProductRequest(t *testing.T, p *Panel, cookie *http.Cookie, method, path string, files map[string]string) {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	_ = writer.WriteField("name", "Widget")
+	for key, name := range files {
+		part, _ := writer.CreateFormFile(key, name)
+		if strings.HasSuffix(name, ".png") {
+			part.Write(pngWithComment(t))
+		} else {
+			part.Write([]byte("%PDF-1.4 " + name))
+		}
+	}
+	writer.Close()
+
+	req := httptest.NewRequest(method, path, &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.AddCookie(cookie)
+	resp, err := testFiberRequest(p.Fiber, req)
+	if err != nil {
+		t.Fatalf("%s %s failed: %v", method, path, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		t.Fatalf("%s %s: unexpected status %d", method, path, resp.StatusCode)
+	}
+}
+
+// storageCleanupRequest, JSON isteği gönderir ve başarılı yanıt bekler.
+func storageCleanupRequest(t *testing.T, p *Panel, cookie *http.Cookie, method, path string, body interface{}) {
+	t.Helper()
+	resp := testJSONRequest(t, p, cookie, method, path, body, nil)
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		t.Fatalf("%s %s: unexpected status %d", method, path, resp.StatusCode)
+	}
+}
+
+func TestStorageCleanup_ReplacedClearedAndDeletedFilesAreRemoved(t *testing.T) {
+	p := setupStoragePanel(t)
+	cookie := registerAndLoginTestUser(t, p, "cleanup@example.com")
+	media, private := memoryDisk(t, p, "media"), memoryDisk(t, p, "private")
+
+	storedProductRequest(t, p, cookie, "POST", "/api/internal/resource/stored-products",
+		map[string]string{"cover": "first.png", "manual": "manual.pdf"})
+	var product storedProduct
+	if err := p.Db.First(&product).Error; err != nil {
+		t.Fatalf("expected stored product: %v", err)
+	}
+	first := media.Paths()
+	if len(first) != 1 {
+		t.Fatalf("expected one cover, got %v", first)
+	}
+	var tracked int64
+	p.Db.Model(&storedfile.File{}).Where("orphaned_at IS NULL").Count(&tracked)
+	if tracked != 2 {
+		t.Fatalf("expected cover and manual to be tracked, got %d rows", tracked)
+	}
+
+	productPath := fmt.Sprintf("/api/internal/resource/stored-products/%d", product.ID)
+	storedProductRequest(t, p, cookie, "PUT", productPath, map[string]string{"cover": "second.png"})
+	second := media.Paths()
+	if len(second) != 1 || second[0] == first[0] {
+		t.Fatalf("expected only the replacement cover to remain, got %v (was %v)", second, first)
+	}
+	if paths := private.Paths(); len(paths) != 1 {
+		t.Fatalf("expected manual not sent in the update to be kept, got %v", paths)
+	}
+
+	storageCleanupRequest(t, p, cookie, "PUT", productPath, map[string]interface{}{"name": "Widget", "cover": ""})
+	if paths := media.Paths(); len(paths) != 0 {
+		t.Fatalf("expected cleared cover to be removed, got %v", paths)
+	}
+
+	storageCleanupRequest(t, p, cookie, "DELETE", productPath, nil)
+	if paths := private.Paths(); len(paths) != 0 {
+		t.Fatalf("expected manual of the deleted product to be removed, got %v", paths)
+	}
+	var remaining int64
+	p.Db.Model(&storedfile.File{}).Count(&remaining)
+	if remaining != 0 {
+		t.Fatalf("expected removed files to be forgotten, got %d rows", remaining)
+	}
+}
+
+func TestStorageCleanup_SoftDeleteKeepsFiles(t *testing.T) {
+	p := setupStoragePanel(t)
+	registerTestResource(t, p, &softStoredProduct{}, "soft-stored-products", func() []fields.Element {
+		return []fields.Element{
+			fields.ID(),
+			fields.Text("Name", "name"),
+			fields.Image("Cover", "cover").Store("media", "soft"),
+		}
+	})
+	cookie := registerAndLoginTestUser(t, p, "cleanup-soft@example.com")
+
+	storedProductRequest(t, p, cookie, "POST", "/api/internal/resource/soft-stored-products",
+		map[string]string{"cover": "soft.png"})
+	var product softStoredProduct
+	if err := p.Db.First(&product).Error; err != nil {
+		t.Fatalf("expected stored product: %v", err)
+	}
+
+	storageCleanupRequest(t, p, cookie, "DELETE", fmt.Sprintf("/api/internal/resource/soft-stored-products/%d", product.ID), nil)
+	if err := p.Db.First(&softStoredProduct{}, product.ID).Error; err == nil {
+		t.Fatal("expected product to be soft deleted")
+	}
+	if paths := memoryDisk(t, p, "media").Paths(); len(paths) != 1 {
+		t.Fatalf("expected cover of the soft deleted product to be kept, got %v", paths)
+	}
+}
+
+func TestStorageCleanup_GracePeriodDefersDeletion(t *testing.T) {
+	p := setupStoragePanelWith(t, func(cfg *Config) {
+		cfg.Storage.OrphanGracePeriod = time.Hour
+	})
+	cookie := registerAndLoginTestUser(t, p, "cleanup-grace@example.com")
+	media := memoryDisk(t, p, "media")
+
+	storedProductRequest(t, p, cookie, "POST", "/api/internal/resource/stored-products",
+		map[string]string{"cover": "first.png"})
+	var product storedProduct
+	p.Db.First(&product)
+	storedProductRequest(t, p, cookie, "PUT", fmt.Sprintf("/api/internal/resource/stored-products/%d", product.ID),
+		map[string]string{"cover": "second.png"})
+
+	if paths := media.Paths(); len(paths) != 2 {
+		t.Fatalf("expected replaced cover to be kept during the grace period, got %v", paths)
+	}
+	var orphaned int64
+	p.Db.Model(&storedfile.File{}).Where("orphaned_at IS NOT NULL").Count(&orphaned)
+	if orphaned != 1 {
+		t.Fatalf("expected replaced cover to be marked orphaned, got %d rows", orphaned)
+	}
+
+	if removed, err := p.fileJanitor.sweep(context.Background(), time.Now()); err != nil || removed != 0 {
+		t.Fatalf("expected nothing to be due yet, got %d (err=%v)", removed, err)
+	}
+	if removed, err := p.fileJanitor.sweep(context.Background(), time.Now().Add(2*time.Hour)); err != nil || removed != 1 {
+		t.Fatalf("expected replaced cover to be removed after the grace period, got %d (err=%v)", removed, err)
+	}
+	var stored storedProduct
+	p.Db.First(&stored, product.ID)
+	if paths := media.Paths(); len(paths) != 1 || stored.Cover != "https://cdn.example.com/"+paths[0] {
+		t.Fatalf("expected only the current cover to remain, got %v (cover %q)", paths, stored.Cover)
+	}
+}
+
+func TestPruneStorage_RemovesUnreferencedFiles(t *testing.T) {
+	p := setupStoragePanel(t)
+	media := memoryDisk(t, p, "media")
+	ctx := context.Background()
+	old := time.Now().Add(-48 * time.Hour)
+
+	for _, name := range []string{"products/used.png", "products/stale.png", "products/fresh.png", "avatars/other.png"} {
+		if err := media.Put(ctx, name, strings.NewReader(name), storage.PutOptions{}); err != nil {
+			t.Fatalf("put failed: %v", err)
+		}
+		if name != "products/fresh.png" {
+			media.Touch(name, old)
+		}
+	}
+	p.Db.Create(&storedProduct{Name: "Widget", Cover: "https://cdn.example.com/products/used.png"})
+
+	results, err := p.PruneStorage(ctx, StoragePruneOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	var mediaResult *StoragePruneResult
+	for i := range results {
+		if results[i].Disk == "media" {
+			mediaResult = &results[i]
+		}
+	}
+	if mediaResult == nil || mediaResult.Scanned != 3 || len(mediaResult.Removed) != 1 || mediaResult.Removed[0] != "products/stale.png" {
+		t.Fatalf("expected only the stale product image to be reported, got %+v", results)
+	}
+	if paths := media.Paths(); len(paths) != 4 {
+		t.Fatalf("expected dry run to keep all files, got %v", paths)
+	}
+
+	if _, err := p.PruneStorage(ctx, StoragePruneOptions{}); err != nil {
+		t.Fatalf("prune failed: %v", err)
+	}
+	if paths := strings.Join(media.Paths(), ","); paths != "avatars/other.png,products/fresh.png,products/used.png" {
+		t.Fatalf("expected stale file to be removed only, got %s", paths)
+	}
+}
+
+func TestStoragePruneCommand(t *testing.T) {
+	p := setupStoragePanel(t)
+	media := memoryDisk(t, p, "media")
+	media.Put(context.Background(), "products/stale.png", strings.NewReader("x"), storage.PutOptions{})
+
+	var out bytes.Buffer
+	cmd := p.newStoragePruneCommand()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--dry-run", "--older-than", "1ns"})
+	time.Sleep(time.Millisecond)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "media [products]: 1 scanned, 1 would be removed") || !strings.Contains(out.String(), "products/stale.png") {
+		t.Fatalf("unexpected output: %s", out.String())
+	}
+	if len(media.Paths()) != 1 {
+		t.Fatal("expected dry run not to delete files")
+	}
+}
@@ -0,0 +1,306 @@
+package panel
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ferdiunal/panel.go/pkg/fields"
+	"github.com/ferdiunal/panel.go/pkg/storage"
+)
+
+type storedProduct struct {
+	ID     uint   `json:"id" gorm:"primaryKey"`
+	Name   string `json:"name"`
+	Cover  string `json:"cover"`
+	Manual string `json:"manual"`
+}
+
+func storedProductFields() []fields.Element {
+	cover := fields.Image("Cover", "cover")
+	cover.Store("media", "products")
+	cover.Accept("image/png", "image/jpeg")
+	cover.MaxSize(64 << 10)
+	cover.MarkRemoveEXIFData()
+
+	return []fields.Element{
+		fields.ID(),
+		fields.Text("Name", "name"),
+		cover,
+		fields.File("Manual", "manual").Store("private", "manuals"),
+	}
+}
+
+func setupStoragePanel(t *testing.T) *Panel {
+	t.Helper()
+	return setupStoragePanelWith(t, nil)
+}
+
+// setupStoragePanelWith, configure verilirse panel oluşturulmadan önce Config'i değiştirir.
+func setupStoragePanelWith(t *testing.T, configure func(*Config)) *Panel {
+	t.Helper()
+
+	config := Config{
+		Storage: StorageConfig{
+			Path:       t.TempDir(),
+			URL:        "/uploads",
+			SigningKey: "test-signing-key",
+			Disks: map[string]DiskConfig{
+				"media":   {Driver: "memory", URL: "https://cdn.example.com"},
+				"private": {Driver: "memory"},
+			},
+		},
+	}
+	if configure != nil {
+		configure(&config)
+	}
+	p := newIsolatedTestPanel(t, config)
+	t.Cleanup(p.Close)
+	registerTestResource(t, p, &storedProduct{}, "stored-products", storedProductFields)
+	return p
+}
+
+func memoryDisk(t *testing.T, p *Panel, name string) *storage.MemoryDisk {
+	t.Helper()
+
+	disk, err := p.Disks().Disk(name)
+	if err != nil {
+		t.Fatalf("expected %s disk: %v", name, err)
+	}
+	memory, ok := disk.(*storage.MemoryDisk)
+	if !ok {
+		t.Fatalf("expected memory disk, got %T", disk)
+	}
+	return memory
+}
+
+// pngWithComment, IEND'den önce konum bilgisi taşıyan bir tEXt parçası olan PNG üretir.
+func pngWithComment(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 2, 2))); err != nil {
+		t.Fatalf("png encode failed: %v", err)
+	}
+	data := buf.Bytes()
+	payload := []byte("Comment\x00GPS 41.0082N 28.9784E")
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(payload)))
+	chunk = append(chunk, "tEXt"...)
+	chunk = append(chunk, payload...)
+	chunk = binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+
+	iend := len(data) - 12
+	out := append([]byte{}, data[:iend]...)
+	out = append(out, chunk...)
+	return append(out, data[iend:]...)
+}
+
+func TestStorage_FieldUploadsUseConfiguredDisks(t *testing.T) {
+	p := setupStoragePanel(t)
+	cookie := registerAndLoginTestUser(t, p, "storage@example.com")
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	_ = writer.WriteField("name", "Widget")
+	cover, _ := writer.CreateFormFile("cover", "Cover.PNG")
+	cover.Write(pngWithComment(t))
+	manual, _ := writer.CreateFormFile("manual", "manual.pdf")
+	manual.Write([]byte("pdf-bytes"))
+	writer.Close()
+
+	req := httptest.NewRequest("POST", "/api/internal/resource/stored-products", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.AddCookie(cookie)
+	resp, err := testFiberRequest(p.Fiber, req)
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		t.Fatalf("expected created product, got %d", resp.StatusCode)
+	}
+
+	var product storedProduct
+	if err := p.Db.First(&product).Error; err != nil {
+		t.Fatalf("expected stored product: %v", err)
+	}
+
+	media := memoryDisk(t, p, "media").Paths()
+	if len(media) != 1 || !strings.HasPrefix(media[0], "products/") || !strings.HasSuffix(media[0], ".png") {
+		t.Fatalf("expected cover on media disk under products/, got %v", media)
+	}
+	if product.Cover != "https://cdn.example.com/"+media[0] {
+		t.Fatalf("expected public cover URL, got %q", product.Cover)
+	}
+	coverDisk, _ := p.Disks().Disk("media")
+	reader, err := coverDisk.Get(context.Background(), media[0])
+	if err != nil {
+		t.Fatalf("expected stored cover: %v", err)
+	}
+	stored, _ := io.ReadAll(reader)
+	reader.Close()
+	if bytes.Contains(stored, []byte("GPS 41.0082N")) {
+		t.Fatal("expected location metadata to be stripped from the cover")
+	}
+	if _, err := png.Decode(bytes.NewReader(stored)); err != nil {
+		t.Fatalf("expected stored cover to remain a valid png: %v", err)
+	}
+
+	private := memoryDisk(t, p, "private").Paths()
+	if len(private) != 1 || !strings.HasPrefix(private[0], "manuals/") {
+		t.Fatalf("expected manual on private disk under manuals/, got %v", private)
+	}
+	if product.Manual != private[0] {
+		t.Fatalf("expected private disk path to be stored, got %q", product.Manual)
+	}
+}
+
+func TestStorage_TemporaryURLServesPrivateFile(t *testing.T) {
+	p := setupStoragePanel(t)
+	disk, _ := p.Disks().Disk("private")
+	ctx := context.Background()
+	if err := disk.Put(ctx, "manuals/guide.pdf", strings.NewReader("guide"), storage.PutOptions{}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if err := disk.Put(ctx, "pages/evil.html", strings.NewReader("<script>"), storage.PutOptions{}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	signed, err := disk.TemporaryURL(ctx, "manuals/guide.pdf", time.Minute)
+	if err != nil {
+		t.Fatalf("TemporaryURL failed: %v", err)
+	}
+	if !strings.HasPrefix(signed, "/api/storage/private/manuals/guide.pdf?") {
+		t.Fatalf("unexpected temporary URL %q", signed)
+	}
+
+	resp, err := testFiberRequest(p.Fiber, httptest.NewRequest("GET", signed, nil))
+	if err != nil {
+		t.Fatalf("GET temporary URL failed: %v", err)
+	}
+	content, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || string(content) != "guide" {
+		t.Fatalf("expected file content, got %d %q", resp.StatusCode, content)
+	}
+	if resp.Header.Get("Content-Type") != "application/pdf" {
+		t.Fatalf("expected pdf content type, got %q", resp.Header.Get("Content-Type"))
+	}
+
+	tampered := strings.Replace(signed, "guide.pdf", "other.pdf", 1)
+	resp, _ = testFiberRequest(p.Fiber, httptest.NewRequest("GET", tampered, nil))
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected 403 for tampered path, got %d", resp.StatusCode)
+	}
+
+	htmlURL, _ := disk.TemporaryURL(ctx, "pages/evil.html", time.Minute)
+	resp, _ = testFiberRequest(p.Fiber, httptest.NewRequest("GET", htmlURL, nil))
+	resp.Body.Close()
+	if !strings.HasPrefix(resp.Header.Get("Content-Disposition"), "attachment") {
+		t.Fatalf("expected html to be served as attachment, got %q", resp.Header.Get("Content-Disposition"))
+	}
+}
+
+func TestStorage_UploadConstraintsRejectInvalidFiles(t *testing.T) {
+	p := setupStoragePanel(t)
+	cookie := registerAndLoginTestUser(t, p, "constraints@example.com")
+
+	cases := []struct {
+		name     string
+		field    string
+		filename string
+		content  []byte
+	}{
+		{"too large", "cover", "big.png", append(pngWithComment(t), make([]byte, 64<<10)...)},
+		{"wrong type", "cover", "notes.png", []byte("plain text pretending to be a png")},
+		{"html disguised as image", "cover", "cat.jpg", []byte("<html><script>alert(1)</script></html>")},
+		{"svg with script", "manual", "diagram.svg", []byte(`<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`)},
+		{"html document", "manual", "manual.html", []byte("<p>manual</p>")},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var body bytes.Buffer
+			writer := multipart.NewWriter(&body)
+			_ = writer.WriteField("name", "Widget")
+			part, _ := writer.CreateFormFile(tc.field, tc.filename)
+			part.Write(tc.content)
+			writer.Close()
+
+			req := httptest.NewRequest("POST", "/api/internal/resource/stored-products", &body)
+			req.Header.Set("Content-Type", writer.FormDataContentType())
+			req.AddCookie(cookie)
+			resp, err := testFiberRequest(p.Fiber, req)
+			if err != nil {
+				t.Fatalf("create failed: %v", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusUnprocessableEntity {
+				t.Fatalf("expected 422, got %d", resp.StatusCode)
+			}
+
+			var payload struct {
+				Code   string              `json:"code"`
+				Errors map[string][]string `json:"errors"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode failed: %v", err)
+			}
+			if payload.Code != "VALIDATION_ERROR" || len(payload.Errors[tc.field]) == 0 {
+				t.Fatalf("expected a validation error for %s, got %+v", tc.field, payload)
+			}
+		})
+	}
+
+	if paths := memoryDisk(t, p, "media").Paths(); len(paths) != 0 {
+		t.Fatalf("expected rejected covers not to be stored, got %v", paths)
+	}
+	if paths := memoryDisk(t, p, "private").Paths(); len(paths) != 0 {
+		t.Fatalf("expected rejected manuals not to be stored, got %v", paths)
+	}
+	var count int64
+	p.Db.Model(&storedProduct{}).Count(&count)
+	if count != 0 {
+		t.Fatalf("expected no products to be created, got %d", count)
+	}
+}
+
+func TestValidateStorageConfig(t *testing.T) {
+	valid := StorageConfig{
+		Default: "s3",
+		Disks: map[string]DiskConfig{
+			"s3":    {Driver: "s3", Bucket: "media", AccessKey: "key", SecretKey: "secret"},
+			"cache": {Driver: "memory"},
+		},
+	}
+	if err := validateStorageConfig(valid); err != nil {
+		t.Fatalf("expected valid storage config, got %v", err)
+	}
+
+	invalid := StorageConfig{
+		Default: "missing",
+		Disks: map[string]DiskConfig{
+			"s3":  {Driver: "s3"},
+			"ftp": {Driver: "ftp"},
+		},
+	}
+	err := validateStorageConfig(invalid)
+	if err == nil {
+		t.Fatal("expected invalid storage config to be rejected")
+	}
+	for _, want := range []string{"storage.default", "storage.disks.s3.bucket", "storage.disks.ftp.driver"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q in error, got %v", want, err)
+		}
+	}
+}
@@ -1,9 +1,21 @@
 package panel
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
 
+	appContext "github.com/ferdiunal/panel.go/pkg/context"
+	"github.com/ferdiunal/panel.go/pkg/fields"
+	"github.com/ferdiunal/panel.go/pkg/resource"
 	"github.com/gofiber/fiber/v2"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
 )
 
 const panelTestRequestTimeoutMS = 15000
@@ -11,3 +23,86 @@ const panelTestRequestTimeoutMS = 15000
 func testFiberRequest(app *fiber.App, req *http.Request) (*http.Response, error) {
 	return app.Test(req, panelTestRequestTimeoutMS)
 }
+
+// newIsolatedTestPanel, teste özel paylaşımlı bellek içi SQLite veritabanıyla test ortamında
+// bir panel oluşturur. Veritabanı adı test adından türetildiği için alt testler birbirini görmez.
+func newIsolatedTestPanel(t *testing.T, cfg Config) *Panel {
+	t.Helper()
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", strings.ReplaceAll(t.Name(), "/", "_"))
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect db: %v", err)
+	}
+	cfg.Database = DatabaseConfig{Instance: db}
+	cfg.Environment = "test"
+	return New(cfg)
+}
+
+// testFields, her çağrıda yeni alan listesi üreten fonksiyonu resource.FieldResolver olarak kullanır.
+type testFields func() []fields.Element
+
+func (f testFields) ResolveFields(_ *appContext.Context) []fields.Element {
+	return f()
+}
+
+// migrateTestModels, ilişki tabloları gibi resource olarak kaydedilmeyen modellerin tablolarını oluşturur.
+func migrateTestModels(t *testing.T, p *Panel, models ...interface{}) {
+	t.Helper()
+	if err := p.Db.AutoMigrate(models...); err != nil {
+		t.Fatalf("failed to migrate test models: %v", err)
+	}
+}
+
+// registerTestResource, modelin tablosunu oluşturur ve fieldsFn alanlarıyla görünür
+// bir resource kaydeder. configure, kayıttan önce policy, ikon gibi ayarlar için
+// çağrılır. İlişkili tabloların migration'ı çağırana aittir.
+func registerTestResource(t *testing.T, p *Panel, model interface{}, slug string, fieldsFn func() []fields.Element, configure ...func(*resource.OptimizedBase)) *resource.OptimizedBase {
+	t.Helper()
+	if err := p.Db.AutoMigrate(model); err != nil {
+		t.Fatalf("failed to migrate %s: %v", slug, err)
+	}
+
+	res := &resource.OptimizedBase{}
+	res.SetModel(model)
+	res.SetSlug(slug)
+	res.SetTitle(slug)
+	res.SetVisible(true)
+	res.SetFieldResolver(testFields(fieldsFn))
+	for _, fn := range configure {
+		fn(res)
+	}
+	p.RegisterResource(res)
+	return res
+}
+
+// testJSONRequest, body nil değilse JSON olarak gönderir; cookie varsa isteğe ekler.
+// out verilirse yanıt gövdesi out'a çözülür ve kapatılır, aksi halde gövde çağırana kalır.
+func testJSONRequest(t *testing.T, p *Panel, cookie *http.Cookie, method, path string, body, out interface{}) *http.Response {
+	t.Helper()
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("failed to encode %s %s body: %v", method, path, err)
+		}
+		reader = bytes.NewReader(encoded)
+	}
+	req := httptest.NewRequest(method, path, reader)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	resp, err := testFiberRequest(p.Fiber, req)
+	if err != nil {
+		t.Fatalf("%s %s failed: %v", method, path, err)
+	}
+	if out != nil {
+		defer resp.Body.Close()
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("failed to decode %s %s response: %v", method, path, err)
+		}
+	}
+	return resp
+}
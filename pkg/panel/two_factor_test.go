@@ -0,0 +1,292 @@
+package panel
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	appConfig "github.com/ferdiunal/panel.go/pkg/config"
+	"github.com/ferdiunal/panel.go/pkg/domain/twofactor"
+	"github.com/ferdiunal/panel.go/pkg/domain/verification"
+	"github.com/ferdiunal/panel.go/pkg/service/auth"
+	"github.com/ferdiunal/panel.go/shared/totp"
+)
+
+func setupTwoFactorPanel(t *testing.T, twoFactor TwoFactorConfig) *Panel {
+	t.Helper()
+	return setupTwoFactorPanelWith(t, Config{TwoFactor: twoFactor})
+}
+
+// setupTwoFactorPanelWith, cfg'ye alan şifreleme anahtarı ekleyerek 2FA kullanılabilen bir panel kurar.
+func setupTwoFactorPanelWith(t *testing.T, cfg Config) *Panel {
+	t.Helper()
+	cfg.FieldEncryption = FieldEncryptionConfig{Keys: map[string]string{"k1": fieldEncryptionOldKey}}
+	p := newIsolatedTestPanel(t, cfg)
+	t.Cleanup(p.Close)
+	return p
+}
+
+func twoFactorRequest(t *testing.T, p *Panel, method, path string, cookie *http.Cookie, body interface{}) (*http.Response, map[string]interface{}) {
+	t.Helper()
+
+	var reader *bytes.Reader
+	if body != nil {
+		raw, _ := json.Marshal(body)
+		reader = bytes.NewReader(raw)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+
+	resp, err := testFiberRequest(p.Fiber, req)
+	if err != nil {
+		t.Fatalf("%s %s failed: %v", method, path, err)
+	}
+	payload := map[string]interface{}{}
+	_ = json.NewDecoder(resp.Body).Decode(&payload)
+	return resp, payload
+}
+
+// enableTwoFactor enrolls the signed-in user and returns the TOTP secret and recovery codes.
+func enableTwoFactor(t *testing.T, p *Panel, cookie *http.Cookie) (string, []string) {
+	t.Helper()
+
+	resp, payload := twoFactorRequest(t, p, "POST", "/api/internal/auth/two-factor/enroll", cookie, nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("enroll: expected 200, got %d (%v)", resp.StatusCode, payload)
+	}
+	secret, _ := payload["secret"].(string)
+	if secret == "" || !strings.HasPrefix(payload["otpauth_url"].(string), "otpauth://totp/") {
+		t.Fatalf("enroll: unexpected payload %v", payload)
+	}
+
+	code, _ := totp.Code(secret, totp.Step(time.Now()))
+	resp, payload = twoFactorRequest(t, p, "POST", "/api/internal/auth/two-factor/confirm", cookie, map[string]string{"code": code})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("confirm: expected 200, got %d (%v)", resp.StatusCode, payload)
+	}
+	raw, _ := payload["recovery_codes"].([]interface{})
+	codes := make([]string, 0, len(raw))
+	for _, c := range raw {
+		codes = append(codes, c.(string))
+	}
+	return secret, codes
+}
+
+func signInWithPassword(t *testing.T, p *Panel, email, password string) (*http.Response, map[string]interface{}) {
+	t.Helper()
+	return twoFactorRequest(t, p, "POST", "/api/internal/auth/sign-in/email", nil, map[string]string{
+		"email":    email,
+		"password": password,
+	})
+}
+
+func sessionCookie(resp *http.Response) *http.Cookie {
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == "session_token" {
+			return cookie
+		}
+	}
+	return nil
+}
+
+func TestTwoFactor_EnrollmentAndSignInChallenge(t *testing.T) {
+	p := setupTwoFactorPanel(t, TwoFactorConfig{Issuer: "Acme"})
+	email := "two-factor@example.com"
+	cookie := registerAndLoginTestUser(t, p, email)
+	if cookie == nil {
+		t.Fatal("expected session cookie")
+	}
+
+	resp, payload := twoFactorRequest(t, p, "POST", "/api/internal/auth/two-factor/confirm", cookie, map[string]string{"code": "000000"})
+	if resp.StatusCode != http.StatusConflict && resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("confirm without enrollment: expected 409/422, got %d (%v)", resp.StatusCode, payload)
+	}
+
+	secret, recoveryCodes := enableTwoFactor(t, p, cookie)
+	if len(recoveryCodes) != 10 {
+		t.Fatalf("expected 10 recovery codes, got %d", len(recoveryCodes))
+	}
+
+	var stored twofactor.TwoFactor
+	if err := p.Db.First(&stored).Error; err != nil {
+		t.Fatalf("failed to load two factor record: %v", err)
+	}
+	if stored.Secret == secret || !stored.IsEnabled() {
+		t.Fatalf("expected encrypted, enabled secret; got %+v", stored)
+	}
+
+	resp, payload = signInWithPassword(t, p, email, "password")
+	if resp.StatusCode != http.StatusOK || payload["two_factor_required"] != true {
+		t.Fatalf("expected challenge, got %d (%v)", resp.StatusCode, payload)
+	}
+	if sessionCookie(resp) != nil {
+		t.Fatal("session cookie must not be issued before the second factor")
+	}
+	challenge := payload["challenge_token"].(string)
+
+	// The code used during confirmation cannot be replayed.
+	replayed, _ := totp.Code(secret, stored.LastUsedStep)
+	resp, _ = twoFactorRequest(t, p, "POST", "/api/internal/auth/sign-in/two-factor", nil, map[string]string{
+		"challenge_token": challenge,
+		"code":            replayed,
+	})
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("replayed code: expected 401, got %d", resp.StatusCode)
+	}
+
+	resp, payload = twoFactorRequest(t, p, "POST", "/api/internal/auth/sign-in/two-factor", nil, map[string]string{
+		"challenge_token": challenge,
+		"code":            strings.ToUpper(recoveryCodes[0]),
+	})
+	if resp.StatusCode != http.StatusOK || sessionCookie(resp) == nil {
+		t.Fatalf("recovery code: expected session, got %d (%v)", resp.StatusCode, payload)
+	}
+
+	// Challenges and recovery codes are single use.
+	resp, _ = twoFactorRequest(t, p, "POST", "/api/internal/auth/sign-in/two-factor", nil, map[string]string{
+		"challenge_token": challenge,
+		"code":            recoveryCodes[1],
+	})
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("reused challenge: expected 401, got %d", resp.StatusCode)
+	}
+
+	_, payload = signInWithPassword(t, p, email, "password")
+	resp, _ = twoFactorRequest(t, p, "POST", "/api/internal/auth/sign-in/two-factor", nil, map[string]string{
+		"challenge_token": payload["challenge_token"].(string),
+		"code":            recoveryCodes[0],
+	})
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("reused recovery code: expected 401, got %d", resp.StatusCode)
+	}
+
+	resp, payload = twoFactorRequest(t, p, "GET", "/api/internal/auth/two-factor", cookie, nil)
+	if resp.StatusCode != http.StatusOK || payload["enabled"] != true || payload["recovery_codes_remaining"] != float64(9) {
+		t.Fatalf("unexpected status response %d (%v)", resp.StatusCode, payload)
+	}
+}
+
+func TestTwoFactor_FailedCodesCountTowardsLockout(t *testing.T) {
+	p := setupTwoFactorPanel(t, TwoFactorConfig{})
+	email := "lockout-2fa@example.com"
+	cookie := registerAndLoginTestUser(t, p, email)
+	enableTwoFactor(t, p, cookie)
+
+	_, payload := signInWithPassword(t, p, email, "password")
+	challenge := payload["challenge_token"].(string)
+
+	// Default lockout allows 5 attempts; the successful password step did not reset them.
+	for i := 0; i < 5; i++ {
+		resp, _ := twoFactorRequest(t, p, "POST", "/api/internal/auth/sign-in/two-factor", nil, map[string]string{
+			"challenge_token": challenge,
+			"code":            "bad-code",
+		})
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("attempt %d: expected 401, got %d", i+1, resp.StatusCode)
+		}
+	}
+
+	// The challenge is discarded once its own attempt limit is reached.
+	resp, _ := twoFactorRequest(t, p, "POST", "/api/internal/auth/sign-in/two-factor", nil, map[string]string{
+		"challenge_token": challenge,
+		"code":            "bad-code",
+	})
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for the discarded challenge, got %d", resp.StatusCode)
+	}
+
+	resp, _ = signInWithPassword(t, p, email, "password")
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("password sign-in should share the lockout, got %d", resp.StatusCode)
+	}
+}
+
+func TestTwoFactor_ChallengeDiscardedAfterMaxAttemptsWithoutLockout(t *testing.T) {
+	security := appConfig.DevelopmentSecurityConfig()
+	security.AccountLockout.Enabled = false
+	p := setupTwoFactorPanelWith(t, Config{Security: &security})
+	email := "challenge-attempts@example.com"
+	cookie := registerAndLoginTestUser(t, p, email)
+	secret, recoveryCodes := enableTwoFactor(t, p, cookie)
+
+	_, payload := signInWithPassword(t, p, email, "password")
+	challenge := payload["challenge_token"].(string)
+	for i := 0; i < auth.TwoFactorChallengeMaxAttempts; i++ {
+		resp, _ := twoFactorRequest(t, p, "POST", "/api/internal/auth/sign-in/two-factor", nil, map[string]string{
+			"challenge_token": challenge,
+			"code":            "000000",
+		})
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("attempt %d: expected 401, got %d", i+1, resp.StatusCode)
+		}
+	}
+
+	next, _ := totp.Code(secret, totp.Step(time.Now())+1)
+	resp, _ := twoFactorRequest(t, p, "POST", "/api/internal/auth/sign-in/two-factor", nil, map[string]string{
+		"challenge_token": challenge,
+		"code":            next,
+	})
+	if resp.StatusCode != http.StatusUnauthorized || sessionCookie(resp) != nil {
+		t.Fatalf("expected valid code to be rejected on a discarded challenge, got %d", resp.StatusCode)
+	}
+	var remaining int64
+	p.Db.Model(&verification.Verification{}).Where("identifier LIKE ?", "two_factor_challenge:%").Count(&remaining)
+	if remaining != 0 {
+		t.Fatalf("expected challenge record to be deleted, got %d", remaining)
+	}
+
+	_, payload = signInWithPassword(t, p, email, "password")
+	resp, _ = twoFactorRequest(t, p, "POST", "/api/internal/auth/sign-in/two-factor", nil, map[string]string{
+		"challenge_token": payload["challenge_token"].(string),
+		"code":            recoveryCodes[0],
+	})
+	if resp.StatusCode != http.StatusOK || sessionCookie(resp) == nil {
+		t.Fatalf("expected a fresh challenge to succeed, got %d", resp.StatusCode)
+	}
+}
+
+func TestTwoFactor_RequiredRoleMustEnroll(t *testing.T) {
+	p := setupTwoFactorPanel(t, TwoFactorConfig{RequiredRoles: []string{"admin"}})
+	cookie := registerAndLoginTestUser(t, p, "enforced@example.com")
+
+	resp, payload := twoFactorRequest(t, p, "GET", "/api/internal/pages", cookie, nil)
+	if resp.StatusCode != http.StatusForbidden || payload["code"] != "two_factor_enrollment_required" {
+		t.Fatalf("expected enrollment requirement, got %d (%v)", resp.StatusCode, payload)
+	}
+
+	resp, payload = twoFactorRequest(t, p, "GET", "/api/internal/auth/two-factor", cookie, nil)
+	if resp.StatusCode != http.StatusOK || payload["required"] != true || payload["enabled"] != false {
+		t.Fatalf("unexpected status response %d (%v)", resp.StatusCode, payload)
+	}
+
+	enableTwoFactor(t, p, cookie)
+
+	resp, _ = twoFactorRequest(t, p, "GET", "/api/internal/pages", cookie, nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected access after enrollment, got %d", resp.StatusCode)
+	}
+
+	resp, _ = twoFactorRequest(t, p, "POST", "/api/internal/auth/two-factor/disable", cookie, map[string]string{"password": "password"})
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("disable for enforced role: expected 403, got %d", resp.StatusCode)
+	}
+}
+
+func TestTwoFactor_EnrollRequiresKeyring(t *testing.T) {
+	p := setupSecurityPanel(t, appConfig.DevelopmentSecurityConfig())
+	cookie := registerAndLoginTestUser(t, p, "no-keyring@example.com")
+
+	resp, _ := twoFactorRequest(t, p, "POST", "/api/internal/auth/two-factor/enroll", cookie, nil)
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 without keyring, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,103 @@
+package panel
+
+import (
+	stdcontext "context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/ferdiunal/panel.go/pkg/context"
+	"github.com/ferdiunal/panel.go/pkg/handler"
+)
+
+// DefaultUploadSweepInterval, süresi dolan devam ettirilebilir yüklemelerin en
+// fazla hangi sıklıkla silindiğidir. Daha kısa ömürlerde ömür kadar beklenir.
+const DefaultUploadSweepInterval = time.Hour
+
+// uploadSweeper, yarım kalan veya form gönderiminde kullanılmayan yüklemeleri
+// Storage.Uploads.Expiration dolduktan sonra arka planda siler.
+type uploadSweeper struct {
+	uploads   *handler.ResumableUploads
+	stopCh    chan struct{}
+	doneCh    chan struct{}
+	closeOnce sync.Once
+}
+
+func newUploadSweeper(uploads *handler.ResumableUploads) *uploadSweeper {
+	s := &uploadSweeper{
+		uploads: uploads,
+		stopCh:  make(chan struct{}),
+		doneCh:  make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+func (s *uploadSweeper) run() {
+	defer close(s.doneCh)
+
+	interval := DefaultUploadSweepInterval
+	if expiration := s.uploads.Expiration(); expiration < interval {
+		interval = expiration
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := s.uploads.Sweep(stdcontext.Background(), time.Now()); err != nil {
+				log.Printf("[storage] expired upload sweep failed: %v", err)
+			}
+		case <-s.stopCh:
+			return
+		}
+	}
+}
+
+// Close, arka plandaki temizleme goroutine'ini durdurur ve çıkmasını bekler.
+// Birden fazla kez çağrılabilir; nil sweeper için bir şey yapmaz.
+func (s *uploadSweeper) Close() {
+	if s == nil {
+		return
+	}
+	s.closeOnce.Do(func() {
+		close(s.stopCh)
+	})
+	<-s.doneCh
+}
+
+// handleResumableUploadOptions, OPTIONS /api/resource/:resource/uploads isteğini işler.
+func (p *Panel) handleResumableUploadOptions(c *context.Context) error {
+	return p.withResourceHandler(c, func(h *handler.FieldHandler) error {
+		return handler.HandleResumableUploadOptions(h, c)
+	})
+}
+
+// handleResumableUploadCreate, POST /api/resource/:resource/uploads isteğini işler.
+func (p *Panel) handleResumableUploadCreate(c *context.Context) error {
+	return p.withResourceHandler(c, func(h *handler.FieldHandler) error {
+		return handler.HandleResumableUploadCreate(h, c)
+	})
+}
+
+// handleResumableUploadHead, HEAD /api/resource/:resource/uploads/:upload isteğini işler.
+func (p *Panel) handleResumableUploadHead(c *context.Context) error {
+	return p.withResourceHandler(c, func(h *handler.FieldHandler) error {
+		return handler.HandleResumableUploadHead(h, c)
+	})
+}
+
+// handleResumableUploadPatch, PATCH /api/resource/:resource/uploads/:upload isteğini işler.
+func (p *Panel) handleResumableUploadPatch(c *context.Context) error {
+	return p.withResourceHandler(c, func(h *handler.FieldHandler) error {
+		return handler.HandleResumableUploadPatch(h, c)
+	})
+}
+
+// handleResumableUploadDelete, DELETE /api/resource/:resource/uploads/:upload isteğini işler.
+func (p *Panel) handleResumableUploadDelete(c *context.Context) error {
+	return p.withResourceHandler(c, func(h *handler.FieldHandler) error {
+		return handler.HandleResumableUploadDelete(h, c)
+	})
+}
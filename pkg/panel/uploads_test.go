@@ -0,0 +1,373 @@
+package panel
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ferdiunal/panel.go/pkg/handler"
+)
+
+const resumableUploadsPath = "/api/internal/resource/stored-products/uploads"
+
+func setupUploadsPanel(t *testing.T) (*Panel, string) {
+	t.Helper()
+	dir := t.TempDir()
+	p := setupStoragePanelWith(t, func(config *Config) {
+		config.Storage.Uploads = UploadConfig{TempDir: dir, Expiration: time.Hour}
+	})
+	return p, dir
+}
+
+func tusRequest(t *testing.T, p *Panel, cookie *http.Cookie, method, path string, headers map[string]string, body []byte) *http.Response {
+	t.Helper()
+	req := httptest.NewRequest(method, path, bytes.NewReader(body))
+	req.Header.Set("Tus-Resumable", handler.TusVersion)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	req.AddCookie(cookie)
+	resp, err := testFiberRequest(p.Fiber, req)
+	if err != nil {
+		t.Fatalf("%s %s failed: %v", method, path, err)
+	}
+	resp.Body.Close()
+	return resp
+}
+
+// createUpload, field alanı için length baytlık bir yükleme başlatır ve adresini döner.
+func createUpload(t *testing.T, p *Panel, cookie *http.Cookie, field string, length int) string {
+	t.Helper()
+	metadata := "field " + base64.StdEncoding.EncodeToString([]byte(field)) +
+		",filename " + base64.StdEncoding.EncodeToString([]byte("manual.pdf")) +
+		",filetype " + base64.StdEncoding.EncodeToString([]byte("application/pdf"))
+	resp := tusRequest(t, p, cookie, "POST", resumableUploadsPath, map[string]string{
+		"Upload-Length":   strconv.Itoa(length),
+		"Upload-Metadata": metadata,
+	}, nil)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected 201 on create, got %d", resp.StatusCode)
+	}
+	location := resp.Header.Get("Location")
+	if !strings.HasPrefix(location, resumableUploadsPath+"/") || resp.Header.Get("Upload-Expires") == "" {
+		t.Fatalf("unexpected create headers: %v", resp.Header)
+	}
+	return location
+}
+
+func patchUpload(t *testing.T, p *Panel, cookie *http.Cookie, location string, offset int, chunk []byte, checksum string) *http.Response {
+	t.Helper()
+	headers := map[string]string{
+		"Content-Type":  "application/offset+octet-stream",
+		"Upload-Offset": strconv.Itoa(offset),
+	}
+	if checksum != "" {
+		headers["Upload-Checksum"] = checksum
+	}
+	return tusRequest(t, p, cookie, "PATCH", location, headers, chunk)
+}
+
+func sha1Checksum(chunk []byte) string {
+	sum := sha1.Sum(chunk)
+	return "sha1 " + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// uploadContent, content'i iki parça hâlinde yükler ve yükleme ID'sini döner.
+func uploadContent(t *testing.T, p *Panel, cookie *http.Cookie, content []byte) string {
+	t.Helper()
+	location := createUpload(t, p, cookie, "manual", len(content))
+	half := len(content) / 2
+	for _, chunk := range []struct {
+		offset int
+		data   []byte
+	}{{0, content[:half]}, {half, content[half:]}} {
+		resp := patchUpload(t, p, cookie, location, chunk.offset, chunk.data, sha1Checksum(chunk.data))
+		if resp.StatusCode != http.StatusNoContent {
+			t.Fatalf("expected 204 on patch, got %d", resp.StatusCode)
+		}
+		if got := resp.Header.Get("Upload-Offset"); got != strconv.Itoa(chunk.offset+len(chunk.data)) {
+			t.Fatalf("unexpected Upload-Offset %q", got)
+		}
+	}
+	return location[strings.LastIndex(location, "/")+1:]
+}
+
+func storeWithUpload(t *testing.T, p *Panel, cookie *http.Cookie, id string) *http.Response {
+	t.Helper()
+	body, _ := json.Marshal(map[string]interface{}{
+		"name":   "Manual",
+		"manual": map[string]string{"upload_id": id},
+	})
+	req := httptest.NewRequest("POST", "/api/internal/resource/stored-products", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(cookie)
+	resp, err := testFiberRequest(p.Fiber, req)
+	if err != nil {
+		t.Fatalf("store failed: %v", err)
+	}
+	resp.Body.Close()
+	return resp
+}
+
+func TestResumableUploads_ChunkedUploadIsStoredOnSubmit(t *testing.T) {
+	p, dir := setupUploadsPanel(t)
+	cookie := registerAndLoginTestUser(t, p, "uploads@example.com")
+	content := []byte("%PDF-1.4\n" + strings.Repeat("resumable upload content\n", 200))
+
+	resp := tusRequest(t, p, cookie, "OPTIONS", resumableUploadsPath, nil, nil)
+	if resp.StatusCode != http.StatusNoContent || !strings.Contains(resp.Header.Get("Tus-Extension"), "checksum") {
+		t.Fatalf("unexpected OPTIONS response %d %v", resp.StatusCode, resp.Header)
+	}
+
+	id := uploadContent(t, p, cookie, content)
+	resp = tusRequest(t, p, cookie, "HEAD", resumableUploadsPath+"/"+id, nil, nil)
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Upload-Offset") != strconv.Itoa(len(content)) {
+		t.Fatalf("expected completed offset, got %d %q", resp.StatusCode, resp.Header.Get("Upload-Offset"))
+	}
+
+	if resp := storeWithUpload(t, p, cookie, id); resp.StatusCode >= http.StatusBadRequest {
+		t.Fatalf("expected created product, got %d", resp.StatusCode)
+	}
+	var product storedProduct
+	if err := p.Db.First(&product).Error; err != nil {
+		t.Fatalf("expected stored product: %v", err)
+	}
+	if !strings.HasPrefix(product.Manual, "manuals/") || !strings.HasSuffix(product.Manual, ".pdf") {
+		t.Fatalf("expected manual on the private disk, got %q", product.Manual)
+	}
+	disk, _ := p.Disks().Disk("private")
+	reader, err := disk.Get(context.Background(), product.Manual)
+	if err != nil {
+		t.Fatalf("expected stored manual: %v", err)
+	}
+	stored, _ := io.ReadAll(reader)
+	reader.Close()
+	if !bytes.Equal(stored, content) {
+		t.Fatal("expected stored manual to match the uploaded chunks")
+	}
+
+	// Kullanılan yükleme silinir
+	if _, err := p.uploads.Find(context.Background(), id); err != handler.ErrUploadNotFound {
+		t.Fatalf("expected upload to be consumed, got %v", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Fatalf("expected temporary file to be removed, got %d entries", len(entries))
+	}
+}
+
+func TestResumableUploads_MultipartReference(t *testing.T) {
+	p, _ := setupUploadsPanel(t)
+	cookie := registerAndLoginTestUser(t, p, "uploads-multipart@example.com")
+	content := []byte("%PDF-1.4\nmultipart reference\n")
+	id := uploadContent(t, p, cookie, content)
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	_ = writer.WriteField("name", "Manual")
+	_ = writer.WriteField("manual", `{"upload_id":"`+id+`"}`)
+	writer.Close()
+	req := httptest.NewRequest("POST", "/api/internal/resource/stored-products", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.AddCookie(cookie)
+	resp, err := testFiberRequest(p.Fiber, req)
+	if err != nil {
+		t.Fatalf("store failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		t.Fatalf("expected created product, got %d", resp.StatusCode)
+	}
+
+	var product storedProduct
+	p.Db.First(&product)
+	if paths := memoryDisk(t, p, "private").Paths(); len(paths) != 1 || paths[0] != product.Manual {
+		t.Fatalf("expected referenced upload on the private disk, got %v (manual=%q)", paths, product.Manual)
+	}
+}
+
+func TestResumableUploads_ReferencedImageIsInspectedBeforeStoring(t *testing.T) {
+	p, _ := setupUploadsPanel(t)
+	cookie := registerAndLoginTestUser(t, p, "uploads-image@example.com")
+	content := pngWithComment(t)
+
+	metadata := "field " + base64.StdEncoding.EncodeToString([]byte("cover")) +
+		",filename " + base64.StdEncoding.EncodeToString([]byte("cover.png")) +
+		",filetype " + base64.StdEncoding.EncodeToString([]byte("text/plain"))
+	resp := tusRequest(t, p, cookie, "POST", resumableUploadsPath, map[string]string{
+		"Upload-Length":   strconv.Itoa(len(content)),
+		"Upload-Metadata": metadata,
+	}, nil)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected 201 on create, got %d", resp.StatusCode)
+	}
+	location := resp.Header.Get("Location")
+	if resp := patchUpload(t, p, cookie, location, 0, content, ""); resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("expected 204 on patch, got %d", resp.StatusCode)
+	}
+
+	var payload struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	resp = testJSONRequest(t, p, cookie, "POST", "/api/internal/resource/stored-products", map[string]interface{}{
+		"name":  "Cover",
+		"cover": map[string]string{"upload_id": location[strings.LastIndex(location, "/")+1:]},
+	}, &payload)
+	if resp.StatusCode >= http.StatusBadRequest {
+		t.Fatalf("expected created product, got %d", resp.StatusCode)
+	}
+
+	var product storedProduct
+	p.Db.First(&product)
+	path := strings.TrimPrefix(product.Cover, "https://cdn.example.com/")
+	disk, _ := p.Disks().Disk("media")
+	reader, err := disk.Get(context.Background(), path)
+	if err != nil {
+		t.Fatalf("expected stored cover at %q: %v", path, err)
+	}
+	stored, _ := io.ReadAll(reader)
+	reader.Close()
+	if bytes.Contains(stored, []byte("GPS")) || len(stored) >= len(content) {
+		t.Fatal("expected metadata to be stripped from the referenced upload")
+	}
+	if !strings.HasPrefix(path, "products/") || !strings.HasSuffix(path, ".png") {
+		t.Fatalf("expected cover on the media disk, got %q", product.Cover)
+	}
+}
+
+func TestResumableUploads_ProtocolErrors(t *testing.T) {
+	p, _ := setupUploadsPanel(t)
+	cookie := registerAndLoginTestUser(t, p, "uploads-errors@example.com")
+	location := createUpload(t, p, cookie, "manual", 8)
+
+	req := httptest.NewRequest("HEAD", location, nil)
+	req.AddCookie(cookie)
+	if resp, _ := testFiberRequest(p.Fiber, req); resp.StatusCode != http.StatusPreconditionFailed {
+		t.Fatalf("expected 412 without Tus-Resumable, got %d", resp.StatusCode)
+	}
+	if resp := patchUpload(t, p, cookie, location, 4, []byte("abcd"), ""); resp.StatusCode != http.StatusConflict {
+		t.Fatalf("expected 409 on offset mismatch, got %d", resp.StatusCode)
+	}
+	if resp := patchUpload(t, p, cookie, location, 0, []byte("abcd"), sha1Checksum([]byte("dcba"))); resp.StatusCode != handler.StatusChecksumMismatch {
+		t.Fatalf("expected 460 on checksum mismatch, got %d", resp.StatusCode)
+	}
+	if resp := patchUpload(t, p, cookie, location, 0, []byte("abcd"), "crc32 AAAA"); resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400 on unsupported checksum, got %d", resp.StatusCode)
+	}
+	if resp := patchUpload(t, p, cookie, location, 0, []byte("abcdefghij"), ""); resp.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413 when exceeding Upload-Length, got %d", resp.StatusCode)
+	}
+
+	// Cover alanı MaxSize(64 KB) tanımlar
+	metadata := "field " + base64.StdEncoding.EncodeToString([]byte("cover"))
+	resp := tusRequest(t, p, cookie, "POST", resumableUploadsPath, map[string]string{
+		"Upload-Length":   strconv.Itoa(128 << 10),
+		"Upload-Metadata": metadata,
+	}, nil)
+	if resp.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413 above the field MaxSize, got %d", resp.StatusCode)
+	}
+	metadata = "field " + base64.StdEncoding.EncodeToString([]byte("name"))
+	resp = tusRequest(t, p, cookie, "POST", resumableUploadsPath, map[string]string{
+		"Upload-Length":   "8",
+		"Upload-Metadata": metadata,
+	}, nil)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a non-file field, got %d", resp.StatusCode)
+	}
+}
+
+func TestResumableUploads_CreateRejectsLengthAboveMaxSize(t *testing.T) {
+	p, _ := setupUploadsPanel(t)
+	if p.uploads.MaxSize() != handler.DefaultUploadMaxSize {
+		t.Fatalf("expected default max size %d, got %d", handler.DefaultUploadMaxSize, p.uploads.MaxSize())
+	}
+
+	p = setupStoragePanelWith(t, func(config *Config) {
+		config.Storage.Uploads = UploadConfig{TempDir: t.TempDir(), MaxSize: 1 << 10}
+	})
+	cookie := registerAndLoginTestUser(t, p, "uploads-limit@example.com")
+	create := func(field string, length int) int {
+		metadata := "field " + base64.StdEncoding.EncodeToString([]byte(field))
+		return tusRequest(t, p, cookie, "POST", resumableUploadsPath, map[string]string{
+			"Upload-Length":   strconv.Itoa(length),
+			"Upload-Metadata": metadata,
+		}, nil).StatusCode
+	}
+
+	if resp := tusRequest(t, p, cookie, "OPTIONS", resumableUploadsPath, nil, nil); resp.Header.Get("Tus-Max-Size") != "1024" {
+		t.Fatalf("expected Tus-Max-Size 1024, got %q", resp.Header.Get("Tus-Max-Size"))
+	}
+	if status := create("manual", 2<<10); status != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413 above the global MaxSize, got %d", status)
+	}
+	// Cover alanının 64 KB sınırı genel sınırı genişletmez
+	if status := create("cover", 4<<10); status != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413 above the global MaxSize for a larger field limit, got %d", status)
+	}
+	if status := create("manual", 1<<10); status != http.StatusCreated {
+		t.Fatalf("expected 201 within the limit, got %d", status)
+	}
+}
+
+func TestResumableUploads_UnavailableReferencesAreRejected(t *testing.T) {
+	p, _ := setupUploadsPanel(t)
+	owner := registerAndLoginTestUser(t, p, "uploads-owner@example.com")
+	other := registerAndLoginTestUser(t, p, "uploads-other@example.com")
+
+	id := uploadContent(t, p, owner, []byte("%PDF-1.4\nprivate\n"))
+	if resp := tusRequest(t, p, other, "HEAD", resumableUploadsPath+"/"+id, nil, nil); resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected foreign upload to be hidden, got %d", resp.StatusCode)
+	}
+	if resp := storeWithUpload(t, p, other, id); resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422 for a foreign upload, got %d", resp.StatusCode)
+	}
+
+	location := createUpload(t, p, owner, "manual", 32)
+	incomplete := location[strings.LastIndex(location, "/")+1:]
+	if resp := storeWithUpload(t, p, owner, incomplete); resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422 for an incomplete upload, got %d", resp.StatusCode)
+	}
+	var count int64
+	p.Db.Model(&storedProduct{}).Count(&count)
+	if count != 0 {
+		t.Fatalf("expected no product to be created, got %d", count)
+	}
+	if _, err := p.uploads.Find(context.Background(), id); err != nil {
+		t.Fatalf("expected rejected reference to keep the upload: %v", err)
+	}
+}
+
+func TestResumableUploads_SweepRemovesExpiredUploads(t *testing.T) {
+	p, dir := setupUploadsPanel(t)
+	cookie := registerAndLoginTestUser(t, p, "uploads-sweep@example.com")
+	location := createUpload(t, p, cookie, "manual", 16)
+	if resp := patchUpload(t, p, cookie, location, 0, []byte("partial"), ""); resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("expected 204 on patch, got %d", resp.StatusCode)
+	}
+
+	removed, err := p.uploads.Sweep(context.Background(), time.Now())
+	if err != nil || removed != 0 {
+		t.Fatalf("expected active upload to be kept, removed %d (err=%v)", removed, err)
+	}
+	removed, err = p.uploads.Sweep(context.Background(), time.Now().Add(2*time.Hour))
+	if err != nil || removed != 1 {
+		t.Fatalf("expected expired upload to be removed, removed %d (err=%v)", removed, err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Fatalf("expected temporary file to be removed, got %d entries", len(entries))
+	}
+	if resp := tusRequest(t, p, cookie, "HEAD", location, nil, nil); resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected swept upload to be gone, got %d", resp.StatusCode)
+	}
+}
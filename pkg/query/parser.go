@@ -430,12 +430,23 @@ func parseFilterParam(inner, value string, params *ResourceQueryParams) {
 	case OpBetween:
 		// İki virgülle ayrılmış değer -> []string
 		betweenParts := strings.Split(value, ",")
-		if len(betweenParts) == 2 {
-			parsedValue = betweenParts
-		} else {
+		if len(betweenParts) != 2 {
 			// Geçersiz between format, atla
 			return
 		}
+		min, max := strings.TrimSpace(betweenParts[0]), strings.TrimSpace(betweenParts[1])
+		switch {
+		case min == "" && max == "":
+			return
+		case max == "":
+			// Açık uçlu aralık: "10," -> gte 10
+			operator, parsedValue = OpGreaterEq, min
+		case min == "":
+			// Açık uçlu aralık: ",20" -> lte 20
+			operator, parsedValue = OpLessEq, max
+		default:
+			parsedValue = []string{min, max}
+		}
 
 	case OpIsNull, OpIsNotNull:
 		// Boolean değer
@@ -0,0 +1,31 @@
+package query
+
+import "testing"
+
+func TestParseNestedFormat_OpenEndedBetweenFilter(t *testing.T) {
+	params := DefaultParams()
+
+	parseNestedFormat(
+		"products[filters][price][between]=10,&products[filters][stock][between]=,5&products[filters][rating][between]=,",
+		"products",
+		params,
+	)
+
+	if len(params.Filters) != 2 {
+		t.Fatalf("expected empty range to be skipped, got %+v", params.Filters)
+	}
+	for _, filter := range params.Filters {
+		switch filter.Field {
+		case "price":
+			if filter.Operator != OpGreaterEq || filter.Value != "10" {
+				t.Fatalf("expected price >= 10, got %+v", filter)
+			}
+		case "stock":
+			if filter.Operator != OpLessEq || filter.Value != "5" {
+				t.Fatalf("expected stock <= 5, got %+v", filter)
+			}
+		default:
+			t.Fatalf("unexpected filter %+v", filter)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package audit
+
+import (
+	"github.com/ferdiunal/panel.go/pkg/context"
+	"github.com/ferdiunal/panel.go/pkg/core"
+	"github.com/ferdiunal/panel.go/pkg/fields"
+)
+
+// AuditEventFieldResolver, audit olay kaynağının alanlarını tanımlar.
+//
+// Kullanıcı, kaynak, olay türü ve zaman alanları filtrelenebilir; liste
+// endpoint'inde örneğin `audit-events[filters][user_id][eq]=42` veya
+// `audit-events[filters][timestamp][gte]=2026-01-01` ile sorgulanır.
+// Tüm alanlar salt okunurdur.
+type AuditEventFieldResolver struct{}
+
+// ResolveFields, audit olay alanlarını döndürür.
+func (r *AuditEventFieldResolver) ResolveFields(ctx *context.Context) []core.Element {
+	return []core.Element{
+		fields.ID("ID").ReadOnly().OnlyOnDetail(),
+		fields.DateTime("Timestamp", "timestamp").ReadOnly().OnList().OnDetail().Filterable(),
+		fields.Text("Event Type", "event_type").ReadOnly().OnList().OnDetail().Filterable(),
+		fields.Text("User ID", "user_id").ReadOnly().OnList().OnDetail().Filterable(),
+		fields.Text("Email", "email").ReadOnly().OnList().OnDetail(),
+		fields.Text("Resource", "resource").ReadOnly().OnList().OnDetail().Filterable(),
+		fields.Text("Action", "action").ReadOnly().OnDetail(),
+		fields.Text("Method", "method").ReadOnly().OnList().OnDetail(),
+		fields.Text("Path", "path").ReadOnly().OnList().OnDetail(),
+		fields.Number("Status Code", "status_code").ReadOnly().OnList().OnDetail(),
+		fields.Switch("Success", "success").ReadOnly().OnDetail(),
+		fields.Text("IP Address", "ip").ReadOnly().OnDetail(),
+		fields.Text("User Agent", "user_agent").ReadOnly().OnDetail(),
+		fields.Text("Error", "error_message").ReadOnly().OnDetail(),
+		fields.Code("Metadata", "metadata").ReadOnly().OnDetail(),
+		fields.Text("Previous Hash", "prev_hash").ReadOnly().OnDetail(),
+		fields.Text("Hash", "hash").ReadOnly().OnDetail(),
+	}
+}
@@ -0,0 +1,56 @@
+package audit
+
+import (
+	"strings"
+
+	"github.com/ferdiunal/panel.go/pkg/auth"
+	"github.com/ferdiunal/panel.go/pkg/context"
+	domainAudit "github.com/ferdiunal/panel.go/pkg/domain/audit"
+)
+
+// AuditEventPolicy, audit kayıtlarını yalnızca admin rolüne okuma amaçlı açar.
+// Kayıtlar hash zincirinin parçası olduğundan oluşturma, güncelleme ve silme
+// her kullanıcı için reddedilir.
+type AuditEventPolicy struct{}
+
+func (p *AuditEventPolicy) ViewAny(ctx *context.Context) bool {
+	return isAdmin(ctx)
+}
+
+func (p *AuditEventPolicy) View(ctx *context.Context, model any) bool {
+	if !isAdmin(ctx) {
+		return false
+	}
+	event, ok := model.(*domainAudit.Event)
+	return ok && event != nil
+}
+
+func (p *AuditEventPolicy) Create(ctx *context.Context) bool {
+	return false
+}
+
+func (p *AuditEventPolicy) Update(ctx *context.Context, model any) bool {
+	return false
+}
+
+func (p *AuditEventPolicy) Delete(ctx *context.Context, model any) bool {
+	return false
+}
+
+func (p *AuditEventPolicy) Restore(ctx *context.Context, model any) bool {
+	return false
+}
+
+func (p *AuditEventPolicy) ForceDelete(ctx *context.Context, model any) bool {
+	return false
+}
+
+func isAdmin(ctx *context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	current := ctx.User()
+	return current != nil && strings.EqualFold(current.Role, "admin")
+}
+
+var _ auth.Policy = (*AuditEventPolicy)(nil)
@@ -0,0 +1,71 @@
+// Package audit, veritabanına yazılan audit olayları için salt okunur panel
+// kaynağını sağlar. Kaynak, Security.Audit.Destination "database" olduğunda
+// panel tarafından sistem kaynağı olarak kaydedilir.
+package audit
+
+import (
+	"github.com/ferdiunal/panel.go/pkg/data"
+	domainAudit "github.com/ferdiunal/panel.go/pkg/domain/audit"
+	"github.com/ferdiunal/panel.go/pkg/resource"
+	"gorm.io/gorm"
+)
+
+// AuditEventResource, audit_events tablosunu listeleme ve detay görünümüyle sunar.
+// Kayıtlar hash zinciriyle bağlı olduğu için oluşturma, düzenleme ve silme kapalıdır;
+// eski kayıtlar yalnızca Retention ile silinir.
+type AuditEventResource struct {
+	resource.OptimizedBase
+}
+
+// NewAuditEventResource, audit olay kaynağını varsayılan ayarlarla oluşturur.
+func NewAuditEventResource() *AuditEventResource {
+	r := &AuditEventResource{}
+
+	r.SetModel(&domainAudit.Event{})
+	r.SetSlug("audit-events")
+	r.SetTitle("Audit Log")
+	r.SetIcon("shield")
+	r.SetGroup("System")
+	r.SetNavigationOrder(53)
+	r.SetVisible(true)
+	r.SetRecordTitleKey("id")
+	r.SetFieldResolver(&AuditEventFieldResolver{})
+	r.SetPolicy(&AuditEventPolicy{})
+
+	return r
+}
+
+// Repository, audit olayları için GORM veri sağlayıcısını döndürür.
+func (r *AuditEventResource) Repository(client *gorm.DB) data.DataProvider {
+	if client == nil {
+		return nil
+	}
+
+	return data.NewGormDataProvider(client, &domainAudit.Event{})
+}
+
+func (r *AuditEventResource) With() []string {
+	return []string{}
+}
+
+func (r *AuditEventResource) Lenses() []resource.Lens {
+	return []resource.Lens{}
+}
+
+func (r *AuditEventResource) GetActions() []resource.Action {
+	return []resource.Action{}
+}
+
+func (r *AuditEventResource) GetFilters() []resource.Filter {
+	return []resource.Filter{}
+}
+
+// GetSortable, en yeni olayları önce listeler.
+func (r *AuditEventResource) GetSortable() []resource.Sortable {
+	return []resource.Sortable{
+		{
+			Column:    "id",
+			Direction: "desc",
+		},
+	}
+}
@@ -0,0 +1,67 @@
+package audit
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ferdiunal/panel.go/pkg/context"
+	domainAudit "github.com/ferdiunal/panel.go/pkg/domain/audit"
+	"github.com/ferdiunal/panel.go/pkg/domain/user"
+	"github.com/ferdiunal/panel.go/pkg/resource"
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestNewAuditEventResource(t *testing.T) {
+	r := NewAuditEventResource()
+
+	if r.Slug() != "audit-events" {
+		t.Errorf("Expected slug 'audit-events', got '%s'", r.Slug())
+	}
+	if _, ok := r.Model().(*domainAudit.Event); !ok {
+		t.Error("Expected audit Event model")
+	}
+	if len(r.Fields()) == 0 {
+		t.Error("Expected at least one field")
+	}
+}
+
+func TestAuditEventResourceImplementsResource(t *testing.T) {
+	var _ resource.Resource = (*AuditEventResource)(nil)
+}
+
+func TestAuditEventPolicyIsReadOnlyForAdmins(t *testing.T) {
+	policy := &AuditEventPolicy{}
+	event := &domainAudit.Event{ID: 1}
+
+	check := func(current *user.User) (viewAny, view, write bool) {
+		app := fiber.New()
+		app.Get("/", func(c *fiber.Ctx) error {
+			if current != nil {
+				c.Locals("user", current)
+			}
+			ctx := &context.Context{Ctx: c}
+			viewAny = policy.ViewAny(ctx)
+			view = policy.View(ctx, event)
+			write = policy.Create(ctx) || policy.Update(ctx, event) || policy.Delete(ctx, event) || policy.ForceDelete(ctx, event)
+			return nil
+		})
+		if _, err := app.Test(httptest.NewRequest("GET", "/", nil)); err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		return viewAny, view, write
+	}
+
+	if viewAny, view, _ := check(nil); viewAny || view {
+		t.Error("Expected anonymous users to be denied")
+	}
+	if viewAny, view, _ := check(&user.User{ID: 1, Role: "user"}); viewAny || view {
+		t.Error("Expected non-admin users to be denied")
+	}
+	viewAny, view, write := check(&user.User{ID: 1, Role: "admin"})
+	if !viewAny || !view {
+		t.Error("Expected admin users to view audit events")
+	}
+	if write {
+		t.Error("Expected audit events to be read-only")
+	}
+}
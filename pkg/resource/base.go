@@ -7,13 +7,13 @@ import (
 	"path/filepath"
 	"reflect"
 	"strings"
-	"time"
 
 	"github.com/ferdiunal/panel.go/pkg/auth"
 	appContext "github.com/ferdiunal/panel.go/pkg/context"
 	"github.com/ferdiunal/panel.go/pkg/data"
 	"github.com/ferdiunal/panel.go/pkg/fields"
 	"github.com/ferdiunal/panel.go/pkg/i18n"
+	"github.com/ferdiunal/panel.go/pkg/storage"
 	"github.com/ferdiunal/panel.go/pkg/widget"
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
@@ -167,6 +167,12 @@ type Base struct {
 
 	/// Kayıt başlığını özel fonksiyon ile hesaplamak için kullanılır
 	recordTitleFunc func(record any) string
+
+	/// Global arama ayarları - /api/search sonuçlarına katılım, limit ve alt başlık
+	globalSearch GlobalSearchConfig
+
+	/// Global arama ayarının explicit olarak set edilip edilmediğini izler.
+	globalSearchConfigured bool
 }
 
 // / # SettingsSeed Yapısı
@@ -913,6 +919,38 @@ func (r Base) IsGridEnabled() bool {
 	return r.IndexGridEnabled
 }
 
+// SetGlobalSearch, resource'un global aramaya dahil edilip edilmeyeceğini ayarlar.
+//
+// Varsayılan olarak Searchable() alanı olan tüm resource'lar global aramaya katılır.
+func (r *Base) SetGlobalSearch(enabled bool) Resource {
+	r.globalSearch.Enabled = enabled
+	r.globalSearchConfigured = true
+	return r
+}
+
+// SetGlobalSearchLimit, global aramada bu resource için döndürülecek kayıt sayısını ayarlar.
+func (r *Base) SetGlobalSearchLimit(limit int) Resource {
+	r.globalSearch.Limit = limit
+	return r
+}
+
+// SetGlobalSearchSubtitleKey, global arama sonucunda alt başlık olarak gösterilecek alanı ayarlar.
+func (r *Base) SetGlobalSearchSubtitleKey(key string) Resource {
+	r.globalSearch.SubtitleKey = key
+	return r
+}
+
+// SetGlobalSearchSubtitleFunc, global arama alt başlığını özel bir fonksiyonla hesaplar.
+func (r *Base) SetGlobalSearchSubtitleFunc(fn func(record any) string) Resource {
+	r.globalSearch.SubtitleFunc = fn
+	return r
+}
+
+// GetGlobalSearchConfig, resource'un global arama ayarlarını döner.
+func (r Base) GetGlobalSearchConfig() GlobalSearchConfig {
+	return normalizeGlobalSearchConfig(r.globalSearch, r.globalSearchConfigured)
+}
+
 // / # OpenAPIEnabled Metodu
 // /
 // / Bu fonksiyon, kaynağın OpenAPI spesifikasyonunda görünüp görünmeyeceğini döner.
@@ -1312,8 +1350,7 @@ func (r Base) StoreHandler(c *appContext.Context, file *multipart.FileHeader, st
 		storageURL = "/storage"
 	}
 
-	ext := filepath.Ext(file.Filename)
-	filename := fmt.Sprintf("%d%s", time.Now().UnixNano(), ext)
+	filename := storage.GenerateName(file.Filename)
 	localPath := filepath.Join(storagePath, filename)
 
 	// Ensure directory exists
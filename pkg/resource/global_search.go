@@ -0,0 +1,112 @@
+package resource
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// DefaultGlobalSearchLimit, limit ayarlanmamışsa bir resource'un global arama
+// sonuçlarına kattığı kayıt sayısıdır.
+const DefaultGlobalSearchLimit = 5
+
+// GlobalSearchConfig, resource'un global arama endpoint'ine nasıl katılacağını tanımlar.
+type GlobalSearchConfig struct {
+	// Enabled, resource'un global aramaya dahil edilip edilmeyeceğini belirtir.
+	// Searchable() alanı olmayan resource'lar yine de atlanır.
+	Enabled bool `json:"enabled"`
+
+	// Limit, bu resource için dönen kayıt sayısının üst sınırıdır.
+	Limit int `json:"limit"`
+
+	// SubtitleKey, sonuç alt başlığı olarak kullanılan model alanıdır.
+	SubtitleKey string `json:"subtitle_key"`
+
+	// SubtitleFunc, sonuç alt başlığını hesaplar ve SubtitleKey'den önceliklidir.
+	SubtitleFunc func(record any) string `json:"-"`
+}
+
+type globalSearchConfigProvider interface {
+	GetGlobalSearchConfig() GlobalSearchConfig
+}
+
+// ResolveGlobalSearchConfig, resource'un normalize edilmiş global arama ayarlarını döner.
+// GetGlobalSearchConfig sunmayan resource'lar varsayılan olarak aranabilir kabul edilir.
+func ResolveGlobalSearchConfig(res Resource) GlobalSearchConfig {
+	cfg := GlobalSearchConfig{Enabled: true}
+	if provider, ok := res.(globalSearchConfigProvider); ok {
+		cfg = provider.GetGlobalSearchConfig()
+	}
+
+	if cfg.Limit <= 0 {
+		cfg.Limit = DefaultGlobalSearchLimit
+	}
+	cfg.SubtitleKey = strings.TrimSpace(cfg.SubtitleKey)
+
+	return cfg
+}
+
+// GlobalSearchSubtitle, global arama sonucunun altında gösterilen alt başlığı çözer.
+func (cfg GlobalSearchConfig) GlobalSearchSubtitle(record any) string {
+	if cfg.SubtitleFunc != nil {
+		return cfg.SubtitleFunc(record)
+	}
+	if cfg.SubtitleKey == "" {
+		return ""
+	}
+	return recordFieldString(record, cfg.SubtitleKey)
+}
+
+func normalizeGlobalSearchConfig(cfg GlobalSearchConfig, configured bool) GlobalSearchConfig {
+	if !configured {
+		cfg.Enabled = true
+	}
+	return cfg
+}
+
+// recordFieldString, struct alanını (büyük/küçük harf duyarsız, snake_case uyumlu) string olarak okur.
+func recordFieldString(record any, key string) string {
+	if record == nil || key == "" {
+		return ""
+	}
+
+	if values, ok := record.(map[string]interface{}); ok {
+		if value, exists := values[key]; exists && value != nil {
+			return fmt.Sprintf("%v", value)
+		}
+		return ""
+	}
+
+	v := reflect.ValueOf(record)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+
+	needle := strings.ReplaceAll(key, "_", "")
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !strings.EqualFold(field.Name, key) && !strings.EqualFold(field.Name, needle) {
+			continue
+		}
+
+		value := v.Field(i)
+		if value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				return ""
+			}
+			value = value.Elem()
+		}
+		if value.Kind() == reflect.String {
+			return value.String()
+		}
+		return fmt.Sprintf("%v", value.Interface())
+	}
+
+	return ""
+}
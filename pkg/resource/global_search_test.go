@@ -0,0 +1,48 @@
+package resource
+
+import "testing"
+
+type globalSearchRecord struct {
+	Name      string
+	ShortCode string
+}
+
+func TestResolveGlobalSearchConfig_Defaults(t *testing.T) {
+	cfg := ResolveGlobalSearchConfig(&OptimizedBase{})
+	if !cfg.Enabled {
+		t.Fatal("expected resources to be globally searchable by default")
+	}
+	if cfg.Limit != DefaultGlobalSearchLimit {
+		t.Fatalf("expected default limit %d, got %d", DefaultGlobalSearchLimit, cfg.Limit)
+	}
+}
+
+func TestBaseGlobalSearch_Setters(t *testing.T) {
+	r := &Base{}
+	if returned := r.SetGlobalSearch(false); returned != r {
+		t.Fatal("expected SetGlobalSearch to support method chaining")
+	}
+	r.SetGlobalSearchLimit(3)
+	r.SetGlobalSearchSubtitleKey("short_code")
+
+	cfg := ResolveGlobalSearchConfig(r)
+	if cfg.Enabled || cfg.Limit != 3 {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+	if subtitle := cfg.GlobalSearchSubtitle(&globalSearchRecord{ShortCode: "ABC"}); subtitle != "ABC" {
+		t.Fatalf("expected subtitle from snake_case key, got %q", subtitle)
+	}
+}
+
+func TestOptimizedBaseGlobalSearch_SubtitleFunc(t *testing.T) {
+	r := &OptimizedBase{}
+	r.SetGlobalSearchSubtitleKey("name")
+	r.SetGlobalSearchSubtitleFunc(func(record any) string {
+		return "custom"
+	})
+
+	cfg := ResolveGlobalSearchConfig(r)
+	if subtitle := cfg.GlobalSearchSubtitle(&globalSearchRecord{Name: "ignored"}); subtitle != "custom" {
+		t.Fatalf("expected subtitle func to take precedence, got %q", subtitle)
+	}
+}
@@ -7,13 +7,13 @@ import (
 	"path/filepath"
 	"reflect"
 	"strings"
-	"time"
 
 	"github.com/ferdiunal/panel.go/pkg/action"
 	"github.com/ferdiunal/panel.go/pkg/auth"
 	"github.com/ferdiunal/panel.go/pkg/context"
 	"github.com/ferdiunal/panel.go/pkg/data"
 	"github.com/ferdiunal/panel.go/pkg/fields"
+	"github.com/ferdiunal/panel.go/pkg/storage"
 	"github.com/ferdiunal/panel.go/pkg/widget"
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
@@ -1396,6 +1396,11 @@ type OptimizedBase struct {
 	openAPIDisabled bool
 	recordTitleKey  string
 	recordTitleFunc func(record any) string
+
+	globalSearch           GlobalSearchConfig
+	globalSearchConfigured bool
+
+	auditDataChanges bool
 }
 
 // / SetModel, resource'un temsil ettiği veritabanı model'ini ayarlar.
@@ -1875,6 +1880,52 @@ func (b *OptimizedBase) IsGridEnabled() bool {
 	return b.Navigable.IsGridEnabled()
 }
 
+// SetGlobalSearch, resource'un global aramaya dahil edilip edilmeyeceğini ayarlar.
+//
+// Varsayılan olarak Searchable() alanı olan tüm resource'lar global aramaya katılır.
+func (b *OptimizedBase) SetGlobalSearch(enabled bool) Resource {
+	b.globalSearch.Enabled = enabled
+	b.globalSearchConfigured = true
+	return b
+}
+
+// SetGlobalSearchLimit, global aramada bu resource için döndürülecek kayıt sayısını ayarlar.
+func (b *OptimizedBase) SetGlobalSearchLimit(limit int) Resource {
+	b.globalSearch.Limit = limit
+	return b
+}
+
+// SetGlobalSearchSubtitleKey, global arama sonucunda alt başlık olarak gösterilecek alanı ayarlar.
+func (b *OptimizedBase) SetGlobalSearchSubtitleKey(key string) Resource {
+	b.globalSearch.SubtitleKey = key
+	return b
+}
+
+// SetGlobalSearchSubtitleFunc, global arama alt başlığını özel bir fonksiyonla hesaplar.
+func (b *OptimizedBase) SetGlobalSearchSubtitleFunc(fn func(record any) string) Resource {
+	b.globalSearch.SubtitleFunc = fn
+	return b
+}
+
+// GetGlobalSearchConfig, resource'un global arama ayarlarını döner.
+func (b *OptimizedBase) GetGlobalSearchConfig() GlobalSearchConfig {
+	return normalizeGlobalSearchConfig(b.globalSearch, b.globalSearchConfigured)
+}
+
+// SetAuditDataChanges, create/update/delete işlemlerinin alan bazında eski ve yeni
+// değerleriyle audit log'a yazılıp yazılmayacağını ayarlar (varsayılan: kapalı).
+//
+// Audit log panel yapılandırmasında (Security.Audit) kapalıysa kayıt yazılmaz.
+func (b *OptimizedBase) SetAuditDataChanges(enabled bool) Resource {
+	b.auditDataChanges = enabled
+	return b
+}
+
+// AuditsDataChanges, resource'un veri değişikliklerinin audit log'a yazılıp yazılmadığını döner.
+func (b *OptimizedBase) AuditsDataChanges() bool {
+	return b.auditDataChanges
+}
+
 // / GetFields, belirli bir context'e göre alanları döner.
 // /
 // / Bu metod, Resolvable mixin'in ResolveFields metodunu çağırır.
@@ -2155,8 +2206,7 @@ func (b *OptimizedBase) StoreHandler(c *context.Context, file *multipart.FileHea
 		storageURL = "/storage"
 	}
 
-	ext := filepath.Ext(file.Filename)
-	filename := fmt.Sprintf("%d%s", time.Now().UnixNano(), ext)
+	filename := storage.GenerateName(file.Filename)
 	localPath := filepath.Join(storagePath, filename)
 
 	if err := os.MkdirAll(storagePath, 0755); err != nil {
@@ -0,0 +1,72 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ferdiunal/panel.go/pkg/action"
+	domainUser "github.com/ferdiunal/panel.go/pkg/domain/user"
+	"github.com/ferdiunal/panel.go/pkg/resource"
+)
+
+// EmailVerifier, kullanıcı kaynağındaki e-posta doğrulama aksiyonlarının kullandığı servistir.
+// *auth.Service bu arayüzü uygular; panel, varsayılan kullanıcı kaynağına otomatik bağlar.
+type EmailVerifier interface {
+	MarkEmailVerified(ctx context.Context, userID uint) error
+	SendEmailVerification(ctx context.Context, u *domainUser.User) error
+}
+
+// SetEmailVerifier, "Mark Email Verified" ve "Resend Verification Email" aksiyonlarını etkinleştirir.
+// nil verilirse aksiyonlar listelenmez.
+func (r *UserResource) SetEmailVerifier(verifier EmailVerifier) *UserResource {
+	r.emailVerifier = verifier
+	return r
+}
+
+// GetActions, varsayılan aksiyonlara ek olarak EmailVerifier tanımlıysa doğrulama aksiyonlarını döndürür.
+func (r *UserResource) GetActions() []resource.Action {
+	actions := r.OptimizedBase.GetDefaultActions()
+	if r.emailVerifier == nil {
+		return actions
+	}
+	return append(actions, markEmailVerifiedAction(r.emailVerifier), resendVerificationAction(r.emailVerifier))
+}
+
+func markEmailVerifiedAction(verifier EmailVerifier) *action.BaseAction {
+	return action.New("Mark Email Verified").
+		SetSlug("mark-email-verified").
+		SetIcon("mail-check").
+		Confirm("Mark the selected users' email addresses as verified?").
+		Handle(func(ctx *action.ActionContext) error {
+			for _, model := range ctx.Models {
+				u, ok := model.(*domainUser.User)
+				if !ok {
+					continue
+				}
+				if err := verifier.MarkEmailVerified(ctx.Ctx.Context(), u.ID); err != nil {
+					return fmt.Errorf("mark %s as verified: %w", u.Email, err)
+				}
+			}
+			return nil
+		})
+}
+
+func resendVerificationAction(verifier EmailVerifier) *action.BaseAction {
+	return action.New("Resend Verification Email").
+		SetSlug("resend-verification-email").
+		SetIcon("mail").
+		Handle(func(ctx *action.ActionContext) error {
+			var errs []error
+			for _, model := range ctx.Models {
+				u, ok := model.(*domainUser.User)
+				if !ok || u.EmailVerified {
+					continue
+				}
+				if err := verifier.SendEmailVerification(ctx.Ctx.Context(), u); err != nil {
+					errs = append(errs, fmt.Errorf("%s: %w", u.Email, err))
+				}
+			}
+			return errors.Join(errs...)
+		})
+}
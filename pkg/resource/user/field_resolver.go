@@ -30,11 +30,14 @@ import (
 // - Rol seçimi için dinamik seçeneklerin sağlanması
 //
 // Önemli Notlar:
-// - Bu yapı durum taşımaz (stateless), sadece alan tanımlarını sağlar
+// - Bu yapı yalnızca isteğe bağlı PasswordService'i taşır, alan tanımları her çağrıda yeniden oluşturulur
 // - Tüm alan konfigürasyonları method chaining kullanılarak yapılır
 // - Dosya depolama işlemleri "./storage/public" dizinine yapılır
 // - Roller dinamik olarak permission yöneticisinden alınır
-type UserFieldResolver struct{}
+type UserFieldResolver struct {
+	// passwordService tanımlıysa şifre alanı panelin şifre politikasıyla doğrulanır.
+	passwordService PasswordService
+}
 
 // Bu metod, kullanıcı kaynağı için tüm form alanlarını tanımlar ve döner.
 //
@@ -50,7 +53,7 @@ type UserFieldResolver struct{}
 //  3. Name: Kullanıcı adı, arama yapılabilir
 //  4. Email: E-posta adresi, arama yapılabilir
 //  5. Role: Kullanıcı rolü, dinamik seçenekler ile
-//  6. Password: Şifre, sadece form görünümünde, boş bırakılabilir
+//  6. Password: Şifre, sadece form görünümünde, boş bırakılabilir (PasswordService varsa şifre politikasıyla doğrulanır)
 //
 // Kullanım Örneği:
 //
@@ -70,6 +73,11 @@ func (r *UserFieldResolver) ResolveFields(ctx *context.Context) []fields.Element
 		ctx = &context.Context{} // Boş context oluştur
 	}
 
+	password := fields.Password("Password")
+	if r.passwordService != nil {
+		password.CustomValidators = append(password.CustomValidators, passwordPolicyValidator(r.passwordService))
+	}
+
 	return []fields.Element{
 		// ID alanı - i18n destekli
 		fields.ID().Searchable(),
@@ -134,7 +142,7 @@ func (r *UserFieldResolver) ResolveFields(ctx *context.Context) []fields.Element
 			}()),
 
 		// Şifre alanı - i18n destekli label ve placeholder
-		fields.Password("Password").
+		password.
 			Label(i18n.Trans(ctx.Ctx, "resources.users.fields.password")).
 			Placeholder(i18n.Trans(ctx.Ctx, "resources.users.fields.password_placeholder")).
 			Nullable().
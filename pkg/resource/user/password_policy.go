@@ -0,0 +1,61 @@
+package user
+
+import (
+	stdcontext "context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/ferdiunal/panel.go/pkg/context"
+	"github.com/ferdiunal/panel.go/pkg/fields"
+	"github.com/ferdiunal/panel.go/pkg/i18n"
+	"github.com/ferdiunal/panel.go/pkg/service/auth"
+)
+
+// PasswordService, yönetici formundan girilen şifreleri panelin şifre politikasıyla doğrular ve kaydeder.
+// *auth.Service bu arayüzü uygular; panel, varsayılan kullanıcı kaynağına otomatik bağlar.
+// Şifre, kayıt güncellemesinin transaction'ına bağlı repository'lerle SetPasswordWith üzerinden yazılır.
+type PasswordService interface {
+	ValidatePassword(ctx stdcontext.Context, userID uint, password string) error
+	SetPasswordWith(ctx stdcontext.Context, stores auth.PasswordStores, userID uint, password string, keepSessionID uint) error
+}
+
+// SetPasswordService, şifre alanına politika doğrulamasını ekler ve düzenleme formundan
+// yapılan şifre değişikliklerini servis üzerinden (şifre geçmişi ve yaşı ile) kaydeder.
+// nil verilirse şifreler yalnızca hash'lenerek saklanır.
+func (r *UserResource) SetPasswordService(service PasswordService) *UserResource {
+	r.passwordService = service
+	r.SetFieldResolver(&UserFieldResolver{passwordService: service})
+	return r
+}
+
+// passwordPolicyValidator, şifre alanı için fields.ValidatorFunc döndürür. Düzenleme
+// formunda kaydın ID'si şifre geçmişi kontrolü için kullanılır; ihlaller isteğin
+// diline çevrilerek tek mesajda birleştirilir.
+func passwordPolicyValidator(service PasswordService) fields.ValidatorFunc {
+	return func(value interface{}, ctx interface{}) error {
+		password, ok := value.(string)
+		if !ok || password == "" {
+			return nil
+		}
+		c, _ := ctx.(*context.Context)
+		if c == nil || c.Ctx == nil {
+			return service.ValidatePassword(stdcontext.Background(), 0, password)
+		}
+
+		var userID uint
+		if id, err := strconv.ParseUint(c.Params("id"), 10, 64); err == nil {
+			userID = uint(id)
+		}
+		err := service.ValidatePassword(c.Context(), userID, password)
+		var policyErr *auth.PasswordPolicyError
+		if !errors.As(err, &policyErr) {
+			return err
+		}
+		messages := make([]string, 0, len(policyErr.Violations))
+		for _, v := range policyErr.Violations {
+			messages = append(messages, i18n.TransWithFallback(c.Ctx, v.Key, v.Message, v.Params))
+		}
+		return errors.New(strings.Join(messages, "; "))
+	}
+}
@@ -3,6 +3,7 @@ package user
 import (
 	appContext "github.com/ferdiunal/panel.go/pkg/context"
 	domainUser "github.com/ferdiunal/panel.go/pkg/domain/user"
+	"github.com/ferdiunal/panel.go/pkg/permission"
 )
 
 // ============================================================================
@@ -288,3 +289,59 @@ func (p UserPolicy) Delete(ctx *appContext.Context, model any) bool {
 	// Tüm kontroller geçerse true döner (silme işlemine izin ver)
 	return true
 }
+
+// ============================================================================
+// Bu metod, kimliği doğrulanmış kullanıcının başka bir kullanıcının kimliğine
+// bürünüp (impersonation) bürünemeyeceğini kontrol eder. auth.ImpersonationPolicy
+// interface'ini uygular; "users.impersonate" izni panel tarafından ayrıca
+// kontrol edilir.
+//
+// **Kontrol Akışı:**
+// 1. model *domainUser.User değilse false döner
+// 2. ctx veya ctx.User() nil ise false döner
+// 3. Kullanıcı kendine bürünemez
+// 4. Aynı roldeki kullanıcılara ve admin'in herkese bürünmesine izin verilir
+// 5. Admin kullanıcılara yalnızca admin'ler bürünebilir
+// 6. Diğer rollerde hedef rolün tüm izinleri bürünen rolde de bulunmalıdır;
+//    permission manager yüklenmemişse farklı role bürünülemez (yetki
+//    yükseltmeyi önler)
+// ============================================================================
+func (p UserPolicy) Impersonate(ctx *appContext.Context, model any) bool {
+	target, ok := model.(*domainUser.User)
+	if !ok || target == nil || ctx == nil {
+		return false
+	}
+
+	authUser := ctx.User()
+	if authUser == nil || authUser.ID == target.ID {
+		return false
+	}
+
+	if target.Role == authUser.Role || authUser.Role == "admin" {
+		return true
+	}
+	if target.Role == "admin" {
+		return false
+	}
+	return rolePermissionsCovered(authUser.Role, target.Role)
+}
+
+// rolePermissionsCovered, target rolünün tüm izinlerinin actor rolünde de
+// bulunup bulunmadığını döner. "*" izni yalnızca "*" iznine sahip rol
+// tarafından karşılanır.
+func rolePermissionsCovered(actor, target string) bool {
+	mgr := permission.GetInstance()
+	if mgr == nil {
+		return false
+	}
+	if _, ok := mgr.GetRole(actor); !ok {
+		return false
+	}
+	targetRole, _ := mgr.GetRole(target)
+	for _, perm := range targetRole.Permissions {
+		if !mgr.HasPermission(actor, perm) {
+			return false
+		}
+	}
+	return true
+}
@@ -1,9 +1,15 @@
 package user
 
 import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
+	appContext "github.com/ferdiunal/panel.go/pkg/context"
 	domainUser "github.com/ferdiunal/panel.go/pkg/domain/user"
+	"github.com/ferdiunal/panel.go/pkg/permission"
+	"github.com/gofiber/fiber/v2"
 )
 
 // TestUserPolicyDeleteWithoutModel, model olmadan Delete permission'ını test eder
@@ -41,3 +47,62 @@ func TestUserPolicyDeleteWithUser(t *testing.T) {
 		t.Error("Expected Delete to return false with nil context")
 	}
 }
+
+// TestUserPolicyImpersonateRoleEscalation, bürünen rolün izinlerini aşan
+// rollere bürünmenin engellendiğini test eder
+func TestUserPolicyImpersonateRoleEscalation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "permissions.toml")
+	content := `
+[admin]
+permissions = ["*"]
+
+[support]
+permissions = ["users.impersonate", "users.view"]
+
+[manager]
+permissions = ["users.impersonate", "users.view", "users.delete", "billing.manage"]
+
+[customer]
+permissions = ["users.view"]
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write permissions: %v", err)
+	}
+	if _, err := permission.Load(path); err != nil {
+		t.Fatalf("failed to load permissions: %v", err)
+	}
+
+	policy := UserPolicy{}
+	impersonate := func(actor, target *domainUser.User) bool {
+		var allowed bool
+		app := fiber.New()
+		app.Get("/", func(c *fiber.Ctx) error {
+			c.Locals("user", actor)
+			allowed = policy.Impersonate(&appContext.Context{Ctx: c}, target)
+			return nil
+		})
+		if _, err := app.Test(httptest.NewRequest("GET", "/", nil)); err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		return allowed
+	}
+
+	support := &domainUser.User{ID: 1, Role: "support"}
+	cases := []struct {
+		name   string
+		actor  *domainUser.User
+		target *domainUser.User
+		want   bool
+	}{
+		{"lower role", support, &domainUser.User{ID: 2, Role: "customer"}, true},
+		{"same role", support, &domainUser.User{ID: 3, Role: "support"}, true},
+		{"higher non-admin role", support, &domainUser.User{ID: 4, Role: "manager"}, false},
+		{"admin target", support, &domainUser.User{ID: 5, Role: "admin"}, false},
+		{"admin actor", &domainUser.User{ID: 6, Role: "admin"}, &domainUser.User{ID: 4, Role: "manager"}, true},
+	}
+	for _, tc := range cases {
+		if got := impersonate(tc.actor, tc.target); got != tc.want {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, got)
+		}
+	}
+}
@@ -4,6 +4,7 @@
 package user
 
 import (
+	stdcontext "context"
 	"fmt"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/ferdiunal/panel.go/pkg/data/orm"
 	"github.com/ferdiunal/panel.go/pkg/domain/account"
 	"github.com/ferdiunal/panel.go/pkg/domain/user"
+	"github.com/ferdiunal/panel.go/pkg/service/auth"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
@@ -36,6 +38,9 @@ type UserDataProvider struct {
 	*data.GormDataProvider
 	client            *gorm.DB
 	accountRepository *orm.AccountRepository
+
+	// passwordService tanımlıysa mevcut hesapların şifre değişiklikleri bu servis üzerinden yapılır.
+	passwordService PasswordService
 }
 
 // Bu fonksiyon, yeni bir UserDataProvider örneği oluşturur ve başlatır.
@@ -152,13 +157,15 @@ func (p *UserDataProvider) Create(ctx *context.Context, data map[string]interfac
 	// Adım 4: Oluşturulan kullanıcı için hesap (Account) kaydı oluşturur
 	// GORM kullanarak Account oluşturulur
 	stdCtx := ctx.Context()
+	now := time.Now()
 	err = p.accountRepository.Create(stdCtx, &account.Account{
-		ProviderID: "credential",
-		Password:   string(hashed),
-		UserID:     user.ID,
-		AccountID:  nil,
-		CreatedAt:  time.Now(),
-		UpdatedAt:  time.Now(),
+		ProviderID:        "credential",
+		Password:          string(hashed),
+		PasswordChangedAt: &now,
+		UserID:            user.ID,
+		AccountID:         nil,
+		CreatedAt:         now,
+		UpdatedAt:         now,
 	})
 
 	if err != nil {
@@ -171,3 +178,112 @@ func (p *UserDataProvider) Create(ctx *context.Context, data map[string]interfac
 	// Başarılı olursa, oluşturulan User nesnesi döndürülür
 	return user, nil
 }
+
+// Bu metod, varsayılan güncelleme işlemini geçersiz kılarak şifre değişikliğini destekler.
+//
+// Formda yeni bir şifre girildiyse credential hesabının şifresi bcrypt ile hashlenerek
+// güncellenir (hesap yoksa oluşturulur) ve kullanıcının tüm oturumları sonlandırılır.
+// Şifre alanı boş bırakılırsa yalnızca diğer alanlar güncellenir.
+//
+// Önemli Notlar:
+//   - Diğer alanlar ve şifre tek transaction içinde yazılır; şifre kaydedilemezse
+//     (örn. politika ihlali) diğer alanlardaki değişiklikler de geri alınır
+//   - PasswordService tanımlıysa mevcut hesabın şifresi servis üzerinden değiştirilir;
+//     böylece şifre geçmişi ve şifre yaşı da güncellenir
+//   - Şifre hiçbir zaman users tablosuna yazılmaz
+//   - Oturumların sonlandırılması, ele geçirilmiş hesaplarda eski cihazların erişimini keser
+func (p *UserDataProvider) Update(ctx *context.Context, id string, data map[string]interface{}) (interface{}, error) {
+	password, _ := data["password"].(string)
+	delete(data, "password")
+	if password == "" {
+		return p.GormDataProvider.Update(ctx, id, data)
+	}
+
+	txProvider, err := p.GormDataProvider.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = txProvider.Rollback()
+		}
+	}()
+
+	tx, ok := txProvider.GetClient().(*gorm.DB)
+	if !ok || tx == nil {
+		return nil, fmt.Errorf("users: database is not available")
+	}
+
+	result, err := txProvider.Update(ctx, id, data)
+	if err != nil {
+		return nil, err
+	}
+	if u, ok := result.(*user.User); ok {
+		if err := p.setPassword(ctx.Context(), tx, u.ID, password); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := txProvider.Commit(); err != nil {
+		return nil, err
+	}
+	committed = true
+	return result, nil
+}
+
+// setPassword, kullanıcının credential hesabının şifresini tx üzerinden değiştirir ve
+// tüm oturumlarını sonlandırır.
+func (p *UserDataProvider) setPassword(ctx stdcontext.Context, tx *gorm.DB, userID uint, password string) error {
+	accountRepository := orm.NewAccountRepository(tx)
+	sessionRepository := orm.NewSessionRepository(tx)
+
+	accounts, err := accountRepository.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	var credential *account.Account
+	for i := range accounts {
+		if accounts[i].ProviderID == "credential" {
+			credential = &accounts[i]
+			break
+		}
+	}
+	if credential != nil && p.passwordService != nil {
+		// SetPasswordWith oturumların tamamını da sonlandırır
+		return p.passwordService.SetPasswordWith(ctx, auth.PasswordStores{
+			Users:    orm.NewUserRepository(tx),
+			Sessions: sessionRepository,
+			Accounts: accountRepository,
+			History:  orm.NewPasswordHistoryRepository(tx),
+		}, userID, password, 0)
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if credential == nil {
+		err = accountRepository.Create(ctx, &account.Account{
+			ProviderID:        "credential",
+			Password:          string(hashed),
+			PasswordChangedAt: &now,
+			UserID:            userID,
+			CreatedAt:         now,
+			UpdatedAt:         now,
+		})
+	} else {
+		credential.Password = string(hashed)
+		credential.PasswordChangedAt = &now
+		credential.UpdatedAt = now
+		err = accountRepository.Update(ctx, credential)
+	}
+	if err != nil {
+		return err
+	}
+
+	// SECURITY: A password change signs the user out everywhere.
+	return sessionRepository.DeleteByUserID(ctx, userID)
+}
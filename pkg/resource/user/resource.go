@@ -59,6 +59,12 @@ type UserResource struct {
 	// navigasyon sırası, yetkilendirme politikası, alan çözümleyici,
 	// kart çözümleyici ve veri sağlayıcı gibi özellikleri içerir.
 	resource.OptimizedBase
+
+	// emailVerifier, e-posta doğrulama aksiyonlarını sağlar (SetEmailVerifier ile ayarlanır).
+	emailVerifier EmailVerifier
+
+	// passwordService, şifre politikasını uygular (SetPasswordService ile ayarlanır).
+	passwordService PasswordService
 }
 
 // Bu fonksiyon, yeni bir UserResource örneği oluşturur ve tüm gerekli
@@ -178,5 +184,7 @@ func (r *UserResource) Repository(client *gorm.DB) data.DataProvider {
 		return nil
 	}
 
-	return NewUserDataProvider(client)
+	provider := NewUserDataProvider(client)
+	provider.passwordService = r.passwordService
+	return provider
 }
@@ -0,0 +1,153 @@
+package auth
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// BreachedPasswordChecker, bir şifrenin bilinen veri sızıntılarında yer alıp almadığını kontrol eder.
+// PasswordPolicy.Breached ile bağlanır; HashRangeChecker yerleşik, çevrimdışı uygulamadır.
+type BreachedPasswordChecker interface {
+	IsBreached(password string) (bool, error)
+}
+
+// HashRangeChecker, Have I Been Pwned "Pwned Passwords" listesinin yerel bir kopyasını
+// kullanır. Şifre veya hash'i hiçbir servise gönderilmez.
+//
+// Path iki biçimde verilebilir:
+//
+//   - Dizin: k-anonymity aralık dosyaları. SHA-1 hash'in ilk 5 hex karakteri dosya adıdır
+//     ("21BD1" veya "21BD1.txt"); satırlar range API yanıtındaki gibi "SUFFIX:COUNT" biçimindedir.
+//   - Dosya: "HASH:COUNT" satırlarından oluşan, hash'e göre sıralı tek liste
+//     (haveibeenpwned-downloader çıktısı). Dosya belleğe yüklenmez, ikili arama yapılır.
+//
+// COUNT değeri 0 olan satırlar (range API padding kayıtları) eşleşme sayılmaz.
+type HashRangeChecker struct {
+	path string
+	dir  bool
+}
+
+// NewHashRangeChecker, path'teki listeyi kullanan bir kontrolcü oluşturur.
+// Path yoksa hata döner; dosyalar her kontrolde yeniden açılır.
+func NewHashRangeChecker(path string) (*HashRangeChecker, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("breached password list: %w", err)
+	}
+	return &HashRangeChecker{path: path, dir: info.IsDir()}, nil
+}
+
+// IsBreached, şifrenin SHA-1 hash'i listede bulunuyorsa true döner.
+func (c *HashRangeChecker) IsBreached(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	if c.dir {
+		return c.searchRange(hash[:5], hash[5:])
+	}
+	return c.searchSorted(hash)
+}
+
+// searchRange, hash önekine ait aralık dosyasında soneki arar. Dosya yoksa eşleşme yoktur.
+func (c *HashRangeChecker) searchRange(prefix, suffix string) (bool, error) {
+	var f *os.File
+	for _, name := range []string{prefix + ".txt", prefix, strings.ToLower(prefix) + ".txt", strings.ToLower(prefix)} {
+		opened, err := os.Open(filepath.Join(c.path, name))
+		if err == nil {
+			f = opened
+			break
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return false, err
+		}
+	}
+	if f == nil {
+		return false, nil
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lineHash, count := splitHashLine(scanner.Text())
+		if strings.EqualFold(lineHash, suffix) {
+			return count != "0", nil
+		}
+	}
+	return false, scanner.Err()
+}
+
+// searchSorted, sıralı tam hash listesinde satır başlarına hizalanan ikili arama yapar.
+func (c *HashRangeChecker) searchSorted(hash string) (bool, error) {
+	f, err := os.Open(c.path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return false, err
+	}
+
+	// Eşleşen satır varsa başlangıcı her zaman [lo, hi) aralığındadır
+	lo, hi := int64(0), info.Size()
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		start, line, err := lineAtOrAfter(f, info.Size(), mid)
+		if err == io.EOF || (err == nil && start >= hi) {
+			hi = mid
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+
+		lineHash, count := splitHashLine(line)
+		switch cmp := strings.Compare(strings.ToUpper(lineHash), hash); {
+		case cmp == 0:
+			return count != "0", nil
+		case cmp < 0:
+			lo = start + int64(len(line))
+		default:
+			hi = mid
+		}
+	}
+	return false, nil
+}
+
+// lineAtOrAfter, offset'te veya sonrasında başlayan ilk satırı (satır sonu dahil) ve başlangıç konumunu döndürür.
+func lineAtOrAfter(f *os.File, size, offset int64) (int64, string, error) {
+	if offset == 0 {
+		return readLineAt(bufio.NewReader(io.NewSectionReader(f, 0, size)), 0)
+	}
+	// offset-1'den okunarak offset'in bir satır başı olup olmadığı anlaşılır
+	reader := bufio.NewReader(io.NewSectionReader(f, offset-1, size-offset+1))
+	skipped, err := reader.ReadString('\n')
+	if err != nil {
+		return 0, "", io.EOF
+	}
+	return readLineAt(reader, offset-1+int64(len(skipped)))
+}
+
+func readLineAt(reader *bufio.Reader, start int64) (int64, string, error) {
+	line, err := reader.ReadString('\n')
+	if line == "" {
+		if err == nil {
+			err = io.EOF
+		}
+		return 0, "", err
+	}
+	return start, line, nil
+}
+
+// splitHashLine, "HASH:COUNT" satırını hash ve sayı olarak ayırır. Sayı yoksa boş döner.
+func splitHashLine(line string) (string, string) {
+	line = strings.TrimSpace(line)
+	hash, count, _ := strings.Cut(line, ":")
+	return strings.TrimSpace(hash), strings.TrimSpace(count)
+}
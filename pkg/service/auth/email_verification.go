@@ -0,0 +1,290 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ferdiunal/panel.go/pkg/domain/user"
+	"github.com/ferdiunal/panel.go/pkg/domain/verification"
+	"github.com/ferdiunal/panel.go/pkg/mail"
+	"gorm.io/gorm"
+)
+
+// E-posta doğrulama akışında oluşabilecek hata değişkenleri.
+var (
+	// ErrEmailNotVerified: Doğrulama zorunluyken e-postası doğrulanmamış kullanıcı giriş yapmaya çalıştığında döndürülür.
+	ErrEmailNotVerified = errors.New("email address is not verified")
+
+	// ErrEmailVerificationNotConfigured: SetEmailVerification çağrılmadan doğrulama e-postası gönderilmek istendiğinde döndürülür.
+	ErrEmailVerificationNotConfigured = errors.New("email verification is not configured")
+
+	// ErrInvalidEmailVerificationToken: Token bulunamadığında veya süresi dolduğunda döndürülür.
+	ErrInvalidEmailVerificationToken = errors.New("invalid or expired email verification token")
+
+	// ErrEmailAlreadyVerified: Doğrulanmış bir adres için yeniden e-posta gönderilmek istendiğinde döndürülür.
+	ErrEmailAlreadyVerified = errors.New("email address is already verified")
+
+	// ErrEmailVerificationThrottled: ResendInterval dolmadan yeni doğrulama e-postası istendiğinde döndürülür.
+	ErrEmailVerificationThrottled = errors.New("verification email was sent recently, please wait before requesting another")
+)
+
+// EmailVerificationEnforcement, e-postası doğrulanmamış kullanıcılara uygulanacak kısıtlamayı belirler.
+type EmailVerificationEnforcement string
+
+const (
+	// EmailVerificationOptional, doğrulanmamış kullanıcılar için kısıtlama uygulamaz.
+	EmailVerificationOptional EmailVerificationEnforcement = ""
+
+	// EmailVerificationBlockLogin, doğrulanmamış kullanıcıların giriş yapmasını engeller.
+	EmailVerificationBlockLogin EmailVerificationEnforcement = "login"
+
+	// EmailVerificationRestrictAccess, girişe izin verir ancak oturum gerektiren API isteklerini reddeder.
+	EmailVerificationRestrictAccess EmailVerificationEnforcement = "restrict"
+)
+
+const (
+	// DefaultEmailVerificationLifetime, doğrulama bağlantısının varsayılan geçerlilik süresidir.
+	DefaultEmailVerificationLifetime = 24 * time.Hour
+
+	// DefaultEmailVerificationResendInterval, aynı kullanıcıya iki doğrulama e-postası arasındaki en kısa süredir.
+	DefaultEmailVerificationResendInterval = time.Minute
+
+	// emailVerificationPrefix, doğrulama kayıtlarının verification tablosundaki identifier ön ekidir.
+	emailVerificationPrefix = "email_verification:"
+)
+
+// EmailVerificationOptions, doğrulama e-postalarının içeriğini ve politikasını yapılandırır.
+type EmailVerificationOptions struct {
+	// From, gönderen adresidir (örn: "Panel <no-reply@example.com>").
+	From string
+
+	// AppName, e-posta konusunda ve gövdesinde kullanılır. Boşsa "Panel.go".
+	AppName string
+
+	// Enforcement, doğrulanmamış kullanıcılara uygulanacak kısıtlamadır.
+	Enforcement EmailVerificationEnforcement
+
+	// TokenLifetime, bağlantının geçerlilik süresidir. Sıfırsa DefaultEmailVerificationLifetime.
+	TokenLifetime time.Duration
+
+	// ResendInterval, yeniden gönderim için beklenecek süredir. Sıfırsa DefaultEmailVerificationResendInterval.
+	ResendInterval time.Duration
+
+	// VerifyURL, e-postadaki bağlantının tabanıdır (token query parametresi olarak eklenir).
+	// Zorunludur; token içeren bağlantı istek başlıklarından (Host) üretilmez.
+	// Boşsa doğrulama e-postası gönderilmez.
+	VerifyURL string
+}
+
+// emailVerificationSettings, Service üzerindeki e-posta doğrulama bağımlılıklarını tutar.
+type emailVerificationSettings struct {
+	verifications verification.Repository
+	sender        mail.Sender
+	options       EmailVerificationOptions
+}
+
+// Bu metod, doğrulama token'larının saklanacağı repository'yi, e-posta göndericisini ve politikayı ayarlar.
+// Çağrılmazsa kayıt sonrası doğrulama e-postası gönderilmez ve hiçbir kısıtlama uygulanmaz.
+//
+// Örnek:
+//
+//	authService.SetEmailVerification(orm.NewVerificationRepository(db), mail.NewFileSender("storage/mail"), auth.EmailVerificationOptions{
+//	    From:        "no-reply@example.com",
+//	    Enforcement: auth.EmailVerificationBlockLogin,
+//	})
+func (s *Service) SetEmailVerification(verifications verification.Repository, sender mail.Sender, opts EmailVerificationOptions) {
+	if opts.TokenLifetime <= 0 {
+		opts.TokenLifetime = DefaultEmailVerificationLifetime
+	}
+	if opts.ResendInterval <= 0 {
+		opts.ResendInterval = DefaultEmailVerificationResendInterval
+	}
+	if strings.TrimSpace(opts.AppName) == "" {
+		opts.AppName = DefaultTwoFactorIssuer
+	}
+	s.emailVerification = emailVerificationSettings{
+		verifications: verifications,
+		sender:        sender,
+		options:       opts,
+	}
+}
+
+// Bu metod, doğrulama e-postası gönderilebiliyorsa true döner.
+func (s *Service) EmailVerificationEnabled() bool {
+	return s.emailVerification.verifications != nil && s.emailVerification.sender != nil &&
+		strings.TrimSpace(s.emailVerification.options.VerifyURL) != ""
+}
+
+// Bu metod, kullanıcının doğrulanmamış e-postası nedeniyle giriş yapamayacağını bildirir.
+func (s *Service) EmailVerificationBlocksLogin(u *user.User) bool {
+	return u != nil && !u.EmailVerified && s.emailVerification.options.Enforcement == EmailVerificationBlockLogin
+}
+
+// Bu metod, kullanıcının doğrulama tamamlanana kadar oturum gerektiren API'lere erişemeyeceğini bildirir.
+// API key ile oluşturulan sentetik kullanıcı (ID 0) kısıtlanmaz.
+func (s *Service) EmailVerificationRestricted(u *user.User) bool {
+	return u != nil && u.ID != 0 && !u.EmailVerified &&
+		s.emailVerification.options.Enforcement == EmailVerificationRestrictAccess
+}
+
+// Bu metod, kullanıcıya Options.VerifyURL tabanlı yeni bir doğrulama bağlantısı gönderir.
+// Önceki bağlantılar geçersiz olur; ResendInterval dolmadan çağrılırsa ErrEmailVerificationThrottled döner.
+func (s *Service) SendEmailVerification(ctx context.Context, u *user.User) error {
+	if !s.EmailVerificationEnabled() {
+		return ErrEmailVerificationNotConfigured
+	}
+	if u == nil || u.ID == 0 {
+		return ErrUserNotFound
+	}
+	if u.EmailVerified {
+		return ErrEmailAlreadyVerified
+	}
+
+	opts := s.emailVerification.options
+	identifier := emailVerificationIdentifier(u.ID)
+	latest, err := s.emailVerification.verifications.FindLatestByIdentifier(ctx, identifier)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if latest != nil && time.Since(latest.CreatedAt) < opts.ResendInterval {
+		return ErrEmailVerificationThrottled
+	}
+
+	token, err := randomToken(32)
+	if err != nil {
+		return err
+	}
+	if err := s.emailVerification.verifications.DeleteByIdentifier(ctx, identifier); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	record := &verification.Verification{
+		Identifier: identifier,
+		Token:      hashToken(token),
+		ExpiresAt:  now.Add(opts.TokenLifetime),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := s.emailVerification.verifications.Create(ctx, record); err != nil {
+		return err
+	}
+
+	link := emailVerificationLink(opts.VerifyURL, token)
+	if err := s.emailVerification.sender.Send(ctx, emailVerificationMessage(opts, u, link)); err != nil {
+		return fmt.Errorf("send verification email: %w", err)
+	}
+	return nil
+}
+
+// Bu metod, e-posta adresine göre yeniden doğrulama e-postası gönderir.
+// Hesabın varlığını açığa çıkarmamak için bilinmeyen veya zaten doğrulanmış adreslerde nil döner.
+func (s *Service) ResendEmailVerification(ctx context.Context, email string) error {
+	if !s.EmailVerificationEnabled() {
+		return ErrEmailVerificationNotConfigured
+	}
+	u, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil || u == nil || u.EmailVerified {
+		return nil
+	}
+	return s.SendEmailVerification(ctx, u)
+}
+
+// Bu metod, bağlantıdaki token'ı doğrular ve kullanıcının EmailVerified alanını true yapar.
+// Token tek kullanımlıktır.
+func (s *Service) VerifyEmail(ctx context.Context, token string) (*user.User, error) {
+	if s.emailVerification.verifications == nil {
+		return nil, ErrEmailVerificationNotConfigured
+	}
+	if strings.TrimSpace(token) == "" {
+		return nil, ErrInvalidEmailVerificationToken
+	}
+
+	record, err := s.emailVerification.verifications.FindByToken(ctx, hashToken(token))
+	if err != nil || !strings.HasPrefix(record.Identifier, emailVerificationPrefix) {
+		return nil, ErrInvalidEmailVerificationToken
+	}
+	if time.Now().After(record.ExpiresAt) {
+		_ = s.emailVerification.verifications.Delete(ctx, record.ID)
+		return nil, ErrInvalidEmailVerificationToken
+	}
+
+	userID, err := strconv.ParseUint(strings.TrimPrefix(record.Identifier, emailVerificationPrefix), 10, 64)
+	if err != nil {
+		return nil, ErrInvalidEmailVerificationToken
+	}
+	u, err := s.markEmailVerified(ctx, uint(userID))
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// Bu metod, kullanıcının e-posta adresini bağlantı gerekmeden doğrulanmış olarak işaretler.
+// Yönetici aksiyonları için kullanılır; bekleyen doğrulama bağlantıları geçersiz olur.
+func (s *Service) MarkEmailVerified(ctx context.Context, userID uint) error {
+	_, err := s.markEmailVerified(ctx, userID)
+	return err
+}
+
+func (s *Service) markEmailVerified(ctx context.Context, userID uint) (*user.User, error) {
+	u, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if !u.EmailVerified {
+		u.EmailVerified = true
+		u.UpdatedAt = time.Now()
+		if err := s.userRepo.UpdateUser(ctx, u); err != nil {
+			return nil, err
+		}
+	}
+	if s.emailVerification.verifications != nil {
+		if err := s.emailVerification.verifications.DeleteByIdentifier(ctx, emailVerificationIdentifier(userID)); err != nil {
+			return nil, err
+		}
+	}
+	return u, nil
+}
+
+func emailVerificationIdentifier(userID uint) string {
+	return emailVerificationPrefix + strconv.FormatUint(uint64(userID), 10)
+}
+
+func emailVerificationLink(base, token string) string {
+	separator := "?"
+	if strings.Contains(base, "?") {
+		separator = "&"
+	}
+	return base + separator + "token=" + url.QueryEscape(token)
+}
+
+func emailVerificationMessage(opts EmailVerificationOptions, u *user.User, link string) mail.Message {
+	name := u.Name
+	if name == "" {
+		name = u.Email
+	}
+	expires := opts.TokenLifetime.Round(time.Minute).String()
+
+	text := fmt.Sprintf("Hi %s,\n\nPlease confirm your email address for %s by opening the link below:\n\n%s\n\n"+
+		"The link expires in %s. If you did not create an account, you can ignore this email.\n",
+		name, opts.AppName, link, expires)
+	htmlBody := fmt.Sprintf(`<p>Hi %s,</p><p>Please confirm your email address for %s.</p>`+
+		`<p><a href="%s">Verify email address</a></p>`+
+		`<p>The link expires in %s. If you did not create an account, you can ignore this email.</p>`,
+		html.EscapeString(name), html.EscapeString(opts.AppName), html.EscapeString(link), expires)
+
+	return mail.Message{
+		From:    opts.From,
+		To:      []string{u.Email},
+		Subject: opts.AppName + ": verify your email address",
+		Text:    text,
+		HTML:    htmlBody,
+	}
+}
@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ferdiunal/panel.go/pkg/domain/session"
+	"github.com/ferdiunal/panel.go/pkg/domain/user"
+)
+
+// Kimliğine bürünme (impersonation) sırasında döndürülen hatalar.
+var (
+	// ErrImpersonationNotAllowed: İzin veya hedef kullanıcı policy'si impersonation'a izin vermediğinde döndürülür.
+	ErrImpersonationNotAllowed = errors.New("impersonation not allowed")
+
+	// ErrAlreadyImpersonating: Impersonation oturumundan yeni bir impersonation başlatılmak istendiğinde döndürülür.
+	ErrAlreadyImpersonating = errors.New("already impersonating another user")
+
+	// ErrNotImpersonating: Normal bir oturumda impersonation sonlandırılmak istendiğinde döndürülür.
+	ErrNotImpersonating = errors.New("session is not impersonating a user")
+)
+
+// ImpersonationLifetime, impersonation oturumlarının en uzun geçerlilik süresidir.
+// SessionLifetime daha kısaysa o kullanılır; idle timeout da ayrıca uygulanır.
+const ImpersonationLifetime = time.Hour
+
+// Bu metod, verilen ID'ye sahip kullanıcıyı döndürür.
+// Kullanıcı yoksa ErrUserNotFound döner.
+func (s *Service) FindUser(ctx context.Context, id uint) (*user.User, error) {
+	u, err := s.userRepo.FindByID(ctx, id)
+	if err != nil || u == nil {
+		return nil, ErrUserNotFound
+	}
+	return u, nil
+}
+
+// Bu metod, current oturumun sahibi adına target kullanıcı için bir impersonation oturumu açar.
+// Yeni oturum ImpersonatorID alanında orijinal kullanıcıyı taşır ve current oturum sonlandırılır;
+// istemci cookie'si yeni oturumla değiştirilmelidir.
+//
+// Yetki kontrolü (izin ve hedef kullanıcı policy'si) çağıranın sorumluluğundadır; bu metod
+// yalnızca kendine bürünmeyi ve zincirleme impersonation'ı engeller.
+func (s *Service) StartImpersonation(ctx context.Context, current *session.Session, target *user.User, ip, userAgent string) (*session.Session, error) {
+	if current == nil || current.ID == 0 || target == nil {
+		return nil, ErrImpersonationNotAllowed
+	}
+	if current.IsImpersonated() {
+		return nil, ErrAlreadyImpersonating
+	}
+	if target.ID == current.UserID {
+		return nil, ErrImpersonationNotAllowed
+	}
+
+	impersonatorID := current.UserID
+	sess, err := s.openSession(ctx, &session.Session{
+		UserID:         target.ID,
+		ImpersonatorID: &impersonatorID,
+		IPAddress:      ip,
+		UserAgent:      userAgent,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.sessionRepo.Delete(ctx, current.ID); err != nil {
+		return nil, err
+	}
+	return sess, nil
+}
+
+// Bu metod, impersonation oturumunu sonlandırır ve orijinal kullanıcı için yeni bir oturum açar.
+func (s *Service) StopImpersonation(ctx context.Context, current *session.Session, ip, userAgent string) (*session.Session, error) {
+	if !current.IsImpersonated() {
+		return nil, ErrNotImpersonating
+	}
+	if _, err := s.FindUser(ctx, *current.ImpersonatorID); err != nil {
+		return nil, err
+	}
+
+	if err := s.sessionRepo.Delete(ctx, current.ID); err != nil {
+		return nil, err
+	}
+	return s.createSession(ctx, *current.ImpersonatorID, ip, userAgent)
+}
@@ -0,0 +1,316 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ferdiunal/panel.go/pkg/domain/account"
+	"github.com/ferdiunal/panel.go/pkg/domain/user"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Şifre politikası ihlalleri. Politika bu hataları PasswordPolicyError ile sarmalar;
+// hangi kuralın ihlal edildiği errors.Is ile kontrol edilebilir.
+var (
+	// ErrPasswordTooShort: Şifre PasswordPolicy.MinLength değerinden kısa olduğunda döndürülür.
+	ErrPasswordTooShort = errors.New("password is too short")
+
+	// ErrPasswordTooLong: Şifre bcrypt'in 72 byte sınırını aştığında döndürülür.
+	ErrPasswordTooLong = errors.New("password is too long")
+
+	// ErrPasswordMissingUppercase: RequireUppercase açıkken şifrede büyük harf yoksa döndürülür.
+	ErrPasswordMissingUppercase = errors.New("password must contain an uppercase letter")
+
+	// ErrPasswordMissingLowercase: RequireLowercase açıkken şifrede küçük harf yoksa döndürülür.
+	ErrPasswordMissingLowercase = errors.New("password must contain a lowercase letter")
+
+	// ErrPasswordMissingDigit: RequireDigit açıkken şifrede rakam yoksa döndürülür.
+	ErrPasswordMissingDigit = errors.New("password must contain a digit")
+
+	// ErrPasswordMissingSymbol: RequireSymbol açıkken şifrede harf/rakam dışı karakter yoksa döndürülür.
+	ErrPasswordMissingSymbol = errors.New("password must contain a symbol")
+
+	// ErrPasswordReused: Şifre, kullanıcının son HistorySize şifresinden biriyle aynıysa döndürülür.
+	ErrPasswordReused = errors.New("password was used recently")
+
+	// ErrPasswordBreached: Şifre, yapılandırılan sızıntı listesinde bulunduğunda döndürülür.
+	ErrPasswordBreached = errors.New("password appears in a known data breach")
+)
+
+const (
+	// DefaultPasswordMinLength, PasswordPolicy.MinLength tanımlı değilse kullanılan en kısa şifre uzunluğudur.
+	DefaultPasswordMinLength = 8
+
+	// maxPasswordBytes, bcrypt'in dikkate aldığı en uzun şifre uzunluğudur; fazlası sessizce kesilir.
+	maxPasswordBytes = 72
+)
+
+// PasswordPolicy, kayıt, şifre değişikliği ve yönetici tarafından yapılan şifre
+// sıfırlamalarında uygulanan kuralları tanımlar. Sıfır değer yalnızca uzunluk
+// sınırlarını uygular.
+type PasswordPolicy struct {
+	// MinLength, karakter cinsinden en kısa şifre uzunluğudur. Sıfır ise DefaultPasswordMinLength kullanılır.
+	MinLength int
+
+	// RequireUppercase, RequireLowercase, RequireDigit ve RequireSymbol, açık olan her
+	// karakter sınıfından en az bir karakter bulunmasını zorunlu kılar.
+	RequireUppercase bool
+	RequireLowercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+
+	// MaxAge, şifrenin değiştirilmesi gereken yaştır. Sıfır ise şifreler süresiz geçerlidir.
+	MaxAge time.Duration
+
+	// HistorySize, yeni şifrenin aynı olmaması gereken son şifre sayısıdır (mevcut şifre dahil).
+	// Sıfır ise geçmiş kontrol edilmez. SetPasswordHistoryStore ile bir depo bağlanmalıdır.
+	HistorySize int
+
+	// Breached, şifreyi bilinen sızıntı listelerine karşı kontrol eder. nil ise kontrol yapılmaz.
+	Breached BreachedPasswordChecker
+}
+
+// PasswordViolation, tek bir politika ihlalini çevrilebilir biçimde taşır.
+// Handler'lar Key ve Params ile i18n çevirisini, çeviri yoksa Message'ı kullanır.
+type PasswordViolation struct {
+	// Err, ihlal edilen kuralın sentinel hatasıdır (örn. ErrPasswordTooShort).
+	Err error
+
+	// Key, i18n anahtarıdır (örn. "auth.password.tooShort").
+	Key string
+
+	// Message, çeviri bulunamazsa gösterilecek İngilizce metindir.
+	Message string
+
+	// Params, çeviri şablonuna verilecek değerlerdir (örn. {"Min": 8}).
+	Params map[string]interface{}
+}
+
+func (v PasswordViolation) Error() string { return v.Message }
+
+func (v PasswordViolation) Unwrap() error { return v.Err }
+
+// PasswordPolicyError, bir şifrenin ihlal ettiği tüm kuralları taşır.
+type PasswordPolicyError struct {
+	Violations []PasswordViolation
+}
+
+func (e *PasswordPolicyError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = v.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap, errors.Is(err, ErrPasswordTooShort) gibi kontrollerin çalışmasını sağlar.
+func (e *PasswordPolicyError) Unwrap() []error {
+	errs := make([]error, len(e.Violations))
+	for i, v := range e.Violations {
+		errs[i] = v
+	}
+	return errs
+}
+
+// Validate, şifrenin uzunluk, karakter sınıfı ve sızıntı kurallarına uyup uymadığını
+// kontrol eder. Şifre geçmişi kullanıcıya bağlı olduğundan Service.ValidatePassword
+// tarafından ayrıca kontrol edilir. İhlal varsa *PasswordPolicyError döner.
+func (p PasswordPolicy) Validate(password string) error {
+	return policyError(p.violations(password))
+}
+
+func (p PasswordPolicy) violations(password string) []PasswordViolation {
+	var violations []PasswordViolation
+
+	minLength := p.MinLength
+	if minLength <= 0 {
+		minLength = DefaultPasswordMinLength
+	}
+	if utf8.RuneCountInString(password) < minLength {
+		violations = append(violations, PasswordViolation{
+			Err:     ErrPasswordTooShort,
+			Key:     "auth.password.tooShort",
+			Message: fmt.Sprintf("Password must be at least %d characters", minLength),
+			Params:  map[string]interface{}{"Min": minLength},
+		})
+	}
+	if len(password) > maxPasswordBytes {
+		violations = append(violations, PasswordViolation{
+			Err:     ErrPasswordTooLong,
+			Key:     "auth.password.tooLong",
+			Message: "Password is too long",
+		})
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case !unicode.IsLetter(r):
+			hasSymbol = true
+		}
+	}
+	if p.RequireUppercase && !hasUpper {
+		violations = append(violations, PasswordViolation{
+			Err:     ErrPasswordMissingUppercase,
+			Key:     "auth.password.uppercase",
+			Message: "Password must contain at least one uppercase letter",
+		})
+	}
+	if p.RequireLowercase && !hasLower {
+		violations = append(violations, PasswordViolation{
+			Err:     ErrPasswordMissingLowercase,
+			Key:     "auth.password.lowercase",
+			Message: "Password must contain at least one lowercase letter",
+		})
+	}
+	if p.RequireDigit && !hasDigit {
+		violations = append(violations, PasswordViolation{
+			Err:     ErrPasswordMissingDigit,
+			Key:     "auth.password.digit",
+			Message: "Password must contain at least one number",
+		})
+	}
+	if p.RequireSymbol && !hasSymbol {
+		violations = append(violations, PasswordViolation{
+			Err:     ErrPasswordMissingSymbol,
+			Key:     "auth.password.symbol",
+			Message: "Password must contain at least one symbol",
+		})
+	}
+
+	if p.Breached != nil {
+		// Liste okunamazsa kayıt ve şifre değişikliği engellenmez; hata loglanır
+		breached, err := p.Breached.IsBreached(password)
+		if err != nil {
+			log.Printf("[auth] breached password check failed: %v", err)
+		} else if breached {
+			violations = append(violations, PasswordViolation{
+				Err:     ErrPasswordBreached,
+				Key:     "auth.password.breached",
+				Message: "This password has appeared in a data breach. Choose a different password",
+			})
+		}
+	}
+	return violations
+}
+
+func policyError(violations []PasswordViolation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	return &PasswordPolicyError{Violations: violations}
+}
+
+// Bu metod, kayıt, şifre değişikliği ve şifre sıfırlamalarında uygulanan politikayı ayarlar.
+func (s *Service) SetPasswordPolicy(policy PasswordPolicy) {
+	s.passwordPolicy = policy
+}
+
+// Bu metod, etkin şifre politikasını döndürür.
+func (s *Service) PasswordPolicy() PasswordPolicy {
+	return s.passwordPolicy
+}
+
+// Bu metod, PasswordPolicy.HistorySize için kullanılan şifre geçmişi deposunu ayarlar.
+// Depo yoksa yalnızca mevcut şifrenin tekrar kullanımı engellenir.
+func (s *Service) SetPasswordHistoryStore(repo account.PasswordHistoryRepository) {
+	s.passwordHistory = repo
+}
+
+// Bu metod, şifreyi politikaya göre doğrular. userID sıfır değilse ve HistorySize
+// tanımlıysa şifrenin kullanıcının son şifrelerinden biri olmadığı da kontrol edilir.
+// Tüm ihlaller tek bir *PasswordPolicyError içinde döner.
+func (s *Service) ValidatePassword(ctx context.Context, userID uint, password string) error {
+	violations := s.passwordPolicy.violations(password)
+	if userID != 0 && s.passwordPolicy.HistorySize > 0 {
+		reused, err := s.passwordReused(ctx, userID, password)
+		if err != nil {
+			return err
+		}
+		if reused {
+			violations = append(violations, PasswordViolation{
+				Err:     ErrPasswordReused,
+				Key:     "auth.password.reused",
+				Message: fmt.Sprintf("Password cannot match any of your last %d passwords", s.passwordPolicy.HistorySize),
+				Params:  map[string]interface{}{"Count": s.passwordPolicy.HistorySize},
+			})
+		}
+	}
+	return policyError(violations)
+}
+
+// Bu metod, kullanıcının şifresinin PasswordPolicy.MaxAge süresini aşıp aşmadığını döndürür.
+// MaxAge tanımlı değilse veya kullanıcının credential hesabı yoksa false döner.
+func (s *Service) PasswordExpired(ctx context.Context, u *user.User) (bool, error) {
+	if s.passwordPolicy.MaxAge <= 0 || u == nil {
+		return false, nil
+	}
+	acc, err := s.credentialAccount(ctx, u)
+	if errors.Is(err, ErrInvalidCredentials) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	changedAt := acc.CreatedAt
+	if acc.PasswordChangedAt != nil {
+		changedAt = *acc.PasswordChangedAt
+	}
+	return time.Since(changedAt) > s.passwordPolicy.MaxAge, nil
+}
+
+// passwordReused, şifrenin mevcut şifre veya geçmişteki son HistorySize şifreden biri olup olmadığını kontrol eder.
+func (s *Service) passwordReused(ctx context.Context, userID uint, password string) (bool, error) {
+	u, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return false, ErrUserNotFound
+	}
+
+	hashes := make([]string, 0, s.passwordPolicy.HistorySize+1)
+	if acc, err := s.credentialAccount(ctx, u); err == nil && acc.Password != "" {
+		hashes = append(hashes, acc.Password)
+	}
+	if s.passwordHistory != nil {
+		entries, err := s.passwordHistory.Recent(ctx, userID, s.passwordPolicy.HistorySize)
+		if err != nil {
+			return false, err
+		}
+		for _, entry := range entries {
+			if len(hashes) > 0 && entry.Hash == hashes[0] {
+				continue
+			}
+			hashes = append(hashes, entry.Hash)
+		}
+	}
+
+	for _, hash := range hashes {
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// recordPassword, yeni şifre hash'ini geçmişe ekler ve HistorySize dışındaki eski kayıtları siler.
+func (s *Service) recordPassword(ctx context.Context, userID uint, hash string) error {
+	if s.passwordHistory == nil || s.passwordPolicy.HistorySize <= 0 {
+		return nil
+	}
+	return s.passwordHistory.Add(ctx, &account.PasswordHistory{
+		UserID:    userID,
+		Hash:      hash,
+		CreatedAt: time.Now(),
+	}, s.passwordPolicy.HistorySize)
+}
@@ -0,0 +1,195 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ferdiunal/panel.go/pkg/domain/user"
+	"github.com/ferdiunal/panel.go/pkg/domain/verification"
+	"github.com/ferdiunal/panel.go/pkg/mail"
+	"gorm.io/gorm"
+)
+
+// Şifre sıfırlama akışında oluşabilecek hata değişkenleri.
+var (
+	// ErrPasswordResetNotConfigured: SetPasswordReset çağrılmadan şifre sıfırlama istendiğinde döndürülür.
+	ErrPasswordResetNotConfigured = errors.New("password reset is not configured")
+
+	// ErrInvalidPasswordResetToken: Token bulunamadığında veya süresi dolduğunda döndürülür.
+	ErrInvalidPasswordResetToken = errors.New("invalid or expired password reset token")
+)
+
+const (
+	// DefaultPasswordResetLifetime, sıfırlama bağlantısının varsayılan geçerlilik süresidir.
+	DefaultPasswordResetLifetime = time.Hour
+
+	// DefaultPasswordResetResendInterval, aynı kullanıcıya iki sıfırlama e-postası arasındaki en kısa süredir.
+	DefaultPasswordResetResendInterval = time.Minute
+
+	// passwordResetPrefix, sıfırlama kayıtlarının verification tablosundaki identifier ön ekidir.
+	passwordResetPrefix = "password_reset:"
+)
+
+// PasswordResetOptions, şifre sıfırlama e-postalarının içeriğini ve politikasını yapılandırır.
+type PasswordResetOptions struct {
+	// From, gönderen adresidir (örn: "Panel <no-reply@example.com>").
+	From string
+
+	// AppName, e-posta konusunda ve gövdesinde kullanılır. Boşsa "Panel.go".
+	AppName string
+
+	// TokenLifetime, bağlantının geçerlilik süresidir. Sıfırsa DefaultPasswordResetLifetime.
+	TokenLifetime time.Duration
+
+	// ResendInterval, yeni bağlantı için beklenecek süredir. Sıfırsa DefaultPasswordResetResendInterval.
+	ResendInterval time.Duration
+
+	// ResetURL, e-postadaki bağlantının tabanıdır (token query parametresi olarak eklenir).
+	// Genellikle yeni şifrenin girildiği frontend sayfasıdır. Zorunludur; token içeren
+	// bağlantı istek başlıklarından (Host) üretilmez. Boşsa sıfırlama e-postası gönderilmez.
+	ResetURL string
+}
+
+// passwordResetSettings, Service üzerindeki şifre sıfırlama bağımlılıklarını tutar.
+type passwordResetSettings struct {
+	verifications verification.Repository
+	sender        mail.Sender
+	options       PasswordResetOptions
+}
+
+// Bu metod, sıfırlama token'larının saklanacağı repository'yi, e-posta göndericisini ve politikayı ayarlar.
+// Çağrılmazsa ForgotPassword ve ResetPassword ErrPasswordResetNotConfigured döner.
+//
+// Örnek:
+//
+//	authService.SetPasswordReset(orm.NewVerificationRepository(db), mail.NewFileSender("storage/mail"), auth.PasswordResetOptions{
+//	    From:     "no-reply@example.com",
+//	    ResetURL: "https://admin.example.com/reset-password",
+//	})
+func (s *Service) SetPasswordReset(verifications verification.Repository, sender mail.Sender, opts PasswordResetOptions) {
+	if opts.TokenLifetime <= 0 {
+		opts.TokenLifetime = DefaultPasswordResetLifetime
+	}
+	if opts.ResendInterval <= 0 {
+		opts.ResendInterval = DefaultPasswordResetResendInterval
+	}
+	if strings.TrimSpace(opts.AppName) == "" {
+		opts.AppName = DefaultTwoFactorIssuer
+	}
+	s.passwordReset = passwordResetSettings{
+		verifications: verifications,
+		sender:        sender,
+		options:       opts,
+	}
+}
+
+// Bu metod, şifre sıfırlama e-postası gönderilebiliyorsa true döner.
+func (s *Service) PasswordResetEnabled() bool {
+	return s.passwordReset.verifications != nil && s.passwordReset.sender != nil &&
+		strings.TrimSpace(s.passwordReset.options.ResetURL) != ""
+}
+
+// Bu metod, bağlantıdaki token'ı doğrular ve kullanıcının şifresini değiştirir.
+// Yeni şifre SetPassword ile PasswordPolicy ve şifre geçmişine göre doğrulanır; ihlalde
+// *PasswordPolicyError döner ve token kullanıcı yeniden deneyebilsin diye silinmez.
+// Başarılı sıfırlamada token ve bekleyen diğer bağlantılar silinir, kullanıcının tüm
+// oturumları sonlandırılır.
+func (s *Service) ResetPassword(ctx context.Context, token, password string) error {
+	if !s.PasswordResetEnabled() {
+		return ErrPasswordResetNotConfigured
+	}
+	if strings.TrimSpace(token) == "" {
+		return ErrInvalidPasswordResetToken
+	}
+
+	record, err := s.passwordReset.verifications.FindByToken(ctx, hashToken(token))
+	if err != nil || !strings.HasPrefix(record.Identifier, passwordResetPrefix) {
+		return ErrInvalidPasswordResetToken
+	}
+	if time.Now().After(record.ExpiresAt) {
+		_ = s.passwordReset.verifications.Delete(ctx, record.ID)
+		return ErrInvalidPasswordResetToken
+	}
+
+	userID, err := strconv.ParseUint(strings.TrimPrefix(record.Identifier, passwordResetPrefix), 10, 64)
+	if err != nil {
+		return ErrInvalidPasswordResetToken
+	}
+	if err := s.SetPassword(ctx, uint(userID), password, 0); err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			_ = s.passwordReset.verifications.Delete(ctx, record.ID)
+			return ErrInvalidPasswordResetToken
+		}
+		return err
+	}
+	return s.passwordReset.verifications.DeleteByIdentifier(ctx, record.Identifier)
+}
+
+// sendPasswordReset, kullanıcıya ResetURL tabanlı yeni bir sıfırlama bağlantısı gönderir.
+// Önceki bağlantılar geçersiz olur; ResendInterval dolmadan çağrılırsa hiçbir şey yapmaz.
+func (s *Service) sendPasswordReset(ctx context.Context, u *user.User) error {
+	opts := s.passwordReset.options
+	identifier := passwordResetPrefix + strconv.FormatUint(uint64(u.ID), 10)
+	latest, err := s.passwordReset.verifications.FindLatestByIdentifier(ctx, identifier)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if latest != nil && time.Since(latest.CreatedAt) < opts.ResendInterval {
+		return nil
+	}
+
+	token, err := randomToken(32)
+	if err != nil {
+		return err
+	}
+	if err := s.passwordReset.verifications.DeleteByIdentifier(ctx, identifier); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	record := &verification.Verification{
+		Identifier: identifier,
+		Token:      hashToken(token),
+		ExpiresAt:  now.Add(opts.TokenLifetime),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := s.passwordReset.verifications.Create(ctx, record); err != nil {
+		return err
+	}
+
+	link := emailVerificationLink(opts.ResetURL, token)
+	if err := s.passwordReset.sender.Send(ctx, passwordResetMessage(opts, u, link)); err != nil {
+		return fmt.Errorf("send password reset email: %w", err)
+	}
+	return nil
+}
+
+func passwordResetMessage(opts PasswordResetOptions, u *user.User, link string) mail.Message {
+	name := u.Name
+	if name == "" {
+		name = u.Email
+	}
+	expires := opts.TokenLifetime.Round(time.Minute).String()
+
+	text := fmt.Sprintf("Hi %s,\n\nA password reset was requested for your %s account. Open the link below to choose a new password:\n\n%s\n\n"+
+		"The link expires in %s. If you did not request a reset, you can ignore this email.\n",
+		name, opts.AppName, link, expires)
+	htmlBody := fmt.Sprintf(`<p>Hi %s,</p><p>A password reset was requested for your %s account.</p>`+
+		`<p><a href="%s">Choose a new password</a></p>`+
+		`<p>The link expires in %s. If you did not request a reset, you can ignore this email.</p>`,
+		html.EscapeString(name), html.EscapeString(opts.AppName), html.EscapeString(link), expires)
+
+	return mail.Message{
+		From:    opts.From,
+		To:      []string{u.Email},
+		Subject: opts.AppName + ": reset your password",
+		Text:    text,
+		HTML:    htmlBody,
+	}
+}
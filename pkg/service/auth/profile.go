@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/ferdiunal/panel.go/pkg/domain/user"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Profil ve şifre değişikliği sırasında döndürülen hatalar.
+var (
+	// ErrCurrentPasswordInvalid: Şifre değişikliğinde mevcut şifre yanlış girildiğinde döndürülür.
+	ErrCurrentPasswordInvalid = errors.New("current password is incorrect")
+
+	// ErrInvalidEmail: Profil güncellemesinde geçersiz bir e-posta adresi verildiğinde döndürülür.
+	ErrInvalidEmail = errors.New("invalid email address")
+
+	// ErrInvalidName: Profil güncellemesinde boş bir ad verildiğinde döndürülür.
+	ErrInvalidName = errors.New("name cannot be empty")
+)
+
+// ProfileUpdate, kullanıcının kendi profilinde değiştirebileceği alanları taşır.
+// nil alanlar değiştirilmez.
+type ProfileUpdate struct {
+	Name  *string
+	Email *string
+}
+
+// Bu metod, kullanıcının adını ve e-posta adresini günceller. E-posta adresi değiştiyse
+// ikinci dönüş değeri true olur; kullanıcı doğrulanmamış olarak işaretlenir ve bekleyen
+// doğrulama bağlantıları geçersiz olur. Yeni adrese doğrulama e-postası göndermek
+// (SendEmailVerification) çağıranın sorumluluğundadır.
+func (s *Service) UpdateProfile(ctx context.Context, userID uint, update ProfileUpdate) (*user.User, bool, error) {
+	u, err := s.FindUser(ctx, userID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if update.Name != nil {
+		name := strings.TrimSpace(*update.Name)
+		if name == "" {
+			return nil, false, ErrInvalidName
+		}
+		u.Name = name
+	}
+
+	emailChanged := false
+	if update.Email != nil {
+		email := strings.TrimSpace(*update.Email)
+		if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+			return nil, false, ErrInvalidEmail
+		}
+		if !strings.EqualFold(email, u.Email) {
+			if existing, _ := s.userRepo.FindByEmail(ctx, email); existing != nil && existing.ID != u.ID {
+				return nil, false, ErrEmailAlreadyExists
+			}
+			u.EmailVerified = false
+			emailChanged = true
+		}
+		u.Email = email
+	}
+
+	u.UpdatedAt = time.Now()
+	if err := s.userRepo.UpdateUser(ctx, u); err != nil {
+		return nil, false, err
+	}
+	if emailChanged && s.emailVerification.verifications != nil {
+		if err := s.emailVerification.verifications.DeleteByIdentifier(ctx, emailVerificationIdentifier(u.ID)); err != nil {
+			return nil, false, err
+		}
+	}
+	return u, emailChanged, nil
+}
+
+// Bu metod, kullanıcının kendi şifresini değiştirir. Mevcut şifre doğrulanır, yeni şifre
+// SetPassword tarafından PasswordPolicy ile kontrol edilir ve keepSessionID dışındaki tüm
+// oturumlar sonlandırılır.
+func (s *Service) ChangePassword(ctx context.Context, userID uint, currentPassword, newPassword string, keepSessionID uint) error {
+	u, err := s.FindUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	acc, err := s.credentialAccount(ctx, u)
+	if err != nil {
+		return ErrCurrentPasswordInvalid
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(acc.Password), []byte(currentPassword)); err != nil {
+		return ErrCurrentPasswordInvalid
+	}
+	return s.SetPassword(ctx, userID, newPassword, keepSessionID)
+}
+
+// Bu metod, kullanıcının profil resmini (User.Image) verilen URL ile değiştirir.
+// Dosyanın saklanması çağıranın sorumluluğundadır.
+func (s *Service) UpdateAvatar(ctx context.Context, userID uint, imageURL string) (*user.User, error) {
+	u, err := s.FindUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	u.Image = imageURL
+	u.UpdatedAt = time.Now()
+	if err := s.userRepo.UpdateUser(ctx, u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/ferdiunal/panel.go/pkg/domain/account"
 	"github.com/ferdiunal/panel.go/pkg/domain/session"
 	"github.com/ferdiunal/panel.go/pkg/domain/user"
-	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -55,8 +54,32 @@ type Service struct {
 
 	// accountRepo: Hesap bilgilerini (şifre, provider vb.) yönetmek için kullanılan repository
 	accountRepo account.Repository
+
+	// sessionLifetime: Yeni oturumların geçerlilik süresi (sıfırsa DefaultSessionLifetime)
+	sessionLifetime time.Duration
+
+	// sessionIdleTimeout: Hareketsiz oturumların sonlandırılacağı süre (sıfırsa kapalı)
+	sessionIdleTimeout time.Duration
+
+	// twoFactor: TOTP iki faktörlü doğrulama ayarları (SetTwoFactorStore ile etkinleşir)
+	twoFactor twoFactorSettings
+
+	// emailVerification: E-posta doğrulama ayarları (SetEmailVerification ile etkinleşir)
+	emailVerification emailVerificationSettings
+
+	// passwordReset: Şifre sıfırlama ayarları (SetPasswordReset ile etkinleşir)
+	passwordReset passwordResetSettings
+
+	// passwordPolicy: Kayıt, şifre değişikliği ve sıfırlamada uygulanan kurallar
+	passwordPolicy PasswordPolicy
+
+	// passwordHistory: Şifre tekrarını engellemek için son şifre hash'leri (SetPasswordHistoryStore ile ayarlanır)
+	passwordHistory account.PasswordHistoryRepository
 }
 
+// DefaultSessionLifetime, SetSessionLifetime çağrılmadığında oturumların geçerlilik süresidir.
+const DefaultSessionLifetime = 24 * 7 * time.Hour
+
 // Bu fonksiyon, kimlik doğrulama hizmetinin yeni bir örneğini oluşturur.
 // Dependency injection pattern kullanarak tüm repository'leri alır ve Service yapısını başlatır.
 //
@@ -89,6 +112,23 @@ func NewService(u user.Repository, s session.Repository, a account.Repository) *
 	}
 }
 
+// Bu metod, yeni oluşturulan oturumların geçerlilik süresini ayarlar.
+// Sıfır veya negatif değer verilirse DefaultSessionLifetime (7 gün) kullanılır.
+//
+// Örnek:
+//   authService.SetSessionLifetime(24 * time.Hour)
+func (s *Service) SetSessionLifetime(lifetime time.Duration) {
+	s.sessionLifetime = lifetime
+}
+
+// Bu metod, yeni oturumlar için geçerli olan süreyi döndürür.
+func (s *Service) SessionLifetime() time.Duration {
+	if s.sessionLifetime <= 0 {
+		return DefaultSessionLifetime
+	}
+	return s.sessionLifetime
+}
+
 // Bu metod, e-posta ve şifre kullanarak yeni bir kullanıcı kaydı oluşturur.
 // Kullanıcı kaydı sırasında şifre bcrypt ile hash'lenir ve veritabanına kaydedilir.
 // İlk kayıt yapan kullanıcı otomatik olarak admin rolü alır, sonraki kullanıcılar "user" rolü alır.
@@ -105,6 +145,7 @@ func NewService(u user.Repository, s session.Repository, a account.Repository) *
 //
 // Olası Hatalar:
 //   - ErrEmailAlreadyExists: E-posta adresi zaten kullanımda
+//   - *PasswordPolicyError: Şifre yapılandırılan PasswordPolicy'ye uymuyor
 //   - bcrypt hata: Şifre hash'leme başarısız
 //   - Repository hata: Veritabanı işlemi başarısız
 //
@@ -137,6 +178,11 @@ func (s *Service) RegisterEmail(ctx context.Context, name, email, password strin
 		return nil, ErrEmailAlreadyExists
 	}
 
+	// Şifre politikasını uygula (geçmiş kontrolü yeni kullanıcı için gereksizdir)
+	if err := s.ValidatePassword(ctx, 0, password); err != nil {
+		return nil, err
+	}
+
 	// Şifreyi bcrypt ile hash'le
 	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
@@ -167,13 +213,15 @@ func (s *Service) RegisterEmail(ctx context.Context, name, email, password strin
 	}
 
 	// Hesap (account) nesnesi oluştur
+	now := time.Now()
 	acc := &account.Account{
-		UserID:     u.ID,
-		ProviderID: "credential",
-		AccountID:  nil, // Credential provider'ın harici hesap ID'si yoktur
-		Password:   string(hashed),
-		CreatedAt:  time.Now(),
-		UpdatedAt:  time.Now(),
+		UserID:            u.ID,
+		ProviderID:        "credential",
+		AccountID:         nil, // Credential provider'ın harici hesap ID'si yoktur
+		Password:          string(hashed),
+		PasswordChangedAt: &now,
+		CreatedAt:         now,
+		UpdatedAt:         now,
 	}
 
 	// Hesabı veritabanına kaydet
@@ -182,6 +230,9 @@ func (s *Service) RegisterEmail(ctx context.Context, name, email, password strin
 		// Üretim ortamında transaction kullanılması önerilir
 		return nil, err
 	}
+	if err := s.recordPassword(ctx, u.ID, acc.Password); err != nil {
+		return nil, err
+	}
 
 	return u, nil
 }
@@ -228,65 +279,145 @@ func (s *Service) RegisterEmail(ctx context.Context, name, email, password strin
 //   fmt.Printf("Oturum oluşturuldu: %s (Süresi: %v)\n", session.Token, session.ExpiresAt)
 //
 // Önemli Notlar:
-//   - Oturum 7 gün (168 saat) geçerlidir
+//   - Oturum varsayılan olarak 7 gün (168 saat) geçerlidir, SetSessionLifetime ile değiştirilebilir
 //   - Şifre bcrypt.CompareHashAndPassword ile doğrulanır
 //   - Hem e-posta hem şifre hataları için aynı hata döndürülür (güvenlik)
 //   - IP adresi ve User-Agent oturum kaydında saklanır (güvenlik denetimi için)
-//   - Oturum token'ı UUID v7 formatında oluşturulur
+//   - Oturum token'ı 32 byte rastgele değerdir; veritabanında yalnızca sha256 hash'i saklanır
 //   - Credential provider'ı için hesap aranır
+//   - Kullanıcının 2FA kaydı etkinse ErrTwoFactorRequired döner; bu durumda BeginLogin kullanılmalıdır
 func (s *Service) LoginEmail(ctx context.Context, email, password string, ip, userAgent string) (*session.Session, error) {
+	result, err := s.BeginLogin(ctx, email, password, ip, userAgent)
+	if err != nil {
+		return nil, err
+	}
+	if result.Challenge != nil {
+		// İkinci faktör gerekiyorsa oturum açılmaz; çağıran taraf BeginLogin kullanmalıdır
+		return nil, ErrTwoFactorRequired
+	}
+	return result.Session, nil
+}
+
+// LoginResult, BeginLogin sonucunu tutar.
+//
+// Kullanıcının iki faktörlü doğrulaması etkinse Session nil, Challenge dolu döner;
+// oturum CompleteTwoFactorChallenge ile açılır. EnrollmentRequired, kullanıcının
+// rolü 2FA zorunlu olduğu halde henüz kayıt yapılmadığını belirtir.
+type LoginResult struct {
+	Session            *session.Session
+	Challenge          *TwoFactorChallenge
+	EnrollmentRequired bool
+}
+
+// Bu metod, e-posta ve şifreyi doğrular; kullanıcının 2FA kaydı varsa oturum yerine
+// kısa ömürlü bir doğrulama challenge'ı, yoksa yeni bir oturum döndürür.
+//
+// Olası Hatalar:
+//   - ErrInvalidCredentials: E-posta bulunamadı veya şifre yanlış
+//   - ErrEmailNotVerified: Doğrulama girişte zorunlu ve e-posta doğrulanmamış
+//   - Repository hata: Veritabanı işlemi başarısız
+func (s *Service) BeginLogin(ctx context.Context, email, password string, ip, userAgent string) (*LoginResult, error) {
+	u, err := s.authenticatePassword(ctx, email, password)
+	if err != nil {
+		return nil, err
+	}
+	if s.EmailVerificationBlocksLogin(u) {
+		return nil, ErrEmailNotVerified
+	}
+
+	tf, err := s.findTwoFactor(ctx, u.ID)
+	if err != nil {
+		return nil, err
+	}
+	if tf.IsEnabled() {
+		challenge, err := s.createTwoFactorChallenge(ctx, u)
+		if err != nil {
+			return nil, err
+		}
+		return &LoginResult{Challenge: challenge}, nil
+	}
+
+	sess, err := s.createSession(ctx, u.ID, ip, userAgent)
+	if err != nil {
+		return nil, err
+	}
+	return &LoginResult{Session: sess, EnrollmentRequired: s.TwoFactorRequiredForRole(u.Role)}, nil
+}
+
+// authenticatePassword, credential hesabının şifresini doğrular ve kullanıcıyı döndürür.
+func (s *Service) authenticatePassword(ctx context.Context, email, password string) (*user.User, error) {
 	// Kullanıcıyı e-posta adresine göre bul
 	u, err := s.userRepo.FindByEmail(ctx, email)
 	if err != nil {
 		return nil, ErrInvalidCredentials
 	}
 
-	// Credential provider'ı için hesabı bul
-	// Hesap repository'den doğrudan sorgula veya kullanıcı hesaplarından ara
-	acc, err := s.accountRepo.FindByProvider(ctx, "credential", email)
+	acc, err := s.credentialAccount(ctx, u)
 	if err != nil {
-		// Fallback: Kullanıcının tüm hesaplarını al ve credential provider'ını ara
-		accounts, err := s.accountRepo.FindByUserID(ctx, u.ID)
-		if err != nil {
-			return nil, ErrInvalidCredentials
-		}
-		found := false
-		for _, a := range accounts {
-			if a.ProviderID == "credential" {
-				acc = &a
-				found = true
-				break
-			}
-		}
-		if !found {
-			return nil, ErrInvalidCredentials
-		}
+		return nil, ErrInvalidCredentials
 	}
 
 	// Şifreyi doğrula
 	if err := bcrypt.CompareHashAndPassword([]byte(acc.Password), []byte(password)); err != nil {
 		return nil, ErrInvalidCredentials
 	}
+	return u, nil
+}
 
-	// Yeni oturum oluştur
-	sessionToken, _ := uuid.NewV7()
-	sess := &session.Session{
-		UserID:    u.ID,
-		Token:     sessionToken.String(),
-		ExpiresAt: time.Now().Add(24 * 7 * time.Hour), // 7 gün
-		IPAddress: ip,
-		UserAgent: userAgent,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+// credentialAccount, kullanıcının "credential" provider hesabını bulur.
+func (s *Service) credentialAccount(ctx context.Context, u *user.User) (*account.Account, error) {
+	// Hesap repository'den doğrudan sorgula veya kullanıcı hesaplarından ara
+	acc, err := s.accountRepo.FindByProvider(ctx, "credential", u.Email)
+	if err == nil {
+		return acc, nil
 	}
 
+	// Fallback: Kullanıcının tüm hesaplarını al ve credential provider'ını ara
+	accounts, err := s.accountRepo.FindByUserID(ctx, u.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range accounts {
+		if a.ProviderID == "credential" {
+			found := a
+			return &found, nil
+		}
+	}
+	return nil, ErrInvalidCredentials
+}
+
+// createSession, kullanıcı için yeni bir oturum oluşturur ve kullanıcı bilgisiyle birlikte döndürür.
+func (s *Service) createSession(ctx context.Context, userID uint, ip, userAgent string) (*session.Session, error) {
+	return s.openSession(ctx, &session.Session{UserID: userID, IPAddress: ip, UserAgent: userAgent})
+}
+
+// openSession, oturum için token üretir, zaman alanlarını doldurur ve kaydeder.
+// Veritabanına token'ın yalnızca sha256 hash'i yazılır; döndürülen oturumun Token alanı
+// istemciye verilecek düz token'ı içerir.
+func (s *Service) openSession(ctx context.Context, sess *session.Session) (*session.Session, error) {
+	token, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	sess.Token = hashToken(token)
+	sess.CreatedAt = now
+	sess.UpdatedAt = now
+	sess.LastSeenAt = now
+	sess.ExpiresAt = s.sessionExpiry(sess, now)
+
 	// Oturumu veritabanına kaydet
 	if err := s.sessionRepo.Create(ctx, sess); err != nil {
 		return nil, err
 	}
 
 	// Oturumu kullanıcı bilgileriyle birlikte getir
-	return s.sessionRepo.FindByToken(ctx, sess.Token)
+	stored, err := s.sessionRepo.FindByToken(ctx, sess.Token)
+	if err != nil {
+		return nil, err
+	}
+	stored.Token = token
+	return stored, nil
 }
 
 // Bu metod, verilen oturum token'ını doğrular ve geçerliliğini kontrol eder.
@@ -294,14 +425,14 @@ func (s *Service) LoginEmail(ctx context.Context, email, password string, ip, us
 //
 // Parametreler:
 //   - ctx (context.Context): İşlem için context (timeout, cancellation vb.)
-//   - token (string): Doğrulanacak oturum token'ı (UUID v7 formatında)
+//   - token (string): Doğrulanacak oturum token'ı (istemcideki düz değer)
 //
 // Dönüş Değeri:
-//   - *session.Session: Token geçerli ise oturum nesnesi
+//   - *session.Session: Token geçerli ise oturum nesnesi (Token alanı düz token'dır)
 //   - error: Token bulunamadı veya süresi dolmuş ise hata
 //
 // Olası Hatalar:
-//   - "session expired": Oturum süresi dolmuş
+//   - ErrSessionExpired ("session expired"): Mutlak süre veya idle timeout dolmuş
 //   - Repository hata: Veritabanı işlemi başarısız
 //
 // Kullanım Senaryosu:
@@ -310,7 +441,7 @@ func (s *Service) LoginEmail(ctx context.Context, email, password string, ip, us
 //   Token geçersiz ise, kullanıcı yeniden giriş yapmaya yönlendirilir.
 //
 // Örnek:
-//   session, err := authService.ValidateSession(ctx, "550e8400-e29b-41d4-a716-446655440000")
+//   session, err := authService.ValidateSession(ctx, cookieToken)
 //   if err != nil {
 //       if err.Error() == "session expired" {
 //           // Oturum süresi dolmuş, yeniden giriş gerekli
@@ -321,24 +452,23 @@ func (s *Service) LoginEmail(ctx context.Context, email, password string, ip, us
 //   fmt.Printf("Oturum geçerli, Kullanıcı ID: %s\n", session.UserID)
 //
 // Önemli Notlar:
-//   - Oturum süresi dolmuş ise, oturum veritabanında kalabilir (temizleme yapılmaz)
-//   - Token'ın tam eşleşmesi gerekir (case-sensitive)
-//   - Oturum uzatma (extension) şu anda yapılmamaktadır (TODO)
+//   - Token veritabanında sha256 hash'i ile aranır
+//   - Süresi dolmuş oturumlar SessionSweeper tarafından periyodik olarak silinir
+//   - Oturum uzatma (sliding expiry) TouchSession ile yapılır; SessionMiddleware her istekte çağırır
 //   - Middleware'de kullanılması önerilir
 func (s *Service) ValidateSession(ctx context.Context, token string) (*session.Session, error) {
-	// Token'a göre oturumu bul
-	sess, err := s.sessionRepo.FindByToken(ctx, token)
+	// Token hash'ine göre oturumu bul
+	sess, err := s.sessionRepo.FindByToken(ctx, hashToken(token))
 	if err != nil {
 		return nil, err
 	}
 
 	// Oturum süresinin dolup dolmadığını kontrol et
-	if sess.ExpiresAt.Before(time.Now()) {
-		return nil, errors.New("session expired")
+	if !sess.ExpiresAt.After(time.Now()) {
+		return nil, ErrSessionExpired
 	}
 
-	// Opsiyonel: Oturum süresini uzat?
-	// TODO: Oturum uzatma özelliği eklenebilir
+	sess.Token = token
 	return sess, nil
 }
 
@@ -347,7 +477,7 @@ func (s *Service) ValidateSession(ctx context.Context, token string) (*session.S
 //
 // Parametreler:
 //   - ctx (context.Context): İşlem için context (timeout, cancellation vb.)
-//   - token (string): İptal edilecek oturum token'ı (UUID v7 formatında)
+//   - token (string): İptal edilecek oturum token'ı (istemcideki düz değer)
 //
 // Dönüş Değeri:
 //   - error: Silme işlemi başarısız ise hata, başarılı ise nil
@@ -373,72 +503,46 @@ func (s *Service) ValidateSession(ctx context.Context, token string) (*session.S
 //   - Çıkış sonrası istemci tarafında cookie silinmelidir
 //   - Middleware'de kullanılması önerilir
 func (s *Service) Logout(ctx context.Context, token string) error {
-	return s.sessionRepo.DeleteByToken(ctx, token)
+	return s.sessionRepo.DeleteByToken(ctx, hashToken(token))
 }
 
 // Bu metod, şifresi unutulan kullanıcılar için şifre sıfırlama işlemini başlatır.
 // Güvenlik nedeniyle, e-posta adresinin veritabanında var olup olmadığı açıklanmaz.
-// Şu anda sadece başarı döndürür, gerçek şifre sıfırlama e-posta gönderimi yapılmamaktadır.
 //
 // Parametreler:
 //   - ctx (context.Context): İşlem için context (timeout, cancellation vb.)
 //   - email (string): Şifresi sıfırlanacak kullanıcının e-posta adresi
 //
 // Dönüş Değeri:
-//   - error: Hata durumunda (şu anda her zaman nil döndürür)
+//   - error: SetPasswordReset çağrılmadıysa ErrPasswordResetNotConfigured; token
+//     kaydedilemez veya e-posta gönderilemezse ilgili hata, aksi halde nil
 //
 // Kullanım Senaryosu:
 //   Kullanıcı "Şifremi Unuttum" bağlantısına tıkladığında, e-posta adresi
-//   bu metoda iletilir. Başarılı ise, kullanıcıya e-posta gönderilir.
+//   bu metoda iletilir. Hesap varsa PasswordResetOptions.ResetURL tabanlı tek
+//   kullanımlık bir bağlantı gönderilir; yeni şifre ResetPassword ile belirlenir.
 //
 // Örnek:
 //   err := authService.ForgotPassword(ctx, "john@example.com")
 //   if err != nil {
 //       return err
 //   }
-//   // Şifre sıfırlama e-postası gönderildi (veya gönderilecek)
-//   fmt.Println("Şifre sıfırlama bağlantısı e-postanıza gönderildi")
+//   fmt.Println("Hesap varsa şifre sıfırlama bağlantısı e-postanıza gönderildi")
 //
 // Önemli Notlar:
-//   - Güvenlik: E-posta adresinin var olup olmadığı açıklanmaz (timing attack önleme)
-//   - TODO: Şu anda sadece başarı döndürür, gerçek implementasyon yapılmamıştır
-//   - Üretim ortamında aşağıdaki adımlar uygulanmalıdır:
-//     1. Benzersiz şifre sıfırlama token'ı oluştur
-//     2. Token'ı veritabanında sakla (süresi sınırlı, örn: 1 saat)
-//     3. Şifre sıfırlama bağlantısı içeren e-posta gönder
-//     4. Başarı döndür
-//   - E-posta gönderimi için SMTP veya e-posta servisi (SendGrid, AWS SES vb.) gerekli
+//   - Güvenlik: E-posta adresinin var olup olmadığı açıklanmaz; bilinmeyen adreslerde nil döner
+//   - Veritabanında token'ın yalnızca sha256 hash'i saklanır (varsayılan süre: 1 saat)
+//   - Yeni bağlantı öncekileri geçersiz kılar; ResendInterval dolmadan gelen istekler sessizce atlanır
 func (s *Service) ForgotPassword(ctx context.Context, email string) error {
-	// Kullanıcının e-posta adresine göre var olup olmadığını kontrol et
-	u, err := s.userRepo.FindByEmail(ctx, email)
-	if err != nil {
-		// Güvenlik: E-posta adresinin var olup olmadığını açıklama
-		return nil
+	if !s.PasswordResetEnabled() {
+		return ErrPasswordResetNotConfigured
 	}
 
-	if u == nil {
-		// Güvenlik: E-posta adresinin var olup olmadığını açıklama
+	// Güvenlik: E-posta adresinin var olup olmadığını açıklama
+	u, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil || u == nil {
 		return nil
 	}
 
-	// TODO: Şifre sıfırlama token'ı oluştur ve e-posta gönder
-	// Üretim ortamında aşağıdaki adımlar uygulanmalıdır:
-	// 1. Benzersiz şifre sıfırlama token'ı oluştur (UUID v7 veya random string)
-	// 2. Token'ı veritabanında sakla (süresi sınırlı, örn: 1 saat)
-	// 3. Şifre sıfırlama bağlantısı içeren e-posta gönder
-	//    Örn: https://example.com/reset-password?token=<token>
-	// 4. Başarı döndür
-	//
-	// Örnek implementasyon:
-	//   resetToken := uuid.NewV7().String()
-	//   resetTokenHash := bcrypt.GenerateFromPassword([]byte(resetToken), bcrypt.DefaultCost)
-	//   resetRecord := &PasswordReset{
-	//       UserID:    u.ID,
-	//       Token:     string(resetTokenHash),
-	//       ExpiresAt: time.Now().Add(1 * time.Hour),
-	//   }
-	//   s.passwordResetRepo.Create(ctx, resetRecord)
-	//   s.emailService.SendPasswordResetEmail(u.Email, resetToken)
-
-	return nil
+	return s.sendPasswordReset(ctx, u)
 }
@@ -0,0 +1,277 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/ferdiunal/panel.go/pkg/domain/account"
+	"github.com/ferdiunal/panel.go/pkg/domain/session"
+	"github.com/ferdiunal/panel.go/pkg/domain/user"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// Oturum yönetimi sırasında döndürülen hatalar.
+var (
+	// ErrSessionExpired: Oturumun mutlak süresi veya idle timeout'u dolduğunda döndürülür.
+	ErrSessionExpired = errors.New("session expired")
+
+	// ErrSessionNotFound: Oturum bulunamadığında veya başka bir kullanıcıya ait olduğunda döndürülür.
+	ErrSessionNotFound = errors.New("session not found")
+)
+
+const (
+	// DefaultSessionSweepInterval, süresi dolmuş oturum kayıtlarının silinme sıklığıdır.
+	DefaultSessionSweepInterval = time.Hour
+
+	// sessionTouchInterval, LastSeenAt güncellemeleri arasındaki en kısa süredir.
+	// Her istekte veritabanına yazmamak için kullanılır.
+	sessionTouchInterval = time.Minute
+)
+
+// Bu metod, oturumun hareketsiz kalabileceği en uzun süreyi ayarlar.
+// Her istekte oturumun bitiş zamanı now+timeout olarak ileri kaydırılır, ancak
+// oluşturulma zamanı + SessionLifetime değerini (mutlak süre) hiçbir zaman aşmaz.
+// Sıfır veya negatif değer idle timeout'u kapatır; oturumlar yalnızca mutlak sürede biter.
+func (s *Service) SetSessionIdleTimeout(timeout time.Duration) {
+	s.sessionIdleTimeout = timeout
+}
+
+// Bu metod, etkin idle timeout değerini döndürür (kapalıysa sıfır).
+func (s *Service) SessionIdleTimeout() time.Duration {
+	if s.sessionIdleTimeout <= 0 {
+		return 0
+	}
+	return s.sessionIdleTimeout
+}
+
+// sessionExpiry, mutlak süre ile idle timeout'tan hangisi önce doluyorsa onu döndürür.
+// Impersonation oturumlarının mutlak süresi ayrıca ImpersonationLifetime ile sınırlanır.
+func (s *Service) sessionExpiry(sess *session.Session, lastSeenAt time.Time) time.Time {
+	lifetime := s.SessionLifetime()
+	if sess.IsImpersonated() && ImpersonationLifetime < lifetime {
+		lifetime = ImpersonationLifetime
+	}
+	expiresAt := sess.CreatedAt.Add(lifetime)
+	if idle := s.SessionIdleTimeout(); idle > 0 {
+		if idleExpiry := lastSeenAt.Add(idle); idleExpiry.Before(expiresAt) {
+			return idleExpiry
+		}
+	}
+	return expiresAt
+}
+
+// Bu metod, doğrulanmış bir oturumun son görülme zamanını günceller ve idle timeout
+// etkinse bitiş zamanını ileri kaydırır (sliding expiry). Güncellemeler dakikada en
+// fazla bir kez yapılır. Bitiş zamanı değiştiyse true döner; SessionMiddleware bu
+// durumda cookie'nin Expires değerini yeniler.
+func (s *Service) TouchSession(ctx context.Context, sess *session.Session) (bool, error) {
+	if sess == nil || sess.ID == 0 {
+		return false, nil
+	}
+
+	interval := sessionTouchInterval
+	if idle := s.SessionIdleTimeout(); idle > 0 && idle/2 < interval {
+		interval = idle / 2
+	}
+	now := time.Now()
+	if now.Sub(sess.LastSeenAt) < interval {
+		return false, nil
+	}
+
+	expiresAt := s.sessionExpiry(sess, now)
+	if err := s.sessionRepo.Touch(ctx, sess.ID, now, expiresAt); err != nil {
+		return false, err
+	}
+	renewed := !expiresAt.Equal(sess.ExpiresAt)
+	sess.LastSeenAt = now
+	sess.UpdatedAt = now
+	sess.ExpiresAt = expiresAt
+	return renewed, nil
+}
+
+// Bu metod, kullanıcının süresi dolmamış oturumlarını son görülme zamanına göre listeler.
+func (s *Service) ListSessions(ctx context.Context, userID uint) ([]session.Session, error) {
+	sessions, err := s.sessionRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	active := sessions[:0]
+	for _, sess := range sessions {
+		if sess.ExpiresAt.After(now) {
+			active = append(active, sess)
+		}
+	}
+	return active, nil
+}
+
+// Bu metod, kullanıcının tek bir oturumunu sonlandırır (uzaktan çıkış).
+// Oturum başka bir kullanıcıya aitse ErrSessionNotFound döner.
+func (s *Service) RevokeSession(ctx context.Context, userID, sessionID uint) error {
+	sess, err := s.sessionRepo.FindByID(ctx, sessionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrSessionNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if sess.UserID != userID {
+		return ErrSessionNotFound
+	}
+	return s.sessionRepo.Delete(ctx, sess.ID)
+}
+
+// Bu metod, keepSessionID dışındaki tüm kullanıcı oturumlarını sonlandırır.
+// keepSessionID sıfırsa kullanıcının tüm oturumları sonlandırılır.
+func (s *Service) RevokeOtherSessions(ctx context.Context, userID, keepSessionID uint) error {
+	return s.sessionRepo.DeleteByUserIDExcept(ctx, userID, keepSessionID)
+}
+
+// Bu metod, kullanıcının credential hesabının şifresini değiştirir ve keepSessionID
+// dışındaki tüm oturumlarını sonlandırır. Yönetici tarafından yapılan değişikliklerde
+// keepSessionID sıfır verilir ve kullanıcı tüm cihazlardan çıkarılır.
+// Yeni şifre PasswordPolicy ve şifre geçmişiyle doğrulanır; ihlalde *PasswordPolicyError döner.
+func (s *Service) SetPassword(ctx context.Context, userID uint, password string, keepSessionID uint) error {
+	u, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+	acc, err := s.credentialAccount(ctx, u)
+	if err != nil {
+		return err
+	}
+	if err := s.ValidatePassword(ctx, userID, password); err != nil {
+		return err
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	acc.Password = string(hashed)
+	acc.PasswordChangedAt = &now
+	acc.UpdatedAt = now
+	if err := s.accountRepo.Update(ctx, acc); err != nil {
+		return err
+	}
+	if err := s.recordPassword(ctx, userID, acc.Password); err != nil {
+		return err
+	}
+	return s.RevokeOtherSessions(ctx, userID, keepSessionID)
+}
+
+// PasswordStores, SetPasswordWith ile yapılan şifre değişikliğinin okuyup yazdığı
+// repository'lerdir. Boş bırakılan alanlar için servisin kendi repository'leri kullanılır.
+type PasswordStores struct {
+	Users    user.Repository
+	Sessions session.Repository
+	Accounts account.Repository
+	History  account.PasswordHistoryRepository
+}
+
+// Bu metod, şifreyi SetPassword ile aynı kurallarla stores üzerinden değiştirir.
+// Transaction'a bağlı repository'ler verildiğinde hesap, şifre geçmişi ve oturumların
+// sonlandırılması çağıranın transaction'ına dahil olur; transaction geri alınırsa
+// şifre de değişmemiş olur.
+func (s *Service) SetPasswordWith(ctx context.Context, stores PasswordStores, userID uint, password string, keepSessionID uint) error {
+	scoped := *s
+	if stores.Users != nil {
+		scoped.userRepo = stores.Users
+	}
+	if stores.Sessions != nil {
+		scoped.sessionRepo = stores.Sessions
+	}
+	if stores.Accounts != nil {
+		scoped.accountRepo = stores.Accounts
+	}
+	if stores.History != nil {
+		scoped.passwordHistory = stores.History
+	}
+	return scoped.SetPassword(ctx, userID, password, keepSessionID)
+}
+
+// Bu metod, token'ı düz metin saklanmış eski oturum kayıtlarının token'ını sha256
+// hash'iyle değiştirir ve güncellenen kayıt sayısını döndürür. Token'lar hash'lenmeden
+// önce açılan oturumlar böylece ValidateSession ile doğrulanmaya devam eder; kullanıcıların
+// yeniden giriş yapması gerekmez. Panel başlangıçta bir kez çağırır; hash'lenmiş kayıtlar
+// yeniden işlenmez.
+func (s *Service) HashLegacySessionTokens(ctx context.Context) (int64, error) {
+	sessions, err := s.sessionRepo.FindUnhashed(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var migrated int64
+	for _, sess := range sessions {
+		if err := s.sessionRepo.UpdateToken(ctx, sess.ID, hashToken(sess.Token)); err != nil {
+			return migrated, err
+		}
+		migrated++
+	}
+	return migrated, nil
+}
+
+// Bu metod, süresi dolmuş oturum kayıtlarını siler ve silinen kayıt sayısını döndürür.
+func (s *Service) PruneExpiredSessions(ctx context.Context) (int64, error) {
+	return s.sessionRepo.DeleteExpired(ctx, time.Now())
+}
+
+// SessionSweeper, süresi dolmuş oturum kayıtlarını arka planda periyodik olarak siler.
+// Panel, New içinde bir sweeper başlatır ve Close/Start dönüşünde durdurur.
+type SessionSweeper struct {
+	service   *Service
+	interval  time.Duration
+	stopCh    chan struct{}
+	doneCh    chan struct{}
+	closeOnce sync.Once
+}
+
+// NewSessionSweeper, verilen aralıkla çalışan bir temizleyici başlatır.
+// Sıfır veya negatif aralık için DefaultSessionSweepInterval kullanılır.
+func NewSessionSweeper(service *Service, interval time.Duration) *SessionSweeper {
+	if interval <= 0 {
+		interval = DefaultSessionSweepInterval
+	}
+	sw := &SessionSweeper{
+		service:  service,
+		interval: interval,
+		stopCh:   make(chan struct{}),
+		doneCh:   make(chan struct{}),
+	}
+	go sw.run()
+	return sw
+}
+
+func (sw *SessionSweeper) run() {
+	defer close(sw.doneCh)
+
+	ticker := time.NewTicker(sw.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := sw.service.PruneExpiredSessions(context.Background()); err != nil {
+				log.Printf("[auth] expired session sweep failed: %v", err)
+			}
+		case <-sw.stopCh:
+			return
+		}
+	}
+}
+
+// Close, arka plandaki temizleme goroutine'ini durdurur ve çıkmasını bekler.
+// Birden fazla kez çağrılabilir; nil sweeper için bir şey yapmaz.
+func (sw *SessionSweeper) Close() {
+	if sw == nil {
+		return
+	}
+	sw.closeOnce.Do(func() {
+		close(sw.stopCh)
+	})
+	<-sw.doneCh
+}
@@ -0,0 +1,534 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ferdiunal/panel.go/pkg/domain/session"
+	"github.com/ferdiunal/panel.go/pkg/domain/twofactor"
+	"github.com/ferdiunal/panel.go/pkg/domain/user"
+	"github.com/ferdiunal/panel.go/pkg/domain/verification"
+	"github.com/ferdiunal/panel.go/shared/encrypt"
+	"github.com/ferdiunal/panel.go/shared/totp"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// İki faktörlü doğrulama (TOTP) sırasında oluşabilecek hata değişkenleri.
+var (
+	// ErrTwoFactorRequired: Kullanıcının 2FA kaydı etkin olduğu için LoginEmail oturum açmadığında döndürülür.
+	ErrTwoFactorRequired = errors.New("two-factor authentication required")
+
+	// ErrTwoFactorNotConfigured: SetTwoFactorStore çağrılmadan 2FA işlemi yapıldığında döndürülür.
+	ErrTwoFactorNotConfigured = errors.New("two-factor authentication is not configured")
+
+	// ErrTwoFactorKeyringMissing: TOTP secret'ını şifreleyecek keyring tanımlı olmadığında döndürülür.
+	ErrTwoFactorKeyringMissing = errors.New("two-factor authentication requires a field encryption keyring")
+
+	// ErrTwoFactorAlreadyEnabled: Etkin bir kayıt varken yeniden kayıt başlatıldığında döndürülür.
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+
+	// ErrTwoFactorNotEnabled: Kayıt olmadan doğrulama, kapatma veya kurtarma kodu işlemi yapıldığında döndürülür.
+	ErrTwoFactorNotEnabled = errors.New("two-factor authentication is not enabled")
+
+	// ErrTwoFactorEnforced: Rolü 2FA zorunlu olan kullanıcı 2FA'yı kapatmaya çalıştığında döndürülür.
+	ErrTwoFactorEnforced = errors.New("two-factor authentication is required for this role")
+
+	// ErrInvalidTwoFactorCode: TOTP veya kurtarma kodu geçersiz ya da daha önce kullanılmışsa döndürülür.
+	ErrInvalidTwoFactorCode = errors.New("invalid two-factor code")
+
+	// ErrInvalidTwoFactorChallenge: Challenge token'ı bulunamadığında veya süresi dolduğunda döndürülür.
+	ErrInvalidTwoFactorChallenge = errors.New("invalid or expired two-factor challenge")
+)
+
+const (
+	// DefaultTwoFactorIssuer, otpauth URI'sinde SetTwoFactorIssuer çağrılmadığında kullanılan issuer'dır.
+	DefaultTwoFactorIssuer = "Panel.go"
+
+	// TwoFactorChallengeLifetime, şifre doğrulandıktan sonra ikinci faktör için verilen süredir.
+	TwoFactorChallengeLifetime = 5 * time.Minute
+
+	// TwoFactorChallengeMaxAttempts, bir challenge silinmeden önce kabul edilen hatalı kod sayısıdır.
+	// Hesap kilitleme kapalı olsa bile tek bir challenge token'ı ile kod denemesini sınırlar.
+	TwoFactorChallengeMaxAttempts = 5
+
+	// RecoveryCodeCount, kayıt onayında ve yenilemede üretilen kurtarma kodu sayısıdır.
+	RecoveryCodeCount = 10
+
+	// twoFactorSkew, saat kaymasına karşı kabul edilen komşu TOTP adımı sayısıdır.
+	twoFactorSkew = 1
+
+	// twoFactorChallengePrefix, challenge kayıtlarının verification tablosundaki identifier ön ekidir.
+	twoFactorChallengePrefix = "two_factor_challenge:"
+)
+
+// twoFactorSettings, Service üzerindeki 2FA bağımlılıklarını ve politikasını tutar.
+type twoFactorSettings struct {
+	repo          twofactor.Repository
+	verifications verification.Repository
+	keyring       *encrypt.Keyring
+	issuer        string
+	requiredRoles map[string]struct{}
+}
+
+// TwoFactorChallenge, şifre doğrulaması ile oturum açılması arasındaki bekleyen adımdır.
+// Token yalnızca istemciye döner; veritabanında sha256 hash'i saklanır.
+type TwoFactorChallenge struct {
+	Token     string
+	UserID    uint
+	Email     string
+	ExpiresAt time.Time
+
+	// recordID, hatalı denemelerin sayıldığı verification kaydıdır.
+	recordID uint
+}
+
+// TwoFactorEnrollment, kayıt başlatıldığında authenticator uygulamasına girilecek bilgileri tutar.
+type TwoFactorEnrollment struct {
+	Secret     string
+	OTPAuthURI string
+}
+
+// TwoFactorStatus, kullanıcının 2FA durumunu özetler.
+type TwoFactorStatus struct {
+	Enabled                bool
+	Pending                bool
+	Required               bool
+	RecoveryCodesRemaining int64
+}
+
+// Bu metod, 2FA kayıtlarının ve challenge'ların saklanacağı repository'leri ayarlar.
+// Çağrılmazsa 2FA devre dışıdır ve LoginEmail doğrudan oturum açar.
+//
+// Örnek:
+//
+//	authService.SetTwoFactorStore(orm.NewTwoFactorRepository(db), orm.NewVerificationRepository(db))
+func (s *Service) SetTwoFactorStore(repo twofactor.Repository, verifications verification.Repository) {
+	s.twoFactor.repo = repo
+	s.twoFactor.verifications = verifications
+}
+
+// Bu metod, TOTP secret'larını şifrelemek için kullanılacak keyring'i ayarlar.
+// Panel, Config.FieldEncryption anahtarlarından oluşturduğu keyring'i başlangıçta verir.
+// Ayarlanmazsa kayıt ve doğrulama ErrTwoFactorKeyringMissing döner.
+func (s *Service) SetTwoFactorKeyring(keyring *encrypt.Keyring) {
+	s.twoFactor.keyring = keyring
+}
+
+// Bu metod, authenticator uygulamasında görünecek issuer adını ayarlar.
+func (s *Service) SetTwoFactorIssuer(issuer string) {
+	s.twoFactor.issuer = strings.TrimSpace(issuer)
+}
+
+// Bu metod, 2FA kaydının zorunlu olduğu rolleri ayarlar.
+// Bu rollerdeki kullanıcılar kayıt tamamlanana kadar yalnızca 2FA endpoint'lerine erişebilir
+// ve 2FA'yı kapatamaz.
+func (s *Service) SetTwoFactorRequiredRoles(roles []string) {
+	s.twoFactor.requiredRoles = nil
+	for _, role := range roles {
+		if role = strings.TrimSpace(role); role != "" {
+			if s.twoFactor.requiredRoles == nil {
+				s.twoFactor.requiredRoles = make(map[string]struct{})
+			}
+			s.twoFactor.requiredRoles[role] = struct{}{}
+		}
+	}
+}
+
+// Bu metod, verilen rol için 2FA'nın zorunlu olup olmadığını döndürür.
+func (s *Service) TwoFactorRequiredForRole(role string) bool {
+	if s.twoFactor.repo == nil {
+		return false
+	}
+	_, ok := s.twoFactor.requiredRoles[role]
+	return ok
+}
+
+// Bu metod, kullanıcının rolü 2FA gerektirdiği halde kaydı tamamlanmamışsa true döner.
+// SessionMiddleware bu kontrolle 2FA endpoint'leri dışındaki istekleri reddeder.
+func (s *Service) TwoFactorEnrollmentRequired(ctx context.Context, u *user.User) (bool, error) {
+	if u == nil || !s.TwoFactorRequiredForRole(u.Role) {
+		return false, nil
+	}
+	tf, err := s.findTwoFactor(ctx, u.ID)
+	if err != nil {
+		return false, err
+	}
+	return !tf.IsEnabled(), nil
+}
+
+// Bu metod, kullanıcının 2FA durumunu döndürür.
+func (s *Service) TwoFactorStatus(ctx context.Context, u *user.User) (*TwoFactorStatus, error) {
+	if s.twoFactor.repo == nil {
+		return nil, ErrTwoFactorNotConfigured
+	}
+	tf, err := s.findTwoFactor(ctx, u.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &TwoFactorStatus{
+		Enabled:  tf.IsEnabled(),
+		Pending:  tf != nil && !tf.IsEnabled(),
+		Required: s.TwoFactorRequiredForRole(u.Role),
+	}
+	if status.Enabled {
+		if status.RecoveryCodesRemaining, err = s.twoFactor.repo.CountUnusedRecoveryCodes(ctx, u.ID); err != nil {
+			return nil, err
+		}
+	}
+	return status, nil
+}
+
+// Bu metod, yeni bir TOTP secret'ı üretir ve onaylanmamış (pending) kayıt olarak saklar.
+// Daha önce onaylanmamış bir kayıt varsa secret yenilenir. Secret, otpauth URI ile birlikte
+// yalnızca bu çağrıda düz metin olarak döner.
+//
+// Olası Hatalar:
+//   - ErrTwoFactorAlreadyEnabled: Kullanıcının onaylanmış kaydı var
+//   - ErrTwoFactorKeyringMissing: Secret'ı şifreleyecek keyring yok
+func (s *Service) StartTwoFactorEnrollment(ctx context.Context, u *user.User) (*TwoFactorEnrollment, error) {
+	if s.twoFactor.repo == nil {
+		return nil, ErrTwoFactorNotConfigured
+	}
+	keyring := s.twoFactorKeyring()
+	if keyring == nil {
+		return nil, ErrTwoFactorKeyringMissing
+	}
+
+	tf, err := s.findTwoFactor(ctx, u.ID)
+	if err != nil {
+		return nil, err
+	}
+	if tf.IsEnabled() {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+	if tf == nil {
+		tf = &twofactor.TwoFactor{UserID: u.ID, CreatedAt: time.Now()}
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := keyring.Encrypt(secret)
+	if err != nil {
+		return nil, err
+	}
+	tf.Secret = encrypted
+	tf.LastUsedStep = 0
+	tf.UpdatedAt = time.Now()
+	if err := s.twoFactor.repo.Save(ctx, tf); err != nil {
+		return nil, err
+	}
+
+	return &TwoFactorEnrollment{
+		Secret:     secret,
+		OTPAuthURI: totp.KeyURI(s.twoFactorIssuer(), u.Email, secret),
+	}, nil
+}
+
+// Bu metod, authenticator uygulamasından gelen kodla bekleyen kaydı onaylar ve
+// tek kullanımlık kurtarma kodlarını üretir. Kodlar yalnızca bu çağrıda döner.
+func (s *Service) ConfirmTwoFactorEnrollment(ctx context.Context, userID uint, code string) ([]string, error) {
+	if s.twoFactor.repo == nil {
+		return nil, ErrTwoFactorNotConfigured
+	}
+	tf, err := s.findTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if tf == nil {
+		return nil, ErrTwoFactorNotEnabled
+	}
+	if tf.IsEnabled() {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	if err := s.verifyTOTP(ctx, tf, code); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	tf.ConfirmedAt = &now
+	tf.UpdatedAt = now
+	if err := s.twoFactor.repo.Save(ctx, tf); err != nil {
+		return nil, err
+	}
+	return s.replaceRecoveryCodes(ctx, userID)
+}
+
+// Bu metod, geçerli bir TOTP koduyla kurtarma kodlarını yeniler; eski kodlar geçersiz olur.
+func (s *Service) RegenerateRecoveryCodes(ctx context.Context, userID uint, code string) ([]string, error) {
+	tf, err := s.enabledTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.verifyTOTP(ctx, tf, code); err != nil {
+		return nil, err
+	}
+	return s.replaceRecoveryCodes(ctx, userID)
+}
+
+// Bu metod, mevcut şifre doğrulanarak kullanıcının 2FA kaydını ve kurtarma kodlarını siler.
+// Rolü 2FA zorunlu olan kullanıcılar için ErrTwoFactorEnforced döner.
+func (s *Service) DisableTwoFactor(ctx context.Context, u *user.User, password string) error {
+	if s.twoFactor.repo == nil {
+		return ErrTwoFactorNotConfigured
+	}
+	if s.TwoFactorRequiredForRole(u.Role) {
+		return ErrTwoFactorEnforced
+	}
+
+	acc, err := s.credentialAccount(ctx, u)
+	if err != nil {
+		return ErrInvalidCredentials
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(acc.Password), []byte(password)); err != nil {
+		return ErrInvalidCredentials
+	}
+
+	return s.twoFactor.repo.DeleteByUserID(ctx, u.ID)
+}
+
+// Bu metod, challenge token'ına ait bekleyen girişi döndürür.
+// Handler, hesap kilitleme kontrolünü kod doğrulanmadan önce Email ile yapar.
+func (s *Service) FindTwoFactorChallenge(ctx context.Context, token string) (*TwoFactorChallenge, error) {
+	if s.twoFactor.repo == nil || s.twoFactor.verifications == nil {
+		return nil, ErrTwoFactorNotConfigured
+	}
+	if strings.TrimSpace(token) == "" {
+		return nil, ErrInvalidTwoFactorChallenge
+	}
+
+	record, err := s.twoFactor.verifications.FindByToken(ctx, hashToken(token))
+	if err != nil || !strings.HasPrefix(record.Identifier, twoFactorChallengePrefix) {
+		return nil, ErrInvalidTwoFactorChallenge
+	}
+	if record.ExpiresAt.Before(time.Now()) || record.Attempts >= TwoFactorChallengeMaxAttempts {
+		_ = s.twoFactor.verifications.Delete(ctx, record.ID)
+		return nil, ErrInvalidTwoFactorChallenge
+	}
+
+	userID, err := strconv.ParseUint(strings.TrimPrefix(record.Identifier, twoFactorChallengePrefix), 10, 64)
+	if err != nil {
+		return nil, ErrInvalidTwoFactorChallenge
+	}
+	u, err := s.userRepo.FindByID(ctx, uint(userID))
+	if err != nil {
+		return nil, ErrInvalidTwoFactorChallenge
+	}
+
+	return &TwoFactorChallenge{Token: token, UserID: u.ID, Email: u.Email, ExpiresAt: record.ExpiresAt, recordID: record.ID}, nil
+}
+
+// Bu metod, challenge için TOTP veya kurtarma kodunu doğrular ve oturumu açar.
+// Challenge tek kullanımlıktır; başarılı doğrulamadan sonra silinir. Hatalı kodlar
+// challenge kaydında sayılır ve TwoFactorChallengeMaxAttempts'e ulaşıldığında
+// challenge silinir; kullanıcı şifresiyle yeniden giriş yapmalıdır.
+//
+// Olası Hatalar:
+//   - ErrInvalidTwoFactorCode: Kod geçersiz veya daha önce kullanılmış
+//   - ErrInvalidTwoFactorChallenge: Challenge bulunamadı veya süresi dolmuş
+func (s *Service) CompleteTwoFactorChallenge(ctx context.Context, challenge *TwoFactorChallenge, code, ip, userAgent string) (*session.Session, error) {
+	if challenge == nil {
+		return nil, ErrInvalidTwoFactorChallenge
+	}
+	tf, err := s.enabledTwoFactor(ctx, challenge.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.verifyTOTP(ctx, tf, code); err != nil {
+		if !errors.Is(err, ErrInvalidTwoFactorCode) {
+			return nil, err
+		}
+		used, useErr := s.twoFactor.repo.UseRecoveryCode(ctx, challenge.UserID, hashRecoveryCode(code))
+		if useErr != nil {
+			return nil, useErr
+		}
+		if !used {
+			return nil, s.recordTwoFactorChallengeFailure(ctx, challenge)
+		}
+	}
+
+	if err := s.twoFactor.verifications.DeleteByIdentifier(ctx, twoFactorChallengeIdentifier(challenge.UserID)); err != nil {
+		return nil, err
+	}
+	return s.createSession(ctx, challenge.UserID, ip, userAgent)
+}
+
+// recordTwoFactorChallengeFailure, hatalı denemeyi challenge kaydına yazar ve
+// deneme sınırına ulaşıldıysa challenge'ı siler. Sayaç yazılamazsa veritabanı
+// hatası, challenge zaten silinmişse ErrInvalidTwoFactorChallenge döner.
+func (s *Service) recordTwoFactorChallengeFailure(ctx context.Context, challenge *TwoFactorChallenge) error {
+	attempts, err := s.twoFactor.verifications.IncrementAttempts(ctx, challenge.recordID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrInvalidTwoFactorChallenge
+	}
+	if err != nil {
+		return err
+	}
+	if attempts >= TwoFactorChallengeMaxAttempts {
+		if err := s.twoFactor.verifications.Delete(ctx, challenge.recordID); err != nil {
+			return err
+		}
+	}
+	return ErrInvalidTwoFactorCode
+}
+
+// createTwoFactorChallenge, kullanıcının önceki challenge'larını silip yenisini oluşturur.
+func (s *Service) createTwoFactorChallenge(ctx context.Context, u *user.User) (*TwoFactorChallenge, error) {
+	if s.twoFactor.verifications == nil {
+		return nil, ErrTwoFactorNotConfigured
+	}
+
+	token, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	identifier := twoFactorChallengeIdentifier(u.ID)
+	if err := s.twoFactor.verifications.DeleteByIdentifier(ctx, identifier); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	record := &verification.Verification{
+		Identifier: identifier,
+		Token:      hashToken(token),
+		ExpiresAt:  now.Add(TwoFactorChallengeLifetime),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := s.twoFactor.verifications.Create(ctx, record); err != nil {
+		return nil, err
+	}
+
+	return &TwoFactorChallenge{Token: token, UserID: u.ID, Email: u.Email, ExpiresAt: record.ExpiresAt, recordID: record.ID}, nil
+}
+
+// verifyTOTP, kodu kayıtlı secret'a karşı doğrular ve aynı zaman adımının yeniden kullanılmasını engeller.
+func (s *Service) verifyTOTP(ctx context.Context, tf *twofactor.TwoFactor, code string) error {
+	keyring := s.twoFactorKeyring()
+	if keyring == nil {
+		return ErrTwoFactorKeyringMissing
+	}
+	secret, err := keyring.Decrypt(tf.Secret)
+	if err != nil {
+		return fmt.Errorf("two-factor secret: %w", err)
+	}
+
+	step, ok := totp.Validate(secret, code, time.Now(), twoFactorSkew)
+	if !ok {
+		return ErrInvalidTwoFactorCode
+	}
+	fresh, err := s.twoFactor.repo.MarkStepUsed(ctx, tf.ID, step)
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		return ErrInvalidTwoFactorCode
+	}
+	tf.LastUsedStep = step
+	return nil
+}
+
+func (s *Service) replaceRecoveryCodes(ctx context.Context, userID uint) ([]string, error) {
+	codes := make([]string, 0, RecoveryCodeCount)
+	hashes := make([]string, 0, RecoveryCodeCount)
+	for i := 0; i < RecoveryCodeCount; i++ {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+	if err := s.twoFactor.repo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// findTwoFactor, kullanıcının kaydını döndürür; kayıt yoksa veya 2FA yapılandırılmamışsa nil döner.
+func (s *Service) findTwoFactor(ctx context.Context, userID uint) (*twofactor.TwoFactor, error) {
+	if s.twoFactor.repo == nil {
+		return nil, nil
+	}
+	tf, err := s.twoFactor.repo.FindByUserID(ctx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return tf, err
+}
+
+func (s *Service) enabledTwoFactor(ctx context.Context, userID uint) (*twofactor.TwoFactor, error) {
+	if s.twoFactor.repo == nil {
+		return nil, ErrTwoFactorNotConfigured
+	}
+	tf, err := s.findTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !tf.IsEnabled() {
+		return nil, ErrTwoFactorNotEnabled
+	}
+	return tf, nil
+}
+
+func (s *Service) twoFactorKeyring() *encrypt.Keyring {
+	return s.twoFactor.keyring
+}
+
+func (s *Service) twoFactorIssuer() string {
+	if s.twoFactor.issuer != "" {
+		return s.twoFactor.issuer
+	}
+	return DefaultTwoFactorIssuer
+}
+
+func twoFactorChallengeIdentifier(userID uint) string {
+	return twoFactorChallengePrefix + strconv.FormatUint(uint64(userID), 10)
+}
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// generateRecoveryCode, "xxxxx-xxxxx" biçiminde 50 bit entropili bir kurtarma kodu üretir.
+func generateRecoveryCode() (string, error) {
+	buf := make([]byte, 10)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	raw := strings.ToLower(recoveryCodeEncoding.EncodeToString(buf))[:10]
+	return raw[:5] + "-" + raw[5:], nil
+}
+
+// hashRecoveryCode, kullanıcı girdisini normalize edip (büyük/küçük harf, tire, boşluk) hash'ler.
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(code)))
+	return hashToken(normalized)
+}
+
+// hashToken, veritabanında saklanan token ve kodların sha256 hex özetini döndürür.
+func hashToken(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// randomToken, size byte'lık rastgele değerin hex gösterimini döndürür.
+func randomToken(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
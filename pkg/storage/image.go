@@ -0,0 +1,325 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"path"
+	"strings"
+)
+
+// DefaultImageQuality, kalite belirtilmeyen JPEG dönüşümlerinde kullanılır.
+const DefaultImageQuality = 82
+
+// ErrUnsupportedImage, dönüştürülemeyen bir resim biçimi istendiğinde döner.
+var ErrUnsupportedImage = errors.New("storage: unsupported image format")
+
+// imageFormats, dönüşüm çıktısı olarak desteklenen biçimlerin uzantılarıdır.
+var imageFormats = map[string]string{
+	"jpeg": ".jpg",
+	"png":  ".png",
+	"gif":  ".gif",
+	"webp": ".webp",
+}
+
+// convertibleExtensions, saf Go çözücülerle okunabilen kaynak uzantılarıdır.
+var convertibleExtensions = map[string]string{
+	".jpg":  "jpeg",
+	".jpeg": "jpeg",
+	".png":  "png",
+	".gif":  "gif",
+}
+
+// ImageOptions, bir resim dönüşümünü tanımlar.
+//
+// Width ve Height kutunun boyutlarıdır; biri sıfırsa oran korunarak diğerinden
+// hesaplanır, ikisi de sıfırsa boyut değişmez. Crop false iken resim kutunun
+// içine sığdırılır, true iken kutuyu dolduracak şekilde ölçeklenip ortadan
+// kırpılır. Resimler hiçbir zaman büyütülmez. Format boşsa kaynak biçim korunur.
+type ImageOptions struct {
+	Width   int    `json:"width,omitempty"`
+	Height  int    `json:"height,omitempty"`
+	Crop    bool   `json:"crop,omitempty"`
+	Format  string `json:"format,omitempty"`
+	Quality int    `json:"quality,omitempty"`
+}
+
+// CanConvertImage, dosyanın uzantısına göre dönüştürülebilir bir resim olup
+// olmadığını döner.
+func CanConvertImage(filename string) bool {
+	_, ok := convertibleExtensions[strings.ToLower(path.Ext(filename))]
+	return ok
+}
+
+// IsImageFormat, biçimin dönüşüm çıktısı olarak desteklenip desteklenmediğini döner.
+func IsImageFormat(format string) bool {
+	_, ok := imageFormats[normalizeImageFormat(format)]
+	return ok
+}
+
+// ConversionPath, orijinal dosyanın yanında saklanan dönüşümün yolunu döner:
+// "products/abc.jpg" için "thumb" ve "webp" → "products/abc-thumb.webp".
+func ConversionPath(original, name, format string) string {
+	ext := path.Ext(original)
+	base := strings.TrimSuffix(original, ext)
+	if formatExt, ok := imageFormats[normalizeImageFormat(format)]; ok {
+		ext = formatExt
+	}
+	return base + "-" + name + ext
+}
+
+// ConvertImage, JPEG, PNG veya GIF resmi opts'a göre yeniden boyutlandırır ve
+// istenen biçimde kodlar. JPEG'lerin EXIF yönlendirmesi uygulanır; çıktı meta
+// veri içermez. Kodlanmış veri ve içerik tipini döner.
+func ConvertImage(data []byte, opts ImageOptions) ([]byte, string, error) {
+	src, source, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		if errors.Is(err, image.ErrFormat) {
+			return nil, "", ErrUnsupportedImage
+		}
+		return nil, "", ErrInvalidImage
+	}
+
+	format := normalizeImageFormat(opts.Format)
+	if format == "" {
+		format = source
+	}
+	if _, ok := imageFormats[format]; !ok {
+		return nil, "", ErrUnsupportedImage
+	}
+
+	img := toRGBA(src)
+	if source == "jpeg" {
+		img = orientImage(img, jpegOrientation(data))
+	}
+	img = resizeImage(img, opts)
+
+	var out bytes.Buffer
+	switch format {
+	case "jpeg":
+		quality := opts.Quality
+		if quality <= 0 || quality > 100 {
+			quality = DefaultImageQuality
+		}
+		err = jpeg.Encode(&out, flattenImage(img), &jpeg.Options{Quality: quality})
+	case "png":
+		err = png.Encode(&out, img)
+	case "gif":
+		err = gif.Encode(&out, img, nil)
+	case "webp":
+		err = EncodeWebP(&out, img)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return out.Bytes(), "image/" + format, nil
+}
+
+func normalizeImageFormat(format string) string {
+	format = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(format), "."))
+	if format == "jpg" {
+		return "jpeg"
+	}
+	return format
+}
+
+func toRGBA(src image.Image) *image.RGBA {
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), src, bounds.Min, draw.Src)
+	return dst
+}
+
+// flattenImage, saydam pikselleri beyaz zemine yerleştirir; JPEG alfa taşımaz.
+func flattenImage(img *image.RGBA) *image.RGBA {
+	if img.Opaque() {
+		return img
+	}
+	dst := image.NewRGBA(img.Bounds())
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), img, image.Point{}, draw.Over)
+	return dst
+}
+
+// resizeImage, hedef boyutu hesaplar ve gerekirse kırpıp küçültür.
+func resizeImage(img *image.RGBA, opts ImageOptions) *image.RGBA {
+	srcW, srcH := img.Bounds().Dx(), img.Bounds().Dy()
+	width, height := opts.Width, opts.Height
+	if width <= 0 && height <= 0 {
+		return img
+	}
+
+	if opts.Crop && width > 0 && height > 0 {
+		// Kutunun oranında, kaynağa sığan en büyük alan ortadan kırpılır
+		cropW, cropH := srcW, int(math.Round(float64(srcW)*float64(height)/float64(width)))
+		if cropH > srcH {
+			cropW, cropH = int(math.Round(float64(srcH)*float64(width)/float64(height))), srcH
+		}
+		cropW, cropH = max(cropW, 1), max(cropH, 1)
+		x0, y0 := (srcW-cropW)/2, (srcH-cropH)/2
+		img = img.SubImage(image.Rect(x0, y0, x0+cropW, y0+cropH)).(*image.RGBA)
+		if cropW > width {
+			return resampleImage(img, width, height)
+		}
+		return toRGBA(img)
+	}
+
+	scale := 1.0
+	if width > 0 {
+		scale = math.Min(scale, float64(width)/float64(srcW))
+	}
+	if height > 0 {
+		scale = math.Min(scale, float64(height)/float64(srcH))
+	}
+	if scale >= 1 {
+		return img
+	}
+	dstW := max(int(math.Round(float64(srcW)*scale)), 1)
+	dstH := max(int(math.Round(float64(srcH)*scale)), 1)
+	return resampleImage(img, dstW, dstH)
+}
+
+// resampleImage, alan ortalaması (box filtre) ile küçültür. Ağırlıklar kaynak
+// pikselin hedef piksele düşen kesirli payıdır; premultiplied RGBA üzerinde
+// çalışıldığı için saydam kenarlarda renk sızması olmaz.
+func resampleImage(src *image.RGBA, width, height int) *image.RGBA {
+	bounds := src.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	xWeights := areaWeights(srcW, width)
+	yWeights := areaWeights(srcH, height)
+
+	// Önce yatay, sonra dikey geçiş
+	tmp := make([]float32, srcH*width*4)
+	for y := 0; y < srcH; y++ {
+		row := src.Pix[src.PixOffset(bounds.Min.X, bounds.Min.Y+y):]
+		for x, w := range xWeights {
+			var r, g, b, a float32
+			for i, weight := range w.weights {
+				p := (w.start + i) * 4
+				r += float32(row[p]) * weight
+				g += float32(row[p+1]) * weight
+				b += float32(row[p+2]) * weight
+				a += float32(row[p+3]) * weight
+			}
+			t := (y*width + x) * 4
+			tmp[t], tmp[t+1], tmp[t+2], tmp[t+3] = r, g, b, a
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y, w := range yWeights {
+		for x := 0; x < width; x++ {
+			var r, g, b, a float32
+			for i, weight := range w.weights {
+				t := ((w.start+i)*width + x) * 4
+				r += tmp[t] * weight
+				g += tmp[t+1] * weight
+				b += tmp[t+2] * weight
+				a += tmp[t+3] * weight
+			}
+			d := dst.PixOffset(x, y)
+			dst.Pix[d], dst.Pix[d+1], dst.Pix[d+2], dst.Pix[d+3] = clampChannel(r), clampChannel(g), clampChannel(b), clampChannel(a)
+		}
+	}
+	return dst
+}
+
+type areaWeight struct {
+	start   int
+	weights []float32
+}
+
+func areaWeights(srcLen, dstLen int) []areaWeight {
+	scale := float64(srcLen) / float64(dstLen)
+	out := make([]areaWeight, dstLen)
+	for i := range out {
+		lo, hi := float64(i)*scale, float64(i+1)*scale
+		start, end := int(lo), min(int(math.Ceil(hi)), srcLen)
+		weights := make([]float32, end-start)
+		for j := start; j < end; j++ {
+			weights[j-start] = float32((math.Min(hi, float64(j+1)) - math.Max(lo, float64(j))) / scale)
+		}
+		out[i] = areaWeight{start: start, weights: weights}
+	}
+	return out
+}
+
+func clampChannel(v float32) uint8 {
+	switch {
+	case v <= 0:
+		return 0
+	case v >= 255:
+		return 255
+	}
+	return uint8(v + 0.5)
+}
+
+// jpegOrientation, JPEG'in EXIF APP1 segmentindeki yönlendirmeyi döner.
+func jpegOrientation(data []byte) uint16 {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 0
+	}
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 0
+		}
+		marker := data[i+1]
+		if marker == 0xDA || marker == 0xD9 {
+			return 0
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return 0
+		}
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+		i += 2 + length
+	}
+	return 0
+}
+
+// orientImage, EXIF yönlendirmesini (2-8) piksellere uygular.
+func orientImage(img *image.RGBA, orientation uint16) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+	dstW, dstH := w, h
+	if orientation >= 5 {
+		dstW, dstH = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // yatay ayna
+				dx, dy = w-1-x, y
+			case 3: // 180°
+				dx, dy = w-1-x, h-1-y
+			case 4: // dikey ayna
+				dx, dy = x, h-1-y
+			case 5: // transpoze
+				dx, dy = y, x
+			case 6: // saat yönünde 90°
+				dx, dy = h-1-y, x
+			case 7: // ters transpoze
+				dx, dy = h-1-y, w-1-x
+			case 8: // saat yönünün tersine 90°
+				dx, dy = y, w-1-x
+			}
+			s, d := img.PixOffset(x, y), dst.PixOffset(dx, dy)
+			copy(dst.Pix[d:d+4], img.Pix[s:s+4])
+		}
+	}
+	return dst
+}
@@ -0,0 +1,185 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"golang.org/x/image/webp"
+)
+
+func encodeTestImage(t *testing.T, format string, width, height int) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	// Sol üst köşe kırmızı; yönlendirme testleri bu pikseli izler
+	for y := 0; y < height/4; y++ {
+		for x := 0; x < width/4; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: 255, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	var err error
+	if format == "jpeg" {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95})
+	} else {
+		err = png.Encode(&buf, img)
+	}
+	if err != nil {
+		t.Fatalf("encode failed: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func decodeSize(t *testing.T, data []byte) (int, int, string) {
+	t.Helper()
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	return cfg.Width, cfg.Height, format
+}
+
+func TestConvertImageResize(t *testing.T) {
+	source := encodeTestImage(t, "png", 400, 200)
+
+	tests := []struct {
+		name          string
+		opts          ImageOptions
+		width, height int
+	}{
+		{"fit width", ImageOptions{Width: 100}, 100, 50},
+		{"fit height", ImageOptions{Height: 50}, 100, 50},
+		{"fit box", ImageOptions{Width: 100, Height: 100}, 100, 50},
+		{"crop", ImageOptions{Width: 100, Height: 100, Crop: true}, 100, 100},
+		{"crop wide", ImageOptions{Width: 300, Height: 50, Crop: true}, 300, 50},
+		{"no upscale", ImageOptions{Width: 1200}, 400, 200},
+		{"crop no upscale", ImageOptions{Width: 1000, Height: 1000, Crop: true}, 200, 200},
+		{"original size", ImageOptions{}, 400, 200},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, contentType, err := ConvertImage(source, tt.opts)
+			if err != nil {
+				t.Fatalf("convert failed: %v", err)
+			}
+			width, height, format := decodeSize(t, out)
+			if width != tt.width || height != tt.height || format != "png" || contentType != "image/png" {
+				t.Fatalf("expected %dx%d png, got %dx%d %s (%s)", tt.width, tt.height, width, height, format, contentType)
+			}
+		})
+	}
+}
+
+func TestConvertImageFormats(t *testing.T) {
+	source := encodeTestImage(t, "png", 64, 32)
+
+	out, contentType, err := ConvertImage(source, ImageOptions{Width: 32, Format: "jpg"})
+	if err != nil || contentType != "image/jpeg" {
+		t.Fatalf("expected jpeg output, got %q (err=%v)", contentType, err)
+	}
+	if width, height, format := decodeSize(t, out); width != 32 || height != 16 || format != "jpeg" {
+		t.Fatalf("unexpected jpeg %dx%d %s", width, height, format)
+	}
+
+	out, contentType, err = ConvertImage(source, ImageOptions{Width: 32, Format: "webp"})
+	if err != nil || contentType != "image/webp" {
+		t.Fatalf("expected webp output, got %q (err=%v)", contentType, err)
+	}
+	if string(out[:4]) != "RIFF" || string(out[8:16]) != "WEBPVP8L" || int(binary.LittleEndian.Uint32(out[4:]))+8 != len(out) {
+		t.Fatalf("unexpected webp container: %q", out[:16])
+	}
+	// VP8L başlığı: imza, 14 bit genişlik-1, 14 bit yükseklik-1
+	bits := binary.LittleEndian.Uint32(out[21:])
+	if out[20] != 0x2f || int(bits&0x3fff)+1 != 32 || int(bits>>14&0x3fff)+1 != 16 {
+		t.Fatalf("unexpected VP8L header % x", out[20:25])
+	}
+	// Kayıpsız çıktı aynı boyuttaki PNG dönüşümüyle piksel piksel eşleşmeli
+	reference, _, err := ConvertImage(source, ImageOptions{Width: 32, Format: "png"})
+	if err != nil {
+		t.Fatalf("png conversion failed: %v", err)
+	}
+	assertWebPMatches(t, out, reference)
+
+	// Boyutlandırma olmadan çözülen WebP kaynak resmin kendisidir
+	out, _, err = ConvertImage(source, ImageOptions{Format: "webp"})
+	if err != nil {
+		t.Fatalf("webp conversion failed: %v", err)
+	}
+	assertWebPMatches(t, out, source)
+
+	if _, _, err := ConvertImage(source, ImageOptions{Format: "tiff"}); err != ErrUnsupportedImage {
+		t.Fatalf("expected unsupported format error, got %v", err)
+	}
+	if _, _, err := ConvertImage([]byte("not an image"), ImageOptions{}); err != ErrUnsupportedImage {
+		t.Fatalf("expected unsupported image error, got %v", err)
+	}
+}
+
+// assertWebPMatches, WebP çıktısını golang.org/x/image/webp ile çözer ve
+// boyutunun ve piksellerinin beklenen resimle aynı olduğunu doğrular.
+func assertWebPMatches(t *testing.T, encoded, expected []byte) {
+	t.Helper()
+	got, err := webp.Decode(bytes.NewReader(encoded))
+	if err != nil {
+		t.Fatalf("webp decode failed: %v", err)
+	}
+	want, _, err := image.Decode(bytes.NewReader(expected))
+	if err != nil {
+		t.Fatalf("reference decode failed: %v", err)
+	}
+	if got.Bounds() != want.Bounds() {
+		t.Fatalf("expected %v webp, got %v", want.Bounds(), got.Bounds())
+	}
+	bounds := want.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			g := color.NRGBAModel.Convert(got.At(x, y))
+			w := color.NRGBAModel.Convert(want.At(x, y))
+			if g != w {
+				t.Fatalf("pixel (%d,%d): expected %v, got %v", x, y, w, g)
+			}
+		}
+	}
+}
+
+func TestConvertImageAppliesOrientation(t *testing.T) {
+	// 6: saat yönünde 90° döndürülmeli; sol üstteki kırmızı alan sağ üste geçer
+	source := withEXIF(encodeTestImage(t, "jpeg", 80, 40), 6)
+
+	out, _, err := ConvertImage(source, ImageOptions{Format: "png"})
+	if err != nil {
+		t.Fatalf("convert failed: %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(out))
+	if err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if img.Bounds().Dx() != 40 || img.Bounds().Dy() != 80 {
+		t.Fatalf("expected rotated 40x80 image, got %v", img.Bounds())
+	}
+	if r, g, _, _ := img.At(37, 2).RGBA(); r>>8 < 200 || g>>8 > 60 {
+		t.Fatalf("expected red corner at the top right, got %v", img.At(37, 2))
+	}
+}
+
+func TestConversionPath(t *testing.T) {
+	if got := ConversionPath("products/abc.jpeg", "thumb", ""); got != "products/abc-thumb.jpeg" {
+		t.Fatalf("unexpected path %q", got)
+	}
+	if got := ConversionPath("products/abc.png", "preview", "webp"); got != "products/abc-preview.webp" {
+		t.Fatalf("unexpected path %q", got)
+	}
+	if !CanConvertImage("a/b.JPG") || CanConvertImage("a/b.svg") || CanConvertImage("a/b.webp") {
+		t.Fatal("unexpected convertible extensions")
+	}
+}
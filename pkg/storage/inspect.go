@@ -0,0 +1,389 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"html"
+	"mime"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// SniffLength, DetectContentType'ın incelediği en fazla bayt sayısıdır.
+const SniffLength = 512
+
+// ErrInvalidImage, meta veri temizlenecek resim çözümlenemediğinde döner.
+var ErrInvalidImage = errors.New("storage: invalid image data")
+
+// extensionTypes, sistem MIME tablosundan bağımsız olarak tanınan uzantılardır.
+// mime.TypeByExtension sunucunun /etc/mime.types dosyasına göre farklı sonuçlar
+// verebildiğinden yaygın yükleme tipleri burada sabitlenir.
+var extensionTypes = map[string]string{
+	".jpg":   "image/jpeg",
+	".jpeg":  "image/jpeg",
+	".png":   "image/png",
+	".gif":   "image/gif",
+	".webp":  "image/webp",
+	".bmp":   "image/bmp",
+	".ico":   "image/x-icon",
+	".svg":   "image/svg+xml",
+	".avif":  "image/avif",
+	".heic":  "image/heic",
+	".tif":   "image/tiff",
+	".tiff":  "image/tiff",
+	".pdf":   "application/pdf",
+	".txt":   "text/plain",
+	".csv":   "text/csv",
+	".md":    "text/markdown",
+	".json":  "application/json",
+	".xml":   "application/xml",
+	".htm":   "text/html",
+	".html":  "text/html",
+	".xhtml": "application/xhtml+xml",
+	".zip":   "application/zip",
+	".doc":   "application/msword",
+	".docx":  "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".xls":   "application/vnd.ms-excel",
+	".xlsx":  "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	".ppt":   "application/vnd.ms-powerpoint",
+	".pptx":  "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+	".odt":   "application/vnd.oasis.opendocument.text",
+	".ods":   "application/vnd.oasis.opendocument.spreadsheet",
+	".mp4":   "video/mp4",
+	".m4v":   "video/mp4",
+	".mov":   "video/quicktime",
+	".webm":  "video/webm",
+	".mp3":   "audio/mpeg",
+	".m4a":   "audio/mp4",
+	".wav":   "audio/wav",
+	".ogg":   "audio/ogg",
+}
+
+// typeAliases, aynı biçim için kullanılan farklı MIME adlarını tek ada indirger.
+var typeAliases = map[string]string{
+	"image/jpg":                "image/jpeg",
+	"image/pjpeg":              "image/jpeg",
+	"image/vnd.microsoft.icon": "image/x-icon",
+	"audio/wave":               "audio/wav",
+	"audio/x-wav":              "audio/wav",
+	"audio/x-m4a":              "audio/mp4",
+	"application/x-pdf":        "application/pdf",
+	"text/xml":                 "application/xml",
+}
+
+// signatureTypes, içerikten güvenilir biçimde tanınan tiplerdir. Uzantısı bu
+// tiplerden birini söyleyen ama imzası tutmayan dosyalar uzantıya göre kabul edilmez.
+var signatureTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"image/bmp":       true,
+	"image/x-icon":    true,
+	"application/pdf": true,
+	"text/html":       true,
+	"application/zip": true,
+}
+
+// activeContentPattern, SVG/XML içinde betik çalıştırabilen yapıları yakalar.
+var activeContentPattern = regexp.MustCompile(`<([a-z0-9_.-]+:)?(script|foreignobject|iframe|embed|object|handler)\b|<!entity|<\?xml-stylesheet|\son[a-z]+\s*=`)
+
+// normalizeType, parametreleri atar ve MIME adını küçük harfli tek biçime indirger.
+func normalizeType(contentType string) string {
+	mediaType := strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	if alias, ok := typeAliases[mediaType]; ok {
+		return alias
+	}
+	return mediaType
+}
+
+// TypeByExtension, dosya adının uzantısına göre MIME tipini döner; bilinmiyorsa "".
+func TypeByExtension(filename string) string {
+	ext := strings.ToLower(path.Ext(filename))
+	if ext == "" {
+		return ""
+	}
+	if contentType, ok := extensionTypes[ext]; ok {
+		return contentType
+	}
+	return normalizeType(mime.TypeByExtension(ext))
+}
+
+// DetectContentType, istemcinin gönderdiği Content-Type yerine dosyanın ilk
+// baytlarından gerçek MIME tipini belirler. İçerik yalnızca genel bir tipe
+// (düz metin, XML, zip, ikili veri) işaret ediyorsa ve uzantı bu içerikle
+// tutarlıysa uzantının tipi kullanılır; örneğin .docx dosyaları zip, .csv
+// dosyaları düz metin olarak görünür.
+func DetectContentType(head []byte, filename string) string {
+	if len(head) > SniffLength {
+		head = head[:SniffLength]
+	}
+	sniffed := normalizeType(http.DetectContentType(head))
+	byExtension := TypeByExtension(filename)
+	if byExtension != "" && extensionMatchesContent(sniffed, byExtension) {
+		return byExtension
+	}
+	return sniffed
+}
+
+// extensionMatchesContent, uzantının tipinin koklanan genel tiple tutarlı olup
+// olmadığını söyler.
+func extensionMatchesContent(sniffed, byExtension string) bool {
+	switch sniffed {
+	case "application/octet-stream":
+		// İkili içerik; imzası tanınan tipler ve metin tipleri uzantıyla taklit edilemez
+		return !signatureTypes[byExtension] && !isTextType(byExtension)
+	case "text/plain":
+		return byExtension != "text/html" && isTextType(byExtension)
+	case "application/xml":
+		return strings.Contains(byExtension, "xml")
+	case "application/zip":
+		return strings.HasPrefix(byExtension, "application/vnd.openxmlformats-officedocument.") ||
+			strings.HasPrefix(byExtension, "application/vnd.oasis.opendocument.") ||
+			byExtension == "application/epub+zip"
+	case "video/mp4":
+		return byExtension == "audio/mp4" || byExtension == "video/quicktime"
+	case "application/ogg":
+		return strings.HasSuffix(byExtension, "/ogg")
+	case "video/webm":
+		return byExtension == "audio/webm"
+	}
+	return false
+}
+
+func isTextType(contentType string) bool {
+	return strings.HasPrefix(contentType, "text/") || strings.Contains(contentType, "xml") ||
+		strings.Contains(contentType, "json") || strings.Contains(contentType, "yaml")
+}
+
+// MatchesAccept, içerik tipinin ve dosya adının accept listesine uyup uymadığını
+// söyler. Liste HTML accept özniteliğiyle aynı biçimleri destekler: tam tip
+// ("image/png"), joker ("image/*") ve uzantı (".pdf"). Boş liste her şeyi kabul eder.
+func MatchesAccept(contentType, filename string, accept []string) bool {
+	if len(accept) == 0 {
+		return true
+	}
+	contentType = normalizeType(contentType)
+	for _, entry := range accept {
+		for _, pattern := range strings.Split(entry, ",") {
+			pattern = strings.ToLower(strings.TrimSpace(pattern))
+			switch {
+			case pattern == "":
+				continue
+			case pattern == "*" || pattern == "*/*":
+				return true
+			case strings.HasPrefix(pattern, "."):
+				// Uzantı eşleşmesi, içeriğin de o uzantının tipinde olmasını ister
+				if strings.ToLower(path.Ext(filename)) != pattern {
+					continue
+				}
+				if byExtension := TypeByExtension(pattern); byExtension == "" || byExtension == contentType {
+					return true
+				}
+			case strings.HasSuffix(pattern, "/*"):
+				if strings.HasPrefix(contentType, strings.TrimSuffix(pattern, "*")) {
+					return true
+				}
+			case normalizeType(pattern) == contentType:
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NeedsContentScan, IsDangerous için dosyanın tamamının okunması gerekip
+// gerekmediğini söyler (SVG ve diğer XML tipleri).
+func NeedsContentScan(contentType, filename string) bool {
+	return strings.Contains(normalizeType(contentType), "xml") ||
+		strings.Contains(TypeByExtension(filename), "xml")
+}
+
+// IsDangerous, panel origin'inde açıldığında betik çalıştırabilecek dosyaları
+// yakalar. Dosya özgün uzantısıyla saklandığı ve statik sunucu tipi uzantıdan
+// belirlediği için hem içerik tipi hem uzantı incelenir: HTML/XHTML her zaman,
+// SVG ve XML ise betik, olay özniteliği, javascript: adresi, gömülü belge veya
+// entity tanımı içeriyorsa tehlikelidir. XML için content dosyanın tamamıdır.
+func IsDangerous(contentType, filename string, content []byte) bool {
+	for _, t := range []string{normalizeType(contentType), TypeByExtension(filename)} {
+		if t == "text/html" || t == "application/xhtml+xml" {
+			return true
+		}
+	}
+	if !NeedsContentScan(contentType, filename) {
+		return false
+	}
+
+	// Karakter referanslarıyla gizlenmiş ifadeler de yakalanır ("java&#115;cript:")
+	text := strings.ToLower(html.UnescapeString(string(content)))
+	if activeContentPattern.MatchString(text) {
+		return true
+	}
+	compact := strings.Map(func(r rune) rune {
+		if r <= ' ' {
+			return -1
+		}
+		return r
+	}, text)
+	return strings.Contains(compact, "javascript:") || strings.Contains(compact, "data:text/html")
+}
+
+// StripImageMetadata, JPEG ve PNG dosyalarından EXIF, XMP, IPTC ve metin meta
+// verilerini (GPS konumu, cihaz bilgisi, yorumlar) kaldırır. JPEG yönlendirmesi
+// korunur; böylece resim döndürülmüş görünmez. Diğer tipler değiştirilmeden döner.
+func StripImageMetadata(contentType string, data []byte) ([]byte, error) {
+	switch normalizeType(contentType) {
+	case "image/jpeg":
+		return stripJPEGMetadata(data)
+	case "image/png":
+		return stripPNGMetadata(data)
+	}
+	return data, nil
+}
+
+// stripJPEGMetadata, APP1 (EXIF/XMP), APP13 (IPTC) ve COM segmentlerini atar.
+// Görüntü verisi (SOS sonrası) olduğu gibi kopyalanır.
+func stripJPEGMetadata(data []byte) ([]byte, error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil, ErrInvalidImage
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(data[:2])
+	i := 2
+	for i < len(data) {
+		if data[i] != 0xFF {
+			return nil, ErrInvalidImage
+		}
+		for i < len(data) && data[i] == 0xFF {
+			i++
+		}
+		if i >= len(data) {
+			return nil, ErrInvalidImage
+		}
+		marker := data[i]
+		i++
+
+		switch {
+		case marker == 0xD9: // EOI
+			out.Write([]byte{0xFF, marker})
+			return out.Bytes(), nil
+		case marker == 0xDA: // SOS: geri kalan sıkıştırılmış veri
+			out.Write([]byte{0xFF, marker})
+			out.Write(data[i:])
+			return out.Bytes(), nil
+		case marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7): // uzunluksuz işaretler
+			out.Write([]byte{0xFF, marker})
+			continue
+		}
+
+		if i+2 > len(data) {
+			return nil, ErrInvalidImage
+		}
+		length := int(binary.BigEndian.Uint16(data[i:]))
+		if length < 2 || i+length > len(data) {
+			return nil, ErrInvalidImage
+		}
+		segment := data[i+2 : i+length]
+		next := i + length
+
+		switch marker {
+		case 0xE1: // APP1: EXIF veya XMP
+			if bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+				if orientation := exifOrientation(segment[6:]); orientation > 1 {
+					out.Write(orientationSegment(orientation))
+				}
+			}
+		case 0xED, 0xFE: // APP13 (IPTC), COM
+		default:
+			out.Write([]byte{0xFF, marker})
+			out.Write(data[i:next])
+		}
+		i = next
+	}
+	return nil, ErrInvalidImage
+}
+
+// exifOrientation, TIFF yapısındaki IFD0'dan Orientation (0x0112) etiketini okur.
+func exifOrientation(tiff []byte) uint16 {
+	if len(tiff) < 8 {
+		return 0
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+	offset := int(order.Uint32(tiff[4:]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 0
+	}
+	count := int(order.Uint16(tiff[offset:]))
+	for n := 0; n < count; n++ {
+		entry := offset + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 && order.Uint16(tiff[entry+2:]) == 3 {
+			if orientation := order.Uint16(tiff[entry+8:]); orientation <= 8 {
+				return orientation
+			}
+			return 0
+		}
+	}
+	return 0
+}
+
+// orientationSegment, yalnızca Orientation etiketi içeren bir EXIF APP1 segmenti üretir.
+func orientationSegment(orientation uint16) []byte {
+	segment := []byte{
+		0xFF, 0xE1, 0x00, 0x22,
+		'E', 'x', 'i', 'f', 0x00, 0x00,
+		'M', 'M', 0x00, 0x2A, 0x00, 0x00, 0x00, 0x08, // TIFF başlığı, IFD0 8. baytta
+		0x00, 0x01, // bir etiket
+		0x01, 0x12, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, // Orientation, SHORT
+		0x00, 0x00, 0x00, 0x00, // sonraki IFD yok
+	}
+	binary.BigEndian.PutUint16(segment[28:], orientation)
+	return segment
+}
+
+// pngMetadataChunks, PNG'den atılan meta veri parçalarıdır. XMP (ve içindeki
+// GPS bilgisi) iTXt parçasında taşınır.
+var pngMetadataChunks = map[string]bool{"eXIf": true, "tEXt": true, "zTXt": true, "iTXt": true, "tIME": true}
+
+// stripPNGMetadata, PNG meta veri parçalarını atar; diğer parçalar CRC'leriyle
+// birlikte aynen kopyalanır.
+func stripPNGMetadata(data []byte) ([]byte, error) {
+	const signature = "\x89PNG\r\n\x1a\n"
+	if !bytes.HasPrefix(data, []byte(signature)) {
+		return nil, ErrInvalidImage
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.WriteString(signature)
+	i := len(signature)
+	for i+8 <= len(data) {
+		length := int(binary.BigEndian.Uint32(data[i:]))
+		chunkType := string(data[i+4 : i+8])
+		end := i + 12 + length
+		if length < 0 || end > len(data) {
+			return nil, ErrInvalidImage
+		}
+		if !pngMetadataChunks[chunkType] {
+			out.Write(data[i:end])
+		}
+		i = end
+		if chunkType == "IEND" {
+			return out.Bytes(), nil
+		}
+	}
+	return nil, ErrInvalidImage
+}
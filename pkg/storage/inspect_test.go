@@ -0,0 +1,197 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func testPNG(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 2, 2))); err != nil {
+		t.Fatalf("png encode failed: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func testJPEG(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 2, 2)), nil); err != nil {
+		t.Fatalf("jpeg encode failed: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// withPNGChunk inserts a chunk right after IHDR.
+func withPNGChunk(data []byte, chunkType string, payload []byte) []byte {
+	chunk := make([]byte, 8, 12+len(payload))
+	binary.BigEndian.PutUint32(chunk, uint32(len(payload)))
+	copy(chunk[4:], chunkType)
+	chunk = append(chunk, payload...)
+	chunk = binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+
+	ihdrEnd := 8 + 12 + int(binary.BigEndian.Uint32(data[8:]))
+	out := append([]byte{}, data[:ihdrEnd]...)
+	out = append(out, chunk...)
+	return append(out, data[ihdrEnd:]...)
+}
+
+// withEXIF inserts an APP1 segment with a little-endian IFD0 holding the
+// orientation and a GPS marker string right after SOI.
+func withEXIF(data []byte, orientation uint16) []byte {
+	tiff := []byte{'I', 'I', 0x2A, 0x00, 0x08, 0x00, 0x00, 0x00, 0x01, 0x00}
+	entry := make([]byte, 12)
+	binary.LittleEndian.PutUint16(entry, 0x0112)
+	binary.LittleEndian.PutUint16(entry[2:], 3)
+	binary.LittleEndian.PutUint32(entry[4:], 1)
+	binary.LittleEndian.PutUint16(entry[8:], orientation)
+	tiff = append(tiff, entry...)
+	tiff = append(tiff, 0, 0, 0, 0)
+	tiff = append(tiff, []byte("GPS 41.0082N 28.9784E")...)
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	out := append([]byte{}, data[:2]...)
+	out = append(out, segment...)
+	return append(out, data[2:]...)
+}
+
+func TestDetectContentType(t *testing.T) {
+	pngData := testPNG(t)
+	cases := []struct {
+		name     string
+		head     []byte
+		filename string
+		want     string
+	}{
+		{"png ignores extension", pngData, "photo.jpg", "image/png"},
+		{"csv from extension", []byte("id,name\n1,Widget\n"), "export.csv", "text/csv"},
+		{"svg from extension", []byte(`<svg xmlns="http://www.w3.org/2000/svg"></svg>`), "logo.svg", "image/svg+xml"},
+		{"docx from zip", []byte("PK\x03\x04rest"), "report.docx", "application/vnd.openxmlformats-officedocument.wordprocessingml.document"},
+		{"html disguised as png", []byte("<html><script>alert(1)</script></html>"), "cat.png", "text/html"},
+		{"text disguised as png", []byte("just text"), "cat.png", "text/plain"},
+		{"binary without signature", []byte{0x00, 0x01, 0x02, 0x03}, "cat.png", "application/octet-stream"},
+	}
+	for _, tc := range cases {
+		if got := DetectContentType(tc.head, tc.filename); got != tc.want {
+			t.Errorf("%s: DetectContentType = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestMatchesAccept(t *testing.T) {
+	cases := []struct {
+		contentType string
+		filename    string
+		accept      []string
+		want        bool
+	}{
+		{"image/png", "a.png", nil, true},
+		{"image/png", "a.png", []string{"image/*"}, true},
+		{"image/jpeg", "a.jpg", []string{"image/jpg"}, true},
+		{"application/pdf", "a.pdf", []string{"image/png, application/pdf"}, true},
+		{"application/pdf", "a.pdf", []string{".pdf"}, true},
+		{"text/html", "a.pdf", []string{".pdf"}, false},
+		{"text/plain", "a.png", []string{"image/*"}, false},
+		{"video/mp4", "a.mp4", []string{"image/*", "audio/*"}, false},
+	}
+	for _, tc := range cases {
+		if got := MatchesAccept(tc.contentType, tc.filename, tc.accept); got != tc.want {
+			t.Errorf("MatchesAccept(%q, %q, %v) = %v, want %v", tc.contentType, tc.filename, tc.accept, got, tc.want)
+		}
+	}
+}
+
+func TestIsDangerous(t *testing.T) {
+	dangerous := map[string]string{
+		"page.html": "<p>hello</p>",
+		"a.svg":     `<svg><script>alert(1)</script></svg>`,
+		"b.svg":     `<svg><rect onload="alert(1)"/></svg>`,
+		"c.svg":     `<svg><a href="java&#115;cript:alert(1)">x</a></svg>`,
+		"d.svg":     "<svg><a href=\"java\nscript:alert(1)\">x</a></svg>",
+		"e.svg":     `<svg><foreignObject><body/></foreignObject></svg>`,
+		"f.svg":     `<!DOCTYPE svg [<!ENTITY x SYSTEM "file:///etc/passwd">]><svg>&x;</svg>`,
+		"g.xml":     `<x:script xmlns:x="http://www.w3.org/1999/xhtml">alert(1)</x:script>`,
+	}
+	for filename, content := range dangerous {
+		contentType := DetectContentType([]byte(content), filename)
+		if !IsDangerous(contentType, filename, []byte(content)) {
+			t.Errorf("expected %s (%s) to be dangerous", filename, contentType)
+		}
+	}
+
+	safe := map[string]string{
+		"logo.svg":  `<svg xmlns="http://www.w3.org/2000/svg"><rect width="10" height="10" fill="#000"/></svg>`,
+		"notes.txt": `the <script> tag is shown as text`,
+	}
+	for filename, content := range safe {
+		contentType := DetectContentType([]byte(content), filename)
+		if IsDangerous(contentType, filename, []byte(content)) {
+			t.Errorf("expected %s (%s) to be safe", filename, contentType)
+		}
+	}
+	if !IsDangerous("image/png", "cat.html", testPNG(t)) {
+		t.Error("expected a png stored with an html extension to be dangerous")
+	}
+}
+
+func TestStripImageMetadataJPEG(t *testing.T) {
+	original := withEXIF(testJPEG(t), 6)
+	stripped, err := StripImageMetadata("image/jpeg", original)
+	if err != nil {
+		t.Fatalf("StripImageMetadata failed: %v", err)
+	}
+	if bytes.Contains(stripped, []byte("GPS 41.0082N")) {
+		t.Fatal("expected GPS data to be removed")
+	}
+	if _, err := jpeg.Decode(bytes.NewReader(stripped)); err != nil {
+		t.Fatalf("stripped jpeg does not decode: %v", err)
+	}
+	start := bytes.Index(stripped, []byte("Exif\x00\x00"))
+	if start < 0 || exifOrientation(stripped[start+6:]) != 6 {
+		t.Fatal("expected orientation to be preserved")
+	}
+
+	plain, err := StripImageMetadata("image/jpeg", withEXIF(testJPEG(t), 1))
+	if err != nil {
+		t.Fatalf("StripImageMetadata failed: %v", err)
+	}
+	if bytes.Contains(plain, []byte("Exif")) {
+		t.Fatal("expected no EXIF segment for the default orientation")
+	}
+
+	if _, err := StripImageMetadata("image/jpeg", []byte("not a jpeg")); err != ErrInvalidImage {
+		t.Fatalf("expected ErrInvalidImage, got %v", err)
+	}
+}
+
+func TestStripImageMetadataPNG(t *testing.T) {
+	original := withPNGChunk(testPNG(t), "tEXt", []byte("Comment\x00taken at 41.0082N"))
+	original = withPNGChunk(original, "eXIf", []byte("MM\x00\x2a"))
+	stripped, err := StripImageMetadata("image/png", original)
+	if err != nil {
+		t.Fatalf("StripImageMetadata failed: %v", err)
+	}
+	if bytes.Contains(stripped, []byte("41.0082N")) || bytes.Contains(stripped, []byte("eXIf")) {
+		t.Fatal("expected metadata chunks to be removed")
+	}
+	if _, err := png.Decode(bytes.NewReader(stripped)); err != nil {
+		t.Fatalf("stripped png does not decode: %v", err)
+	}
+
+	if _, err := StripImageMetadata("image/png", original[:40]); err != ErrInvalidImage {
+		t.Fatalf("expected ErrInvalidImage for a truncated png, got %v", err)
+	}
+	if data, err := StripImageMetadata("application/pdf", []byte("%PDF")); err != nil || string(data) != "%PDF" {
+		t.Fatalf("expected other types to be returned unchanged, got %q, %v", data, err)
+	}
+}
@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// FileInfo, diskte listelenen bir dosyanın yolu, boyutu ve son değişiklik zamanıdır.
+type FileInfo struct {
+	Path         string
+	Size         int64
+	LastModified time.Time
+}
+
+// Lister, dosyalarını listeleyebilen disklerin uyguladığı isteğe bağlı arayüzdür.
+// Yetim dosya taraması (storage:prune) yalnızca bu arayüzü uygulayan disklerde çalışır.
+type Lister interface {
+	// List, dir altındaki tüm dosyaları alt klasörlerle birlikte yol sırasıyla döner.
+	// dir boşsa diskin tamamı listelenir; olmayan klasör boş liste döner.
+	List(ctx context.Context, dir string) ([]FileInfo, error)
+}
+
+// PathFromValue, dosya alanının veritabanındaki değerini (StoreUpload'ın döndürdüğü
+// herkese açık URL veya disk yolu) disk yoluna çevirir. Değer bu diske ait değilse
+// (başka bir adres veya geçersiz yol) ok false döner.
+func PathFromValue(disk Disk, value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", false
+	}
+
+	// URL("_") ile diskin adres öneki bulunur; S3 Root öneki de bu öneke dahildir
+	if base := strings.TrimSuffix(disk.URL("_"), "_"); base != "" && strings.HasPrefix(value, base) {
+		rest := strings.TrimPrefix(value, base)
+		if i := strings.IndexAny(rest, "?#"); i >= 0 {
+			rest = rest[:i]
+		}
+		if decoded, err := url.PathUnescape(rest); err == nil {
+			rest = decoded
+		}
+		cleaned, err := CleanPath(rest)
+		return cleaned, err == nil
+	}
+
+	if strings.Contains(value, "://") || strings.HasPrefix(value, "/") || strings.ContainsAny(value, "?#") {
+		return "", false
+	}
+	cleaned, err := CleanPath(value)
+	return cleaned, err == nil
+}
+
+// inDir, disk yolunun dir klasörünün altında olup olmadığını döner.
+func inDir(p, dir string) bool {
+	dir = strings.Trim(dir, "/")
+	return dir == "" || strings.HasPrefix(p, dir+"/")
+}
@@ -0,0 +1,198 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LocalOptions, LocalDisk ayarlarıdır.
+type LocalOptions struct {
+	// Root, dosyaların yazılacağı dizindir (örn: "./storage/public").
+	Root string
+
+	// URL, dosyaların herkese açık temel adresidir (örn: "/storage" veya
+	// "https://cdn.example.com"). Özel diskler için boş bırakılabilir.
+	URL string
+
+	// TemporaryURL, süreli URL'lerin temel adresidir. Panel bu adresi
+	// "/api/storage/<disk>" olarak ayarlar ve imzayı doğrulayarak dosyayı sunar.
+	TemporaryURL string
+
+	// SigningKey, süreli URL imzalarının HMAC anahtarıdır. Boşsa TemporaryURL
+	// ErrTemporaryURLUnsupported döner.
+	SigningKey []byte
+}
+
+// LocalDisk, dosyaları yerel dosya sisteminde saklar.
+type LocalDisk struct {
+	opts LocalOptions
+}
+
+// NewLocalDisk, verilen ayarlarla bir LocalDisk oluşturur.
+func NewLocalDisk(opts LocalOptions) *LocalDisk {
+	if opts.Root == "" {
+		opts.Root = "./storage/public"
+	}
+	return &LocalDisk{opts: opts}
+}
+
+// Root, diskin kök dizinini döner.
+func (d *LocalDisk) Root() string {
+	return d.opts.Root
+}
+
+// fullPath, disk yolunu kök dizin altındaki dosya sistemi yoluna çevirir.
+func (d *LocalDisk) fullPath(p string) (string, error) {
+	cleaned, err := CleanPath(p)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(d.opts.Root, filepath.FromSlash(cleaned)), nil
+}
+
+// Put, içeriği önce geçici dosyaya yazar ve ardından yerine taşır; böylece
+// yarım kalan yüklemeler okuyuculara görünmez.
+func (d *LocalDisk) Put(ctx context.Context, p string, r io.Reader, _ PutOptions) error {
+	target, err := d.fullPath(p)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), target)
+}
+
+func (d *LocalDisk) Get(_ context.Context, p string) (io.ReadCloser, error) {
+	target, err := d.fullPath(p)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(target)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if info, err := file.Stat(); err == nil && info.IsDir() {
+		file.Close()
+		return nil, ErrNotFound
+	}
+	return file, nil
+}
+
+func (d *LocalDisk) Delete(_ context.Context, p string) error {
+	target, err := d.fullPath(p)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (d *LocalDisk) Exists(_ context.Context, p string) (bool, error) {
+	target, err := d.fullPath(p)
+	if err != nil {
+		return false, err
+	}
+	info, err := os.Stat(target)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return !info.IsDir(), nil
+}
+
+// URL, disk için URL tanımlanmamışsa (özel disk) boş döner.
+func (d *LocalDisk) URL(p string) string {
+	if d.opts.URL == "" {
+		return ""
+	}
+	return joinURL(d.opts.URL, p)
+}
+
+func (d *LocalDisk) TemporaryURL(_ context.Context, p string, expiry time.Duration) (string, error) {
+	if len(d.opts.SigningKey) == 0 || d.opts.TemporaryURL == "" {
+		return "", ErrTemporaryURLUnsupported
+	}
+	cleaned, err := CleanPath(p)
+	if err != nil {
+		return "", err
+	}
+	return SignURL(d.opts.SigningKey, joinURL(d.opts.TemporaryURL, cleaned), time.Now().Add(expiry)), nil
+}
+
+// List, dir altındaki dosyaları döner. Put'un yarım kalan geçici dosyaları
+// (".upload-*") listelenmez.
+func (d *LocalDisk) List(ctx context.Context, dir string) ([]FileInfo, error) {
+	root := d.opts.Root
+	if strings.Trim(dir, "/") != "" {
+		full, err := d.fullPath(dir)
+		if err != nil {
+			return nil, err
+		}
+		root = full
+	}
+
+	var files []FileInfo
+	err := filepath.WalkDir(root, func(current string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".upload-") {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(d.opts.Root, current)
+		if err != nil {
+			return err
+		}
+		files = append(files, FileInfo{
+			Path:         filepath.ToSlash(rel),
+			Size:         info.Size(),
+			LastModified: info.ModTime(),
+		})
+		return nil
+	})
+	return files, err
+}
@@ -0,0 +1,146 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryOptions, MemoryDisk ayarlarıdır. Alanlar LocalOptions ile aynı anlamı taşır.
+type MemoryOptions struct {
+	URL          string
+	TemporaryURL string
+	SigningKey   []byte
+}
+
+// MemoryDisk, dosyaları bellekte tutar. Testler ve geçici kurulumlar içindir;
+// süreç kapandığında içerik kaybolur.
+type MemoryDisk struct {
+	mu       sync.RWMutex
+	files    map[string][]byte
+	modified map[string]time.Time
+	opts     MemoryOptions
+}
+
+// NewMemoryDisk, boş bir MemoryDisk oluşturur.
+func NewMemoryDisk(opts MemoryOptions) *MemoryDisk {
+	return &MemoryDisk{
+		files:    make(map[string][]byte),
+		modified: make(map[string]time.Time),
+		opts:     opts,
+	}
+}
+
+func (d *MemoryDisk) Put(ctx context.Context, p string, r io.Reader, _ PutOptions) error {
+	cleaned, err := CleanPath(p)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.files[cleaned] = content
+	d.modified[cleaned] = time.Now()
+	return nil
+}
+
+func (d *MemoryDisk) Get(_ context.Context, p string) (io.ReadCloser, error) {
+	cleaned, err := CleanPath(p)
+	if err != nil {
+		return nil, err
+	}
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	content, ok := d.files[cleaned]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(content)), nil
+}
+
+func (d *MemoryDisk) Delete(_ context.Context, p string) error {
+	cleaned, err := CleanPath(p)
+	if err != nil {
+		return err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.files, cleaned)
+	delete(d.modified, cleaned)
+	return nil
+}
+
+func (d *MemoryDisk) Exists(_ context.Context, p string) (bool, error) {
+	cleaned, err := CleanPath(p)
+	if err != nil {
+		return false, err
+	}
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	_, ok := d.files[cleaned]
+	return ok, nil
+}
+
+// URL, disk için URL tanımlanmamışsa boş döner.
+func (d *MemoryDisk) URL(p string) string {
+	if d.opts.URL == "" {
+		return ""
+	}
+	return joinURL(d.opts.URL, p)
+}
+
+func (d *MemoryDisk) TemporaryURL(_ context.Context, p string, expiry time.Duration) (string, error) {
+	if len(d.opts.SigningKey) == 0 || d.opts.TemporaryURL == "" {
+		return "", ErrTemporaryURLUnsupported
+	}
+	cleaned, err := CleanPath(p)
+	if err != nil {
+		return "", err
+	}
+	return SignURL(d.opts.SigningKey, joinURL(d.opts.TemporaryURL, cleaned), time.Now().Add(expiry)), nil
+}
+
+// Paths, diskteki dosya yollarını alfabetik sırayla döner.
+func (d *MemoryDisk) Paths() []string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	paths := make([]string, 0, len(d.files))
+	for p := range d.files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// List, dir altındaki dosyaları yol sırasıyla döner.
+func (d *MemoryDisk) List(_ context.Context, dir string) ([]FileInfo, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	var files []FileInfo
+	for p, content := range d.files {
+		if inDir(p, dir) {
+			files = append(files, FileInfo{Path: p, Size: int64(len(content)), LastModified: d.modified[p]})
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, nil
+}
+
+// Touch, dosyanın son değişiklik zamanını ayarlar. Yetim dosya taramasında bekleme
+// süresini test etmek içindir.
+func (d *MemoryDisk) Touch(p string, modified time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.files[p]; ok {
+		d.modified[p] = modified
+	}
+}
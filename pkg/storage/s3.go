@@ -0,0 +1,504 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	s3Algorithm       = "AWS4-HMAC-SHA256"
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+
+	// s3MaxPresignExpiry, SigV4 imzalı URL'lerin azami geçerlilik süresidir.
+	s3MaxPresignExpiry = 7 * 24 * time.Hour
+)
+
+// S3Options, S3Disk ayarlarıdır. AWS S3 dışında MinIO, Cloudflare R2, DigitalOcean
+// Spaces gibi S3 uyumlu servisler Endpoint ile kullanılır.
+type S3Options struct {
+	// Endpoint, servis adresidir (örn: "http://localhost:9000"). Boşsa
+	// "https://s3.<region>.amazonaws.com" kullanılır.
+	Endpoint string
+
+	// Region, imzada kullanılan bölgedir. Varsayılan: "us-east-1".
+	Region string
+
+	// Bucket, dosyaların yazılacağı bucket'tır (zorunlu).
+	Bucket string
+
+	// AccessKey ve SecretKey, istekleri imzalayan kimlik bilgileridir.
+	AccessKey string
+	SecretKey string
+
+	// SessionToken, geçici (STS) kimlik bilgileri için oturum token'ıdır.
+	SessionToken string
+
+	// PathStyle, bucket'ı host yerine yolda kullanır (http://host/bucket/key).
+	// MinIO ve çoğu self-hosted servis için gereklidir.
+	PathStyle bool
+
+	// Root, tüm anahtarların önüne eklenen klasördür (örn: "panel/uploads").
+	Root string
+
+	// URL, herkese açık dosyaların temel adresidir (örn: CDN). Boşsa bucket
+	// adresi kullanılır.
+	URL string
+
+	// HTTPClient, istekler için kullanılır. Varsayılan: 30 saniye zaman aşımlı istemci.
+	HTTPClient *http.Client
+}
+
+// S3Disk, dosyaları S3 uyumlu bir object storage'da saklar. İstekler AWS
+// Signature Version 4 ile imzalanır; harici SDK gerektirmez.
+type S3Disk struct {
+	opts     S3Options
+	endpoint *url.URL
+	client   *http.Client
+	now      func() time.Time
+}
+
+// NewS3Disk, ayarları doğrular ve bir S3Disk oluşturur.
+func NewS3Disk(opts S3Options) (*S3Disk, error) {
+	if opts.Bucket == "" {
+		return nil, errors.New("storage: s3 bucket is required")
+	}
+	if opts.Region == "" {
+		opts.Region = "us-east-1"
+	}
+	if opts.Endpoint == "" {
+		opts.Endpoint = "https://s3." + opts.Region + ".amazonaws.com"
+	}
+	endpoint, err := url.Parse(strings.TrimRight(opts.Endpoint, "/"))
+	if err != nil || endpoint.Host == "" || (endpoint.Scheme != "http" && endpoint.Scheme != "https") {
+		return nil, fmt.Errorf("storage: invalid s3 endpoint %q", opts.Endpoint)
+	}
+	opts.Root = strings.Trim(opts.Root, "/")
+
+	client := opts.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &S3Disk{opts: opts, endpoint: endpoint, client: client, now: time.Now}, nil
+}
+
+// key, disk yolunu Root önekiyle object anahtarına çevirir.
+func (d *S3Disk) key(p string) (string, error) {
+	cleaned, err := CleanPath(p)
+	if err != nil {
+		return "", err
+	}
+	return Join(d.opts.Root, cleaned), nil
+}
+
+// objectURL, anahtarın bucket adresini path-style veya virtual-hosted biçiminde döner.
+func (d *S3Disk) objectURL(key string) *url.URL {
+	u := *d.endpoint
+	objectPath := "/" + key
+	if d.opts.PathStyle {
+		objectPath = "/" + d.opts.Bucket + objectPath
+	} else {
+		u.Host = d.opts.Bucket + "." + u.Host
+	}
+	u.Path = strings.TrimRight(d.endpoint.Path, "/") + objectPath
+	u.RawPath = s3EncodePath(u.Path)
+	return &u
+}
+
+func (d *S3Disk) Put(ctx context.Context, p string, r io.Reader, opts PutOptions) error {
+	key, err := d.key(p)
+	if err != nil {
+		return err
+	}
+
+	// Boyut biliniyorsa içerik akıtılır (UNSIGNED-PAYLOAD); bilinmiyorsa okunur
+	// ve gövde özeti imzaya dahil edilir.
+	payloadHash := s3UnsignedPayload
+	size := opts.Size
+	body := r
+	if size <= 0 {
+		content, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(content)
+		payloadHash = hex.EncodeToString(sum[:])
+		size = int64(len(content))
+		body = bytes.NewReader(content)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, d.objectURL(key).String(), body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if size == 0 {
+		req.Body = http.NoBody
+	}
+	if opts.ContentType != "" {
+		req.Header.Set("Content-Type", opts.ContentType)
+	}
+	resp, err := d.do(req, payloadHash)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return s3ResponseError(http.MethodPut, key, resp)
+	}
+	return nil
+}
+
+func (d *S3Disk) Get(ctx context.Context, p string) (io.ReadCloser, error) {
+	key, err := d.key(p)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.objectURL(key).String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := d.do(req, emptyPayloadHash)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode/100 != 2 {
+		defer resp.Body.Close()
+		return nil, s3ResponseError(http.MethodGet, key, resp)
+	}
+	return resp.Body, nil
+}
+
+func (d *S3Disk) Delete(ctx context.Context, p string) error {
+	key, err := d.key(p)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, d.objectURL(key).String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := d.do(req, emptyPayloadHash)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 && resp.StatusCode != http.StatusNotFound {
+		return s3ResponseError(http.MethodDelete, key, resp)
+	}
+	return nil
+}
+
+func (d *S3Disk) Exists(ctx context.Context, p string) (bool, error) {
+	key, err := d.key(p)
+	if err != nil {
+		return false, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, d.objectURL(key).String(), nil)
+	if err != nil {
+		return false, err
+	}
+	resp, err := d.do(req, emptyPayloadHash)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode/100 == 2:
+		return true, nil
+	case resp.StatusCode == http.StatusNotFound:
+		return false, nil
+	default:
+		return false, s3ResponseError(http.MethodHead, key, resp)
+	}
+}
+
+func (d *S3Disk) URL(p string) string {
+	key, err := d.key(p)
+	if err != nil {
+		return ""
+	}
+	if d.opts.URL != "" {
+		return joinURL(d.opts.URL, key)
+	}
+	return d.objectURL(key).String()
+}
+
+// TemporaryURL, SigV4 query imzalı (presigned) bir GET adresi döner. Süre
+// 1 saniye ile 7 gün arasına sıkıştırılır.
+func (d *S3Disk) TemporaryURL(_ context.Context, p string, expiry time.Duration) (string, error) {
+	key, err := d.key(p)
+	if err != nil {
+		return "", err
+	}
+	if expiry < time.Second {
+		expiry = time.Second
+	}
+	if expiry > s3MaxPresignExpiry {
+		expiry = s3MaxPresignExpiry
+	}
+
+	u := d.objectURL(key)
+	now := d.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	scope := now.Format("20060102") + "/" + d.opts.Region + "/s3/aws4_request"
+
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", s3Algorithm)
+	query.Set("X-Amz-Credential", d.opts.AccessKey+"/"+scope)
+	query.Set("X-Amz-Date", amzDate)
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expiry/time.Second)))
+	query.Set("X-Amz-SignedHeaders", "host")
+	if d.opts.SessionToken != "" {
+		query.Set("X-Amz-Security-Token", d.opts.SessionToken)
+	}
+
+	canonicalQuery := s3CanonicalQuery(query)
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		u.EscapedPath(),
+		canonicalQuery,
+		"host:" + u.Host + "\n",
+		"host",
+		s3UnsignedPayload,
+	}, "\n")
+	signature := d.signature(now, scope, canonicalRequest)
+
+	u.RawQuery = canonicalQuery + "&X-Amz-Signature=" + signature
+	return u.String(), nil
+}
+
+// List, ListObjectsV2 ile dir altındaki nesneleri sayfa sayfa okur.
+func (d *S3Disk) List(ctx context.Context, dir string) ([]FileInfo, error) {
+	prefix := d.opts.Root
+	if strings.Trim(dir, "/") != "" {
+		cleaned, err := CleanPath(dir)
+		if err != nil {
+			return nil, err
+		}
+		prefix = Join(prefix, cleaned)
+	}
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	var files []FileInfo
+	token := ""
+	for {
+		query := url.Values{}
+		query.Set("list-type", "2")
+		if prefix != "" {
+			query.Set("prefix", prefix)
+		}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		u := d.bucketURL()
+		u.RawQuery = s3CanonicalQuery(query)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := d.do(req, emptyPayloadHash)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode/100 != 2 {
+			err := s3ResponseError(http.MethodGet, prefix, resp)
+			resp.Body.Close()
+			return nil, err
+		}
+		var page struct {
+			IsTruncated           bool   `xml:"IsTruncated"`
+			NextContinuationToken string `xml:"NextContinuationToken"`
+			Contents              []struct {
+				Key          string    `xml:"Key"`
+				Size         int64     `xml:"Size"`
+				LastModified time.Time `xml:"LastModified"`
+			} `xml:"Contents"`
+		}
+		err = xml.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, object := range page.Contents {
+			p := object.Key
+			if d.opts.Root != "" {
+				p = strings.TrimPrefix(p, d.opts.Root+"/")
+			}
+			if p == "" || strings.HasSuffix(p, "/") {
+				continue
+			}
+			files = append(files, FileInfo{Path: p, Size: object.Size, LastModified: object.LastModified})
+		}
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			return files, nil
+		}
+		token = page.NextContinuationToken
+	}
+}
+
+// bucketURL, bucket'ın kök adresini path-style veya virtual-hosted biçiminde döner.
+func (d *S3Disk) bucketURL() *url.URL {
+	u := *d.endpoint
+	bucketPath := "/"
+	if d.opts.PathStyle {
+		bucketPath = "/" + d.opts.Bucket
+	} else {
+		u.Host = d.opts.Bucket + "." + u.Host
+	}
+	u.Path = strings.TrimRight(d.endpoint.Path, "/") + bucketPath
+	u.RawPath = s3EncodePath(u.Path)
+	return &u
+}
+
+// emptyPayloadHash, gövdesiz isteklerin SHA-256 özetidir.
+var emptyPayloadHash = func() string {
+	sum := sha256.Sum256(nil)
+	return hex.EncodeToString(sum[:])
+}()
+
+// do, isteği Authorization başlığıyla imzalar ve gönderir.
+func (d *S3Disk) do(req *http.Request, payloadHash string) (*http.Response, error) {
+	now := d.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	scope := now.Format("20060102") + "/" + d.opts.Region + "/s3/aws4_request"
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if d.opts.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", d.opts.SessionToken)
+	}
+
+	headers := map[string]string{
+		"host":                 req.URL.Host,
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           amzDate,
+	}
+	if d.opts.SessionToken != "" {
+		headers["x-amz-security-token"] = d.opts.SessionToken
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		s3CanonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	signature := d.signature(now, scope, canonicalRequest)
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, d.opts.AccessKey, scope, signedHeaders, signature))
+	return d.client.Do(req)
+}
+
+// signature, canonical isteğin SigV4 imzasını hesaplar.
+func (d *S3Disk) signature(now time.Time, scope, canonicalRequest string) string {
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		now.Format("20060102T150405Z"),
+		scope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+d.opts.SecretKey), now.Format("20060102"))
+	key = hmacSHA256(key, d.opts.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3CanonicalQuery, sorgu parametrelerini SigV4'ün beklediği sırada ve kodlamada birleştirir.
+func s3CanonicalQuery(values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, key := range keys {
+		items := append([]string(nil), values[key]...)
+		sort.Strings(items)
+		for _, value := range items {
+			parts = append(parts, s3Encode(key, true)+"="+s3Encode(value, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// s3EncodePath, yolu segment segment RFC 3986'ya göre kodlar.
+func s3EncodePath(p string) string {
+	return s3Encode(p, false)
+}
+
+// s3Encode, RFC 3986 unreserved karakterleri dışındaki her baytı %XX olarak kodlar.
+// encodeSlash false ise "/" korunur.
+func s3Encode(value string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// s3ResponseError, S3 hata yanıtındaki Code/Message alanlarını hataya taşır.
+func s3ResponseError(method, key string, resp *http.Response) error {
+	var payload struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	_ = xml.Unmarshal(raw, &payload)
+	if payload.Code != "" {
+		return fmt.Errorf("storage: s3 %s %s: %s: %s (%s)", method, key, resp.Status, payload.Code, payload.Message)
+	}
+	return fmt.Errorf("storage: s3 %s %s: %s", method, key, resp.Status)
+}
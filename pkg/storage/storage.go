@@ -0,0 +1,273 @@
+// Package storage, yüklenen dosyalar için değiştirilebilir bir disk arayüzü sağlar.
+//
+// Her disk dosya yazma, okuma, silme, varlık kontrolü, herkese açık URL ve süreli
+// (imzalı) URL üretme işlemlerini destekler. Yerel dosya sistemi (LocalDisk),
+// S3 uyumlu servisler (S3Disk; AWS S3, MinIO, R2 vb.) ve testler için bellek içi
+// (MemoryDisk) sürücüler hazırdır. Farklı bir servis için Disk arayüzünü uygulamak
+// yeterlidir.
+//
+// Diskler Manager üzerinde isimle kaydedilir; alanlar Store("s3", "products")
+// ile hangi diske ve klasöre yazılacaklarını belirtir.
+//
+// # Kullanım Örneği
+//
+//	disks := storage.NewManager("public")
+//	disks.Register("public", storage.NewLocalDisk(storage.LocalOptions{
+//	    Root: "./storage/public",
+//	    URL:  "/storage",
+//	}))
+//	disk, _ := disks.Disk("public")
+//	err := disk.Put(ctx, "avatars/ada.png", file, storage.PutOptions{ContentType: "image/png"})
+//	url := disk.URL("avatars/ada.png") // /storage/avatars/ada.png
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrNotFound, istenen dosya diskte yoksa döndürülür.
+	ErrNotFound = errors.New("storage: file not found")
+
+	// ErrInvalidPath, boş, ".." içeren veya disk kökünün dışına çıkan yollar için döndürülür.
+	ErrInvalidPath = errors.New("storage: invalid path")
+
+	// ErrTemporaryURLUnsupported, disk süreli URL üretemiyorsa döndürülür
+	// (örn: imza anahtarı tanımlanmamış yerel disk).
+	ErrTemporaryURLUnsupported = errors.New("storage: temporary URLs are not supported by this disk")
+
+	// ErrUnknownDisk, Manager'da kayıtlı olmayan bir disk istendiğinde döndürülür.
+	ErrUnknownDisk = errors.New("storage: unknown disk")
+
+	// ErrInvalidSignature, süreli URL'in imzası hatalı veya süresi dolmuşsa döndürülür.
+	ErrInvalidSignature = errors.New("storage: invalid or expired signature")
+)
+
+// PutOptions, Put çağrısının isteğe bağlı ayarlarıdır.
+type PutOptions struct {
+	// ContentType, dosyanın MIME tipidir (S3'te Content-Type olarak saklanır).
+	ContentType string
+
+	// Size, içeriğin bayt cinsinden boyutudur. Biliniyorsa S3 sürücüsü içeriği
+	// belleğe almadan akıtır; 0 ise içerik önce okunur.
+	Size int64
+}
+
+// Disk, dosya depolama sürücülerinin uyguladığı arayüzdür. Yollar disk köküne
+// göredir ve "/" ile ayrılır (örn: "products/42/cover.jpg").
+type Disk interface {
+	// Put, r içeriğini path'e yazar; dosya varsa üzerine yazar.
+	Put(ctx context.Context, path string, r io.Reader, opts PutOptions) error
+
+	// Get, dosyayı okumak için açar. Dosya yoksa ErrNotFound döner.
+	Get(ctx context.Context, path string) (io.ReadCloser, error)
+
+	// Delete, dosyayı siler. Olmayan dosyayı silmek hata değildir.
+	Delete(ctx context.Context, path string) error
+
+	// Exists, dosyanın var olup olmadığını döner.
+	Exists(ctx context.Context, path string) (bool, error)
+
+	// URL, dosyanın herkese açık adresini döner. Herkese açık adresi olmayan
+	// (özel) diskler boş string döner.
+	URL(path string) string
+
+	// TemporaryURL, expiry süresince geçerli imzalı bir adres döner. Özel (private)
+	// dosyaları kısa süreliğine paylaşmak için kullanılır.
+	TemporaryURL(ctx context.Context, path string, expiry time.Duration) (string, error)
+}
+
+// CleanPath, disk yolunu normalleştirir: ters eğik çizgileri "/" yapar, baştaki
+// "/" işaretini kaldırır ve ".." ile kökün dışına çıkan yolları reddeder.
+func CleanPath(p string) (string, error) {
+	p = strings.ReplaceAll(strings.TrimSpace(p), "\\", "/")
+	if p == "" {
+		return "", ErrInvalidPath
+	}
+	for _, segment := range strings.Split(p, "/") {
+		if segment == ".." {
+			return "", ErrInvalidPath
+		}
+	}
+	cleaned := strings.TrimPrefix(path.Clean("/"+p), "/")
+	if cleaned == "" || cleaned == "." {
+		return "", ErrInvalidPath
+	}
+	return cleaned, nil
+}
+
+// Join, klasör ve dosya adını disk yolu olarak birleştirir. dir boş olabilir.
+func Join(dir, name string) string {
+	dir = strings.Trim(strings.ReplaceAll(dir, "\\", "/"), "/")
+	if dir == "" {
+		return name
+	}
+	return dir + "/" + name
+}
+
+// GenerateName, yüklenen dosya için tahmin edilemeyen benzersiz bir ad üretir.
+// Orijinal dosyanın uzantısı küçük harfe çevrilerek korunur; kullanıcıdan gelen
+// ad yol olarak kullanılmaz.
+func GenerateName(original string) string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("%d%s", time.Now().UnixNano(), safeExtension(original))
+	}
+	return hex.EncodeToString(buf) + safeExtension(original)
+}
+
+// safeExtension, yalnızca harf ve rakamdan oluşan uzantıları kabul eder.
+func safeExtension(name string) string {
+	ext := strings.ToLower(filepath.Ext(filepath.Base(strings.ReplaceAll(name, "\\", "/"))))
+	if len(ext) < 2 || len(ext) > 16 {
+		return ""
+	}
+	for _, r := range ext[1:] {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			return ""
+		}
+	}
+	return ext
+}
+
+// Manager, isimlendirilmiş diskleri tutar. Eşzamanlı kullanım için güvenlidir.
+type Manager struct {
+	mu          sync.RWMutex
+	disks       map[string]Disk
+	defaultDisk string
+}
+
+// NewManager, defaultDisk adını varsayılan kabul eden boş bir Manager oluşturur.
+func NewManager(defaultDisk string) *Manager {
+	return &Manager{
+		disks:       make(map[string]Disk),
+		defaultDisk: defaultDisk,
+	}
+}
+
+// Register, diski verilen adla kaydeder; aynı adlı disk varsa değiştirilir.
+func (m *Manager) Register(name string, disk Disk) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.disks[name] = disk
+}
+
+// Disk, adı verilen diski döner. Boş ad varsayılan diski ifade eder.
+func (m *Manager) Disk(name string) (Disk, error) {
+	if m == nil {
+		return nil, ErrUnknownDisk
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if name == "" {
+		name = m.defaultDisk
+	}
+	disk, ok := m.disks[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownDisk, name)
+	}
+	return disk, nil
+}
+
+// Default, varsayılan diskin adını döner.
+func (m *Manager) Default() string {
+	if m == nil {
+		return ""
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.defaultDisk
+}
+
+// Names, kayıtlı disk adlarını alfabetik sırayla döner.
+func (m *Manager) Names() []string {
+	if m == nil {
+		return nil
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	names := make([]string, 0, len(m.disks))
+	for name := range m.disks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SignURL, rawURL'e expires ve signature sorgu parametrelerini ekler. İmza,
+// URL yolu ve son geçerlilik zamanı üzerinden HMAC-SHA256 ile hesaplanır;
+// VerifySignedURL aynı anahtarla doğrular.
+func SignURL(key []byte, rawURL string, expiresAt time.Time) string {
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+	separator := "?"
+	if strings.Contains(rawURL, "?") {
+		separator = "&"
+	}
+	return rawURL + separator + "expires=" + expires + "&signature=" + urlSignature(key, urlPathOf(rawURL), expires)
+}
+
+// VerifySignedURL, SignURL ile üretilmiş bir adresin yolunu, expires ve
+// signature değerlerini now anına göre doğrular.
+func VerifySignedURL(key []byte, urlPath, expires, signature string, now time.Time) error {
+	if len(key) == 0 || expires == "" || signature == "" {
+		return ErrInvalidSignature
+	}
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || now.Unix() > unix {
+		return ErrInvalidSignature
+	}
+	expected := urlSignature(key, urlPath, expires)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func urlSignature(key []byte, urlPath, expires string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(urlPath + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// urlPathOf, mutlak veya göreli adresin çözülmüş (unescape) yol kısmını döner.
+func urlPathOf(rawURL string) string {
+	if i := strings.Index(rawURL, "?"); i >= 0 {
+		rawURL = rawURL[:i]
+	}
+	if i := strings.Index(rawURL, "://"); i >= 0 {
+		rest := rawURL[i+3:]
+		rawURL = "/"
+		if j := strings.Index(rest, "/"); j >= 0 {
+			rawURL = rest[j:]
+		}
+	}
+	if decoded, err := url.PathUnescape(rawURL); err == nil {
+		return decoded
+	}
+	return rawURL
+}
+
+// joinURL, temel adres ile disk yolunu tek "/" ile birleştirir; yol segmentleri
+// URL için kodlanır.
+func joinURL(base, p string) string {
+	segments := strings.Split(strings.TrimLeft(p, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.TrimRight(base, "/") + "/" + strings.Join(segments, "/")
+}
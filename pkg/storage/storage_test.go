@@ -0,0 +1,248 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testDisk exercises the Disk contract shared by every driver.
+func testDisk(t *testing.T, disk Disk, prefix string) {
+	t.Helper()
+	ctx := context.Background()
+	name := prefix + "products/42/cover.jpg"
+
+	if ok, err := disk.Exists(ctx, name); err != nil || ok {
+		t.Fatalf("expected missing file before Put, got %v (err=%v)", ok, err)
+	}
+	if _, err := disk.Get(ctx, name); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound before Put, got %v", err)
+	}
+
+	if err := disk.Put(ctx, name, strings.NewReader("first"), PutOptions{ContentType: "image/jpeg"}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if err := disk.Put(ctx, name, strings.NewReader("second"), PutOptions{ContentType: "image/jpeg", Size: 6}); err != nil {
+		t.Fatalf("overwriting Put failed: %v", err)
+	}
+	if ok, err := disk.Exists(ctx, name); err != nil || !ok {
+		t.Fatalf("expected file after Put, got %v (err=%v)", ok, err)
+	}
+
+	reader, err := disk.Get(ctx, name)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	content, _ := io.ReadAll(reader)
+	reader.Close()
+	if string(content) != "second" {
+		t.Fatalf("expected overwritten content, got %q", content)
+	}
+
+	if err := disk.Put(ctx, "../escape.txt", strings.NewReader("x"), PutOptions{}); !errors.Is(err, ErrInvalidPath) {
+		t.Fatalf("expected ErrInvalidPath for traversal, got %v", err)
+	}
+
+	if err := disk.Delete(ctx, name); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := disk.Delete(ctx, name); err != nil {
+		t.Fatalf("deleting a missing file should not fail: %v", err)
+	}
+	if ok, _ := disk.Exists(ctx, name); ok {
+		t.Fatal("expected file to be gone after Delete")
+	}
+}
+
+// testList checks that List walks nested folders below dir only.
+func testList(t *testing.T, disk interface {
+	Disk
+	Lister
+}) {
+	t.Helper()
+	ctx := context.Background()
+	for _, name := range []string{"gallery/a.png", "gallery/2026/b.png", "galleryx/c.png", "other/d.png"} {
+		if err := disk.Put(ctx, name, strings.NewReader(name), PutOptions{}); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+
+	files, err := disk.List(ctx, "gallery")
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	var paths []string
+	for _, file := range files {
+		paths = append(paths, file.Path)
+		if file.Size != int64(len(file.Path)) || file.LastModified.IsZero() {
+			t.Fatalf("expected size and modification time for %s, got %+v", file.Path, file)
+		}
+	}
+	if strings.Join(paths, ",") != "gallery/2026/b.png,gallery/a.png" {
+		t.Fatalf("unexpected listing %v", paths)
+	}
+
+	if files, err := disk.List(ctx, "missing"); err != nil || len(files) != 0 {
+		t.Fatalf("expected empty listing for a missing folder, got %v (err=%v)", files, err)
+	}
+	if all, err := disk.List(ctx, ""); err != nil || len(all) < 4 {
+		t.Fatalf("expected whole disk listing, got %v (err=%v)", all, err)
+	}
+}
+
+func TestPathFromValue(t *testing.T) {
+	public := NewMemoryDisk(MemoryOptions{URL: "https://cdn.example.com/media"})
+	private := NewMemoryDisk(MemoryOptions{})
+	cases := []struct {
+		disk  Disk
+		value string
+		want  string
+		ok    bool
+	}{
+		{public, "https://cdn.example.com/media/products/a%20b.png", "products/a b.png", true},
+		{public, "products/a.png", "products/a.png", true},
+		{public, "https://other.example.com/products/a.png", "", false},
+		{private, "manuals/guide.pdf", "manuals/guide.pdf", true},
+		{private, "/uploads/legacy.pdf", "", false},
+		{private, "../secret.txt", "", false},
+		{private, "", "", false},
+	}
+	for _, tc := range cases {
+		got, ok := PathFromValue(tc.disk, tc.value)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("PathFromValue(%q) = %q, %v; want %q, %v", tc.value, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestMemoryDisk(t *testing.T) {
+	disk := NewMemoryDisk(MemoryOptions{URL: "/memory"})
+	testDisk(t, disk, "")
+	testList(t, disk)
+
+	if got := disk.URL("a b/c.txt"); got != "/memory/a%20b/c.txt" {
+		t.Fatalf("unexpected URL %q", got)
+	}
+	if got := NewMemoryDisk(MemoryOptions{}).URL("c.txt"); got != "" {
+		t.Fatalf("expected no public URL for a private disk, got %q", got)
+	}
+}
+
+func TestLocalDisk(t *testing.T) {
+	root := t.TempDir()
+	disk := NewLocalDisk(LocalOptions{
+		Root:         root,
+		URL:          "/storage/",
+		TemporaryURL: "/api/storage/public",
+		SigningKey:   []byte("secret"),
+	})
+	testDisk(t, disk, "")
+	testList(t, disk)
+
+	ctx := context.Background()
+	if err := disk.Put(ctx, "/docs/report.pdf", strings.NewReader("pdf"), PutOptions{}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "docs", "report.pdf")); err != nil {
+		t.Fatalf("expected file under root: %v", err)
+	}
+	leftovers, _ := filepath.Glob(filepath.Join(root, "docs", ".upload-*"))
+	if len(leftovers) != 0 {
+		t.Fatalf("expected no temporary files, got %v", leftovers)
+	}
+	if got := disk.URL("docs/report.pdf"); got != "/storage/docs/report.pdf" {
+		t.Fatalf("unexpected URL %q", got)
+	}
+
+	signed, err := disk.TemporaryURL(ctx, "docs/report.pdf", time.Minute)
+	if err != nil {
+		t.Fatalf("TemporaryURL failed: %v", err)
+	}
+	path, query, _ := strings.Cut(signed, "?")
+	if path != "/api/storage/public/docs/report.pdf" {
+		t.Fatalf("unexpected temporary URL %q", signed)
+	}
+	values := parseQuery(query)
+	if err := VerifySignedURL([]byte("secret"), path, values["expires"], values["signature"], time.Now()); err != nil {
+		t.Fatalf("expected valid signature: %v", err)
+	}
+	if err := VerifySignedURL([]byte("secret"), "/api/storage/public/docs/other.pdf", values["expires"], values["signature"], time.Now()); err == nil {
+		t.Fatal("expected signature to be bound to the path")
+	}
+	if err := VerifySignedURL([]byte("secret"), path, values["expires"], values["signature"], time.Now().Add(2*time.Minute)); err == nil {
+		t.Fatal("expected expired signature to be rejected")
+	}
+
+	unsigned := NewLocalDisk(LocalOptions{Root: root})
+	if _, err := unsigned.TemporaryURL(ctx, "docs/report.pdf", time.Minute); !errors.Is(err, ErrTemporaryURLUnsupported) {
+		t.Fatalf("expected ErrTemporaryURLUnsupported without a signing key, got %v", err)
+	}
+}
+
+func parseQuery(raw string) map[string]string {
+	values := map[string]string{}
+	for _, pair := range strings.Split(raw, "&") {
+		key, value, _ := strings.Cut(pair, "=")
+		values[key] = value
+	}
+	return values
+}
+
+func TestCleanPath(t *testing.T) {
+	valid := map[string]string{
+		"a/b.txt":         "a/b.txt",
+		"/a//b.txt":       "a/b.txt",
+		"a\\b.txt":        "a/b.txt",
+		"./a/./b.txt":     "a/b.txt",
+		" avatars/x.png ": "avatars/x.png",
+	}
+	for input, want := range valid {
+		if got, err := CleanPath(input); err != nil || got != want {
+			t.Errorf("CleanPath(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	for _, input := range []string{"", "/", ".", "../a", "a/../../b", "a\\..\\b"} {
+		if _, err := CleanPath(input); !errors.Is(err, ErrInvalidPath) {
+			t.Errorf("CleanPath(%q) expected ErrInvalidPath, got %v", input, err)
+		}
+	}
+}
+
+func TestGenerateName(t *testing.T) {
+	first, second := GenerateName("Photo.JPG"), GenerateName("Photo.JPG")
+	if first == second || !strings.HasSuffix(first, ".jpg") || len(first) != 36 {
+		t.Fatalf("unexpected generated names %q, %q", first, second)
+	}
+	if got := GenerateName("../../etc/passwd"); strings.Contains(got, "/") || strings.Contains(got, ".") {
+		t.Fatalf("expected name without path or extension, got %q", got)
+	}
+	if got := GenerateName("shell.p hp"); strings.Contains(got, " ") {
+		t.Fatalf("expected unsafe extension to be dropped, got %q", got)
+	}
+}
+
+func TestManager(t *testing.T) {
+	manager := NewManager("public")
+	public := NewMemoryDisk(MemoryOptions{URL: "/public"})
+	private := NewMemoryDisk(MemoryOptions{})
+	manager.Register("public", public)
+	manager.Register("private", private)
+
+	if disk, err := manager.Disk(""); err != nil || disk != public {
+		t.Fatalf("expected default disk, got %v (err=%v)", disk, err)
+	}
+	if disk, err := manager.Disk("private"); err != nil || disk != private {
+		t.Fatalf("expected private disk, got %v (err=%v)", disk, err)
+	}
+	if _, err := manager.Disk("s3"); !errors.Is(err, ErrUnknownDisk) {
+		t.Fatalf("expected ErrUnknownDisk, got %v", err)
+	}
+	if names := manager.Names(); strings.Join(names, ",") != "private,public" {
+		t.Fatalf("unexpected names %v", names)
+	}
+}
@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"mime/multipart"
+)
+
+// Upload, diske yazılacak bir dosyanın adını, tipini, boyutunu ve içeriğini
+// açan fonksiyonu taşır. Multipart dosyaları ve sunucuda biriktirilmiş dosyalar
+// (örn: devam ettirilebilir yüklemeler) aynı yolla diske yazılır.
+type Upload struct {
+	Filename    string
+	ContentType string
+	Size        int64
+	Open        func() (io.ReadCloser, error)
+}
+
+// FileHeaderUpload, multipart ile yüklenen dosyayı Upload'a çevirir.
+func FileHeaderUpload(file *multipart.FileHeader) Upload {
+	return Upload{
+		Filename:    file.Filename,
+		ContentType: file.Header.Get("Content-Type"),
+		Size:        file.Size,
+		Open: func() (io.ReadCloser, error) {
+			return file.Open()
+		},
+	}
+}
+
+// PutFile, dosyayı dir klasörüne benzersiz bir adla yazar ve disk içi yolu
+// döner. İçerik ara kopya oluşturulmadan doğrudan Disk.Put'a aktarılır.
+func PutFile(ctx context.Context, disk Disk, dir string, file Upload) (string, error) {
+	src, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	p := Join(dir, GenerateName(file.Filename))
+	if _, err := CleanPath(p); err != nil {
+		return "", err
+	}
+	err = disk.Put(ctx, p, src, PutOptions{
+		ContentType: file.ContentType,
+		Size:        file.Size,
+	})
+	if err != nil {
+		return "", err
+	}
+	return p, nil
+}
+
+// PutUpload, multipart ile yüklenen dosyayı dir klasörüne benzersiz bir adla
+// yazar ve disk içi yolu döner.
+func PutUpload(ctx context.Context, disk Disk, dir string, file *multipart.FileHeader) (string, error) {
+	return PutFile(ctx, disk, dir, FileHeaderUpload(file))
+}
+
+// StoreUpload, dosyayı PutUpload ile yazar. Diskin herkese açık URL'i varsa URL,
+// yoksa (özel diskler) disk yolu döner; dönen değer alanın veritabanındaki
+// değeri olarak saklanır.
+func StoreUpload(ctx context.Context, disk Disk, dir string, file *multipart.FileHeader) (string, error) {
+	p, err := PutUpload(ctx, disk, dir, file)
+	if err != nil {
+		return "", err
+	}
+	if url := disk.URL(p); url != "" {
+		return url, nil
+	}
+	return p, nil
+}
@@ -0,0 +1,343 @@
+package storage
+
+import (
+	"container/heap"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/draw"
+	"io"
+)
+
+// webpMaxDimension, VP8L başlığındaki 14 bitlik genişlik/yükseklik sınırıdır.
+const webpMaxDimension = 1 << 14
+
+// webpCodeLengthOrder, kod uzunluğu kodunun uzunluklarının yazıldığı sıradır.
+var webpCodeLengthOrder = [19]int{17, 18, 0, 1, 2, 3, 4, 5, 16, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+// webpAlphabetSizes, yeşil (256 literal + 24 uzunluk öneki), kırmızı, mavi, alfa
+// ve mesafe prefix kodlarının alfabe boyutlarıdır (renk önbelleği kullanılmaz).
+var webpAlphabetSizes = [5]int{256 + 24, 256, 256, 256, 40}
+
+// ErrImageTooLarge, WebP'nin desteklediği boyutu aşan resimler için döner.
+var ErrImageTooLarge = errors.New("storage: image is too large to encode")
+
+// EncodeWebP, resmi kayıpsız WebP (VP8L) olarak yazar.
+//
+// Kodlayıcı yalnızca "subtract green" dönüşümü ve piksel başına literal prefix
+// kodları kullanır; geri referans ve renk önbelleği yoktur. Çıktı tüm WebP
+// çözücülerle uyumludur, sıkıştırma oranı libwebp'nin kayıpsız kipinden düşüktür.
+func EncodeWebP(w io.Writer, img image.Image) error {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width < 1 || height < 1 || width > webpMaxDimension || height > webpMaxDimension {
+		return ErrImageTooLarge
+	}
+
+	nrgba, ok := img.(*image.NRGBA)
+	if !ok || nrgba.Stride != width*4 {
+		nrgba = image.NewNRGBA(image.Rect(0, 0, width, height))
+		draw.Draw(nrgba, nrgba.Bounds(), img, bounds.Min, draw.Src)
+	}
+
+	// Subtract green: kırmızı ve maviden yeşil çıkarılır, çözücü geri ekler
+	pixels := make([]byte, width*height*4)
+	copy(pixels, nrgba.Pix)
+	var histograms [5][]int
+	for i, size := range webpAlphabetSizes {
+		histograms[i] = make([]int, size)
+	}
+	hasAlpha := false
+	for i := 0; i < len(pixels); i += 4 {
+		r, g, b, a := pixels[i], pixels[i+1], pixels[i+2], pixels[i+3]
+		pixels[i], pixels[i+2] = r-g, b-g
+		histograms[0][g]++
+		histograms[1][pixels[i]]++
+		histograms[2][pixels[i+2]]++
+		histograms[3][a]++
+		if a != 0xff {
+			hasAlpha = true
+		}
+	}
+
+	bw := &webpBitWriter{}
+	bw.write(0x2f, 8) // VP8L imzası
+	bw.write(uint32(width-1), 14)
+	bw.write(uint32(height-1), 14)
+	if hasAlpha {
+		bw.write(1, 1)
+	} else {
+		bw.write(0, 1)
+	}
+	bw.write(0, 3) // sürüm
+
+	bw.write(1, 1) // dönüşüm var
+	bw.write(2, 2) // subtract green
+	bw.write(0, 1) // başka dönüşüm yok
+	bw.write(0, 1) // renk önbelleği yok
+	bw.write(0, 1) // tek prefix kod grubu
+
+	var codes [5]webpPrefixCode
+	for i := range codes {
+		codes[i] = newWebPPrefixCode(histograms[i])
+		codes[i].writeTo(bw)
+	}
+	for i := 0; i < len(pixels); i += 4 {
+		codes[0].writeSymbol(bw, int(pixels[i+1]))
+		codes[1].writeSymbol(bw, int(pixels[i]))
+		codes[2].writeSymbol(bw, int(pixels[i+2]))
+		codes[3].writeSymbol(bw, int(pixels[i+3]))
+	}
+	data := bw.flush()
+
+	padding := len(data) & 1
+	header := make([]byte, 20)
+	copy(header[0:], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:], uint32(4+8+len(data)+padding))
+	copy(header[8:], "WEBPVP8L")
+	binary.LittleEndian.PutUint32(header[16:], uint32(len(data)))
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if padding == 1 {
+		_, err := w.Write([]byte{0})
+		return err
+	}
+	return nil
+}
+
+// webpBitWriter, bitleri VP8L'nin beklediği gibi en düşük bitten başlayarak yazar.
+type webpBitWriter struct {
+	buf   []byte
+	acc   uint64
+	nbits uint
+}
+
+func (bw *webpBitWriter) write(value uint32, bits uint) {
+	bw.acc |= uint64(value) << bw.nbits
+	bw.nbits += bits
+	for bw.nbits >= 8 {
+		bw.buf = append(bw.buf, byte(bw.acc))
+		bw.acc >>= 8
+		bw.nbits -= 8
+	}
+}
+
+func (bw *webpBitWriter) flush() []byte {
+	if bw.nbits > 0 {
+		bw.buf = append(bw.buf, byte(bw.acc))
+		bw.acc, bw.nbits = 0, 0
+	}
+	return bw.buf
+}
+
+// webpPrefixCode, tek bir alfabenin kanonik Huffman kodudur. En fazla iki
+// sembol kullanılıyorsa ve semboller 256'dan küçükse "simple" kod olarak yazılır.
+type webpPrefixCode struct {
+	lengths []uint8
+	codes   []uint32 // bit sırası ters çevrilmiş kodlar
+	simple  []int
+}
+
+func newWebPPrefixCode(histogram []int) webpPrefixCode {
+	var used []int
+	for symbol, count := range histogram {
+		if count > 0 {
+			used = append(used, symbol)
+		}
+	}
+	if len(used) <= 2 && (len(used) == 0 || used[len(used)-1] < 256) {
+		if len(used) == 0 {
+			used = []int{0}
+		}
+		lengths := make([]uint8, len(histogram))
+		if len(used) == 2 {
+			lengths[used[0]], lengths[used[1]] = 1, 1
+		}
+		return webpPrefixCode{lengths: lengths, codes: canonicalCodes(lengths), simple: used}
+	}
+
+	lengths := huffmanLengths(histogram, 15)
+	return webpPrefixCode{lengths: lengths, codes: canonicalCodes(lengths)}
+}
+
+func (pc webpPrefixCode) writeTo(bw *webpBitWriter) {
+	if pc.simple != nil {
+		bw.write(1, 1)
+		bw.write(uint32(len(pc.simple)-1), 1)
+		if first := pc.simple[0]; first < 2 {
+			bw.write(0, 1)
+			bw.write(uint32(first), 1)
+		} else {
+			bw.write(1, 1)
+			bw.write(uint32(first), 8)
+		}
+		if len(pc.simple) == 2 {
+			bw.write(uint32(pc.simple[1]), 8)
+		}
+		return
+	}
+
+	// Uzunluklar, kod uzunluğu kodunun 0-15 literal sembolleriyle yazılır
+	bw.write(0, 1)
+	histogram := make([]int, len(webpCodeLengthOrder))
+	for _, length := range pc.lengths {
+		histogram[length]++
+	}
+	used := 0
+	for _, count := range histogram {
+		if count > 0 {
+			used++
+		}
+	}
+	if used == 1 {
+		// Tek sembollü kod çözücülerde 0 bit olarak yorumlanır; tam bir kod için ikinci sembol eklenir
+		if histogram[0] == 0 {
+			histogram[0] = 1
+		} else {
+			histogram[1] = 1
+		}
+	}
+	clLengths := huffmanLengths(histogram, 7)
+	clCodes := canonicalCodes(clLengths)
+
+	count := len(webpCodeLengthOrder)
+	for count > 4 && clLengths[webpCodeLengthOrder[count-1]] == 0 {
+		count--
+	}
+	bw.write(uint32(count-4), 4)
+	for i := 0; i < count; i++ {
+		bw.write(uint32(clLengths[webpCodeLengthOrder[i]]), 3)
+	}
+	bw.write(0, 1) // max_symbol alfabe boyutudur
+	for _, length := range pc.lengths {
+		bw.write(clCodes[length], uint(clLengths[length]))
+	}
+}
+
+func (pc webpPrefixCode) writeSymbol(bw *webpBitWriter, symbol int) {
+	if length := pc.lengths[symbol]; length > 0 {
+		bw.write(pc.codes[symbol], uint(length))
+	}
+}
+
+// huffmanLengths, histogramdan en fazla limit uzunluğunda Huffman kod uzunlukları
+// üretir. Ağaç derinliği sınırı aşarsa düşük frekanslar yükseltilerek yeniden
+// denenir; sonuç her zaman tam (complete) bir koddur.
+func huffmanLengths(histogram []int, limit int) []uint8 {
+	lengths := make([]uint8, len(histogram))
+	for minCount := 1; ; minCount *= 2 {
+		h := &huffmanHeap{}
+		var parents []int
+		for symbol, count := range histogram {
+			if count <= 0 {
+				continue
+			}
+			if count < minCount {
+				count = minCount
+			}
+			*h = append(*h, huffmanNode{weight: count, id: len(parents), symbol: symbol})
+			parents = append(parents, -1)
+		}
+		leaves := len(parents)
+		if leaves < 2 {
+			for _, node := range *h {
+				lengths[node.symbol] = 1
+			}
+			return lengths
+		}
+
+		heap.Init(h)
+		for h.Len() > 1 {
+			a := heap.Pop(h).(huffmanNode)
+			b := heap.Pop(h).(huffmanNode)
+			parent := len(parents)
+			parents = append(parents, -1)
+			parents[a.id], parents[b.id] = parent, parent
+			heap.Push(h, huffmanNode{weight: a.weight + b.weight, id: parent, symbol: -1})
+		}
+
+		maxDepth := 0
+		depths := make([]int, leaves)
+		for i := 0; i < leaves; i++ {
+			for node := i; parents[node] >= 0; node = parents[node] {
+				depths[i]++
+			}
+			if depths[i] > maxDepth {
+				maxDepth = depths[i]
+			}
+		}
+		if maxDepth > limit {
+			continue
+		}
+
+		leaf := 0
+		for symbol, count := range histogram {
+			if count > 0 {
+				lengths[symbol] = uint8(depths[leaf])
+				leaf++
+			}
+		}
+		return lengths
+	}
+}
+
+// canonicalCodes, uzunluklardan kanonik kodları üretir ve bitlerini ters çevirir;
+// VP8L kodun ilk bitini akıştaki ilk (en düşük) bit olarak okur.
+func canonicalCodes(lengths []uint8) []uint32 {
+	var counts [16]int
+	for _, length := range lengths {
+		if length > 0 {
+			counts[length]++
+		}
+	}
+	var next [16]uint32
+	code := uint32(0)
+	for bits := 1; bits < len(next); bits++ {
+		code = (code + uint32(counts[bits-1])) << 1
+		next[bits] = code
+	}
+
+	codes := make([]uint32, len(lengths))
+	for symbol, length := range lengths {
+		if length == 0 {
+			continue
+		}
+		value := next[length]
+		next[length]++
+		reversed := uint32(0)
+		for i := uint8(0); i < length; i++ {
+			reversed = reversed<<1 | (value>>i)&1
+		}
+		codes[symbol] = reversed
+	}
+	return codes
+}
+
+type huffmanNode struct {
+	weight int
+	id     int
+	symbol int
+}
+
+// huffmanHeap, ağırlığa (eşitlikte ekleme sırasına) göre sıralı min-heap'tir.
+type huffmanHeap []huffmanNode
+
+func (h huffmanHeap) Len() int { return len(h) }
+func (h huffmanHeap) Less(i, j int) bool {
+	if h[i].weight != h[j].weight {
+		return h[i].weight < h[j].weight
+	}
+	return h[i].id < h[j].id
+}
+func (h huffmanHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *huffmanHeap) Push(x interface{}) { *h = append(*h, x.(huffmanNode)) }
+func (h *huffmanHeap) Pop() interface{} {
+	old := *h
+	node := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return node
+}
@@ -0,0 +1,189 @@
+package encrypt
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// EncryptedValuePrefix, Keyring tarafından üretilen şifreli değerlerin ön ekidir.
+//
+// Şifreli değer formatı: `enc:v1:<anahtar-id>:<base64(nonce + ciphertext)>`
+//
+// Anahtar ID'sinin şifreli değere gömülmesi sayesinde, birincil anahtar değiştirildikten
+// sonra da eski anahtarla şifrelenmiş değerler çözülebilir (anahtar rotasyonu).
+const EncryptedValuePrefix = "enc:v1:"
+
+var (
+	// ErrUnknownKeyID, şifreli değerdeki anahtar ID'si keyring'de bulunmadığında döner.
+	ErrUnknownKeyID = errors.New("encrypt: unknown key id")
+
+	// ErrInvalidEncryptedValue, değer Keyring formatında değilse döner.
+	ErrInvalidEncryptedValue = errors.New("encrypt: invalid encrypted value")
+)
+
+// Keyring, ID'lendirilmiş birden fazla AES-GCM anahtarını yönetir.
+//
+// Yeni değerler her zaman birincil anahtar ile şifrelenir; çözme işlemi ise
+// değerin içindeki anahtar ID'sine göre ilgili anahtarla yapılır. Keyring,
+// Crypt interface'ini uygular.
+//
+// # Kullanım Örneği
+//
+//	keyring, err := encrypt.NewKeyringFromHex("2025-01", map[string]string{
+//	    "2024-06": os.Getenv("FIELD_KEY_2024_06"),
+//	    "2025-01": os.Getenv("FIELD_KEY_2025_01"),
+//	})
+//	ciphertext, _ := keyring.Encrypt("12345678901") // enc:v1:2025-01:...
+type Keyring struct {
+	primaryID string
+	ciphers   map[string]*CryptGCM
+}
+
+// NewKeyring, ham anahtarlardan yeni bir Keyring oluşturur.
+//
+// Anahtarlar 16, 24 veya 32 byte olmalıdır. primaryID boş bırakılırsa ve yalnızca
+// tek anahtar varsa, o anahtar birincil kabul edilir.
+func NewKeyring(primaryID string, keys map[string][]byte) (*Keyring, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("encrypt: keyring requires at least one key")
+	}
+
+	primaryID = strings.TrimSpace(primaryID)
+	if primaryID == "" {
+		if len(keys) > 1 {
+			return nil, errors.New("encrypt: primary key id is required when multiple keys are configured")
+		}
+		for id := range keys {
+			primaryID = id
+		}
+	}
+
+	ciphers := make(map[string]*CryptGCM, len(keys))
+	for id, key := range keys {
+		if id == "" || strings.Contains(id, ":") {
+			return nil, fmt.Errorf("encrypt: invalid key id %q", id)
+		}
+		switch len(key) {
+		case 16, 24, 32:
+		default:
+			return nil, fmt.Errorf("encrypt: key %q must be 16, 24 or 32 bytes, got %d", id, len(key))
+		}
+		ciphers[id] = NewCryptGCM(key)
+	}
+
+	if _, ok := ciphers[primaryID]; !ok {
+		return nil, fmt.Errorf("encrypt: primary key %q is not configured", primaryID)
+	}
+
+	return &Keyring{primaryID: primaryID, ciphers: ciphers}, nil
+}
+
+// NewKeyringFromHex, hex kodlanmış anahtarlardan yeni bir Keyring oluşturur.
+// Anahtar formatı NewCrypt ile aynıdır (örn: `openssl rand -hex 32`).
+func NewKeyringFromHex(primaryID string, keys map[string]string) (*Keyring, error) {
+	raw := make(map[string][]byte, len(keys))
+	for id, value := range keys {
+		key, err := hex.DecodeString(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("encrypt: key %q is not valid hex: %w", id, err)
+		}
+		raw[id] = key
+	}
+	return NewKeyring(primaryID, raw)
+}
+
+// PrimaryKeyID, yeni değerleri şifrelemek için kullanılan anahtarın ID'sini döndürür.
+func (k *Keyring) PrimaryKeyID() string {
+	return k.primaryID
+}
+
+// KeyIDs, keyring'deki tüm anahtar ID'lerini sıralı olarak döndürür.
+func (k *Keyring) KeyIDs() []string {
+	ids := make([]string, 0, len(k.ciphers))
+	for id := range k.ciphers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Encrypt, düz metni birincil anahtar ile şifreler ve anahtar ID'sini değere gömer.
+func (k *Keyring) Encrypt(plaintext string) (string, error) {
+	ciphertext, err := k.ciphers[k.primaryID].Encrypt(plaintext)
+	if err != nil {
+		return "", err
+	}
+	return EncryptedValuePrefix + k.primaryID + ":" + ciphertext, nil
+}
+
+// Decrypt, Keyring formatındaki değeri, içindeki anahtar ID'sine ait anahtarla çözer.
+func (k *Keyring) Decrypt(value string) (string, error) {
+	keyID, ciphertext, ok := splitEncryptedValue(value)
+	if !ok {
+		return "", ErrInvalidEncryptedValue
+	}
+	cipher, exists := k.ciphers[keyID]
+	if !exists {
+		return "", fmt.Errorf("%w: %s", ErrUnknownKeyID, keyID)
+	}
+	return cipher.Decrypt(ciphertext)
+}
+
+// NeedsRotation, değerin düz metin olduğunu veya birincil olmayan bir anahtarla
+// şifrelendiğini bildirir. Re-encrypt işlemleri bu kontrolü kullanır.
+func (k *Keyring) NeedsRotation(value string) bool {
+	keyID, _, ok := splitEncryptedValue(value)
+	return !ok || keyID != k.primaryID
+}
+
+// Rotate, değeri birincil anahtarla yeniden şifreler.
+// Düz metin değerler doğrudan şifrelenir; zaten birincil anahtarla şifrelenmiş
+// değerler değiştirilmeden döner (ikinci dönüş değeri false olur).
+func (k *Keyring) Rotate(value string) (string, bool, error) {
+	if !k.NeedsRotation(value) {
+		return value, false, nil
+	}
+
+	plaintext := value
+	if IsEncryptedValue(value) {
+		decrypted, err := k.Decrypt(value)
+		if err != nil {
+			return "", false, err
+		}
+		plaintext = decrypted
+	}
+
+	rotated, err := k.Encrypt(plaintext)
+	if err != nil {
+		return "", false, err
+	}
+	return rotated, true, nil
+}
+
+// IsEncryptedValue, değerin Keyring formatında şifrelenmiş olup olmadığını bildirir.
+func IsEncryptedValue(value string) bool {
+	_, _, ok := splitEncryptedValue(value)
+	return ok
+}
+
+// EncryptedValueKeyID, şifreli değerin anahtar ID'sini döndürür.
+// Değer Keyring formatında değilse boş string döner.
+func EncryptedValueKeyID(value string) string {
+	keyID, _, _ := splitEncryptedValue(value)
+	return keyID
+}
+
+func splitEncryptedValue(value string) (keyID string, ciphertext string, ok bool) {
+	if !strings.HasPrefix(value, EncryptedValuePrefix) {
+		return "", "", false
+	}
+	rest := strings.TrimPrefix(value, EncryptedValuePrefix)
+	separator := strings.IndexByte(rest, ':')
+	if separator <= 0 || separator == len(rest)-1 {
+		return "", "", false
+	}
+	return rest[:separator], rest[separator+1:], true
+}
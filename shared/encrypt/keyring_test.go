@@ -0,0 +1,69 @@
+package encrypt
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestKeyring_RotatesToPrimaryKey(t *testing.T) {
+	oldKeyring, err := NewKeyring("", map[string][]byte{"old": []byte("0123456789abcdef")})
+	if err != nil {
+		t.Fatalf("failed to create keyring: %v", err)
+	}
+	ciphertext, err := oldKeyring.Encrypt("secret")
+	if err != nil {
+		t.Fatalf("encrypt failed: %v", err)
+	}
+	if EncryptedValueKeyID(ciphertext) != "old" {
+		t.Fatalf("expected key id to be embedded, got %q", ciphertext)
+	}
+
+	keyring, err := NewKeyring("new", map[string][]byte{
+		"old": []byte("0123456789abcdef"),
+		"new": []byte("fedcba9876543210fedcba9876543210"),
+	})
+	if err != nil {
+		t.Fatalf("failed to create keyring: %v", err)
+	}
+
+	if !keyring.NeedsRotation(ciphertext) || !keyring.NeedsRotation("plain") {
+		t.Fatal("expected old ciphertext and plaintext to need rotation")
+	}
+
+	rotated, changed, err := keyring.Rotate(ciphertext)
+	if err != nil || !changed {
+		t.Fatalf("expected rotation, changed=%v err=%v", changed, err)
+	}
+	if !strings.HasPrefix(rotated, EncryptedValuePrefix+"new:") {
+		t.Fatalf("expected value encrypted with primary key, got %q", rotated)
+	}
+	if plaintext, err := keyring.Decrypt(rotated); err != nil || plaintext != "secret" {
+		t.Fatalf("expected round trip, got %q err=%v", plaintext, err)
+	}
+
+	if _, changed, _ := keyring.Rotate(rotated); changed {
+		t.Fatal("expected value encrypted with primary key to be left unchanged")
+	}
+}
+
+func TestKeyring_RejectsUnknownKeyAndInvalidConfig(t *testing.T) {
+	keyring, err := NewKeyringFromHex("a", map[string]string{"a": "000102030405060708090a0b0c0d0e0f"})
+	if err != nil {
+		t.Fatalf("failed to create keyring: %v", err)
+	}
+
+	if _, err := keyring.Decrypt(EncryptedValuePrefix + "b:AAAA"); !errors.Is(err, ErrUnknownKeyID) {
+		t.Fatalf("expected unknown key error, got %v", err)
+	}
+	if _, err := keyring.Decrypt("plain"); !errors.Is(err, ErrInvalidEncryptedValue) {
+		t.Fatalf("expected invalid value error, got %v", err)
+	}
+
+	if _, err := NewKeyring("", map[string][]byte{"a": []byte("short")}); err == nil {
+		t.Fatal("expected invalid key length to be rejected")
+	}
+	if _, err := NewKeyring("missing", map[string][]byte{"a": []byte("0123456789abcdef")}); err == nil {
+		t.Fatal("expected unknown primary key to be rejected")
+	}
+}
@@ -0,0 +1,130 @@
+// Package totp, RFC 6238 (TOTP) tek kullanımlık şifrelerini üretir ve doğrular.
+//
+// Google Authenticator, 1Password, Authy gibi uygulamalarla uyumlu varsayılanlar
+// kullanılır: HMAC-SHA1, 6 hane, 30 saniyelik periyot.
+//
+// # Kullanım Örneği
+//
+//	secret, _ := totp.GenerateSecret()
+//	uri := totp.KeyURI("Panel.go", "ahmet@example.com", secret)
+//	step, ok := totp.Validate(secret, "287082", time.Now(), 1)
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// Digits, üretilen kodların hane sayısıdır.
+	Digits = 6
+
+	// Period, bir kodun geçerli olduğu zaman adımıdır.
+	Period = 30 * time.Second
+
+	// SecretSize, GenerateSecret ile üretilen secret'ın byte uzunluğudur (160 bit, RFC 4226 önerisi).
+	SecretSize = 20
+)
+
+var secretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret, base32 kodlanmış rastgele bir TOTP secret'ı üretir.
+func GenerateSecret() (string, error) {
+	buf := make([]byte, SecretSize)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return secretEncoding.EncodeToString(buf), nil
+}
+
+// KeyURI, authenticator uygulamalarının QR kod ile okuyabildiği otpauth:// URI'sini döndürür.
+func KeyURI(issuer, account, secret string) string {
+	label := url.PathEscape(account)
+	if issuer != "" {
+		label = url.PathEscape(issuer) + ":" + label
+	}
+
+	query := url.Values{}
+	query.Set("secret", secret)
+	if issuer != "" {
+		query.Set("issuer", issuer)
+	}
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(Digits))
+	query.Set("period", fmt.Sprint(int(Period/time.Second)))
+
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// Step, verilen zamanın TOTP zaman adımını döndürür.
+func Step(t time.Time) int64 {
+	return t.Unix() / int64(Period/time.Second)
+}
+
+// Code, secret için verilen zaman adımındaki kodu üretir.
+func Code(secret string, step int64) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < Digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", Digits, value%mod), nil
+}
+
+// Validate, kodu t anındaki adım ve ±skew komşu adımlara karşı sabit zamanlı karşılaştırır.
+// Eşleşme varsa eşleşen adımı döndürür; çağıran taraf aynı adımın ikinci kez
+// kullanılmasını (replay) bu değerle engellemelidir.
+func Validate(secret, code string, t time.Time, skew int) (int64, bool) {
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	if len(code) != Digits {
+		return 0, false
+	}
+
+	current := Step(t)
+	for offset := -skew; offset <= skew; offset++ {
+		step := current + int64(offset)
+		expected, err := Code(secret, step)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+func decodeSecret(secret string) ([]byte, error) {
+	normalized := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(secret), " ", ""))
+	normalized = strings.TrimRight(normalized, "=")
+	key, err := secretEncoding.DecodeString(normalized)
+	if err != nil {
+		return nil, fmt.Errorf("totp: invalid secret: %w", err)
+	}
+	if len(key) == 0 {
+		return nil, fmt.Errorf("totp: empty secret")
+	}
+	return key, nil
+}
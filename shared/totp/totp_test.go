@@ -0,0 +1,72 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret, RFC 6238 Appendix B'deki SHA1 test secret'ıdır ("12345678901234567890").
+var rfc6238Secret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestCode_RFC6238Vectors(t *testing.T) {
+	// RFC 8 haneli değerler verir; 6 haneli kod bunların son 6 hanesidir.
+	cases := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+	for unix, want := range cases {
+		got, err := Code(rfc6238Secret, Step(time.Unix(unix, 0)))
+		if err != nil {
+			t.Fatalf("Code failed: %v", err)
+		}
+		if got != want {
+			t.Fatalf("Code at %d = %s, want %s", unix, got, want)
+		}
+	}
+}
+
+func TestValidate_SkewAndStep(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	previous, _ := Code(rfc6238Secret, Step(now)-1)
+
+	step, ok := Validate(rfc6238Secret, previous, now, 1)
+	if !ok || step != Step(now)-1 {
+		t.Fatalf("expected previous step to be accepted with skew 1, got %d %v", step, ok)
+	}
+	if _, ok := Validate(rfc6238Secret, previous, now, 0); ok {
+		t.Fatal("expected previous step to be rejected without skew")
+	}
+	if _, ok := Validate(rfc6238Secret, "12345", now, 1); ok {
+		t.Fatal("expected short code to be rejected")
+	}
+}
+
+func TestGenerateSecretAndKeyURI(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret failed: %v", err)
+	}
+	if len(secret) != 32 {
+		t.Fatalf("expected 32 base32 chars for a 20 byte secret, got %q", secret)
+	}
+	code, err := Code(secret, Step(time.Now()))
+	if err != nil {
+		t.Fatalf("generated secret should be usable: %v", err)
+	}
+	if _, ok := Validate(secret, code, time.Now(), 1); !ok {
+		t.Fatal("expected generated code to validate")
+	}
+
+	uri := KeyURI("Panel.go", "ada@example.com", secret)
+	for _, want := range []string{"otpauth://totp/Panel.go:ada@example.com?", "secret=" + secret, "issuer=Panel.go", "digits=6", "period=30"} {
+		if !strings.Contains(uri, want) {
+			t.Fatalf("expected %q in %s", want, uri)
+		}
+	}
+}